@@ -5,6 +5,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// ReconcilePausedAnnotation suspends the reconciliation of a TemporalCluster or a TemporalNamespace when set to "true".
+// The operator doesn't change anything while it's set, it only updates the resource's observed status.
+const ReconcilePausedAnnotation = "temporal.io/paused"
+
+// IsReconcilePaused returns true if the reconciliation of the provided object is suspended.
+func IsReconcilePaused(obj client.Object) bool {
+	return obj.GetAnnotations()[ReconcilePausedAnnotation] == "true"
+}
+
 // TemporalClusterReference is a reference to a TemporalCluster.
 type TemporalClusterReference struct {
 	// The name of the TemporalCluster to reference.
@@ -12,6 +12,14 @@ const (
 	ReconcileSuccessCondition string = "ReconcileSuccess"
 	// ReadyCondition indicates the cluster is ready to receive traffic.
 	ReadyCondition string = "Ready"
+	// UpgradeFailedCondition indicates the last version upgrade of the cluster failed and was rolled back.
+	UpgradeFailedCondition string = "UpgradeFailed"
+	// ReshardReplicationConfiguredCondition indicates the clusters of a reshard replicate each other.
+	ReshardReplicationConfiguredCondition string = "ReplicationConfigured"
+	// ReshardNamespacesReplicatedCondition indicates the namespaces of a reshard are replicated to the target cluster.
+	ReshardNamespacesReplicatedCondition string = "NamespacesReplicated"
+	// ReshardCutOverCondition indicates the namespaces of a reshard are active in the target cluster.
+	ReshardCutOverCondition string = "CutOver"
 )
 
 const (
@@ -25,14 +33,84 @@ const (
 	ServicesReadyReason string = "ServicesReady"
 	// ServicesNotReadyReason signals that not all temporal services for the cluster are in ready state.
 	ServicesNotReadyReason string = "ServicesNotReady"
+	// PausedReason signals the cluster is paused.
+	PausedReason string = "Paused"
+	// ReconcilePausedReason signals the reconciliation is suspended by the temporal.io/paused annotation.
+	ReconcilePausedReason string = "ReconcilePaused"
 	// PersistenceReconciliationFailedReason signals an error while reconciling persistence.
 	PersistenceReconciliationFailedReason string = "PersistenceReconciliationFailed"
 	// ResourcesReconciliationFailedReason signals an error while reconciling cluster resources.
 	ResourcesReconciliationFailedReason string = "ResoucesReconciliationFailed"
 	// TemporalClusterValidationFailedReason signals an error while validation desired cluster version.
 	TemporalClusterValidationFailedReason string = "TemporalClusterValidationFailed"
+	// VersionUpgradeRefusedReason signals the desired cluster version can't be reached from the running version.
+	VersionUpgradeRefusedReason string = "VersionUpgradeRefused"
+	// CanaryUpgradeFailedReason signals the frontend canary of a version upgrade failed its verification.
+	CanaryUpgradeFailedReason string = "CanaryUpgradeFailed"
+	// UpgradeInProgressReason signals a version upgrade is being rolled out.
+	UpgradeInProgressReason string = "UpgradeInProgress"
+	// UpgradeCompletedReason signals the last version upgrade completed.
+	UpgradeCompletedReason string = "UpgradeCompleted"
+	// UpgradeRolledBackReason signals the last version upgrade failed and the services were rolled back.
+	UpgradeRolledBackReason string = "UpgradeRolledBack"
+	// PreUpgradeHookFailedReason signals a pre-upgrade hook job failed.
+	PreUpgradeHookFailedReason string = "PreUpgradeHookFailed"
+	// MTLSSecretsValidationFailedReason signals that user-provided mTLS secrets are missing or invalid.
+	MTLSSecretsValidationFailedReason string = "MTLSSecretsValidationFailed"
+	// VaultCertificatesIssuanceFailedReason signals an error while issuing mTLS certificates using vault.
+	VaultCertificatesIssuanceFailedReason string = "VaultCertificatesIssuanceFailed"
+	// CertificatesExpiryCheckFailedReason signals an error while reading mTLS certificates expiry.
+	CertificatesExpiryCheckFailedReason string = "CertificatesExpiryCheckFailed"
+	// ReplicationReconciliationFailedReason signals an error while registering the cluster's remote clusters.
+	ReplicationReconciliationFailedReason string = "ReplicationReconciliationFailed"
+	// DefaultNamespacesRegistrationFailedReason signals an error while registering the cluster's default namespaces.
+	DefaultNamespacesRegistrationFailedReason string = "DefaultNamespacesRegistrationFailed"
 	// TemporalNamespaceCreatedReason signals a successful namespace creation.
 	TemporalNamespaceCreatedReason string = "TemporalNamespaceCreated"
+	// BackupInProgressReason signals a backup job is running.
+	BackupInProgressReason string = "BackupInProgress"
+	// BackupScheduledReason signals backups are scheduled.
+	BackupScheduledReason string = "BackupScheduled"
+	// BackupSucceededReason signals a backup successfully completed.
+	BackupSucceededReason string = "BackupSucceeded"
+	// BackupFailedReason signals a backup job failed.
+	BackupFailedReason string = "BackupFailed"
+	// BackupWaitingForClusterReason signals a backup is waiting for the referenced cluster to exist.
+	BackupWaitingForClusterReason string = "WaitingForCluster"
+	// RestoreDownloadingClusterSpecReason signals a restore is downloading the cluster spec stored with the backup.
+	RestoreDownloadingClusterSpecReason string = "DownloadingClusterSpec"
+	// RestoreCreatingClusterReason signals a restore is waiting for the cluster it created to be ready.
+	RestoreCreatingClusterReason string = "CreatingCluster"
+	// RestoreWaitingForPausedClusterReason signals a restore is waiting for the cluster to be paused.
+	RestoreWaitingForPausedClusterReason string = "WaitingForPausedCluster"
+	// RestoreInProgressReason signals a restore job is running.
+	RestoreInProgressReason string = "RestoreInProgress"
+	// RestoreSucceededReason signals a restore successfully completed.
+	RestoreSucceededReason string = "RestoreSucceeded"
+	// RestoreFailedReason signals a restore job failed.
+	RestoreFailedReason string = "RestoreFailed"
+	// FailoverInProgressReason signals a failover is waiting for the clusters to report the new active cluster.
+	FailoverInProgressReason string = "FailoverInProgress"
+	// FailoverSucceededReason signals a failover successfully completed.
+	FailoverSucceededReason string = "FailoverSucceeded"
+	// FailoverFailedReason signals a failover pre-check failed.
+	FailoverFailedReason string = "FailoverFailed"
+	// NamespaceMigrationInProgressReason signals a namespace migration is running.
+	NamespaceMigrationInProgressReason string = "NamespaceMigrationInProgress"
+	// NamespaceMigrationSucceededReason signals a namespace migration successfully completed.
+	NamespaceMigrationSucceededReason string = "NamespaceMigrationSucceeded"
+	// NamespaceMigrationFailedReason signals a namespace migration can't be completed.
+	NamespaceMigrationFailedReason string = "NamespaceMigrationFailed"
+	// ReshardInProgressReason signals a reshard phase is running.
+	ReshardInProgressReason string = "ReshardInProgress"
+	// ReshardPhaseCompletedReason signals a reshard phase successfully completed.
+	ReshardPhaseCompletedReason string = "PhaseCompleted"
+	// ReshardWaitingForCutOverReason signals the namespaces are replicated and the reshard waits for the cut over to be requested.
+	ReshardWaitingForCutOverReason string = "WaitingForCutOver"
+	// ReshardSucceededReason signals a reshard successfully completed.
+	ReshardSucceededReason string = "ReshardSucceeded"
+	// ReshardFailedReason signals a reshard can't be completed.
+	ReshardFailedReason string = "ReshardFailed"
 )
 
 // SetTemporalClusterReconcileSuccess sets the ReconcileSuccessCondition status for a temporal cluster.
@@ -61,6 +139,19 @@ func SetTemporalClusterReconcileError(c *TemporalCluster, status metav1.Conditio
 	apimeta.SetStatusCondition(&c.Status.Conditions, condition)
 }
 
+// SetTemporalClusterUpgradeFailed sets the UpgradeFailedCondition status for a temporal cluster.
+func SetTemporalClusterUpgradeFailed(c *TemporalCluster, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               UpgradeFailedCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: c.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&c.Status.Conditions, condition)
+}
+
 // GetTemporalClusterReadyCondition returns the ready condition for the provided cluster if found.
 func GetTemporalClusterReadyCondition(c *TemporalCluster) (*metav1.Condition, bool) {
 	condition := apimeta.FindStatusCondition(c.Status.Conditions, ReadyCondition)
@@ -118,3 +209,187 @@ func SetTemporalNamespaceReconcileError(n *TemporalNamespace, status metav1.Cond
 	}
 	apimeta.SetStatusCondition(&n.Status.Conditions, condition)
 }
+
+// SetTemporalBackupReady sets the ReadyCondition status for a temporal backup.
+func SetTemporalBackupReady(b *TemporalBackup, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               ReadyCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: b.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&b.Status.Conditions, condition)
+}
+
+// SetTemporalBackupReconcileSuccess sets the ReconcileSuccessCondition status for a temporal backup.
+func SetTemporalBackupReconcileSuccess(b *TemporalBackup, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               ReconcileSuccessCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: b.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&b.Status.Conditions, condition)
+}
+
+// SetTemporalBackupReconcileError sets the ReconcileErrorCondition status for a temporal backup.
+func SetTemporalBackupReconcileError(b *TemporalBackup, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               ReconcileErrorCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: b.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&b.Status.Conditions, condition)
+}
+
+// SetTemporalRestoreReady sets the ReadyCondition status for a temporal restore.
+func SetTemporalRestoreReady(r *TemporalRestore, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               ReadyCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: r.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&r.Status.Conditions, condition)
+}
+
+// SetTemporalRestoreReconcileSuccess sets the ReconcileSuccessCondition status for a temporal restore.
+func SetTemporalRestoreReconcileSuccess(r *TemporalRestore, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               ReconcileSuccessCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: r.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&r.Status.Conditions, condition)
+}
+
+// SetTemporalRestoreReconcileError sets the ReconcileErrorCondition status for a temporal restore.
+func SetTemporalRestoreReconcileError(r *TemporalRestore, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               ReconcileErrorCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: r.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&r.Status.Conditions, condition)
+}
+
+// SetTemporalFailoverReady sets the ReadyCondition status for a temporal failover.
+func SetTemporalFailoverReady(f *TemporalFailover, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               ReadyCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: f.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&f.Status.Conditions, condition)
+}
+
+// SetTemporalFailoverReconcileSuccess sets the ReconcileSuccessCondition status for a temporal failover.
+func SetTemporalFailoverReconcileSuccess(f *TemporalFailover, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               ReconcileSuccessCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: f.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&f.Status.Conditions, condition)
+}
+
+// SetTemporalFailoverReconcileError sets the ReconcileErrorCondition status for a temporal failover.
+func SetTemporalFailoverReconcileError(f *TemporalFailover, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               ReconcileErrorCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: f.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&f.Status.Conditions, condition)
+}
+
+// SetTemporalNamespaceMigrationReady sets the ReadyCondition status for a temporal namespace migration.
+func SetTemporalNamespaceMigrationReady(m *TemporalNamespaceMigration, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               ReadyCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: m.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&m.Status.Conditions, condition)
+}
+
+// SetTemporalNamespaceMigrationReconcileSuccess sets the ReconcileSuccessCondition status for a temporal namespace migration.
+func SetTemporalNamespaceMigrationReconcileSuccess(m *TemporalNamespaceMigration, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               ReconcileSuccessCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: m.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&m.Status.Conditions, condition)
+}
+
+// SetTemporalNamespaceMigrationReconcileError sets the ReconcileErrorCondition status for a temporal namespace migration.
+func SetTemporalNamespaceMigrationReconcileError(m *TemporalNamespaceMigration, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               ReconcileErrorCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: m.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&m.Status.Conditions, condition)
+}
+
+// SetTemporalReshardCondition sets the provided condition status for a temporal reshard.
+func SetTemporalReshardCondition(r *TemporalReshard, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               conditionType,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: r.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&r.Status.Conditions, condition)
+}
+
+// SetTemporalReshardReady sets the ReadyCondition status for a temporal reshard.
+func SetTemporalReshardReady(r *TemporalReshard, status metav1.ConditionStatus, reason, message string) {
+	SetTemporalReshardCondition(r, ReadyCondition, status, reason, message)
+}
+
+// SetTemporalReshardReconcileSuccess sets the ReconcileSuccessCondition status for a temporal reshard.
+func SetTemporalReshardReconcileSuccess(r *TemporalReshard, status metav1.ConditionStatus, reason, message string) {
+	SetTemporalReshardCondition(r, ReconcileSuccessCondition, status, reason, message)
+}
+
+// SetTemporalReshardReconcileError sets the ReconcileErrorCondition status for a temporal reshard.
+func SetTemporalReshardReconcileError(r *TemporalReshard, status metav1.ConditionStatus, reason, message string) {
+	SetTemporalReshardCondition(r, ReconcileErrorCondition, status, reason, message)
+}
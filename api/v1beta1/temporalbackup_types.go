@@ -0,0 +1,178 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BackupS3Storage is the S3 backup storage configuration.
+type BackupS3Storage struct {
+	// Bucket is the name of the bucket backups are stored in.
+	// +kubebuilder:validation:Required
+	Bucket string `json:"bucket"`
+	// Prefix is an optional path prefix under which backups are stored in the bucket.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+	// Region is the aws s3 region.
+	// +kubebuilder:validation:Required
+	Region string `json:"region"`
+	// Use Endpoint if you want to use s3-compatible object storage.
+	// +optional
+	Endpoint *string `json:"endpoint,omitempty"`
+	// Use credentials if you want to use aws credentials from secret.
+	// If not set, the backup jobs rely on the credentials provided to their service account (e.g. IRSA).
+	// +optional
+	Credentials *S3Credentials `json:"credentials,omitempty"`
+	// Use s3ForcePathStyle if you want to use s3 path style.
+	// +optional
+	S3ForcePathStyle bool `json:"s3ForcePathStyle,omitempty"`
+}
+
+// BackupStorageSpec defines where backups are stored.
+type BackupStorageSpec struct {
+	// S3 stores backups in an s3 or s3-compatible bucket.
+	// +kubebuilder:validation:Required
+	S3 *BackupS3Storage `json:"s3"`
+}
+
+// BackupImagesSpec allows overriding the images used by backup and restore jobs.
+type BackupImagesSpec struct {
+	// PostgreSQL is the image providing pg_dump and psql.
+	// +optional
+	PostgreSQL string `json:"postgresql,omitempty"`
+	// MySQL is the image providing mysqldump and mysql.
+	// +optional
+	MySQL string `json:"mysql,omitempty"`
+	// Storage is the image providing the aws cli used to transfer backups.
+	// +optional
+	Storage string `json:"storage,omitempty"`
+	// Medusa is the image providing medusa, used to back up and restore Cassandra datastores.
+	// +optional
+	Medusa string `json:"medusa,omitempty"`
+}
+
+// BackupCassandraSpec configures how Cassandra datastores are backed up using medusa.
+// Medusa uploads Cassandra snapshots to its own storage, the backup ID is used as the medusa backup name.
+type BackupCassandraSpec struct {
+	// MedusaConfigRef is the secret holding the medusa configuration file (medusa.ini),
+	// describing how to reach the Cassandra nodes and where snapshots are stored.
+	// +kubebuilder:validation:Required
+	MedusaConfigRef corev1.LocalObjectReference `json:"medusaConfigRef"`
+	// BackupHook is the shell script taking the Cassandra backup.
+	// The BACKUP_ID environment variable holds the backup identifier.
+	// Defaults to running "medusa backup-cluster".
+	// +optional
+	BackupHook string `json:"backupHook,omitempty"`
+	// RestoreHook is the shell script restoring the Cassandra backup.
+	// The BACKUP_ID and CASSANDRA_KEYSPACES (space-separated) environment variables
+	// hold the backup identifier and the keyspaces of the cluster's Cassandra datastores.
+	// Defaults to running "medusa restore-cluster" for these keyspaces.
+	// +optional
+	RestoreHook string `json:"restoreHook,omitempty"`
+}
+
+// TemporalBackupSpec defines the desired state of TemporalBackup.
+type TemporalBackupSpec struct {
+	// Reference to the temporal cluster to backup.
+	ClusterRef TemporalClusterReference `json:"clusterRef"`
+	// Schedule is a cron expression at which backups are taken.
+	// If not set, a single backup is taken when the TemporalBackup is created.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+	// Suspend suspends subsequent scheduled backups.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+	// Storage is the location backups are uploaded to.
+	Storage BackupStorageSpec `json:"storage"`
+	// Cassandra enables backups of Cassandra datastores using medusa.
+	// Backups of clusters using Cassandra are rejected if not set.
+	// +optional
+	Cassandra *BackupCassandraSpec `json:"cassandra,omitempty"`
+	// Images overrides the images used by backup jobs.
+	// +optional
+	Images *BackupImagesSpec `json:"images,omitempty"`
+	// ServiceAccountName is the service account backup jobs run with.
+	// Defaults to the cluster's job service account.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// Resources are the compute resources of backup jobs containers.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// IsScheduled returns true if the backup is taken on a schedule.
+func (s *TemporalBackupSpec) IsScheduled() bool {
+	return s.Schedule != ""
+}
+
+// TemporalBackupStatus defines the observed state of TemporalBackup.
+type TemporalBackupStatus struct {
+	// Conditions represent the latest available observations of the backup state.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// BackupID is the identifier of the backup taken for non-scheduled backups.
+	// +optional
+	BackupID string `json:"backupID,omitempty"`
+	// Location is the storage location of the backup taken for non-scheduled backups.
+	// +optional
+	Location string `json:"location,omitempty"`
+	// LastScheduleTime is the last time a scheduled backup was started.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// LastSuccessfulTime is the last time a scheduled backup successfully completed.
+	// +optional
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+	// ClusterSpec is the spec of the backed up TemporalCluster.
+	// For scheduled backups, it is the spec at the time of the last reconciliation.
+	// The spec of each backup is stored next to its dumps (cluster.json), it is used to recreate the cluster on restore.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	ClusterSpec *apiextensionsv1.JSON `json:"clusterSpec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterRef.name"
+// +kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type == 'Ready')].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// A TemporalBackup backs up the SQL datastores of a temporal cluster to an object storage.
+type TemporalBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TemporalBackupSpec   `json:"spec,omitempty"`
+	Status TemporalBackupStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TemporalBackupList contains a list of TemporalBackup.
+type TemporalBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TemporalBackup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TemporalBackup{}, &TemporalBackupList{})
+}
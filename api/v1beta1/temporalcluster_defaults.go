@@ -18,6 +18,7 @@
 package v1beta1
 
 import (
+	"sync"
 	"time"
 
 	"github.com/alexandrevilain/temporal-operator/pkg/version"
@@ -27,7 +28,8 @@ import (
 
 const (
 	defaultTemporalVersion = "1.23.0"
-	defaultTemporalImage   = "temporalio/server"
+	// DefaultTemporalImage is the official temporal server image.
+	DefaultTemporalImage = "temporalio/server"
 
 	defaultTemporalUIVersion = "2.25.0"
 	defaultTemporalUIImage   = "temporalio/ui"
@@ -35,6 +37,53 @@ const (
 	defaultTemporalAdmintoolsImage = "temporalio/admin-tools"
 )
 
+// DefaultImages are the images used by the clusters not setting their own.
+// +kubebuilder:object:generate=false
+type DefaultImages struct {
+	// Temporal is the temporal server image.
+	Temporal string `yaml:"temporal"`
+	// UI is the temporal UI image.
+	UI string `yaml:"ui"`
+	// AdminTools is the temporal admin tools image.
+	AdminTools string `yaml:"adminTools"`
+}
+
+var (
+	defaultImagesMu sync.RWMutex
+	defaultImages   = DefaultImages{
+		Temporal:   DefaultTemporalImage,
+		UI:         defaultTemporalUIImage,
+		AdminTools: defaultTemporalAdmintoolsImage,
+	}
+)
+
+// SetDefaultImages sets the images used by the clusters not setting their own, e.g. to use a registry mirror.
+// Empty images are reset to the official ones.
+func SetDefaultImages(images DefaultImages) {
+	if images.Temporal == "" {
+		images.Temporal = DefaultTemporalImage
+	}
+	if images.UI == "" {
+		images.UI = defaultTemporalUIImage
+	}
+	if images.AdminTools == "" {
+		images.AdminTools = defaultTemporalAdmintoolsImage
+	}
+
+	defaultImagesMu.Lock()
+	defer defaultImagesMu.Unlock()
+
+	defaultImages = images
+}
+
+// GetDefaultImages returns the images used by the clusters not setting their own.
+func GetDefaultImages() DefaultImages {
+	defaultImagesMu.RLock()
+	defer defaultImagesMu.RUnlock()
+
+	return defaultImages
+}
+
 // Default set default fields values.
 func (s *DatastoreSpec) Default() {
 	if s.SQL != nil {
@@ -61,8 +110,9 @@ func (c *TemporalCluster) Default() {
 	if c.Spec.Version == nil {
 		c.Spec.Version = version.MustNewVersionFromString(defaultTemporalVersion)
 	}
+	images := GetDefaultImages()
 	if c.Spec.Image == "" {
-		c.Spec.Image = defaultTemporalImage
+		c.Spec.Image = images.Temporal
 	}
 
 	if c.Spec.Log == nil {
@@ -161,6 +211,25 @@ func (c *TemporalCluster) Default() {
 		c.Spec.Services.Worker.HTTPPort = ptr.To(0)
 	}
 
+	if c.Spec.DevMode.IsEnabled() {
+		if c.Spec.Persistence.DefaultStore == nil {
+			c.Spec.Persistence.DefaultStore = &DatastoreSpec{
+				SQL: &SQLSpec{
+					PluginName:   "sqlite",
+					DatabaseName: "default.db",
+				},
+			}
+		}
+		if c.Spec.Persistence.VisibilityStore == nil {
+			c.Spec.Persistence.VisibilityStore = &DatastoreSpec{
+				SQL: &SQLSpec{
+					PluginName:   "sqlite",
+					DatabaseName: "visibility.db",
+				},
+			}
+		}
+	}
+
 	if c.Spec.Persistence.DefaultStore != nil {
 		if c.Spec.Persistence.DefaultStore.Name == "" {
 			c.Spec.Persistence.DefaultStore.Name = DefaultStoreName
@@ -198,7 +267,7 @@ func (c *TemporalCluster) Default() {
 	}
 
 	if c.Spec.UI.Image == "" {
-		c.Spec.UI.Image = defaultTemporalUIImage
+		c.Spec.UI.Image = images.UI
 	}
 
 	if c.Spec.UI.Replicas == nil {
@@ -210,13 +279,16 @@ func (c *TemporalCluster) Default() {
 	}
 
 	if c.Spec.AdminTools.Image == "" {
-		c.Spec.AdminTools.Image = defaultTemporalAdmintoolsImage
+		c.Spec.AdminTools.Image = images.AdminTools
 	}
 
 	if c.Spec.MTLS != nil {
 		if c.Spec.MTLS.RefreshInterval == nil {
 			c.Spec.MTLS.RefreshInterval = &metav1.Duration{Duration: time.Hour}
 		}
+		if c.Spec.MTLS.ExpiryWarningThreshold == nil {
+			c.Spec.MTLS.ExpiryWarningThreshold = &metav1.Duration{Duration: 7 * 24 * time.Hour}
+		}
 	}
 
 	if c.MTLSWithCertManagerEnabled() {
@@ -245,6 +317,56 @@ func (c *TemporalCluster) Default() {
 			if c.Spec.Metrics.Prometheus.ListenPort == nil {
 				c.Spec.Metrics.Prometheus.ListenPort = ptr.To[int32](9090)
 			}
+			if c.Spec.Metrics.Prometheus.GrafanaDashboards.IsEnabled() && c.Spec.Metrics.Prometheus.GrafanaDashboards.Labels == nil {
+				c.Spec.Metrics.Prometheus.GrafanaDashboards.Labels = map[string]string{"grafana_dashboard": "1"}
+			}
+		}
+		if c.Spec.Metrics.Statsd != nil && c.Spec.Metrics.Statsd.Prefix == "" {
+			c.Spec.Metrics.Statsd.Prefix = "temporal"
+		}
+	}
+
+	if c.Spec.ClusterMetadata != nil {
+		if c.Spec.ClusterMetadata.InitialFailoverVersion == 0 {
+			c.Spec.ClusterMetadata.InitialFailoverVersion = 1
+		}
+		if c.Spec.ClusterMetadata.FailoverVersionIncrement == 0 {
+			c.Spec.ClusterMetadata.FailoverVersionIncrement = 10
+		}
+	}
+
+	if c.Spec.Persistence.VisibilityMigration != nil {
+		if c.Spec.Persistence.VisibilityMigration.WritingMode == "" {
+			c.Spec.Persistence.VisibilityMigration.WritingMode = SecondaryVisibilityWritingModeDual
+		}
+
+		// Dual visibility settings are rendered in the dynamic config.
+		if c.Spec.DynamicConfig == nil {
+			c.Spec.DynamicConfig = &DynamicConfigSpec{
+				Values: map[string][]ConstrainedValue{},
+			}
+		}
+	}
+
+	// Frontend limits are rendered in the dynamic config.
+	if c.Spec.Services != nil && c.Spec.Services.Frontend != nil && c.Spec.Services.Frontend.Limits != nil && c.Spec.DynamicConfig == nil {
+		c.Spec.DynamicConfig = &DynamicConfigSpec{
+			Values: map[string][]ConstrainedValue{},
+		}
+	}
+
+	if c.Spec.UpgradeStrategy != nil && c.Spec.UpgradeStrategy.Canary != nil && c.Spec.UpgradeStrategy.Canary.SoakPeriod == nil {
+		c.Spec.UpgradeStrategy.Canary.SoakPeriod = &metav1.Duration{Duration: 5 * time.Minute}
+	}
+
+	if c.Spec.UpgradeStrategy != nil && c.Spec.UpgradeStrategy.AutoRollback != nil && c.Spec.UpgradeStrategy.AutoRollback.ProgressDeadline == nil {
+		c.Spec.UpgradeStrategy.AutoRollback.ProgressDeadline = &metav1.Duration{Duration: 15 * time.Minute}
+	}
+
+	// Persistence limits are rendered in the dynamic config.
+	if c.Spec.Persistence.Limits != nil && c.Spec.DynamicConfig == nil {
+		c.Spec.DynamicConfig = &DynamicConfigSpec{
+			Values: map[string][]ConstrainedValue{},
 		}
 	}
 
@@ -19,6 +19,7 @@ package v1beta1
 
 import (
 	"fmt"
+	"net"
 	"path"
 	"strings"
 
@@ -28,10 +29,13 @@ import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"go.temporal.io/server/common/primitives"
 	"golang.org/x/exp/slices"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 // LogSpec contains the temporal logging configuration.
@@ -62,6 +66,84 @@ type LogSpec struct {
 	Development bool `json:"development"`
 }
 
+// ServiceLogSpec overrides the cluster's logging configuration for a temporal service.
+type ServiceLogSpec struct {
+	// Level overrides the log level of the service.
+	// +kubebuilder:validation:Enum=debug;info;warn;error;dpanic;panic;fatal
+	// +optional
+	Level string `json:"level,omitempty"`
+	// Format overrides the log format of the service.
+	// +kubebuilder:validation:Enum=json;console
+	// +optional
+	Format string `json:"format,omitempty"`
+}
+
+// ServiceMetricsSpec overrides the cluster's prometheus metrics exposition for a temporal service.
+type ServiceMetricsSpec struct {
+	// Enabled defines if the service's metrics are exposed. Defaults to true.
+	// When disabled, the service's metrics endpoint only listens on the loopback interface,
+	// and is neither added to the service's pods nor scraped.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Port overrides spec.metrics.prometheus.listenPort for the service,
+	// e.g. when it conflicts with a sidecar.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+}
+
+// FrontendLimitsSpec configures the frontend rate limits and keepalive settings.
+// They are written in the cluster's dynamic config.
+type FrontendLimitsSpec struct {
+	// RPS is the rate limit of requests per second of each frontend instance.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RPS *int32 `json:"rps,omitempty"`
+	// NamespaceRPS is the rate limit of requests per second of each namespace on each frontend instance.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	NamespaceRPS *int32 `json:"namespaceRPS,omitempty"`
+	// GlobalNamespaceRPS is the rate limit of requests per second of each namespace, shared by all frontend instances.
+	// When set, it overrides namespaceRPS.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	GlobalNamespaceRPS *int32 `json:"globalNamespaceRPS,omitempty"`
+	// MaxConcurrentLongPolls is the maximum number of concurrent long poll requests of each namespace on each frontend instance.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentLongPolls *int32 `json:"maxConcurrentLongPolls,omitempty"`
+	// KeepAlive configures the frontend gRPC server keepalive.
+	// Keepalive settings are only applied when the frontend starts.
+	// +optional
+	KeepAlive *FrontendKeepAliveSpec `json:"keepAlive,omitempty"`
+}
+
+// FrontendKeepAliveSpec configures the frontend gRPC server keepalive.
+type FrontendKeepAliveSpec struct {
+	// MinTime is the minimum amount of time a client should wait before sending a keepalive ping.
+	// +optional
+	MinTime *metav1.Duration `json:"minTime,omitempty"`
+	// PermitWithoutStream allows clients to send keepalive pings when there are no active streams.
+	// +optional
+	PermitWithoutStream *bool `json:"permitWithoutStream,omitempty"`
+	// MaxConnectionIdle is the duration after which an idle connection is closed.
+	// +optional
+	MaxConnectionIdle *metav1.Duration `json:"maxConnectionIdle,omitempty"`
+	// MaxConnectionAge is the maximum duration a connection may exist before it's closed.
+	// +optional
+	MaxConnectionAge *metav1.Duration `json:"maxConnectionAge,omitempty"`
+	// MaxConnectionAgeGrace is the additional duration after maxConnectionAge before connections are forcibly closed.
+	// +optional
+	MaxConnectionAgeGrace *metav1.Duration `json:"maxConnectionAgeGrace,omitempty"`
+	// Time is the duration after which the server pings an inactive client.
+	// +optional
+	Time *metav1.Duration `json:"time,omitempty"`
+	// Timeout is the duration the server waits for a ping response before closing the connection.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
 // ServiceSpec contains a temporal service specifications.
 type ServiceSpec struct {
 	// Port defines a custom gRPC port for the service.
@@ -89,10 +171,44 @@ type ServiceSpec struct {
 	// +kubebuilder:validation:Minimum=1
 	// +optional
 	Replicas *int32 `json:"replicas"`
+	// WorkloadType defines the kind of workload used to run the service.
+	// StatefulSet is only supported for the history and matching services.
+	// Defaults to Deployment.
+	// +optional
+	WorkloadType WorkloadType `json:"workloadType,omitempty"`
+	// Strategy is the deployment strategy used to replace the service's pods.
+	// Can't be set when the service runs as a StatefulSet.
+	// Defaults to a RollingUpdate with 25% maxSurge and 25% maxUnavailable.
+	// +optional
+	Strategy *appsv1.DeploymentStrategy `json:"strategy,omitempty"`
 	// Compute Resources required by this service.
 	// More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Image overrides the temporal server docker image the service should run.
+	// The image tag is the cluster's version.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// ImageDigest pins the service's image to the given digest (e.g. "sha256:...").
+	// If set, it takes precedence over the image tag: it must be updated along with the cluster version.
+	// +optional
+	ImageDigest string `json:"imageDigest,omitempty"`
+	// ImagePullPolicy sets the pull policy of the service's image.
+	// Defaults to IfNotPresent.
+	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// Command overrides the entrypoint of the service's container.
+	// If left empty, the image's entrypoint is used.
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// Args overrides the arguments passed to the service's container entrypoint.
+	// If left empty, the image's default arguments are used.
+	// +optional
+	Args []string `json:"args,omitempty"`
+	// PodMetadata adds labels and annotations to the service's pods.
+	// +optional
+	PodMetadata *ObjectMetaOverride `json:"podMetadata,omitempty"`
 	// Overrides adds some overrides to the resources deployed for the service.
 	// Those overrides takes precedence over spec.services.overrides.
 	// +optional
@@ -100,7 +216,397 @@ type ServiceSpec struct {
 	// InitContainers adds a list of init containers to the service's deployment.
 	// +optional
 	InitContainers []corev1.Container `json:"initContainers,omitempty"`
-	// ServiceAccountOverride
+	// Sidecars adds a list of containers running alongside the service's container.
+	// +optional
+	Sidecars []corev1.Container `json:"sidecars,omitempty"`
+	// Env adds a list of environment variables to the service's container.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// EnvFrom adds a list of sources to populate environment variables in the service's container.
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+	// Volumes adds a list of volumes to the service's pods.
+	// +optional
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+	// VolumeMounts adds a list of volume mounts to the service's container.
+	// +optional
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+	// LivenessProbe overrides the liveness probe of the service's container.
+	// Defaults to a TCP check on the rpc port (none for the worker service).
+	// +optional
+	LivenessProbe *corev1.Probe `json:"livenessProbe,omitempty"`
+	// ReadinessProbe sets the readiness probe of the service's container.
+	// +optional
+	ReadinessProbe *corev1.Probe `json:"readinessProbe,omitempty"`
+	// StartupProbe sets the startup probe of the service's container.
+	// +optional
+	StartupProbe *corev1.Probe `json:"startupProbe,omitempty"`
+	// Lifecycle sets the lifecycle hooks (like preStop) of the service's container.
+	// +optional
+	Lifecycle *corev1.Lifecycle `json:"lifecycle,omitempty"`
+	// TerminationGracePeriodSeconds is the duration in seconds the service's pods need to terminate gracefully.
+	// Defaults to 30 seconds.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+	// DNSPolicy sets the DNS policy of the service's pods.
+	// Defaults to ClusterFirst.
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	// +optional
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+	// DNSConfig sets the DNS parameters of the service's pods.
+	// +optional
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+	// HostAliases adds entries to the service's pods /etc/hosts file.
+	// +optional
+	HostAliases []corev1.HostAlias `json:"hostAliases,omitempty"`
+	// PodSecurityContext overrides the pod-level security context of the service's pods.
+	// If left empty, the operator uses a context compliant with the "restricted" Pod Security Standard.
+	// +optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// SecurityContext overrides the security context of the service's container.
+	// If left empty, the operator uses a context compliant with the "restricted" Pod Security Standard.
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+	// ServiceAccount configures the service account used by the service's pods.
+	// +optional
+	ServiceAccount *ServiceAccountSpec `json:"serviceAccount,omitempty"`
+	// Route is an optional OpenShift Route exposing the service.
+	// Only supported for the frontend service.
+	// +optional
+	Route *RouteSpec `json:"route,omitempty"`
+	// ExternalHostname is the hostname clients outside the cluster use to reach the service.
+	// It's set as the external-dns hostname of the service's Service, and added to the frontend certificate.
+	// Only supported for the frontend service.
+	// +optional
+	ExternalHostname string `json:"externalHostname,omitempty"`
+	// Service configures the Kubernetes Service exposing the service,
+	// e.g. to reach the frontend from outside the cluster.
+	// Only supported for the frontend service, the internal frontend supports traffic policy and session affinity settings.
+	// +optional
+	Service *KubernetesServiceSpec `json:"service,omitempty"`
+	// AppProtocols overrides the appProtocol of the ports of the service's Kubernetes Services.
+	// +optional
+	AppProtocols *AppProtocolsSpec `json:"appProtocols,omitempty"`
+	// Log overrides the cluster's log level and format for the service, e.g. to debug a single service.
+	// +optional
+	Log *ServiceLogSpec `json:"log,omitempty"`
+	// Metrics overrides the cluster's prometheus metrics port for the service, or disables their exposition.
+	// +optional
+	Metrics *ServiceMetricsSpec `json:"metrics,omitempty"`
+	// ConfigOverlay is merged into the temporal server configuration rendered for the service.
+	// It's an escape hatch for configuration keys not modeled by the TemporalCluster:
+	// maps are merged recursively, other values replace the rendered ones.
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	ConfigOverlay *apiextensionsv1.JSON `json:"configOverlay,omitempty"`
+	// Limits configures the frontend rate limits and keepalive settings, without using the dynamic config keys.
+	// Only supported for the frontend service.
+	// +optional
+	Limits *FrontendLimitsSpec `json:"limits,omitempty"`
+	// GRPCRoute is an optional Gateway API GRPCRoute exposing the service.
+	// Only supported for the frontend service.
+	// +optional
+	GRPCRoute *GatewayRouteSpec `json:"grpcRoute,omitempty"`
+	// TLSRoute is an optional Gateway API TLSRoute exposing the service,
+	// for Gateways passing TLS traffic through to the service.
+	// Only supported for the frontend service, with frontend mTLS enabled.
+	// +optional
+	TLSRoute *GatewayRouteSpec `json:"tlsRoute,omitempty"`
+}
+
+// TracingHeader is a header sent with the exported traces, e.g. for authentication.
+type TracingHeader struct {
+	// Name of the header.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9-_]+$`
+	Name string `json:"name"`
+	// ValueFrom references the Secret key holding the header's value.
+	ValueFrom *corev1.SecretKeySelector `json:"valueFrom"`
+}
+
+// TracingSpec configures the export of temporal services traces to an OpenTelemetry collector, using OTLP over gRPC.
+type TracingSpec struct {
+	// Endpoint is the host:port of the collector's OTLP gRPC receiver.
+	Endpoint string `json:"endpoint"`
+	// Insecure disables TLS when connecting to the collector.
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+	// Headers are sent with the exported traces.
+	// +optional
+	Headers []TracingHeader `json:"headers,omitempty"`
+	// SamplingRatio is the ratio of traces to sample, between 0 and 1, e.g. "0.1".
+	// Sampling decisions of parent spans are respected.
+	// Defaults to "1", sampling all traces.
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +optional
+	SamplingRatio string `json:"samplingRatio,omitempty"`
+}
+
+// GetHeaderEnvVarName returns the name of the environment variable holding the value of the i-th header.
+func (s *TracingSpec) GetHeaderEnvVarName(i int) string {
+	return fmt.Sprintf("TEMPORAL_OTEL_HEADER_%d", i)
+}
+
+// TelemetrySpec configures the telemetry exported by temporal services.
+type TelemetrySpec struct {
+	// Tracing configures the export of OpenTelemetry traces.
+	// +optional
+	Tracing *TracingSpec `json:"tracing,omitempty"`
+}
+
+// TracingEnabled returns true if traces export is configured.
+func (s *TelemetrySpec) TracingEnabled() bool {
+	return s != nil && s.Tracing != nil
+}
+
+// NetworkSpec defines the IP families used by the cluster.
+type NetworkSpec struct {
+	// IPFamilyPolicy is the IP family policy of the Services created by the operator.
+	// If empty, the Kubernetes default (SingleStack) is used.
+	// +kubebuilder:validation:Enum=SingleStack;PreferDualStack;RequireDualStack
+	// +optional
+	IPFamilyPolicy *corev1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+	// IPFamilies is the ordered list of IP families of the Services created by the operator.
+	// If empty, the Kubernetes cluster's default families are used.
+	// When it contains IPv6, temporal services listen on IPv6 addresses.
+	// +kubebuilder:validation:MaxItems=2
+	// +optional
+	IPFamilies []corev1.IPFamily `json:"ipFamilies,omitempty"`
+}
+
+// IPv6Enabled returns true if the cluster uses the IPv6 family.
+func (s *NetworkSpec) IPv6Enabled() bool {
+	return s != nil && slices.Contains(s.IPFamilies, corev1.IPv6Protocol)
+}
+
+// AppProtocolsSpec overrides the appProtocol of Kubernetes Service ports,
+// used by service meshes and proxies to detect the protocol of the traffic.
+// Set a value to an empty string to remove the appProtocol from the port.
+type AppProtocolsSpec struct {
+	// RPC is the appProtocol of the gRPC port.
+	// Defaults to "grpc" on the frontend Services, and "tcp" on the headless Services used for pod-to-pod traffic.
+	// +optional
+	RPC *string `json:"rpc,omitempty"`
+	// HTTP is the appProtocol of the frontend HTTP port.
+	// Defaults to "http".
+	// +optional
+	HTTP *string `json:"http,omitempty"`
+	// Membership is the appProtocol of the membership port.
+	// Defaults to "tcp".
+	// +optional
+	Membership *string `json:"membership,omitempty"`
+	// Metrics is the appProtocol of the metrics port.
+	// Defaults to "http".
+	// +optional
+	Metrics *string `json:"metrics,omitempty"`
+}
+
+// GetRPC returns the appProtocol of the gRPC port, or the provided default.
+func (s *AppProtocolsSpec) GetRPC(defaultValue string) *string {
+	if s == nil {
+		return appProtocol(nil, defaultValue)
+	}
+	return appProtocol(s.RPC, defaultValue)
+}
+
+// GetHTTP returns the appProtocol of the HTTP port.
+func (s *AppProtocolsSpec) GetHTTP() *string {
+	if s == nil {
+		return appProtocol(nil, "http")
+	}
+	return appProtocol(s.HTTP, "http")
+}
+
+// GetMembership returns the appProtocol of the membership port.
+func (s *AppProtocolsSpec) GetMembership() *string {
+	if s == nil {
+		return appProtocol(nil, "tcp")
+	}
+	return appProtocol(s.Membership, "tcp")
+}
+
+// GetMetrics returns the appProtocol of the metrics port.
+func (s *AppProtocolsSpec) GetMetrics() *string {
+	if s == nil {
+		return appProtocol(nil, "http")
+	}
+	return appProtocol(s.Metrics, "http")
+}
+
+// appProtocol returns the overridden appProtocol, or the default one.
+// It returns nil if the appProtocol is overridden with an empty string.
+func appProtocol(override *string, defaultValue string) *string {
+	value := defaultValue
+	if override != nil {
+		value = *override
+	}
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// GatewayRouteSpec contains the configuration of a Gateway API route.
+type GatewayRouteSpec struct {
+	// Annotations allows custom annotations on the route resource.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// ParentRefs references the Gateways the route attaches to.
+	// +kubebuilder:validation:MinItems=1
+	ParentRefs []gatewayv1.ParentReference `json:"parentRefs"`
+	// Hostnames is the list of hostnames the route matches.
+	// +optional
+	Hostnames []gatewayv1.Hostname `json:"hostnames,omitempty"`
+}
+
+// KubernetesServiceSpec configures a Kubernetes Service.
+type KubernetesServiceSpec struct {
+	// Labels and annotations added to the Service.
+	// +optional
+	*ObjectMetaOverride `json:"metadata,omitempty"`
+	// Type is the type of the Service.
+	// Defaults to ClusterIP.
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	// +optional
+	Type corev1.ServiceType `json:"type,omitempty"`
+	// NodePort is the node port of the gRPC port, when the type is NodePort or LoadBalancer.
+	// If empty, Kubernetes allocates one.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	NodePort *int32 `json:"nodePort,omitempty"`
+	// HTTPNodePort is the node port of the HTTP port, when the type is NodePort or LoadBalancer.
+	// If empty, Kubernetes allocates one.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	HTTPNodePort *int32 `json:"httpNodePort,omitempty"`
+	// LoadBalancerClass is the class of the load balancer implementation, when the type is LoadBalancer.
+	// +optional
+	LoadBalancerClass *string `json:"loadBalancerClass,omitempty"`
+	// LoadBalancerSourceRanges restricts the client IPs allowed to reach the load balancer, when the type is LoadBalancer.
+	// +optional
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+	// InternalTrafficPolicy describes how nodes distribute the traffic they receive on the ClusterIP.
+	// Use Local to keep in-cluster traffic on the client's node and reduce cross-zone traffic.
+	// Defaults to Cluster.
+	// +kubebuilder:validation:Enum=Cluster;Local
+	// +optional
+	InternalTrafficPolicy *corev1.ServiceInternalTrafficPolicy `json:"internalTrafficPolicy,omitempty"`
+	// SessionAffinity enables client IP based session affinity.
+	// Defaults to None.
+	// +kubebuilder:validation:Enum=None;ClientIP
+	// +optional
+	SessionAffinity corev1.ServiceAffinity `json:"sessionAffinity,omitempty"`
+	// SessionAffinityTimeoutSeconds is the maximum session sticky time, when sessionAffinity is ClientIP.
+	// If empty, Kubernetes defaults it to 10800 (3 hours).
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=86400
+	// +optional
+	SessionAffinityTimeoutSeconds *int32 `json:"sessionAffinityTimeoutSeconds,omitempty"`
+}
+
+// GetType returns the type of the Service, defaulting to ClusterIP.
+func (s *KubernetesServiceSpec) GetType() corev1.ServiceType {
+	if s == nil || s.Type == "" {
+		return corev1.ServiceTypeClusterIP
+	}
+	return s.Type
+}
+
+// GetSessionAffinity returns the session affinity of the Service, defaulting to None.
+func (s *KubernetesServiceSpec) GetSessionAffinity() corev1.ServiceAffinity {
+	if s == nil || s.SessionAffinity == "" {
+		return corev1.ServiceAffinityNone
+	}
+	return s.SessionAffinity
+}
+
+// RouteTermination is the TLS termination type of an OpenShift Route.
+// +kubebuilder:validation:Enum=edge;passthrough;reencrypt
+type RouteTermination string
+
+const (
+	// EdgeRouteTermination terminates TLS at the router.
+	EdgeRouteTermination RouteTermination = "edge"
+	// PassthroughRouteTermination passes the TLS traffic through to the service.
+	PassthroughRouteTermination RouteTermination = "passthrough"
+	// ReencryptRouteTermination terminates TLS at the router and re-encrypts the traffic to the service.
+	ReencryptRouteTermination RouteTermination = "reencrypt"
+)
+
+// RouteSpec contains the configuration of an OpenShift Route.
+type RouteSpec struct {
+	// Annotations allows custom annotations on the route resource.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Host is the host name the route is exposed at.
+	// If empty, OpenShift generates one.
+	// +optional
+	Host string `json:"host,omitempty"`
+	// Termination is the TLS termination type of the route.
+	// Defaults to edge, or passthrough for the frontend when frontend mTLS is enabled.
+	// +optional
+	Termination RouteTermination `json:"termination,omitempty"`
+	// InsecureEdgeTerminationPolicy is the behavior of insecure connections to an edge terminated route.
+	// +kubebuilder:validation:Enum=None;Allow;Redirect
+	// +optional
+	InsecureEdgeTerminationPolicy string `json:"insecureEdgeTerminationPolicy,omitempty"`
+	// DestinationCACertificate is the PEM encoded CA certificate used by the router
+	// to validate the service certificate with reencrypt termination.
+	// +optional
+	DestinationCACertificate string `json:"destinationCACertificate,omitempty"`
+}
+
+// WorkloadType is the kind of workload used to run a temporal service.
+// +kubebuilder:validation:Enum=Deployment;StatefulSet
+type WorkloadType string
+
+const (
+	// DeploymentWorkloadType runs the service as a Deployment.
+	DeploymentWorkloadType WorkloadType = "Deployment"
+	// StatefulSetWorkloadType runs the service as a StatefulSet.
+	StatefulSetWorkloadType WorkloadType = "StatefulSet"
+)
+
+// IsStatefulSet returns true if the service should run as a StatefulSet.
+func (s *ServiceSpec) IsStatefulSet() bool {
+	return s != nil && s.WorkloadType == StatefulSetWorkloadType
+}
+
+// ServiceAccountSpec defines the service account used by a component's pods.
+type ServiceAccountSpec struct {
+	// Name of an existing service account to use.
+	// If set, the operator does not create any service account for the component.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Annotations to add to the service account created by the operator.
+	// Useful to bind cloud identities (AWS IRSA, GCP Workload Identity, ...) to the component.
+	// Ignored if name is set.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// IsExisting returns true if the spec references an existing service account.
+func (s *ServiceAccountSpec) IsExisting() bool {
+	return s != nil && s.Name != ""
+}
+
+// GetName returns the name of the referenced service account or the provided default name.
+func (s *ServiceAccountSpec) GetName(defaultName string) string {
+	if s.IsExisting() {
+		return s.Name
+	}
+	return defaultName
+}
+
+// GetAnnotations returns the annotations to add to the service account created by the operator.
+func (s *ServiceAccountSpec) GetAnnotations() map[string]string {
+	if s == nil {
+		return nil
+	}
+	return s.Annotations
 }
 
 // InternalFrontendServiceSpec contains temporal internal frontend service specifications.
@@ -163,6 +669,53 @@ func (s *ServicesSpec) GetServiceSpec(name primitives.ServiceName) (*ServiceSpec
 	}
 }
 
+// HasLogOverrides returns true if a service overrides the cluster's log configuration.
+func (s *ServicesSpec) HasLogOverrides() bool {
+	if s == nil {
+		return false
+	}
+	for _, spec := range s.GetServiceSpecsMap() {
+		if spec.Log != nil && (spec.Log.Level != "" || spec.Log.Format != "") {
+			return true
+		}
+	}
+	return false
+}
+
+// HasMetricsOverrides returns true if a service overrides the cluster's prometheus metrics exposition.
+func (s *ServicesSpec) HasMetricsOverrides() bool {
+	if s == nil {
+		return false
+	}
+	for _, spec := range s.GetServiceSpecsMap() {
+		if spec.Metrics != nil && (spec.Metrics.Enabled != nil || spec.Metrics.Port != nil) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetServiceSpecsMap returns the non-nil services specs indexed by their field name.
+func (s *ServicesSpec) GetServiceSpecsMap() map[string]*ServiceSpec {
+	services := map[string]*ServiceSpec{}
+	if s.Frontend != nil {
+		services["frontend"] = s.Frontend
+	}
+	if s.InternalFrontend != nil {
+		services["internalFrontend"] = &s.InternalFrontend.ServiceSpec
+	}
+	if s.History != nil {
+		services["history"] = s.History
+	}
+	if s.Matching != nil {
+		services["matching"] = s.Matching
+	}
+	if s.Worker != nil {
+		services["worker"] = s.Worker
+	}
+	return services
+}
+
 // ServiceSpecOverride provides the ability to override the generated manifests of a temporal service.
 type ServiceSpecOverride struct {
 	// Override configuration for the temporal service Deployment.
@@ -220,14 +773,31 @@ type SecretKeyReference struct {
 	Key string `json:"key,omitempty"`
 }
 
+// ConfigMapKeyReference contains enough information to locate the referenced Kubernetes ConfigMap object in the same
+// namespace.
+type ConfigMapKeyReference struct {
+	// Name of the ConfigMap.
+	// +required
+	Name string `json:"name"`
+	// Key in the ConfigMap.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
 // SQLSpec contains SQL datastore connections specifications.
 type SQLSpec struct {
 	// User is the username to be used for the connection.
 	User string `json:"user"`
 	// PluginName is the name of SQL plugin.
-	// +kubebuilder:validation:Enum=postgres;postgres12;mysql;mysql8
+	// The postgres12 (pgx driver) and mysql8 plugins require temporal >= 1.20.0.
+	// An existing database can be switched from postgres to postgres12 or from mysql to mysql8,
+	// its schema is then upgraded by the operator.
+	// The sqlite plugin is only supported in dev mode.
+	// The cockroachdb plugin configures temporal to use CockroachDB through the postgres12 plugin.
+	// +kubebuilder:validation:Enum=postgres;postgres12;mysql;mysql8;sqlite;cockroachdb
 	PluginName string `json:"pluginName"`
 	// DatabaseName is the name of SQL database to connect to.
+	// For sqlite, it's the database file name, relative to the dev mode data volume.
 	DatabaseName string `json:"databaseName"`
 	// ConnectAddr is the remote addr of the database.
 	ConnectAddr string `json:"connectAddr"`
@@ -238,12 +808,17 @@ type SQLSpec struct {
 	// +optional
 	ConnectAttributes map[string]string `json:"connectAttributes,omitempty"`
 	// MaxConns the max number of connections to this datastore.
+	// The limit applies to each temporal service pod. Unlimited if not set.
+	// +kubebuilder:validation:Minimum=0
 	// +optional
 	MaxConns int `json:"maxConns"`
 	// MaxIdleConns is the max number of idle connections to this datastore.
+	// Must be lower or equal to MaxConns. Uses the driver default if not set.
+	// +kubebuilder:validation:Minimum=0
 	// +optional
 	MaxIdleConns int `json:"maxIdleConns"`
-	// MaxConnLifetime is the maximum time a connection can be alive
+	// MaxConnLifetime is the maximum time a connection can be alive.
+	// Connections are reused forever if not set.
 	// +optional
 	MaxConnLifetime metav1.Duration `json:"maxConnLifetime"`
 	// TaskScanPartitions is the number of partitions to sequentially scan during ListTaskQueue operations.
@@ -254,6 +829,37 @@ type SQLSpec struct {
 	GCPServiceAccount *string `json:"gcpServiceAccount,omitempty"`
 }
 
+// cockroachDBConnectAttributes are the session variables set when connecting to CockroachDB.
+// Sequences are used for SERIAL columns so IDs are strictly increasing, as temporal relies on them for ordering.
+var cockroachDBConnectAttributes = map[string]string{
+	"serial_normalization": "sql_sequence",
+}
+
+// GetTemporalPluginName returns the name of the plugin temporal uses to connect to the datastore.
+func (s *SQLSpec) GetTemporalPluginName() string {
+	if s.PluginName == string(CockroachDBDatastore) {
+		return string(PostgresSQL12Datastore)
+	}
+	return s.PluginName
+}
+
+// GetConnectAttributes returns the connect attributes, including the required ones for the datastore's plugin.
+// User-provided attributes take precedence.
+func (s *SQLSpec) GetConnectAttributes() map[string]string {
+	if s.PluginName != string(CockroachDBDatastore) {
+		return s.ConnectAttributes
+	}
+
+	attributes := map[string]string{}
+	for k, v := range cockroachDBConnectAttributes {
+		attributes[k] = v
+	}
+	for k, v := range s.ConnectAttributes {
+		attributes[k] = v
+	}
+	return attributes
+}
+
 // DatastoreTLSSpec contains datastore TLS connections specifications.
 type DatastoreTLSSpec struct {
 	// Enabled defines if the cluster should use a TLS connection to connect to the datastore.
@@ -267,13 +873,25 @@ type DatastoreTLSSpec struct {
 	// CaFileRef is a reference to a secret containing the ca file.
 	// +optional
 	CaFileRef *SecretKeyReference `json:"caFileRef,omitempty"`
+	// CaConfigMapRef is a reference to a configmap containing the ca file.
+	// Useful for public CA bundles like the ones provided by cloud providers.
+	// Can't be used along with CaFileRef.
+	// +optional
+	CaConfigMapRef *ConfigMapKeyReference `json:"caConfigMapRef,omitempty"`
 	// EnableHostVerification defines if the hostname should be verified when connecting to the datastore.
+	// For PostgreSQL, it switches the sslmode from "require" to "verify-full".
 	EnableHostVerification bool `json:"enableHostVerification"`
 	// ServerName the datastore should present.
+	// It's also sent as SNI, which is required by some managed datastores (e.g. Astra).
 	// +optional
 	ServerName string `json:"serverName"`
 }
 
+// HasCaFile returns true if a CA file is provided, either from a secret or a configmap.
+func (s *DatastoreTLSSpec) HasCaFile() bool {
+	return s != nil && (s.CaFileRef != nil || s.CaConfigMapRef != nil)
+}
+
 // ElasticsearchIndices holds index names.
 type ElasticsearchIndices struct {
 	// Visibility defines visibility's index name.
@@ -309,6 +927,21 @@ type ElasticsearchSpec struct {
 	// EnableHealthcheck enables or disables healthcheck on the temporal cluster's es client.
 	// +optional
 	EnableHealthcheck bool `json:"enableHealthcheck"`
+	// IndexLifecyclePolicy is an optional index lifecycle management (ILM) policy
+	// created by the operator and attached to visibility indices.
+	// +optional
+	IndexLifecyclePolicy *ElasticsearchIndexLifecyclePolicySpec `json:"indexLifecyclePolicy,omitempty"`
+}
+
+// ElasticsearchIndexLifecyclePolicySpec defines an elasticsearch index lifecycle management policy.
+type ElasticsearchIndexLifecyclePolicySpec struct {
+	// Name is the name of the policy.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Policy is the content of the policy, as expected by the elasticsearch "_ilm/policy" API
+	// under the "policy" key (e.g. {"phases": {...}}).
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Policy *apiextensionsv1.JSON `json:"policy"`
 }
 
 // CassandraConsistencySpec sets the consistency level for regular & serial queries to Cassandra.
@@ -361,10 +994,12 @@ const (
 	MySQLDatastore         DatastoreType = "mysql"
 	MySQL8Datastore        DatastoreType = "mysql8"
 	ElasticsearchDatastore DatastoreType = "elasticsearch"
+	SQLiteDatastore        DatastoreType = "sqlite"
+	CockroachDBDatastore   DatastoreType = "cockroachdb"
 	UnknownDatastore       DatastoreType = "unknown"
 )
 
-var SQLDataStores = []DatastoreType{MySQLDatastore, MySQL8Datastore, PostgresSQLDatastore, PostgresSQL12Datastore}
+var SQLDataStores = []DatastoreType{MySQLDatastore, MySQL8Datastore, PostgresSQLDatastore, PostgresSQL12Datastore, CockroachDBDatastore}
 
 const (
 	DefaultStoreName             = "default"
@@ -401,8 +1036,34 @@ type DatastoreSpec struct {
 	// SkipCreate instructs the operator to skip creating the database for SQL datastores or to skip creating keyspace for Cassandra. Use this option if your database or keyspace has already been provisioned by an administrator.
 	// +optional
 	SkipCreate bool `json:"skipCreate"`
+	// SkipSchemaSetup instructs the operator to never run database creation, schema setup and schema upgrade jobs
+	// for this datastore. Use this option if the schema is managed out-of-band, for instance by DBAs.
+	// The datastore status still reports the schema version expected by the cluster.
+	// +optional
+	SkipSchemaSetup bool `json:"skipSchemaSetup,omitempty"`
+	// CredentialsFile is the path of a file containing the datastore password, provided to temporal services
+	// pods by an external tool (e.g. Vault Agent or the Secrets Store CSI driver).
+	// When set, it takes precedence over PasswordSecretRef for temporal services, and the file is read when
+	// the service starts. Schema setup jobs still use PasswordSecretRef.
+	// As the file isn't read again, it can't provide short-lived credentials such as RDS IAM authentication tokens.
+	// +optional
+	CredentialsFile string `json:"credentialsFile,omitempty"`
+	// DeletionPolicy overrides spec.persistence.deletionPolicy for this datastore.
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
+// DeletionPolicy defines what happens to a datastore when its cluster is deleted.
+// +kubebuilder:validation:Enum=Retain;Delete
+type DeletionPolicy string
+
+const (
+	// RetainDeletionPolicy keeps the datastore when the cluster is deleted.
+	RetainDeletionPolicy DeletionPolicy = "Retain"
+	// DeleteDeletionPolicy drops the database, keyspace or indices created by the operator when the cluster is deleted.
+	DeleteDeletionPolicy DeletionPolicy = "Delete"
+)
+
 // LowerCaseName returns the datastore name in lower case.
 func (s *DatastoreSpec) LowerCaseName() string {
 	return strings.ToLower(s.Name)
@@ -420,6 +1081,10 @@ func (s *DatastoreSpec) GetType() DatastoreType {
 			return MySQLDatastore
 		case "mysql8":
 			return MySQL8Datastore
+		case "sqlite":
+			return SQLiteDatastore
+		case "cockroachdb":
+			return CockroachDBDatastore
 		}
 	}
 	if s.Elasticsearch != nil {
@@ -471,7 +1136,7 @@ func (s *DatastoreSpec) GetTLSKeyFileMountPath() string {
 // GetTLSCaFileMountPath  returns the CA key mount path.
 // It returns empty if the tls config is nil or if no secret key ref has been specified.
 func (s *DatastoreSpec) GetTLSCaFileMountPath() string {
-	if s.TLS == nil || s.TLS.CaFileRef == nil {
+	if !s.TLS.HasCaFile() {
 		return ""
 	}
 	return path.Join(dataStoreTLSCertificateBasePath, dataStoreTLSCAPrefix, s.Name, DataStoreClientTLSCaFileName)
@@ -484,11 +1149,31 @@ func (s *DatastoreSpec) GetPasswordEnvVarName() string {
 	return fmt.Sprintf("TEMPORAL_%s_DATASTORE_PASSWORD", storeName)
 }
 
+// GetReferencedSecretNames returns the names of the secrets referenced by the datastore.
+func (s *DatastoreSpec) GetReferencedSecretNames() []string {
+	names := []string{}
+	if s.PasswordSecretRef != nil {
+		names = append(names, s.PasswordSecretRef.Name)
+	}
+
+	if s.TLS != nil {
+		for _, ref := range []*SecretKeyReference{s.TLS.CaFileRef, s.TLS.CertFileRef, s.TLS.KeyFileRef} {
+			if ref != nil {
+				names = append(names, ref.Name)
+			}
+		}
+	}
+
+	return names
+}
+
 // TemporalPersistenceSpec contains temporal persistence specifications.
 type TemporalPersistenceSpec struct {
 	// DefaultStore holds the default datastore specs.
+	// Defaults to a SQLite database when dev mode is enabled.
 	DefaultStore *DatastoreSpec `json:"defaultStore"`
 	// VisibilityStore holds the visibility datastore specs.
+	// Defaults to a SQLite database when dev mode is enabled.
 	VisibilityStore *DatastoreSpec `json:"visibilityStore"`
 	// SecondaryVisibilityStore holds the secondary visibility datastore specs.
 	// Feature only available for clusters >= 1.21.0.
@@ -497,6 +1182,94 @@ type TemporalPersistenceSpec struct {
 	// AdvancedVisibilityStore holds the advanced visibility datastore specs.
 	// +optional
 	AdvancedVisibilityStore *DatastoreSpec `json:"advancedVisibilityStore,omitempty"`
+	// VisibilityMigration configures dual visibility, used to migrate visibility records
+	// from the visibility store to the secondary visibility store.
+	// Requires a secondary visibility store.
+	// +optional
+	VisibilityMigration *VisibilityMigrationSpec `json:"visibilityMigration,omitempty"`
+	// Limits configures the rate limits of the services queries to the datastores.
+	// +optional
+	Limits *PersistenceLimitsSpec `json:"limits,omitempty"`
+	// DeletionPolicy defines what happens to the datastores when the cluster is deleted.
+	// With Delete, the databases, keyspaces and indices created by the operator are dropped
+	// before the cluster is removed. Datastores not created by the operator (skipCreate or skipSchemaSetup)
+	// are always retained.
+	// Defaults to Retain.
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// GetDeletionPolicy returns the deletion policy of the provided datastore.
+func (p *TemporalPersistenceSpec) GetDeletionPolicy(datastore *DatastoreSpec) DeletionPolicy {
+	if datastore != nil && datastore.DeletionPolicy != "" {
+		return datastore.DeletionPolicy
+	}
+	if p.DeletionPolicy != "" {
+		return p.DeletionPolicy
+	}
+	return RetainDeletionPolicy
+}
+
+// PersistenceLimitsSpec defines the rate limits of the services queries to the datastores.
+// Limits are rendered in the dynamic config.
+type PersistenceLimitsSpec struct {
+	// DefaultStore limits the queries of each service host to the default store.
+	// +optional
+	DefaultStore *DefaultStoreLimitsSpec `json:"defaultStore,omitempty"`
+	// VisibilityStore limits the queries of each service host to the visibility stores.
+	// +optional
+	VisibilityStore *VisibilityStoreLimitsSpec `json:"visibilityStore,omitempty"`
+}
+
+// DefaultStoreLimitsSpec defines the max queries per second of each service host to the default store.
+type DefaultStoreLimitsSpec struct {
+	// FrontendMaxQPS is the max queries per second of a frontend host.
+	// +optional
+	FrontendMaxQPS *int32 `json:"frontendMaxQPS,omitempty"`
+	// HistoryMaxQPS is the max queries per second of a history host.
+	// +optional
+	HistoryMaxQPS *int32 `json:"historyMaxQPS,omitempty"`
+	// MatchingMaxQPS is the max queries per second of a matching host.
+	// +optional
+	MatchingMaxQPS *int32 `json:"matchingMaxQPS,omitempty"`
+	// WorkerMaxQPS is the max queries per second of a worker host.
+	// +optional
+	WorkerMaxQPS *int32 `json:"workerMaxQPS,omitempty"`
+}
+
+// VisibilityStoreLimitsSpec defines the max queries per second of each service host to the visibility stores.
+type VisibilityStoreLimitsSpec struct {
+	// MaxReadQPS is the max read queries per second of a service host.
+	// +optional
+	MaxReadQPS *int32 `json:"maxReadQPS,omitempty"`
+	// MaxWriteQPS is the max write queries per second of a service host.
+	// +optional
+	MaxWriteQPS *int32 `json:"maxWriteQPS,omitempty"`
+}
+
+// SecondaryVisibilityWritingMode defines how visibility records are written to the secondary visibility store.
+// +kubebuilder:validation:Enum=off;dual;on
+type SecondaryVisibilityWritingMode string
+
+const (
+	// SecondaryVisibilityWritingModeOff only writes to the visibility store.
+	SecondaryVisibilityWritingModeOff SecondaryVisibilityWritingMode = "off"
+	// SecondaryVisibilityWritingModeDual writes to both visibility and secondary visibility stores.
+	SecondaryVisibilityWritingModeDual SecondaryVisibilityWritingMode = "dual"
+	// SecondaryVisibilityWritingModeOn only writes to the secondary visibility store.
+	SecondaryVisibilityWritingModeOn SecondaryVisibilityWritingMode = "on"
+)
+
+// VisibilityMigrationSpec defines the dual visibility settings.
+type VisibilityMigrationSpec struct {
+	// WritingMode defines how visibility records are written to the secondary visibility store.
+	// +kubebuilder:default=dual
+	// +optional
+	WritingMode SecondaryVisibilityWritingMode `json:"writingMode,omitempty"`
+	// ReadFromSecondary makes temporal read visibility records from the secondary visibility store.
+	// Enable it once the secondary visibility store contains all records.
+	// +optional
+	ReadFromSecondary bool `json:"readFromSecondary,omitempty"`
 }
 
 func (p *TemporalPersistenceSpec) GetDatastores() []*DatastoreSpec {
@@ -533,8 +1306,25 @@ func (p *TemporalPersistenceSpec) GetDatastoresMap() map[string]*DatastoreSpec {
 	return stores
 }
 
-// TemporalUIIngressSpec contains all configurations options for the UI ingress.
-type TemporalUIIngressSpec struct {
+// TemporalUIAction is a UI write action that can be disabled.
+// +kubebuilder:validation:Enum=Terminate;Cancel;Signal;Reset;BatchActions
+type TemporalUIAction string
+
+const (
+	// TerminateUIAction is the workflow terminate action.
+	TerminateUIAction TemporalUIAction = "Terminate"
+	// CancelUIAction is the workflow cancel action.
+	CancelUIAction TemporalUIAction = "Cancel"
+	// SignalUIAction is the workflow signal action.
+	SignalUIAction TemporalUIAction = "Signal"
+	// ResetUIAction is the workflow reset action.
+	ResetUIAction TemporalUIAction = "Reset"
+	// BatchActionsUIAction is the batch terminate and cancel of workflows.
+	BatchActionsUIAction TemporalUIAction = "BatchActions"
+)
+
+// TemporalUIIngressSpec contains all configurations options for the UI ingress.
+type TemporalUIIngressSpec struct {
 	// Annotations allows custom annotations on the ingress resource.
 	Annotations map[string]string `json:"annotations,omitempty"`
 	// IngressClassName is the name of the IngressClass the deployed ingress resource should use.
@@ -543,6 +1333,124 @@ type TemporalUIIngressSpec struct {
 	Hosts []string `json:"hosts"`
 	// TLS configuration.
 	TLS []networkingv1.IngressTLS `json:"tls,omitempty" protobuf:"bytes,2,rep,name=tls"`
+	// Path is the path prefix the ingress routes to the service. Defaults to "/".
+	// For the UI, it defaults to spec.ui.publicPath, and is used as the UI public path if spec.ui.publicPath is not set.
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	Path string `json:"path,omitempty"`
+	// CertManagerIssuerRef lets cert-manager issue the ingress TLS certificate using the referenced issuer.
+	// If no TLS configuration is provided, one is generated for all hosts, using the "<cluster name>-<component>-tls" secret.
+	// +optional
+	CertManagerIssuerRef *CertManagerIssuerReference `json:"certManagerIssuerRef,omitempty"`
+}
+
+// GetPath returns the path prefix routed by the ingress.
+func (s *TemporalUIIngressSpec) GetPath() string {
+	if s.Path == "" {
+		return "/"
+	}
+	return s.Path
+}
+
+// TemporalUIHTTPRouteSpec contains all configurations options for the UI Gateway API HTTPRoute.
+type TemporalUIHTTPRouteSpec struct {
+	// Annotations allows custom annotations on the HTTPRoute resource.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// ParentRefs references the Gateways the route attaches to.
+	// +kubebuilder:validation:MinItems=1
+	ParentRefs []gatewayv1.ParentReference `json:"parentRefs"`
+	// Hostnames is the list of hostnames the route matches.
+	// +optional
+	Hostnames []gatewayv1.Hostname `json:"hostnames,omitempty"`
+	// Path is the path prefix the route matches.
+	// Defaults to spec.ui.publicPath, and is used as the UI public path if spec.ui.publicPath is not set.
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	Path string `json:"path,omitempty"`
+	// Filters are applied to the requests matched by the route.
+	// +optional
+	Filters []gatewayv1.HTTPRouteFilter `json:"filters,omitempty"`
+}
+
+// GetPath returns the path prefix matched by the route.
+func (s *TemporalUIHTTPRouteSpec) GetPath() string {
+	if s.Path == "" {
+		return "/"
+	}
+	return s.Path
+}
+
+// AutoscalingSpec defines a HorizontalPodAutoscaler for a deployment.
+type AutoscalingSpec struct {
+	// Enabled defines if the operator should create the HorizontalPodAutoscaler.
+	// +optional
+	Enabled bool `json:"enabled"`
+	// MinReplicas is the lower limit for the number of replicas. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the upper limit for the number of replicas.
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+	// Metrics contains the specifications used to calculate the desired replica count.
+	// Defaults to 80% average CPU utilization.
+	// +optional
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
+	// Behavior configures the scaling behavior in both up and down directions.
+	// +optional
+	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+}
+
+// IsEnabled returns true if autoscaling is enabled.
+func (a *AutoscalingSpec) IsEnabled() bool {
+	return a != nil && a.Enabled
+}
+
+// TemporalUIAuthSpec defines the UI OIDC authentication settings.
+type TemporalUIAuthSpec struct {
+	// Enabled defines if the UI requires users to log in.
+	// +optional
+	Enabled bool `json:"enabled"`
+	// ProviderURL is the URL of the OIDC provider, e.g. "https://accounts.google.com".
+	ProviderURL string `json:"providerURL"`
+	// IssuerURL is the issuer URL, if it differs from the provider URL.
+	// +optional
+	IssuerURL string `json:"issuerURL,omitempty"`
+	// ClientID is the OIDC client ID.
+	// +optional
+	ClientID string `json:"clientID,omitempty"`
+	// ClientIDSecretRef references the secret key holding the OIDC client ID.
+	// Takes precedence over ClientID.
+	// +optional
+	ClientIDSecretRef *corev1.SecretKeySelector `json:"clientIDSecretRef,omitempty"`
+	// ClientSecretRef references the secret key holding the OIDC client secret.
+	// +optional
+	ClientSecretRef *corev1.SecretKeySelector `json:"clientSecretRef,omitempty"`
+	// Scopes are the OIDC scopes requested by the UI.
+	// Defaults to openid, profile and email.
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+	// CallbackURL is the URL the OIDC provider redirects users to after login.
+	// Defaults to "<ui url>/auth/sso/callback", using the first ingress host or HTTPRoute hostname.
+	// +optional
+	CallbackURL string `json:"callbackURL,omitempty"`
+	// Label is the text of the login button.
+	// +optional
+	Label string `json:"label,omitempty"`
+}
+
+// IsEnabled returns true if the UI authentication is enabled.
+func (s *TemporalUIAuthSpec) IsEnabled() bool {
+	return s != nil && s.Enabled
+}
+
+// GetScopes returns the OIDC scopes requested by the UI.
+func (s *TemporalUIAuthSpec) GetScopes() []string {
+	if len(s.Scopes) == 0 {
+		return []string{"openid", "profile", "email"}
+	}
+	return s.Scopes
 }
 
 // TemporalUISpec defines parameters for the temporal UI within a Temporal cluster deployment.
@@ -556,14 +1464,37 @@ type TemporalUISpec struct {
 	// Image defines the temporal ui docker image the instance should run.
 	// +optional
 	Image string `json:"image"`
+	// ImageDigest pins the ui image to the given digest (e.g. "sha256:...").
+	// If set, it takes precedence over the ui version: it must be updated along with the ui version.
+	// +optional
+	ImageDigest string `json:"imageDigest,omitempty"`
+	// ImagePullPolicy sets the pull policy of the ui image.
+	// Defaults to IfNotPresent.
+	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
 	// Number of desired replicas for the ui. Default to 1.
+	// Ignored if autoscaling is enabled.
 	// +kubebuilder:validation:Minimum=1
 	// +optional
 	Replicas *int32 `json:"replicas"`
+	// Autoscaling enables a HorizontalPodAutoscaler for the ui.
+	// +optional
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
 	// Compute Resources required by the ui.
 	// More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// LivenessProbe overrides the liveness probe of the ui container.
+	// Defaults to a TCP check on the http port.
+	// +optional
+	LivenessProbe *corev1.Probe `json:"livenessProbe,omitempty"`
+	// ReadinessProbe sets the readiness probe of the ui container.
+	// +optional
+	ReadinessProbe *corev1.Probe `json:"readinessProbe,omitempty"`
+	// StartupProbe sets the startup probe of the ui container.
+	// +optional
+	StartupProbe *corev1.Probe `json:"startupProbe,omitempty"`
 	// Overrides adds some overrides to the resources deployed for the ui.
 	// +optional
 	Overrides *ServiceSpecOverride `json:"overrides,omitempty"`
@@ -571,9 +1502,197 @@ type TemporalUISpec struct {
 	// If lived empty, no ingress configuration will be created and the UI will only by available trough ClusterIP service.
 	// +optional
 	Ingress *TemporalUIIngressSpec `json:"ingress,omitempty"`
+	// Route is an optional OpenShift Route configuration for the UI, an alternative to the ingress.
+	// +optional
+	Route *RouteSpec `json:"route,omitempty"`
+	// AppProtocol is the appProtocol of the UI Service port.
+	// Set it to an empty string to remove the appProtocol from the port.
+	// Defaults to "http".
+	// +optional
+	AppProtocol *string `json:"appProtocol,omitempty"`
+	// ExternalHostname is the hostname users reach the UI at.
+	// It's set as the external-dns hostname of the UI ingress, or of the UI Service if the UI has no ingress.
+	// +optional
+	ExternalHostname string `json:"externalHostname,omitempty"`
+	// PublicPath is the path the UI is served from, e.g. "/temporal" to expose the UI behind a shared ingress.
+	// The UI ingress and HTTPRoute route this path, unless they set their own.
+	// Defaults to the ingress or HTTPRoute path, or "/".
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	PublicPath string `json:"publicPath,omitempty"`
+	// HTTPRoute is an optional Gateway API HTTPRoute configuration for the UI, an alternative to the ingress.
+	// Requires the Gateway API CRDs to be installed in the cluster.
+	// +optional
+	HTTPRoute *TemporalUIHTTPRouteSpec `json:"httpRoute,omitempty"`
+	// Auth configures the UI OIDC authentication.
+	// +optional
+	Auth *TemporalUIAuthSpec `json:"auth,omitempty"`
+	// ReadOnly disables all write actions in the UI, e.g. terminating, canceling, signaling or resetting workflows.
+	// +optional
+	ReadOnly bool `json:"readOnly,omitempty"`
+	// DisabledActions lists the UI write actions to disable. It's ignored when readOnly is true.
+	// +optional
+	DisabledActions []TemporalUIAction `json:"disabledActions,omitempty"`
+	// Config holds additional temporal-ui configuration, as environment variables,
+	// e.g. TEMPORAL_DEFAULT_NAMESPACE or TEMPORAL_BANNER_TEXT.
+	// See https://docs.temporal.io/references/web-ui-environment-variables.
+	// Values are stored in a ConfigMap loaded by the UI pods, and take precedence over the values generated by the operator.
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
 	// Service is an optional service resource configuration for the UI.
 	// +optional
 	Service *ObjectMetaOverride `json:"service,omitempty"`
+	// InitContainers adds a list of init containers to the ui's deployment.
+	// +optional
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+	// Sidecars adds a list of containers running alongside the ui's container.
+	// +optional
+	Sidecars []corev1.Container `json:"sidecars,omitempty"`
+	// PodSecurityContext overrides the pod-level security context of the ui pods.
+	// If left empty, the operator uses a context compliant with the "restricted" Pod Security Standard.
+	// +optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// SecurityContext overrides the security context of the ui container.
+	// If left empty, the operator uses a context compliant with the "restricted" Pod Security Standard.
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+	// CodecServer allows deploying a remote codec server, used by the UI to decode payloads.
+	// +optional
+	CodecServer *CodecServerSpec `json:"codecServer,omitempty"`
+}
+
+// PublicURL returns the URL users reach the UI at, built from the first ingress host, the HTTPRoute hostname or the Route host.
+// HTTPRoutes are assumed to be attached to an HTTPS listener.
+// It returns an empty string if the UI is not exposed.
+func (s *TemporalUISpec) PublicURL() string {
+	var scheme, host string
+	switch {
+	case s.Ingress != nil && len(s.Ingress.Hosts) > 0:
+		scheme = "http"
+		if len(s.Ingress.TLS) > 0 || s.Ingress.CertManagerIssuerRef != nil {
+			scheme = "https"
+		}
+		host = strings.SplitN(s.Ingress.Hosts[0], "/", 2)[0]
+	case s.HTTPRoute != nil && len(s.HTTPRoute.Hostnames) > 0:
+		scheme = "https"
+		host = string(s.HTTPRoute.Hostnames[0])
+	case s.Route != nil && s.Route.Host != "":
+		scheme = "https"
+		host = s.Route.Host
+	default:
+		return ""
+	}
+
+	return fmt.Sprintf("%s://%s%s", scheme, host, strings.TrimSuffix(s.GetPublicPath(), "/"))
+}
+
+// GetAppProtocol returns the appProtocol of the UI Service port.
+func (s *TemporalUISpec) GetAppProtocol() *string {
+	return appProtocol(s.AppProtocol, "http")
+}
+
+// GetPublicPath returns the path the UI is served from.
+// It defaults to the path configured on the UI ingress or HTTPRoute.
+func (s *TemporalUISpec) GetPublicPath() string {
+	switch {
+	case s.PublicPath != "":
+		return s.PublicPath
+	case s.Ingress != nil:
+		return s.Ingress.GetPath()
+	case s.HTTPRoute != nil:
+		return s.HTTPRoute.GetPath()
+	default:
+		return "/"
+	}
+}
+
+// IngressPath returns the path prefix routed to the UI by its ingress.
+func (s *TemporalUISpec) IngressPath() string {
+	if s.Ingress != nil && s.Ingress.Path != "" {
+		return s.Ingress.Path
+	}
+	return s.GetPublicPath()
+}
+
+// HTTPRoutePath returns the path prefix routed to the UI by its HTTPRoute.
+func (s *TemporalUISpec) HTTPRoutePath() string {
+	if s.HTTPRoute != nil && s.HTTPRoute.Path != "" {
+		return s.HTTPRoute.Path
+	}
+	return s.GetPublicPath()
+}
+
+// CodecServerSpec defines a remote codec server deployed alongside the cluster.
+// The codec server is deployed even if the UI is disabled, so it can be used by other clients (e.g. the temporal CLI).
+type CodecServerSpec struct {
+	// Enabled defines if the operator should deploy the codec server.
+	// +optional
+	Enabled bool `json:"enabled"`
+	// Image is the codec server docker image reference, including its tag.
+	// +optional
+	Image string `json:"image"`
+	// ImagePullPolicy sets the pull policy of the codec server image.
+	// Defaults to IfNotPresent.
+	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// Number of desired replicas for the codec server. Default to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Port is the port the codec server listens on. Default to 8888.
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+	// Args are the arguments passed to the codec server container.
+	// +optional
+	Args []string `json:"args,omitempty"`
+	// Env are environment variables passed to the codec server container.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// EnvFrom are sources of environment variables passed to the codec server container,
+	// e.g. a secret containing encryption keys.
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+	// Compute Resources required by the codec server.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Ingress is an optional ingress configuration for the codec server.
+	// +optional
+	Ingress *TemporalUIIngressSpec `json:"ingress,omitempty"`
+	// Endpoint is the codec server URL configured in the UI. The UI calls the codec server from users browsers,
+	// so this URL must be reachable by them.
+	// Defaults to the first ingress host if set, otherwise to the codec server service URL.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// PassAccessToken enables passing the user access token to the codec server.
+	// +optional
+	PassAccessToken bool `json:"passAccessToken,omitempty"`
+	// IncludeCredentials enables including cross-origin credentials in the codec server requests.
+	// +optional
+	IncludeCredentials bool `json:"includeCredentials,omitempty"`
+	// MTLS enables issuing a certificate for the codec server using the cluster's frontend CA.
+	// The certificate is mounted in the codec server pods, which are responsible for serving TLS with it.
+	// Only supported if mTLS provider is cert-manager.
+	// +optional
+	MTLS bool `json:"mTLS,omitempty"` //nolint:tagliatelle
+}
+
+// IsEnabled returns true if the codec server should be deployed.
+func (c *CodecServerSpec) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+// GetPort returns the codec server port.
+func (c *CodecServerSpec) GetPort() int32 {
+	if c.Port == nil {
+		return 8888
+	}
+	return *c.Port
+}
+
+// GetCertificateMountPath returns the mount path for the codec server certificate.
+func (CodecServerSpec) GetCertificateMountPath() string {
+	return "/etc/codec-server/certs"
 }
 
 // TemporalAdminToolsSpec defines parameters for the temporal admin tools within a Temporal cluster deployment.
@@ -585,6 +1704,16 @@ type TemporalAdminToolsSpec struct {
 	// Image defines the temporal admin tools docker image the instance should run.
 	// +optional
 	Image string `json:"image"`
+	// ImageDigest pins the admin tools image to the given digest (e.g. "sha256:...").
+	// If set, it takes precedence over the cluster version: it must be updated along with the cluster version.
+	// The admin tools image is also used by the persistence setup jobs.
+	// +optional
+	ImageDigest string `json:"imageDigest,omitempty"`
+	// ImagePullPolicy sets the pull policy of the admin tools image.
+	// Defaults to IfNotPresent.
+	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
 	// Compute Resources required by the ui.
 	// More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
 	// +optional
@@ -592,6 +1721,20 @@ type TemporalAdminToolsSpec struct {
 	// Overrides adds some overrides to the resources deployed for the ui.
 	// +optional
 	Overrides *ServiceSpecOverride `json:"overrides,omitempty"`
+	// InitContainers adds a list of init containers to the admin tools deployment.
+	// +optional
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+	// Sidecars adds a list of containers running alongside the admin tools container.
+	// +optional
+	Sidecars []corev1.Container `json:"sidecars,omitempty"`
+	// PodSecurityContext overrides the pod-level security context of the admin tools pod.
+	// If left empty, the operator uses a context compliant with the "restricted" Pod Security Standard.
+	// +optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// SecurityContext overrides the security context of the admin tools container.
+	// If left empty, the operator uses a context compliant with the "restricted" Pod Security Standard.
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
 }
 
 // MTLSProvider is the enum for support mTLS provider.
@@ -602,6 +1745,12 @@ const (
 	CertManagerMTLSProvider MTLSProvider = "cert-manager"
 	LinkerdMTLSProvider     MTLSProvider = "linkerd"
 	IstioMTLSProvider       MTLSProvider = "istio"
+	// SecretsMTLSProvider uses user-provided secrets as mTLS certificates.
+	SecretsMTLSProvider MTLSProvider = "secrets"
+	// VaultMTLSProvider uses HashiCorp Vault PKI secrets engine to issue mTLS certificates.
+	VaultMTLSProvider MTLSProvider = "vault"
+	// SPIFFEMTLSProvider uses SVIDs from the SPIFFE Workload API as mTLS certificates.
+	SPIFFEMTLSProvider MTLSProvider = "spiffe"
 )
 
 // FrontendMTLSSpec defines parameters for the temporal encryption in transit with mTLS.
@@ -614,6 +1763,55 @@ type FrontendMTLSSpec struct {
 	// The DNS names specified here will be added to the TLS certificate for secure communication.
 	// +nullable
 	ExtraDNSNames []string `json:"extraDnsNames,omitempty"`
+	// ExtraIPAddresses is a list of additional IP addresses added to the frontend certificate.
+	// Useful for clients connecting to the frontend using a LoadBalancer IP.
+	// +optional
+	ExtraIPAddresses []string `json:"extraIpAddresses,omitempty"`
+	// External allows configuration of a dedicated certificate served to clients
+	// connecting using external hostnames.
+	// Only supported if mTLS provider is cert-manager.
+	// +optional
+	External *FrontendExternalTLSSpec `json:"external,omitempty"`
+	// SecretRef references an existing secret containing the frontend certificate (tls.crt and tls.key)
+	// and the CA bundle used to verify clients and the frontend certificate (ca.crt).
+	// Required if mTLS provider is "secrets".
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+	// ClientSecretRef references an existing secret containing the client certificate (tls.crt and tls.key)
+	// used by the worker service, the UI, the admin tools and the operator to connect to the frontend.
+	// It also contains the CA bundle used to verify the frontend certificate (ca.crt).
+	// Required if mTLS provider is "secrets".
+	// +optional
+	ClientSecretRef *corev1.LocalObjectReference `json:"clientSecretRef,omitempty"`
+}
+
+// FrontendExternalTLSSpec defines the certificate served by the frontend to clients
+// connecting using external hostnames (e.g. a LoadBalancer or an Ingress hostname).
+// The certificate is selected using the server name requested by clients (SNI).
+type FrontendExternalTLSSpec struct {
+	// DNSNames are the external hostnames clients use to connect to the frontend.
+	// +kubebuilder:validation:MinItems=1
+	DNSNames []string `json:"dnsNames"`
+	// IssuerRef references an existing cert-manager issuer used to issue the external certificate,
+	// for instance an issuer trusted by clients outside of the cluster.
+	// Defaults to the frontend intermediate CA issuer.
+	// +optional
+	IssuerRef *CertManagerIssuerReference `json:"issuerRef,omitempty"`
+	// ClientCASecretRef references a secret key containing the CA bundle used to verify
+	// the certificates of clients connecting using external hostnames.
+	// Defaults to the CAs used for in-cluster clients.
+	// +optional
+	ClientCASecretRef *corev1.SecretKeySelector `json:"clientCASecretRef,omitempty"` //nolint:tagliatelle
+}
+
+// GetCertificateMountPath returns the mount path for the frontend external certificate.
+func (FrontendExternalTLSSpec) GetCertificateMountPath() string {
+	return "/etc/temporal/config/certs/cluster/frontend-external"
+}
+
+// GetClientCACertificateMountPath returns the mount path for the external clients CA bundle.
+func (FrontendExternalTLSSpec) GetClientCACertificateMountPath() string {
+	return "/etc/temporal/config/certs/client/external-ca"
 }
 
 // ServerName returns frontend servername for mTLS certificates.
@@ -621,6 +1819,21 @@ func (FrontendMTLSSpec) ServerName(cluster *TemporalCluster) string {
 	return fmt.Sprintf("%s.%s", cluster.ChildResourceName("frontend"), cluster.FQDNSuffix())
 }
 
+// DNSNames returns the DNS names of the frontend certificate: its server name,
+// the extra DNS names and the frontend external hostname.
+func (s FrontendMTLSSpec) DNSNames(cluster *TemporalCluster) []string {
+	names := append([]string{s.ServerName(cluster)}, s.ExtraDNSNames...)
+
+	if cluster.Spec.Services != nil && cluster.Spec.Services.Frontend != nil {
+		hostname := cluster.Spec.Services.Frontend.ExternalHostname
+		if hostname != "" && !slices.Contains(names, hostname) {
+			names = append(names, hostname)
+		}
+	}
+
+	return names
+}
+
 // GetIntermediateCACertificateMountPath returns the mount path for intermediate CA certificates.
 func (FrontendMTLSSpec) GetIntermediateCACertificateMountPath() string {
 	return "/etc/temporal/config/certs/client/ca"
@@ -641,6 +1854,11 @@ type InternodeMTLSSpec struct {
 	// Enabled defines if the operator should enable mTLS for network between cluster nodes.
 	// +optional
 	Enabled bool `json:"enabled"`
+	// SecretRef references an existing secret containing the internode certificate (tls.crt and tls.key)
+	// and the CA bundle used to verify peers (ca.crt).
+	// Required if mTLS provider is "secrets".
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
 }
 
 // ServerName returns internode servername for mTLS certificates.
@@ -682,36 +1900,203 @@ type CertificatesDurationSpec struct {
 	InternodeCertificate *metav1.Duration `json:"internodeCertificate"`
 }
 
+// CertManagerIssuerReference references an existing cert-manager issuer.
+type CertManagerIssuerReference struct {
+	// Name of the issuer.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// Kind of the issuer, "Issuer" or "ClusterIssuer" for cert-manager's built-in issuers.
+	// An Issuer must be in the same namespace as the TemporalCluster.
+	// +kubebuilder:default=Issuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// Group of the issuer, set it when using an external issuer.
+	// Defaults to "cert-manager.io".
+	// +optional
+	Group string `json:"group,omitempty"`
+}
+
+// CertManagerMTLSSpec defines cert-manager specific mTLS parameters.
+type CertManagerMTLSSpec struct {
+	// IssuerRef references an existing issuer used to issue the intermediate CAs certificates,
+	// instead of the self-signed root CA created by the operator.
+	// The issuer must be able to issue CA certificates.
+	// +optional
+	IssuerRef *CertManagerIssuerReference `json:"issuerRef,omitempty"`
+}
+
+// VaultKubernetesAuthSpec defines the Vault kubernetes auth method configuration.
+type VaultKubernetesAuthSpec struct {
+	// Role is the Vault role the operator's service account authenticates with.
+	// +kubebuilder:validation:Required
+	Role string `json:"role"`
+	// MountPath is the path where the kubernetes auth method is mounted.
+	// Defaults to "kubernetes".
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+}
+
+// GetMountPath returns the kubernetes auth method mount path.
+func (s *VaultKubernetesAuthSpec) GetMountPath() string {
+	if s.MountPath == "" {
+		return "kubernetes"
+	}
+	return s.MountPath
+}
+
+// VaultAuthSpec defines how the operator authenticates against Vault.
+// Only one auth method should be provided.
+type VaultAuthSpec struct {
+	// Kubernetes uses the operator's service account token to authenticate
+	// using the Vault kubernetes auth method.
+	// +optional
+	Kubernetes *VaultKubernetesAuthSpec `json:"kubernetes,omitempty"`
+	// TokenSecretRef references a secret key containing a Vault token.
+	// +optional
+	TokenSecretRef *corev1.SecretKeySelector `json:"tokenSecretRef,omitempty"`
+}
+
+// VaultMTLSSpec defines the HashiCorp Vault PKI configuration used to issue mTLS certificates.
+type VaultMTLSSpec struct {
+	// Address is the Vault server address (e.g. https://vault.vault.svc:8200).
+	// +kubebuilder:validation:Required
+	Address string `json:"address"`
+	// PKIMountPath is the path where the PKI secrets engine is mounted.
+	// Defaults to "pki".
+	// +optional
+	PKIMountPath string `json:"pkiMountPath,omitempty"`
+	// Role is the PKI role used to issue certificates.
+	// It should allow the cluster's service names (e.g. "<namespace>.svc.cluster.local" with subdomains).
+	// +kubebuilder:validation:Required
+	Role string `json:"role"`
+	// Auth configures how the operator authenticates against Vault.
+	// +kubebuilder:validation:Required
+	Auth VaultAuthSpec `json:"auth"`
+	// CASecretRef references a secret key containing the CA bundle used to verify the Vault server certificate.
+	// If not set, the system's CA bundle is used.
+	// +optional
+	CASecretRef *corev1.SecretKeySelector `json:"caSecretRef,omitempty"`
+}
+
+// GetPKIMountPath returns the PKI secrets engine mount path.
+func (s *VaultMTLSSpec) GetPKIMountPath() string {
+	if s.PKIMountPath == "" {
+		return "pki"
+	}
+	return s.PKIMountPath
+}
+
+// SPIFFEMTLSSpec defines how SVIDs are retrieved from the SPIFFE Workload API.
+type SPIFFEMTLSSpec struct {
+	// CSIDriver is the name of the CSI driver providing the Workload API socket.
+	// Defaults to "csi.spiffe.io".
+	// +optional
+	CSIDriver string `json:"csiDriver,omitempty"`
+	// SocketName is the name of the Workload API socket in the CSI volume.
+	// Defaults to "spire-agent.sock".
+	// +optional
+	SocketName string `json:"socketName,omitempty"`
+	// HelperImage is the spiffe-helper image used to write SVIDs to the pods filesystem.
+	// Defaults to "ghcr.io/spiffe/spiffe-helper:0.8.0".
+	// +optional
+	HelperImage string `json:"helperImage,omitempty"`
+	// DisableHostVerification disables the server name verification of SVIDs.
+	// Use it if your SVIDs don't contain the cluster's DNS names.
+	// +optional
+	DisableHostVerification bool `json:"disableHostVerification,omitempty"`
+}
+
+// GetCSIDriver returns the name of the CSI driver providing the Workload API socket.
+func (s *SPIFFEMTLSSpec) GetCSIDriver() string {
+	if s == nil || s.CSIDriver == "" {
+		return "csi.spiffe.io"
+	}
+	return s.CSIDriver
+}
+
+// GetSocketName returns the name of the Workload API socket.
+func (s *SPIFFEMTLSSpec) GetSocketName() string {
+	if s == nil || s.SocketName == "" {
+		return "spire-agent.sock"
+	}
+	return s.SocketName
+}
+
+// GetHelperImage returns the spiffe-helper image.
+func (s *SPIFFEMTLSSpec) GetHelperImage() string {
+	if s == nil || s.HelperImage == "" {
+		return "ghcr.io/spiffe/spiffe-helper:0.8.0"
+	}
+	return s.HelperImage
+}
+
 // MTLSSpec defines parameters for the temporal encryption in transit with mTLS.
 type MTLSSpec struct {
 	// Provider defines the tool used to manage mTLS certificates.
 	// +kubebuilder:default=cert-manager
-	// +kubebuilder:validation:Enum=cert-manager;linkerd;istio
+	// +kubebuilder:validation:Enum=cert-manager;linkerd;istio;secrets;vault;spiffe
 	// +optional
 	Provider MTLSProvider `json:"provider"`
-	// Internode allows configuration of the internode traffic encryption.
+	// CertManager allows configuration of cert-manager specific parameters.
 	// Useless if mTLS provider is not cert-manager.
 	// +optional
+	CertManager *CertManagerMTLSSpec `json:"certManager,omitempty"`
+	// Vault configures the HashiCorp Vault PKI used to issue certificates.
+	// Required if mTLS provider is "vault".
+	// +optional
+	Vault *VaultMTLSSpec `json:"vault,omitempty"`
+	// SPIFFE configures how SVIDs are retrieved from the SPIFFE Workload API.
+	// Useless if mTLS provider is not spiffe.
+	// +optional
+	SPIFFE *SPIFFEMTLSSpec `json:"spiffe,omitempty"`
+	// Internode allows configuration of the internode traffic encryption.
+	// Useless if mTLS provider is linkerd or istio.
+	// +optional
 	Internode *InternodeMTLSSpec `json:"internode,omitempty"`
 	// Frontend allows configuration of the frontend's public endpoint traffic encryption.
-	// Useless if mTLS provider is not cert-manager.
+	// Useless if mTLS provider is linkerd or istio.
 	// +optional
 	Frontend *FrontendMTLSSpec `json:"frontend,omitempty"`
 	// CertificatesDuration allows configuration of maximum certificates lifetime.
-	// Useless if mTLS provider is not cert-manager.
+	// Useless if mTLS provider is not cert-manager or vault.
 	// +optional
 	CertificatesDuration *CertificatesDurationSpec `json:"certificatesDuration,omitempty"`
 	// RefreshInterval defines interval between refreshes of certificates in the cluster components.
 	// Defaults to 1 hour.
-	// Useless if mTLS provider is not cert-manager.
+	// Useless if mTLS provider is linkerd or istio.
 	// +optional
 	RefreshInterval *metav1.Duration `json:"refreshInterval"`
 	// RenewBefore is defines how long before the currently issued certificate's expiry
 	// cert-manager should renew the certificate. The default is 2/3 of the
 	// issued certificate's duration. Minimum accepted value is 5 minutes.
-	// Useless if mTLS provider is not cert-manager.
+	// Useless if mTLS provider is not cert-manager or vault.
 	// +optional
 	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+	// RestartOnRenewal enables ordered rolling restarts of the cluster's pods when certificates are renewed,
+	// so all pods use the new certificates before the old ones expire.
+	// Services are restarted one after the other: history, matching, frontend, internal frontend,
+	// worker, then UI and admin tools.
+	// Defaults to true. Useless if mTLS provider is linkerd, istio or spiffe.
+	// +optional
+	RestartOnRenewal *bool `json:"restartOnRenewal,omitempty"`
+	// ExpiryWarningThreshold defines how long before a certificate's expiry
+	// the operator starts emitting warning events for it.
+	// Defaults to 7 days. Useless if mTLS provider is linkerd, istio or spiffe.
+	// +optional
+	ExpiryWarningThreshold *metav1.Duration `json:"expiryWarningThreshold,omitempty"`
+}
+
+// RestartOnRenewalEnabled returns true if services should be restarted when certificates are renewed.
+func (m *MTLSSpec) RestartOnRenewalEnabled() bool {
+	return m != nil && (m.RestartOnRenewal == nil || *m.RestartOnRenewal)
+}
+
+// CertManagerIssuerRef returns the user-provided cert-manager issuer, if any.
+func (m *MTLSSpec) CertManagerIssuerRef() *CertManagerIssuerReference {
+	if m == nil || m.CertManager == nil {
+		return nil
+	}
+	return m.CertManager.IssuerRef
 }
 
 func (m *MTLSSpec) InternodeEnabled() bool {
@@ -737,6 +2122,41 @@ type PrometheusScrapeConfigServiceMonitor struct {
 	// MetricRelabelConfigs to apply to samples before ingestion.
 	// +optional
 	MetricRelabelConfigs []*monitoringv1.RelabelConfig `json:"metricRelabelings,omitempty"`
+	// RelabelConfigs to apply to the targets before scraping.
+	// +optional
+	RelabelConfigs []*monitoringv1.RelabelConfig `json:"relabelings,omitempty"`
+	// Interval at which metrics should be scraped.
+	// If empty, Prometheus uses the global scrape interval.
+	// +optional
+	Interval monitoringv1.Duration `json:"interval,omitempty"`
+	// ScrapeTimeout after which the scrape is ended.
+	// If empty, Prometheus uses the global scrape timeout.
+	// +optional
+	ScrapeTimeout monitoringv1.Duration `json:"scrapeTimeout,omitempty"`
+}
+
+// PrometheusScrapeConfigPodMonitor is the configuration for prometheus operator PodMonitor.
+type PrometheusScrapeConfigPodMonitor struct {
+	// Enabled defines if the operator should create a PodMonitor for each services.
+	// +optional
+	Enabled bool `json:"enabled"`
+	// Labels adds extra labels to the PodMonitor.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// MetricRelabelConfigs to apply to samples before ingestion.
+	// +optional
+	MetricRelabelConfigs []*monitoringv1.RelabelConfig `json:"metricRelabelings,omitempty"`
+	// RelabelConfigs to apply to the targets before scraping.
+	// +optional
+	RelabelConfigs []*monitoringv1.RelabelConfig `json:"relabelings,omitempty"`
+	// Interval at which metrics should be scraped.
+	// If empty, Prometheus uses the global scrape interval.
+	// +optional
+	Interval monitoringv1.Duration `json:"interval,omitempty"`
+	// ScrapeTimeout after which the scrape is ended.
+	// If empty, Prometheus uses the global scrape timeout.
+	// +optional
+	ScrapeTimeout monitoringv1.Duration `json:"scrapeTimeout,omitempty"`
 }
 
 // PrometheusScrapeConfig is the configuration for making prometheus scrape components metrics.
@@ -746,6 +2166,72 @@ type PrometheusScrapeConfig struct {
 	Annotations bool `json:"annotations"`
 	// +optional
 	ServiceMonitor *PrometheusScrapeConfigServiceMonitor `json:"serviceMonitor,omitempty"`
+	// PodMonitor creates a PodMonitor scraping the pods of each services directly,
+	// e.g. when the headless Services can't be selected by Prometheus.
+	// +optional
+	PodMonitor *PrometheusScrapeConfigPodMonitor `json:"podMonitor,omitempty"`
+}
+
+// PrometheusAlert is the name of an alert of the PrometheusRule created by the operator.
+// +kubebuilder:validation:Enum=TemporalPersistenceLatencyHigh;TemporalShardLockLatencyHigh;TemporalTaskBacklogHigh;TemporalFrontendErrorRateHigh;TemporalCertificateExpiringSoon
+type PrometheusAlert string
+
+const (
+	// PersistenceLatencyHighAlert fires when the p99 latency of persistence requests is above 1 second.
+	PersistenceLatencyHighAlert PrometheusAlert = "TemporalPersistenceLatencyHigh"
+	// ShardLockLatencyHighAlert fires when the p99 latency of history shard locks is above 500 milliseconds.
+	ShardLockLatencyHighAlert PrometheusAlert = "TemporalShardLockLatencyHigh"
+	// TaskBacklogHighAlert fires when the p95 latency of tasks dispatched from the matching backlog is above 10 seconds.
+	TaskBacklogHighAlert PrometheusAlert = "TemporalTaskBacklogHigh"
+	// FrontendErrorRateHighAlert fires when more than 5% of frontend requests fail.
+	FrontendErrorRateHighAlert PrometheusAlert = "TemporalFrontendErrorRateHigh"
+	// CertificateExpiringSoonAlert fires when a mTLS certificate expires within the mTLS expiry warning threshold.
+	CertificateExpiringSoonAlert PrometheusAlert = "TemporalCertificateExpiringSoon"
+)
+
+// PrometheusRuleSpec is the configuration of the PrometheusRule holding the cluster's alerts.
+type PrometheusRuleSpec struct {
+	// Enabled defines if the operator should create a PrometheusRule with alerts for the cluster.
+	// +optional
+	Enabled bool `json:"enabled"`
+	// Labels adds extra labels to the PrometheusRule, e.g. to match the ruleSelector of your Prometheus.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// AlertLabels adds extra labels to all alerts, e.g. to route them in Alertmanager.
+	// +optional
+	AlertLabels map[string]string `json:"alertLabels,omitempty"`
+	// DisabledAlerts lists the alerts not to create.
+	// +optional
+	DisabledAlerts []PrometheusAlert `json:"disabledAlerts,omitempty"`
+}
+
+// IsEnabled returns true if the PrometheusRule is enabled.
+func (s *PrometheusRuleSpec) IsEnabled() bool {
+	return s != nil && s.Enabled
+}
+
+// IsAlertEnabled returns true if the provided alert isn't disabled.
+func (s *PrometheusRuleSpec) IsAlertEnabled(alert PrometheusAlert) bool {
+	return !slices.Contains(s.DisabledAlerts, alert)
+}
+
+// GrafanaDashboardsSpec is the configuration of the ConfigMap holding the cluster's Grafana dashboards.
+type GrafanaDashboardsSpec struct {
+	// Enabled defines if the operator should create a ConfigMap holding Grafana dashboards for the cluster.
+	// +optional
+	Enabled bool `json:"enabled"`
+	// Labels adds extra labels to the ConfigMap.
+	// Defaults to the label watched by the Grafana sidecar: grafana_dashboard: "1".
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations adds extra annotations to the ConfigMap, e.g. the Grafana sidecar folder annotation.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// IsEnabled returns true if the Grafana dashboards are enabled.
+func (s *GrafanaDashboardsSpec) IsEnabled() bool {
+	return s != nil && s.Enabled
 }
 
 // PrometheusSpec is the configuration for prometheus reporter.
@@ -760,6 +2246,37 @@ type PrometheusSpec struct {
 	// ScrapeConfig is the prometheus scrape configuration.
 	// +optional
 	ScrapeConfig *PrometheusScrapeConfig `json:"scrapeConfig,omitempty"`
+	// Rule creates a PrometheusRule with alerts for the cluster.
+	// +optional
+	Rule *PrometheusRuleSpec `json:"rule,omitempty"`
+	// GrafanaDashboards creates a ConfigMap with Grafana dashboards for the cluster,
+	// to be loaded by the Grafana sidecar.
+	// +optional
+	GrafanaDashboards *GrafanaDashboardsSpec `json:"grafanaDashboards,omitempty"`
+}
+
+// StatsdSpec is the configuration for statsd reporter.
+type StatsdSpec struct {
+	// Address is the host:port of the statsd server.
+	Address string `json:"address"`
+	// Prefix is prepended to all metrics reported to statsd.
+	// +kubebuilder:default:=temporal
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+	// TagSeparator appends tags to the metric names using the provided separator,
+	// e.g. "," renders "name,tag=value" for statsd servers parsing Telegraf-style tags.
+	// If empty, tags are embedded in the metric names.
+	// +optional
+	TagSeparator string `json:"tagSeparator,omitempty"`
+	// FlushInterval is the maximum interval for sending packets.
+	// If empty, temporal defaults it to 1 second.
+	// +optional
+	FlushInterval *metav1.Duration `json:"flushInterval,omitempty"`
+	// FlushBytes is the maximum UDP packet size.
+	// If empty, temporal defaults it to 1432 bytes.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FlushBytes *int `json:"flushBytes,omitempty"`
 }
 
 // MetricsSpec determines parameters for configuring metrics endpoints.
@@ -783,9 +2300,18 @@ type MetricsSpec struct {
 	// Prefix sets the prefix to all outgoing metrics
 	// +optional
 	Prefix *string `json:"prefix,omitempty"`
+	// Tags are constant tags added to all outgoing metrics, e.g. env, region or team,
+	// to distinguish the series of multiple clusters.
+	// The "type" tag is reserved, it holds the name of the temporal service.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
 	// Prometheus reporter configuration.
 	// +optional
 	Prometheus *PrometheusSpec `json:"prometheus,omitempty"`
+	// Statsd reporter configuration.
+	// It can't be used with the prometheus reporter.
+	// +optional
+	Statsd *StatsdSpec `json:"statsd,omitempty"`
 }
 
 func (m *MetricsSpec) IsEnabled() bool {
@@ -824,13 +2350,25 @@ type ConstrainedValue struct {
 // DynamicConfigSpec is the configuration for temporal dynamic config.
 type DynamicConfigSpec struct {
 	// PollInterval defines how often the config should be updated by checking provided values.
-	// Defaults to 10s.
+	// Defaults to 10m.
 	// +optional
 	PollInterval *metav1.Duration `json:"pollInterval"`
 	// Values contains all dynamic config keys and their constrained values.
 	Values map[string][]ConstrainedValue `json:"values"`
 }
 
+// GetMountPath returns the path where the dynamic config configmap is mounted.
+// The configmap is mounted as a directory, so its updates are propagated to the pods
+// and reloaded by the services without restarts.
+func (DynamicConfigSpec) GetMountPath() string {
+	return "/etc/temporal/config/dynamicconfig"
+}
+
+// GetFilePath returns the path of the dynamic config file polled by the services.
+func (s DynamicConfigSpec) GetFilePath() string {
+	return path.Join(s.GetMountPath(), "dynamic_config.yaml")
+}
+
 // ClusterArchivalSpec is the configuration for cluster-wide archival config.
 type ClusterArchivalSpec struct {
 	// Enabled defines if the archival is enabled for the cluster.
@@ -902,8 +2440,9 @@ type ArchivalSpec struct {
 	// EnableRead allows temporal to read from the archived Event History.
 	// +kubebuilder:default:=false
 	EnableRead bool `json:"enableRead"`
-	// Path is ...
-	// +kubebuilder:validation:Required
+	// Path is the archival location, its format depends on the provider (e.g. the bucket name for s3).
+	// It is required at the cluster level. For a TemporalNamespace, it defaults to the cluster's path.
+	// +optional
 	Path string `json:"path"`
 }
 
@@ -930,17 +2469,75 @@ type AuthorizationSpec struct {
 	// +optional
 	PermissionsClaimName string `json:"permissionsClaimName"`
 
-	// Authorizer defines the authorization mechanism to be used. It can be left as an empty string to
-	// use a no-operation authorizer (noopAuthorizer), or set to "default" to use the temporal's default
-	// authorizer (defaultAuthorizer).
+	// Authorizer defines the authorization mechanism to be used. It can be left as an empty string or set
+	// to "noop" to use a no-operation authorizer (noopAuthorizer), or set to "default" to use the temporal's
+	// default authorizer (defaultAuthorizer).
+	// Any other value is passed as is to the server and requires a custom temporal server image
+	// providing its own authorizer.
 	// +optional
 	Authorizer string `json:"authorizer"`
 
 	// ClaimMapper specifies the claim mapping mechanism used for handling JWT claims. Similar to the Authorizer,
-	// it can be left as an empty string to use a no-operation claim mapper (noopClaimMapper), or set to "default"
-	// to use the default JWT claim mapper (defaultJWTClaimMapper).
+	// it can be left as an empty string or set to "noop" to use a no-operation claim mapper (noopClaimMapper),
+	// or set to "default" to use the default JWT claim mapper (defaultJWTClaimMapper).
+	// Any other value is passed as is to the server and requires a custom temporal server image
+	// providing its own claim mapper.
 	// +optional
 	ClaimMapper string `json:"claimMapper"`
+
+	// Plugin provides files and environment variables to a custom authorizer or claim mapper
+	// built into the temporal server image. They are added to the frontend pods.
+	// +optional
+	Plugin *AuthorizationPluginSpec `json:"plugin,omitempty"`
+
+	// AuthHeaderName is the name of the header containing the JWT token passed to the claim mapper.
+	// Defaults to "authorization".
+	// +optional
+	AuthHeaderName string `json:"authHeaderName,omitempty"`
+
+	// AuthExtraHeaderName is the name of the additional header passed to the claim mapper.
+	// Defaults to "authorization-extras".
+	// +optional
+	AuthExtraHeaderName string `json:"authExtraHeaderName,omitempty"`
+
+	// OperatorCredentials defines the credentials the operator uses to connect to the cluster's frontend,
+	// e.g. to reconcile namespaces when the frontend is protected by JWT authorization.
+	// +optional
+	OperatorCredentials *OperatorCredentialsSpec `json:"operatorCredentials,omitempty"`
+}
+
+// OperatorCredentialsSpec defines the credentials attached to the operator's requests to the frontend.
+type OperatorCredentialsSpec struct {
+	// TokenSecretRef references the secret key holding an API key or an OAuth/JWT access token.
+	// The token is sent as a bearer token in the authorization header (see authHeaderName) of each request.
+	// The secret is read at each reconciliation, so the token can be rotated by updating the secret.
+	TokenSecretRef *corev1.SecretKeySelector `json:"tokenSecretRef"`
+}
+
+const (
+	// NoopAuthorization is the name of the built-in no-operation authorizer and claim mapper.
+	NoopAuthorization = "noop"
+	// DefaultAuthorization is the name of the built-in default authorizer and claim mapper.
+	DefaultAuthorization = "default"
+)
+
+// IsBuiltin returns true if the provided authorizer or claim mapper name is provided by temporal.
+func (AuthorizationSpec) IsBuiltin(name string) bool {
+	return name == "" || name == NoopAuthorization || name == DefaultAuthorization
+}
+
+// AuthorizationPluginSpec defines the files and environment variables provided to
+// a custom authorizer or claim mapper.
+type AuthorizationPluginSpec struct {
+	// Volumes are added to the frontend pods, e.g. to provide the plugin configuration.
+	// +optional
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+	// VolumeMounts are added to the frontend container.
+	// +optional
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+	// Env are environment variables added to the frontend container.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
 }
 
 // AuthorizationSpecJWTKeyProvider defines the configuration for a JWT key provider within the AuthorizationSpec.
@@ -957,6 +2554,38 @@ type AuthorizationSpecJWTKeyProvider struct {
 	RefreshInterval *metav1.Duration `json:"refreshInterval"`
 }
 
+// NetworkPoliciesSpec defines the NetworkPolicies created for the cluster's components.
+// Temporal services only accept internode traffic from other temporal services,
+// the frontend accepts traffic from the UI and admin tools, and admin tools pods don't accept any traffic.
+type NetworkPoliciesSpec struct {
+	// Enabled defines if the operator should create NetworkPolicies.
+	// +optional
+	Enabled bool `json:"enabled"`
+	// FrontendIngressFrom lists the sources allowed to connect to the frontend, in addition to the UI and admin tools.
+	// The operator connects to the frontend to manage namespaces, remember to allow its namespace.
+	// If empty, the frontend accepts connections from anywhere.
+	// +optional
+	FrontendIngressFrom []networkingv1.NetworkPolicyPeer `json:"frontendIngressFrom,omitempty"`
+	// UIIngressFrom lists the sources allowed to connect to the UI.
+	// If empty, the UI accepts connections from anywhere.
+	// +optional
+	UIIngressFrom []networkingv1.NetworkPolicyPeer `json:"uiIngressFrom,omitempty"`
+	// MetricsIngressFrom lists the sources allowed to scrape temporal services metrics.
+	// If empty, metrics can be scraped from anywhere.
+	// +optional
+	MetricsIngressFrom []networkingv1.NetworkPolicyPeer `json:"metricsIngressFrom,omitempty"`
+	// Egress lists the destinations temporal services are allowed to connect to, in addition to
+	// other temporal services and DNS. Datastores should be listed here.
+	// If empty, temporal services egress traffic is not restricted.
+	// +optional
+	Egress []networkingv1.NetworkPolicyEgressRule `json:"egress,omitempty"`
+}
+
+// IsEnabled returns true if NetworkPolicies should be created.
+func (n *NetworkPoliciesSpec) IsEnabled() bool {
+	return n != nil && n.Enabled
+}
+
 // S3Archiver is the S3 archival provider configuration.
 type S3Archiver struct {
 	// Region is the aws s3 region.
@@ -988,15 +2617,84 @@ type S3Credentials struct {
 
 // GCSArchiver is the GCS archival provider configuration.
 type GCSArchiver struct {
-	// SecretAccessKeyRef is the secret key selector containing Google Cloud Storage credentials file.
-	// +kubebuilder:validation:Required
-	CredentialsRef *corev1.SecretKeySelector `json:"credentialsRef"`
+	// CredentialsRef is the secret key selector containing Google Cloud Storage credentials file.
+	// If not set, the application default credentials are used.
+	// +optional
+	CredentialsRef *corev1.SecretKeySelector `json:"credentialsRef,omitempty"`
+	// Use ServiceAccount if you want the temporal service account to impersonate
+	// a GCP service account using GKE workload identity.
+	// +optional
+	ServiceAccount *string `json:"serviceAccount,omitempty"`
 }
 
 func (GCSArchiver) CredentialsFileMountPath() string {
 	return "/etc/archival/credentials.json"
 }
 
+// LifecycleSpec defines jobs run by the operator on cluster lifecycle events.
+type LifecycleSpec struct {
+	// PreUpgrade hooks run, in order, when the cluster version changes. They must all complete
+	// successfully before the operator upgrades the persistence schemas and rolls out the new version.
+	// +optional
+	PreUpgrade []LifecycleHookSpec `json:"preUpgrade,omitempty"`
+}
+
+// LifecycleHookSpec defines a job run by the operator on a cluster lifecycle event.
+type LifecycleHookSpec struct {
+	// Name of the hook, used in the name of its jobs.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=20
+	Name string `json:"name"`
+	// Template is the pod template of the hook's job. The pod spec must define at least one container.
+	// The TEMPORAL_CURRENT_VERSION and TEMPORAL_TARGET_VERSION environment variables are added to its containers.
+	// The restart policy defaults to Never.
+	Template PodTemplateSpecOverride `json:"template"`
+	// BackoffLimit is the number of retries before the hook is considered as failed.
+	// Defaults to 0.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+	// ActiveDeadlineSeconds is the duration in seconds the hook's job may run before it's failed.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+}
+
+// UpgradeStrategySpec defines how the operator rolls out version changes.
+type UpgradeStrategySpec struct {
+	// Canary upgrades a single frontend replica first, and only upgrades the remaining
+	// replicas and the other services once it has been verified.
+	// +optional
+	Canary *CanaryUpgradeSpec `json:"canary,omitempty"`
+	// AutoRollback rolls the services back to the previous version when an upgrade fails its health gates.
+	// +optional
+	AutoRollback *AutoRollbackSpec `json:"autoRollback,omitempty"`
+}
+
+// AutoRollbackSpec configures automatic rollbacks of failed upgrades.
+type AutoRollbackSpec struct {
+	// ProgressDeadline is how long the services have to be ready and the frontend healthy
+	// with the new version before the upgrade is considered as failed.
+	// Defaults to 15m.
+	// +optional
+	ProgressDeadline *metav1.Duration `json:"progressDeadline,omitempty"`
+}
+
+// CanaryUpgradeSpec configures canary upgrades of the frontend service.
+type CanaryUpgradeSpec struct {
+	// SoakPeriod is how long the canary frontend has to stay healthy before the upgrade proceeds.
+	// Defaults to 5m.
+	// +optional
+	SoakPeriod *metav1.Duration `json:"soakPeriod,omitempty"`
+	// MaxErrorRatePercent is the maximum percentage of requests the canary frontend can fail
+	// during the soak period, computed from its service_errors and service_requests metrics.
+	// Requires prometheus metrics to be enabled on the frontend. The error rate isn't checked if not set.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxErrorRatePercent *int32 `json:"maxErrorRatePercent,omitempty"`
+}
+
 // TemporalClusterSpec defines the desired state of Cluster.
 type TemporalClusterSpec struct {
 	// Image defines the temporal server docker image the cluster should use for each services.
@@ -1006,6 +2704,17 @@ type TemporalClusterSpec struct {
 	// This version impacts the underlying persistence schemas versions.
 	// +optional
 	Version *version.Version `json:"version"`
+	// VersionPolicy defines how the operator updates the cluster version.
+	// With PinnedMinor, the cluster automatically runs the newest patch release of spec.version's minor version
+	// known by the operator. Defaults to Manual.
+	// +optional
+	VersionPolicy VersionPolicy `json:"versionPolicy,omitempty"`
+	// UpgradeStrategy defines how the operator rolls out version changes.
+	// +optional
+	UpgradeStrategy *UpgradeStrategySpec `json:"upgradeStrategy,omitempty"`
+	// Lifecycle defines jobs run by the operator on cluster lifecycle events.
+	// +optional
+	Lifecycle *LifecycleSpec `json:"lifecycle,omitempty"`
 	// Log defines temporal cluster's logger configuration.
 	// +optional
 	Log *LogSpec `json:"log,omitempty"`
@@ -1021,8 +2730,11 @@ type TemporalClusterSpec struct {
 	// JobInitContainers adds a list of init containers to the setup's jobs.
 	// +optional
 	JobInitContainers []corev1.Container `json:"jobInitContainers,omitempty"`
-	// NumHistoryShards is the desired number of history shards.
-	// This field is immutable.
+	// JobServiceAccount configures the service account used by setup/update jobs.
+	// +optional
+	JobServiceAccount *ServiceAccountSpec `json:"jobServiceAccount,omitempty"`
+	// NumHistoryShards is the desired number of history shards, a power of two is recommended.
+	// This field is immutable: the number of history shards can't be changed once the cluster is created.
 	//+kubebuilder:validation:Minimum=1
 	NumHistoryShards int32 `json:"numHistoryShards"`
 	// Services allows customizations for each temporal services deployment.
@@ -1046,15 +2758,138 @@ type TemporalClusterSpec struct {
 	// Metrics allows configuration of scraping endpoints for stats. prometheus or m3.
 	// +optional
 	Metrics *MetricsSpec `json:"metrics,omitempty"`
+	// Telemetry configures the export of temporal services telemetry, e.g. OpenTelemetry traces.
+	// +optional
+	Telemetry *TelemetrySpec `json:"telemetry,omitempty"`
+	// ClusterMetadata configures the temporal cluster name and failover versions.
+	// +optional
+	ClusterMetadata *ClusterMetadataSpec `json:"clusterMetadata,omitempty"`
+	// Replication configures the cross-cluster replication (XDC) with other temporal clusters,
+	// enabling global namespaces.
+	// +optional
+	Replication *ReplicationSpec `json:"replication,omitempty"`
+	// DCRedirectionPolicy defines how the frontend forwards the requests for global namespaces
+	// active in another cluster. Defaults to temporal's default policy, which doesn't forward requests.
+	// +optional
+	DCRedirectionPolicy DCRedirectionPolicy `json:"dcRedirectionPolicy,omitempty"`
+	// PublicClient configures how the history, matching and worker services connect to the frontend.
+	// Can't be set when the internal frontend is enabled.
+	// +optional
+	PublicClient *PublicClientSpec `json:"publicClient,omitempty"`
 	// DynamicConfig allows advanced configuration for the temporal cluster.
 	// +optional
 	DynamicConfig *DynamicConfigSpec `json:"dynamicConfig,omitempty"`
 	// Archival allows Workflow Execution Event Histories and Visibility data backups for the temporal cluster.
 	// +optional
 	Archival *ClusterArchivalSpec `json:"archival,omitempty"`
+	// DefaultNamespaces lists namespaces registered by the operator once the cluster is ready.
+	// They are only registered once: use TemporalNamespaces to manage namespaces settings over time.
+	// +optional
+	DefaultNamespaces []DefaultNamespaceSpec `json:"defaultNamespaces,omitempty"`
+	// NamespaceDefaults are settings inherited by the TemporalNamespaces referencing the cluster.
+	// TemporalNamespaces can override them.
+	// +optional
+	NamespaceDefaults *NamespaceDefaultsSpec `json:"namespaceDefaults,omitempty"`
 	// Authorization allows authorization configuration for the temporal cluster.
 	// +optional
 	Authorization *AuthorizationSpec `json:"authorization,omitempty"`
+	// NetworkPolicies allows creating NetworkPolicies restricting traffic to the cluster's components.
+	// +optional
+	NetworkPolicies *NetworkPoliciesSpec `json:"networkPolicies,omitempty"`
+	// Network configures the IP families used by the cluster, e.g. for IPv6-only or dual-stack clusters.
+	// +optional
+	Network *NetworkSpec `json:"network,omitempty"`
+	// DevMode allows running a lightweight cluster for CI and preview environments.
+	// +optional
+	DevMode *DevModeSpec `json:"devMode,omitempty"`
+	// Paused puts the cluster in maintenance mode.
+	// When true, the operator scales all temporal services down to zero, one service at a time
+	// (frontends first, history last), and stops running persistence jobs.
+	// Setting it back to false restores the services.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+	// MaintenanceWindow restricts disruptive changes (version upgrades, services restarts) to maintenance windows.
+	// Outside of a window, these changes are queued until the next window opens.
+	// +optional
+	MaintenanceWindow *MaintenanceWindowSpec `json:"maintenanceWindow,omitempty"`
+	// Adoption makes the operator take over the resources of an existing installation made with
+	// the official temporal helm chart, instead of creating new ones. It can't be changed once the cluster is created.
+	// +optional
+	Adoption *AdoptionSpec `json:"adoption,omitempty"`
+}
+
+// AdoptionSpec defines the helm release whose resources are adopted by the cluster.
+type AdoptionSpec struct {
+	// HelmRelease is the name of the helm release of the official temporal chart.
+	// +kubebuilder:validation:MinLength=1
+	HelmRelease string `json:"helmRelease"`
+	// ChartName is the name of the chart, as set by the chart's nameOverride value.
+	// Defaults to temporal.
+	// +optional
+	ChartName string `json:"chartName,omitempty"`
+}
+
+// HelmChartClusterName is the cluster name (currentClusterName) set by the official helm chart.
+// Its failover versions are the same as the operator's defaults.
+const HelmChartClusterName = "active"
+
+// GetChartName returns the name of the adopted chart, with its default value.
+func (s *AdoptionSpec) GetChartName() string {
+	if s.ChartName == "" {
+		return "temporal"
+	}
+	return s.ChartName
+}
+
+// HelmFullname returns the prefix of the resources created by the adopted helm release.
+// The cluster must be named after it, so the names of its resources match the adopted ones.
+func (s *AdoptionSpec) HelmFullname() string {
+	if strings.Contains(s.HelmRelease, s.GetChartName()) {
+		return s.HelmRelease
+	}
+	return fmt.Sprintf("%s-%s", s.HelmRelease, s.GetChartName())
+}
+
+// VersionPolicy defines how the operator updates the cluster version.
+// +kubebuilder:validation:Enum=Manual;PinnedMinor
+type VersionPolicy string
+
+const (
+	// ManualVersionPolicy runs the version set in spec.version.
+	ManualVersionPolicy VersionPolicy = "Manual"
+	// PinnedMinorVersionPolicy runs the newest patch release of spec.version's minor version.
+	PinnedMinorVersionPolicy VersionPolicy = "PinnedMinor"
+)
+
+// MaintenanceWindowSpec defines when disruptive changes can be rolled out.
+type MaintenanceWindowSpec struct {
+	// Schedule is a cron expression at which maintenance windows open.
+	// A time zone can be set using the CRON_TZ prefix, for instance "CRON_TZ=Europe/Paris 0 2 * * SAT".
+	Schedule string `json:"schedule"`
+	// Duration is how long each maintenance window stays open.
+	// Defaults to 2h.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// SQLiteDataMountPath is the path where the dev mode data volume is mounted.
+const SQLiteDataMountPath = "/var/lib/temporal/sqlite"
+
+// DevModeSpec defines a lightweight setup for ephemeral clusters.
+type DevModeSpec struct {
+	// Enabled runs all temporal services in a single frontend pod.
+	// Default and visibility stores default to SQLite databases if not set.
+	// This mode is not suitable for production.
+	Enabled bool `json:"enabled"`
+	// PersistentVolumeClaim references an existing claim used to store SQLite databases.
+	// If not set, databases are stored in an emptyDir volume and are lost when the pod restarts.
+	// +optional
+	PersistentVolumeClaim *corev1.PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
+}
+
+// IsEnabled returns true if dev mode is enabled.
+func (s *DevModeSpec) IsEnabled() bool {
+	return s != nil && s.Enabled
 }
 
 // ServiceStatus reports a service status.
@@ -1067,6 +2902,171 @@ type ServiceStatus struct {
 	Ready bool `json:"ready"`
 }
 
+// ClusterMetadataSpec configures the temporal cluster metadata.
+// Those values are persisted by temporal, they can't be changed once the cluster is created.
+type ClusterMetadataSpec struct {
+	// ClusterName is the temporal cluster name. It must be unique among the replicated clusters.
+	// Defaults to the TemporalCluster's name, or to the helm chart's cluster name ("active") when adopting a helm release.
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+	// InitialFailoverVersion of the cluster. It must be unique among the replicated clusters,
+	// and lower than or equal to failoverVersionIncrement.
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	InitialFailoverVersion int64 `json:"initialFailoverVersion,omitempty"`
+	// FailoverVersionIncrement must be the same on all the replicated clusters.
+	// +kubebuilder:default:=10
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailoverVersionIncrement int64 `json:"failoverVersionIncrement,omitempty"`
+	// EnableGlobalNamespace enables global namespaces. It's always enabled when replication is configured.
+	// It can't be disabled once enabled.
+	// +optional
+	EnableGlobalNamespace bool `json:"enableGlobalNamespace,omitempty"`
+}
+
+// DefaultNamespaceSpec is a namespace registered by the operator when the cluster is ready.
+type DefaultNamespaceSpec struct {
+	// Name of the namespace.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// RetentionPeriod to apply on closed workflow executions.
+	RetentionPeriod *metav1.Duration `json:"retentionPeriod"`
+}
+
+// NamespaceDefaultsSpec defines the settings inherited by the TemporalNamespaces referencing a cluster.
+type NamespaceDefaultsSpec struct {
+	// RetentionPeriod to apply on closed workflow executions, when not set on the namespace.
+	// +optional
+	RetentionPeriod *metav1.Duration `json:"retentionPeriod,omitempty"`
+	// Archival is the archival configuration of the namespaces.
+	// History and visibility archival are overridden independently by the namespaces archival.
+	// +optional
+	Archival *TemporalNamespaceArchivalSpec `json:"archival,omitempty"`
+	// SearchAttributes are custom search attributes added to every namespace, by name.
+	// A namespace can override the type of a search attribute.
+	// +optional
+	SearchAttributes map[string]SearchAttributeType `json:"searchAttributes,omitempty"`
+}
+
+// DCRedirectionPolicy is the frontend datacenter redirection policy.
+// +kubebuilder:validation:Enum=noop;selected-apis-forwarding;all-apis-forwarding
+type DCRedirectionPolicy string
+
+const (
+	// NoopDCRedirectionPolicy doesn't forward requests.
+	NoopDCRedirectionPolicy DCRedirectionPolicy = "noop"
+	// SelectedAPIsForwardingDCRedirectionPolicy forwards the requests of a selected set of APIs to the active cluster.
+	SelectedAPIsForwardingDCRedirectionPolicy DCRedirectionPolicy = "selected-apis-forwarding"
+	// AllAPIsForwardingDCRedirectionPolicy forwards the requests of all APIs to the active cluster.
+	AllAPIsForwardingDCRedirectionPolicy DCRedirectionPolicy = "all-apis-forwarding"
+)
+
+// PublicClientSpec configures how the temporal services connect to the frontend.
+type PublicClientSpec struct {
+	// HostPort is the host:port address of the frontend, overriding the frontend service address.
+	HostPort string `json:"hostPort"`
+}
+
+// ReplicationSpec configures the cross-cluster replication (XDC) of a temporal cluster.
+// The cluster metadata of the replicated clusters is configured using spec.clusterMetadata.
+type ReplicationSpec struct {
+	// RemoteClusters lists the clusters this cluster replicates namespaces with.
+	// Replication must be configured on both sides.
+	// +optional
+	RemoteClusters []RemoteClusterSpec `json:"remoteClusters,omitempty"`
+}
+
+// RemoteClusterSpec references a remote temporal cluster.
+type RemoteClusterSpec struct {
+	// ClusterRef references a TemporalCluster managed by the operator in the same Kubernetes cluster.
+	// +optional
+	ClusterRef *TemporalClusterReference `json:"clusterRef,omitempty"`
+	// Address is the host:port address of the remote cluster's frontend.
+	// Required when clusterRef is not set.
+	// +optional
+	Address string `json:"address,omitempty"`
+	// ConnectionEnabled enables the connection to the remote cluster. Defaults to true.
+	// +optional
+	ConnectionEnabled *bool `json:"connectionEnabled,omitempty"`
+	// TLS configures the connection of the cluster's services to the remote cluster's frontend.
+	// When not set for a remote cluster referenced using clusterRef, and both clusters use frontend mTLS issued by cert-manager,
+	// the clusters trust each other's CA automatically.
+	// +optional
+	TLS *RemoteClusterTLSSpec `json:"tls,omitempty"`
+}
+
+// GetTrustBundleMountPath returns the path where the CA bundle of the remote clusters is mounted.
+func (ReplicationSpec) GetTrustBundleMountPath() string {
+	return "/etc/temporal/config/certs/remote-clusters"
+}
+
+// RemoteClusterTLSSpec configures the TLS connection to a remote cluster.
+type RemoteClusterTLSSpec struct {
+	// SecretRef is a secret holding the "ca.crt" of the remote cluster's frontend,
+	// and the "tls.crt" and "tls.key" client certificate.
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+	// ServerName overrides the server name used to verify the remote cluster's frontend certificate.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+}
+
+// IsConnectionEnabled returns true if the connection to the remote cluster is enabled.
+func (s *RemoteClusterSpec) IsConnectionEnabled() bool {
+	return s.ConnectionEnabled == nil || *s.ConnectionEnabled
+}
+
+// Host returns the host of the remote cluster's frontend.
+// References are resolved in the provided namespace if they don't set one.
+func (s *RemoteClusterSpec) Host(namespace string) string {
+	if s.ClusterRef != nil {
+		if s.ClusterRef.Namespace != "" {
+			namespace = s.ClusterRef.Namespace
+		}
+		return fmt.Sprintf("%s-frontend.%s", s.ClusterRef.Name, namespace)
+	}
+	host, _, err := net.SplitHostPort(s.Address)
+	if err != nil {
+		return s.Address
+	}
+	return host
+}
+
+// UsesTrustBundle returns true if the connection to the remote cluster is secured using
+// the CA bundle of the remote clusters managed by the operator.
+func (s *RemoteClusterSpec) UsesTrustBundle() bool {
+	return s.ClusterRef != nil && s.TLS == nil
+}
+
+// ServerName returns the server name of the remote cluster's frontend certificate
+// issued by cert-manager, for remote clusters referenced using clusterRef.
+func (s *RemoteClusterSpec) ServerName(namespace string) string {
+	return fmt.Sprintf("%s.svc.cluster.local", s.Host(namespace))
+}
+
+// GetCertificateMountPath returns the path where the remote cluster's TLS secret is mounted.
+func (s *RemoteClusterTLSSpec) GetCertificateMountPath() string {
+	return path.Join("/etc/tls/remote-clusters", s.SecretRef.Name)
+}
+
+// RemoteClusterStatus reports a remote cluster registered by the operator.
+type RemoteClusterStatus struct {
+	// Name is the temporal cluster name of the remote cluster.
+	Name string `json:"name"`
+	// Address is the address of the remote cluster's frontend.
+	Address string `json:"address"`
+}
+
+// CertificateStatus reports the expiry of a certificate used by the cluster.
+type CertificateStatus struct {
+	// SecretName is the name of the secret holding the certificate.
+	SecretName string `json:"secretName"`
+	// NotAfter is the expiration time of the certificate.
+	NotAfter metav1.Time `json:"notAfter"`
+}
+
 // DatastoreStatus contains the current status of a datastore.
 type DatastoreStatus struct {
 	// Created indicates if the database or keyspace has been created.
@@ -1079,6 +3079,12 @@ type DatastoreStatus struct {
 	// SchemaVersion report the current schema version.
 	// +optional
 	SchemaVersion *version.Version `json:"schemaVersion,omitempty"`
+	// Dropped indicates if the datastore has been dropped on cluster deletion.
+	// +optional
+	Dropped bool `json:"dropped,omitempty"`
+	// ILMPolicyHash is the hash of the last elasticsearch index lifecycle policy applied to the datastore.
+	// +optional
+	ILMPolicyHash string `json:"ilmPolicyHash,omitempty"`
 }
 
 // TemporalPersistenceStatus contains temporal persistence status.
@@ -1103,10 +3109,123 @@ type TemporalClusterStatus struct {
 	Services []ServiceStatus `json:"services,omitempty"`
 	// Persistence holds all datastores statuses.
 	Persistence *TemporalPersistenceStatus `json:"persistence,omitempty"`
+	// NumHistoryShards is the number of history shards the cluster was created with.
+	// +optional
+	NumHistoryShards int32 `json:"numHistoryShards,omitempty"`
+	// Certificates holds the expiry of the cluster's mTLS certificates.
+	// +optional
+	Certificates []CertificateStatus `json:"certificates,omitempty"`
+	// RemoteClusters holds the remote clusters registered by the operator.
+	// +optional
+	RemoteClusters []RemoteClusterStatus `json:"remoteClusters,omitempty"`
+	// DefaultNamespaces holds the names of the default namespaces registered by the operator.
+	// +optional
+	DefaultNamespaces []string `json:"defaultNamespaces,omitempty"`
+	// Upgrade holds the progress of the running multi-minor version upgrade.
+	// +optional
+	Upgrade *VersionUpgradeStatus `json:"upgrade,omitempty"`
+	// Canary holds the state of the running frontend canary upgrade.
+	// +optional
+	Canary *CanaryStatus `json:"canary,omitempty"`
+	// Rollout holds the state of the running version rollout, when automatic rollbacks are enabled.
+	// +optional
+	Rollout *VersionRolloutStatus `json:"rollout,omitempty"`
+	// PreUpgradeHooks holds the pre-upgrade hooks completed for the version being rolled out.
+	// +optional
+	PreUpgradeHooks *LifecycleHooksStatus `json:"preUpgradeHooks,omitempty"`
+	// AutoUpgradeVersion is the patch release the cluster is automatically upgraded to by the PinnedMinor version policy.
+	// +optional
+	AutoUpgradeVersion string `json:"autoUpgradeVersion,omitempty"`
+	// MaintenanceWindow reports the state of the cluster's maintenance window and the changes waiting for it.
+	// +optional
+	MaintenanceWindow *MaintenanceWindowStatus `json:"maintenanceWindow,omitempty"`
 	// Conditions represent the latest available observations of the Cluster state.
 	Conditions []metav1.Condition `json:"conditions"`
 }
 
+// VersionUpgradeStatus reports the progress of an upgrade spanning several minor versions.
+// The cluster is upgraded one minor version at a time: each step runs the schema upgrades,
+// rolls out the services and waits for them to be ready before moving to the next step.
+type VersionUpgradeStatus struct {
+	// TargetVersion is the version requested in spec.version.
+	TargetVersion string `json:"targetVersion"`
+	// CurrentStep is the version the cluster is currently upgraded to.
+	CurrentStep string `json:"currentStep"`
+	// CompletedSteps are the intermediate versions the cluster has been upgraded to.
+	// +optional
+	CompletedSteps []string `json:"completedSteps,omitempty"`
+	// RemainingSteps are the versions to upgrade the cluster to after the current step.
+	// +optional
+	RemainingSteps []string `json:"remainingSteps,omitempty"`
+}
+
+// CanaryPhase is the phase of a frontend canary upgrade.
+// +kubebuilder:validation:Enum=Progressing;Soaking;Succeeded;Failed
+type CanaryPhase string
+
+const (
+	// CanaryProgressingPhase means the canary frontend is being rolled out.
+	CanaryProgressingPhase CanaryPhase = "Progressing"
+	// CanarySoakingPhase means the canary frontend is healthy and verified during the soak period.
+	CanarySoakingPhase CanaryPhase = "Soaking"
+	// CanarySucceededPhase means the canary frontend has been verified, the upgrade proceeds.
+	CanarySucceededPhase CanaryPhase = "Succeeded"
+	// CanaryFailedPhase means the canary frontend failed its verification, the upgrade is stopped.
+	CanaryFailedPhase CanaryPhase = "Failed"
+)
+
+// CanaryStatus reports the state of a frontend canary upgrade.
+type CanaryStatus struct {
+	// Version is the version the canary frontend runs.
+	Version string `json:"version"`
+	// Phase is the phase of the canary upgrade.
+	Phase CanaryPhase `json:"phase"`
+	// StartTime is when the canary frontend was created.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// SoakStartTime is when the canary frontend became healthy.
+	// +optional
+	SoakStartTime *metav1.Time `json:"soakStartTime,omitempty"`
+	// Message explains why the canary failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// VersionRolloutStatus reports the state of a version rollout watched for automatic rollbacks.
+type VersionRolloutStatus struct {
+	// Version is the version being rolled out.
+	Version string `json:"version"`
+	// StartTime is when the services started to be upgraded to the version.
+	StartTime metav1.Time `json:"startTime"`
+	// RolledBack is true if the rollout failed and the services were rolled back to the previous version.
+	// +optional
+	RolledBack bool `json:"rolledBack,omitempty"`
+	// Message explains why the rollout failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// LifecycleHooksStatus reports the lifecycle hooks completed for a version.
+type LifecycleHooksStatus struct {
+	// Version is the version the hooks ran for.
+	Version string `json:"version"`
+	// Completed holds the names of the hooks which completed successfully.
+	// +optional
+	Completed []string `json:"completed,omitempty"`
+}
+
+// MaintenanceWindowStatus reports the state of a cluster's maintenance window.
+type MaintenanceWindowStatus struct {
+	// Open is true while the maintenance window is open.
+	Open bool `json:"open"`
+	// NextWindowStart is the time at which the next maintenance window opens.
+	// +optional
+	NextWindowStart *metav1.Time `json:"nextWindowStart,omitempty"`
+	// Pending holds the disruptive changes queued until the next maintenance window.
+	// +optional
+	Pending []string `json:"pending,omitempty"`
+}
+
 // AddServiceStatus adds the provided service status to the cluster's status.
 func (s *TemporalClusterStatus) AddServiceStatus(status *ServiceStatus) {
 	found := false
@@ -1143,13 +3262,108 @@ type TemporalCluster struct {
 	Status TemporalClusterStatus `json:"status,omitempty"`
 }
 
+// CodecServer returns the codec server spec, if any.
+func (c *TemporalCluster) CodecServer() *CodecServerSpec {
+	if c.Spec.UI == nil {
+		return nil
+	}
+	return c.Spec.UI.CodecServer
+}
+
 func (c *TemporalCluster) SelectorLabels() map[string]string {
+	// Selectors of deployments can't be changed: adopted resources keep the helm chart's selector labels.
+	if c.Spec.Adoption != nil {
+		return map[string]string{
+			"app.kubernetes.io/name":     c.Spec.Adoption.GetChartName(),
+			"app.kubernetes.io/instance": c.Spec.Adoption.HelmRelease,
+		}
+	}
 	return map[string]string{
 		"app.kubernetes.io/name":    c.GetName(),
 		"app.kubernetes.io/part-of": "temporal",
 	}
 }
 
+// GetClusterMetadata returns the temporal cluster metadata of the cluster, with default values.
+// Adopted clusters default to the helm chart's cluster metadata, as namespaces record the name of their active cluster.
+func (c *TemporalCluster) GetClusterMetadata() ClusterMetadataSpec {
+	metadata := ClusterMetadataSpec{
+		ClusterName:              c.Name,
+		InitialFailoverVersion:   1,
+		FailoverVersionIncrement: 10,
+	}
+	if c.Spec.Adoption != nil {
+		metadata.ClusterName = HelmChartClusterName
+	}
+	if spec := c.Spec.ClusterMetadata; spec != nil {
+		if spec.ClusterName != "" {
+			metadata.ClusterName = spec.ClusterName
+		}
+		if spec.InitialFailoverVersion != 0 {
+			metadata.InitialFailoverVersion = spec.InitialFailoverVersion
+		}
+		if spec.FailoverVersionIncrement != 0 {
+			metadata.FailoverVersionIncrement = spec.FailoverVersionIncrement
+		}
+		metadata.EnableGlobalNamespace = spec.EnableGlobalNamespace
+	}
+	metadata.EnableGlobalNamespace = metadata.EnableGlobalNamespace || c.ReplicationEnabled()
+	return metadata
+}
+
+// ReplicationEnabled returns true if the cluster replicates namespaces with remote clusters.
+func (c *TemporalCluster) ReplicationEnabled() bool {
+	return c.Spec.Replication != nil
+}
+
+// RemoteClustersTrustBundleEnabled returns true if the cluster trusts the CA of the remote clusters referenced using clusterRef.
+// It requires the frontend mTLS to be issued by cert-manager.
+func (c *TemporalCluster) RemoteClustersTrustBundleEnabled() bool {
+	if !c.ReplicationEnabled() || !c.MTLSWithCertManagerEnabled() || !c.Spec.MTLS.FrontendEnabled() {
+		return false
+	}
+	for _, remote := range c.Spec.Replication.RemoteClusters {
+		if remote.UsesTrustBundle() {
+			return true
+		}
+	}
+	return false
+}
+
+// LoopbackAddress returns the loopback address temporal services can listen on.
+func (c *TemporalCluster) LoopbackAddress() string {
+	if c.Spec.Network.IPv6Enabled() {
+		return "::1"
+	}
+	return "127.0.0.1"
+}
+
+// ServiceMetricsPort returns the port the provided service exposes its prometheus metrics on.
+// It returns nil if the service's metrics are not exposed.
+func (c *TemporalCluster) ServiceMetricsPort(service *ServiceSpec) *int32 {
+	if !c.Spec.Metrics.IsEnabled() || c.Spec.Metrics.Prometheus == nil || c.Spec.Metrics.Prometheus.ListenPort == nil {
+		return nil
+	}
+	if service == nil || service.Metrics == nil {
+		return c.Spec.Metrics.Prometheus.ListenPort
+	}
+	if service.Metrics.Enabled != nil && !*service.Metrics.Enabled {
+		return nil
+	}
+	if service.Metrics.Port != nil {
+		return service.Metrics.Port
+	}
+	return c.Spec.Metrics.Prometheus.ListenPort
+}
+
+// BindAddress returns the address temporal services listen on.
+func (c *TemporalCluster) BindAddress() string {
+	if c.Spec.Network.IPv6Enabled() {
+		return "::"
+	}
+	return "0.0.0.0"
+}
+
 // ServerName returns cluster's server name.
 func (c *TemporalCluster) ServerName() string {
 	return fmt.Sprintf("%s.%s", c.Name, c.FQDNSuffix())
@@ -1160,13 +3374,62 @@ func (c *TemporalCluster) FQDNSuffix() string {
 	return fmt.Sprintf("%s.svc.cluster.local", c.Namespace)
 }
 
-// MTLSEnabled returns true if mTLS is enabled for internode or frontend using cert-manager.
+// MTLSWithCertManagerEnabled returns true if mTLS is enabled for internode or frontend using cert-manager.
 func (c *TemporalCluster) MTLSWithCertManagerEnabled() bool {
 	return c.Spec.MTLS != nil &&
 		(c.Spec.MTLS.InternodeEnabled() || c.Spec.MTLS.FrontendEnabled()) &&
 		c.Spec.MTLS.Provider == CertManagerMTLSProvider
 }
 
+// MTLSWithSecretsEnabled returns true if mTLS is enabled for internode or frontend using user-provided secrets.
+func (c *TemporalCluster) MTLSWithSecretsEnabled() bool {
+	return c.Spec.MTLS != nil &&
+		(c.Spec.MTLS.InternodeEnabled() || c.Spec.MTLS.FrontendEnabled()) &&
+		c.Spec.MTLS.Provider == SecretsMTLSProvider
+}
+
+// ImageDigestsPinned returns true if one of the temporal server or admin tools images is pinned to a digest.
+// Pinned images can't follow the intermediate versions of a multi-minor upgrade.
+func (c *TemporalCluster) ImageDigestsPinned() bool {
+	if c.Spec.AdminTools != nil && c.Spec.AdminTools.ImageDigest != "" {
+		return true
+	}
+	if c.Spec.Services == nil {
+		return false
+	}
+	for _, service := range c.Spec.Services.GetServiceSpecsMap() {
+		if service.ImageDigest != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// MTLSWithVaultEnabled returns true if mTLS is enabled for internode or frontend using Vault.
+func (c *TemporalCluster) MTLSWithVaultEnabled() bool {
+	return c.Spec.MTLS != nil &&
+		(c.Spec.MTLS.InternodeEnabled() || c.Spec.MTLS.FrontendEnabled()) &&
+		c.Spec.MTLS.Provider == VaultMTLSProvider
+}
+
+// MTLSWithSPIFFEEnabled returns true if mTLS is enabled for internode or frontend using SPIFFE SVIDs.
+func (c *TemporalCluster) MTLSWithSPIFFEEnabled() bool {
+	return c.Spec.MTLS != nil &&
+		(c.Spec.MTLS.InternodeEnabled() || c.Spec.MTLS.FrontendEnabled()) &&
+		c.Spec.MTLS.Provider == SPIFFEMTLSProvider
+}
+
+// MTLSWithCertificatesEnabled returns true if mTLS is enabled for internode or frontend
+// using certificates stored in secrets (cert-manager, user-provided secrets or vault).
+func (c *TemporalCluster) MTLSWithCertificatesEnabled() bool {
+	return c.MTLSWithCertManagerEnabled() || c.MTLSWithSecretsEnabled() || c.MTLSWithVaultEnabled()
+}
+
+// MTLSWithHostVerification returns true if the cluster components should verify the mTLS server names.
+func (c *TemporalCluster) MTLSWithHostVerification() bool {
+	return !(c.MTLSWithSPIFFEEnabled() && c.Spec.MTLS.SPIFFE != nil && c.Spec.MTLS.SPIFFE.DisableHostVerification)
+}
+
 // ChildResourceName returns child resource name using the cluster's name.
 func (c *TemporalCluster) ChildResourceName(resource string) string {
 	return fmt.Sprintf("%s-%s", c.Name, resource)
@@ -1176,6 +3439,11 @@ func (c *TemporalCluster) GetPublicClientAddress() string {
 	return fmt.Sprintf("%s.%s:%d", c.ChildResourceName("frontend"), c.GetNamespace(), *c.Spec.Services.Frontend.Port)
 }
 
+// IsPaused returns true if the TemporalCluster is in maintenance mode.
+func (c *TemporalCluster) IsPaused() bool {
+	return c.Spec.Paused
+}
+
 // IsReady returns true if the TemporalCluster's conditions reports it ready.
 func (c *TemporalCluster) IsReady() bool {
 	for _, condition := range c.Status.Conditions {
@@ -11,10 +11,37 @@ func (m *MTLSSpec) Validate() (admission.Warnings, field.ErrorList) {
 	var warns admission.Warnings
 	var errs field.ErrorList
 
-	if m == nil || m.Provider != CertManagerMTLSProvider {
+	if m == nil {
 		return nil, nil
 	}
 
+	if m.FrontendEnabled() && m.Frontend.External != nil && m.Provider != CertManagerMTLSProvider {
+		errs = append(errs, field.Forbidden(field.NewPath("spec.mTLS.frontend.external"), "only supported when using cert-manager as mTLS provider"))
+	}
+
+	if m.FrontendEnabled() && m.Frontend.External != nil && len(m.Frontend.External.DNSNames) == 0 {
+		errs = append(errs, field.Required(field.NewPath("spec.mTLS.frontend.external.dnsNames"), "at least one external hostname should be provided"))
+	}
+
+	if m.Provider == SecretsMTLSProvider {
+		if m.InternodeEnabled() && m.Internode.SecretRef == nil {
+			errs = append(errs, field.Required(field.NewPath("spec.mTLS.internode.secretRef"), "must be set when using secrets as mTLS provider"))
+		}
+		if m.FrontendEnabled() && m.Frontend.SecretRef == nil {
+			errs = append(errs, field.Required(field.NewPath("spec.mTLS.frontend.secretRef"), "must be set when using secrets as mTLS provider"))
+		}
+		if m.FrontendEnabled() && m.Frontend.ClientSecretRef == nil {
+			errs = append(errs, field.Required(field.NewPath("spec.mTLS.frontend.clientSecretRef"), "must be set when using secrets as mTLS provider"))
+		}
+		return warns, errs
+	}
+
+	if m.Provider == VaultMTLSProvider {
+		errs = append(errs, m.Vault.validate()...)
+	} else if m.Provider != CertManagerMTLSProvider {
+		return warns, errs
+	}
+
 	if m.RenewBefore != nil {
 		if m.RenewBefore.Duration < 5*time.Minute {
 			errs = append(errs, field.Invalid(field.NewPath("spec.mTLS.renewBefore"), m.RenewBefore, "must be at least 5 minutes"))
@@ -23,3 +50,26 @@ func (m *MTLSSpec) Validate() (admission.Warnings, field.ErrorList) {
 
 	return warns, errs
 }
+
+func (v *VaultMTLSSpec) validate() field.ErrorList {
+	var errs field.ErrorList
+
+	path := field.NewPath("spec.mTLS.vault")
+	if v == nil {
+		return append(errs, field.Required(path, "must be set when using vault as mTLS provider"))
+	}
+
+	if v.Address == "" {
+		errs = append(errs, field.Required(path.Child("address"), "must be set when using vault as mTLS provider"))
+	}
+
+	if v.Role == "" {
+		errs = append(errs, field.Required(path.Child("role"), "must be set when using vault as mTLS provider"))
+	}
+
+	if (v.Auth.Kubernetes == nil) == (v.Auth.TokenSecretRef == nil) {
+		errs = append(errs, field.Invalid(path.Child("auth"), v.Auth, "exactly one auth method should be provided"))
+	}
+
+	return errs
+}
@@ -0,0 +1,103 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TemporalFailoverSpec defines the desired state of TemporalFailover.
+type TemporalFailoverSpec struct {
+	// Reference to the temporal cluster the namespaces are failed over to.
+	ClusterRef TemporalClusterReference `json:"clusterRef"`
+	// Reference to the temporal cluster currently active for the namespaces.
+	// Both clusters must replicate each other.
+	SourceClusterRef TemporalClusterReference `json:"sourceClusterRef"`
+	// Namespaces is the list of global namespaces to fail over.
+	// +kubebuilder:validation:MinItems=1
+	Namespaces []string `json:"namespaces"`
+}
+
+// TemporalFailoverNamespaceStatus is the failover status of a namespace.
+type TemporalFailoverNamespaceStatus struct {
+	// Name of the namespace.
+	Name string `json:"name"`
+	// PreviousActiveCluster is the name of the temporal cluster which was active before the failover.
+	PreviousActiveCluster string `json:"previousActiveCluster"`
+	// FailoverVersion is the namespace failover version after the failover.
+	// +optional
+	FailoverVersion int64 `json:"failoverVersion,omitempty"`
+	// Verified is true once both clusters report the target cluster as active for the namespace.
+	// +optional
+	Verified bool `json:"verified,omitempty"`
+}
+
+// TemporalFailoverStatus defines the observed state of TemporalFailover.
+type TemporalFailoverStatus struct {
+	// Conditions represent the latest available observations of the failover state.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Namespaces reports the failover status of each namespace.
+	// +optional
+	Namespaces []TemporalFailoverNamespaceStatus `json:"namespaces,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Source",type="string",JSONPath=".spec.sourceClusterRef.name"
+// +kubebuilder:printcolumn:name="Target",type="string",JSONPath=".spec.clusterRef.name"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type == 'Ready')].status"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type == 'Ready')].reason"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// A TemporalFailover fails over global namespaces from a temporal cluster to another one replicating it.
+type TemporalFailover struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TemporalFailoverSpec   `json:"spec,omitempty"`
+	Status TemporalFailoverStatus `json:"status,omitempty"`
+}
+
+// IsCompleted returns true if the failover has finished, successfully or not.
+func (f *TemporalFailover) IsCompleted() bool {
+	for _, condition := range f.Status.Conditions {
+		if condition.Type == ReadyCondition && (condition.Reason == FailoverSucceededReason || condition.Reason == FailoverFailedReason) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsStarted returns true if the namespaces active cluster has been changed.
+func (f *TemporalFailover) IsStarted() bool {
+	return len(f.Status.Namespaces) > 0
+}
+
+//+kubebuilder:object:root=true
+
+// TemporalFailoverList contains a list of TemporalFailover.
+type TemporalFailoverList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TemporalFailover `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TemporalFailover{}, &TemporalFailoverList{})
+}
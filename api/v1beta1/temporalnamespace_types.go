@@ -31,6 +31,20 @@ type TemporalNamespaceArchivalSpec struct {
 	Visibility *ArchivalSpec `json:"visibility,omitempty"`
 }
 
+// SearchAttributeType is the type of a custom search attribute.
+// +kubebuilder:validation:Enum=Text;Keyword;Int;Double;Bool;Datetime;KeywordList
+type SearchAttributeType string
+
+const (
+	SearchAttributeTypeText        SearchAttributeType = "Text"
+	SearchAttributeTypeKeyword     SearchAttributeType = "Keyword"
+	SearchAttributeTypeInt         SearchAttributeType = "Int"
+	SearchAttributeTypeDouble      SearchAttributeType = "Double"
+	SearchAttributeTypeBool        SearchAttributeType = "Bool"
+	SearchAttributeTypeDatetime    SearchAttributeType = "Datetime"
+	SearchAttributeTypeKeywordList SearchAttributeType = "KeywordList"
+)
+
 // TemporalNamespaceSpec defines the desired state of Namespace.
 type TemporalNamespaceSpec struct {
 	// Reference to the temporal cluster the namespace will be created.
@@ -42,7 +56,9 @@ type TemporalNamespaceSpec struct {
 	// +optional
 	OwnerEmail string `json:"ownerEmail,omitempty"`
 	// RetentionPeriod to apply on closed workflow executions.
-	RetentionPeriod *metav1.Duration `json:"retentionPeriod"`
+	// Required if the referenced cluster doesn't set a default retention period in spec.namespaceDefaults.
+	// +optional
+	RetentionPeriod *metav1.Duration `json:"retentionPeriod,omitempty"`
 	// Data is a key-value map for any customized purpose.
 	// +optional
 	Data map[string]string `json:"data,omitempty"`
@@ -67,6 +83,11 @@ type TemporalNamespaceSpec struct {
 	// If not set, the default cluster configuration is used.
 	// +optional
 	Archival *TemporalNamespaceArchivalSpec `json:"archival,omitempty"`
+	// SearchAttributes are custom search attributes added to the namespace, by name.
+	// They are merged with the search attributes of the referenced cluster's spec.namespaceDefaults.
+	// Search attributes are never removed by the operator.
+	// +optional
+	SearchAttributes map[string]SearchAttributeType `json:"searchAttributes,omitempty"`
 }
 
 // TemporalNamespaceStatus defines the observed state of Namespace.
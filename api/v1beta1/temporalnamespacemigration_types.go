@@ -0,0 +1,98 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TemporalNamespaceMigrationSpec defines the desired state of TemporalNamespaceMigration.
+type TemporalNamespaceMigrationSpec struct {
+	// NamespaceRef is the TemporalNamespace, in the same namespace, to migrate.
+	NamespaceRef corev1.LocalObjectReference `json:"namespaceRef"`
+	// Reference to the temporal cluster the namespace is migrated to.
+	ClusterRef TemporalClusterReference `json:"clusterRef"`
+	// Replicate replicates the namespace from its current cluster to the target cluster
+	// and fails it over to the target cluster, instead of creating a new namespace in the target cluster.
+	// Both clusters must be connected using spec.replication. The namespace is promoted to a global namespace
+	// if needed, and the source cluster is removed from its replication clusters once it is migrated.
+	// +optional
+	Replicate bool `json:"replicate,omitempty"`
+}
+
+// TemporalNamespaceMigrationStatus defines the observed state of TemporalNamespaceMigration.
+type TemporalNamespaceMigrationStatus struct {
+	// Conditions represent the latest available observations of the migration state.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// SourceClusterRef is the temporal cluster the namespace is migrated from,
+	// recorded when the migration starts.
+	// +optional
+	SourceClusterRef *TemporalClusterReference `json:"sourceClusterRef,omitempty"`
+	// RemoteClustersAdded lists the clusters the migration added the other cluster to the remote clusters of,
+	// to replicate the namespace. They are removed from the remote clusters once the namespace is migrated.
+	// +optional
+	RemoteClustersAdded []TemporalClusterReference `json:"remoteClustersAdded,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Namespace",type="string",JSONPath=".spec.namespaceRef.name"
+// +kubebuilder:printcolumn:name="Source",type="string",JSONPath=".status.sourceClusterRef.name"
+// +kubebuilder:printcolumn:name="Target",type="string",JSONPath=".spec.clusterRef.name"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type == 'Ready')].status"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type == 'Ready')].reason"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// A TemporalNamespaceMigration migrates the namespace managed by a TemporalNamespace to another temporal cluster.
+type TemporalNamespaceMigration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TemporalNamespaceMigrationSpec   `json:"spec,omitempty"`
+	Status TemporalNamespaceMigrationStatus `json:"status,omitempty"`
+}
+
+// IsCompleted returns true if the migration has finished, successfully or not.
+func (m *TemporalNamespaceMigration) IsCompleted() bool {
+	for _, condition := range m.Status.Conditions {
+		if condition.Type == ReadyCondition && (condition.Reason == NamespaceMigrationSucceededReason || condition.Reason == NamespaceMigrationFailedReason) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsStarted returns true if the source cluster has been recorded.
+func (m *TemporalNamespaceMigration) IsStarted() bool {
+	return m.Status.SourceClusterRef != nil
+}
+
+//+kubebuilder:object:root=true
+
+// TemporalNamespaceMigrationList contains a list of TemporalNamespaceMigration.
+type TemporalNamespaceMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TemporalNamespaceMigration `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TemporalNamespaceMigration{}, &TemporalNamespaceMigrationList{})
+}
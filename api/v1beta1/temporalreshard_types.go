@@ -0,0 +1,125 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TemporalReshardSpec defines the desired state of TemporalReshard.
+type TemporalReshardSpec struct {
+	// Reference to the temporal cluster currently serving the namespaces.
+	ClusterRef TemporalClusterReference `json:"clusterRef"`
+	// Reference to the temporal cluster created with the new number of history shards.
+	// The number of history shards of one cluster must be a multiple of the other's.
+	// The cluster is created from the source cluster spec if it doesn't exist and spec.target is set.
+	TargetClusterRef TemporalClusterReference `json:"targetClusterRef"`
+	// Target configures the target cluster created by the reshard.
+	// +optional
+	Target *TemporalReshardTargetSpec `json:"target,omitempty"`
+	// Namespaces is the list of namespaces to move to the target cluster.
+	// +kubebuilder:validation:MinItems=1
+	Namespaces []string `json:"namespaces"`
+	// CutOver fails the namespaces over to the target cluster once their workflows are replicated.
+	// +optional
+	CutOver bool `json:"cutOver,omitempty"`
+}
+
+// TemporalReshardTargetSpec configures the target cluster of a reshard.
+// The target cluster is created with the source cluster spec, overridden by these fields.
+type TemporalReshardTargetSpec struct {
+	// NumHistoryShards is the number of history shards of the target cluster.
+	//+kubebuilder:validation:Minimum=1
+	NumHistoryShards int32 `json:"numHistoryShards"`
+	// Persistence defines the target cluster persistence configuration.
+	// The target cluster can't use the datastores of the source cluster.
+	Persistence TemporalPersistenceSpec `json:"persistence"`
+	// ClusterMetadata configures the target cluster name and failover versions.
+	// The cluster name defaults to the target cluster name, and the initial failover version to the one following the source cluster's.
+	// +optional
+	ClusterMetadata *ClusterMetadataSpec `json:"clusterMetadata,omitempty"`
+}
+
+// TemporalReshardPhase is the current phase of a reshard.
+// +kubebuilder:validation:Enum=ConfiguringReplication;ReplicatingNamespaces;WaitingForCutOver;CuttingOver;Completed;Failed
+type TemporalReshardPhase string
+
+const (
+	// ReshardPhaseConfiguringReplication is the phase where the clusters are configured to replicate each other.
+	ReshardPhaseConfiguringReplication TemporalReshardPhase = "ConfiguringReplication"
+	// ReshardPhaseReplicatingNamespaces is the phase where the namespaces are replicated to the target cluster.
+	ReshardPhaseReplicatingNamespaces TemporalReshardPhase = "ReplicatingNamespaces"
+	// ReshardPhaseWaitingForCutOver is the phase where the namespaces are replicated and the reshard waits for spec.cutOver.
+	ReshardPhaseWaitingForCutOver TemporalReshardPhase = "WaitingForCutOver"
+	// ReshardPhaseCuttingOver is the phase where the namespaces are failed over to the target cluster.
+	ReshardPhaseCuttingOver TemporalReshardPhase = "CuttingOver"
+	// ReshardPhaseCompleted is the phase where the namespaces are active in the target cluster.
+	ReshardPhaseCompleted TemporalReshardPhase = "Completed"
+	// ReshardPhaseFailed is the phase where the reshard can't be completed.
+	ReshardPhaseFailed TemporalReshardPhase = "Failed"
+)
+
+// TemporalReshardStatus defines the observed state of TemporalReshard.
+type TemporalReshardStatus struct {
+	// Phase is the current phase of the reshard.
+	// +optional
+	Phase TemporalReshardPhase `json:"phase,omitempty"`
+	// FailoverRef references the TemporalFailover created to cut over.
+	// +optional
+	FailoverRef *corev1.LocalObjectReference `json:"failoverRef,omitempty"`
+	// Conditions represent the latest available observations of the reshard state.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Source",type="string",JSONPath=".spec.clusterRef.name"
+// +kubebuilder:printcolumn:name="Target",type="string",JSONPath=".spec.targetClusterRef.name"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type == 'Ready')].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// A TemporalReshard moves namespaces to a temporal cluster with a different number of history shards,
+// by replicating them to the new cluster and failing them over.
+type TemporalReshard struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TemporalReshardSpec   `json:"spec,omitempty"`
+	Status TemporalReshardStatus `json:"status,omitempty"`
+}
+
+// IsCompleted returns true if the reshard has finished, successfully or not.
+func (r *TemporalReshard) IsCompleted() bool {
+	return r.Status.Phase == ReshardPhaseCompleted || r.Status.Phase == ReshardPhaseFailed
+}
+
+//+kubebuilder:object:root=true
+
+// TemporalReshardList contains a list of TemporalReshard.
+type TemporalReshardList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TemporalReshard `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TemporalReshard{}, &TemporalReshardList{})
+}
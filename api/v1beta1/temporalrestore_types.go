@@ -0,0 +1,109 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultRestoreBackoffLimit is the number of restore retries used when not set.
+const defaultRestoreBackoffLimit int32 = 2
+
+// TemporalRestoreSpec defines the desired state of TemporalRestore.
+type TemporalRestoreSpec struct {
+	// Reference to the temporal cluster to restore the backup into.
+	// The cluster must be paused before the restore starts.
+	// If it doesn't exist, it is created from the cluster spec stored with the backup, then paused once ready.
+	ClusterRef TemporalClusterReference `json:"clusterRef"`
+	// BackupRef is the TemporalBackup, in the same namespace, providing the backup storage location.
+	BackupRef corev1.LocalObjectReference `json:"backupRef"`
+	// BackupID is the identifier of the backup to restore.
+	// Defaults to the backup ID reported by the referenced TemporalBackup, required for scheduled backups.
+	// +optional
+	BackupID string `json:"backupID,omitempty"`
+	// BackoffLimit is the number of times a failed restore job is recreated
+	// before the restore is marked as failed.
+	// +kubebuilder:default=2
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+}
+
+// GetBackoffLimit returns the number of allowed restore retries.
+func (s *TemporalRestoreSpec) GetBackoffLimit() int32 {
+	if s.BackoffLimit == nil {
+		return defaultRestoreBackoffLimit
+	}
+	return *s.BackoffLimit
+}
+
+// TemporalRestoreStatus defines the observed state of TemporalRestore.
+type TemporalRestoreStatus struct {
+	// Conditions represent the latest available observations of the restore state.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// BackupID is the identifier of the restored backup.
+	// +optional
+	BackupID string `json:"backupID,omitempty"`
+	// Retries is the number of times the restore job has been recreated after a failure.
+	// +optional
+	Retries int32 `json:"retries,omitempty"`
+	// ClusterCreated is true if the restore created the TemporalCluster from the spec stored with the backup.
+	// +optional
+	ClusterCreated bool `json:"clusterCreated,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterRef.name"
+// +kubebuilder:printcolumn:name="Backup",type="string",JSONPath=".spec.backupRef.name"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type == 'Ready')].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// A TemporalRestore restores a backup taken by a TemporalBackup into a temporal cluster's SQL datastores.
+type TemporalRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TemporalRestoreSpec   `json:"spec,omitempty"`
+	Status TemporalRestoreStatus `json:"status,omitempty"`
+}
+
+// IsCompleted returns true if the restore has finished, successfully or not.
+func (r *TemporalRestore) IsCompleted() bool {
+	for _, condition := range r.Status.Conditions {
+		if condition.Type == ReadyCondition && (condition.Reason == RestoreSucceededReason || condition.Reason == RestoreFailedReason) {
+			return true
+		}
+	}
+	return false
+}
+
+//+kubebuilder:object:root=true
+
+// TemporalRestoreList contains a list of TemporalRestore.
+type TemporalRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TemporalRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TemporalRestore{}, &TemporalRestoreList{})
+}
@@ -26,13 +26,66 @@ import (
 	"github.com/alexandrevilain/temporal-operator/pkg/version"
 	"github.com/gocql/gocql"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
-	"k8s.io/api/core/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	apisv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdoptionSpec) DeepCopyInto(out *AdoptionSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdoptionSpec.
+func (in *AdoptionSpec) DeepCopy() *AdoptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AdoptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppProtocolsSpec) DeepCopyInto(out *AppProtocolsSpec) {
+	*out = *in
+	if in.RPC != nil {
+		in, out := &in.RPC, &out.RPC
+		*out = new(string)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(string)
+		**out = **in
+	}
+	if in.Membership != nil {
+		in, out := &in.Membership, &out.Membership
+		*out = new(string)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppProtocolsSpec.
+func (in *AppProtocolsSpec) DeepCopy() *AppProtocolsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AppProtocolsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArchivalProvider) DeepCopyInto(out *ArchivalProvider) {
 	*out = *in
@@ -78,10 +131,56 @@ func (in *ArchivalSpec) DeepCopy() *ArchivalSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthorizationPluginSpec) DeepCopyInto(out *AuthorizationPluginSpec) {
+	*out = *in
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]corev1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]corev1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationPluginSpec.
+func (in *AuthorizationPluginSpec) DeepCopy() *AuthorizationPluginSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthorizationPluginSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthorizationSpec) DeepCopyInto(out *AuthorizationSpec) {
 	*out = *in
 	in.JWTKeyProvider.DeepCopyInto(&out.JWTKeyProvider)
+	if in.Plugin != nil {
+		in, out := &in.Plugin, &out.Plugin
+		*out = new(AuthorizationPluginSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OperatorCredentials != nil {
+		in, out := &in.OperatorCredentials, &out.OperatorCredentials
+		*out = new(OperatorCredentialsSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationSpec.
@@ -104,7 +203,7 @@ func (in *AuthorizationSpecJWTKeyProvider) DeepCopyInto(out *AuthorizationSpecJW
 	}
 	if in.RefreshInterval != nil {
 		in, out := &in.RefreshInterval, &out.RefreshInterval
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 }
@@ -119,6 +218,182 @@ func (in *AuthorizationSpecJWTKeyProvider) DeepCopy() *AuthorizationSpecJWTKeyPr
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoRollbackSpec) DeepCopyInto(out *AutoRollbackSpec) {
+	*out = *in
+	if in.ProgressDeadline != nil {
+		in, out := &in.ProgressDeadline, &out.ProgressDeadline
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoRollbackSpec.
+func (in *AutoRollbackSpec) DeepCopy() *AutoRollbackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoRollbackSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingSpec) DeepCopyInto(out *AutoscalingSpec) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]v2.MetricSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Behavior != nil {
+		in, out := &in.Behavior, &out.Behavior
+		*out = new(v2.HorizontalPodAutoscalerBehavior)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingSpec.
+func (in *AutoscalingSpec) DeepCopy() *AutoscalingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupCassandraSpec) DeepCopyInto(out *BackupCassandraSpec) {
+	*out = *in
+	out.MedusaConfigRef = in.MedusaConfigRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupCassandraSpec.
+func (in *BackupCassandraSpec) DeepCopy() *BackupCassandraSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupCassandraSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupImagesSpec) DeepCopyInto(out *BackupImagesSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupImagesSpec.
+func (in *BackupImagesSpec) DeepCopy() *BackupImagesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupImagesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupS3Storage) DeepCopyInto(out *BackupS3Storage) {
+	*out = *in
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(S3Credentials)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupS3Storage.
+func (in *BackupS3Storage) DeepCopy() *BackupS3Storage {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupS3Storage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupStorageSpec) DeepCopyInto(out *BackupStorageSpec) {
+	*out = *in
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(BackupS3Storage)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupStorageSpec.
+func (in *BackupStorageSpec) DeepCopy() *BackupStorageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupStorageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryStatus) DeepCopyInto(out *CanaryStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.SoakStartTime != nil {
+		in, out := &in.SoakStartTime, &out.SoakStartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryStatus.
+func (in *CanaryStatus) DeepCopy() *CanaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryUpgradeSpec) DeepCopyInto(out *CanaryUpgradeSpec) {
+	*out = *in
+	if in.SoakPeriod != nil {
+		in, out := &in.SoakPeriod, &out.SoakPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxErrorRatePercent != nil {
+		in, out := &in.MaxErrorRatePercent, &out.MaxErrorRatePercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryUpgradeSpec.
+func (in *CanaryUpgradeSpec) DeepCopy() *CanaryUpgradeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryUpgradeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CassandraConsistencySpec) DeepCopyInto(out *CassandraConsistencySpec) {
 	*out = *in
@@ -154,7 +429,7 @@ func (in *CassandraSpec) DeepCopyInto(out *CassandraSpec) {
 	}
 	if in.ConnectTimeout != nil {
 		in, out := &in.ConnectTimeout, &out.ConnectTimeout
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 	if in.Consistency != nil {
@@ -174,32 +449,83 @@ func (in *CassandraSpec) DeepCopy() *CassandraSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerIssuerReference) DeepCopyInto(out *CertManagerIssuerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuerReference.
+func (in *CertManagerIssuerReference) DeepCopy() *CertManagerIssuerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerIssuerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerMTLSSpec) DeepCopyInto(out *CertManagerMTLSSpec) {
+	*out = *in
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(CertManagerIssuerReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerMTLSSpec.
+func (in *CertManagerMTLSSpec) DeepCopy() *CertManagerMTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerMTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
+	*out = *in
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateStatus.
+func (in *CertificateStatus) DeepCopy() *CertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificatesDurationSpec) DeepCopyInto(out *CertificatesDurationSpec) {
 	*out = *in
 	if in.RootCACertificate != nil {
 		in, out := &in.RootCACertificate, &out.RootCACertificate
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 	if in.IntermediateCAsCertificates != nil {
 		in, out := &in.IntermediateCAsCertificates, &out.IntermediateCAsCertificates
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 	if in.ClientCertificates != nil {
 		in, out := &in.ClientCertificates, &out.ClientCertificates
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 	if in.FrontendCertificate != nil {
 		in, out := &in.FrontendCertificate, &out.FrontendCertificate
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 	if in.InternodeCertificate != nil {
 		in, out := &in.InternodeCertificate, &out.InternodeCertificate
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 }
@@ -245,54 +571,134 @@ func (in *ClusterArchivalSpec) DeepCopy() *ClusterArchivalSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ConstrainedValue) DeepCopyInto(out *ConstrainedValue) {
-	*out = *in
-	out.Constraints = in.Constraints
-	if in.Value != nil {
-		in, out := &in.Value, &out.Value
-		*out = new(apiextensionsv1.JSON)
-		(*in).DeepCopyInto(*out)
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConstrainedValue.
-func (in *ConstrainedValue) DeepCopy() *ConstrainedValue {
-	if in == nil {
-		return nil
-	}
-	out := new(ConstrainedValue)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Constraints) DeepCopyInto(out *Constraints) {
+func (in *ClusterMetadataSpec) DeepCopyInto(out *ClusterMetadataSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Constraints.
-func (in *Constraints) DeepCopy() *Constraints {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMetadataSpec.
+func (in *ClusterMetadataSpec) DeepCopy() *ClusterMetadataSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Constraints)
+	out := new(ClusterMetadataSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatastoreSpec) DeepCopyInto(out *DatastoreSpec) {
+func (in *CodecServerSpec) DeepCopyInto(out *CodecServerSpec) {
 	*out = *in
-	if in.SQL != nil {
-		in, out := &in.SQL, &out.SQL
-		*out = new(SQLSpec)
-		(*in).DeepCopyInto(*out)
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
 	}
-	if in.Elasticsearch != nil {
-		in, out := &in.Elasticsearch, &out.Elasticsearch
-		*out = new(ElasticsearchSpec)
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
 		**out = **in
 	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]corev1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(TemporalUIIngressSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CodecServerSpec.
+func (in *CodecServerSpec) DeepCopy() *CodecServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CodecServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapKeyReference) DeepCopyInto(out *ConfigMapKeyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapKeyReference.
+func (in *ConfigMapKeyReference) DeepCopy() *ConfigMapKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConstrainedValue) DeepCopyInto(out *ConstrainedValue) {
+	*out = *in
+	out.Constraints = in.Constraints
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConstrainedValue.
+func (in *ConstrainedValue) DeepCopy() *ConstrainedValue {
+	if in == nil {
+		return nil
+	}
+	out := new(ConstrainedValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Constraints) DeepCopyInto(out *Constraints) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Constraints.
+func (in *Constraints) DeepCopy() *Constraints {
+	if in == nil {
+		return nil
+	}
+	out := new(Constraints)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatastoreSpec) DeepCopyInto(out *DatastoreSpec) {
+	*out = *in
+	if in.SQL != nil {
+		in, out := &in.SQL, &out.SQL
+		*out = new(SQLSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Elasticsearch != nil {
+		in, out := &in.Elasticsearch, &out.Elasticsearch
+		*out = new(ElasticsearchSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Cassandra != nil {
 		in, out := &in.Cassandra, &out.Cassandra
 		*out = new(CassandraSpec)
@@ -358,6 +764,11 @@ func (in *DatastoreTLSSpec) DeepCopyInto(out *DatastoreTLSSpec) {
 		*out = new(SecretKeyReference)
 		**out = **in
 	}
+	if in.CaConfigMapRef != nil {
+		in, out := &in.CaConfigMapRef, &out.CaConfigMapRef
+		*out = new(ConfigMapKeyReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatastoreTLSSpec.
@@ -370,6 +781,61 @@ func (in *DatastoreTLSSpec) DeepCopy() *DatastoreTLSSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultNamespaceSpec) DeepCopyInto(out *DefaultNamespaceSpec) {
+	*out = *in
+	if in.RetentionPeriod != nil {
+		in, out := &in.RetentionPeriod, &out.RetentionPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultNamespaceSpec.
+func (in *DefaultNamespaceSpec) DeepCopy() *DefaultNamespaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultNamespaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultStoreLimitsSpec) DeepCopyInto(out *DefaultStoreLimitsSpec) {
+	*out = *in
+	if in.FrontendMaxQPS != nil {
+		in, out := &in.FrontendMaxQPS, &out.FrontendMaxQPS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HistoryMaxQPS != nil {
+		in, out := &in.HistoryMaxQPS, &out.HistoryMaxQPS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MatchingMaxQPS != nil {
+		in, out := &in.MatchingMaxQPS, &out.MatchingMaxQPS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WorkerMaxQPS != nil {
+		in, out := &in.WorkerMaxQPS, &out.WorkerMaxQPS
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultStoreLimitsSpec.
+func (in *DefaultStoreLimitsSpec) DeepCopy() *DefaultStoreLimitsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultStoreLimitsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeploymentOverride) DeepCopyInto(out *DeploymentOverride) {
 	*out = *in
@@ -415,12 +881,32 @@ func (in *DeploymentOverrideSpec) DeepCopy() *DeploymentOverrideSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DevModeSpec) DeepCopyInto(out *DevModeSpec) {
+	*out = *in
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(corev1.PersistentVolumeClaimVolumeSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevModeSpec.
+func (in *DevModeSpec) DeepCopy() *DevModeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DevModeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DynamicConfigSpec) DeepCopyInto(out *DynamicConfigSpec) {
 	*out = *in
 	if in.PollInterval != nil {
 		in, out := &in.PollInterval, &out.PollInterval
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 	if in.Values != nil {
@@ -452,6 +938,26 @@ func (in *DynamicConfigSpec) DeepCopy() *DynamicConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchIndexLifecyclePolicySpec) DeepCopyInto(out *ElasticsearchIndexLifecyclePolicySpec) {
+	*out = *in
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchIndexLifecyclePolicySpec.
+func (in *ElasticsearchIndexLifecyclePolicySpec) DeepCopy() *ElasticsearchIndexLifecyclePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchIndexLifecyclePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ElasticsearchIndices) DeepCopyInto(out *ElasticsearchIndices) {
 	*out = *in
@@ -472,6 +978,11 @@ func (in *ElasticsearchSpec) DeepCopyInto(out *ElasticsearchSpec) {
 	*out = *in
 	out.Indices = in.Indices
 	out.CloseIdleConnectionsInterval = in.CloseIdleConnectionsInterval
+	if in.IndexLifecyclePolicy != nil {
+		in, out := &in.IndexLifecyclePolicy, &out.IndexLifecyclePolicy
+		*out = new(ElasticsearchIndexLifecyclePolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSpec.
@@ -500,193 +1011,226 @@ func (in *FilestoreArchiver) DeepCopy() *FilestoreArchiver {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FrontendMTLSSpec) DeepCopyInto(out *FrontendMTLSSpec) {
+func (in *FrontendExternalTLSSpec) DeepCopyInto(out *FrontendExternalTLSSpec) {
 	*out = *in
-	if in.ExtraDNSNames != nil {
-		in, out := &in.ExtraDNSNames, &out.ExtraDNSNames
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(CertManagerIssuerReference)
+		**out = **in
+	}
+	if in.ClientCASecretRef != nil {
+		in, out := &in.ClientCASecretRef, &out.ClientCASecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrontendMTLSSpec.
-func (in *FrontendMTLSSpec) DeepCopy() *FrontendMTLSSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrontendExternalTLSSpec.
+func (in *FrontendExternalTLSSpec) DeepCopy() *FrontendExternalTLSSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FrontendMTLSSpec)
+	out := new(FrontendExternalTLSSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCSArchiver) DeepCopyInto(out *GCSArchiver) {
+func (in *FrontendKeepAliveSpec) DeepCopyInto(out *FrontendKeepAliveSpec) {
 	*out = *in
-	if in.CredentialsRef != nil {
-		in, out := &in.CredentialsRef, &out.CredentialsRef
-		*out = new(v1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
+	if in.MinTime != nil {
+		in, out := &in.MinTime, &out.MinTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PermitWithoutStream != nil {
+		in, out := &in.PermitWithoutStream, &out.PermitWithoutStream
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxConnectionIdle != nil {
+		in, out := &in.MaxConnectionIdle, &out.MaxConnectionIdle
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxConnectionAge != nil {
+		in, out := &in.MaxConnectionAge, &out.MaxConnectionAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxConnectionAgeGrace != nil {
+		in, out := &in.MaxConnectionAgeGrace, &out.MaxConnectionAgeGrace
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCSArchiver.
-func (in *GCSArchiver) DeepCopy() *GCSArchiver {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrontendKeepAliveSpec.
+func (in *FrontendKeepAliveSpec) DeepCopy() *FrontendKeepAliveSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GCSArchiver)
+	out := new(FrontendKeepAliveSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InternalFrontendServiceSpec) DeepCopyInto(out *InternalFrontendServiceSpec) {
+func (in *FrontendLimitsSpec) DeepCopyInto(out *FrontendLimitsSpec) {
 	*out = *in
-	in.ServiceSpec.DeepCopyInto(&out.ServiceSpec)
+	if in.RPS != nil {
+		in, out := &in.RPS, &out.RPS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NamespaceRPS != nil {
+		in, out := &in.NamespaceRPS, &out.NamespaceRPS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.GlobalNamespaceRPS != nil {
+		in, out := &in.GlobalNamespaceRPS, &out.GlobalNamespaceRPS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxConcurrentLongPolls != nil {
+		in, out := &in.MaxConcurrentLongPolls, &out.MaxConcurrentLongPolls
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KeepAlive != nil {
+		in, out := &in.KeepAlive, &out.KeepAlive
+		*out = new(FrontendKeepAliveSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalFrontendServiceSpec.
-func (in *InternalFrontendServiceSpec) DeepCopy() *InternalFrontendServiceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrontendLimitsSpec.
+func (in *FrontendLimitsSpec) DeepCopy() *FrontendLimitsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InternalFrontendServiceSpec)
+	out := new(FrontendLimitsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InternodeMTLSSpec) DeepCopyInto(out *InternodeMTLSSpec) {
+func (in *FrontendMTLSSpec) DeepCopyInto(out *FrontendMTLSSpec) {
 	*out = *in
+	if in.ExtraDNSNames != nil {
+		in, out := &in.ExtraDNSNames, &out.ExtraDNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtraIPAddresses != nil {
+		in, out := &in.ExtraIPAddresses, &out.ExtraIPAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(FrontendExternalTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.ClientSecretRef != nil {
+		in, out := &in.ClientSecretRef, &out.ClientSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternodeMTLSSpec.
-func (in *InternodeMTLSSpec) DeepCopy() *InternodeMTLSSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrontendMTLSSpec.
+func (in *FrontendMTLSSpec) DeepCopy() *FrontendMTLSSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InternodeMTLSSpec)
+	out := new(FrontendMTLSSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogSpec) DeepCopyInto(out *LogSpec) {
+func (in *GCSArchiver) DeepCopyInto(out *GCSArchiver) {
 	*out = *in
-	if in.Stdout != nil {
-		in, out := &in.Stdout, &out.Stdout
-		*out = new(bool)
+	if in.CredentialsRef != nil {
+		in, out := &in.CredentialsRef, &out.CredentialsRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogSpec.
-func (in *LogSpec) DeepCopy() *LogSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCSArchiver.
+func (in *GCSArchiver) DeepCopy() *GCSArchiver {
 	if in == nil {
 		return nil
 	}
-	out := new(LogSpec)
+	out := new(GCSArchiver)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MTLSSpec) DeepCopyInto(out *MTLSSpec) {
+func (in *GatewayRouteSpec) DeepCopyInto(out *GatewayRouteSpec) {
 	*out = *in
-	if in.Internode != nil {
-		in, out := &in.Internode, &out.Internode
-		*out = new(InternodeMTLSSpec)
-		**out = **in
-	}
-	if in.Frontend != nil {
-		in, out := &in.Frontend, &out.Frontend
-		*out = new(FrontendMTLSSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.CertificatesDuration != nil {
-		in, out := &in.CertificatesDuration, &out.CertificatesDuration
-		*out = new(CertificatesDurationSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.RefreshInterval != nil {
-		in, out := &in.RefreshInterval, &out.RefreshInterval
-		*out = new(metav1.Duration)
-		**out = **in
-	}
-	if in.RenewBefore != nil {
-		in, out := &in.RenewBefore, &out.RenewBefore
-		*out = new(metav1.Duration)
-		**out = **in
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MTLSSpec.
-func (in *MTLSSpec) DeepCopy() *MTLSSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(MTLSSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MetricsSpec) DeepCopyInto(out *MetricsSpec) {
-	*out = *in
-	if in.ExcludeTags != nil {
-		in, out := &in.ExcludeTags, &out.ExcludeTags
-		*out = make(map[string][]string, len(*in))
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
-			var outVal []string
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				in, out := &val, &outVal
-				*out = make([]string, len(*in))
-				copy(*out, *in)
-			}
-			(*out)[key] = outVal
+			(*out)[key] = val
 		}
 	}
-	if in.PerUnitHistogramBoundaries != nil {
-		in, out := &in.PerUnitHistogramBoundaries, &out.PerUnitHistogramBoundaries
-		*out = make(map[string][]string, len(*in))
-		for key, val := range *in {
-			var outVal []string
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				in, out := &val, &outVal
-				*out = make([]string, len(*in))
-				copy(*out, *in)
-			}
-			(*out)[key] = outVal
+	if in.ParentRefs != nil {
+		in, out := &in.ParentRefs, &out.ParentRefs
+		*out = make([]apisv1.ParentReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Prefix != nil {
-		in, out := &in.Prefix, &out.Prefix
-		*out = new(string)
-		**out = **in
-	}
-	if in.Prometheus != nil {
-		in, out := &in.Prometheus, &out.Prometheus
-		*out = new(PrometheusSpec)
-		(*in).DeepCopyInto(*out)
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]apisv1.Hostname, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsSpec.
-func (in *MetricsSpec) DeepCopy() *MetricsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayRouteSpec.
+func (in *GatewayRouteSpec) DeepCopy() *GatewayRouteSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MetricsSpec)
+	out := new(GatewayRouteSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectMetaOverride) DeepCopyInto(out *ObjectMetaOverride) {
+func (in *GrafanaDashboardsSpec) DeepCopyInto(out *GrafanaDashboardsSpec) {
 	*out = *in
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
@@ -704,419 +1248,2555 @@ func (in *ObjectMetaOverride) DeepCopyInto(out *ObjectMetaOverride) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectMetaOverride.
-func (in *ObjectMetaOverride) DeepCopy() *ObjectMetaOverride {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaDashboardsSpec.
+func (in *GrafanaDashboardsSpec) DeepCopy() *GrafanaDashboardsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectMetaOverride)
+	out := new(GrafanaDashboardsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodTemplateSpecOverride) DeepCopyInto(out *PodTemplateSpecOverride) {
+func (in *InternalFrontendServiceSpec) DeepCopyInto(out *InternalFrontendServiceSpec) {
 	*out = *in
-	if in.ObjectMetaOverride != nil {
-		in, out := &in.ObjectMetaOverride, &out.ObjectMetaOverride
-		*out = new(ObjectMetaOverride)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Spec != nil {
-		in, out := &in.Spec, &out.Spec
-		*out = new(apiextensionsv1.JSON)
-		(*in).DeepCopyInto(*out)
-	}
+	in.ServiceSpec.DeepCopyInto(&out.ServiceSpec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplateSpecOverride.
-func (in *PodTemplateSpecOverride) DeepCopy() *PodTemplateSpecOverride {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalFrontendServiceSpec.
+func (in *InternalFrontendServiceSpec) DeepCopy() *InternalFrontendServiceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PodTemplateSpecOverride)
+	out := new(InternalFrontendServiceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PrometheusScrapeConfig) DeepCopyInto(out *PrometheusScrapeConfig) {
+func (in *InternodeMTLSSpec) DeepCopyInto(out *InternodeMTLSSpec) {
 	*out = *in
-	if in.ServiceMonitor != nil {
-		in, out := &in.ServiceMonitor, &out.ServiceMonitor
-		*out = new(PrometheusScrapeConfigServiceMonitor)
-		(*in).DeepCopyInto(*out)
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusScrapeConfig.
-func (in *PrometheusScrapeConfig) DeepCopy() *PrometheusScrapeConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternodeMTLSSpec.
+func (in *InternodeMTLSSpec) DeepCopy() *InternodeMTLSSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PrometheusScrapeConfig)
+	out := new(InternodeMTLSSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PrometheusScrapeConfigServiceMonitor) DeepCopyInto(out *PrometheusScrapeConfigServiceMonitor) {
+func (in *KubernetesServiceSpec) DeepCopyInto(out *KubernetesServiceSpec) {
 	*out = *in
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Override != nil {
-		in, out := &in.Override, &out.Override
-		*out = new(monitoringv1.ServiceMonitorSpec)
+	if in.ObjectMetaOverride != nil {
+		in, out := &in.ObjectMetaOverride, &out.ObjectMetaOverride
+		*out = new(ObjectMetaOverride)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.MetricRelabelConfigs != nil {
-		in, out := &in.MetricRelabelConfigs, &out.MetricRelabelConfigs
-		*out = make([]*monitoringv1.RelabelConfig, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(monitoringv1.RelabelConfig)
-				(*in).DeepCopyInto(*out)
-			}
-		}
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusScrapeConfigServiceMonitor.
-func (in *PrometheusScrapeConfigServiceMonitor) DeepCopy() *PrometheusScrapeConfigServiceMonitor {
-	if in == nil {
-		return nil
+	if in.NodePort != nil {
+		in, out := &in.NodePort, &out.NodePort
+		*out = new(int32)
+		**out = **in
 	}
-	out := new(PrometheusScrapeConfigServiceMonitor)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PrometheusSpec) DeepCopyInto(out *PrometheusSpec) {
-	*out = *in
-	if in.ListenPort != nil {
-		in, out := &in.ListenPort, &out.ListenPort
+	if in.HTTPNodePort != nil {
+		in, out := &in.HTTPNodePort, &out.HTTPNodePort
 		*out = new(int32)
 		**out = **in
 	}
-	if in.ScrapeConfig != nil {
-		in, out := &in.ScrapeConfig, &out.ScrapeConfig
-		*out = new(PrometheusScrapeConfig)
-		(*in).DeepCopyInto(*out)
+	if in.LoadBalancerClass != nil {
+		in, out := &in.LoadBalancerClass, &out.LoadBalancerClass
+		*out = new(string)
+		**out = **in
+	}
+	if in.LoadBalancerSourceRanges != nil {
+		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InternalTrafficPolicy != nil {
+		in, out := &in.InternalTrafficPolicy, &out.InternalTrafficPolicy
+		*out = new(corev1.ServiceInternalTrafficPolicy)
+		**out = **in
+	}
+	if in.SessionAffinityTimeoutSeconds != nil {
+		in, out := &in.SessionAffinityTimeoutSeconds, &out.SessionAffinityTimeoutSeconds
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusSpec.
-func (in *PrometheusSpec) DeepCopy() *PrometheusSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesServiceSpec.
+func (in *KubernetesServiceSpec) DeepCopy() *KubernetesServiceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PrometheusSpec)
+	out := new(KubernetesServiceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *S3Archiver) DeepCopyInto(out *S3Archiver) {
+func (in *LifecycleHookSpec) DeepCopyInto(out *LifecycleHookSpec) {
 	*out = *in
-	if in.Endpoint != nil {
-		in, out := &in.Endpoint, &out.Endpoint
-		*out = new(string)
+	in.Template.DeepCopyInto(&out.Template)
+	if in.BackoffLimit != nil {
+		in, out := &in.BackoffLimit, &out.BackoffLimit
+		*out = new(int32)
 		**out = **in
 	}
-	if in.RoleName != nil {
-		in, out := &in.RoleName, &out.RoleName
-		*out = new(string)
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
 		**out = **in
 	}
-	if in.Credentials != nil {
-		in, out := &in.Credentials, &out.Credentials
-		*out = new(S3Credentials)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3Archiver.
-func (in *S3Archiver) DeepCopy() *S3Archiver {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleHookSpec.
+func (in *LifecycleHookSpec) DeepCopy() *LifecycleHookSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(S3Archiver)
+	out := new(LifecycleHookSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *S3Credentials) DeepCopyInto(out *S3Credentials) {
+func (in *LifecycleHooksStatus) DeepCopyInto(out *LifecycleHooksStatus) {
 	*out = *in
-	if in.AccessKeyIDRef != nil {
-		in, out := &in.AccessKeyIDRef, &out.AccessKeyIDRef
-		*out = new(v1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.SecretAccessKeyRef != nil {
-		in, out := &in.SecretAccessKeyRef, &out.SecretAccessKeyRef
-		*out = new(v1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
+	if in.Completed != nil {
+		in, out := &in.Completed, &out.Completed
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3Credentials.
-func (in *S3Credentials) DeepCopy() *S3Credentials {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleHooksStatus.
+func (in *LifecycleHooksStatus) DeepCopy() *LifecycleHooksStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(S3Credentials)
+	out := new(LifecycleHooksStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SQLSpec) DeepCopyInto(out *SQLSpec) {
+func (in *LifecycleSpec) DeepCopyInto(out *LifecycleSpec) {
 	*out = *in
-	if in.ConnectAttributes != nil {
-		in, out := &in.ConnectAttributes, &out.ConnectAttributes
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	if in.PreUpgrade != nil {
+		in, out := &in.PreUpgrade, &out.PreUpgrade
+		*out = make([]LifecycleHookSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.MaxConnLifetime = in.MaxConnLifetime
-	if in.GCPServiceAccount != nil {
-		in, out := &in.GCPServiceAccount, &out.GCPServiceAccount
-		*out = new(string)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLSpec.
-func (in *SQLSpec) DeepCopy() *SQLSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleSpec.
+func (in *LifecycleSpec) DeepCopy() *LifecycleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SQLSpec)
+	out := new(LifecycleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretKeyReference) DeepCopyInto(out *SecretKeyReference) {
+func (in *LogSpec) DeepCopyInto(out *LogSpec) {
 	*out = *in
+	if in.Stdout != nil {
+		in, out := &in.Stdout, &out.Stdout
+		*out = new(bool)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyReference.
-func (in *SecretKeyReference) DeepCopy() *SecretKeyReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogSpec.
+func (in *LogSpec) DeepCopy() *LogSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretKeyReference)
+	out := new(LogSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
+func (in *MTLSSpec) DeepCopyInto(out *MTLSSpec) {
 	*out = *in
-	if in.Port != nil {
-		in, out := &in.Port, &out.Port
-		*out = new(int)
-		**out = **in
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(CertManagerMTLSSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.MembershipPort != nil {
-		in, out := &in.MembershipPort, &out.MembershipPort
-		*out = new(int)
-		**out = **in
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultMTLSSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.HTTPPort != nil {
-		in, out := &in.HTTPPort, &out.HTTPPort
-		*out = new(int)
+	if in.SPIFFE != nil {
+		in, out := &in.SPIFFE, &out.SPIFFE
+		*out = new(SPIFFEMTLSSpec)
 		**out = **in
 	}
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
+	if in.Internode != nil {
+		in, out := &in.Internode, &out.Internode
+		*out = new(InternodeMTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Frontend != nil {
+		in, out := &in.Frontend, &out.Frontend
+		*out = new(FrontendMTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertificatesDuration != nil {
+		in, out := &in.CertificatesDuration, &out.CertificatesDuration
+		*out = new(CertificatesDurationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RestartOnRenewal != nil {
+		in, out := &in.RestartOnRenewal, &out.RestartOnRenewal
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExpiryWarningThreshold != nil {
+		in, out := &in.ExpiryWarningThreshold, &out.ExpiryWarningThreshold
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MTLSSpec.
+func (in *MTLSSpec) DeepCopy() *MTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindowSpec) DeepCopyInto(out *MaintenanceWindowSpec) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindowSpec.
+func (in *MaintenanceWindowSpec) DeepCopy() *MaintenanceWindowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindowStatus) DeepCopyInto(out *MaintenanceWindowStatus) {
+	*out = *in
+	if in.NextWindowStart != nil {
+		in, out := &in.NextWindowStart, &out.NextWindowStart
+		*out = (*in).DeepCopy()
+	}
+	if in.Pending != nil {
+		in, out := &in.Pending, &out.Pending
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindowStatus.
+func (in *MaintenanceWindowStatus) DeepCopy() *MaintenanceWindowStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindowStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsSpec) DeepCopyInto(out *MetricsSpec) {
+	*out = *in
+	if in.ExcludeTags != nil {
+		in, out := &in.ExcludeTags, &out.ExcludeTags
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.PerUnitHistogramBoundaries != nil {
+		in, out := &in.PerUnitHistogramBoundaries, &out.PerUnitHistogramBoundaries
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(PrometheusSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Statsd != nil {
+		in, out := &in.Statsd, &out.Statsd
+		*out = new(StatsdSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsSpec.
+func (in *MetricsSpec) DeepCopy() *MetricsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceDefaultsSpec) DeepCopyInto(out *NamespaceDefaultsSpec) {
+	*out = *in
+	if in.RetentionPeriod != nil {
+		in, out := &in.RetentionPeriod, &out.RetentionPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Archival != nil {
+		in, out := &in.Archival, &out.Archival
+		*out = new(TemporalNamespaceArchivalSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SearchAttributes != nil {
+		in, out := &in.SearchAttributes, &out.SearchAttributes
+		*out = make(map[string]SearchAttributeType, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceDefaultsSpec.
+func (in *NamespaceDefaultsSpec) DeepCopy() *NamespaceDefaultsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceDefaultsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPoliciesSpec) DeepCopyInto(out *NetworkPoliciesSpec) {
+	*out = *in
+	if in.FrontendIngressFrom != nil {
+		in, out := &in.FrontendIngressFrom, &out.FrontendIngressFrom
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UIIngressFrom != nil {
+		in, out := &in.UIIngressFrom, &out.UIIngressFrom
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MetricsIngressFrom != nil {
+		in, out := &in.MetricsIngressFrom, &out.MetricsIngressFrom
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = make([]networkingv1.NetworkPolicyEgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPoliciesSpec.
+func (in *NetworkPoliciesSpec) DeepCopy() *NetworkPoliciesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPoliciesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
+	*out = *in
+	if in.IPFamilyPolicy != nil {
+		in, out := &in.IPFamilyPolicy, &out.IPFamilyPolicy
+		*out = new(corev1.IPFamilyPolicy)
+		**out = **in
+	}
+	if in.IPFamilies != nil {
+		in, out := &in.IPFamilies, &out.IPFamilies
+		*out = make([]corev1.IPFamily, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
+func (in *NetworkSpec) DeepCopy() *NetworkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectMetaOverride) DeepCopyInto(out *ObjectMetaOverride) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectMetaOverride.
+func (in *ObjectMetaOverride) DeepCopy() *ObjectMetaOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectMetaOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorCredentialsSpec) DeepCopyInto(out *OperatorCredentialsSpec) {
+	*out = *in
+	if in.TokenSecretRef != nil {
+		in, out := &in.TokenSecretRef, &out.TokenSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorCredentialsSpec.
+func (in *OperatorCredentialsSpec) DeepCopy() *OperatorCredentialsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorCredentialsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistenceLimitsSpec) DeepCopyInto(out *PersistenceLimitsSpec) {
+	*out = *in
+	if in.DefaultStore != nil {
+		in, out := &in.DefaultStore, &out.DefaultStore
+		*out = new(DefaultStoreLimitsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VisibilityStore != nil {
+		in, out := &in.VisibilityStore, &out.VisibilityStore
+		*out = new(VisibilityStoreLimitsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistenceLimitsSpec.
+func (in *PersistenceLimitsSpec) DeepCopy() *PersistenceLimitsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistenceLimitsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodTemplateSpecOverride) DeepCopyInto(out *PodTemplateSpecOverride) {
+	*out = *in
+	if in.ObjectMetaOverride != nil {
+		in, out := &in.ObjectMetaOverride, &out.ObjectMetaOverride
+		*out = new(ObjectMetaOverride)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplateSpecOverride.
+func (in *PodTemplateSpecOverride) DeepCopy() *PodTemplateSpecOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(PodTemplateSpecOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusRuleSpec) DeepCopyInto(out *PrometheusRuleSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AlertLabels != nil {
+		in, out := &in.AlertLabels, &out.AlertLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DisabledAlerts != nil {
+		in, out := &in.DisabledAlerts, &out.DisabledAlerts
+		*out = make([]PrometheusAlert, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusRuleSpec.
+func (in *PrometheusRuleSpec) DeepCopy() *PrometheusRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusScrapeConfig) DeepCopyInto(out *PrometheusScrapeConfig) {
+	*out = *in
+	if in.ServiceMonitor != nil {
+		in, out := &in.ServiceMonitor, &out.ServiceMonitor
+		*out = new(PrometheusScrapeConfigServiceMonitor)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodMonitor != nil {
+		in, out := &in.PodMonitor, &out.PodMonitor
+		*out = new(PrometheusScrapeConfigPodMonitor)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusScrapeConfig.
+func (in *PrometheusScrapeConfig) DeepCopy() *PrometheusScrapeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusScrapeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusScrapeConfigPodMonitor) DeepCopyInto(out *PrometheusScrapeConfigPodMonitor) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MetricRelabelConfigs != nil {
+		in, out := &in.MetricRelabelConfigs, &out.MetricRelabelConfigs
+		*out = make([]*monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(monitoringv1.RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.RelabelConfigs != nil {
+		in, out := &in.RelabelConfigs, &out.RelabelConfigs
+		*out = make([]*monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(monitoringv1.RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusScrapeConfigPodMonitor.
+func (in *PrometheusScrapeConfigPodMonitor) DeepCopy() *PrometheusScrapeConfigPodMonitor {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusScrapeConfigPodMonitor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusScrapeConfigServiceMonitor) DeepCopyInto(out *PrometheusScrapeConfigServiceMonitor) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Override != nil {
+		in, out := &in.Override, &out.Override
+		*out = new(monitoringv1.ServiceMonitorSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MetricRelabelConfigs != nil {
+		in, out := &in.MetricRelabelConfigs, &out.MetricRelabelConfigs
+		*out = make([]*monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(monitoringv1.RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.RelabelConfigs != nil {
+		in, out := &in.RelabelConfigs, &out.RelabelConfigs
+		*out = make([]*monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(monitoringv1.RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusScrapeConfigServiceMonitor.
+func (in *PrometheusScrapeConfigServiceMonitor) DeepCopy() *PrometheusScrapeConfigServiceMonitor {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusScrapeConfigServiceMonitor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusSpec) DeepCopyInto(out *PrometheusSpec) {
+	*out = *in
+	if in.ListenPort != nil {
+		in, out := &in.ListenPort, &out.ListenPort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScrapeConfig != nil {
+		in, out := &in.ScrapeConfig, &out.ScrapeConfig
+		*out = new(PrometheusScrapeConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rule != nil {
+		in, out := &in.Rule, &out.Rule
+		*out = new(PrometheusRuleSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GrafanaDashboards != nil {
+		in, out := &in.GrafanaDashboards, &out.GrafanaDashboards
+		*out = new(GrafanaDashboardsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusSpec.
+func (in *PrometheusSpec) DeepCopy() *PrometheusSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublicClientSpec) DeepCopyInto(out *PublicClientSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicClientSpec.
+func (in *PublicClientSpec) DeepCopy() *PublicClientSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PublicClientSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteClusterSpec) DeepCopyInto(out *RemoteClusterSpec) {
+	*out = *in
+	if in.ClusterRef != nil {
+		in, out := &in.ClusterRef, &out.ClusterRef
+		*out = new(TemporalClusterReference)
+		**out = **in
+	}
+	if in.ConnectionEnabled != nil {
+		in, out := &in.ConnectionEnabled, &out.ConnectionEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(RemoteClusterTLSSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteClusterSpec.
+func (in *RemoteClusterSpec) DeepCopy() *RemoteClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteClusterStatus) DeepCopyInto(out *RemoteClusterStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteClusterStatus.
+func (in *RemoteClusterStatus) DeepCopy() *RemoteClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteClusterTLSSpec) DeepCopyInto(out *RemoteClusterTLSSpec) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteClusterTLSSpec.
+func (in *RemoteClusterTLSSpec) DeepCopy() *RemoteClusterTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteClusterTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationSpec) DeepCopyInto(out *ReplicationSpec) {
+	*out = *in
+	if in.RemoteClusters != nil {
+		in, out := &in.RemoteClusters, &out.RemoteClusters
+		*out = make([]RemoteClusterSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSpec.
+func (in *ReplicationSpec) DeepCopy() *ReplicationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteSpec) DeepCopyInto(out *RouteSpec) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteSpec.
+func (in *RouteSpec) DeepCopy() *RouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3Archiver) DeepCopyInto(out *S3Archiver) {
+	*out = *in
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.RoleName != nil {
+		in, out := &in.RoleName, &out.RoleName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(S3Credentials)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3Archiver.
+func (in *S3Archiver) DeepCopy() *S3Archiver {
+	if in == nil {
+		return nil
+	}
+	out := new(S3Archiver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3Credentials) DeepCopyInto(out *S3Credentials) {
+	*out = *in
+	if in.AccessKeyIDRef != nil {
+		in, out := &in.AccessKeyIDRef, &out.AccessKeyIDRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretAccessKeyRef != nil {
+		in, out := &in.SecretAccessKeyRef, &out.SecretAccessKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3Credentials.
+func (in *S3Credentials) DeepCopy() *S3Credentials {
+	if in == nil {
+		return nil
+	}
+	out := new(S3Credentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SPIFFEMTLSSpec) DeepCopyInto(out *SPIFFEMTLSSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SPIFFEMTLSSpec.
+func (in *SPIFFEMTLSSpec) DeepCopy() *SPIFFEMTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SPIFFEMTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLSpec) DeepCopyInto(out *SQLSpec) {
+	*out = *in
+	if in.ConnectAttributes != nil {
+		in, out := &in.ConnectAttributes, &out.ConnectAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	out.MaxConnLifetime = in.MaxConnLifetime
+	if in.GCPServiceAccount != nil {
+		in, out := &in.GCPServiceAccount, &out.GCPServiceAccount
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLSpec.
+func (in *SQLSpec) DeepCopy() *SQLSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyReference) DeepCopyInto(out *SecretKeyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyReference.
+func (in *SecretKeyReference) DeepCopy() *SecretKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountSpec) DeepCopyInto(out *ServiceAccountSpec) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountSpec.
+func (in *ServiceAccountSpec) DeepCopy() *ServiceAccountSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceLogSpec) DeepCopyInto(out *ServiceLogSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceLogSpec.
+func (in *ServiceLogSpec) DeepCopy() *ServiceLogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceLogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceMetricsSpec) DeepCopyInto(out *ServiceMetricsSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMetricsSpec.
+func (in *ServiceMetricsSpec) DeepCopy() *ServiceMetricsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceMetricsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int)
+		**out = **in
+	}
+	if in.MembershipPort != nil {
+		in, out := &in.MembershipPort, &out.MembershipPort
+		*out = new(int)
+		**out = **in
+	}
+	if in.HTTPPort != nil {
+		in, out := &in.HTTPPort, &out.HTTPPort
+		*out = new(int)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(appsv1.DeploymentStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodMetadata != nil {
+		in, out := &in.PodMetadata, &out.PodMetadata
+		*out = new(ObjectMetaOverride)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = new(ServiceSpecOverride)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]corev1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]corev1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]corev1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(corev1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(corev1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(corev1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Lifecycle != nil {
+		in, out := &in.Lifecycle, &out.Lifecycle
+		*out = new(corev1.Lifecycle)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostAliases != nil {
+		in, out := &in.HostAliases, &out.HostAliases
+		*out = make([]corev1.HostAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(ServiceAccountSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Route != nil {
+		in, out := &in.Route, &out.Route
+		*out = new(RouteSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(KubernetesServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AppProtocols != nil {
+		in, out := &in.AppProtocols, &out.AppProtocols
+		*out = new(AppProtocolsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Log != nil {
+		in, out := &in.Log, &out.Log
+		*out = new(ServiceLogSpec)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(ServiceMetricsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigOverlay != nil {
+		in, out := &in.ConfigOverlay, &out.ConfigOverlay
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(FrontendLimitsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GRPCRoute != nil {
+		in, out := &in.GRPCRoute, &out.GRPCRoute
+		*out = new(GatewayRouteSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSRoute != nil {
+		in, out := &in.TLSRoute, &out.TLSRoute
+		*out = new(GatewayRouteSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSpec.
+func (in *ServiceSpec) DeepCopy() *ServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceSpecOverride) DeepCopyInto(out *ServiceSpecOverride) {
+	*out = *in
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(DeploymentOverride)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSpecOverride.
+func (in *ServiceSpecOverride) DeepCopy() *ServiceSpecOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceSpecOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceStatus) DeepCopyInto(out *ServiceStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceStatus.
+func (in *ServiceStatus) DeepCopy() *ServiceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServicesSpec) DeepCopyInto(out *ServicesSpec) {
+	*out = *in
+	if in.Frontend != nil {
+		in, out := &in.Frontend, &out.Frontend
+		*out = new(ServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InternalFrontend != nil {
+		in, out := &in.InternalFrontend, &out.InternalFrontend
+		*out = new(InternalFrontendServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = new(ServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Matching != nil {
+		in, out := &in.Matching, &out.Matching
+		*out = new(ServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Worker != nil {
+		in, out := &in.Worker, &out.Worker
+		*out = new(ServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = new(ServiceSpecOverride)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicesSpec.
+func (in *ServicesSpec) DeepCopy() *ServicesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServicesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatsdSpec) DeepCopyInto(out *StatsdSpec) {
+	*out = *in
+	if in.FlushInterval != nil {
+		in, out := &in.FlushInterval, &out.FlushInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.FlushBytes != nil {
+		in, out := &in.FlushBytes, &out.FlushBytes
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatsdSpec.
+func (in *StatsdSpec) DeepCopy() *StatsdSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StatsdSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TelemetrySpec) DeepCopyInto(out *TelemetrySpec) {
+	*out = *in
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(TracingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TelemetrySpec.
+func (in *TelemetrySpec) DeepCopy() *TelemetrySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TelemetrySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalAdminToolsSpec) DeepCopyInto(out *TemporalAdminToolsSpec) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = new(ServiceSpecOverride)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalAdminToolsSpec.
+func (in *TemporalAdminToolsSpec) DeepCopy() *TemporalAdminToolsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalAdminToolsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalBackup) DeepCopyInto(out *TemporalBackup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalBackup.
+func (in *TemporalBackup) DeepCopy() *TemporalBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalBackupList) DeepCopyInto(out *TemporalBackupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TemporalBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalBackupList.
+func (in *TemporalBackupList) DeepCopy() *TemporalBackupList {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalBackupSpec) DeepCopyInto(out *TemporalBackupSpec) {
+	*out = *in
+	out.ClusterRef = in.ClusterRef
+	in.Storage.DeepCopyInto(&out.Storage)
+	if in.Cassandra != nil {
+		in, out := &in.Cassandra, &out.Cassandra
+		*out = new(BackupCassandraSpec)
+		**out = **in
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = new(BackupImagesSpec)
+		**out = **in
 	}
 	in.Resources.DeepCopyInto(&out.Resources)
-	if in.Overrides != nil {
-		in, out := &in.Overrides, &out.Overrides
-		*out = new(ServiceSpecOverride)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalBackupSpec.
+func (in *TemporalBackupSpec) DeepCopy() *TemporalBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalBackupStatus) DeepCopyInto(out *TemporalBackupStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ClusterSpec != nil {
+		in, out := &in.ClusterSpec, &out.ClusterSpec
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalBackupStatus.
+func (in *TemporalBackupStatus) DeepCopy() *TemporalBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalCluster) DeepCopyInto(out *TemporalCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalCluster.
+func (in *TemporalCluster) DeepCopy() *TemporalCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalClusterClient) DeepCopyInto(out *TemporalClusterClient) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterClient.
+func (in *TemporalClusterClient) DeepCopy() *TemporalClusterClient {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalClusterClient)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalClusterClient) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalClusterClientList) DeepCopyInto(out *TemporalClusterClientList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TemporalClusterClient, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterClientList.
+func (in *TemporalClusterClientList) DeepCopy() *TemporalClusterClientList {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalClusterClientList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalClusterClientList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalClusterClientSpec) DeepCopyInto(out *TemporalClusterClientSpec) {
+	*out = *in
+	out.ClusterRef = in.ClusterRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterClientSpec.
+func (in *TemporalClusterClientSpec) DeepCopy() *TemporalClusterClientSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalClusterClientSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalClusterClientStatus) DeepCopyInto(out *TemporalClusterClientStatus) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterClientStatus.
+func (in *TemporalClusterClientStatus) DeepCopy() *TemporalClusterClientStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalClusterClientStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalClusterList) DeepCopyInto(out *TemporalClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TemporalCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterList.
+func (in *TemporalClusterList) DeepCopy() *TemporalClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalClusterReference) DeepCopyInto(out *TemporalClusterReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterReference.
+func (in *TemporalClusterReference) DeepCopy() *TemporalClusterReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalClusterReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalClusterSpec) DeepCopyInto(out *TemporalClusterSpec) {
+	*out = *in
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(version.Version)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradeStrategy != nil {
+		in, out := &in.UpgradeStrategy, &out.UpgradeStrategy
+		*out = new(UpgradeStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Lifecycle != nil {
+		in, out := &in.Lifecycle, &out.Lifecycle
+		*out = new(LifecycleSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Log != nil {
+		in, out := &in.Log, &out.Log
+		*out = new(LogSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JobTTLSecondsAfterFinished != nil {
+		in, out := &in.JobTTLSecondsAfterFinished, &out.JobTTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+	in.JobResources.DeepCopyInto(&out.JobResources)
+	if in.JobInitContainers != nil {
+		in, out := &in.JobInitContainers, &out.JobInitContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.JobServiceAccount != nil {
+		in, out := &in.JobServiceAccount, &out.JobServiceAccount
+		*out = new(ServiceAccountSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = new(ServicesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Persistence.DeepCopyInto(&out.Persistence)
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.UI != nil {
+		in, out := &in.UI, &out.UI
+		*out = new(TemporalUISpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdminTools != nil {
+		in, out := &in.AdminTools, &out.AdminTools
+		*out = new(TemporalAdminToolsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MTLS != nil {
+		in, out := &in.MTLS, &out.MTLS
+		*out = new(MTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(MetricsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Telemetry != nil {
+		in, out := &in.Telemetry, &out.Telemetry
+		*out = new(TelemetrySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterMetadata != nil {
+		in, out := &in.ClusterMetadata, &out.ClusterMetadata
+		*out = new(ClusterMetadataSpec)
+		**out = **in
+	}
+	if in.Replication != nil {
+		in, out := &in.Replication, &out.Replication
+		*out = new(ReplicationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PublicClient != nil {
+		in, out := &in.PublicClient, &out.PublicClient
+		*out = new(PublicClientSpec)
+		**out = **in
+	}
+	if in.DynamicConfig != nil {
+		in, out := &in.DynamicConfig, &out.DynamicConfig
+		*out = new(DynamicConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Archival != nil {
+		in, out := &in.Archival, &out.Archival
+		*out = new(ClusterArchivalSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultNamespaces != nil {
+		in, out := &in.DefaultNamespaces, &out.DefaultNamespaces
+		*out = make([]DefaultNamespaceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NamespaceDefaults != nil {
+		in, out := &in.NamespaceDefaults, &out.NamespaceDefaults
+		*out = new(NamespaceDefaultsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Authorization != nil {
+		in, out := &in.Authorization, &out.Authorization
+		*out = new(AuthorizationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicies != nil {
+		in, out := &in.NetworkPolicies, &out.NetworkPolicies
+		*out = new(NetworkPoliciesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(NetworkSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DevMode != nil {
+		in, out := &in.DevMode, &out.DevMode
+		*out = new(DevModeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindowSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(AdoptionSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterSpec.
+func (in *TemporalClusterSpec) DeepCopy() *TemporalClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalClusterStatus) DeepCopyInto(out *TemporalClusterStatus) {
+	*out = *in
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]ServiceStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Persistence != nil {
+		in, out := &in.Persistence, &out.Persistence
+		*out = new(TemporalPersistenceStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]CertificateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RemoteClusters != nil {
+		in, out := &in.RemoteClusters, &out.RemoteClusters
+		*out = make([]RemoteClusterStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultNamespaces != nil {
+		in, out := &in.DefaultNamespaces, &out.DefaultNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(VersionUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanaryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(VersionRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PreUpgradeHooks != nil {
+		in, out := &in.PreUpgradeHooks, &out.PreUpgradeHooks
+		*out = new(LifecycleHooksStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindowStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterStatus.
+func (in *TemporalClusterStatus) DeepCopy() *TemporalClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalFailover) DeepCopyInto(out *TemporalFailover) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalFailover.
+func (in *TemporalFailover) DeepCopy() *TemporalFailover {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalFailover)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalFailover) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalFailoverList) DeepCopyInto(out *TemporalFailoverList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TemporalFailover, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalFailoverList.
+func (in *TemporalFailoverList) DeepCopy() *TemporalFailoverList {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalFailoverList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalFailoverList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalFailoverNamespaceStatus) DeepCopyInto(out *TemporalFailoverNamespaceStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalFailoverNamespaceStatus.
+func (in *TemporalFailoverNamespaceStatus) DeepCopy() *TemporalFailoverNamespaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalFailoverNamespaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalFailoverSpec) DeepCopyInto(out *TemporalFailoverSpec) {
+	*out = *in
+	out.ClusterRef = in.ClusterRef
+	out.SourceClusterRef = in.SourceClusterRef
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalFailoverSpec.
+func (in *TemporalFailoverSpec) DeepCopy() *TemporalFailoverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalFailoverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalFailoverStatus) DeepCopyInto(out *TemporalFailoverStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]TemporalFailoverNamespaceStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalFailoverStatus.
+func (in *TemporalFailoverStatus) DeepCopy() *TemporalFailoverStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalFailoverStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalNamespace) DeepCopyInto(out *TemporalNamespace) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespace.
+func (in *TemporalNamespace) DeepCopy() *TemporalNamespace {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalNamespace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalNamespace) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalNamespaceArchivalSpec) DeepCopyInto(out *TemporalNamespaceArchivalSpec) {
+	*out = *in
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = new(ArchivalSpec)
+		**out = **in
+	}
+	if in.Visibility != nil {
+		in, out := &in.Visibility, &out.Visibility
+		*out = new(ArchivalSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceArchivalSpec.
+func (in *TemporalNamespaceArchivalSpec) DeepCopy() *TemporalNamespaceArchivalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalNamespaceArchivalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalNamespaceList) DeepCopyInto(out *TemporalNamespaceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TemporalNamespace, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceList.
+func (in *TemporalNamespaceList) DeepCopy() *TemporalNamespaceList {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalNamespaceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalNamespaceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalNamespaceMigration) DeepCopyInto(out *TemporalNamespaceMigration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceMigration.
+func (in *TemporalNamespaceMigration) DeepCopy() *TemporalNamespaceMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalNamespaceMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalNamespaceMigration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalNamespaceMigrationList) DeepCopyInto(out *TemporalNamespaceMigrationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TemporalNamespaceMigration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceMigrationList.
+func (in *TemporalNamespaceMigrationList) DeepCopy() *TemporalNamespaceMigrationList {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalNamespaceMigrationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalNamespaceMigrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalNamespaceMigrationSpec) DeepCopyInto(out *TemporalNamespaceMigrationSpec) {
+	*out = *in
+	out.NamespaceRef = in.NamespaceRef
+	out.ClusterRef = in.ClusterRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceMigrationSpec.
+func (in *TemporalNamespaceMigrationSpec) DeepCopy() *TemporalNamespaceMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalNamespaceMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalNamespaceMigrationStatus) DeepCopyInto(out *TemporalNamespaceMigrationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SourceClusterRef != nil {
+		in, out := &in.SourceClusterRef, &out.SourceClusterRef
+		*out = new(TemporalClusterReference)
+		**out = **in
+	}
+	if in.RemoteClustersAdded != nil {
+		in, out := &in.RemoteClustersAdded, &out.RemoteClustersAdded
+		*out = make([]TemporalClusterReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceMigrationStatus.
+func (in *TemporalNamespaceMigrationStatus) DeepCopy() *TemporalNamespaceMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalNamespaceMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalNamespaceSpec) DeepCopyInto(out *TemporalNamespaceSpec) {
+	*out = *in
+	out.ClusterRef = in.ClusterRef
+	if in.RetentionPeriod != nil {
+		in, out := &in.RetentionPeriod, &out.RetentionPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Archival != nil {
+		in, out := &in.Archival, &out.Archival
+		*out = new(TemporalNamespaceArchivalSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.InitContainers != nil {
-		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]v1.Container, len(*in))
+	if in.SearchAttributes != nil {
+		in, out := &in.SearchAttributes, &out.SearchAttributes
+		*out = make(map[string]SearchAttributeType, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceSpec.
+func (in *TemporalNamespaceSpec) DeepCopy() *TemporalNamespaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalNamespaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalNamespaceStatus) DeepCopyInto(out *TemporalNamespaceStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSpec.
-func (in *ServiceSpec) DeepCopy() *ServiceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceStatus.
+func (in *TemporalNamespaceStatus) DeepCopy() *TemporalNamespaceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceSpec)
+	out := new(TemporalNamespaceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceSpecOverride) DeepCopyInto(out *ServiceSpecOverride) {
+func (in *TemporalPersistenceSpec) DeepCopyInto(out *TemporalPersistenceSpec) {
 	*out = *in
-	if in.Deployment != nil {
-		in, out := &in.Deployment, &out.Deployment
-		*out = new(DeploymentOverride)
+	if in.DefaultStore != nil {
+		in, out := &in.DefaultStore, &out.DefaultStore
+		*out = new(DatastoreSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VisibilityStore != nil {
+		in, out := &in.VisibilityStore, &out.VisibilityStore
+		*out = new(DatastoreSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecondaryVisibilityStore != nil {
+		in, out := &in.SecondaryVisibilityStore, &out.SecondaryVisibilityStore
+		*out = new(DatastoreSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdvancedVisibilityStore != nil {
+		in, out := &in.AdvancedVisibilityStore, &out.AdvancedVisibilityStore
+		*out = new(DatastoreSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VisibilityMigration != nil {
+		in, out := &in.VisibilityMigration, &out.VisibilityMigration
+		*out = new(VisibilityMigrationSpec)
+		**out = **in
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(PersistenceLimitsSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSpecOverride.
-func (in *ServiceSpecOverride) DeepCopy() *ServiceSpecOverride {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalPersistenceSpec.
+func (in *TemporalPersistenceSpec) DeepCopy() *TemporalPersistenceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalPersistenceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalPersistenceStatus) DeepCopyInto(out *TemporalPersistenceStatus) {
+	*out = *in
+	if in.DefaultStore != nil {
+		in, out := &in.DefaultStore, &out.DefaultStore
+		*out = new(DatastoreStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VisibilityStore != nil {
+		in, out := &in.VisibilityStore, &out.VisibilityStore
+		*out = new(DatastoreStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecondaryVisibilityStore != nil {
+		in, out := &in.SecondaryVisibilityStore, &out.SecondaryVisibilityStore
+		*out = new(DatastoreStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdvancedVisibilityStore != nil {
+		in, out := &in.AdvancedVisibilityStore, &out.AdvancedVisibilityStore
+		*out = new(DatastoreStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalPersistenceStatus.
+func (in *TemporalPersistenceStatus) DeepCopy() *TemporalPersistenceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalPersistenceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalReshard) DeepCopyInto(out *TemporalReshard) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalReshard.
+func (in *TemporalReshard) DeepCopy() *TemporalReshard {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceSpecOverride)
+	out := new(TemporalReshard)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalReshard) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceStatus) DeepCopyInto(out *ServiceStatus) {
+func (in *TemporalReshardList) DeepCopyInto(out *TemporalReshardList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TemporalReshard, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceStatus.
-func (in *ServiceStatus) DeepCopy() *ServiceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalReshardList.
+func (in *TemporalReshardList) DeepCopy() *TemporalReshardList {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceStatus)
+	out := new(TemporalReshardList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalReshardList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServicesSpec) DeepCopyInto(out *ServicesSpec) {
+func (in *TemporalReshardSpec) DeepCopyInto(out *TemporalReshardSpec) {
 	*out = *in
-	if in.Frontend != nil {
-		in, out := &in.Frontend, &out.Frontend
-		*out = new(ServiceSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.InternalFrontend != nil {
-		in, out := &in.InternalFrontend, &out.InternalFrontend
-		*out = new(InternalFrontendServiceSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.History != nil {
-		in, out := &in.History, &out.History
-		*out = new(ServiceSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Matching != nil {
-		in, out := &in.Matching, &out.Matching
-		*out = new(ServiceSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Worker != nil {
-		in, out := &in.Worker, &out.Worker
-		*out = new(ServiceSpec)
+	out.ClusterRef = in.ClusterRef
+	out.TargetClusterRef = in.TargetClusterRef
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(TemporalReshardTargetSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Overrides != nil {
-		in, out := &in.Overrides, &out.Overrides
-		*out = new(ServiceSpecOverride)
-		(*in).DeepCopyInto(*out)
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicesSpec.
-func (in *ServicesSpec) DeepCopy() *ServicesSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalReshardSpec.
+func (in *TemporalReshardSpec) DeepCopy() *TemporalReshardSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ServicesSpec)
+	out := new(TemporalReshardSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalAdminToolsSpec) DeepCopyInto(out *TemporalAdminToolsSpec) {
+func (in *TemporalReshardStatus) DeepCopyInto(out *TemporalReshardStatus) {
 	*out = *in
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.Overrides != nil {
-		in, out := &in.Overrides, &out.Overrides
-		*out = new(ServiceSpecOverride)
-		(*in).DeepCopyInto(*out)
+	if in.FailoverRef != nil {
+		in, out := &in.FailoverRef, &out.FailoverRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalAdminToolsSpec.
-func (in *TemporalAdminToolsSpec) DeepCopy() *TemporalAdminToolsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalReshardStatus.
+func (in *TemporalReshardStatus) DeepCopy() *TemporalReshardStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalAdminToolsSpec)
+	out := new(TemporalReshardStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalCluster) DeepCopyInto(out *TemporalCluster) {
+func (in *TemporalReshardTargetSpec) DeepCopyInto(out *TemporalReshardTargetSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.Persistence.DeepCopyInto(&out.Persistence)
+	if in.ClusterMetadata != nil {
+		in, out := &in.ClusterMetadata, &out.ClusterMetadata
+		*out = new(ClusterMetadataSpec)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalCluster.
-func (in *TemporalCluster) DeepCopy() *TemporalCluster {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalReshardTargetSpec.
+func (in *TemporalReshardTargetSpec) DeepCopy() *TemporalReshardTargetSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalCluster)
+	out := new(TemporalReshardTargetSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TemporalCluster) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalClusterClient) DeepCopyInto(out *TemporalClusterClient) {
+func (in *TemporalRestore) DeepCopyInto(out *TemporalRestore) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterClient.
-func (in *TemporalClusterClient) DeepCopy() *TemporalClusterClient {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalRestore.
+func (in *TemporalRestore) DeepCopy() *TemporalRestore {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalClusterClient)
+	out := new(TemporalRestore)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TemporalClusterClient) DeepCopyObject() runtime.Object {
+func (in *TemporalRestore) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1124,31 +3804,31 @@ func (in *TemporalClusterClient) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalClusterClientList) DeepCopyInto(out *TemporalClusterClientList) {
+func (in *TemporalRestoreList) DeepCopyInto(out *TemporalRestoreList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]TemporalClusterClient, len(*in))
+		*out = make([]TemporalRestore, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterClientList.
-func (in *TemporalClusterClientList) DeepCopy() *TemporalClusterClientList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalRestoreList.
+func (in *TemporalRestoreList) DeepCopy() *TemporalRestoreList {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalClusterClientList)
+	out := new(TemporalRestoreList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TemporalClusterClientList) DeepCopyObject() runtime.Object {
+func (in *TemporalRestoreList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1156,489 +3836,486 @@ func (in *TemporalClusterClientList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalClusterClientSpec) DeepCopyInto(out *TemporalClusterClientSpec) {
+func (in *TemporalRestoreSpec) DeepCopyInto(out *TemporalRestoreSpec) {
 	*out = *in
 	out.ClusterRef = in.ClusterRef
+	out.BackupRef = in.BackupRef
+	if in.BackoffLimit != nil {
+		in, out := &in.BackoffLimit, &out.BackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterClientSpec.
-func (in *TemporalClusterClientSpec) DeepCopy() *TemporalClusterClientSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalRestoreSpec.
+func (in *TemporalRestoreSpec) DeepCopy() *TemporalRestoreSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalClusterClientSpec)
+	out := new(TemporalRestoreSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalClusterClientStatus) DeepCopyInto(out *TemporalClusterClientStatus) {
+func (in *TemporalRestoreStatus) DeepCopyInto(out *TemporalRestoreStatus) {
 	*out = *in
-	if in.SecretRef != nil {
-		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(v1.LocalObjectReference)
-		**out = **in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterClientStatus.
-func (in *TemporalClusterClientStatus) DeepCopy() *TemporalClusterClientStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalRestoreStatus.
+func (in *TemporalRestoreStatus) DeepCopy() *TemporalRestoreStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalClusterClientStatus)
+	out := new(TemporalRestoreStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalClusterList) DeepCopyInto(out *TemporalClusterList) {
+func (in *TemporalUIAuthSpec) DeepCopyInto(out *TemporalUIAuthSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]TemporalCluster, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.ClientIDSecretRef != nil {
+		in, out := &in.ClientIDSecretRef, &out.ClientIDSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientSecretRef != nil {
+		in, out := &in.ClientSecretRef, &out.ClientSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterList.
-func (in *TemporalClusterList) DeepCopy() *TemporalClusterList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalUIAuthSpec.
+func (in *TemporalUIAuthSpec) DeepCopy() *TemporalUIAuthSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalClusterList)
+	out := new(TemporalUIAuthSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TemporalClusterList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalUIHTTPRouteSpec) DeepCopyInto(out *TemporalUIHTTPRouteSpec) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	return nil
+	if in.ParentRefs != nil {
+		in, out := &in.ParentRefs, &out.ParentRefs
+		*out = make([]apisv1.ParentReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]apisv1.Hostname, len(*in))
+		copy(*out, *in)
+	}
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]apisv1.HTTPRouteFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalUIHTTPRouteSpec.
+func (in *TemporalUIHTTPRouteSpec) DeepCopy() *TemporalUIHTTPRouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalUIHTTPRouteSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalClusterReference) DeepCopyInto(out *TemporalClusterReference) {
+func (in *TemporalUIIngressSpec) DeepCopyInto(out *TemporalUIIngressSpec) {
 	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = make([]networkingv1.IngressTLS, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CertManagerIssuerRef != nil {
+		in, out := &in.CertManagerIssuerRef, &out.CertManagerIssuerRef
+		*out = new(CertManagerIssuerReference)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterReference.
-func (in *TemporalClusterReference) DeepCopy() *TemporalClusterReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalUIIngressSpec.
+func (in *TemporalUIIngressSpec) DeepCopy() *TemporalUIIngressSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalClusterReference)
+	out := new(TemporalUIIngressSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalClusterSpec) DeepCopyInto(out *TemporalClusterSpec) {
+func (in *TemporalUISpec) DeepCopyInto(out *TemporalUISpec) {
 	*out = *in
-	if in.Version != nil {
-		in, out := &in.Version, &out.Version
-		*out = new(version.Version)
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Log != nil {
-		in, out := &in.Log, &out.Log
-		*out = new(LogSpec)
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(corev1.Probe)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.JobTTLSecondsAfterFinished != nil {
-		in, out := &in.JobTTLSecondsAfterFinished, &out.JobTTLSecondsAfterFinished
-		*out = new(int32)
-		**out = **in
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(corev1.Probe)
+		(*in).DeepCopyInto(*out)
 	}
-	in.JobResources.DeepCopyInto(&out.JobResources)
-	if in.JobInitContainers != nil {
-		in, out := &in.JobInitContainers, &out.JobInitContainers
-		*out = make([]v1.Container, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(corev1.Probe)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Services != nil {
-		in, out := &in.Services, &out.Services
-		*out = new(ServicesSpec)
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = new(ServiceSpecOverride)
 		(*in).DeepCopyInto(*out)
 	}
-	in.Persistence.DeepCopyInto(&out.Persistence)
-	if in.ImagePullSecrets != nil {
-		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]v1.LocalObjectReference, len(*in))
-		copy(*out, *in)
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(TemporalUIIngressSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.UI != nil {
-		in, out := &in.UI, &out.UI
-		*out = new(TemporalUISpec)
+	if in.Route != nil {
+		in, out := &in.Route, &out.Route
+		*out = new(RouteSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.AdminTools != nil {
-		in, out := &in.AdminTools, &out.AdminTools
-		*out = new(TemporalAdminToolsSpec)
+	if in.AppProtocol != nil {
+		in, out := &in.AppProtocol, &out.AppProtocol
+		*out = new(string)
+		**out = **in
+	}
+	if in.HTTPRoute != nil {
+		in, out := &in.HTTPRoute, &out.HTTPRoute
+		*out = new(TemporalUIHTTPRouteSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.MTLS != nil {
-		in, out := &in.MTLS, &out.MTLS
-		*out = new(MTLSSpec)
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(TemporalUIAuthSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Metrics != nil {
-		in, out := &in.Metrics, &out.Metrics
-		*out = new(MetricsSpec)
+	if in.DisabledActions != nil {
+		in, out := &in.DisabledActions, &out.DisabledActions
+		*out = make([]TemporalUIAction, len(*in))
+		copy(*out, *in)
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(ObjectMetaOverride)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.DynamicConfig != nil {
-		in, out := &in.DynamicConfig, &out.DynamicConfig
-		*out = new(DynamicConfigSpec)
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(corev1.PodSecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Archival != nil {
-		in, out := &in.Archival, &out.Archival
-		*out = new(ClusterArchivalSpec)
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.SecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Authorization != nil {
-		in, out := &in.Authorization, &out.Authorization
-		*out = new(AuthorizationSpec)
+	if in.CodecServer != nil {
+		in, out := &in.CodecServer, &out.CodecServer
+		*out = new(CodecServerSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterSpec.
-func (in *TemporalClusterSpec) DeepCopy() *TemporalClusterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalUISpec.
+func (in *TemporalUISpec) DeepCopy() *TemporalUISpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalClusterSpec)
+	out := new(TemporalUISpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalClusterStatus) DeepCopyInto(out *TemporalClusterStatus) {
+func (in *TracingHeader) DeepCopyInto(out *TracingHeader) {
 	*out = *in
-	if in.Services != nil {
-		in, out := &in.Services, &out.Services
-		*out = make([]ServiceStatus, len(*in))
-		copy(*out, *in)
-	}
-	if in.Persistence != nil {
-		in, out := &in.Persistence, &out.Persistence
-		*out = new(TemporalPersistenceStatus)
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterStatus.
-func (in *TemporalClusterStatus) DeepCopy() *TemporalClusterStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingHeader.
+func (in *TracingHeader) DeepCopy() *TracingHeader {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalClusterStatus)
+	out := new(TracingHeader)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalNamespace) DeepCopyInto(out *TemporalNamespace) {
+func (in *TracingSpec) DeepCopyInto(out *TracingSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]TracingHeader, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespace.
-func (in *TemporalNamespace) DeepCopy() *TemporalNamespace {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingSpec.
+func (in *TracingSpec) DeepCopy() *TracingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalNamespace)
+	out := new(TracingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TemporalNamespace) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalNamespaceArchivalSpec) DeepCopyInto(out *TemporalNamespaceArchivalSpec) {
+func (in *UpgradeStrategySpec) DeepCopyInto(out *UpgradeStrategySpec) {
 	*out = *in
-	if in.History != nil {
-		in, out := &in.History, &out.History
-		*out = new(ArchivalSpec)
-		**out = **in
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanaryUpgradeSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Visibility != nil {
-		in, out := &in.Visibility, &out.Visibility
-		*out = new(ArchivalSpec)
-		**out = **in
+	if in.AutoRollback != nil {
+		in, out := &in.AutoRollback, &out.AutoRollback
+		*out = new(AutoRollbackSpec)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceArchivalSpec.
-func (in *TemporalNamespaceArchivalSpec) DeepCopy() *TemporalNamespaceArchivalSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStrategySpec.
+func (in *UpgradeStrategySpec) DeepCopy() *UpgradeStrategySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalNamespaceArchivalSpec)
+	out := new(UpgradeStrategySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalNamespaceList) DeepCopyInto(out *TemporalNamespaceList) {
+func (in *VaultAuthSpec) DeepCopyInto(out *VaultAuthSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]TemporalNamespace, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Kubernetes != nil {
+		in, out := &in.Kubernetes, &out.Kubernetes
+		*out = new(VaultKubernetesAuthSpec)
+		**out = **in
+	}
+	if in.TokenSecretRef != nil {
+		in, out := &in.TokenSecretRef, &out.TokenSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceList.
-func (in *TemporalNamespaceList) DeepCopy() *TemporalNamespaceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAuthSpec.
+func (in *VaultAuthSpec) DeepCopy() *VaultAuthSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalNamespaceList)
+	out := new(VaultAuthSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TemporalNamespaceList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalNamespaceSpec) DeepCopyInto(out *TemporalNamespaceSpec) {
+func (in *VaultKubernetesAuthSpec) DeepCopyInto(out *VaultKubernetesAuthSpec) {
 	*out = *in
-	out.ClusterRef = in.ClusterRef
-	if in.RetentionPeriod != nil {
-		in, out := &in.RetentionPeriod, &out.RetentionPeriod
-		*out = new(metav1.Duration)
-		**out = **in
-	}
-	if in.Data != nil {
-		in, out := &in.Data, &out.Data
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Clusters != nil {
-		in, out := &in.Clusters, &out.Clusters
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Archival != nil {
-		in, out := &in.Archival, &out.Archival
-		*out = new(TemporalNamespaceArchivalSpec)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceSpec.
-func (in *TemporalNamespaceSpec) DeepCopy() *TemporalNamespaceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultKubernetesAuthSpec.
+func (in *VaultKubernetesAuthSpec) DeepCopy() *VaultKubernetesAuthSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalNamespaceSpec)
+	out := new(VaultKubernetesAuthSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalNamespaceStatus) DeepCopyInto(out *TemporalNamespaceStatus) {
+func (in *VaultMTLSSpec) DeepCopyInto(out *VaultMTLSSpec) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	in.Auth.DeepCopyInto(&out.Auth)
+	if in.CASecretRef != nil {
+		in, out := &in.CASecretRef, &out.CASecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceStatus.
-func (in *TemporalNamespaceStatus) DeepCopy() *TemporalNamespaceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultMTLSSpec.
+func (in *VaultMTLSSpec) DeepCopy() *VaultMTLSSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalNamespaceStatus)
+	out := new(VaultMTLSSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalPersistenceSpec) DeepCopyInto(out *TemporalPersistenceSpec) {
+func (in *VersionRolloutStatus) DeepCopyInto(out *VersionRolloutStatus) {
 	*out = *in
-	if in.DefaultStore != nil {
-		in, out := &in.DefaultStore, &out.DefaultStore
-		*out = new(DatastoreSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.VisibilityStore != nil {
-		in, out := &in.VisibilityStore, &out.VisibilityStore
-		*out = new(DatastoreSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.SecondaryVisibilityStore != nil {
-		in, out := &in.SecondaryVisibilityStore, &out.SecondaryVisibilityStore
-		*out = new(DatastoreSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.AdvancedVisibilityStore != nil {
-		in, out := &in.AdvancedVisibilityStore, &out.AdvancedVisibilityStore
-		*out = new(DatastoreSpec)
-		(*in).DeepCopyInto(*out)
-	}
+	in.StartTime.DeepCopyInto(&out.StartTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalPersistenceSpec.
-func (in *TemporalPersistenceSpec) DeepCopy() *TemporalPersistenceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VersionRolloutStatus.
+func (in *VersionRolloutStatus) DeepCopy() *VersionRolloutStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalPersistenceSpec)
+	out := new(VersionRolloutStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalPersistenceStatus) DeepCopyInto(out *TemporalPersistenceStatus) {
+func (in *VersionUpgradeStatus) DeepCopyInto(out *VersionUpgradeStatus) {
 	*out = *in
-	if in.DefaultStore != nil {
-		in, out := &in.DefaultStore, &out.DefaultStore
-		*out = new(DatastoreStatus)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.VisibilityStore != nil {
-		in, out := &in.VisibilityStore, &out.VisibilityStore
-		*out = new(DatastoreStatus)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.SecondaryVisibilityStore != nil {
-		in, out := &in.SecondaryVisibilityStore, &out.SecondaryVisibilityStore
-		*out = new(DatastoreStatus)
-		(*in).DeepCopyInto(*out)
+	if in.CompletedSteps != nil {
+		in, out := &in.CompletedSteps, &out.CompletedSteps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.AdvancedVisibilityStore != nil {
-		in, out := &in.AdvancedVisibilityStore, &out.AdvancedVisibilityStore
-		*out = new(DatastoreStatus)
-		(*in).DeepCopyInto(*out)
+	if in.RemainingSteps != nil {
+		in, out := &in.RemainingSteps, &out.RemainingSteps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalPersistenceStatus.
-func (in *TemporalPersistenceStatus) DeepCopy() *TemporalPersistenceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VersionUpgradeStatus.
+func (in *VersionUpgradeStatus) DeepCopy() *VersionUpgradeStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalPersistenceStatus)
+	out := new(VersionUpgradeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalUIIngressSpec) DeepCopyInto(out *TemporalUIIngressSpec) {
+func (in *VisibilityMigrationSpec) DeepCopyInto(out *VisibilityMigrationSpec) {
 	*out = *in
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.IngressClassName != nil {
-		in, out := &in.IngressClassName, &out.IngressClassName
-		*out = new(string)
-		**out = **in
-	}
-	if in.Hosts != nil {
-		in, out := &in.Hosts, &out.Hosts
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = make([]networkingv1.IngressTLS, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalUIIngressSpec.
-func (in *TemporalUIIngressSpec) DeepCopy() *TemporalUIIngressSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VisibilityMigrationSpec.
+func (in *VisibilityMigrationSpec) DeepCopy() *VisibilityMigrationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalUIIngressSpec)
+	out := new(VisibilityMigrationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalUISpec) DeepCopyInto(out *TemporalUISpec) {
+func (in *VisibilityStoreLimitsSpec) DeepCopyInto(out *VisibilityStoreLimitsSpec) {
 	*out = *in
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
+	if in.MaxReadQPS != nil {
+		in, out := &in.MaxReadQPS, &out.MaxReadQPS
 		*out = new(int32)
 		**out = **in
 	}
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.Overrides != nil {
-		in, out := &in.Overrides, &out.Overrides
-		*out = new(ServiceSpecOverride)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Ingress != nil {
-		in, out := &in.Ingress, &out.Ingress
-		*out = new(TemporalUIIngressSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Service != nil {
-		in, out := &in.Service, &out.Service
-		*out = new(ObjectMetaOverride)
-		(*in).DeepCopyInto(*out)
+	if in.MaxWriteQPS != nil {
+		in, out := &in.MaxWriteQPS, &out.MaxWriteQPS
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalUISpec.
-func (in *TemporalUISpec) DeepCopy() *TemporalUISpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VisibilityStoreLimitsSpec.
+func (in *VisibilityStoreLimitsSpec) DeepCopy() *VisibilityStoreLimitsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalUISpec)
+	out := new(VisibilityStoreLimitsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
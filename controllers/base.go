@@ -18,8 +18,12 @@
 package controllers
 
 import (
+	"time"
+
 	"github.com/alexandrevilain/controller-tools/pkg/discovery"
 	"github.com/alexandrevilain/controller-tools/pkg/reconciler"
+	"github.com/alexandrevilain/temporal-operator/internal/backoff"
+	internalreconciler "github.com/alexandrevilain/temporal-operator/internal/reconciler"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -31,20 +35,24 @@ type Base struct {
 	Recorder record.EventRecorder
 
 	Jobs       *reconciler.JosbReconciler
-	Reconciler *reconciler.Reconciler
+	Reconciler *internalreconciler.Reconciler
+
+	// Backoff computes the delays between the reconciliations of resources waiting for a dependency or failing.
+	Backoff *backoff.Backoff
 }
 
-func New(crclient client.Client, scheme *runtime.Scheme, recorder record.EventRecorder, discoveryMgr discovery.Manager) Base {
+func New(crclient client.Client, scheme *runtime.Scheme, recorder record.EventRecorder, discoveryMgr discovery.Manager, maxRequeueDelay time.Duration) Base {
 	return Base{
 		Client:   crclient,
 		Scheme:   scheme,
 		Recorder: recorder,
+		Backoff:  backoff.New(backoff.DefaultBaseDelay, maxRequeueDelay),
 		Jobs: &reconciler.JosbReconciler{
 			Client:   crclient,
 			Scheme:   scheme,
 			Recorder: recorder,
 		},
-		Reconciler: &reconciler.Reconciler{
+		Reconciler: &internalreconciler.Reconciler{
 			Client:    crclient,
 			Scheme:    scheme,
 			Recorder:  recorder,
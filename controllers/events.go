@@ -0,0 +1,41 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// processingErrorEventReason is the reason of the events emitted when a reconciliation fails.
+	processingErrorEventReason = "ProcessingError"
+	// frontendUnreachableEventReason is the reason of the events emitted when the operator can't reach a cluster's frontend.
+	frontendUnreachableEventReason = "FrontendUnreachable"
+)
+
+// temporalErrorEventReason returns the reason of the warning event emitted for the provided error,
+// distinguishing errors caused by an unreachable temporal frontend.
+func temporalErrorEventReason(err error) string {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return frontendUnreachableEventReason
+	default:
+		return processingErrorEventReason
+	}
+}
@@ -0,0 +1,67 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileAdoptedAdminTools deletes the admin tools deployment of the adopted helm release when the cluster's admin tools
+// are disabled. The chart's deployment isn't controlled by the cluster, so it's neither deleted with the disabled
+// admin tools builder nor pruned. Enabled admin tools are adopted in place by the admin tools builder, as the names match.
+func (r *TemporalClusterReconciler) reconcileAdoptedAdminTools(ctx context.Context, cluster *v1beta1.TemporalCluster) error {
+	if cluster.Spec.Adoption == nil || (cluster.Spec.AdminTools != nil && cluster.Spec.AdminTools.Enabled) {
+		return nil
+	}
+
+	deployment := &appsv1.Deployment{}
+	err := r.Client.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.ChildResourceName("admintools")}, deployment)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("can't get helm release admin tools deployment: %w", err)
+	}
+
+	if metav1.GetControllerOf(deployment) != nil || !isHelmReleaseObject(deployment, cluster.Spec.Adoption.HelmRelease) {
+		return nil
+	}
+
+	err = r.Client.Delete(ctx, deployment)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("can't delete helm release admin tools deployment: %w", err)
+	}
+
+	r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "AdminToolsRemoved", "Admin tools deployment %s of helm release %s removed", deployment.GetName(), cluster.Spec.Adoption.HelmRelease)
+
+	return nil
+}
+
+// isHelmReleaseObject returns true if the provided object was created by the provided helm release.
+func isHelmReleaseObject(obj client.Object, release string) bool {
+	labels := obj.GetLabels()
+	return labels["app.kubernetes.io/managed-by"] == "Helm" && labels["app.kubernetes.io/instance"] == release
+}
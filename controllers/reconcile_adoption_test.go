@@ -0,0 +1,249 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	routev1 "github.com/alexandrevilain/temporal-operator/internal/apis/openshift/route/v1"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	istionetworkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	istiosecurityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// coreDiscovery supports the kubernetes built-in APIs only.
+type coreDiscovery struct{}
+
+func (coreDiscovery) IsGVKSupported(gvk schema.GroupVersionKind) (bool, error) {
+	switch gvk.Group {
+	case "", "apps", "batch", "policy", "networking.k8s.io", "autoscaling":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+func (d coreDiscovery) IsObjectSupported(obj client.Object) (bool, error) {
+	return d.IsGVKSupported(obj.GetObjectKind().GroupVersionKind())
+}
+
+func (d coreDiscovery) AreObjectsSupported(objs ...client.Object) (bool, error) {
+	for _, obj := range objs {
+		supported, err := d.IsObjectSupported(obj)
+		if err != nil || !supported {
+			return supported, err
+		}
+	}
+	return true, nil
+}
+
+// applyAsUpdate emulates server-side apply, which isn't supported by the fake client, by creating or updating the object.
+func applyAsUpdate(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch.Type() != types.ApplyPatchType {
+		return c.Patch(ctx, obj, patch, opts...)
+	}
+
+	current, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return c.Patch(ctx, obj, patch, opts...)
+	}
+	err := c.Get(ctx, client.ObjectKeyFromObject(obj), current)
+	if apierrors.IsNotFound(err) {
+		return c.Create(ctx, obj)
+	}
+	if err != nil {
+		return err
+	}
+
+	obj.SetUID(current.GetUID())
+	obj.SetResourceVersion(current.GetResourceVersion())
+	return c.Update(ctx, obj)
+}
+
+func helmChartDeployment(component string) *appsv1.Deployment {
+	selector := map[string]string{
+		"app.kubernetes.io/name":      "temporal",
+		"app.kubernetes.io/instance":  "prod",
+		"app.kubernetes.io/component": component,
+	}
+	labels := map[string]string{
+		"app.kubernetes.io/managed-by": "Helm",
+		"app.kubernetes.io/part-of":    "temporal",
+		"app.kubernetes.io/version":    "1.22.4",
+		"helm.sh/chart":                "temporal-0.33.0",
+	}
+	for k, v := range selector {
+		labels[k] = v
+	}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "prod-temporal-" + component,
+			Namespace: "demo",
+			UID:       types.UID("helm-" + component),
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "temporal-" + component, Image: "temporalio/server:1.22.4"}},
+				},
+			},
+		},
+	}
+}
+
+func TestReconcileAdoptedResources(t *testing.T) {
+	tests := map[string]struct {
+		adminTools          *v1beta1.TemporalAdminToolsSpec
+		expectedAdminTools  bool
+		expectedAdminEvents int
+	}{
+		"admin tools enabled": {
+			adminTools:         &v1beta1.TemporalAdminToolsSpec{Enabled: true},
+			expectedAdminTools: true,
+		},
+		"admin tools disabled": {
+			adminTools:          nil,
+			expectedAdminTools:  false,
+			expectedAdminEvents: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			ctx := context.Background()
+
+			cluster := &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod-temporal", Namespace: "demo", UID: "cluster-uid"},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version:          version.MustNewVersionFromString("1.22.4"),
+					NumHistoryShards: 512,
+					Adoption:         &v1beta1.AdoptionSpec{HelmRelease: "prod"},
+					AdminTools:       test.adminTools,
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore: &v1beta1.DatastoreSpec{
+							SQL: &v1beta1.SQLSpec{
+								User:            "temporal",
+								PluginName:      "postgres",
+								DatabaseName:    "temporal",
+								ConnectAddr:     "postgres.demo.svc.cluster.local:5432",
+								ConnectProtocol: "tcp",
+							},
+						},
+						VisibilityStore: &v1beta1.DatastoreSpec{
+							SQL: &v1beta1.SQLSpec{
+								User:            "temporal",
+								PluginName:      "postgres",
+								DatabaseName:    "temporal_visibility",
+								ConnectAddr:     "postgres.demo.svc.cluster.local:5432",
+								ConnectProtocol: "tcp",
+							},
+						},
+					},
+				},
+			}
+			cluster.Default()
+
+			components := []string{"frontend", "history", "matching", "worker", "admintools"}
+			objects := []client.Object{cluster}
+			for _, component := range components {
+				objects = append(objects, helmChartDeployment(component))
+			}
+
+			scheme := runtime.NewScheme()
+			utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+			utilruntime.Must(certmanagerv1.AddToScheme(scheme))
+			utilruntime.Must(istiosecurityv1beta1.AddToScheme(scheme))
+			utilruntime.Must(istionetworkingv1beta1.AddToScheme(scheme))
+			utilruntime.Must(v1beta1.AddToScheme(scheme))
+			utilruntime.Must(monitoringv1.AddToScheme(scheme))
+			utilruntime.Must(gatewayv1.AddToScheme(scheme))
+			utilruntime.Must(gatewayv1alpha2.AddToScheme(scheme))
+			utilruntime.Must(routev1.AddToScheme(scheme))
+
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(objects...).
+				WithInterceptorFuncs(interceptor.Funcs{Patch: applyAsUpdate}).
+				Build()
+
+			recorder := record.NewFakeRecorder(100)
+			r := &TemporalClusterReconciler{
+				Base: New(c, scheme, recorder, coreDiscovery{}, 0),
+			}
+
+			require.NoError(tt, r.reconcileResources(ctx, cluster, nil, nil))
+
+			for _, component := range components {
+				seeded := helmChartDeployment(component)
+
+				deployment := &appsv1.Deployment{}
+				err := c.Get(ctx, client.ObjectKeyFromObject(seeded), deployment)
+				if component == "admintools" && !test.expectedAdminTools {
+					assert.True(tt, apierrors.IsNotFound(err), "helm admin tools deployment should be deleted")
+					continue
+				}
+				require.NoError(tt, err)
+
+				// Deployments are updated in place: same object, same selector, now controlled by the cluster.
+				assert.Equal(tt, seeded.UID, deployment.UID, component)
+				assert.Equal(tt, seeded.Spec.Selector, deployment.Spec.Selector, component)
+				assert.True(tt, metav1.IsControlledBy(deployment, cluster), component)
+				assert.Subset(tt, deployment.Spec.Template.Labels, seeded.Spec.Selector.MatchLabels, component)
+			}
+
+			// Namespaces of the release record the chart's cluster name as their active cluster.
+			configMap := &corev1.ConfigMap{}
+			require.NoError(tt, c.Get(ctx, client.ObjectKey{Namespace: "demo", Name: "prod-temporal-config"}, configMap))
+			assert.Contains(tt, configMap.Data["config_template.yaml"], "currentClusterName: active")
+
+			removed := 0
+			for len(recorder.Events) > 0 {
+				event := <-recorder.Events
+				if assert.ObjectsAreEqual("Normal AdminToolsRemoved Admin tools deployment prod-temporal-admintools of helm release prod removed", event) {
+					removed++
+				}
+			}
+			assert.Equal(tt, test.expectedAdminEvents, removed)
+		})
+	}
+}
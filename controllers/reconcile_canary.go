@@ -0,0 +1,264 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/base"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	temporalclient "go.temporal.io/sdk/client"
+	"go.temporal.io/server/common/primitives"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// canaryReadyTimeout is how long the canary frontend has to become healthy once created.
+	canaryReadyTimeout = 10 * time.Minute
+	// canaryCheckInterval is the interval between two checks of the canary frontend.
+	canaryCheckInterval = 10 * time.Second
+	// defaultCanarySoakPeriod is the soak period used if none is set.
+	defaultCanarySoakPeriod = 5 * time.Minute
+	// canaryMetricsTimeout is how long the operator waits for the canary frontend's metrics.
+	canaryMetricsTimeout = 10 * time.Second
+)
+
+// canaryMetricsClient gets the canary frontend's metrics, so a hung metrics endpoint can't block the cluster reconciliation.
+var canaryMetricsClient = &http.Client{Timeout: canaryMetricsTimeout}
+
+// reconcileCanary verifies the frontend canary of the running version upgrade, when the cluster uses the canary upgrade strategy.
+// It returns the version the canary frontend should run, or nil if no canary is needed or once the canary has been verified.
+// If the canary fails, the canary frontend is removed and an error is returned until spec.version is changed.
+func (r *TemporalClusterReconciler) reconcileCanary(ctx context.Context, cluster *v1beta1.TemporalCluster) (*version.Version, time.Duration, error) {
+	if cluster.Spec.UpgradeStrategy == nil || cluster.Spec.UpgradeStrategy.Canary == nil ||
+		cluster.Status.Version == "" || cluster.Status.Version == cluster.Spec.Version.String() {
+		cluster.Status.Canary = nil
+		return nil, 0, nil
+	}
+
+	// Workloads kept on the current version would still pull the new binary by digest.
+	// It's also enforced here as the validating webhook may not be deployed.
+	if cluster.ImageDigestsPinned() {
+		return nil, 0, errors.New("canary upgrades are not supported when images are pinned to a digest, remove spec.upgradeStrategy.canary")
+	}
+
+	canary := cluster.Spec.UpgradeStrategy.Canary
+
+	status := cluster.Status.Canary
+	if status == nil || status.Version != cluster.Spec.Version.String() {
+		status = &v1beta1.CanaryStatus{
+			Version:   cluster.Spec.Version.String(),
+			Phase:     v1beta1.CanaryProgressingPhase,
+			StartTime: ptr.To(metav1.Now()),
+		}
+		cluster.Status.Canary = status
+		r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "CanaryStarted", "Upgrading a canary frontend to %s", status.Version)
+	}
+
+	switch status.Phase {
+	case v1beta1.CanarySucceededPhase:
+		return nil, 0, nil
+	case v1beta1.CanaryFailedPhase:
+		return nil, 0, fmt.Errorf("canary frontend of version %s failed: %s, change spec.version to retry", status.Version, status.Message)
+	}
+
+	fail := func(message string) (*version.Version, time.Duration, error) {
+		status.Phase = v1beta1.CanaryFailedPhase
+		status.Message = message
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, "CanaryFailed", message)
+
+		// Stop sending traffic to the failed canary frontend.
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      base.FrontendCanaryName(cluster),
+				Namespace: cluster.Namespace,
+			},
+		}
+		err := r.Client.Delete(ctx, deployment)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, 0, fmt.Errorf("can't delete canary frontend: %w", err)
+		}
+
+		return nil, 0, fmt.Errorf("canary frontend of version %s failed: %s, change spec.version to retry", status.Version, message)
+	}
+
+	pod, err := r.canaryFrontendPod(ctx, cluster)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if pod != nil && podRestarts(pod) > 0 {
+		return fail(fmt.Sprintf("canary frontend pod %s restarted", pod.Name))
+	}
+
+	problem := "canary frontend pod not found"
+	if pod != nil {
+		problem = r.checkCanaryFrontendHealth(ctx, cluster, pod)
+	}
+
+	if status.Phase == v1beta1.CanaryProgressingPhase {
+		if problem != "" {
+			if time.Since(status.StartTime.Time) > canaryReadyTimeout {
+				return fail(fmt.Sprintf("canary frontend not healthy after %s: %s", canaryReadyTimeout, problem))
+			}
+			return cluster.Spec.Version, canaryCheckInterval, nil
+		}
+
+		status.Phase = v1beta1.CanarySoakingPhase
+		status.SoakStartTime = ptr.To(metav1.Now())
+		return cluster.Spec.Version, canaryCheckInterval, nil
+	}
+
+	if problem != "" {
+		return fail(problem)
+	}
+
+	soakPeriod := defaultCanarySoakPeriod
+	if canary.SoakPeriod != nil {
+		soakPeriod = canary.SoakPeriod.Duration
+	}
+
+	remaining := soakPeriod - time.Since(status.SoakStartTime.Time)
+	if remaining > 0 {
+		return cluster.Spec.Version, min(remaining, canaryCheckInterval), nil
+	}
+
+	if canary.MaxErrorRatePercent != nil {
+		rate, err := r.canaryFrontendErrorRate(ctx, cluster, pod)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if rate*100 > float64(*canary.MaxErrorRatePercent) {
+			return fail(fmt.Sprintf("canary frontend error rate is %.2f%%, above the %d%% limit", rate*100, *canary.MaxErrorRatePercent))
+		}
+	}
+
+	status.Phase = v1beta1.CanarySucceededPhase
+	r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "CanarySucceeded", "Canary frontend of version %s verified, upgrading the cluster", status.Version)
+
+	return nil, 0, nil
+}
+
+// canaryFrontendPod returns the running canary frontend pod of the provided cluster, or nil if not found.
+func (r *TemporalClusterReconciler) canaryFrontendPod(ctx context.Context, cluster *v1beta1.TemporalCluster) (*corev1.Pod, error) {
+	selector := metadata.Merge(
+		metadata.LabelsSelector(cluster, string(primitives.FrontendService)),
+		metadata.CanaryLabels(),
+		map[string]string{"app.kubernetes.io/version": cluster.Spec.Version.String()},
+	)
+
+	pods := &corev1.PodList{}
+	err := r.Client.List(ctx, pods, client.InNamespace(cluster.Namespace), client.MatchingLabels(selector))
+	if err != nil {
+		return nil, fmt.Errorf("can't list canary frontend pods: %w", err)
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.DeletionTimestamp.IsZero() && pod.Status.Phase == corev1.PodRunning {
+			return pod, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// checkCanaryFrontendHealth returns the reason why the provided canary frontend pod isn't healthy, or an empty string if it is.
+// The pod has to be ready and to answer to gRPC health checks.
+func (r *TemporalClusterReconciler) checkCanaryFrontendHealth(ctx context.Context, cluster *v1beta1.TemporalCluster, pod *corev1.Pod) string {
+	if !podReady(pod) {
+		return fmt.Sprintf("canary frontend pod %s is not ready", pod.Name)
+	}
+
+	hostPort := net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(*cluster.Spec.Services.Frontend.Port))
+	c, err := temporal.GetClusterClient(ctx, r.Client, cluster, temporal.WithHostPort(hostPort))
+	if err != nil {
+		return fmt.Sprintf("can't connect to canary frontend pod %s: %s", pod.Name, err)
+	}
+	defer c.Close()
+
+	_, err = c.CheckHealth(ctx, &temporalclient.CheckHealthRequest{})
+	if err != nil {
+		return fmt.Sprintf("canary frontend pod %s health check failed: %s", pod.Name, err)
+	}
+
+	return ""
+}
+
+// canaryFrontendErrorRate returns the ratio of failed requests served by the provided canary frontend pod since it started.
+func (r *TemporalClusterReconciler) canaryFrontendErrorRate(ctx context.Context, cluster *v1beta1.TemporalCluster, pod *corev1.Pod) (float64, error) {
+	port := cluster.ServiceMetricsPort(cluster.Spec.Services.Frontend)
+	if port == nil {
+		return 0, errors.New("can't check canary frontend error rate: frontend metrics are disabled")
+	}
+
+	url := fmt.Sprintf("http://%s/metrics", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(*port))))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := canaryMetricsClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("can't get canary frontend metrics: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("can't get canary frontend metrics: unexpected status %s", resp.Status)
+	}
+
+	prefix := ""
+	if cluster.Spec.Metrics.Prefix != nil {
+		prefix = *cluster.Spec.Metrics.Prefix
+	}
+
+	return temporal.ErrorRate(resp.Body, prefix)
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func podRestarts(pod *corev1.Pod) int32 {
+	restarts := int32(0)
+	for _, status := range pod.Status.ContainerStatuses {
+		restarts += status.RestartCount
+	}
+	return restarts
+}
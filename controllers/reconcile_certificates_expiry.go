@@ -0,0 +1,75 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metrics"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// reconcileCertificatesExpiry reports the expiry of the cluster's mTLS certificates in its status
+// and as metrics, and emits warning events for certificates close to their expiry.
+// It returns the duration after which certificates should be checked again.
+func (r *TemporalClusterReconciler) reconcileCertificatesExpiry(ctx context.Context, cluster *v1beta1.TemporalCluster) (time.Duration, error) {
+	certificates, err := mtls.CertificatesExpiry(ctx, r.Client, cluster)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(certificates) == 0 {
+		cluster.Status.Certificates = nil
+		metrics.DeleteCluster(cluster.Namespace, cluster.Name)
+		return 0, nil
+	}
+
+	cluster.Status.Certificates = certificates
+	metrics.SetCertificatesExpiry(cluster.Namespace, cluster.Name, certificates)
+
+	threshold := 7 * 24 * time.Hour
+	if cluster.Spec.MTLS.ExpiryWarningThreshold != nil {
+		threshold = cluster.Spec.MTLS.ExpiryWarningThreshold.Duration
+	}
+
+	var checkAfter time.Duration
+	for _, certificate := range certificates {
+		remaining := time.Until(certificate.NotAfter.Time)
+		// Keep warning about expiring certificates until they are renewed.
+		next := time.Hour
+		switch {
+		case remaining <= 0:
+			r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "CertificateExpired",
+				"Certificate stored in secret %s expired at %s", certificate.SecretName, certificate.NotAfter.UTC().Format(time.RFC3339))
+		case remaining < threshold:
+			r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "CertificateExpiring",
+				"Certificate stored in secret %s expires at %s", certificate.SecretName, certificate.NotAfter.UTC().Format(time.RFC3339))
+		default:
+			next = remaining - threshold
+		}
+
+		if checkAfter == 0 || next < checkAfter {
+			checkAfter = next
+		}
+	}
+
+	return checkAfter, nil
+}
@@ -0,0 +1,151 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alexandrevilain/controller-tools/pkg/hash"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls"
+	"go.temporal.io/server/common/primitives"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// certificatesRotationOrder is the order in which services are restarted when certificates are renewed.
+// Servers are restarted before their clients, so clients always connect to servers trusting their new certificates.
+var certificatesRotationOrder = []string{
+	string(primitives.HistoryService),
+	string(primitives.MatchingService),
+	string(primitives.FrontendService),
+	string(primitives.InternalFrontendService),
+	string(primitives.WorkerService),
+	meta.ServiceUIName,
+	meta.ServiceAdminTools,
+}
+
+// certificatesHash computes a hash of the secrets holding the cluster's mTLS certificates.
+// It returns an empty string if the cluster doesn't use certificates stored in secrets.
+func (r *TemporalClusterReconciler) certificatesHash(ctx context.Context, cluster *v1beta1.TemporalCluster) (string, error) {
+	names := mtls.CertificateSecretNames(cluster)
+	if len(names) == 0 {
+		return "", nil
+	}
+
+	data := map[string]map[string][]byte{}
+	for _, name := range names {
+		secret := &corev1.Secret{}
+		err := r.Client.Get(ctx, types.NamespacedName{Namespace: cluster.GetNamespace(), Name: name}, secret)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				// The certificate is not issued yet.
+				continue
+			}
+			return "", fmt.Errorf("can't get mTLS secret %s: %w", name, err)
+		}
+
+		data[name] = secret.Data
+	}
+
+	return hash.Sha256(data)
+}
+
+// certificatesHashes returns the certificates hash each service's pods should use.
+// When certificates are renewed, a service gets the new hash, and is then restarted,
+// only once all services preceding it in certificatesRotationOrder have been fully rolled out.
+func (r *TemporalClusterReconciler) certificatesHashes(ctx context.Context, cluster *v1beta1.TemporalCluster) (map[string]string, error) {
+	result := map[string]string{}
+	if !cluster.Spec.MTLS.RestartOnRenewalEnabled() {
+		return result, nil
+	}
+
+	desired, err := r.certificatesHash(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	if desired == "" {
+		return result, nil
+	}
+
+	previousRolledOut := true
+	for _, serviceName := range certificatesRotationOrder {
+		current, rolledOut, err := getServiceCertificatesHash(ctx, r.Client, cluster, serviceName)
+		if err != nil {
+			return nil, err
+		}
+
+		// Services not created yet, or not using certificates hash yet, directly use the current certificates.
+		if previousRolledOut || current == "" {
+			result[serviceName] = desired
+		} else {
+			result[serviceName] = current
+		}
+
+		if current != "" && (current != desired || !rolledOut) {
+			// Wait for this service to be restarted before restarting the next one.
+			previousRolledOut = false
+		}
+	}
+
+	return result, nil
+}
+
+// getServiceCertificatesHash returns the certificates hash of the provided service's workload pod template,
+// and whether the workload has been fully rolled out.
+// It returns an empty hash if the workload doesn't exist.
+func getServiceCertificatesHash(ctx context.Context, c client.Reader, cluster *v1beta1.TemporalCluster, serviceName string) (string, bool, error) {
+	key := client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.ChildResourceName(serviceName)}
+
+	deployment := &appsv1.Deployment{}
+	err := c.Get(ctx, key, deployment)
+	if err == nil {
+		replicas := ptr.Deref(deployment.Spec.Replicas, 1)
+		rolledOut := deployment.Status.ObservedGeneration >= deployment.Generation &&
+			deployment.Status.UpdatedReplicas == replicas &&
+			deployment.Status.AvailableReplicas == replicas &&
+			deployment.Status.Replicas == replicas
+		return deployment.Spec.Template.Annotations[meta.CertificatesHashKey], rolledOut, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return "", false, fmt.Errorf("can't get %s deployment: %w", serviceName, err)
+	}
+
+	statefulSet := &appsv1.StatefulSet{}
+	err = c.Get(ctx, key, statefulSet)
+	if err == nil {
+		replicas := ptr.Deref(statefulSet.Spec.Replicas, 1)
+		rolledOut := statefulSet.Status.ObservedGeneration >= statefulSet.Generation &&
+			statefulSet.Status.UpdatedReplicas == replicas &&
+			statefulSet.Status.ReadyReplicas == replicas &&
+			statefulSet.Status.CurrentRevision == statefulSet.Status.UpdateRevision
+		return statefulSet.Spec.Template.Annotations[meta.CertificatesHashKey], rolledOut, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return "", false, fmt.Errorf("can't get %s statefulset: %w", serviceName, err)
+	}
+
+	return "", true, nil
+}
@@ -0,0 +1,72 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	"go.temporal.io/api/serviceerror"
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcileDefaultNamespaces registers the default namespaces of the provided cluster which haven't been registered yet.
+// Existing namespaces are left untouched, they may be managed using TemporalNamespaces.
+func (r *TemporalClusterReconciler) reconcileDefaultNamespaces(ctx context.Context, cluster *v1beta1.TemporalCluster) error {
+	pending := []v1beta1.DefaultNamespaceSpec{}
+	for _, namespace := range cluster.Spec.DefaultNamespaces {
+		if !slices.Contains(cluster.Status.DefaultNamespaces, namespace.Name) {
+			pending = append(pending, namespace)
+		}
+	}
+
+	// Namespaces are registered using the cluster's API.
+	if len(pending) == 0 || !cluster.IsReady() {
+		return nil
+	}
+
+	logger := log.FromContext(ctx)
+
+	client, err := temporal.GetClusterNamespaceClient(ctx, r.Client, cluster)
+	if err != nil {
+		return fmt.Errorf("can't create cluster namespace client: %w", err)
+	}
+	defer client.Close()
+
+	for _, namespace := range pending {
+		logger.Info("Registering default namespace", "namespace", namespace.Name)
+		err := client.Register(ctx, temporal.DefaultNamespaceToRegisterNamespaceRequest(namespace))
+		if err == nil {
+			r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "NamespaceRegistered", "Default namespace %s registered", namespace.Name)
+		} else {
+			var namespaceAlreadyExistsError *serviceerror.NamespaceAlreadyExists
+			if !errors.As(err, &namespaceAlreadyExistsError) {
+				return fmt.Errorf("can't register \"%s\" default namespace: %w", namespace.Name, err)
+			}
+		}
+
+		cluster.Status.DefaultNamespaces = append(cluster.Status.DefaultNamespaces, namespace.Name)
+	}
+
+	return nil
+}
@@ -0,0 +1,209 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alexandrevilain/controller-tools/pkg/reconciler"
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/persistence"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// persistenceFinalizer holds the cluster deletion until its datastores with the Delete policy are dropped.
+const persistenceFinalizer = "persistence.finalizers.temporal.io"
+
+// droppableDatastore is a datastore which may be dropped on cluster deletion.
+type droppableDatastore struct {
+	name   string
+	script string
+	spec   func(c *v1beta1.TemporalCluster) *v1beta1.DatastoreSpec
+	status func(c *v1beta1.TemporalCluster) *v1beta1.DatastoreStatus
+}
+
+var droppableDatastores = []droppableDatastore{
+	{
+		name:   "default",
+		script: persistence.DropDefaultDatabaseScript,
+		spec:   func(c *v1beta1.TemporalCluster) *v1beta1.DatastoreSpec { return c.Spec.Persistence.DefaultStore },
+		status: func(c *v1beta1.TemporalCluster) *v1beta1.DatastoreStatus { return c.Status.Persistence.DefaultStore },
+	},
+	{
+		name:   "visibility",
+		script: persistence.DropVisibilityDatabaseScript,
+		spec:   func(c *v1beta1.TemporalCluster) *v1beta1.DatastoreSpec { return c.Spec.Persistence.VisibilityStore },
+		status: func(c *v1beta1.TemporalCluster) *v1beta1.DatastoreStatus { return c.Status.Persistence.VisibilityStore },
+	},
+	{
+		name:   "secondary-visibility",
+		script: persistence.DropSecondaryVisibilityDatabaseScript,
+		spec: func(c *v1beta1.TemporalCluster) *v1beta1.DatastoreSpec {
+			return c.Spec.Persistence.SecondaryVisibilityStore
+		},
+		status: func(c *v1beta1.TemporalCluster) *v1beta1.DatastoreStatus {
+			return c.Status.Persistence.SecondaryVisibilityStore
+		},
+	},
+	{
+		name:   "advanced-visibility",
+		script: persistence.DropAdvancedVisibilityDatabaseScript,
+		spec: func(c *v1beta1.TemporalCluster) *v1beta1.DatastoreSpec {
+			return c.Spec.Persistence.AdvancedVisibilityStore
+		},
+		status: func(c *v1beta1.TemporalCluster) *v1beta1.DatastoreStatus {
+			return c.Status.Persistence.AdvancedVisibilityStore
+		},
+	},
+}
+
+// shouldDrop returns true if the datastore has to be dropped on cluster deletion.
+func (d droppableDatastore) shouldDrop(cluster *v1beta1.TemporalCluster) bool {
+	spec := d.spec(cluster)
+	if spec == nil || !persistence.IsDroppable(spec) {
+		return false
+	}
+	return cluster.Spec.Persistence.GetDeletionPolicy(spec) == v1beta1.DeleteDeletionPolicy
+}
+
+// ensurePersistenceFinalizer sets the persistence finalizer on the cluster if at least one of its datastores
+// has to be dropped on deletion, and removes it otherwise.
+func (r *TemporalClusterReconciler) ensurePersistenceFinalizer(cluster *v1beta1.TemporalCluster) {
+	if cluster.Spec.DevMode.IsEnabled() {
+		_ = controllerutil.RemoveFinalizer(cluster, persistenceFinalizer)
+		return
+	}
+
+	for _, datastore := range droppableDatastores {
+		if datastore.shouldDrop(cluster) {
+			_ = controllerutil.AddFinalizer(cluster, persistenceFinalizer)
+			return
+		}
+	}
+
+	_ = controllerutil.RemoveFinalizer(cluster, persistenceFinalizer)
+}
+
+// reconcileDeletion drops the datastores with the Delete policy of the deleted cluster.
+// Temporal services are stopped first, so nothing writes to the datastores while they are dropped.
+// The persistence finalizer is removed once all the drop jobs have completed.
+func (r *TemporalClusterReconciler) reconcileDeletion(ctx context.Context, cluster *v1beta1.TemporalCluster) (time.Duration, error) {
+	if !controllerutil.ContainsFinalizer(cluster, persistenceFinalizer) {
+		return 0, nil
+	}
+
+	stopped, err := r.stopTemporalServices(ctx, cluster)
+	if err != nil {
+		return 0, err
+	}
+	if !stopped {
+		return 5 * time.Second, nil
+	}
+
+	r.reconcilePersistenceStatus(cluster)
+
+	_, err = r.Reconciler.ReconcileBuilder(ctx, cluster, persistence.NewSchemaScriptsConfigmapBuilder(cluster, r.Scheme))
+	if err != nil {
+		return 0, fmt.Errorf("can't reconcile schema script configmap: %w", err)
+	}
+
+	pending := false
+	jobs := []*reconciler.Job{}
+	for _, datastore := range droppableDatastores {
+		datastore := datastore
+		skip := func(owner runtime.Object) bool {
+			c := owner.(*v1beta1.TemporalCluster)
+			status := datastore.status(c)
+			return !datastore.shouldDrop(c) || status == nil || status.Dropped || (!status.Created && !status.Setup)
+		}
+		pending = pending || !skip(cluster)
+		jobs = append(jobs, &reconciler.Job{
+			Name:    fmt.Sprintf("drop-%s-database", datastore.name),
+			Command: getDatabaseScriptCommand(datastore.script),
+			Skip:    skip,
+			ReportSuccess: func(owner runtime.Object) error {
+				c := owner.(*v1beta1.TemporalCluster)
+				datastore.status(c).Dropped = true
+				r.Recorder.Eventf(owner, corev1.EventTypeNormal, "DatastoreDropped", "Datastore %s dropped", datastore.spec(c).Name)
+				return nil
+			},
+		})
+	}
+
+	factory := func(owner runtime.Object, scheme *runtime.Scheme, name string, command []string) resource.Builder {
+		cluster := owner.(*v1beta1.TemporalCluster)
+		return persistence.NewSchemaJobBuilder(cluster, scheme, name, command)
+	}
+
+	requeueAfter, err := r.Jobs.Reconcile(ctx, cluster, factory, jobs)
+	if err != nil || requeueAfter > 0 {
+		return requeueAfter, err
+	}
+	// Dropped datastores are recorded in the status before the finalizer is removed,
+	// the status can't be patched anymore once the cluster is gone.
+	if pending {
+		return time.Second, nil
+	}
+
+	_ = controllerutil.RemoveFinalizer(cluster, persistenceFinalizer)
+	return 0, nil
+}
+
+// stopTemporalServices deletes the temporal services workloads of the provided cluster in the foreground.
+// It returns true once all of them are gone, including their pods.
+func (r *TemporalClusterReconciler) stopTemporalServices(ctx context.Context, cluster *v1beta1.TemporalCluster) (bool, error) {
+	services := []string{"frontend-canary"}
+	for _, service := range temporalServices {
+		services = append(services, string(service))
+	}
+
+	stopped := true
+	for _, service := range services {
+		for _, workload := range []client.Object{&appsv1.Deployment{}, &appsv1.StatefulSet{}} {
+			workload.SetNamespace(cluster.Namespace)
+			workload.SetName(cluster.ChildResourceName(service))
+
+			err := r.Client.Get(ctx, client.ObjectKeyFromObject(workload), workload)
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return false, fmt.Errorf("can't get %s workload: %w", service, err)
+			}
+
+			stopped = false
+			if workload.GetDeletionTimestamp().IsZero() {
+				err := r.Client.Delete(ctx, workload, client.PropagationPolicy(metav1.DeletePropagationForeground))
+				if err != nil && !apierrors.IsNotFound(err) {
+					return false, fmt.Errorf("can't delete %s workload: %w", service, err)
+				}
+			}
+		}
+	}
+
+	return stopped, nil
+}
@@ -0,0 +1,119 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/lifecycle"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"golang.org/x/exp/slices"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcilePreUpgradeHooks runs the cluster's pre-upgrade hooks, in order, when the cluster version changes.
+// It returns the delay after which the hooks should be checked again while a hook is running.
+// A failed hook job is not retried: it has to be deleted to run the hook again.
+func (r *TemporalClusterReconciler) reconcilePreUpgradeHooks(ctx context.Context, cluster *v1beta1.TemporalCluster) (time.Duration, error) {
+	if cluster.Spec.Lifecycle == nil || len(cluster.Spec.Lifecycle.PreUpgrade) == 0 ||
+		cluster.Status.Version == "" || cluster.Status.Version == cluster.Spec.Version.String() {
+		cluster.Status.PreUpgradeHooks = nil
+		return 0, nil
+	}
+
+	currentVersion, err := version.NewVersionFromString(cluster.Status.Version)
+	if err != nil {
+		return 0, fmt.Errorf("can't parse current cluster version: %w", err)
+	}
+
+	hooksStatus := cluster.Status.PreUpgradeHooks
+	if hooksStatus == nil || hooksStatus.Version != cluster.Spec.Version.String() {
+		hooksStatus = &v1beta1.LifecycleHooksStatus{
+			Version: cluster.Spec.Version.String(),
+		}
+		cluster.Status.PreUpgradeHooks = hooksStatus
+	}
+
+	for i := range cluster.Spec.Lifecycle.PreUpgrade {
+		hook := &cluster.Spec.Lifecycle.PreUpgrade[i]
+		if slices.Contains(hooksStatus.Completed, hook.Name) {
+			continue
+		}
+
+		name := fmt.Sprintf("pre-upgrade-%s-v-%s", hook.Name, sanitizeVersionToName(cluster.Spec.Version))
+		builder := lifecycle.NewHookJobBuilder(cluster, r.Scheme, hook, name, currentVersion)
+
+		job := builder.Build().(*batchv1.Job)
+		err := r.Client.Get(ctx, client.ObjectKeyFromObject(job), job)
+		if apierrors.IsNotFound(err) {
+			err := builder.Update(job)
+			if err != nil {
+				return 0, err
+			}
+
+			err = r.Client.Create(ctx, job)
+			if err != nil {
+				return 0, fmt.Errorf("can't create %s pre-upgrade hook job: %w", hook.Name, err)
+			}
+
+			r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "PreUpgradeHookStarted", "Pre-upgrade hook %s started for version %s", hook.Name, hooksStatus.Version)
+			return 10 * time.Second, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("can't get %s pre-upgrade hook job: %w", hook.Name, err)
+		}
+
+		switch {
+		case isJobSucceeded(job):
+			hooksStatus.Completed = append(hooksStatus.Completed, hook.Name)
+			r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "PreUpgradeHookCompleted", "Pre-upgrade hook %s completed for version %s", hook.Name, hooksStatus.Version)
+		case isJobFailed(job):
+			return 0, fmt.Errorf("pre-upgrade hook %s failed, delete job %s to run it again", hook.Name, job.GetName())
+		default:
+			return 10 * time.Second, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// preUpgradeHooksCompleted returns true if all the pre-upgrade hooks of the provided cluster
+// completed for the version being rolled out.
+func preUpgradeHooksCompleted(cluster *v1beta1.TemporalCluster) bool {
+	if cluster.Spec.Lifecycle == nil || len(cluster.Spec.Lifecycle.PreUpgrade) == 0 {
+		return true
+	}
+
+	hooksStatus := cluster.Status.PreUpgradeHooks
+	if hooksStatus == nil || hooksStatus.Version != cluster.Spec.Version.String() {
+		return false
+	}
+
+	for _, hook := range cluster.Spec.Lifecycle.PreUpgrade {
+		if !slices.Contains(hooksStatus.Completed, hook.Name) {
+			return false
+		}
+	}
+	return true
+}
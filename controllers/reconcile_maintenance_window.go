@@ -0,0 +1,142 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/reconciler"
+	"github.com/alexandrevilain/temporal-operator/pkg/maintenance"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileMaintenanceWindow updates the maintenance window status of the provided cluster.
+// It returns true if disruptive changes have to be held until the next maintenance window.
+func (r *TemporalClusterReconciler) reconcileMaintenanceWindow(cluster *v1beta1.TemporalCluster) (bool, error) {
+	if cluster.Spec.MaintenanceWindow == nil {
+		cluster.Status.MaintenanceWindow = nil
+		return false, nil
+	}
+
+	window, err := maintenance.NewWindow(cluster.Spec.MaintenanceWindow)
+	if err != nil {
+		return false, fmt.Errorf("invalid maintenance window: %w", err)
+	}
+
+	now := time.Now()
+	cluster.Status.MaintenanceWindow = &v1beta1.MaintenanceWindowStatus{
+		Open:            window.IsOpen(now),
+		NextWindowStart: &metav1.Time{Time: window.NextStart(now)},
+	}
+
+	return !cluster.Status.MaintenanceWindow.Open, nil
+}
+
+// versionUpgradeStarted returns true if the rollout of a new version already started:
+// it can't be held anymore once hooks, the canary or services run for the new version.
+func versionUpgradeStarted(cluster *v1beta1.TemporalCluster) bool {
+	if cluster.Status.Rollout != nil || cluster.Status.PreUpgradeHooks != nil || cluster.Status.Canary != nil {
+		return true
+	}
+
+	for _, service := range cluster.Status.Services {
+		if service.Version != cluster.Status.Version {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addPendingChange records a disruptive change queued until the next maintenance window.
+func addPendingChange(cluster *v1beta1.TemporalCluster, change string) {
+	cluster.Status.MaintenanceWindow.Pending = append(cluster.Status.MaintenanceWindow.Pending, change)
+}
+
+// rolloutsHeld returns true if the pod templates of the provided cluster's services can't be changed.
+func rolloutsHeld(cluster *v1beta1.TemporalCluster) bool {
+	return cluster.Status.MaintenanceWindow != nil && !cluster.Status.MaintenanceWindow.Open
+}
+
+// rolloutHoldBuilder wraps a service workload builder and keeps the pod template of the existing workload,
+// so its pods are not restarted outside of the cluster's maintenance window. Other fields, like replicas, are still updated.
+type rolloutHoldBuilder struct {
+	resource.Builder
+	cluster     *v1beta1.TemporalCluster
+	serviceName string
+	current     client.Object
+}
+
+var _ reconciler.CurrentStateBuilder = (*rolloutHoldBuilder)(nil)
+
+func newRolloutHoldBuilder(cluster *v1beta1.TemporalCluster, serviceName string, builder resource.Builder) *rolloutHoldBuilder {
+	return &rolloutHoldBuilder{
+		Builder:     builder,
+		cluster:     cluster,
+		serviceName: serviceName,
+	}
+}
+
+func (b *rolloutHoldBuilder) SetCurrent(current client.Object) {
+	b.current = current
+}
+
+func (b *rolloutHoldBuilder) Update(object client.Object) error {
+	err := b.Builder.Update(object)
+	if err != nil {
+		return err
+	}
+
+	// New workloads don't restart anything.
+	if b.current == nil {
+		return nil
+	}
+
+	template := podTemplate(object)
+	current := podTemplate(b.current)
+	if template == nil || current == nil {
+		return nil
+	}
+
+	// The existing template holds fields defaulted by the API server, only fields set by the builder are compared.
+	if !equality.Semantic.DeepDerivative(*template, *current) {
+		addPendingChange(b.cluster, fmt.Sprintf("%s rollout", b.serviceName))
+	}
+	*template = *current.DeepCopy()
+
+	return nil
+}
+
+// podTemplate returns the pod template of the provided workload, or nil if it's not a deployment or a statefulset.
+func podTemplate(object client.Object) *corev1.PodTemplateSpec {
+	switch workload := object.(type) {
+	case *appsv1.Deployment:
+		return &workload.Spec.Template
+	case *appsv1.StatefulSet:
+		return &workload.Spec.Template
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,110 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"go.temporal.io/server/common/primitives"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// pauseOrder is the order in which services are scaled down when the cluster is paused.
+// Frontends are stopped first so no new requests are accepted, history is stopped last
+// so it can release its shards once nothing depends on it anymore.
+var pauseOrder = []primitives.ServiceName{
+	primitives.FrontendService,
+	primitives.InternalFrontendService,
+	primitives.WorkerService,
+	primitives.MatchingService,
+	primitives.HistoryService,
+}
+
+// servicesToPause returns the services which should be scaled down to zero.
+// A service is only scaled down once all services preceding it in pauseOrder have no running pods left.
+func (r *TemporalClusterReconciler) servicesToPause(ctx context.Context, cluster *v1beta1.TemporalCluster) (map[string]bool, error) {
+	result := map[string]bool{}
+	if !cluster.IsPaused() {
+		return result, nil
+	}
+
+	for _, service := range pauseOrder {
+		serviceName := string(service)
+		result[serviceName] = true
+
+		replicas, err := getServiceReplicas(ctx, r.Client, cluster, serviceName)
+		if err != nil {
+			return nil, err
+		}
+
+		if replicas > 0 {
+			// Wait for this service to be fully scaled down before stopping the next one.
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// isClusterStopped returns true if the cluster is paused and all its services have been scaled down.
+func isClusterStopped(ctx context.Context, c client.Reader, cluster *v1beta1.TemporalCluster) (bool, error) {
+	if !cluster.IsPaused() {
+		return false, nil
+	}
+
+	for _, service := range pauseOrder {
+		replicas, err := getServiceReplicas(ctx, c, cluster, string(service))
+		if err != nil {
+			return false, err
+		}
+		if replicas > 0 {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// getServiceReplicas returns the current number of pods of the provided service's workload.
+func getServiceReplicas(ctx context.Context, c client.Reader, cluster *v1beta1.TemporalCluster, serviceName string) (int32, error) {
+	key := client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.ChildResourceName(serviceName)}
+
+	deployment := &appsv1.Deployment{}
+	err := c.Get(ctx, key, deployment)
+	if err == nil {
+		return deployment.Status.Replicas, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return 0, fmt.Errorf("can't get %s deployment: %w", serviceName, err)
+	}
+
+	statefulSet := &appsv1.StatefulSet{}
+	err = c.Get(ctx, key, statefulSet)
+	if err == nil {
+		return statefulSet.Status.Replicas, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return 0, fmt.Errorf("can't get %s statefulset: %w", serviceName, err)
+	}
+
+	return 0, nil
+}
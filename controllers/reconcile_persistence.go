@@ -30,6 +30,7 @@ import (
 	"github.com/alexandrevilain/temporal-operator/internal/resource/base"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/persistence"
 	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -47,6 +48,8 @@ func datastoreTypeShortName(datastore v1beta1.DatastoreType) string {
 		return "pg"
 	case v1beta1.PostgresSQL12Datastore:
 		return "pg12"
+	case v1beta1.CockroachDBDatastore:
+		return "crdb"
 	case v1beta1.MySQLDatastore:
 		return "my"
 	case v1beta1.MySQL8Datastore:
@@ -69,6 +72,34 @@ func (r *TemporalClusterReconciler) applyStatusDatastoreTypeDefaultValue(status
 	}
 }
 
+// applyStatusSkippedSchemaSetup marks the datastore as ready in the status when its schema is managed out-of-band.
+// As a result, all persistence jobs are skipped for this datastore.
+func (r *TemporalClusterReconciler) applyStatusSkippedSchemaSetup(status *v1beta1.DatastoreStatus, datastore *v1beta1.DatastoreSpec, expectedVersion *version.Version) {
+	if status == nil || datastore == nil || !datastore.SkipSchemaSetup {
+		return
+	}
+
+	status.Created = true
+	status.Setup = true
+	status.SchemaVersion = expectedVersion.DeepCopy()
+	status.Type = datastore.GetType()
+}
+
+// applyStatusAdoptedDatastore marks the datastore as created and set up when the cluster adopts an existing installation.
+// Schemas are then brought to the cluster version by the idempotent schema update jobs.
+// Datastores added once the installation is adopted are created and set up as usual.
+func (r *TemporalClusterReconciler) applyStatusAdoptedDatastore(cluster *v1beta1.TemporalCluster, status *v1beta1.DatastoreStatus, datastore *v1beta1.DatastoreSpec) {
+	if status == nil || datastore == nil || cluster.Spec.Adoption == nil || cluster.Status.Version != "" {
+		return
+	}
+
+	status.Created = true
+	status.Setup = true
+	if status.Type == "" {
+		status.Type = datastore.GetType()
+	}
+}
+
 func (r *TemporalClusterReconciler) reconcilePersistenceStatus(cluster *v1beta1.TemporalCluster) {
 	if cluster.Status.Persistence == nil {
 		cluster.Status.Persistence = new(v1beta1.TemporalPersistenceStatus)
@@ -79,12 +110,16 @@ func (r *TemporalClusterReconciler) reconcilePersistenceStatus(cluster *v1beta1.
 	}
 
 	r.applyStatusDatastoreTypeDefaultValue(cluster.Status.Persistence.DefaultStore, cluster.Spec.Persistence.DefaultStore)
+	r.applyStatusAdoptedDatastore(cluster, cluster.Status.Persistence.DefaultStore, cluster.Spec.Persistence.DefaultStore)
+	r.applyStatusSkippedSchemaSetup(cluster.Status.Persistence.DefaultStore, cluster.Spec.Persistence.DefaultStore, cluster.Spec.Version)
 
 	if cluster.Status.Persistence.VisibilityStore == nil {
 		cluster.Status.Persistence.VisibilityStore = new(v1beta1.DatastoreStatus)
 	}
 
 	r.applyStatusDatastoreTypeDefaultValue(cluster.Status.Persistence.VisibilityStore, cluster.Spec.Persistence.VisibilityStore)
+	r.applyStatusAdoptedDatastore(cluster, cluster.Status.Persistence.VisibilityStore, cluster.Spec.Persistence.VisibilityStore)
+	r.applyStatusSkippedSchemaSetup(cluster.Status.Persistence.VisibilityStore, cluster.Spec.Persistence.VisibilityStore, cluster.Spec.Version)
 
 	if cluster.Spec.Persistence.SecondaryVisibilityStore != nil {
 		if cluster.Status.Persistence.SecondaryVisibilityStore == nil {
@@ -92,6 +127,7 @@ func (r *TemporalClusterReconciler) reconcilePersistenceStatus(cluster *v1beta1.
 		}
 
 		r.applyStatusDatastoreTypeDefaultValue(cluster.Status.Persistence.SecondaryVisibilityStore, cluster.Spec.Persistence.SecondaryVisibilityStore)
+		r.applyStatusSkippedSchemaSetup(cluster.Status.Persistence.SecondaryVisibilityStore, cluster.Spec.Persistence.SecondaryVisibilityStore, cluster.Spec.Version)
 	}
 
 	if cluster.Spec.Persistence.AdvancedVisibilityStore != nil {
@@ -100,6 +136,8 @@ func (r *TemporalClusterReconciler) reconcilePersistenceStatus(cluster *v1beta1.
 		}
 
 		r.applyStatusDatastoreTypeDefaultValue(cluster.Status.Persistence.AdvancedVisibilityStore, cluster.Spec.Persistence.AdvancedVisibilityStore)
+		r.applyStatusAdoptedDatastore(cluster, cluster.Status.Persistence.AdvancedVisibilityStore, cluster.Spec.Persistence.AdvancedVisibilityStore)
+		r.applyStatusSkippedSchemaSetup(cluster.Status.Persistence.AdvancedVisibilityStore, cluster.Spec.Persistence.AdvancedVisibilityStore, cluster.Spec.Version)
 	}
 }
 
@@ -107,6 +145,30 @@ func getDatabaseScriptCommand(script string) []string {
 	return []string{path.Join("/etc/scripts", script)}
 }
 
+// ilmPolicyJob returns the job applying the index lifecycle policy of the provided store, or nil if it has no policy.
+// The job is named after the policy hash so a new job runs each time the policy changes.
+func ilmPolicyJob(store, script string, spec *v1beta1.DatastoreSpec, status func(c *v1beta1.TemporalCluster) *v1beta1.DatastoreStatus) (*reconciler.Job, error) {
+	policyHash, err := persistence.ILMPolicyHash(spec)
+	if err != nil {
+		return nil, fmt.Errorf("can't compute %s index lifecycle policy hash: %w", store, err)
+	}
+	if policyHash == "" {
+		return nil, nil
+	}
+
+	return &reconciler.Job{
+		Name:    fmt.Sprintf("apply-%s-ilm-%s", store, policyHash[:8]),
+		Command: getDatabaseScriptCommand(script),
+		Skip: func(owner runtime.Object) bool {
+			return status(owner.(*v1beta1.TemporalCluster)).ILMPolicyHash == policyHash
+		},
+		ReportSuccess: func(owner runtime.Object) error {
+			status(owner.(*v1beta1.TemporalCluster)).ILMPolicyHash = policyHash
+			return nil
+		},
+	}, nil
+}
+
 // reconcilePersistence tries to reconcile the cluster persistence.
 func (r *TemporalClusterReconciler) reconcilePersistence(ctx context.Context, cluster *v1beta1.TemporalCluster) (time.Duration, error) {
 	// First of all, ensure status fields are set.
@@ -119,7 +181,7 @@ func (r *TemporalClusterReconciler) reconcilePersistence(ctx context.Context, cl
 	}
 
 	// Ensure the serviceaccount used by jobs is up-to-date
-	serviceAccountBuilder := base.NewServiceAccountBuilder(persistence.ServiceNameSuffix, cluster, r.Scheme)
+	serviceAccountBuilder := base.NewServiceAccountBuilder(persistence.ServiceNameSuffix, cluster, r.Scheme, cluster.Spec.JobServiceAccount)
 	_, err = r.Reconciler.ReconcileBuilders(ctx, cluster, []resource.Builder{serviceAccountBuilder})
 	if err != nil {
 		return 0, fmt.Errorf("can't reconcile schema serviceaccount: %w", err)
@@ -310,6 +372,63 @@ func (r *TemporalClusterReconciler) reconcilePersistence(ctx context.Context, cl
 			})
 	}
 
+	// Index lifecycle policies are applied once the schemas are set up, and again each time they change.
+	ilmPolicyJobs := []struct {
+		name   string
+		script string
+		spec   *v1beta1.DatastoreSpec
+		status func(c *v1beta1.TemporalCluster) *v1beta1.DatastoreStatus
+	}{
+		{
+			name:   "visibility",
+			script: persistence.ApplyVisibilityILMPolicyScript,
+			spec:   cluster.Spec.Persistence.VisibilityStore,
+			status: func(c *v1beta1.TemporalCluster) *v1beta1.DatastoreStatus {
+				return c.Status.Persistence.VisibilityStore
+			},
+		},
+		{
+			name:   "2nd-visibility",
+			script: persistence.ApplySecondaryVisibilityILMPolicyScript,
+			spec:   cluster.Spec.Persistence.SecondaryVisibilityStore,
+			status: func(c *v1beta1.TemporalCluster) *v1beta1.DatastoreStatus {
+				return c.Status.Persistence.SecondaryVisibilityStore
+			},
+		},
+		{
+			name:   "advanced-visibility",
+			script: persistence.ApplyAdvancedVisibilityILMPolicyScript,
+			spec:   cluster.Spec.Persistence.AdvancedVisibilityStore,
+			status: func(c *v1beta1.TemporalCluster) *v1beta1.DatastoreStatus {
+				return c.Status.Persistence.AdvancedVisibilityStore
+			},
+		},
+	}
+	for _, j := range ilmPolicyJobs {
+		if j.spec == nil {
+			continue
+		}
+
+		job, err := ilmPolicyJob(j.name, j.script, j.spec, j.status)
+		if err != nil {
+			return 0, err
+		}
+		if job != nil {
+			jobs = append(jobs, job)
+		}
+	}
+
+	for _, job := range jobs {
+		name, reportSuccess := job.Name, job.ReportSuccess
+		job.ReportSuccess = func(owner runtime.Object) error {
+			if err := reportSuccess(owner); err != nil {
+				return err
+			}
+			r.Recorder.Eventf(owner, corev1.EventTypeNormal, "SchemaJobCompleted", "Persistence job %s completed", name)
+			return nil
+		}
+	}
+
 	factory := func(owner runtime.Object, scheme *runtime.Scheme, name string, command []string) resource.Builder {
 		cluster := owner.(*v1beta1.TemporalCluster)
 		return persistence.NewSchemaJobBuilder(cluster, scheme, name, command)
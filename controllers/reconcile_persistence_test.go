@@ -0,0 +1,69 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/persistence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestILMPolicyJob(t *testing.T) {
+	status := func(c *v1beta1.TemporalCluster) *v1beta1.DatastoreStatus { return c.Status.Persistence.VisibilityStore }
+	cluster := &v1beta1.TemporalCluster{
+		Status: v1beta1.TemporalClusterStatus{
+			Persistence: &v1beta1.TemporalPersistenceStatus{
+				VisibilityStore: &v1beta1.DatastoreStatus{Created: true, Setup: true},
+			},
+		},
+	}
+	spec := &v1beta1.DatastoreSpec{
+		Elasticsearch: &v1beta1.ElasticsearchSpec{},
+	}
+
+	job, err := ilmPolicyJob("visibility", persistence.ApplyVisibilityILMPolicyScript, spec, status)
+	require.NoError(t, err)
+	assert.Nil(t, job)
+
+	spec.Elasticsearch.IndexLifecyclePolicy = &v1beta1.ElasticsearchIndexLifecyclePolicySpec{
+		Name:   "temporal",
+		Policy: &apiextensionsv1.JSON{Raw: []byte(`{"phases":{"delete":{"min_age":"30d","actions":{"delete":{}}}}}`)},
+	}
+
+	job, err = ilmPolicyJob("visibility", persistence.ApplyVisibilityILMPolicyScript, spec, status)
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	assert.Equal(t, []string{"/etc/scripts/apply-visibility-ilm-policy.sh"}, job.Command)
+	assert.False(t, job.Skip(cluster))
+
+	require.NoError(t, job.ReportSuccess(cluster))
+	assert.True(t, job.Skip(cluster))
+
+	// Changing the policy runs a new job.
+	spec.Elasticsearch.IndexLifecyclePolicy.Policy = &apiextensionsv1.JSON{Raw: []byte(`{"phases":{"delete":{"min_age":"7d","actions":{"delete":{}}}}}`)}
+
+	updated, err := ilmPolicyJob("visibility", persistence.ApplyVisibilityILMPolicyScript, spec, status)
+	require.NoError(t, err)
+	require.NotNil(t, updated)
+	assert.NotEqual(t, job.Name, updated.Name)
+	assert.False(t, updated.Skip(cluster))
+}
@@ -0,0 +1,352 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	"go.temporal.io/api/operatorservice/v1"
+	"go.temporal.io/api/serviceerror"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcileReplication registers the remote clusters of the provided cluster,
+// and removes the remote clusters previously registered by the operator which are no longer listed.
+func (r *TemporalClusterReconciler) reconcileReplication(ctx context.Context, cluster *v1beta1.TemporalCluster) error {
+	if !cluster.ReplicationEnabled() && len(cluster.Status.RemoteClusters) == 0 {
+		return nil
+	}
+
+	// Remote clusters are registered using the cluster's API.
+	if !cluster.IsReady() {
+		return nil
+	}
+
+	desired, err := r.remoteClustersConnections(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	client, err := temporal.GetClusterClient(ctx, r.Client, cluster)
+	if err != nil {
+		return fmt.Errorf("can't create cluster client: %w", err)
+	}
+	defer client.Close()
+
+	return r.syncRemoteClusters(ctx, cluster, client.OperatorService(), desired)
+}
+
+// syncRemoteClusters registers the desired remote clusters, keyed by their frontend address, using the provided operator service,
+// removes the remote clusters registered by the operator which are no longer desired, and reports the registered ones in the cluster status.
+func (r *TemporalClusterReconciler) syncRemoteClusters(ctx context.Context, cluster *v1beta1.TemporalCluster, operator operatorservice.OperatorServiceClient, desired map[string]bool) error {
+	logger := log.FromContext(ctx)
+
+	registered, err := listRemoteClusters(ctx, operator, cluster.GetClusterMetadata().ClusterName)
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	for address, enabled := range desired {
+		if remote, ok := registered[address]; ok && remote.GetIsConnectionEnabled() == enabled {
+			continue
+		}
+
+		logger.Info("Registering remote cluster", "address", address)
+		_, err := operator.AddOrUpdateRemoteCluster(ctx, &operatorservice.AddOrUpdateRemoteClusterRequest{
+			FrontendAddress:               address,
+			EnableRemoteClusterConnection: enabled,
+		})
+		if err != nil {
+			return fmt.Errorf("can't register remote cluster %s: %w", address, err)
+		}
+		r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "RemoteClusterRegistered", "Remote cluster %s registered", address)
+		changed = true
+	}
+
+	if changed {
+		registered, err = listRemoteClusters(ctx, operator, cluster.GetClusterMetadata().ClusterName)
+		if err != nil {
+			return err
+		}
+	}
+
+	// A remote cluster whose address changed is registered again under the same name: only the names
+	// which aren't registered for any desired address are removed.
+	desiredNames := map[string]bool{}
+	for address := range desired {
+		if remote, ok := registered[address]; ok {
+			desiredNames[remote.GetClusterName()] = true
+		}
+	}
+
+	removed := false
+	for _, remote := range cluster.Status.RemoteClusters {
+		if _, ok := desired[remote.Address]; ok || desiredNames[remote.Name] {
+			continue
+		}
+
+		logger.Info("Removing remote cluster", "name", remote.Name)
+		_, err := operator.RemoveRemoteCluster(ctx, &operatorservice.RemoveRemoteClusterRequest{
+			ClusterName: remote.Name,
+		})
+		if err != nil {
+			var notFound *serviceerror.NotFound
+			if !errors.As(err, &notFound) {
+				return fmt.Errorf("can't remove remote cluster %s: %w", remote.Name, err)
+			}
+		}
+		r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "RemoteClusterRemoved", "Remote cluster %s removed", remote.Name)
+		removed = true
+	}
+
+	if removed {
+		registered, err = listRemoteClusters(ctx, operator, cluster.GetClusterMetadata().ClusterName)
+		if err != nil {
+			return err
+		}
+	}
+
+	addresses := make([]string, 0, len(desired))
+	for address := range desired {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	statuses := []v1beta1.RemoteClusterStatus{}
+	for _, address := range addresses {
+		if remote, ok := registered[address]; ok {
+			statuses = append(statuses, v1beta1.RemoteClusterStatus{
+				Name:    remote.GetClusterName(),
+				Address: address,
+			})
+		}
+	}
+	cluster.Status.RemoteClusters = statuses
+
+	return nil
+}
+
+// remoteClustersTrustBundle returns the CA of the remote clusters referenced using clusterRef, stored in the cluster's trust bundle secret
+// mounted in the services pods to verify the remote clusters frontend and the certificates of their services.
+// It also returns the duration after which the trust bundle should be computed again, when a remote cluster's CA is not issued yet.
+func (r *TemporalClusterReconciler) remoteClustersTrustBundle(ctx context.Context, cluster *v1beta1.TemporalCluster) ([]byte, time.Duration, error) {
+	if !cluster.RemoteClustersTrustBundleEnabled() {
+		return nil, 0, nil
+	}
+
+	var requeueAfter time.Duration
+	bundle := []byte{}
+	for _, remote := range cluster.Spec.Replication.RemoteClusters {
+		if !remote.UsesTrustBundle() {
+			continue
+		}
+
+		remoteCluster := &v1beta1.TemporalCluster{}
+		err := r.Get(ctx, remote.ClusterRef.NamespacedName(cluster), remoteCluster)
+		if err != nil {
+			return nil, 0, fmt.Errorf("can't get remote cluster %s: %w", remote.ClusterRef.Name, err)
+		}
+
+		if !remoteCluster.MTLSWithCertManagerEnabled() || !remoteCluster.Spec.MTLS.FrontendEnabled() {
+			return nil, 0, fmt.Errorf("remote cluster %s doesn't use frontend mTLS issued by cert-manager, set its tls field", remoteCluster.GetName())
+		}
+
+		ca := &corev1.Secret{}
+		name := mtls.CertificateSecretName(remoteCluster, certmanager.FrontendIntermediateCACertificate)
+		err = r.Get(ctx, types.NamespacedName{Namespace: remoteCluster.GetNamespace(), Name: name}, ca)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				// The remote cluster CA is not issued yet.
+				requeueAfter = 30 * time.Second
+				continue
+			}
+			return nil, 0, fmt.Errorf("can't get remote cluster %s CA: %w", remoteCluster.GetName(), err)
+		}
+
+		bundle = append(bundle, bytes.TrimSpace(ca.Data[certmanager.TLSCA])...)
+		bundle = append(bundle, '\n')
+	}
+
+	return bundle, requeueAfter, nil
+}
+
+// getRemoteClustersCASecretKeys returns the namespaced names of the CA secrets of the remote clusters
+// referenced using clusterRef, whose content is stored in the cluster's trust bundle.
+func getRemoteClustersCASecretKeys(cluster *v1beta1.TemporalCluster) []string {
+	if !cluster.RemoteClustersTrustBundleEnabled() {
+		return nil
+	}
+
+	keys := []string{}
+	for _, remote := range cluster.Spec.Replication.RemoteClusters {
+		if !remote.UsesTrustBundle() {
+			continue
+		}
+
+		ref := remote.ClusterRef.NamespacedName(cluster)
+		// Remote clusters using the trust bundle issue their certificates with cert-manager.
+		remoteCluster := &v1beta1.TemporalCluster{ObjectMeta: metav1.ObjectMeta{Name: ref.Name, Namespace: ref.Namespace}}
+		name := remoteCluster.ChildResourceName(certmanager.FrontendIntermediateCACertificate)
+		keys = append(keys, types.NamespacedName{Namespace: ref.Namespace, Name: name}.String())
+	}
+
+	return keys
+}
+
+func addRemoteClustersCASecretsToIndex(rawObj client.Object) []string {
+	cluster, ok := rawObj.(*v1beta1.TemporalCluster)
+	if !ok {
+		return nil
+	}
+
+	return getRemoteClustersCASecretKeys(cluster)
+}
+
+// remoteClustersConnections returns the frontend addresses of the remote clusters of the provided cluster,
+// and whether the connection to each of them is enabled.
+func (r *TemporalClusterReconciler) remoteClustersConnections(ctx context.Context, cluster *v1beta1.TemporalCluster) (map[string]bool, error) {
+	connections := map[string]bool{}
+	if !cluster.ReplicationEnabled() {
+		return connections, nil
+	}
+
+	for _, remote := range cluster.Spec.Replication.RemoteClusters {
+		address := remote.Address
+		if remote.ClusterRef != nil {
+			remoteCluster := &v1beta1.TemporalCluster{}
+			err := r.Get(ctx, remote.ClusterRef.NamespacedName(cluster), remoteCluster)
+			if err != nil {
+				return nil, fmt.Errorf("can't get remote cluster %s: %w", remote.ClusterRef.Name, err)
+			}
+			address = remoteCluster.GetPublicClientAddress()
+		}
+		connections[address] = remote.IsConnectionEnabled()
+	}
+
+	return connections, nil
+}
+
+// listRemoteClusters returns the remote clusters registered in the cluster's metadata, keyed by their address.
+func listRemoteClusters(ctx context.Context, operator operatorservice.OperatorServiceClient, currentCluster string) (map[string]*operatorservice.ClusterMetadata, error) {
+	clusters := map[string]*operatorservice.ClusterMetadata{}
+
+	var nextPageToken []byte
+	for {
+		response, err := operator.ListClusters(ctx, &operatorservice.ListClustersRequest{
+			PageSize:      100,
+			NextPageToken: nextPageToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("can't list clusters: %w", err)
+		}
+
+		for _, metadata := range response.GetClusters() {
+			if metadata.GetClusterName() == currentCluster {
+				continue
+			}
+			clusters[metadata.GetAddress()] = metadata
+		}
+
+		nextPageToken = response.GetNextPageToken()
+		if len(nextPageToken) == 0 {
+			return clusters, nil
+		}
+	}
+}
+
+// addRemoteClusterRef adds the remote cluster to the provided cluster's replication spec, if not already listed.
+// It returns true if the cluster has been updated.
+func addRemoteClusterRef(ctx context.Context, c client.Client, cluster, remote *v1beta1.TemporalCluster) (bool, error) {
+	remoteName := client.ObjectKeyFromObject(remote)
+
+	if cluster.Spec.Replication != nil {
+		for _, spec := range cluster.Spec.Replication.RemoteClusters {
+			if spec.ClusterRef != nil && spec.ClusterRef.NamespacedName(cluster) == remoteName {
+				return false, nil
+			}
+		}
+	}
+
+	original := cluster.DeepCopy()
+	if cluster.Spec.Replication == nil {
+		cluster.Spec.Replication = &v1beta1.ReplicationSpec{}
+	}
+	cluster.Spec.Replication.RemoteClusters = append(cluster.Spec.Replication.RemoteClusters, v1beta1.RemoteClusterSpec{
+		ClusterRef: &v1beta1.TemporalClusterReference{
+			Name:      remote.GetName(),
+			Namespace: remote.GetNamespace(),
+		},
+	})
+
+	err := c.Patch(ctx, cluster, client.MergeFrom(original))
+	if err != nil {
+		return false, fmt.Errorf("can't add remote cluster %s to cluster %s: %w", remote.GetName(), cluster.GetName(), err)
+	}
+
+	return true, nil
+}
+
+// removeRemoteClusterRef removes the remote cluster from the provided cluster's replication spec.
+// The replication spec itself is kept, as global namespaces can't be disabled once enabled.
+func removeRemoteClusterRef(ctx context.Context, c client.Client, cluster, remote *v1beta1.TemporalCluster) error {
+	if cluster.Spec.Replication == nil {
+		return nil
+	}
+
+	remoteName := client.ObjectKeyFromObject(remote)
+	remoteClusters := slices.DeleteFunc(slices.Clone(cluster.Spec.Replication.RemoteClusters), func(spec v1beta1.RemoteClusterSpec) bool {
+		return spec.ClusterRef != nil && spec.ClusterRef.NamespacedName(cluster) == remoteName
+	})
+	if len(remoteClusters) == len(cluster.Spec.Replication.RemoteClusters) {
+		return nil
+	}
+
+	original := cluster.DeepCopy()
+	cluster.Spec.Replication.RemoteClusters = remoteClusters
+
+	err := c.Patch(ctx, cluster, client.MergeFrom(original))
+	if err != nil {
+		return fmt.Errorf("can't remove remote cluster %s from cluster %s: %w", remote.GetName(), cluster.GetName(), err)
+	}
+
+	return nil
+}
+
+// remoteClusterRegistered returns true if the cluster reports the remote cluster as registered.
+func remoteClusterRegistered(cluster, remote *v1beta1.TemporalCluster) bool {
+	name := remote.GetClusterMetadata().ClusterName
+	return slices.ContainsFunc(cluster.Status.RemoteClusters, func(status v1beta1.RemoteClusterStatus) bool {
+		return status.Name == name
+	})
+}
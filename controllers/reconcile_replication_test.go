@@ -0,0 +1,247 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/operatorservice/v1"
+	"go.temporal.io/api/serviceerror"
+	"google.golang.org/grpc"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// fakeOperatorService stores the clusters registered in a temporal cluster metadata, keyed by their name.
+// Remote cluster names are resolved from their frontend address.
+type fakeOperatorService struct {
+	operatorservice.OperatorServiceClient
+
+	names    map[string]string
+	clusters map[string]*operatorservice.ClusterMetadata
+}
+
+func (s *fakeOperatorService) ListClusters(context.Context, *operatorservice.ListClustersRequest, ...grpc.CallOption) (*operatorservice.ListClustersResponse, error) {
+	response := &operatorservice.ListClustersResponse{}
+	for _, cluster := range s.clusters {
+		response.Clusters = append(response.Clusters, cluster)
+	}
+	return response, nil
+}
+
+func (s *fakeOperatorService) AddOrUpdateRemoteCluster(_ context.Context, request *operatorservice.AddOrUpdateRemoteClusterRequest, _ ...grpc.CallOption) (*operatorservice.AddOrUpdateRemoteClusterResponse, error) {
+	name := s.names[request.GetFrontendAddress()]
+	s.clusters[name] = &operatorservice.ClusterMetadata{
+		ClusterName:         name,
+		Address:             request.GetFrontendAddress(),
+		IsConnectionEnabled: request.GetEnableRemoteClusterConnection(),
+	}
+	return &operatorservice.AddOrUpdateRemoteClusterResponse{}, nil
+}
+
+func (s *fakeOperatorService) RemoveRemoteCluster(_ context.Context, request *operatorservice.RemoveRemoteClusterRequest, _ ...grpc.CallOption) (*operatorservice.RemoveRemoteClusterResponse, error) {
+	if _, ok := s.clusters[request.GetClusterName()]; !ok {
+		return nil, serviceerror.NewNotFound("cluster not found")
+	}
+	delete(s.clusters, request.GetClusterName())
+	return &operatorservice.RemoveRemoteClusterResponse{}, nil
+}
+
+func TestSyncRemoteClusters(t *testing.T) {
+	tests := map[string]struct {
+		registered       []*operatorservice.ClusterMetadata
+		status           []v1beta1.RemoteClusterStatus
+		desired          map[string]bool
+		expectedClusters []string
+		expectedStatus   []v1beta1.RemoteClusterStatus
+	}{
+		"new remote cluster": {
+			desired:          map[string]bool{"prod-west.example.com:7233": true},
+			expectedClusters: []string{"prod-east", "prod-west"},
+			expectedStatus: []v1beta1.RemoteClusterStatus{
+				{Name: "prod-west", Address: "prod-west.example.com:7233"},
+			},
+		},
+		"remote cluster address changed": {
+			registered: []*operatorservice.ClusterMetadata{
+				{ClusterName: "prod-west", Address: "prod-west-frontend.demo:7233", IsConnectionEnabled: true},
+			},
+			status: []v1beta1.RemoteClusterStatus{
+				{Name: "prod-west", Address: "prod-west-frontend.demo:7233"},
+			},
+			desired:          map[string]bool{"prod-west.example.com:7233": true},
+			expectedClusters: []string{"prod-east", "prod-west"},
+			expectedStatus: []v1beta1.RemoteClusterStatus{
+				{Name: "prod-west", Address: "prod-west.example.com:7233"},
+			},
+		},
+		"remote cluster removed": {
+			registered: []*operatorservice.ClusterMetadata{
+				{ClusterName: "prod-west", Address: "prod-west.example.com:7233", IsConnectionEnabled: true},
+				{ClusterName: "prod-central", Address: "prod-central.example.com:7233", IsConnectionEnabled: true},
+			},
+			status: []v1beta1.RemoteClusterStatus{
+				{Name: "prod-central", Address: "prod-central.example.com:7233"},
+				{Name: "prod-west", Address: "prod-west.example.com:7233"},
+			},
+			desired:          map[string]bool{"prod-west.example.com:7233": true},
+			expectedClusters: []string{"prod-east", "prod-west"},
+			expectedStatus: []v1beta1.RemoteClusterStatus{
+				{Name: "prod-west", Address: "prod-west.example.com:7233"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			operator := &fakeOperatorService{
+				names: map[string]string{
+					"prod-west.example.com:7233":    "prod-west",
+					"prod-central.example.com:7233": "prod-central",
+				},
+				clusters: map[string]*operatorservice.ClusterMetadata{
+					"prod-east": {ClusterName: "prod-east", Address: "prod-east-frontend.demo:7233"},
+				},
+			}
+			for _, cluster := range test.registered {
+				operator.clusters[cluster.GetClusterName()] = cluster
+			}
+
+			cluster := &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod-east", Namespace: "demo"},
+				Status: v1beta1.TemporalClusterStatus{
+					RemoteClusters: test.status,
+				},
+			}
+
+			scheme := runtime.NewScheme()
+			r := &TemporalClusterReconciler{
+				Base: New(fake.NewClientBuilder().WithScheme(scheme).Build(), scheme, record.NewFakeRecorder(10), nil, 0),
+			}
+
+			err := r.syncRemoteClusters(context.Background(), cluster, operator, test.desired)
+			require.NoError(tt, err)
+
+			clusters := []string{}
+			for name := range operator.clusters {
+				clusters = append(clusters, name)
+			}
+			assert.ElementsMatch(tt, test.expectedClusters, clusters)
+			assert.Equal(tt, "prod-west.example.com:7233", operator.clusters["prod-west"].GetAddress())
+			assert.Equal(tt, test.expectedStatus, cluster.Status.RemoteClusters)
+		})
+	}
+}
+
+func TestRemoteClustersTrustBundle(t *testing.T) {
+	ctx := context.Background()
+
+	mtlsSpec := &v1beta1.MTLSSpec{
+		Provider: v1beta1.CertManagerMTLSProvider,
+		Frontend: &v1beta1.FrontendMTLSSpec{Enabled: true},
+	}
+	cluster := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-east", Namespace: "demo", UID: "cluster-uid"},
+		Spec: v1beta1.TemporalClusterSpec{
+			Version: version.MustNewVersionFromString("1.23.0"),
+			MTLS:    mtlsSpec,
+			Replication: &v1beta1.ReplicationSpec{
+				RemoteClusters: []v1beta1.RemoteClusterSpec{
+					{ClusterRef: &v1beta1.TemporalClusterReference{Name: "prod-west", Namespace: "west"}},
+				},
+			},
+		},
+	}
+	remoteCluster := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-west", Namespace: "west"},
+		Spec: v1beta1.TemporalClusterSpec{
+			Version: version.MustNewVersionFromString("1.23.0"),
+			MTLS:    mtlsSpec,
+		},
+	}
+	remoteCA := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-west-frontend-intermediate-ca-certificate", Namespace: "west"},
+		Data:       map[string][]byte{certmanager.TLSCA: []byte("remote-ca\n")},
+	}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1beta1.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(cluster, remoteCluster, remoteCA).
+		WithIndex(&v1beta1.TemporalCluster{}, datastoreSecretsField, addDatastoresSecretsToIndex).
+		WithIndex(&v1beta1.TemporalCluster{}, mTLSSecretsField, addMTLSSecretsToIndex).
+		WithIndex(&v1beta1.TemporalCluster{}, remoteClustersCASecretsField, addRemoteClustersCASecretsToIndex).
+		WithInterceptorFuncs(interceptor.Funcs{Patch: applyAsUpdate}).
+		Build()
+
+	r := &TemporalClusterReconciler{
+		Base: New(c, scheme, record.NewFakeRecorder(10), coreDiscovery{}, 0),
+	}
+
+	bundle, requeueAfter, err := r.remoteClustersTrustBundle(ctx, cluster)
+	require.NoError(t, err)
+	assert.Zero(t, requeueAfter)
+	assert.Equal(t, "remote-ca\n", string(bundle))
+
+	// The remote cluster CA is watched, even if stored in another namespace.
+	requests := r.secretToClustersMapfunc(ctx, remoteCA)
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: client.ObjectKeyFromObject(remoteCluster)},
+		{NamespacedName: client.ObjectKeyFromObject(cluster)},
+	}, requests)
+
+	_, err = r.Reconciler.ReconcileBuilders(ctx, cluster, []resource.Builder{certmanager.NewRemoteClustersTrustBundleBuilder(cluster, scheme, bundle)})
+	require.NoError(t, err)
+
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: "demo", Name: "prod-east-remote-clusters-trust-bundle"}
+	require.NoError(t, c.Get(ctx, key, secret))
+	assert.Equal(t, bundle, secret.Data[certmanager.TLSCA])
+	assert.True(t, metav1.IsControlledBy(secret, cluster))
+
+	// The trust bundle is deleted once replication is removed.
+	cluster.Spec.Replication = nil
+
+	bundle, _, err = r.remoteClustersTrustBundle(ctx, cluster)
+	require.NoError(t, err)
+	assert.Nil(t, bundle)
+
+	_, err = r.Reconciler.ReconcileBuilders(ctx, cluster, []resource.Builder{certmanager.NewRemoteClustersTrustBundleBuilder(cluster, scheme, bundle)})
+	require.NoError(t, err)
+	assert.True(t, apierrors.IsNotFound(c.Get(ctx, key, secret)))
+}
@@ -0,0 +1,168 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/status"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	temporalclient "go.temporal.io/sdk/client"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultRolloutProgressDeadline is the progress deadline used if none is set.
+const defaultRolloutProgressDeadline = 15 * time.Minute
+
+// reconcileRollback watches the version rollout of the provided cluster, when automatic rollbacks are enabled.
+// If the rollout fails its health gates, it returns the version the services should be rolled back to,
+// until spec.version is changed. Otherwise, it returns the delay after which the rollout should be checked again.
+func (r *TemporalClusterReconciler) reconcileRollback(ctx context.Context, cluster *v1beta1.TemporalCluster) (*version.Version, time.Duration, error) {
+	if cluster.Spec.UpgradeStrategy == nil || cluster.Spec.UpgradeStrategy.AutoRollback == nil || cluster.Status.Version == "" {
+		cluster.Status.Rollout = nil
+		return nil, 0, nil
+	}
+
+	rollout := cluster.Status.Rollout
+
+	if cluster.Status.Version == cluster.Spec.Version.String() {
+		if rollout != nil {
+			cluster.Status.Rollout = nil
+			v1beta1.SetTemporalClusterUpgradeFailed(cluster, metav1.ConditionFalse, v1beta1.UpgradeCompletedReason, "")
+		}
+		return nil, 0, nil
+	}
+
+	// Services would be rolled back to the previous tag while still pulling the new binary by digest.
+	// It's also enforced here as the validating webhook may not be deployed.
+	if cluster.ImageDigestsPinned() {
+		return nil, 0, errors.New("automatic rollbacks are not supported when images are pinned to a digest, remove spec.upgradeStrategy.autoRollback")
+	}
+
+	previousVersion, err := version.NewVersionFromString(cluster.Status.Version)
+	if err != nil {
+		return nil, 0, fmt.Errorf("can't parse current cluster version: %w", err)
+	}
+
+	if rollout == nil || rollout.Version != cluster.Spec.Version.String() {
+		rollout = &v1beta1.VersionRolloutStatus{
+			Version:   cluster.Spec.Version.String(),
+			StartTime: metav1.Now(),
+		}
+		cluster.Status.Rollout = rollout
+		v1beta1.SetTemporalClusterUpgradeFailed(cluster, metav1.ConditionFalse, v1beta1.UpgradeInProgressReason, "")
+	}
+
+	if rollout.RolledBack {
+		return previousVersion, 0, nil
+	}
+
+	// The rollout starts once pre-upgrade hooks completed. The canary frontend has its own health gates,
+	// the rollout starts once it has been verified. Services of paused clusters are scaled down,
+	// the rollout starts once the cluster is resumed.
+	if !preUpgradeHooksCompleted(cluster) || cluster.IsPaused() ||
+		(cluster.Status.Canary != nil && cluster.Status.Canary.Phase != v1beta1.CanarySucceededPhase) {
+		rollout.StartTime = metav1.Now()
+		return nil, 0, nil
+	}
+
+	rollback := func(message string) (*version.Version, time.Duration, error) {
+		rollout.RolledBack = true
+		rollout.Message = message
+		message = fmt.Sprintf("Upgrade to %s failed, services rolled back to %s: %s", rollout.Version, previousVersion, message)
+		v1beta1.SetTemporalClusterUpgradeFailed(cluster, metav1.ConditionTrue, v1beta1.UpgradeRolledBackReason, message)
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, "UpgradeFailed", message)
+		return previousVersion, 0, nil
+	}
+
+	crashLooping, err := r.crashLoopingPods(ctx, cluster)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(crashLooping) > 0 {
+		return rollback(fmt.Sprintf("pods %s are crash-looping", strings.Join(crashLooping, ", ")))
+	}
+
+	progressDeadline := defaultRolloutProgressDeadline
+	if cluster.Spec.UpgradeStrategy.AutoRollback.ProgressDeadline != nil {
+		progressDeadline = cluster.Spec.UpgradeStrategy.AutoRollback.ProgressDeadline.Duration
+	}
+
+	remaining := progressDeadline - time.Since(rollout.StartTime.Time)
+	if remaining > 0 {
+		return nil, min(remaining, 30*time.Second), nil
+	}
+
+	if !status.IsClusterReady(cluster) {
+		return rollback(fmt.Sprintf("services not ready after %s", progressDeadline))
+	}
+
+	if problem := r.checkFrontendHealth(ctx, cluster); problem != "" {
+		return rollback(problem)
+	}
+
+	return nil, 0, nil
+}
+
+// crashLoopingPods returns the names of the cluster's pods running the desired version which are crash-looping.
+func (r *TemporalClusterReconciler) crashLoopingPods(ctx context.Context, cluster *v1beta1.TemporalCluster) ([]string, error) {
+	selector := cluster.SelectorLabels()
+	selector["app.kubernetes.io/version"] = cluster.Spec.Version.String()
+
+	pods := &corev1.PodList{}
+	err := r.Client.List(ctx, pods, client.InNamespace(cluster.Namespace), client.MatchingLabels(selector))
+	if err != nil {
+		return nil, fmt.Errorf("can't list cluster pods: %w", err)
+	}
+
+	result := []string{}
+	for _, pod := range pods.Items {
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.State.Waiting != nil && containerStatus.State.Waiting.Reason == "CrashLoopBackOff" {
+				result = append(result, pod.Name)
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// checkFrontendHealth returns the reason why the cluster's frontend isn't healthy, or an empty string if it is.
+func (r *TemporalClusterReconciler) checkFrontendHealth(ctx context.Context, cluster *v1beta1.TemporalCluster) string {
+	c, err := temporal.GetClusterClient(ctx, r.Client, cluster)
+	if err != nil {
+		return fmt.Sprintf("can't connect to the frontend: %s", err)
+	}
+	defer c.Close()
+
+	_, err = c.CheckHealth(ctx, &temporalclient.CheckHealthRequest{})
+	if err != nil {
+		return fmt.Sprintf("frontend health check failed: %s", err)
+	}
+
+	return ""
+}
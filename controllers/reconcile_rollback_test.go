@@ -0,0 +1,279 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func rolloutTestCluster() *v1beta1.TemporalCluster {
+	return &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+		Spec: v1beta1.TemporalClusterSpec{
+			Version: version.MustNewVersionFromString("1.23.0"),
+			UpgradeStrategy: &v1beta1.UpgradeStrategySpec{
+				AutoRollback: &v1beta1.AutoRollbackSpec{
+					ProgressDeadline: &metav1.Duration{Duration: time.Minute},
+				},
+			},
+		},
+		Status: v1beta1.TemporalClusterStatus{
+			Version: "1.22.4",
+			Services: []v1beta1.ServiceStatus{
+				{Name: "frontend", Version: "1.23.0", Ready: false},
+			},
+		},
+	}
+}
+
+func rolloutTestPod(name, podVersion, waitingReason string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "demo",
+			Labels: map[string]string{
+				"app.kubernetes.io/name":    "prod",
+				"app.kubernetes.io/part-of": "temporal",
+				"app.kubernetes.io/version": podVersion,
+			},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "service",
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{Reason: waitingReason},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestReconcileRollback(t *testing.T) {
+	expiredStart := metav1.NewTime(time.Now().Add(-2 * time.Minute))
+
+	tests := map[string]struct {
+		mutate            func(cluster *v1beta1.TemporalCluster)
+		pods              []client.Object
+		expectedRollback  string
+		expectedRequeue   bool
+		expectedRollout   *v1beta1.VersionRolloutStatus
+		expectedReason    string
+		expectedEvents    int
+		expectRestartedAt bool
+		expectedErr       string
+	}{
+		"auto rollback disabled": {
+			mutate: func(cluster *v1beta1.TemporalCluster) {
+				cluster.Spec.UpgradeStrategy = nil
+				cluster.Status.Rollout = &v1beta1.VersionRolloutStatus{Version: "1.23.0", RolledBack: true}
+			},
+		},
+		"upgrade completed clears the rollout": {
+			mutate: func(cluster *v1beta1.TemporalCluster) {
+				cluster.Status.Version = "1.23.0"
+				cluster.Status.Rollout = &v1beta1.VersionRolloutStatus{Version: "1.23.0", StartTime: expiredStart}
+			},
+			expectedReason: v1beta1.UpgradeCompletedReason,
+		},
+		"rollout starts": {
+			expectedRequeue:   true,
+			expectedRollout:   &v1beta1.VersionRolloutStatus{Version: "1.23.0"},
+			expectedReason:    v1beta1.UpgradeInProgressReason,
+			expectRestartedAt: true,
+		},
+		"crash-looping pods": {
+			pods: []client.Object{
+				rolloutTestPod("prod-frontend-0", "1.23.0", "CrashLoopBackOff"),
+				rolloutTestPod("prod-history-0", "1.23.0", "ContainerCreating"),
+			},
+			expectedRollback: "1.22.4",
+			expectedRollout:  &v1beta1.VersionRolloutStatus{Version: "1.23.0", RolledBack: true, Message: "pods prod-frontend-0 are crash-looping"},
+			expectedReason:   v1beta1.UpgradeRolledBackReason,
+			expectedEvents:   1,
+		},
+		"crash-looping pods of the previous version are ignored": {
+			pods: []client.Object{
+				rolloutTestPod("prod-frontend-0", "1.22.4", "CrashLoopBackOff"),
+			},
+			expectedRequeue: true,
+			expectedRollout: &v1beta1.VersionRolloutStatus{Version: "1.23.0"},
+			expectedReason:  v1beta1.UpgradeInProgressReason,
+		},
+		"progress deadline exceeded": {
+			mutate: func(cluster *v1beta1.TemporalCluster) {
+				cluster.Status.Rollout = &v1beta1.VersionRolloutStatus{Version: "1.23.0", StartTime: expiredStart}
+			},
+			expectedRollback: "1.22.4",
+			expectedRollout:  &v1beta1.VersionRolloutStatus{Version: "1.23.0", RolledBack: true, Message: "services not ready after 1m0s"},
+			expectedReason:   v1beta1.UpgradeRolledBackReason,
+			expectedEvents:   1,
+		},
+		"rolled back rollout stays rolled back": {
+			mutate: func(cluster *v1beta1.TemporalCluster) {
+				cluster.Status.Rollout = &v1beta1.VersionRolloutStatus{Version: "1.23.0", StartTime: expiredStart, RolledBack: true, Message: "services not ready after 1m0s"}
+				cluster.Status.Services[0].Ready = true
+				v1beta1.SetTemporalClusterUpgradeFailed(cluster, metav1.ConditionTrue, v1beta1.UpgradeRolledBackReason, "rolled back")
+			},
+			expectedRollback: "1.22.4",
+			expectedRollout:  &v1beta1.VersionRolloutStatus{Version: "1.23.0", RolledBack: true, Message: "services not ready after 1m0s"},
+			expectedReason:   v1beta1.UpgradeRolledBackReason,
+		},
+		"new version replaces a rolled back rollout": {
+			mutate: func(cluster *v1beta1.TemporalCluster) {
+				cluster.Spec.Version = version.MustNewVersionFromString("1.23.1")
+				cluster.Status.Rollout = &v1beta1.VersionRolloutStatus{Version: "1.23.0", StartTime: expiredStart, RolledBack: true}
+			},
+			expectedRequeue:   true,
+			expectedRollout:   &v1beta1.VersionRolloutStatus{Version: "1.23.1"},
+			expectedReason:    v1beta1.UpgradeInProgressReason,
+			expectRestartedAt: true,
+		},
+		"pinned image digests": {
+			mutate: func(cluster *v1beta1.TemporalCluster) {
+				cluster.Spec.Services = &v1beta1.ServicesSpec{
+					Frontend: &v1beta1.ServiceSpec{ImageDigest: "sha256:bbbb"},
+				}
+				cluster.Status.Rollout = &v1beta1.VersionRolloutStatus{Version: "1.23.0", StartTime: expiredStart}
+			},
+			pods: []client.Object{
+				rolloutTestPod("prod-frontend-0", "1.23.0", "CrashLoopBackOff"),
+			},
+			expectedRollout: &v1beta1.VersionRolloutStatus{Version: "1.23.0"},
+			expectedErr:     "automatic rollbacks are not supported when images are pinned to a digest, remove spec.upgradeStrategy.autoRollback",
+		},
+		"canary not verified": {
+			mutate: func(cluster *v1beta1.TemporalCluster) {
+				cluster.Status.Rollout = &v1beta1.VersionRolloutStatus{Version: "1.23.0", StartTime: expiredStart}
+				cluster.Status.Canary = &v1beta1.CanaryStatus{Version: "1.23.0", Phase: v1beta1.CanarySoakingPhase}
+			},
+			pods: []client.Object{
+				rolloutTestPod("prod-frontend-canary", "1.23.0", "CrashLoopBackOff"),
+			},
+			expectedRollout:   &v1beta1.VersionRolloutStatus{Version: "1.23.0"},
+			expectRestartedAt: true,
+		},
+		"canary verified": {
+			mutate: func(cluster *v1beta1.TemporalCluster) {
+				cluster.Status.Rollout = &v1beta1.VersionRolloutStatus{Version: "1.23.0", StartTime: expiredStart}
+				cluster.Status.Canary = &v1beta1.CanaryStatus{Version: "1.23.0", Phase: v1beta1.CanarySucceededPhase}
+			},
+			expectedRollback: "1.22.4",
+			expectedRollout:  &v1beta1.VersionRolloutStatus{Version: "1.23.0", RolledBack: true, Message: "services not ready after 1m0s"},
+			expectedReason:   v1beta1.UpgradeRolledBackReason,
+			expectedEvents:   1,
+		},
+		"paused cluster": {
+			mutate: func(cluster *v1beta1.TemporalCluster) {
+				cluster.Spec.Paused = true
+				cluster.Status.Rollout = &v1beta1.VersionRolloutStatus{Version: "1.23.0", StartTime: expiredStart}
+			},
+			expectedRollout:   &v1beta1.VersionRolloutStatus{Version: "1.23.0"},
+			expectRestartedAt: true,
+		},
+		"pre-upgrade hooks pending": {
+			mutate: func(cluster *v1beta1.TemporalCluster) {
+				cluster.Spec.Lifecycle = &v1beta1.LifecycleSpec{
+					PreUpgrade: []v1beta1.LifecycleHookSpec{{Name: "drain"}},
+				}
+				cluster.Status.Rollout = &v1beta1.VersionRolloutStatus{Version: "1.23.0", StartTime: expiredStart}
+			},
+			expectedRollout:   &v1beta1.VersionRolloutStatus{Version: "1.23.0"},
+			expectRestartedAt: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			scheme := runtime.NewScheme()
+			utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+			utilruntime.Must(v1beta1.AddToScheme(scheme))
+
+			recorder := record.NewFakeRecorder(10)
+			r := &TemporalClusterReconciler{
+				Base: New(fake.NewClientBuilder().WithScheme(scheme).WithObjects(test.pods...).Build(), scheme, recorder, nil, 0),
+			}
+
+			cluster := rolloutTestCluster()
+			if test.mutate != nil {
+				test.mutate(cluster)
+			}
+
+			rollbackVersion, requeueAfter, err := r.reconcileRollback(context.Background(), cluster)
+			if test.expectedErr != "" {
+				require.EqualError(tt, err, test.expectedErr)
+			} else {
+				require.NoError(tt, err)
+			}
+
+			if test.expectedRollback == "" {
+				assert.Nil(tt, rollbackVersion)
+			} else {
+				require.NotNil(tt, rollbackVersion)
+				assert.Equal(tt, test.expectedRollback, rollbackVersion.String())
+			}
+
+			if test.expectedRequeue {
+				assert.Greater(tt, requeueAfter, time.Duration(0))
+				assert.LessOrEqual(tt, requeueAfter, 30*time.Second)
+			} else {
+				assert.Zero(tt, requeueAfter)
+			}
+
+			if test.expectedRollout == nil {
+				assert.Nil(tt, cluster.Status.Rollout)
+			} else {
+				require.NotNil(tt, cluster.Status.Rollout)
+				assert.Equal(tt, test.expectedRollout.Version, cluster.Status.Rollout.Version)
+				assert.Equal(tt, test.expectedRollout.RolledBack, cluster.Status.Rollout.RolledBack)
+				assert.Equal(tt, test.expectedRollout.Message, cluster.Status.Rollout.Message)
+				if test.expectRestartedAt {
+					assert.WithinDuration(tt, time.Now(), cluster.Status.Rollout.StartTime.Time, 5*time.Second)
+				}
+			}
+
+			condition := apimeta.FindStatusCondition(cluster.Status.Conditions, v1beta1.UpgradeFailedCondition)
+			if test.expectedReason == "" {
+				assert.Nil(tt, condition)
+			} else {
+				require.NotNil(tt, condition)
+				assert.Equal(tt, test.expectedReason, condition.Reason)
+				assert.Equal(tt, test.expectedReason == v1beta1.UpgradeRolledBackReason, condition.Status == metav1.ConditionTrue)
+			}
+
+			assert.Len(tt, recorder.Events, test.expectedEvents)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/alexandrevilain/controller-tools/pkg/hash"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/strings/slices"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	datastoreSecretsField = "spec.persistence.secrets"
+	mTLSSecretsField      = "spec.mTLS.secrets"
+	// remoteClustersCASecretsField indexes clusters by the namespaced names of their remote clusters CA secrets,
+	// which can be stored in other namespaces.
+	remoteClustersCASecretsField = "spec.replication.remoteClusters.caSecrets"
+)
+
+// getDatastoresSecretNames returns the sorted and deduplicated list of secrets referenced by the cluster's datastores.
+func getDatastoresSecretNames(cluster *v1beta1.TemporalCluster) []string {
+	names := []string{}
+	for _, store := range cluster.Spec.Persistence.GetDatastores() {
+		if store == nil {
+			continue
+		}
+
+		for _, name := range store.GetReferencedSecretNames() {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func addDatastoresSecretsToIndex(rawObj client.Object) []string {
+	cluster, ok := rawObj.(*v1beta1.TemporalCluster)
+	if !ok {
+		return nil
+	}
+
+	return getDatastoresSecretNames(cluster)
+}
+
+func addMTLSSecretsToIndex(rawObj client.Object) []string {
+	cluster, ok := rawObj.(*v1beta1.TemporalCluster)
+	if !ok {
+		return nil
+	}
+
+	return mtls.CertificateSecretNames(cluster)
+}
+
+// secretToClustersMapfunc returns reconcile requests for clusters referencing the provided secret
+// in their datastores, as mTLS certificates or as the CA of one of their remote clusters.
+func (r *TemporalClusterReconciler) secretToClustersMapfunc(ctx context.Context, o client.Object) []reconcile.Request {
+	result := []reconcile.Request{}
+	seen := map[types.NamespacedName]bool{}
+	listOptions := [][]client.ListOption{
+		{client.InNamespace(o.GetNamespace()), client.MatchingFields{datastoreSecretsField: o.GetName()}},
+		{client.InNamespace(o.GetNamespace()), client.MatchingFields{mTLSSecretsField: o.GetName()}},
+		{client.MatchingFields{remoteClustersCASecretsField: client.ObjectKeyFromObject(o).String()}},
+	}
+	for _, opts := range listOptions {
+		clusters := &v1beta1.TemporalClusterList{}
+		err := r.Client.List(ctx, clusters, opts...)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "Failed to list TemporalClusters referencing secret, skipping mapping.")
+			return nil
+		}
+
+		for _, cluster := range clusters.Items {
+			cluster := cluster
+			key := client.ObjectKeyFromObject(&cluster)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			result = append(result, reconcile.Request{
+				NamespacedName: key,
+			})
+		}
+	}
+
+	return result
+}
+
+// datastoresSecretsHash computes a hash of the secrets referenced by the cluster's datastores.
+// It returns an empty string if the cluster doesn't reference any secret.
+func (r *TemporalClusterReconciler) datastoresSecretsHash(ctx context.Context, cluster *v1beta1.TemporalCluster) (string, error) {
+	names := getDatastoresSecretNames(cluster)
+	if len(names) == 0 {
+		return "", nil
+	}
+
+	data := map[string]map[string][]byte{}
+	for _, name := range names {
+		secret := &corev1.Secret{}
+		err := r.Client.Get(ctx, types.NamespacedName{Namespace: cluster.GetNamespace(), Name: name}, secret)
+		if err != nil {
+			return "", fmt.Errorf("can't get datastore secret %s: %w", name, err)
+		}
+
+		data[name] = secret.Data
+	}
+
+	return hash.Sha256(data)
+}
@@ -0,0 +1,81 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/status"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcilePausedMessage is the message of the ReconcileSuccess condition while the reconciliation is suspended.
+const reconcilePausedMessage = "Reconciliation is paused by the " + v1beta1.ReconcilePausedAnnotation + " annotation"
+
+// observeClusterStatus updates the services statuses and the Ready condition of the provided cluster
+// from its existing workloads, without changing anything.
+func (r *TemporalClusterReconciler) observeClusterStatus(ctx context.Context, cluster *v1beta1.TemporalCluster) error {
+	objects := []client.Object{}
+	for _, service := range temporalServices {
+		key := client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.ChildResourceName(string(service))}
+
+		deployment := &appsv1.Deployment{}
+		err := r.Client.Get(ctx, key, deployment)
+		if err == nil {
+			deployment.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("Deployment"))
+			objects = append(objects, deployment)
+			continue
+		}
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("can't get %s deployment: %w", service, err)
+		}
+
+		statefulSet := &appsv1.StatefulSet{}
+		err = r.Client.Get(ctx, key, statefulSet)
+		if err == nil {
+			statefulSet.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("StatefulSet"))
+			objects = append(objects, statefulSet)
+			continue
+		}
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("can't get %s statefulset: %w", service, err)
+		}
+	}
+
+	statuses, err := status.ReconciledObjectsToServiceStatuses(cluster, objects)
+	if err != nil {
+		return err
+	}
+
+	for _, status := range statuses {
+		cluster.Status.AddServiceStatus(status)
+	}
+
+	if status.IsClusterReady(cluster) {
+		v1beta1.SetTemporalClusterReady(cluster, metav1.ConditionTrue, v1beta1.ServicesReadyReason, "")
+	} else {
+		v1beta1.SetTemporalClusterReady(cluster, metav1.ConditionFalse, v1beta1.ServicesNotReadyReason, "")
+	}
+
+	return nil
+}
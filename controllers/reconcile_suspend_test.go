@@ -0,0 +1,319 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newSuspendTestClient(objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1beta1.AddToScheme(scheme))
+
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(&v1beta1.TemporalCluster{}, &v1beta1.TemporalNamespace{}).
+		Build()
+}
+
+func suspendTestDeployment(service string, ready bool) *appsv1.Deployment {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "prod-" + service,
+			Namespace:  "demo",
+			Generation: 1,
+			Labels: map[string]string{
+				"app.kubernetes.io/version": "1.22.4",
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "service", Image: "temporalio/server:1.22.4"}},
+				},
+			},
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+			Replicas:           1,
+			UpdatedReplicas:    1,
+			ReadyReplicas:      1,
+			AvailableReplicas:  1,
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+	if !ready {
+		deployment.Status.ReadyReplicas = 0
+		deployment.Status.AvailableReplicas = 0
+	}
+	return deployment
+}
+
+func TestReconcilePausedCluster(t *testing.T) {
+	tests := map[string]struct {
+		specVersion    string
+		historyReady   bool
+		expectedReady  metav1.ConditionStatus
+		expectedReason string
+	}{
+		"services ready": {
+			specVersion:    "1.22.4",
+			historyReady:   true,
+			expectedReady:  metav1.ConditionTrue,
+			expectedReason: v1beta1.ServicesReadyReason,
+		},
+		"service not ready": {
+			specVersion:    "1.22.4",
+			historyReady:   false,
+			expectedReady:  metav1.ConditionFalse,
+			expectedReason: v1beta1.ServicesNotReadyReason,
+		},
+		"upgrade not rolled out": {
+			specVersion:    "1.23.0",
+			historyReady:   true,
+			expectedReady:  metav1.ConditionFalse,
+			expectedReason: v1beta1.ServicesNotReadyReason,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			ctx := context.Background()
+
+			cluster := &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "prod",
+					Namespace:   "demo",
+					Annotations: map[string]string{v1beta1.ReconcilePausedAnnotation: "true"},
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version:          version.MustNewVersionFromString(test.specVersion),
+					NumHistoryShards: 512,
+				},
+				Status: v1beta1.TemporalClusterStatus{
+					Version: "1.22.4",
+					Services: []v1beta1.ServiceStatus{
+						{Name: "frontend", Version: "1.22.3", Ready: false},
+					},
+				},
+			}
+			deployments := []*appsv1.Deployment{
+				suspendTestDeployment("frontend", true),
+				suspendTestDeployment("history", test.historyReady),
+				suspendTestDeployment("matching", true),
+				suspendTestDeployment("worker", true),
+			}
+
+			objects := []client.Object{cluster}
+			for _, deployment := range deployments {
+				objects = append(objects, deployment)
+			}
+
+			c := newSuspendTestClient(objects...)
+			r := &TemporalClusterReconciler{
+				Base: New(c, c.Scheme(), record.NewFakeRecorder(10), nil, 0),
+			}
+
+			// Keep the seeded objects as stored by the client to compare them after the reconciliation.
+			before := &appsv1.DeploymentList{}
+			require.NoError(tt, c.List(ctx, before))
+
+			result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(cluster)})
+			require.NoError(tt, err)
+			assert.Equal(tt, ctrl.Result{}, result)
+
+			after := &appsv1.DeploymentList{}
+			require.NoError(tt, c.List(ctx, after))
+			assert.Equal(tt, before.Items, after.Items)
+
+			for _, list := range []client.ObjectList{&corev1.ConfigMapList{}, &corev1.ServiceList{}, &corev1.ServiceAccountList{}, &appsv1.StatefulSetList{}} {
+				require.NoError(tt, c.List(ctx, list))
+				assert.Zero(tt, apimeta.LenList(list))
+			}
+
+			updated := &v1beta1.TemporalCluster{}
+			require.NoError(tt, c.Get(ctx, client.ObjectKeyFromObject(cluster), updated))
+
+			assert.Empty(tt, updated.GetFinalizers())
+			assert.ElementsMatch(tt, []v1beta1.ServiceStatus{
+				{Name: "frontend", Version: "1.22.4", Ready: true},
+				{Name: "history", Version: "1.22.4", Ready: test.historyReady},
+				{Name: "matching", Version: "1.22.4", Ready: true},
+				{Name: "worker", Version: "1.22.4", Ready: true},
+			}, updated.Status.Services)
+
+			ready := apimeta.FindStatusCondition(updated.Status.Conditions, v1beta1.ReadyCondition)
+			require.NotNil(tt, ready)
+			assert.Equal(tt, test.expectedReady, ready.Status)
+			assert.Equal(tt, test.expectedReason, ready.Reason)
+
+			reconcileSuccess := apimeta.FindStatusCondition(updated.Status.Conditions, v1beta1.ReconcileSuccessCondition)
+			require.NotNil(tt, reconcileSuccess)
+			assert.Equal(tt, metav1.ConditionFalse, reconcileSuccess.Status)
+			assert.Equal(tt, v1beta1.ReconcilePausedReason, reconcileSuccess.Reason)
+		})
+	}
+}
+
+func TestReconcilePausedClusterDeletion(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "prod",
+			Namespace:         "demo",
+			Annotations:       map[string]string{v1beta1.ReconcilePausedAnnotation: "true"},
+			Finalizers:        []string{persistenceFinalizer},
+			DeletionTimestamp: ptr.To(metav1.Now()),
+		},
+		Spec: v1beta1.TemporalClusterSpec{
+			Version:          version.MustNewVersionFromString("1.22.4"),
+			NumHistoryShards: 512,
+		},
+	}
+
+	c := newSuspendTestClient(cluster, suspendTestDeployment("frontend", true), suspendTestDeployment("history", true))
+	r := &TemporalClusterReconciler{
+		Base: New(c, c.Scheme(), record.NewFakeRecorder(10), nil, 0),
+	}
+
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(cluster)})
+	require.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, result)
+
+	// Neither the services are stopped nor the datastores dropped while the reconciliation is paused.
+	deployments := &appsv1.DeploymentList{}
+	require.NoError(t, c.List(ctx, deployments))
+	assert.Len(t, deployments.Items, 2)
+
+	jobs := &batchv1.JobList{}
+	require.NoError(t, c.List(ctx, jobs))
+	assert.Empty(t, jobs.Items)
+
+	updated := &v1beta1.TemporalCluster{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(cluster), updated))
+	assert.Equal(t, []string{persistenceFinalizer}, updated.GetFinalizers())
+
+	reconcileSuccess := apimeta.FindStatusCondition(updated.Status.Conditions, v1beta1.ReconcileSuccessCondition)
+	require.NotNil(t, reconcileSuccess)
+	assert.Equal(t, v1beta1.ReconcilePausedReason, reconcileSuccess.Reason)
+
+	// The datastores are dropped once the reconciliation is resumed.
+	delete(updated.Annotations, v1beta1.ReconcilePausedAnnotation)
+	require.NoError(t, c.Update(ctx, updated))
+
+	result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(cluster)})
+	require.NoError(t, err)
+	assert.Greater(t, result.RequeueAfter, time.Duration(0))
+
+	require.NoError(t, c.List(ctx, deployments))
+	assert.Empty(t, deployments.Items)
+}
+
+func TestReconcilePausedNamespace(t *testing.T) {
+	tests := map[string]struct {
+		deleted            bool
+		expectedFinalizers []string
+	}{
+		"namespace": {
+			deleted:            false,
+			expectedFinalizers: nil,
+		},
+		"deleted namespace": {
+			deleted:            true,
+			expectedFinalizers: []string{deletionFinalizer},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			ctx := context.Background()
+
+			cluster := &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+				Status: v1beta1.TemporalClusterStatus{
+					Conditions: []metav1.Condition{
+						{Type: v1beta1.ReadyCondition, Status: metav1.ConditionTrue, Reason: v1beta1.ServicesReadyReason},
+					},
+				},
+			}
+			namespace := &v1beta1.TemporalNamespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "payments",
+					Namespace:   "demo",
+					Annotations: map[string]string{v1beta1.ReconcilePausedAnnotation: "true"},
+				},
+				Spec: v1beta1.TemporalNamespaceSpec{
+					ClusterRef:    v1beta1.TemporalClusterReference{Name: "prod"},
+					AllowDeletion: true,
+				},
+			}
+			if test.deleted {
+				namespace.SetFinalizers([]string{deletionFinalizer})
+				namespace.SetDeletionTimestamp(ptr.To(metav1.Now()))
+			}
+
+			c := newSuspendTestClient(cluster, namespace)
+			r := &TemporalNamespaceReconciler{
+				Client:   c,
+				Scheme:   c.Scheme(),
+				Recorder: record.NewFakeRecorder(10),
+			}
+
+			result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(namespace)})
+			require.NoError(tt, err)
+			assert.Equal(tt, ctrl.Result{}, result)
+
+			updated := &v1beta1.TemporalNamespace{}
+			require.NoError(tt, c.Get(ctx, client.ObjectKeyFromObject(namespace), updated))
+			assert.Equal(tt, test.expectedFinalizers, updated.GetFinalizers())
+
+			assert.Nil(tt, apimeta.FindStatusCondition(updated.Status.Conditions, v1beta1.ReadyCondition))
+
+			reconcileSuccess := apimeta.FindStatusCondition(updated.Status.Conditions, v1beta1.ReconcileSuccessCondition)
+			require.NotNil(tt, reconcileSuccess)
+			assert.Equal(tt, metav1.ConditionFalse, reconcileSuccess.Status)
+			assert.Equal(tt, v1beta1.ReconcilePausedReason, reconcileSuccess.Reason)
+		})
+	}
+}
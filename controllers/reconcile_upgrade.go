@@ -0,0 +1,74 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+)
+
+// reconcileVersionUpgrade returns the version the provided cluster should be upgraded to in order to reach spec.version.
+// Upgrades skipping minor versions go through each intermediate minor version, the progress is recorded in the cluster status.
+func (r *TemporalClusterReconciler) reconcileVersionUpgrade(cluster *v1beta1.TemporalCluster) (*version.Version, error) {
+	currentVersion, err := version.NewVersionFromString(cluster.Status.Version)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse current cluster version: %w", err)
+	}
+
+	path, err := currentVersion.UpgradePath(cluster.Spec.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	upgrade := cluster.Status.Upgrade
+	if upgrade == nil || upgrade.TargetVersion != cluster.Spec.Version.String() {
+		// Upgrades to the next minor version or to a patch release don't need to be tracked.
+		if len(path) <= 1 {
+			cluster.Status.Upgrade = nil
+			return cluster.Spec.Version, nil
+		}
+		if cluster.ImageDigestsPinned() {
+			return nil, errors.New("upgrades skipping minor versions are not supported when images are pinned to a digest, upgrade one minor version at a time")
+		}
+		upgrade = &v1beta1.VersionUpgradeStatus{
+			TargetVersion: cluster.Spec.Version.String(),
+		}
+	}
+
+	if len(path) == 0 {
+		cluster.Status.Upgrade = nil
+		return cluster.Spec.Version, nil
+	}
+
+	if upgrade.CurrentStep != "" && upgrade.CurrentStep == cluster.Status.Version {
+		upgrade.CompletedSteps = append(upgrade.CompletedSteps, upgrade.CurrentStep)
+	}
+
+	upgrade.CurrentStep = path[0].String()
+	upgrade.RemainingSteps = []string{}
+	for _, step := range path[1:] {
+		upgrade.RemainingSteps = append(upgrade.RemainingSteps, step.String())
+	}
+
+	cluster.Status.Upgrade = upgrade
+
+	return path[0], nil
+}
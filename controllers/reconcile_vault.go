@@ -0,0 +1,173 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/vault"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// serviceAccountTokenPath is the path of the operator's service account token, used for vault kubernetes auth.
+const serviceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token" //nolint:gosec
+
+func (r *TemporalClusterReconciler) getSecretKey(ctx context.Context, namespace string, selector *corev1.SecretKeySelector) ([]byte, error) {
+	secret := &corev1.Secret{}
+	err := r.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: selector.Name}, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := secret.Data[selector.Key]
+	if !ok {
+		return nil, fmt.Errorf("secret %s is missing the %s key", selector.Name, selector.Key)
+	}
+
+	return value, nil
+}
+
+// getVaultClient returns an authenticated vault client for the provided cluster.
+func (r *TemporalClusterReconciler) getVaultClient(ctx context.Context, cluster *v1beta1.TemporalCluster) (*vault.Client, error) {
+	spec := cluster.Spec.MTLS.Vault
+
+	var caBundle []byte
+	if spec.CASecretRef != nil {
+		var err error
+		caBundle, err = r.getSecretKey(ctx, cluster.GetNamespace(), spec.CASecretRef)
+		if err != nil {
+			return nil, fmt.Errorf("can't get vault CA bundle: %w", err)
+		}
+	}
+
+	client, err := vault.NewClient(spec.Address, caBundle)
+	if err != nil {
+		return nil, err
+	}
+
+	if spec.Auth.TokenSecretRef != nil {
+		token, err := r.getSecretKey(ctx, cluster.GetNamespace(), spec.Auth.TokenSecretRef)
+		if err != nil {
+			return nil, fmt.Errorf("can't get vault token: %w", err)
+		}
+
+		client.SetToken(strings.TrimSpace(string(token)))
+		return client, nil
+	}
+
+	jwt, err := os.ReadFile(serviceAccountTokenPath)
+	if err != nil {
+		return nil, fmt.Errorf("can't read service account token: %w", err)
+	}
+
+	err = client.LoginWithKubernetes(ctx, spec.Auth.Kubernetes.GetMountPath(), spec.Auth.Kubernetes.Role, string(jwt))
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// reconcileVaultCertificates issues the cluster's mTLS certificates using vault, and renews them when needed.
+// It returns the duration after which the next certificate should be renewed.
+func (r *TemporalClusterReconciler) reconcileVaultCertificates(ctx context.Context, cluster *v1beta1.TemporalCluster) (time.Duration, error) {
+	logger := log.FromContext(ctx)
+
+	var client *vault.Client
+	var nextRenewal time.Time
+
+	for _, certificate := range vault.Certificates(cluster) {
+		secret := &corev1.Secret{}
+		err := r.Client.Get(ctx, types.NamespacedName{Namespace: cluster.GetNamespace(), Name: certificate.SecretName}, secret)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return 0, err
+		}
+
+		// Keep the current certificate if it's still valid for the request.
+		if err == nil && mtls.ValidateSecret(secret) == nil {
+			cert, err := vault.ParseCertificate(secret)
+			if err == nil && vault.MatchesRequest(cert, certificate.Request) {
+				renewal := vault.RenewalTime(cert, cluster.Spec.MTLS.RenewBefore)
+				if time.Now().Before(renewal) {
+					if nextRenewal.IsZero() || renewal.Before(nextRenewal) {
+						nextRenewal = renewal
+					}
+					continue
+				}
+			}
+		}
+
+		if client == nil {
+			client, err = r.getVaultClient(ctx, cluster)
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		logger.Info("Issuing certificate using vault", "secret", certificate.SecretName)
+
+		issued, err := client.IssueCertificate(ctx, cluster.Spec.MTLS.Vault.GetPKIMountPath(), cluster.Spec.MTLS.Vault.Role, certificate.Request)
+		if err != nil {
+			return 0, err
+		}
+
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      certificate.SecretName,
+				Namespace: cluster.GetNamespace(),
+			},
+		}
+		_, err = controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+			secret.Labels = metadata.Merge(secret.Labels, metadata.GetLabels(cluster, certificate.SecretName, cluster.Spec.Version, cluster.Labels))
+			secret.Type = corev1.SecretTypeTLS
+			secret.Data = vault.SecretData(issued)
+			return controllerutil.SetControllerReference(cluster, secret, r.Scheme)
+		})
+		if err != nil {
+			return 0, fmt.Errorf("can't store certificate in secret %s: %w", certificate.SecretName, err)
+		}
+
+		cert, err := vault.ParseCertificate(secret)
+		if err != nil {
+			return 0, fmt.Errorf("can't parse certificate issued by vault: %w", err)
+		}
+
+		renewal := vault.RenewalTime(cert, cluster.Spec.MTLS.RenewBefore)
+		if nextRenewal.IsZero() || renewal.Before(nextRenewal) {
+			nextRenewal = renewal
+		}
+	}
+
+	if nextRenewal.IsZero() {
+		return 0, nil
+	}
+
+	return time.Until(nextRenewal), nil
+}
@@ -0,0 +1,56 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// reconcileVersionPolicy returns the version the provided cluster should run according to its version policy.
+// With the PinnedMinor policy, it's the newest known patch release of spec.version's minor version,
+// and an event records each automatic upgrade.
+func (r *TemporalClusterReconciler) reconcileVersionPolicy(cluster *v1beta1.TemporalCluster) *version.Version {
+	if cluster.Spec.VersionPolicy != v1beta1.PinnedMinorVersionPolicy || cluster.Spec.Version == nil {
+		cluster.Status.AutoUpgradeVersion = ""
+		return cluster.Spec.Version
+	}
+
+	target := cluster.Spec.Version.LatestPatchRelease()
+
+	// Clusters already running a newer patch release of the same minor version are not downgraded.
+	if current, err := version.NewVersionFromString(cluster.Status.Version); err == nil &&
+		current.Major() == target.Major() && current.Minor() == target.Minor() && current.GreaterThan(target.Version) {
+		target = current
+	}
+
+	if target.Equal(cluster.Spec.Version.Version) {
+		cluster.Status.AutoUpgradeVersion = ""
+		return cluster.Spec.Version
+	}
+
+	if cluster.Status.AutoUpgradeVersion != target.String() {
+		cluster.Status.AutoUpgradeVersion = target.String()
+		if cluster.Status.Version != "" && cluster.Status.Version != target.String() {
+			r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "VersionAutoUpgrade", "Upgrading cluster from %s to patch release %s", cluster.Status.Version, target.String())
+		}
+	}
+
+	return target
+}
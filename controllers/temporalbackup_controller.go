@@ -0,0 +1,259 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alexandrevilain/controller-tools/pkg/patch"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metrics"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/backup"
+)
+
+// TemporalBackupReconciler reconciles a TemporalBackup object.
+type TemporalBackupReconciler struct {
+	Base
+}
+
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalbackups,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalbackups/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalbackups/finalizers,verbs=update
+//+kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *TemporalBackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	logger := log.FromContext(ctx)
+
+	logger.Info("Starting reconciliation")
+
+	temporalBackup := &v1beta1.TemporalBackup{}
+	err := r.Get(ctx, req.NamespacedName, temporalBackup)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			metrics.DeleteObject("TemporalBackup", req.Namespace, req.Name)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	start := time.Now()
+	defer func() {
+		metrics.ObserveReconcile("TemporalBackup", req.Namespace, req.Name, time.Since(start), reterr)
+	}()
+
+	patchHelper, err := patch.NewHelper(temporalBackup, r.Client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	defer func() {
+		// Always attempt to Patch the TemporalBackup object and status after each reconciliation.
+		err := patchHelper.Patch(ctx, temporalBackup)
+		if err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	if !temporalBackup.ObjectMeta.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	cluster := &v1beta1.TemporalCluster{}
+	err = r.Get(ctx, temporalBackup.Spec.ClusterRef.NamespacedName(temporalBackup), cluster)
+	if err != nil {
+		// When rebuilding a lost cluster, the backup is recreated before its cluster is restored.
+		if apierrors.IsNotFound(err) {
+			logger.Info("Skipping backup until referenced cluster exists")
+			v1beta1.SetTemporalBackupReady(temporalBackup, metav1.ConditionFalse, v1beta1.BackupWaitingForClusterReason, fmt.Sprintf("TemporalCluster \"%s\" not found", temporalBackup.Spec.ClusterRef.Name))
+			return reconcile.Result{RequeueAfter: r.Backoff.When(req.NamespacedName)}, nil
+		}
+		return r.handleError(temporalBackup, v1beta1.ReconcileErrorReason, err)
+	}
+
+	err = backup.ValidateDatastores(temporalBackup, cluster)
+	if err != nil {
+		return r.handleError(temporalBackup, v1beta1.ReconcileErrorReason, err)
+	}
+
+	if !temporalBackup.Spec.IsScheduled() && !cluster.IsReady() {
+		logger.Info("Skipping backup until referenced cluster is ready")
+		return reconcile.Result{RequeueAfter: r.Backoff.When(req.NamespacedName)}, nil
+	}
+
+	// The spec is only recorded once for single backups, as it is part of their immutable job.
+	if temporalBackup.Spec.IsScheduled() || temporalBackup.Status.ClusterSpec == nil {
+		spec, err := json.Marshal(cluster.Spec)
+		if err != nil {
+			return r.handleError(temporalBackup, v1beta1.ReconcileErrorReason, fmt.Errorf("can't marshal cluster spec: %w", err))
+		}
+		temporalBackup.Status.ClusterSpec = &apiextensionsv1.JSON{Raw: spec}
+	}
+
+	jobBuilder := backup.NewJobBuilder(temporalBackup, cluster, r.Scheme)
+	builders := []resource.Builder{
+		backup.NewCronJobBuilder(temporalBackup, cluster, r.Scheme),
+	}
+	// The job of a single backup is only created once, it is deleted by the reconciler when a schedule is set.
+	if !jobBuilder.Enabled() {
+		builders = append(builders, jobBuilder)
+	}
+
+	objects, err := r.Reconciler.ReconcileBuilders(ctx, temporalBackup, builders)
+	if err != nil {
+		err = fmt.Errorf("can't reconcile backup jobs: %w", err)
+		return r.handleError(temporalBackup, v1beta1.ReconcileErrorReason, err)
+	}
+
+	for _, object := range objects {
+		if o, ok := object.(*batchv1.CronJob); ok {
+			temporalBackup.Status.LastScheduleTime = o.Status.LastScheduleTime
+			temporalBackup.Status.LastSuccessfulTime = o.Status.LastSuccessfulTime
+			v1beta1.SetTemporalBackupReady(temporalBackup, metav1.ConditionTrue, v1beta1.BackupScheduledReason, fmt.Sprintf("Backups scheduled at \"%s\"", temporalBackup.Spec.Schedule))
+		}
+	}
+
+	if jobBuilder.Enabled() {
+		job, err := getOrCreateJob(ctx, r.Client, jobBuilder)
+		if err != nil {
+			return r.handleError(temporalBackup, v1beta1.ReconcileErrorReason, err)
+		}
+
+		backupID := backup.BackupID(temporalBackup)
+		temporalBackup.Status.BackupID = backupID
+		temporalBackup.Status.Location = backup.Location(temporalBackup, backupID)
+
+		switch {
+		case isJobSucceeded(job):
+			v1beta1.SetTemporalBackupReady(temporalBackup, metav1.ConditionTrue, v1beta1.BackupSucceededReason, "Backup successfully completed")
+		case isJobFailed(job):
+			v1beta1.SetTemporalBackupReady(temporalBackup, metav1.ConditionFalse, v1beta1.BackupFailedReason, "Backup job failed")
+		default:
+			v1beta1.SetTemporalBackupReady(temporalBackup, metav1.ConditionFalse, v1beta1.BackupInProgressReason, "Backup job is running")
+			return r.handleSuccessWithBackoff(temporalBackup)
+		}
+	}
+
+	logger.Info("Successfully reconciled backup", "backup", temporalBackup.GetName())
+
+	return r.handleSuccess(temporalBackup)
+}
+
+// getOrCreateJob returns the job built by the provided builder, creating it if it doesn't exist.
+// The template of a job is immutable: existing jobs are left untouched, only their status is tracked.
+func getOrCreateJob(ctx context.Context, c client.Client, builder resource.Builder) (*batchv1.Job, error) {
+	job, ok := builder.Build().(*batchv1.Job)
+	if !ok {
+		return nil, errors.New("can't cast object to *batchv1.Job")
+	}
+
+	err := c.Get(ctx, client.ObjectKeyFromObject(job), job)
+	if err == nil {
+		return job, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("can't get job %s: %w", job.GetName(), err)
+	}
+
+	err = builder.Update(job)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.Create(ctx, job)
+	if err != nil {
+		return nil, fmt.Errorf("can't create job %s: %w", job.GetName(), err)
+	}
+
+	return job, nil
+}
+
+// isJobSucceeded returns true if the provided job has completed successfully.
+func isJobSucceeded(job *batchv1.Job) bool {
+	return isJobConditionTrue(job, batchv1.JobComplete)
+}
+
+// isJobFailed returns true if the provided job has failed.
+func isJobFailed(job *batchv1.Job) bool {
+	return isJobConditionTrue(job, batchv1.JobFailed)
+}
+
+func isJobConditionTrue(job *batchv1.Job, conditionType batchv1.JobConditionType) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *TemporalBackupReconciler) handleSuccess(temporalBackup *v1beta1.TemporalBackup) (ctrl.Result, error) {
+	return r.handleSuccessWithRequeue(temporalBackup, 0)
+}
+
+func (r *TemporalBackupReconciler) handleError(temporalBackup *v1beta1.TemporalBackup, reason string, err error) (ctrl.Result, error) { //nolint:unparam
+	return r.handleErrorWithRequeue(temporalBackup, reason, err, r.Backoff.When(client.ObjectKeyFromObject(temporalBackup)))
+}
+
+func (r *TemporalBackupReconciler) handleSuccessWithRequeue(temporalBackup *v1beta1.TemporalBackup, requeueAfter time.Duration) (ctrl.Result, error) {
+	r.Backoff.Forget(client.ObjectKeyFromObject(temporalBackup))
+	v1beta1.SetTemporalBackupReconcileSuccess(temporalBackup, metav1.ConditionTrue, v1beta1.ReconcileSuccessReason, "")
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// handleSuccessWithBackoff requeues the backup while its job is running, with increasing delays.
+func (r *TemporalBackupReconciler) handleSuccessWithBackoff(temporalBackup *v1beta1.TemporalBackup) (ctrl.Result, error) {
+	v1beta1.SetTemporalBackupReconcileSuccess(temporalBackup, metav1.ConditionTrue, v1beta1.ReconcileSuccessReason, "")
+	return reconcile.Result{RequeueAfter: r.Backoff.When(client.ObjectKeyFromObject(temporalBackup))}, nil
+}
+
+func (r *TemporalBackupReconciler) handleErrorWithRequeue(temporalBackup *v1beta1.TemporalBackup, reason string, err error, requeueAfter time.Duration) (ctrl.Result, error) {
+	if reason == "" {
+		reason = v1beta1.ReconcileErrorReason
+	}
+	v1beta1.SetTemporalBackupReconcileError(temporalBackup, metav1.ConditionTrue, reason, err.Error())
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TemporalBackupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.TemporalBackup{}).
+		Owns(&batchv1.Job{}).
+		Owns(&batchv1.CronJob{}).
+		WithOptions(controller.Options{RateLimiter: r.Backoff.RateLimiter()}).
+		Complete(r)
+}
@@ -0,0 +1,134 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestBackupJobIsOnlyCreatedOnce(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1beta1.AddToScheme(scheme))
+
+	cluster := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+		Spec: v1beta1.TemporalClusterSpec{
+			Version: version.MustNewVersionFromString("1.23.0"),
+			Persistence: v1beta1.TemporalPersistenceSpec{
+				DefaultStore: &v1beta1.DatastoreSpec{
+					Name: "default",
+					SQL: &v1beta1.SQLSpec{
+						PluginName:   "postgres12",
+						ConnectAddr:  "postgres.demo:5432",
+						User:         "temporal",
+						DatabaseName: "temporal",
+					},
+				},
+				VisibilityStore: &v1beta1.DatastoreSpec{
+					Name: "visibility",
+					SQL: &v1beta1.SQLSpec{
+						PluginName:   "postgres12",
+						ConnectAddr:  "postgres.demo:5432",
+						User:         "temporal",
+						DatabaseName: "temporal_visibility",
+					},
+				},
+			},
+		},
+		Status: v1beta1.TemporalClusterStatus{
+			Conditions: []metav1.Condition{{Type: v1beta1.ReadyCondition, Status: metav1.ConditionTrue}},
+		},
+	}
+	temporalBackup := &v1beta1.TemporalBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-before-upgrade", Namespace: "demo"},
+		Spec: v1beta1.TemporalBackupSpec{
+			ClusterRef: v1beta1.TemporalClusterReference{Name: "prod"},
+			Storage: v1beta1.BackupStorageSpec{
+				S3: &v1beta1.BackupS3Storage{Bucket: "temporal-backups", Region: "eu-west-1"},
+			},
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(cluster, temporalBackup).
+		WithStatusSubresource(&v1beta1.TemporalBackup{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				if patch.Type() == types.ApplyPatchType {
+					return errors.New("field is immutable")
+				}
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+	r := &TemporalBackupReconciler{
+		Base: New(c, scheme, record.NewFakeRecorder(10), coreDiscovery{}, 0),
+	}
+	ctx := context.Background()
+	key := client.ObjectKey{Namespace: "demo", Name: "prod-before-upgrade-backup"}
+
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(temporalBackup)})
+	require.NoError(t, err)
+
+	job := &batchv1.Job{}
+	require.NoError(t, c.Get(ctx, key, job))
+	assert.Equal(t, "1.23.0", job.Labels["app.kubernetes.io/version"])
+
+	// Upgrading the cluster changes the job template, the existing job is left untouched.
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(cluster), cluster))
+	cluster.Spec.Version = version.MustNewVersionFromString("1.24.0")
+	require.NoError(t, c.Update(ctx, cluster))
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: "True"}}
+	require.NoError(t, c.Status().Update(ctx, job))
+
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(temporalBackup)})
+	require.NoError(t, err)
+
+	require.NoError(t, c.Get(ctx, key, job))
+	assert.Equal(t, "1.23.0", job.Labels["app.kubernetes.io/version"])
+
+	result := &v1beta1.TemporalBackup{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(temporalBackup), result))
+	assert.Nil(t, apimeta.FindStatusCondition(result.Status.Conditions, v1beta1.ReconcileErrorCondition))
+
+	condition := apimeta.FindStatusCondition(result.Status.Conditions, v1beta1.ReadyCondition)
+	require.NotNil(t, condition)
+	assert.Equal(t, v1beta1.BackupSucceededReason, condition.Reason)
+}
@@ -30,33 +30,49 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	routev1 "github.com/alexandrevilain/temporal-operator/internal/apis/openshift/route/v1"
 	"github.com/alexandrevilain/temporal-operator/internal/discovery"
+	"github.com/alexandrevilain/temporal-operator/internal/features"
+	"github.com/alexandrevilain/temporal-operator/internal/metrics"
 	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	istionetworkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	istiosecurityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	"github.com/alexandrevilain/controller-tools/pkg/hash"
 	"github.com/alexandrevilain/controller-tools/pkg/patch"
 	"github.com/alexandrevilain/controller-tools/pkg/resource"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/admintools"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/base"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/codecserver"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/config"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/istio"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/spiffe"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/prometheus"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/ui"
 	"github.com/alexandrevilain/temporal-operator/pkg/status"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	configutil "github.com/alexandrevilain/temporal-operator/pkg/temporal/config"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
 )
 
 const (
@@ -70,18 +86,22 @@ type TemporalClusterReconciler struct {
 	AvailableAPIs *discovery.AvailableAPIs
 }
 
-//+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;delete
-//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;delete
-//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=events,verbs=get;create;patch
-//+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update
-//+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;delete
-//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;delete
-//+kubebuilder:rbac:groups="networking.k8s.io",resources=ingresses,verbs=get;list;watch;create;update;delete
-//+kubebuilder:rbac:groups="cert-manager.io",resources=certificates;issuers,verbs=get;list;watch;create;update;delete
-//+kubebuilder:rbac:groups="security.istio.io",resources=peerauthentications,verbs=get;list;watch;create;update;delete
-//+kubebuilder:rbac:groups="networking.istio.io",resources=destinationrules,verbs=get;list;watch;create;update;delete
-//+kubebuilder:rbac:groups="monitoring.coreos.com",resources=servicemonitors,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="networking.k8s.io",resources=ingresses;networkpolicies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="cert-manager.io",resources=certificates;issuers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="security.istio.io",resources=peerauthentications,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="networking.istio.io",resources=destinationrules,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="monitoring.coreos.com",resources=servicemonitors;podmonitors;prometheusrules,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="gateway.networking.k8s.io",resources=httproutes;grpcroutes;tlsroutes,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="route.openshift.io",resources=routes,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="route.openshift.io",resources=routes/custom-host,verbs=create;update
 //+kubebuilder:rbac:groups=temporal.io,resources=temporalclusters,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=temporal.io,resources=temporalclusters/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=temporal.io,resources=temporalclusters/finalizers,verbs=update
@@ -97,16 +117,17 @@ func (r *TemporalClusterReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	err := r.Get(ctx, req.NamespacedName, cluster)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
+			metrics.DeleteCluster(req.Namespace, req.Name)
+			temporal.ForgetClusterClient(req.NamespacedName)
 			return reconcile.Result{}, nil
 		}
 		return reconcile.Result{}, err
 	}
 
-	// Check if the resource has been marked for deletion
-	if !cluster.ObjectMeta.DeletionTimestamp.IsZero() {
-		logger.Info("Deleting temporal cluster", "name", cluster.Name)
-		return reconcile.Result{}, nil
-	}
+	start := time.Now()
+	defer func() {
+		metrics.ObserveReconcile("TemporalCluster", req.Namespace, req.Name, time.Since(start), reterr)
+	}()
 
 	patchHelper, err := patch.NewHelper(cluster, r.Client)
 	if err != nil {
@@ -121,34 +142,241 @@ func (r *TemporalClusterReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 	}()
 
+	// Reconciliation can be suspended during incidents: nothing is changed, only the observed status is updated.
+	// Like for TemporalNamespaces, the deletion is suspended too: the persistence finalizer holds it until the reconciliation is resumed.
+	if v1beta1.IsReconcilePaused(cluster) {
+		logger.Info("Reconciliation is paused, only observing the cluster status")
+		if err := r.observeClusterStatus(ctx, cluster); err != nil {
+			logger.Error(err, "Can't observe cluster status")
+			return r.handleError(cluster, v1beta1.ResourcesReconciliationFailedReason, err)
+		}
+		v1beta1.SetTemporalClusterReconcileSuccess(cluster, metav1.ConditionFalse, v1beta1.ReconcilePausedReason, reconcilePausedMessage)
+		return reconcile.Result{}, nil
+	}
+
+	// Check if the resource has been marked for deletion.
+	if !cluster.ObjectMeta.DeletionTimestamp.IsZero() {
+		logger.Info("Deleting temporal cluster", "name", cluster.Name)
+		requeueAfter, err := r.reconcileDeletion(ctx, cluster)
+		if err != nil {
+			logger.Error(err, "Can't drop cluster datastores")
+			return r.handleError(cluster, v1beta1.PersistenceReconciliationFailedReason, err)
+		}
+		if requeueAfter > 0 {
+			return reconcile.Result{RequeueAfter: requeueAfter}, nil
+		}
+		metrics.DeleteCluster(cluster.Namespace, cluster.Name)
+		return reconcile.Result{}, nil
+	}
+
+	// Datastores with the Delete policy are dropped by the operator before the cluster is removed.
+	r.ensurePersistenceFinalizer(cluster)
+
 	// Check the ready condition
 	cond, exists := v1beta1.GetTemporalClusterReadyCondition(cluster)
 	if !exists || cond.ObservedGeneration != cluster.GetGeneration() {
 		v1beta1.SetTemporalClusterReady(cluster, metav1.ConditionUnknown, v1beta1.ProgressingReason, "")
 	}
 
-	if requeueAfter, err := r.reconcilePersistence(ctx, cluster); err != nil || requeueAfter > 0 {
+	// The number of history shards is set once and forever, changing it corrupts the cluster.
+	// It's also enforced here as the validating webhook may not be deployed.
+	if cluster.Status.NumHistoryShards == 0 {
+		cluster.Status.NumHistoryShards = cluster.Spec.NumHistoryShards
+	} else if cluster.Spec.NumHistoryShards != cluster.Status.NumHistoryShards {
+		err := fmt.Errorf("spec.numHistoryShards can't be changed from %d to %d once the cluster is created, revert it to %d",
+			cluster.Status.NumHistoryShards, cluster.Spec.NumHistoryShards, cluster.Status.NumHistoryShards)
+		logger.Error(err, "Invalid number of history shards")
+		return r.handleError(cluster, v1beta1.TemporalClusterValidationFailedReason, err)
+	}
+
+	// With the PinnedMinor version policy, spec.version is replaced by the newest patch release of its minor version
+	// for this reconciliation, and restored before the cluster is patched.
+	if policyVersion := r.reconcileVersionPolicy(cluster); policyVersion != cluster.Spec.Version {
+		specVersion := cluster.Spec.Version
+		cluster.Spec.Version = policyVersion
+		defer func() {
+			cluster.Spec.Version = specVersion
+		}()
+	}
+
+	// Outside of the maintenance window, version upgrades which didn't start yet are queued:
+	// spec.version is replaced by the running version for this reconciliation, and restored before the cluster is patched.
+	holdChanges, err := r.reconcileMaintenanceWindow(cluster)
+	if err != nil {
+		logger.Error(err, "Invalid maintenance window")
+		return r.handleError(cluster, v1beta1.TemporalClusterValidationFailedReason, err)
+	}
+	if holdChanges && cluster.Status.Version != "" && cluster.Spec.Version != nil &&
+		cluster.Status.Version != cluster.Spec.Version.String() && !versionUpgradeStarted(cluster) {
+		currentVersion, err := version.NewVersionFromString(cluster.Status.Version)
+		if err != nil {
+			return r.handleError(cluster, v1beta1.TemporalClusterValidationFailedReason, err)
+		}
+		logger.Info("Version upgrade queued until the next maintenance window", "version", cluster.Spec.Version.String())
+		addPendingChange(cluster, fmt.Sprintf("version upgrade to %s", cluster.Spec.Version.String()))
+		targetVersion := cluster.Spec.Version
+		cluster.Spec.Version = currentVersion
+		defer func() {
+			cluster.Spec.Version = targetVersion
+		}()
+	}
+
+	// Refuse unsupported version changes (downgrades, unknown upgrade paths) before running schema upgrades.
+	// Like the number of history shards, it's also enforced here as the validating webhook may not be deployed.
+	// Upgrades skipping minor versions are done one step at a time: spec.version is replaced by the current step
+	// for this reconciliation, and restored before the cluster is patched.
+	if cluster.Status.Version != "" && cluster.Spec.Version != nil {
+		stepVersion, err := r.reconcileVersionUpgrade(cluster)
+		if err != nil {
+			logger.Error(err, "Refusing version upgrade")
+			return r.handleError(cluster, v1beta1.VersionUpgradeRefusedReason, err)
+		}
+		if !stepVersion.Equal(cluster.Spec.Version.Version) {
+			logger.Info("Upgrading cluster through an intermediate version", "version", stepVersion.String(), "targetVersion", cluster.Spec.Version.String())
+			targetVersion := cluster.Spec.Version
+			cluster.Spec.Version = stepVersion
+			defer func() {
+				cluster.Spec.Version = targetVersion
+			}()
+		}
+	}
+
+	// Failed upgrades are rolled back: services run the previous version until spec.version is changed.
+	// Schemas are not downgraded, temporal schema upgrades are compatible with the previous version.
+	rollbackVersion, checkRolloutAfter, err := r.reconcileRollback(ctx, cluster)
+	if err != nil {
+		logger.Error(err, "Can't check version rollout")
+		return r.handleError(cluster, v1beta1.ResourcesReconciliationFailedReason, err)
+	}
+	if rollbackVersion != nil {
+		logger.Info("Upgrade failed, running the previous version", "version", rollbackVersion.String())
+		upgradeVersion := cluster.Spec.Version
+		cluster.Spec.Version = rollbackVersion
+		defer func() {
+			cluster.Spec.Version = upgradeVersion
+		}()
+	}
+
+	// Pre-upgrade hooks have to complete before schemas are upgraded and the new version is rolled out.
+	if requeueAfter, err := r.reconcilePreUpgradeHooks(ctx, cluster); err != nil || requeueAfter > 0 {
+		if err != nil {
+			logger.Error(err, "Pre-upgrade hook failed")
+			return r.handleError(cluster, v1beta1.PreUpgradeHookFailedReason, err)
+		}
+		return reconcile.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	// Persistence jobs are not run while the cluster is paused, storage may be under maintenance.
+	if cluster.IsPaused() {
+		logger.Info("Cluster is paused, skipping persistence reconciliation")
+	} else if cluster.Spec.DevMode.IsEnabled() {
+		// In dev mode, SQLite schemas are set up by temporal itself on startup.
+		logger.Info("Cluster is in dev mode, skipping persistence reconciliation")
+	} else if requeueAfter, err := r.reconcilePersistence(ctx, cluster); err != nil || requeueAfter > 0 {
 		if err != nil {
 			logger.Error(err, "Can't reconcile persistence")
-			if requeueAfter == 0 {
-				requeueAfter = 2 * time.Second
-			}
-			return r.handleErrorWithRequeue(cluster, v1beta1.PersistenceReconciliationFailedReason, err, requeueAfter)
+			return r.handleError(cluster, v1beta1.PersistenceReconciliationFailedReason, err)
 		}
 		if requeueAfter > 0 {
 			return reconcile.Result{RequeueAfter: requeueAfter}, nil
 		}
 	}
 
-	if err := r.reconcileResources(ctx, cluster); err != nil {
+	if err := mtls.ValidateSecrets(ctx, r.Client, cluster); err != nil {
+		logger.Error(err, "Invalid mTLS secrets")
+		return r.handleError(cluster, v1beta1.MTLSSecretsValidationFailedReason, err)
+	}
+
+	var renewCertificatesAfter time.Duration
+	if cluster.MTLSWithVaultEnabled() {
+		renewCertificatesAfter, err = r.reconcileVaultCertificates(ctx, cluster)
+		if err != nil {
+			logger.Error(err, "Can't reconcile vault certificates")
+			return r.handleError(cluster, v1beta1.VaultCertificatesIssuanceFailedReason, err)
+		}
+	}
+
+	trustBundle, reconcileTrustBundleAfter, err := r.remoteClustersTrustBundle(ctx, cluster)
+	if err != nil {
+		logger.Error(err, "Can't compute remote clusters trust bundle")
+		return r.handleError(cluster, v1beta1.ReplicationReconciliationFailedReason, err)
+	}
+
+	canaryVersion, checkCanaryAfter, err := r.reconcileCanary(ctx, cluster)
+	if err != nil {
+		logger.Error(err, "Canary upgrade failed")
+		return r.handleError(cluster, v1beta1.CanaryUpgradeFailedReason, err)
+	}
+
+	// Until the canary frontend is verified, all other workloads keep running the current version.
+	if canaryVersion != nil {
+		currentVersion, err := version.NewVersionFromString(cluster.Status.Version)
+		if err != nil {
+			return r.handleError(cluster, v1beta1.CanaryUpgradeFailedReason, err)
+		}
+		upgradeVersion := cluster.Spec.Version
+		cluster.Spec.Version = currentVersion
+		defer func() {
+			cluster.Spec.Version = upgradeVersion
+		}()
+	}
+
+	if err := r.reconcileResources(ctx, cluster, canaryVersion, trustBundle); err != nil {
 		logger.Error(err, "Can't reconcile resources")
-		return r.handleErrorWithRequeue(cluster, v1beta1.ResourcesReconciliationFailedReason, err, 2*time.Second)
+		return r.handleError(cluster, v1beta1.ResourcesReconciliationFailedReason, err)
+	}
+
+	checkCertificatesAfter, err := r.reconcileCertificatesExpiry(ctx, cluster)
+	if err != nil {
+		logger.Error(err, "Can't check certificates expiry")
+		return r.handleError(cluster, v1beta1.CertificatesExpiryCheckFailedReason, err)
+	}
+
+	if err := r.reconcileReplication(ctx, cluster); err != nil {
+		logger.Error(err, "Can't reconcile replication")
+		return r.handleError(cluster, v1beta1.ReplicationReconciliationFailedReason, err)
 	}
 
-	return r.handleSuccess(cluster)
+	if err := r.reconcileDefaultNamespaces(ctx, cluster); err != nil {
+		logger.Error(err, "Can't register default namespaces")
+		return r.handleError(cluster, v1beta1.DefaultNamespacesRegistrationFailedReason, err)
+	}
+
+	requeueAfter := renewCertificatesAfter
+	if checkCertificatesAfter > 0 && (requeueAfter == 0 || checkCertificatesAfter < requeueAfter) {
+		requeueAfter = checkCertificatesAfter
+	}
+	if reconcileTrustBundleAfter > 0 && (requeueAfter == 0 || reconcileTrustBundleAfter < requeueAfter) {
+		requeueAfter = reconcileTrustBundleAfter
+	}
+	if checkCanaryAfter > 0 && (requeueAfter == 0 || checkCanaryAfter < requeueAfter) {
+		requeueAfter = checkCanaryAfter
+	}
+	if checkRolloutAfter > 0 && (requeueAfter == 0 || checkRolloutAfter < requeueAfter) {
+		requeueAfter = checkRolloutAfter
+	}
+
+	// Queued changes are rolled out when the next maintenance window opens.
+	if mw := cluster.Status.MaintenanceWindow; mw != nil && len(mw.Pending) > 0 && mw.NextWindowStart != nil {
+		openWindowAfter := time.Until(mw.NextWindowStart.Time)
+		if openWindowAfter > 0 && (requeueAfter == 0 || openWindowAfter < requeueAfter) {
+			requeueAfter = openWindowAfter
+		}
+	}
+
+	return r.handleSuccessWithRequeue(cluster, requeueAfter)
+}
+
+// temporalServices are the temporal services deployed by the operator.
+var temporalServices = []primitives.ServiceName{
+	primitives.FrontendService,
+	primitives.HistoryService,
+	primitives.MatchingService,
+	primitives.WorkerService,
+	primitives.InternalFrontendService,
 }
 
-func (r *TemporalClusterReconciler) reconcileResources(ctx context.Context, temporalCluster *v1beta1.TemporalCluster) error {
+func (r *TemporalClusterReconciler) reconcileResources(ctx context.Context, temporalCluster *v1beta1.TemporalCluster, canaryVersion *version.Version, trustBundle []byte) error {
 	// reconcile configmap first, then compute its hash.
 	configMapObject, err := r.Reconciler.ReconcileBuilder(ctx,
 		temporalCluster,
@@ -162,17 +390,43 @@ func (r *TemporalClusterReconciler) reconcileResources(ctx context.Context, temp
 		return errors.New("can't cast configmap object to *corev1.ConfigMap")
 	}
 
-	configHash, err := hash.Sha256(configMap.Data)
+	// Include datastores secrets in the hashes, so services are restarted when credentials are rotated.
+	secretsHash, err := r.datastoresSecretsHash(ctx, temporalCluster)
+	if err != nil {
+		return err
+	}
+
+	pausedServices, err := r.servicesToPause(ctx, temporalCluster)
 	if err != nil {
-		return fmt.Errorf("can't compute configmap hash: %w", err)
+		return err
 	}
 
-	builders, err := r.resourceBuilders(temporalCluster, configHash)
+	certificatesHashes, err := r.certificatesHashes(ctx, temporalCluster)
 	if err != nil {
 		return err
 	}
 
-	objects, err := r.Reconciler.ReconcileBuilders(ctx, temporalCluster, builders)
+	servicesConfigHashes, err := servicesConfigHashes(configMap, secretsHash)
+	if err != nil {
+		return err
+	}
+
+	builders, err := r.resourceBuilders(temporalCluster, servicesConfigHashes, certificatesHashes, pausedServices, canaryVersion, trustBundle)
+	if err != nil {
+		return err
+	}
+
+	var objects []client.Object
+	if features.Enabled(features.ResourcePruning) {
+		objects, err = r.Reconciler.ReconcileAndPruneBuilders(ctx, temporalCluster, "resources", builders)
+	} else {
+		objects, err = r.Reconciler.ReconcileBuilders(ctx, temporalCluster, builders)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = r.reconcileAdoptedAdminTools(ctx, temporalCluster)
 	if err != nil {
 		return err
 	}
@@ -186,11 +440,24 @@ func (r *TemporalClusterReconciler) reconcileResources(ctx context.Context, temp
 		temporalCluster.Status.AddServiceStatus(status)
 	}
 
-	if status.ObservedVersionMatchesDesiredVersion(temporalCluster) {
+	// Intermediate steps of a multi-minor upgrade have to be healthy before moving to the next one,
+	// and so do watched rollouts before being considered as completed.
+	upgraded := status.ObservedVersionMatchesDesiredVersion(temporalCluster)
+	if temporalCluster.Status.Upgrade != nil || temporalCluster.Status.Rollout != nil {
+		upgraded = status.IsClusterReady(temporalCluster)
+	}
+
+	if upgraded {
+		previousVersion := temporalCluster.Status.Version
 		temporalCluster.Status.Version = temporalCluster.Spec.Version.String()
+		if previousVersion != "" && previousVersion != temporalCluster.Status.Version {
+			r.Recorder.Eventf(temporalCluster, corev1.EventTypeNormal, "VersionUpgraded", "Cluster upgraded from %s to %s", previousVersion, temporalCluster.Status.Version)
+		}
 	}
 
-	if status.IsClusterReady(temporalCluster) {
+	if temporalCluster.IsPaused() {
+		v1beta1.SetTemporalClusterReady(temporalCluster, metav1.ConditionFalse, v1beta1.PausedReason, "Cluster is paused")
+	} else if status.IsClusterReady(temporalCluster) {
 		v1beta1.SetTemporalClusterReady(temporalCluster, metav1.ConditionTrue, v1beta1.ServicesReadyReason, "")
 	} else {
 		v1beta1.SetTemporalClusterReady(temporalCluster, metav1.ConditionFalse, v1beta1.ServicesNotReadyReason, "")
@@ -199,20 +466,43 @@ func (r *TemporalClusterReconciler) reconcileResources(ctx context.Context, temp
 	return nil
 }
 
-func (r *TemporalClusterReconciler) resourceBuilders(temporalCluster *v1beta1.TemporalCluster, configHash string) ([]resource.Builder, error) {
-	builders := []resource.Builder{
-		base.NewFrontendServiceBuilder(temporalCluster, r.Scheme),
+// servicesConfigHashes returns the hash of the configuration read by each service, so that a service is only
+// restarted when the configuration it reads changes: the config overlays of the other services and their sections,
+// except their ports, are not included in its hash.
+func servicesConfigHashes(configMap *corev1.ConfigMap, secretsHash string) (map[string]string, error) {
+	hashes := map[string]string{}
+	for _, service := range temporalServices {
+		serviceConfig, ok := configMap.Data[meta.ServiceConfigTemplateKey(string(service))]
+		if !ok {
+			serviceConfig = configMap.Data[meta.ConfigTemplateKey]
+		}
+
+		scopedConfig, err := configutil.ScopeToService([]byte(serviceConfig), string(service))
+		if err != nil {
+			return nil, fmt.Errorf("can't scope %s config: %w", service, err)
+		}
+
+		hashes[string(service)], err = hash.Sha256([]string{string(scopedConfig), secretsHash})
+		if err != nil {
+			return nil, fmt.Errorf("can't compute %s config hash: %w", service, err)
+		}
 	}
 
-	services := []primitives.ServiceName{
-		primitives.FrontendService,
-		primitives.HistoryService,
-		primitives.MatchingService,
-		primitives.WorkerService,
-		primitives.InternalFrontendService,
+	return hashes, nil
+}
+
+func (r *TemporalClusterReconciler) resourceBuilders(temporalCluster *v1beta1.TemporalCluster, servicesConfigHashes, certificatesHashes map[string]string, pausedServices map[string]bool, canaryVersion *version.Version, trustBundle []byte) ([]resource.Builder, error) {
+	builders := []resource.Builder{
+		base.NewFrontendServiceBuilder(temporalCluster, r.Scheme),
+		base.NewInternalFrontendServiceBuilder(temporalCluster, r.Scheme),
+		base.NewFrontendRouteBuilder(temporalCluster, r.Scheme),
+		base.NewFrontendGRPCRouteBuilder(temporalCluster, r.Scheme),
+		base.NewFrontendTLSRouteBuilder(temporalCluster, r.Scheme),
+		// The trust bundle is mounted in the services pods.
+		certmanager.NewRemoteClustersTrustBundleBuilder(temporalCluster, r.Scheme, trustBundle),
 	}
 
-	for _, service := range services {
+	for _, service := range temporalServices {
 		specs, err := temporalCluster.Spec.Services.GetServiceSpec(service)
 		if err != nil {
 			return nil, err
@@ -220,17 +510,41 @@ func (r *TemporalClusterReconciler) resourceBuilders(temporalCluster *v1beta1.Te
 
 		serviceName := string(service)
 
-		builders = append(builders, base.NewServiceAccountBuilder(serviceName, temporalCluster, r.Scheme))
-		builders = append(builders, base.NewDeploymentBuilder(serviceName, temporalCluster, r.Scheme, specs, configHash))
+		if pausedServices[serviceName] {
+			specs = specs.DeepCopy()
+			specs.Replicas = ptr.To[int32](0)
+		}
+
+		builders = append(builders, base.NewServiceAccountBuilder(serviceName, temporalCluster, r.Scheme, specs.ServiceAccount))
+		serviceConfigHash := servicesConfigHashes[serviceName]
+
+		var deploymentBuilder, statefulSetBuilder resource.Builder
+		deploymentBuilder = base.NewDeploymentBuilder(serviceName, temporalCluster, r.Scheme, specs, serviceConfigHash, certificatesHashes[serviceName])
+		statefulSetBuilder = base.NewStatefulSetBuilder(serviceName, temporalCluster, r.Scheme, specs, serviceConfigHash, certificatesHashes[serviceName])
+		// Outside of the maintenance window, services pods are not restarted.
+		if rolloutsHeld(temporalCluster) {
+			deploymentBuilder = newRolloutHoldBuilder(temporalCluster, serviceName, deploymentBuilder)
+			statefulSetBuilder = newRolloutHoldBuilder(temporalCluster, serviceName, statefulSetBuilder)
+		}
+
+		builders = append(builders, deploymentBuilder)
+		if service == primitives.FrontendService {
+			builders = append(builders, base.NewFrontendCanaryDeploymentBuilder(temporalCluster, r.Scheme, specs, serviceConfigHash, certificatesHashes[serviceName], canaryVersion))
+		}
+		builders = append(builders, statefulSetBuilder)
 		builders = append(builders, base.NewHeadlessServiceBuilder(serviceName, temporalCluster, r.Scheme, specs))
+		builders = append(builders, base.NewNetworkPolicyBuilder(serviceName, temporalCluster, r.Scheme, specs))
 
 		builders = append(builders, istio.NewPeerAuthenticationBuilder(serviceName, temporalCluster, r.Scheme, specs))
 		builders = append(builders, istio.NewDestinationRuleBuilder(serviceName, temporalCluster, r.Scheme, specs))
 		builders = append(builders, prometheus.NewServiceMonitorBuilder(serviceName, temporalCluster, r.Scheme, specs))
+		builders = append(builders, prometheus.NewPodMonitorBuilder(serviceName, temporalCluster, r.Scheme, specs))
 	}
 
 	builders = append(builders,
 		base.NewDynamicConfigmapBuilder(temporalCluster, r.Scheme),
+		prometheus.NewPrometheusRuleBuilder(temporalCluster, r.Scheme),
+		prometheus.NewGrafanaDashboardsConfigmapBuilder(temporalCluster, r.Scheme),
 		// mTLS
 		certmanager.NewMTLSBootstrapIssuerBuilder(temporalCluster, r.Scheme),
 		certmanager.NewMTLSRootCACertificateBuilder(temporalCluster, r.Scheme),
@@ -241,46 +555,70 @@ func (r *TemporalClusterReconciler) resourceBuilders(temporalCluster *v1beta1.Te
 		certmanager.NewMTLSFrontendIntermediateCACertificateBuilder(temporalCluster, r.Scheme),
 		certmanager.NewMTLSFrontendIntermediateCAIssuerBuilder(temporalCluster, r.Scheme),
 		certmanager.NewMTLSFrontendCertificateBuilder(temporalCluster, r.Scheme),
+		certmanager.NewMTLSFrontendExternalCertificateBuilder(temporalCluster, r.Scheme),
 		certmanager.NewWorkerFrontendClientCertificateBuilder(temporalCluster, r.Scheme),
+		spiffe.NewHelperConfigmapBuilder(temporalCluster, r.Scheme),
 		// UI:
-		ui.NewDeploymentBuilder(temporalCluster, r.Scheme, configHash),
+		ui.NewConfigmapBuilder(temporalCluster, r.Scheme),
+		ui.NewDeploymentBuilder(temporalCluster, r.Scheme, certificatesHashes[meta.ServiceUIName]),
 		ui.NewServiceBuilder(temporalCluster, r.Scheme),
 		ui.NewIngressBuilder(temporalCluster, r.Scheme),
+		ui.NewHTTPRouteBuilder(temporalCluster, r.Scheme),
+		ui.NewRouteBuilder(temporalCluster, r.Scheme),
+		ui.NewHorizontalPodAutoscalerBuilder(temporalCluster, r.Scheme),
+		ui.NewNetworkPolicyBuilder(temporalCluster, r.Scheme),
 		ui.NewFrontendClientCertificateBuilder(temporalCluster, r.Scheme),
+		// Codec server:
+		codecserver.NewDeploymentBuilder(temporalCluster, r.Scheme),
+		codecserver.NewServiceBuilder(temporalCluster, r.Scheme),
+		codecserver.NewIngressBuilder(temporalCluster, r.Scheme),
+		codecserver.NewCertificateBuilder(temporalCluster, r.Scheme),
 		// Admin tools:
-		admintools.NewDeploymentBuilder(temporalCluster, r.Scheme, configHash),
+		admintools.NewDeploymentBuilder(temporalCluster, r.Scheme, certificatesHashes[meta.ServiceAdminTools]),
 		admintools.NewFrontendClientCertificateBuilder(temporalCluster, r.Scheme),
+		admintools.NewNetworkPolicyBuilder(temporalCluster, r.Scheme),
 	)
 
 	return builders, nil
 }
 
-func (r *TemporalClusterReconciler) handleSuccess(cluster *v1beta1.TemporalCluster) (ctrl.Result, error) {
-	return r.handleSuccessWithRequeue(cluster, 0)
-}
-
 func (r *TemporalClusterReconciler) handleSuccessWithRequeue(cluster *v1beta1.TemporalCluster, requeueAfter time.Duration) (ctrl.Result, error) {
 	v1beta1.SetTemporalClusterReconcileSuccess(cluster, metav1.ConditionTrue, v1beta1.ReconcileSuccessReason, "")
 	return reconcile.Result{RequeueAfter: requeueAfter}, nil
 }
 
-func (r *TemporalClusterReconciler) handleErrorWithRequeue(cluster *v1beta1.TemporalCluster, reason string, err error, requeueAfter time.Duration) (ctrl.Result, error) {
-	r.Recorder.Event(cluster, corev1.EventTypeWarning, "ProcessingError", err.Error())
+// handleError reports the error on the cluster and returns it, so the cluster is requeued using the controller rate limiter.
+func (r *TemporalClusterReconciler) handleError(cluster *v1beta1.TemporalCluster, reason string, err error) (ctrl.Result, error) {
+	r.Recorder.Event(cluster, corev1.EventTypeWarning, temporalErrorEventReason(err), err.Error())
 	if reason == "" {
 		reason = v1beta1.ReconcileErrorReason
 	}
 	v1beta1.SetTemporalClusterReconcileError(cluster, metav1.ConditionTrue, reason, err.Error())
-	return reconcile.Result{RequeueAfter: requeueAfter}, err
+	return reconcile.Result{}, err
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *TemporalClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	for _, resource := range []client.Object{&appsv1.Deployment{}, &corev1.ConfigMap{}, &corev1.Service{}, &corev1.ServiceAccount{}, &networkingv1.Ingress{}, &batchv1.Job{}} {
+	for _, resource := range []client.Object{&appsv1.Deployment{}, &appsv1.StatefulSet{}, &corev1.ConfigMap{}, &corev1.Service{}, &corev1.ServiceAccount{}, &networkingv1.Ingress{}, &batchv1.Job{}, &autoscalingv2.HorizontalPodAutoscaler{}} {
 		if err := mgr.GetFieldIndexer().IndexField(context.Background(), resource, ownerKey, addResourceToIndex); err != nil {
 			return err
 		}
 	}
 
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1beta1.TemporalCluster{}, datastoreSecretsField, addDatastoresSecretsToIndex); err != nil {
+		return err
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1beta1.TemporalCluster{}, mTLSSecretsField, addMTLSSecretsToIndex); err != nil {
+		return err
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1beta1.TemporalCluster{}, remoteClustersCASecretsField, addRemoteClustersCASecretsToIndex); err != nil {
+		return err
+	}
+
+	options := controller.Options{RateLimiter: r.Backoff.RateLimiter()}
+
 	controller := ctrl.NewControllerManagedBy(mgr).
 		For(&v1beta1.TemporalCluster{}, builder.WithPredicates(predicate.Or(
 			predicate.GenerationChangedPredicate{},
@@ -288,11 +626,18 @@ func (r *TemporalClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			predicate.AnnotationChangedPredicate{},
 		))).
 		Owns(&appsv1.Deployment{}).
+		Owns(&appsv1.StatefulSet{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&networkingv1.Ingress{}).
-		Owns(&batchv1.Job{})
+		Owns(&networkingv1.NetworkPolicy{}).
+		Owns(&batchv1.Job{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.secretToClustersMapfunc),
+		)
 
 	if r.AvailableAPIs.CertManager {
 		controller = controller.
@@ -319,26 +664,70 @@ func (r *TemporalClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	}
 
 	if r.AvailableAPIs.PrometheusOperator {
-		controller = controller.Owns(&monitoringv1.ServiceMonitor{})
+		controller = controller.Owns(&monitoringv1.ServiceMonitor{}).
+			Owns(&monitoringv1.PodMonitor{}).
+			Owns(&monitoringv1.PrometheusRule{})
 
-		for _, resource := range []client.Object{&monitoringv1.ServiceMonitor{}} {
+		for _, resource := range []client.Object{&monitoringv1.ServiceMonitor{}, &monitoringv1.PodMonitor{}, &monitoringv1.PrometheusRule{}} {
 			if err := mgr.GetFieldIndexer().IndexField(context.Background(), resource, ownerKey, addPromtheusOperatorResourceToIndex); err != nil {
 				return err
 			}
 		}
 	}
 
-	return controller.Complete(r)
+	if r.AvailableAPIs.GatewayAPI {
+		controller = controller.Owns(&gatewayv1.HTTPRoute{})
+
+		for _, resource := range []client.Object{&gatewayv1.HTTPRoute{}} {
+			if err := mgr.GetFieldIndexer().IndexField(context.Background(), resource, ownerKey, addGatewayAPIResourceToIndex); err != nil {
+				return err
+			}
+		}
+	}
+
+	if r.AvailableAPIs.GRPCRoute {
+		controller = controller.Owns(&gatewayv1alpha2.GRPCRoute{})
+
+		for _, resource := range []client.Object{&gatewayv1alpha2.GRPCRoute{}} {
+			if err := mgr.GetFieldIndexer().IndexField(context.Background(), resource, ownerKey, addGatewayAPIResourceToIndex); err != nil {
+				return err
+			}
+		}
+	}
+
+	if r.AvailableAPIs.TLSRoute {
+		controller = controller.Owns(&gatewayv1alpha2.TLSRoute{})
+
+		for _, resource := range []client.Object{&gatewayv1alpha2.TLSRoute{}} {
+			if err := mgr.GetFieldIndexer().IndexField(context.Background(), resource, ownerKey, addGatewayAPIResourceToIndex); err != nil {
+				return err
+			}
+		}
+	}
+
+	if r.AvailableAPIs.OpenShiftRoute {
+		controller = controller.Owns(&routev1.Route{})
+
+		for _, resource := range []client.Object{&routev1.Route{}} {
+			if err := mgr.GetFieldIndexer().IndexField(context.Background(), resource, ownerKey, addOpenShiftRouteResourceToIndex); err != nil {
+				return err
+			}
+		}
+	}
+
+	return controller.WithOptions(options).Complete(r)
 }
 
 func addResourceToIndex(rawObj client.Object) []string {
 	switch resourceObject := rawObj.(type) {
 	case *appsv1.Deployment,
+		*appsv1.StatefulSet,
 		*corev1.ConfigMap,
 		*corev1.Service,
 		*corev1.ServiceAccount,
 		*networkingv1.Ingress,
-		*batchv1.Job:
+		*batchv1.Job,
+		*autoscalingv2.HorizontalPodAutoscaler:
 		owner := metav1.GetControllerOf(resourceObject)
 		return validateAndGetOwner(owner)
 	default:
@@ -373,6 +762,34 @@ func addPromtheusOperatorResourceToIndex(rawObj client.Object) []string {
 	case *monitoringv1.ServiceMonitor:
 		owner := metav1.GetControllerOf(resourceObject)
 		return validateAndGetOwner(owner)
+	case *monitoringv1.PodMonitor:
+		owner := metav1.GetControllerOf(resourceObject)
+		return validateAndGetOwner(owner)
+	case *monitoringv1.PrometheusRule:
+		owner := metav1.GetControllerOf(resourceObject)
+		return validateAndGetOwner(owner)
+	default:
+		return nil
+	}
+}
+
+func addGatewayAPIResourceToIndex(rawObj client.Object) []string {
+	switch resourceObject := rawObj.(type) {
+	case *gatewayv1.HTTPRoute,
+		*gatewayv1alpha2.GRPCRoute,
+		*gatewayv1alpha2.TLSRoute:
+		owner := metav1.GetControllerOf(resourceObject)
+		return validateAndGetOwner(owner)
+	default:
+		return nil
+	}
+}
+
+func addOpenShiftRouteResourceToIndex(rawObj client.Object) []string {
+	switch resourceObject := rawObj.(type) {
+	case *routev1.Route:
+		owner := metav1.GetControllerOf(resourceObject)
+		return validateAndGetOwner(owner)
 	default:
 		return nil
 	}
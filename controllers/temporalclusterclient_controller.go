@@ -32,12 +32,13 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metrics"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
 	"github.com/alexandrevilain/temporal-operator/pkg/kubernetes"
 	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -70,11 +71,17 @@ func (r *TemporalClusterClientReconciler) Reconcile(ctx context.Context, req ctr
 	err := r.Get(ctx, req.NamespacedName, clusterClient)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
+			metrics.DeleteObject("TemporalClusterClient", req.Namespace, req.Name)
 			return reconcile.Result{}, nil
 		}
 		return reconcile.Result{}, err
 	}
 
+	start := time.Now()
+	defer func() {
+		metrics.ObserveReconcile("TemporalClusterClient", req.Namespace, req.Name, time.Since(start), reterr)
+	}()
+
 	// Check if the resource has been marked for deletion
 	if !clusterClient.ObjectMeta.DeletionTimestamp.IsZero() {
 		return reconcile.Result{}, nil
@@ -103,7 +110,7 @@ func (r *TemporalClusterClientReconciler) Reconcile(ctx context.Context, req ctr
 	if !cluster.IsReady() {
 		logger.Info("Skipping cluster client reconciliation until referenced cluster is ready")
 
-		return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+		return reconcile.Result{RequeueAfter: r.Backoff.When(req.NamespacedName)}, nil
 	}
 
 	if !(cluster.MTLSWithCertManagerEnabled() && cluster.Spec.MTLS.FrontendEnabled()) {
@@ -118,11 +125,7 @@ func (r *TemporalClusterClientReconciler) Reconcile(ctx context.Context, req ctr
 	}
 
 	builder := certmanager.NewGenericFrontendClientCertificateBuilder(cluster, r.Scheme, clusterClient.GetName())
-	certificateObject := builder.Build()
-
-	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, certificateObject, func() error {
-		return builder.Update(certificateObject)
-	})
+	certificateObject, err := r.Reconciler.ReconcileBuilder(ctx, clusterClient, builder)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -132,8 +135,9 @@ func (r *TemporalClusterClientReconciler) Reconcile(ctx context.Context, req ctr
 	condition := certmanagerapiutil.GetCertificateCondition(certificate, certmanagerv1.CertificateConditionReady)
 	if condition == nil || condition.Status != certmanagermeta.ConditionTrue {
 		logger.Info("Waiting for certificate to become ready, requeuing")
-		return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+		return reconcile.Result{RequeueAfter: r.Backoff.When(req.NamespacedName)}, nil
 	}
+	r.Backoff.Forget(req.NamespacedName)
 
 	if clusterClient.GetNamespace() != cluster.GetNamespace() {
 		originalSecret := client.ObjectKey{Namespace: certificate.GetNamespace(), Name: certificate.Spec.SecretName}
@@ -152,6 +156,8 @@ func (r *TemporalClusterClientReconciler) Reconcile(ctx context.Context, req ctr
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *TemporalClusterClientReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	options := controller.Options{RateLimiter: r.Backoff.RateLimiter()}
+
 	controller := ctrl.NewControllerManagedBy(mgr).
 		For(&v1beta1.TemporalClusterClient{})
 
@@ -195,7 +201,7 @@ func (r *TemporalClusterClientReconciler) SetupWithManager(mgr ctrl.Manager) err
 
 	controller.Owns(&corev1.Secret{})
 
-	return controller.Complete(r)
+	return controller.WithOptions(options).Complete(r)
 }
 
 // EnqueueRequestForClusterClientReferencingOwnerCluster returns a reconcile request for any TemporalClusterClient
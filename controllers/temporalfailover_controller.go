@@ -0,0 +1,330 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alexandrevilain/controller-tools/pkg/patch"
+	"go.temporal.io/api/workflowservice/v1"
+	temporalclient "go.temporal.io/sdk/client"
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metrics"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+)
+
+// TemporalFailoverReconciler reconciles a TemporalFailover object.
+type TemporalFailoverReconciler struct {
+	Base
+}
+
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalfailovers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalfailovers/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalfailovers/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *TemporalFailoverReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	logger := log.FromContext(ctx)
+
+	logger.Info("Starting reconciliation")
+
+	failover := &v1beta1.TemporalFailover{}
+	err := r.Get(ctx, req.NamespacedName, failover)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			metrics.DeleteObject("TemporalFailover", req.Namespace, req.Name)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	start := time.Now()
+	defer func() {
+		metrics.ObserveReconcile("TemporalFailover", req.Namespace, req.Name, time.Since(start), reterr)
+	}()
+
+	// A failover is only run once.
+	if failover.IsCompleted() || !failover.ObjectMeta.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(failover, r.Client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	defer func() {
+		// Always attempt to Patch the TemporalFailover object and status after each reconciliation.
+		err := patchHelper.Patch(ctx, failover)
+		if err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	source := &v1beta1.TemporalCluster{}
+	err = r.Get(ctx, failover.Spec.SourceClusterRef.NamespacedName(failover), source)
+	if err != nil {
+		return r.handleError(failover, v1beta1.ReconcileErrorReason, err)
+	}
+
+	target := &v1beta1.TemporalCluster{}
+	err = r.Get(ctx, failover.Spec.ClusterRef.NamespacedName(failover), target)
+	if err != nil {
+		return r.handleError(failover, v1beta1.ReconcileErrorReason, err)
+	}
+
+	if !source.IsReady() || !target.IsReady() {
+		logger.Info("Waiting for the referenced clusters to be ready before failing over")
+		v1beta1.SetTemporalFailoverReady(failover, metav1.ConditionFalse, v1beta1.FailoverInProgressReason, "Waiting for the clusters to be ready")
+		return r.handleSuccessWithBackoff(failover)
+	}
+
+	sourceClient, err := temporal.GetClusterClient(ctx, r.Client, source)
+	if err != nil {
+		err = fmt.Errorf("can't create source cluster client: %w", err)
+		return r.handleError(failover, v1beta1.ReconcileErrorReason, err)
+	}
+	defer sourceClient.Close()
+
+	targetClient, err := temporal.GetClusterClient(ctx, r.Client, target)
+	if err != nil {
+		err = fmt.Errorf("can't create target cluster client: %w", err)
+		return r.handleError(failover, v1beta1.ReconcileErrorReason, err)
+	}
+	defer targetClient.Close()
+
+	sourceName := source.GetClusterMetadata().ClusterName
+	targetName := target.GetClusterMetadata().ClusterName
+
+	if !failover.IsStarted() {
+		err := r.checkFailover(ctx, failover, sourceClient, targetClient, sourceName, targetName)
+		if err != nil {
+			var preCheckErr *failoverPreCheckError
+			if !errors.As(err, &preCheckErr) {
+				return r.handleError(failover, v1beta1.ReconcileErrorReason, err)
+			}
+			r.Recorder.Event(failover, corev1.EventTypeWarning, "FailoverPreCheckFailed", preCheckErr.Error())
+			v1beta1.SetTemporalFailoverReady(failover, metav1.ConditionFalse, v1beta1.FailoverFailedReason, preCheckErr.Error())
+			return r.handleSuccess(failover)
+		}
+
+		// Ensure TemporalNamespaces won't change the active cluster back.
+		err = r.updateNamespacesActiveCluster(ctx, failover, targetName)
+		if err != nil {
+			return r.handleError(failover, v1beta1.ReconcileErrorReason, err)
+		}
+
+		r.Recorder.Eventf(failover, corev1.EventTypeNormal, "FailoverStarted", "Failing over namespaces from cluster %s to cluster %s", sourceName, targetName)
+	}
+
+	verified := true
+	for i := range failover.Status.Namespaces {
+		status := &failover.Status.Namespaces[i]
+		if status.Verified {
+			continue
+		}
+
+		current, err := sourceClient.WorkflowService().DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{Namespace: status.Name})
+		if err != nil {
+			err = fmt.Errorf("can't describe \"%s\" namespace: %w", status.Name, err)
+			return r.handleError(failover, v1beta1.ReconcileErrorReason, err)
+		}
+
+		if current.GetReplicationConfig().GetActiveClusterName() != targetName {
+			logger.Info("Failing over namespace", "namespace", status.Name, "cluster", targetName)
+			_, err = sourceClient.WorkflowService().UpdateNamespace(ctx, temporal.NamespaceFailoverRequest(status.Name, targetName))
+			if err != nil {
+				err = fmt.Errorf("can't fail over \"%s\" namespace: %w", status.Name, err)
+				return r.handleError(failover, v1beta1.ReconcileErrorReason, err)
+			}
+			verified = false
+			continue
+		}
+
+		// The new active cluster must be replicated to the target cluster.
+		replicated, err := targetClient.WorkflowService().DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{Namespace: status.Name})
+		if err != nil {
+			err = fmt.Errorf("can't describe \"%s\" namespace: %w", status.Name, err)
+			return r.handleError(failover, v1beta1.ReconcileErrorReason, err)
+		}
+
+		if replicated.GetReplicationConfig().GetActiveClusterName() != targetName || replicated.GetFailoverVersion() != current.GetFailoverVersion() {
+			verified = false
+			continue
+		}
+
+		status.FailoverVersion = replicated.GetFailoverVersion()
+		status.Verified = true
+	}
+
+	if !verified {
+		v1beta1.SetTemporalFailoverReady(failover, metav1.ConditionFalse, v1beta1.FailoverInProgressReason, "Waiting for both clusters to report the new active cluster")
+		return r.handleSuccessWithBackoff(failover)
+	}
+
+	r.Recorder.Eventf(failover, corev1.EventTypeNormal, "FailoverSucceeded", "Namespaces failed over to cluster %s", targetName)
+	v1beta1.SetTemporalFailoverReady(failover, metav1.ConditionTrue, v1beta1.FailoverSucceededReason, fmt.Sprintf("Namespaces are active in cluster %s", targetName))
+
+	logger.Info("Successfully reconciled failover", "failover", failover.GetName())
+
+	return r.handleSuccess(failover)
+}
+
+// failoverPreCheckError is returned when the failover can't be started.
+type failoverPreCheckError struct {
+	err error
+}
+
+func (e *failoverPreCheckError) Error() string {
+	return e.err.Error()
+}
+
+// checkFailover runs the failover pre-checks and records the namespaces to fail over in the failover status.
+// It returns a *failoverPreCheckError if a pre-check failed.
+func (r *TemporalFailoverReconciler) checkFailover(ctx context.Context, failover *v1beta1.TemporalFailover, sourceClient, targetClient temporalclient.Client, sourceName, targetName string) error {
+	remotes, err := listRemoteClusters(ctx, sourceClient.OperatorService(), sourceName)
+	if err != nil {
+		return err
+	}
+
+	connected := false
+	for _, remote := range remotes {
+		if remote.GetClusterName() == targetName && remote.GetIsConnectionEnabled() {
+			connected = true
+			break
+		}
+	}
+	if !connected {
+		return &failoverPreCheckError{fmt.Errorf("cluster %s is not connected to cluster %s", sourceName, targetName)}
+	}
+
+	statuses := make([]v1beta1.TemporalFailoverNamespaceStatus, 0, len(failover.Spec.Namespaces))
+	for _, name := range failover.Spec.Namespaces {
+		request := &workflowservice.DescribeNamespaceRequest{Namespace: name}
+
+		current, err := sourceClient.WorkflowService().DescribeNamespace(ctx, request)
+		if err != nil {
+			return fmt.Errorf("can't describe \"%s\" namespace in cluster %s: %w", name, sourceName, err)
+		}
+
+		replicated, err := targetClient.WorkflowService().DescribeNamespace(ctx, request)
+		if err != nil {
+			return fmt.Errorf("can't describe \"%s\" namespace in cluster %s: %w", name, targetName, err)
+		}
+
+		if active := current.GetReplicationConfig().GetActiveClusterName(); active != sourceName {
+			return &failoverPreCheckError{fmt.Errorf("namespace %s is active in cluster %s, not in cluster %s", name, active, sourceName)}
+		}
+
+		err = temporal.CheckNamespaceMetadataReplicated(targetName, current, replicated)
+		if err != nil {
+			return &failoverPreCheckError{err}
+		}
+
+		statuses = append(statuses, v1beta1.TemporalFailoverNamespaceStatus{
+			Name:                  name,
+			PreviousActiveCluster: sourceName,
+		})
+	}
+
+	failover.Status.Namespaces = statuses
+
+	return nil
+}
+
+// updateNamespacesActiveCluster sets the active cluster of the TemporalNamespaces managing the failed over namespaces.
+func (r *TemporalFailoverReconciler) updateNamespacesActiveCluster(ctx context.Context, failover *v1beta1.TemporalFailover, activeCluster string) error {
+	namespaces := &v1beta1.TemporalNamespaceList{}
+	err := r.List(ctx, namespaces, client.InNamespace(failover.GetNamespace()))
+	if err != nil {
+		return fmt.Errorf("can't list temporal namespaces: %w", err)
+	}
+
+	clusters := []string{failover.Spec.SourceClusterRef.Name, failover.Spec.ClusterRef.Name}
+	for i := range namespaces.Items {
+		namespace := &namespaces.Items[i]
+		if !slices.Contains(failover.Spec.Namespaces, namespace.GetName()) ||
+			!slices.Contains(clusters, namespace.Spec.ClusterRef.Name) ||
+			namespace.Spec.ActiveClusterName == "" ||
+			namespace.Spec.ActiveClusterName == activeCluster {
+			continue
+		}
+
+		original := namespace.DeepCopy()
+		namespace.Spec.ActiveClusterName = activeCluster
+		err := r.Patch(ctx, namespace, client.MergeFrom(original))
+		if err != nil {
+			return fmt.Errorf("can't update \"%s\" temporal namespace active cluster: %w", namespace.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+func (r *TemporalFailoverReconciler) handleSuccess(failover *v1beta1.TemporalFailover) (ctrl.Result, error) {
+	return r.handleSuccessWithRequeue(failover, 0)
+}
+
+// handleError requeues the failover, as it must be retried until the namespaces are active in the target cluster.
+func (r *TemporalFailoverReconciler) handleError(failover *v1beta1.TemporalFailover, reason string, err error) (ctrl.Result, error) { //nolint:unparam
+	return r.handleErrorWithRequeue(failover, reason, err, r.Backoff.When(client.ObjectKeyFromObject(failover)))
+}
+
+func (r *TemporalFailoverReconciler) handleSuccessWithRequeue(failover *v1beta1.TemporalFailover, requeueAfter time.Duration) (ctrl.Result, error) {
+	r.Backoff.Forget(client.ObjectKeyFromObject(failover))
+	v1beta1.SetTemporalFailoverReconcileSuccess(failover, metav1.ConditionTrue, v1beta1.ReconcileSuccessReason, "")
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// handleSuccessWithBackoff requeues the failover while it waits for the clusters, with increasing delays.
+func (r *TemporalFailoverReconciler) handleSuccessWithBackoff(failover *v1beta1.TemporalFailover) (ctrl.Result, error) {
+	v1beta1.SetTemporalFailoverReconcileSuccess(failover, metav1.ConditionTrue, v1beta1.ReconcileSuccessReason, "")
+	return reconcile.Result{RequeueAfter: r.Backoff.When(client.ObjectKeyFromObject(failover))}, nil
+}
+
+func (r *TemporalFailoverReconciler) handleErrorWithRequeue(failover *v1beta1.TemporalFailover, reason string, err error, requeueAfter time.Duration) (ctrl.Result, error) {
+	r.Recorder.Event(failover, corev1.EventTypeWarning, temporalErrorEventReason(err), err.Error())
+	if reason == "" {
+		reason = v1beta1.ReconcileErrorReason
+	}
+	v1beta1.SetTemporalFailoverReconcileError(failover, metav1.ConditionTrue, reason, err.Error())
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TemporalFailoverReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.TemporalFailover{}).
+		WithOptions(controller.Options{RateLimiter: r.Backoff.RateLimiter()}).
+		Complete(r)
+}
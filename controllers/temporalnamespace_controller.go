@@ -21,18 +21,25 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/alexandrevilain/controller-tools/pkg/patch"
+	"go.temporal.io/api/operatorservice/v1"
 	"go.temporal.io/api/serviceerror"
+	temporalclient "go.temporal.io/sdk/client"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -40,6 +47,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/backoff"
+	"github.com/alexandrevilain/temporal-operator/internal/metrics"
 	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
 )
 
@@ -51,12 +60,17 @@ const (
 // TemporalNamespaceReconciler reconciles a Namespace object.
 type TemporalNamespaceReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// Backoff computes the delays between the reconciliations of namespaces waiting for their cluster or failing.
+	Backoff *backoff.Backoff
 }
 
 //+kubebuilder:rbac:groups=temporal.io,resources=temporalnamespaces,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=temporal.io,resources=temporalnamespaces/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=temporal.io,resources=temporalnamespaces/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=events,verbs=get;create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -69,11 +83,17 @@ func (r *TemporalNamespaceReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	err := r.Get(ctx, req.NamespacedName, namespace)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
+			metrics.DeleteNamespace(req.Namespace, req.Name)
 			return reconcile.Result{}, nil
 		}
 		return reconcile.Result{}, err
 	}
 
+	start := time.Now()
+	defer func() {
+		metrics.ObserveReconcile("TemporalNamespace", req.Namespace, req.Name, time.Since(start), reterr)
+	}()
+
 	patchHelper, err := patch.NewHelper(namespace, r.Client)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -87,6 +107,13 @@ func (r *TemporalNamespaceReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		}
 	}()
 
+	// Reconciliation can be suspended during incidents: the namespace is neither updated nor deleted in the cluster.
+	if v1beta1.IsReconcilePaused(namespace) {
+		logger.Info("Reconciliation is paused, skipping namespace reconciliation")
+		v1beta1.SetTemporalNamespaceReconcileSuccess(namespace, metav1.ConditionFalse, v1beta1.ReconcilePausedReason, reconcilePausedMessage)
+		return reconcile.Result{}, nil
+	}
+
 	cluster := &v1beta1.TemporalCluster{}
 	err = r.Get(ctx, namespace.Spec.ClusterRef.NamespacedName(namespace), cluster)
 	if err != nil {
@@ -103,7 +130,7 @@ func (r *TemporalNamespaceReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	if !cluster.IsReady() {
 		logger.Info("Skipping namespace reconciliation until referenced cluster is ready")
 
-		return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+		return reconcile.Result{RequeueAfter: r.Backoff.When(req.NamespacedName)}, nil
 	}
 
 	// Check if the resource has been marked for deletion
@@ -120,6 +147,11 @@ func (r *TemporalNamespaceReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	// Ensure the namespace have a deletion marker if the AllowDeletion is set to true.
 	r.ensureFinalizer(namespace)
 
+	if temporal.ApplyNamespaceDefaults(cluster, namespace).Spec.RetentionPeriod == nil {
+		err := errors.New("spec.retentionPeriod must be set, as the referenced cluster doesn't set a default retention period")
+		return r.handleError(namespace, v1beta1.ReconcileErrorReason, err)
+	}
+
 	client, err := temporal.GetClusterNamespaceClient(ctx, r.Client, cluster)
 	if err != nil {
 		err = fmt.Errorf("can't create cluster namespace client: %w", err)
@@ -127,25 +159,66 @@ func (r *TemporalNamespaceReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	}
 	defer client.Close()
 
-	err = client.Register(ctx, temporal.NamespaceToRegisterNamespaceRequest(cluster, namespace))
+	err = r.reconcileNamespace(ctx, client, namespace, cluster)
+	if err != nil {
+		return r.handleError(namespace, v1beta1.ReconcileErrorReason, err)
+	}
+
+	err = r.reconcileSearchAttributes(ctx, namespace, cluster)
 	if err != nil {
+		return r.handleError(namespace, v1beta1.ReconcileErrorReason, err)
+	}
+
+	logger.Info("Successfully reconciled namespace", "namespace", namespace.GetName())
+
+	v1beta1.SetTemporalNamespaceReady(namespace, metav1.ConditionTrue, v1beta1.TemporalNamespaceCreatedReason, "Namespace successfully created")
+
+	return r.handleSuccess(namespace)
+}
+
+// reconcileNamespace registers the namespace in the cluster if it doesn't exist yet, or updates it if it differs from its spec.
+// Namespaces in sync with their spec aren't updated, so their metadata version isn't bumped on each reconciliation.
+func (r *TemporalNamespaceReconciler) reconcileNamespace(ctx context.Context, namespaceClient temporalclient.NamespaceClient, namespace *v1beta1.TemporalNamespace, cluster *v1beta1.TemporalCluster) error {
+	logger := log.FromContext(ctx)
+
+	current, err := namespaceClient.Describe(ctx, namespace.GetName())
+	if err != nil {
+		var namespaceNotFoundError *serviceerror.NamespaceNotFound
+		if !errors.As(err, &namespaceNotFoundError) {
+			return fmt.Errorf("can't describe \"%s\" namespace: %w", namespace.GetName(), err)
+		}
+
+		err = namespaceClient.Register(ctx, temporal.NamespaceToRegisterNamespaceRequest(cluster, namespace))
+		// The namespace may have been registered but not be visible yet: it's compared to its spec on the next reconciliation.
 		var namespaceAlreadyExistsError *serviceerror.NamespaceAlreadyExists
-		ok := errors.As(err, &namespaceAlreadyExistsError)
-		if !ok {
-			err = fmt.Errorf("can't create \"%s\" namespace: %w", namespace.GetName(), err)
-			return r.handleError(namespace, v1beta1.ReconcileErrorReason, err)
+		if errors.As(err, &namespaceAlreadyExistsError) {
+			return nil
 		}
-		err = client.Update(ctx, temporal.NamespaceToUpdateNamespaceRequest(cluster, namespace))
 		if err != nil {
-			return r.handleError(namespace, v1beta1.ReconcileErrorReason, err)
+			return fmt.Errorf("can't create \"%s\" namespace: %w", namespace.GetName(), err)
 		}
+
+		r.Recorder.Eventf(namespace, corev1.EventTypeNormal, "NamespaceRegistered", "Namespace registered in cluster %s", cluster.Name)
+		return nil
 	}
 
-	logger.Info("Successfully reconciled namespace", "namespace", namespace.GetName())
+	request := temporal.NamespaceToUpdateNamespaceRequest(cluster, namespace)
+	if !temporal.NamespaceDrifted(request, current) {
+		return nil
+	}
 
-	v1beta1.SetTemporalNamespaceReady(namespace, metav1.ConditionTrue, v1beta1.TemporalNamespaceCreatedReason, "Namespace successfully created")
+	logger.Info("Namespace differs from its spec, updating it", "namespace", namespace.GetName())
+	metrics.NamespaceDrifted(namespace.Namespace, namespace.Name)
 
-	return r.handleSuccess(namespace)
+	err = namespaceClient.Update(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	metrics.NamespaceRepaired(namespace.Namespace, namespace.Name)
+	r.Recorder.Event(namespace, corev1.EventTypeNormal, "NamespaceRepaired", "Namespace updated back to its spec after being changed outside of the operator")
+
+	return nil
 }
 
 // ensureFinalizer ensures the deletion finalizer is set on the object if the user allowed namespace deletion using the CRD.
@@ -182,20 +255,60 @@ func (r *TemporalNamespaceReconciler) ensureNamespaceDeleted(ctx context.Context
 	return nil
 }
 
+// reconcileSearchAttributes adds the namespace's search attributes, including the ones inherited from the cluster, which don't exist yet.
+func (r *TemporalNamespaceReconciler) reconcileSearchAttributes(ctx context.Context, namespace *v1beta1.TemporalNamespace, cluster *v1beta1.TemporalCluster) error {
+	effective := temporal.ApplyNamespaceDefaults(cluster, namespace)
+	if len(effective.Spec.SearchAttributes) == 0 {
+		return nil
+	}
+
+	client, err := temporal.GetClusterClient(ctx, r.Client, cluster)
+	if err != nil {
+		return fmt.Errorf("can't create cluster client: %w", err)
+	}
+	defer client.Close()
+
+	current, err := client.OperatorService().ListSearchAttributes(ctx, &operatorservice.ListSearchAttributesRequest{Namespace: namespace.GetName()})
+	if err != nil {
+		return fmt.Errorf("can't list \"%s\" namespace search attributes: %w", namespace.GetName(), err)
+	}
+
+	request, err := temporal.NamespaceToAddSearchAttributesRequest(effective, current)
+	if err != nil || request == nil {
+		return err
+	}
+
+	_, err = client.OperatorService().AddSearchAttributes(ctx, request)
+	if err != nil {
+		return fmt.Errorf("can't add \"%s\" namespace search attributes: %w", namespace.GetName(), err)
+	}
+
+	names := make([]string, 0, len(request.GetSearchAttributes()))
+	for name := range request.GetSearchAttributes() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	r.Recorder.Eventf(namespace, corev1.EventTypeNormal, "SearchAttributesAdded", "Search attributes added: %s", strings.Join(names, ", "))
+
+	return nil
+}
+
 func (r *TemporalNamespaceReconciler) handleSuccess(namespace *v1beta1.TemporalNamespace) (ctrl.Result, error) {
 	return r.handleSuccessWithRequeue(namespace, 0)
 }
 
 func (r *TemporalNamespaceReconciler) handleError(namespace *v1beta1.TemporalNamespace, reason string, err error) (ctrl.Result, error) { //nolint:unparam
-	return r.handleErrorWithRequeue(namespace, reason, err, 0)
+	return r.handleErrorWithRequeue(namespace, reason, err, r.Backoff.When(client.ObjectKeyFromObject(namespace)))
 }
 
 func (r *TemporalNamespaceReconciler) handleSuccessWithRequeue(namespace *v1beta1.TemporalNamespace, requeueAfter time.Duration) (ctrl.Result, error) {
+	r.Backoff.Forget(client.ObjectKeyFromObject(namespace))
 	v1beta1.SetTemporalNamespaceReconcileSuccess(namespace, metav1.ConditionTrue, v1beta1.ReconcileSuccessReason, "")
 	return reconcile.Result{RequeueAfter: requeueAfter}, nil
 }
 
 func (r *TemporalNamespaceReconciler) handleErrorWithRequeue(namespace *v1beta1.TemporalNamespace, reason string, err error, requeueAfter time.Duration) (ctrl.Result, error) {
+	r.Recorder.Event(namespace, corev1.EventTypeWarning, temporalErrorEventReason(err), err.Error())
 	if reason == "" {
 		reason = v1beta1.ReconcileErrorReason
 	}
@@ -256,5 +369,6 @@ func (r *TemporalNamespaceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&v1beta1.TemporalCluster{},
 			handler.EnqueueRequestsFromMapFunc(r.clusterToNamespacesMapfunc),
 		).
+		WithOptions(controller.Options{RateLimiter: r.Backoff.RateLimiter()}).
 		Complete(r)
 }
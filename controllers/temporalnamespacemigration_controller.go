@@ -0,0 +1,664 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alexandrevilain/controller-tools/pkg/patch"
+	"go.temporal.io/api/operatorservice/v1"
+	"go.temporal.io/api/replication/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
+	temporalclient "go.temporal.io/sdk/client"
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metrics"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+)
+
+// TemporalNamespaceMigrationReconciler reconciles a TemporalNamespaceMigration object.
+type TemporalNamespaceMigrationReconciler struct {
+	Base
+}
+
+// namespaceMigrationPreCheckError is returned when the migration can't be started.
+type namespaceMigrationPreCheckError struct {
+	err error
+}
+
+func (e *namespaceMigrationPreCheckError) Error() string {
+	return e.err.Error()
+}
+
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalnamespacemigrations,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalnamespacemigrations/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalnamespacemigrations/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *TemporalNamespaceMigrationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	logger := log.FromContext(ctx)
+
+	logger.Info("Starting reconciliation")
+
+	migration := &v1beta1.TemporalNamespaceMigration{}
+	err := r.Get(ctx, req.NamespacedName, migration)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			metrics.DeleteObject("TemporalNamespaceMigration", req.Namespace, req.Name)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	start := time.Now()
+	defer func() {
+		metrics.ObserveReconcile("TemporalNamespaceMigration", req.Namespace, req.Name, time.Since(start), reterr)
+	}()
+
+	// A migration is only run once.
+	if migration.IsCompleted() || !migration.ObjectMeta.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(migration, r.Client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	defer func() {
+		// Always attempt to Patch the TemporalNamespaceMigration object and status after each reconciliation.
+		err := patchHelper.Patch(ctx, migration)
+		if err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	namespace := &v1beta1.TemporalNamespace{}
+	err = r.Get(ctx, types.NamespacedName{Namespace: migration.GetNamespace(), Name: migration.Spec.NamespaceRef.Name}, namespace)
+	if err != nil {
+		return r.handleError(migration, v1beta1.ReconcileErrorReason, err)
+	}
+
+	// The source cluster is recorded once the migration started, as the TemporalNamespace cluster is changed at the end of the migration.
+	sourceRef := namespace.Spec.ClusterRef
+	if migration.IsStarted() {
+		sourceRef = *migration.Status.SourceClusterRef
+	}
+
+	if !migration.IsStarted() && sourceRef.NamespacedName(migration) == migration.Spec.ClusterRef.NamespacedName(migration) {
+		return r.fail(migration, fmt.Errorf("TemporalNamespace %s already references cluster %s", namespace.GetName(), sourceRef.Name))
+	}
+
+	source := &v1beta1.TemporalCluster{}
+	err = r.Get(ctx, sourceRef.NamespacedName(migration), source)
+	if err != nil {
+		return r.handleError(migration, v1beta1.ReconcileErrorReason, err)
+	}
+
+	target := &v1beta1.TemporalCluster{}
+	err = r.Get(ctx, migration.Spec.ClusterRef.NamespacedName(migration), target)
+	if err != nil {
+		return r.handleError(migration, v1beta1.ReconcileErrorReason, err)
+	}
+
+	if !migration.IsStarted() {
+		if !source.IsReady() || !target.IsReady() {
+			return r.inProgress(migration, "Waiting for the clusters to be ready")
+		}
+
+		err := r.checkMigration(ctx, migration, namespace, source, target)
+		if err != nil {
+			var preCheckErr *namespaceMigrationPreCheckError
+			if errors.As(err, &preCheckErr) {
+				return r.fail(migration, preCheckErr)
+			}
+			return r.handleError(migration, v1beta1.ReconcileErrorReason, err)
+		}
+
+		migration.Status.SourceClusterRef = &sourceRef
+		r.Recorder.Eventf(migration, corev1.EventTypeNormal, "NamespaceMigrationStarted", "Migrating namespace %s from cluster %s to cluster %s", namespace.GetName(), source.GetName(), target.GetName())
+	}
+
+	var (
+		migrated bool
+		message  string
+	)
+	if migration.Spec.Replicate {
+		migrated, message, err = r.reconcileReplicatedMigration(ctx, migration, namespace, source, target)
+	} else {
+		migrated, message, err = r.reconcileClonedMigration(ctx, migration, namespace, source, target)
+	}
+	if err != nil {
+		var preCheckErr *namespaceMigrationPreCheckError
+		if errors.As(err, &preCheckErr) {
+			return r.fail(migration, preCheckErr)
+		}
+		return r.handleError(migration, v1beta1.ReconcileErrorReason, err)
+	}
+
+	if !migrated {
+		return r.inProgress(migration, message)
+	}
+
+	r.Recorder.Eventf(migration, corev1.EventTypeNormal, "NamespaceMigrationSucceeded", "Namespace %s migrated to cluster %s", namespace.GetName(), target.GetName())
+	v1beta1.SetTemporalNamespaceMigrationReady(migration, metav1.ConditionTrue, v1beta1.NamespaceMigrationSucceededReason, fmt.Sprintf("Namespace is managed in cluster %s", target.GetName()))
+
+	logger.Info("Successfully reconciled namespace migration", "migration", migration.GetName())
+
+	return r.handleSuccess(migration)
+}
+
+// checkMigration runs the migration pre-checks.
+// It returns a *namespaceMigrationPreCheckError if a pre-check failed.
+func (r *TemporalNamespaceMigrationReconciler) checkMigration(ctx context.Context, migration *v1beta1.TemporalNamespaceMigration, namespace *v1beta1.TemporalNamespace, source, target *v1beta1.TemporalCluster) error {
+	sourceClient, err := temporal.GetClusterClient(ctx, r.Client, source)
+	if err != nil {
+		return fmt.Errorf("can't create source cluster client: %w", err)
+	}
+	defer sourceClient.Close()
+
+	current, err := sourceClient.WorkflowService().DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{Namespace: namespace.GetName()})
+	if err != nil {
+		return fmt.Errorf("can't describe \"%s\" namespace in cluster %s: %w", namespace.GetName(), source.GetName(), err)
+	}
+
+	if migration.Spec.Replicate {
+		err := temporal.CheckReplicationClusters(source, target)
+		if err != nil {
+			return &namespaceMigrationPreCheckError{err}
+		}
+
+		sourceName := source.GetClusterMetadata().ClusterName
+		if active := current.GetReplicationConfig().GetActiveClusterName(); current.GetIsGlobalNamespace() && active != sourceName {
+			return &namespaceMigrationPreCheckError{fmt.Errorf("namespace %s is active in cluster %s, not in cluster %s", namespace.GetName(), active, sourceName)}
+		}
+
+		return nil
+	}
+
+	if current.GetIsGlobalNamespace() {
+		return &namespaceMigrationPreCheckError{fmt.Errorf("namespace %s is a global namespace, set spec.replicate to migrate it", namespace.GetName())}
+	}
+
+	targetClient, err := temporal.GetClusterClient(ctx, r.Client, target)
+	if err != nil {
+		return fmt.Errorf("can't create target cluster client: %w", err)
+	}
+	defer targetClient.Close()
+
+	exists, err := namespaceExists(ctx, targetClient, namespace.GetName())
+	if err != nil {
+		return err
+	}
+	if exists {
+		return &namespaceMigrationPreCheckError{fmt.Errorf("namespace %s already exists in cluster %s", namespace.GetName(), target.GetName())}
+	}
+
+	return nil
+}
+
+// reconcileClonedMigration creates the namespace in the target cluster with the configuration and search attributes
+// of the namespace in the source cluster, then makes the TemporalNamespace reference the target cluster.
+// It returns true once the namespace is migrated, or a message describing what the migration is waiting for.
+func (r *TemporalNamespaceMigrationReconciler) reconcileClonedMigration(ctx context.Context, migration *v1beta1.TemporalNamespaceMigration, namespace *v1beta1.TemporalNamespace, source, target *v1beta1.TemporalCluster) (bool, string, error) {
+	logger := log.FromContext(ctx)
+
+	if !source.IsReady() || !target.IsReady() {
+		return false, "Waiting for the clusters to be ready", nil
+	}
+
+	sourceClient, err := temporal.GetClusterClient(ctx, r.Client, source)
+	if err != nil {
+		return false, "", fmt.Errorf("can't create source cluster client: %w", err)
+	}
+	defer sourceClient.Close()
+
+	targetClient, err := temporal.GetClusterClient(ctx, r.Client, target)
+	if err != nil {
+		return false, "", fmt.Errorf("can't create target cluster client: %w", err)
+	}
+	defer targetClient.Close()
+
+	name := namespace.GetName()
+
+	exists, err := namespaceExists(ctx, targetClient, name)
+	if err != nil {
+		return false, "", err
+	}
+
+	if !exists {
+		current, err := sourceClient.WorkflowService().DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{Namespace: name})
+		if err != nil {
+			return false, "", fmt.Errorf("can't describe \"%s\" namespace in cluster %s: %w", name, source.GetName(), err)
+		}
+
+		logger.Info("Creating namespace in target cluster", "namespace", name, "cluster", target.GetName())
+		_, err = targetClient.WorkflowService().RegisterNamespace(ctx, temporal.NamespaceCloneRequest(current, target))
+		// The namespace may have been registered but not be visible yet: its existence is checked on the next reconciliation.
+		var namespaceAlreadyExistsError *serviceerror.NamespaceAlreadyExists
+		if err != nil && !errors.As(err, &namespaceAlreadyExistsError) {
+			return false, "", fmt.Errorf("can't create \"%s\" namespace in cluster %s: %w", name, target.GetName(), err)
+		}
+
+		r.Recorder.Eventf(migration, corev1.EventTypeNormal, "NamespaceCloned", "Namespace %s created in cluster %s", name, target.GetName())
+		return false, fmt.Sprintf("Waiting for the namespace to be created in cluster %s", target.GetName()), nil
+	}
+
+	err = r.cloneSearchAttributes(ctx, migration, name, sourceClient, targetClient)
+	if err != nil {
+		return false, "", err
+	}
+
+	err = r.updateNamespaceCluster(ctx, migration, namespace, "", "")
+	if err != nil {
+		return false, "", err
+	}
+
+	return true, "", nil
+}
+
+// reconcileReplicatedMigration connects both clusters, replicates the namespace and its workflows to the target cluster and fails it over,
+// then makes the TemporalNamespace reference the target cluster and removes the temporary replication.
+// It returns true once the namespace is migrated, or a message describing what the migration is waiting for.
+func (r *TemporalNamespaceMigrationReconciler) reconcileReplicatedMigration(ctx context.Context, migration *v1beta1.TemporalNamespaceMigration, namespace *v1beta1.TemporalNamespace, source, target *v1beta1.TemporalCluster) (bool, string, error) {
+	logger := log.FromContext(ctx)
+
+	sourceName := source.GetClusterMetadata().ClusterName
+	targetName := target.GetClusterMetadata().ClusterName
+	name := namespace.GetName()
+
+	// Remote clusters added by the migration stay listed in its status until they are removed,
+	// so they aren't added back while the migration completes.
+	for _, clusters := range [][2]*v1beta1.TemporalCluster{{source, target}, {target, source}} {
+		if migrationAddedRemoteCluster(migration, clusters[0]) {
+			continue
+		}
+
+		added, err := addRemoteClusterRef(ctx, r.Client, clusters[0], clusters[1])
+		if err != nil {
+			return false, "", err
+		}
+		if added {
+			migration.Status.RemoteClustersAdded = append(migration.Status.RemoteClustersAdded, v1beta1.TemporalClusterReference{
+				Name:      clusters[0].GetName(),
+				Namespace: clusters[0].GetNamespace(),
+			})
+			r.Recorder.Eventf(migration, corev1.EventTypeNormal, "RemoteClusterAdded", "Remote cluster %s added to cluster %s", clusters[1].GetName(), clusters[0].GetName())
+		}
+	}
+
+	if !source.IsReady() || !target.IsReady() {
+		return false, "Waiting for the clusters to be ready", nil
+	}
+
+	sourceClient, err := temporal.GetClusterClient(ctx, r.Client, source)
+	if err != nil {
+		return false, "", fmt.Errorf("can't create source cluster client: %w", err)
+	}
+	defer sourceClient.Close()
+
+	targetClient, err := temporal.GetClusterClient(ctx, r.Client, target)
+	if err != nil {
+		return false, "", fmt.Errorf("can't create target cluster client: %w", err)
+	}
+	defer targetClient.Close()
+
+	current, err := sourceClient.WorkflowService().DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{Namespace: name})
+	if err != nil {
+		return false, "", fmt.Errorf("can't describe \"%s\" namespace in cluster %s: %w", name, source.GetName(), err)
+	}
+
+	switch active := current.GetReplicationConfig().GetActiveClusterName(); {
+	case active == targetName:
+		// The namespace has been failed over, complete the migration below.
+	case current.GetIsGlobalNamespace() && active != sourceName:
+		return false, "", &namespaceMigrationPreCheckError{fmt.Errorf("namespace %s is active in cluster %s, not in cluster %s", name, active, sourceName)}
+	default:
+		if !remoteClusterRegistered(source, target) || !remoteClusterRegistered(target, source) {
+			return false, "Waiting for the clusters to register each other as remote clusters", nil
+		}
+
+		// Ensure the TemporalNamespace won't remove the target cluster from the namespace replication config.
+		err = r.addNamespaceCluster(ctx, namespace, targetName)
+		if err != nil {
+			return false, "", err
+		}
+
+		if request := temporal.NamespaceReplicationRequest(current, targetName); request != nil {
+			logger.Info("Replicating namespace", "namespace", name, "cluster", targetName, "promote", request.GetPromoteNamespace())
+			_, err = sourceClient.WorkflowService().UpdateNamespace(ctx, request)
+			if err != nil {
+				return false, "", fmt.Errorf("can't replicate \"%s\" namespace: %w", name, err)
+			}
+			return false, fmt.Sprintf("Waiting for the namespace to be replicated to cluster %s", targetName), nil
+		}
+
+		exists, err := namespaceExists(ctx, targetClient, name)
+		if err != nil {
+			return false, "", err
+		}
+		if !exists {
+			return false, fmt.Sprintf("Waiting for the namespace to be replicated to cluster %s", targetName), nil
+		}
+
+		err = r.cloneSearchAttributes(ctx, migration, name, sourceClient, targetClient)
+		if err != nil {
+			return false, "", err
+		}
+
+		// The source cluster is removed from the namespace clusters once failed over:
+		// existing workflows must be replicated to the target cluster before failing over.
+		replicated, err := temporal.ForceReplication(ctx, sourceClient, forceReplicationWorkflowID(migration, name), name, targetName)
+		if err != nil {
+			return false, "", err
+		}
+		if !replicated {
+			return false, fmt.Sprintf("Waiting for the workflows to be replicated to cluster %s", targetName), nil
+		}
+
+		failover, err := r.reconcileFailover(ctx, migration, namespace)
+		if err != nil {
+			return false, "", err
+		}
+
+		if !failover.IsCompleted() {
+			return false, fmt.Sprintf("Waiting for TemporalFailover %s to complete", failover.GetName()), nil
+		}
+
+		if !apimeta.IsStatusConditionPresentAndEqual(failover.Status.Conditions, v1beta1.ReadyCondition, metav1.ConditionTrue) {
+			err := fmt.Errorf("TemporalFailover %s failed", failover.GetName())
+			if condition := apimeta.FindStatusCondition(failover.Status.Conditions, v1beta1.ReadyCondition); condition != nil {
+				err = fmt.Errorf("%w: %s", err, condition.Message)
+			}
+			return false, "", &namespaceMigrationPreCheckError{err}
+		}
+
+		return false, fmt.Sprintf("Waiting for the namespace to be active in cluster %s", targetName), nil
+	}
+
+	err = r.updateNamespaceCluster(ctx, migration, namespace, sourceName, targetName)
+	if err != nil {
+		return false, "", err
+	}
+
+	// The replication is temporary: the source cluster is removed from the namespace clusters.
+	replicated, err := targetClient.WorkflowService().DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{Namespace: name})
+	if err != nil {
+		return false, "", fmt.Errorf("can't describe \"%s\" namespace in cluster %s: %w", name, target.GetName(), err)
+	}
+
+	if request := namespaceClusterRemovalRequest(replicated, sourceName); request != nil {
+		logger.Info("Removing source cluster from namespace clusters", "namespace", name, "cluster", sourceName)
+		_, err = targetClient.WorkflowService().UpdateNamespace(ctx, request)
+		if err != nil {
+			return false, "", fmt.Errorf("can't remove cluster %s from \"%s\" namespace clusters: %w", sourceName, name, err)
+		}
+	}
+
+	for _, ref := range migration.Status.RemoteClustersAdded {
+		cluster, remote := source, target
+		if ref.NamespacedName(migration) == client.ObjectKeyFromObject(target) {
+			cluster, remote = target, source
+		}
+
+		err := removeRemoteClusterRef(ctx, r.Client, cluster, remote)
+		if err != nil {
+			return false, "", err
+		}
+		r.Recorder.Eventf(migration, corev1.EventTypeNormal, "RemoteClusterRemoved", "Remote cluster %s removed from cluster %s", remote.GetName(), cluster.GetName())
+	}
+	migration.Status.RemoteClustersAdded = nil
+
+	return true, "", nil
+}
+
+// migrationAddedRemoteCluster returns true if the migration added the other cluster to the remote clusters of the provided cluster.
+func migrationAddedRemoteCluster(migration *v1beta1.TemporalNamespaceMigration, cluster *v1beta1.TemporalCluster) bool {
+	return slices.ContainsFunc(migration.Status.RemoteClustersAdded, func(ref v1beta1.TemporalClusterReference) bool {
+		return ref.NamespacedName(migration) == client.ObjectKeyFromObject(cluster)
+	})
+}
+
+// namespaceClusterRemovalRequest returns the request removing the cluster from the namespace replication config,
+// or nil if the namespace isn't replicated to it.
+func namespaceClusterRemovalRequest(current *workflowservice.DescribeNamespaceResponse, cluster string) *workflowservice.UpdateNamespaceRequest {
+	clusters := make([]*replication.ClusterReplicationConfig, 0, len(current.GetReplicationConfig().GetClusters()))
+	for _, replicated := range current.GetReplicationConfig().GetClusters() {
+		if replicated.GetClusterName() != cluster {
+			clusters = append(clusters, &replication.ClusterReplicationConfig{ClusterName: replicated.GetClusterName()})
+		}
+	}
+
+	if len(clusters) == len(current.GetReplicationConfig().GetClusters()) {
+		return nil
+	}
+
+	return &workflowservice.UpdateNamespaceRequest{
+		Namespace: current.GetNamespaceInfo().GetName(),
+		ReplicationConfig: &replication.NamespaceReplicationConfig{
+			Clusters: clusters,
+		},
+	}
+}
+
+// cloneSearchAttributes adds the custom search attributes of the source cluster missing from the target cluster.
+func (r *TemporalNamespaceMigrationReconciler) cloneSearchAttributes(ctx context.Context, migration *v1beta1.TemporalNamespaceMigration, name string, sourceClient, targetClient temporalclient.Client) error {
+	request := &operatorservice.ListSearchAttributesRequest{Namespace: name}
+
+	current, err := sourceClient.OperatorService().ListSearchAttributes(ctx, request)
+	if err != nil {
+		return fmt.Errorf("can't list \"%s\" namespace search attributes in source cluster: %w", name, err)
+	}
+
+	existing, err := targetClient.OperatorService().ListSearchAttributes(ctx, request)
+	if err != nil {
+		return fmt.Errorf("can't list \"%s\" namespace search attributes in target cluster: %w", name, err)
+	}
+
+	add, err := temporal.SearchAttributesCloneRequest(name, current, existing)
+	if err != nil {
+		return &namespaceMigrationPreCheckError{err}
+	}
+	if add == nil {
+		return nil
+	}
+
+	_, err = targetClient.OperatorService().AddSearchAttributes(ctx, add)
+	if err != nil {
+		return fmt.Errorf("can't add \"%s\" namespace search attributes in target cluster: %w", name, err)
+	}
+
+	names := make([]string, 0, len(add.GetSearchAttributes()))
+	for attribute := range add.GetSearchAttributes() {
+		names = append(names, attribute)
+	}
+	sort.Strings(names)
+	r.Recorder.Eventf(migration, corev1.EventTypeNormal, "SearchAttributesCloned", "Search attributes added to the target cluster: %s", strings.Join(names, ", "))
+
+	return nil
+}
+
+// addNamespaceCluster adds the cluster to the clusters of the TemporalNamespace, if it lists them.
+func (r *TemporalNamespaceMigrationReconciler) addNamespaceCluster(ctx context.Context, namespace *v1beta1.TemporalNamespace, cluster string) error {
+	if !namespace.Spec.IsGlobalNamespace || len(namespace.Spec.Clusters) == 0 || slices.Contains(namespace.Spec.Clusters, cluster) {
+		return nil
+	}
+
+	original := namespace.DeepCopy()
+	namespace.Spec.Clusters = append(namespace.Spec.Clusters, cluster)
+	err := r.Patch(ctx, namespace, client.MergeFrom(original))
+	if err != nil {
+		return fmt.Errorf("can't add cluster to \"%s\" temporal namespace clusters: %w", namespace.GetName(), err)
+	}
+
+	return nil
+}
+
+// updateNamespaceCluster makes the TemporalNamespace reference the target cluster.
+// When the namespace was replicated, the source cluster is removed from the TemporalNamespace clusters.
+func (r *TemporalNamespaceMigrationReconciler) updateNamespaceCluster(ctx context.Context, migration *v1beta1.TemporalNamespaceMigration, namespace *v1beta1.TemporalNamespace, sourceName, targetName string) error {
+	original := namespace.DeepCopy()
+
+	namespace.Spec.ClusterRef = migration.Spec.ClusterRef
+	if sourceName != "" && len(namespace.Spec.Clusters) > 0 {
+		namespace.Spec.Clusters = slices.DeleteFunc(namespace.Spec.Clusters, func(cluster string) bool {
+			return cluster == sourceName
+		})
+		if !slices.Contains(namespace.Spec.Clusters, targetName) {
+			namespace.Spec.Clusters = append(namespace.Spec.Clusters, targetName)
+		}
+	}
+
+	if namespace.Spec.ClusterRef == original.Spec.ClusterRef && slices.Equal(namespace.Spec.Clusters, original.Spec.Clusters) {
+		return nil
+	}
+
+	err := r.Patch(ctx, namespace, client.MergeFrom(original))
+	if err != nil {
+		return fmt.Errorf("can't update \"%s\" temporal namespace cluster: %w", namespace.GetName(), err)
+	}
+
+	r.Recorder.Eventf(migration, corev1.EventTypeNormal, "NamespaceClusterUpdated", "TemporalNamespace %s now references cluster %s", namespace.GetName(), migration.Spec.ClusterRef.Name)
+
+	return nil
+}
+
+// namespaceExists returns true if the namespace exists in the cluster.
+func namespaceExists(ctx context.Context, c temporalclient.Client, name string) (bool, error) {
+	_, err := c.WorkflowService().DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{Namespace: name})
+	if err != nil {
+		var notFound *serviceerror.NamespaceNotFound
+		if errors.As(err, &notFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("can't describe \"%s\" namespace: %w", name, err)
+	}
+	return true, nil
+}
+
+// reconcileFailover creates the TemporalFailover failing the namespace over to the target cluster, and returns it.
+func (r *TemporalNamespaceMigrationReconciler) reconcileFailover(ctx context.Context, migration *v1beta1.TemporalNamespaceMigration, namespace *v1beta1.TemporalNamespace) (*v1beta1.TemporalFailover, error) {
+	failover := &v1beta1.TemporalFailover{}
+	name := types.NamespacedName{Namespace: migration.GetNamespace(), Name: fmt.Sprintf("%s-failover", migration.GetName())}
+
+	err := r.Get(ctx, name, failover)
+	if err == nil {
+		return failover, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	failover = &v1beta1.TemporalFailover{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name,
+			Namespace: name.Namespace,
+		},
+		Spec: v1beta1.TemporalFailoverSpec{
+			ClusterRef:       migration.Spec.ClusterRef,
+			SourceClusterRef: *migration.Status.SourceClusterRef,
+			Namespaces:       []string{namespace.GetName()},
+		},
+	}
+
+	err = controllerutil.SetControllerReference(migration, failover, r.Scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Create(ctx, failover)
+	if err != nil {
+		return nil, fmt.Errorf("can't create TemporalFailover: %w", err)
+	}
+
+	r.Recorder.Eventf(migration, corev1.EventTypeNormal, "FailoverStarted", "TemporalFailover %s created", failover.GetName())
+
+	return failover, nil
+}
+
+// inProgress reports the migration as in progress, and requeues it.
+func (r *TemporalNamespaceMigrationReconciler) inProgress(migration *v1beta1.TemporalNamespaceMigration, message string) (ctrl.Result, error) {
+	v1beta1.SetTemporalNamespaceMigrationReady(migration, metav1.ConditionFalse, v1beta1.NamespaceMigrationInProgressReason, message)
+	return r.handleSuccessWithBackoff(migration)
+}
+
+// fail marks the migration as failed. A failed migration is not retried.
+func (r *TemporalNamespaceMigrationReconciler) fail(migration *v1beta1.TemporalNamespaceMigration, err error) (ctrl.Result, error) {
+	r.Recorder.Event(migration, corev1.EventTypeWarning, "NamespaceMigrationFailed", err.Error())
+	v1beta1.SetTemporalNamespaceMigrationReady(migration, metav1.ConditionFalse, v1beta1.NamespaceMigrationFailedReason, err.Error())
+	return r.handleSuccess(migration)
+}
+
+func (r *TemporalNamespaceMigrationReconciler) handleSuccess(migration *v1beta1.TemporalNamespaceMigration) (ctrl.Result, error) {
+	return r.handleSuccessWithRequeue(migration, 0)
+}
+
+// handleError requeues the migration, as its steps must be retried until the namespace is migrated.
+func (r *TemporalNamespaceMigrationReconciler) handleError(migration *v1beta1.TemporalNamespaceMigration, reason string, err error) (ctrl.Result, error) { //nolint:unparam
+	return r.handleErrorWithRequeue(migration, reason, err, r.Backoff.When(client.ObjectKeyFromObject(migration)))
+}
+
+func (r *TemporalNamespaceMigrationReconciler) handleSuccessWithRequeue(migration *v1beta1.TemporalNamespaceMigration, requeueAfter time.Duration) (ctrl.Result, error) {
+	r.Backoff.Forget(client.ObjectKeyFromObject(migration))
+	v1beta1.SetTemporalNamespaceMigrationReconcileSuccess(migration, metav1.ConditionTrue, v1beta1.ReconcileSuccessReason, "")
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// handleSuccessWithBackoff requeues the migration while its current phase is in progress, with increasing delays.
+func (r *TemporalNamespaceMigrationReconciler) handleSuccessWithBackoff(migration *v1beta1.TemporalNamespaceMigration) (ctrl.Result, error) {
+	v1beta1.SetTemporalNamespaceMigrationReconcileSuccess(migration, metav1.ConditionTrue, v1beta1.ReconcileSuccessReason, "")
+	return reconcile.Result{RequeueAfter: r.Backoff.When(client.ObjectKeyFromObject(migration))}, nil
+}
+
+func (r *TemporalNamespaceMigrationReconciler) handleErrorWithRequeue(migration *v1beta1.TemporalNamespaceMigration, reason string, err error, requeueAfter time.Duration) (ctrl.Result, error) {
+	r.Recorder.Event(migration, corev1.EventTypeWarning, temporalErrorEventReason(err), err.Error())
+	if reason == "" {
+		reason = v1beta1.ReconcileErrorReason
+	}
+	v1beta1.SetTemporalNamespaceMigrationReconcileError(migration, metav1.ConditionTrue, reason, err.Error())
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TemporalNamespaceMigrationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.TemporalNamespaceMigration{}).
+		Owns(&v1beta1.TemporalFailover{}).
+		WithOptions(controller.Options{RateLimiter: r.Backoff.RateLimiter()}).
+		Complete(r)
+}
@@ -0,0 +1,228 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	namespacev1 "go.temporal.io/api/namespace/v1"
+	"go.temporal.io/api/replication/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newNamespaceMigrationTestReconciler(objects ...client.Object) *TemporalNamespaceMigrationReconciler {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(v1beta1.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(&v1beta1.TemporalNamespaceMigration{}).
+		Build()
+
+	return &TemporalNamespaceMigrationReconciler{
+		Base: New(c, scheme, record.NewFakeRecorder(10), nil, 0),
+	}
+}
+
+func TestNamespaceMigrationAlreadyMigrated(t *testing.T) {
+	namespace := &v1beta1.TemporalNamespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "demo"},
+		Spec: v1beta1.TemporalNamespaceSpec{
+			ClusterRef: v1beta1.TemporalClusterReference{Name: "prod-west"},
+		},
+	}
+	migration := &v1beta1.TemporalNamespaceMigration{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments-to-west", Namespace: "demo"},
+		Spec: v1beta1.TemporalNamespaceMigrationSpec{
+			NamespaceRef: corev1.LocalObjectReference{Name: "payments"},
+			ClusterRef:   v1beta1.TemporalClusterReference{Name: "prod-west"},
+		},
+	}
+
+	r := newNamespaceMigrationTestReconciler(namespace, migration)
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(migration)})
+	require.NoError(t, err)
+
+	result := &v1beta1.TemporalNamespaceMigration{}
+	require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(migration), result))
+
+	condition := apimeta.FindStatusCondition(result.Status.Conditions, v1beta1.ReadyCondition)
+	require.NotNil(t, condition)
+	assert.Equal(t, v1beta1.NamespaceMigrationFailedReason, condition.Reason)
+	assert.Equal(t, "TemporalNamespace payments already references cluster prod-west", condition.Message)
+	assert.True(t, result.IsCompleted())
+	assert.False(t, result.IsStarted())
+}
+
+func TestRemoteClusterRefs(t *testing.T) {
+	east := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-east", Namespace: "demo"},
+	}
+	west := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-west", Namespace: "demo"},
+		Spec: v1beta1.TemporalClusterSpec{
+			Replication: &v1beta1.ReplicationSpec{
+				RemoteClusters: []v1beta1.RemoteClusterSpec{
+					{Address: "prod-central.example.com:7233"},
+				},
+			},
+		},
+	}
+
+	r := newNamespaceMigrationTestReconciler(east, west)
+	ctx := context.Background()
+
+	added, err := addRemoteClusterRef(ctx, r.Client, west, east)
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	added, err = addRemoteClusterRef(ctx, r.Client, west, east)
+	require.NoError(t, err)
+	assert.False(t, added)
+
+	result := &v1beta1.TemporalCluster{}
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(west), result))
+	assert.Equal(t, []v1beta1.RemoteClusterSpec{
+		{Address: "prod-central.example.com:7233"},
+		{ClusterRef: &v1beta1.TemporalClusterReference{Name: "prod-east", Namespace: "demo"}},
+	}, result.Spec.Replication.RemoteClusters)
+
+	assert.False(t, remoteClusterRegistered(result, east))
+	result.Status.RemoteClusters = []v1beta1.RemoteClusterStatus{{Name: "prod-east", Address: "prod-east-frontend.demo:7233"}}
+	assert.True(t, remoteClusterRegistered(result, east))
+
+	require.NoError(t, removeRemoteClusterRef(ctx, r.Client, west, east))
+
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(west), result))
+	assert.Equal(t, []v1beta1.RemoteClusterSpec{
+		{Address: "prod-central.example.com:7233"},
+	}, result.Spec.Replication.RemoteClusters)
+}
+
+func TestUpdateNamespaceCluster(t *testing.T) {
+	tests := map[string]struct {
+		spec             v1beta1.TemporalNamespaceSpec
+		sourceName       string
+		targetName       string
+		expectedClusters []string
+	}{
+		"local namespace": {
+			spec: v1beta1.TemporalNamespaceSpec{
+				ClusterRef: v1beta1.TemporalClusterReference{Name: "prod-east"},
+			},
+		},
+		"replicated namespace without clusters": {
+			spec: v1beta1.TemporalNamespaceSpec{
+				ClusterRef:        v1beta1.TemporalClusterReference{Name: "prod-east"},
+				IsGlobalNamespace: true,
+			},
+			sourceName: "prod-east",
+			targetName: "prod-west",
+		},
+		"replicated namespace with clusters": {
+			spec: v1beta1.TemporalNamespaceSpec{
+				ClusterRef:        v1beta1.TemporalClusterReference{Name: "prod-east"},
+				IsGlobalNamespace: true,
+				Clusters:          []string{"prod-east", "prod-central", "prod-west"},
+			},
+			sourceName:       "prod-east",
+			targetName:       "prod-west",
+			expectedClusters: []string{"prod-central", "prod-west"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			namespace := &v1beta1.TemporalNamespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "demo"},
+				Spec:       test.spec,
+			}
+			migration := &v1beta1.TemporalNamespaceMigration{
+				ObjectMeta: metav1.ObjectMeta{Name: "payments-to-west", Namespace: "demo"},
+				Spec: v1beta1.TemporalNamespaceMigrationSpec{
+					NamespaceRef: corev1.LocalObjectReference{Name: "payments"},
+					ClusterRef:   v1beta1.TemporalClusterReference{Name: "prod-west"},
+				},
+			}
+
+			r := newNamespaceMigrationTestReconciler(namespace)
+			ctx := context.Background()
+
+			require.NoError(tt, r.updateNamespaceCluster(ctx, migration, namespace, test.sourceName, test.targetName))
+
+			result := &v1beta1.TemporalNamespace{}
+			require.NoError(tt, r.Get(ctx, client.ObjectKeyFromObject(namespace), result))
+			assert.Equal(tt, "prod-west", result.Spec.ClusterRef.Name)
+			assert.Equal(tt, test.expectedClusters, result.Spec.Clusters)
+		})
+	}
+}
+
+func TestNamespaceClusterRemovalRequest(t *testing.T) {
+	describe := func(clusters ...string) *workflowservice.DescribeNamespaceResponse {
+		config := &replication.NamespaceReplicationConfig{ActiveClusterName: "prod-west"}
+		for _, cluster := range clusters {
+			config.Clusters = append(config.Clusters, &replication.ClusterReplicationConfig{ClusterName: cluster})
+		}
+		return &workflowservice.DescribeNamespaceResponse{
+			NamespaceInfo:     &namespacev1.NamespaceInfo{Name: "payments"},
+			IsGlobalNamespace: true,
+			ReplicationConfig: config,
+		}
+	}
+
+	tests := map[string]struct {
+		current  *workflowservice.DescribeNamespaceResponse
+		expected *workflowservice.UpdateNamespaceRequest
+	}{
+		"replicated to source cluster": {
+			current: describe("prod-east", "prod-west"),
+			expected: &workflowservice.UpdateNamespaceRequest{
+				Namespace: "payments",
+				ReplicationConfig: &replication.NamespaceReplicationConfig{
+					Clusters: []*replication.ClusterReplicationConfig{{ClusterName: "prod-west"}},
+				},
+			},
+		},
+		"not replicated to source cluster": {
+			current:  describe("prod-west"),
+			expected: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			assert.Equal(tt, test.expected, namespaceClusterRemovalRequest(test.current, "prod-east"))
+		})
+	}
+}
@@ -0,0 +1,422 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alexandrevilain/controller-tools/pkg/patch"
+	"go.temporal.io/api/workflowservice/v1"
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metrics"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+)
+
+// TemporalReshardReconciler reconciles a TemporalReshard object.
+type TemporalReshardReconciler struct {
+	Base
+}
+
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalreshards,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalreshards/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalreshards/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *TemporalReshardReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	logger := log.FromContext(ctx)
+
+	logger.Info("Starting reconciliation")
+
+	reshard := &v1beta1.TemporalReshard{}
+	err := r.Get(ctx, req.NamespacedName, reshard)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			metrics.DeleteObject("TemporalReshard", req.Namespace, req.Name)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	start := time.Now()
+	defer func() {
+		metrics.ObserveReconcile("TemporalReshard", req.Namespace, req.Name, time.Since(start), reterr)
+	}()
+
+	// A reshard is only run once.
+	if reshard.IsCompleted() || !reshard.ObjectMeta.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(reshard, r.Client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	defer func() {
+		// Always attempt to Patch the TemporalReshard object and status after each reconciliation.
+		err := patchHelper.Patch(ctx, reshard)
+		if err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	source := &v1beta1.TemporalCluster{}
+	err = r.Get(ctx, reshard.Spec.ClusterRef.NamespacedName(reshard), source)
+	if err != nil {
+		return r.handleError(reshard, v1beta1.ReconcileErrorReason, err)
+	}
+
+	target := &v1beta1.TemporalCluster{}
+	err = r.Get(ctx, reshard.Spec.TargetClusterRef.NamespacedName(reshard), target)
+	// The target cluster is created from the source cluster spec when the reshard starts, if requested.
+	createTarget := apierrors.IsNotFound(err) && reshard.Spec.Target != nil && reshard.Status.Phase == ""
+	if err != nil && !createTarget {
+		return r.handleError(reshard, v1beta1.ReconcileErrorReason, err)
+	}
+	if createTarget {
+		target = temporal.ReshardTargetCluster(reshard, source)
+	}
+
+	if reshard.Status.Phase == "" {
+		err := temporal.CheckReshardClusters(source, target)
+		if err != nil {
+			return r.fail(reshard, err)
+		}
+
+		if createTarget {
+			err := r.Create(ctx, target)
+			if err != nil {
+				err = fmt.Errorf("can't create target cluster: %w", err)
+				return r.handleError(reshard, v1beta1.ReconcileErrorReason, err)
+			}
+			r.Recorder.Eventf(reshard, corev1.EventTypeNormal, "TargetClusterCreated", "Cluster %s created with %d history shards", target.GetName(), target.Spec.NumHistoryShards)
+		}
+
+		reshard.Status.Phase = v1beta1.ReshardPhaseConfiguringReplication
+		r.Recorder.Eventf(reshard, corev1.EventTypeNormal, "ReshardStarted", "Moving namespaces from cluster %s to cluster %s", source.GetName(), target.GetName())
+	}
+
+	sourceName := source.GetClusterMetadata().ClusterName
+	targetName := target.GetClusterMetadata().ClusterName
+
+	// Phase 1: both clusters replicate each other.
+	if !apimeta.IsStatusConditionTrue(reshard.Status.Conditions, v1beta1.ReshardReplicationConfiguredCondition) {
+		configured, err := r.configureReplication(ctx, reshard, source, target)
+		if err != nil {
+			return r.handleError(reshard, v1beta1.ReconcileErrorReason, err)
+		}
+
+		if !configured {
+			return r.inProgress(reshard, v1beta1.ReshardReplicationConfiguredCondition, "Waiting for the clusters to register each other as remote clusters")
+		}
+
+		v1beta1.SetTemporalReshardCondition(reshard, v1beta1.ReshardReplicationConfiguredCondition, metav1.ConditionTrue, v1beta1.ReshardPhaseCompletedReason, "")
+		reshard.Status.Phase = v1beta1.ReshardPhaseReplicatingNamespaces
+	}
+
+	// Phase 2: the namespaces are replicated to the target cluster.
+	if !apimeta.IsStatusConditionTrue(reshard.Status.Conditions, v1beta1.ReshardNamespacesReplicatedCondition) {
+		if !source.IsReady() || !target.IsReady() {
+			return r.inProgress(reshard, v1beta1.ReshardNamespacesReplicatedCondition, "Waiting for the clusters to be ready")
+		}
+
+		replicated, message, err := r.replicateNamespaces(ctx, reshard, source, target, targetName)
+		if err != nil {
+			return r.handleError(reshard, v1beta1.ReconcileErrorReason, err)
+		}
+
+		if !replicated {
+			return r.inProgress(reshard, v1beta1.ReshardNamespacesReplicatedCondition, message)
+		}
+
+		v1beta1.SetTemporalReshardCondition(reshard, v1beta1.ReshardNamespacesReplicatedCondition, metav1.ConditionTrue, v1beta1.ReshardPhaseCompletedReason, "")
+		r.Recorder.Eventf(reshard, corev1.EventTypeNormal, "NamespacesReplicated", "Namespaces replicated to cluster %s", targetName)
+	}
+
+	// Phase 3: the namespaces are failed over to the target cluster once requested.
+	if !reshard.Spec.CutOver {
+		reshard.Status.Phase = v1beta1.ReshardPhaseWaitingForCutOver
+		message := fmt.Sprintf("Set spec.cutOver to fail the namespaces over to cluster %s", targetName)
+		v1beta1.SetTemporalReshardCondition(reshard, v1beta1.ReshardCutOverCondition, metav1.ConditionFalse, v1beta1.ReshardWaitingForCutOverReason, message)
+		v1beta1.SetTemporalReshardReady(reshard, metav1.ConditionFalse, v1beta1.ReshardWaitingForCutOverReason, message)
+		return r.handleSuccess(reshard)
+	}
+
+	failover, err := r.reconcileCutOverFailover(ctx, reshard)
+	if err != nil {
+		return r.handleError(reshard, v1beta1.ReconcileErrorReason, err)
+	}
+
+	if !failover.IsCompleted() {
+		reshard.Status.Phase = v1beta1.ReshardPhaseCuttingOver
+		return r.inProgress(reshard, v1beta1.ReshardCutOverCondition, fmt.Sprintf("Waiting for TemporalFailover %s to complete", failover.GetName()))
+	}
+
+	if !apimeta.IsStatusConditionPresentAndEqual(failover.Status.Conditions, v1beta1.ReadyCondition, metav1.ConditionTrue) {
+		err := fmt.Errorf("TemporalFailover %s failed", failover.GetName())
+		if condition := apimeta.FindStatusCondition(failover.Status.Conditions, v1beta1.ReadyCondition); condition != nil {
+			err = fmt.Errorf("%w: %s", err, condition.Message)
+		}
+		v1beta1.SetTemporalReshardCondition(reshard, v1beta1.ReshardCutOverCondition, metav1.ConditionFalse, v1beta1.ReshardFailedReason, err.Error())
+		return r.fail(reshard, err)
+	}
+
+	v1beta1.SetTemporalReshardCondition(reshard, v1beta1.ReshardCutOverCondition, metav1.ConditionTrue, v1beta1.ReshardPhaseCompletedReason, "")
+	reshard.Status.Phase = v1beta1.ReshardPhaseCompleted
+	r.Recorder.Eventf(reshard, corev1.EventTypeNormal, "ReshardSucceeded", "Namespaces moved from cluster %s to cluster %s", sourceName, targetName)
+	v1beta1.SetTemporalReshardReady(reshard, metav1.ConditionTrue, v1beta1.ReshardSucceededReason, fmt.Sprintf("Namespaces are active in cluster %s", targetName))
+
+	logger.Info("Successfully reconciled reshard", "reshard", reshard.GetName())
+
+	return r.handleSuccess(reshard)
+}
+
+// configureReplication adds each cluster to the other's remote clusters.
+// It returns true once both clusters registered each other.
+func (r *TemporalReshardReconciler) configureReplication(ctx context.Context, reshard *v1beta1.TemporalReshard, source, target *v1beta1.TemporalCluster) (bool, error) {
+	for _, clusters := range [][2]*v1beta1.TemporalCluster{{source, target}, {target, source}} {
+		added, err := addRemoteClusterRef(ctx, r.Client, clusters[0], clusters[1])
+		if err != nil {
+			return false, err
+		}
+		if added {
+			r.Recorder.Eventf(reshard, corev1.EventTypeNormal, "RemoteClusterAdded", "Remote cluster %s added to cluster %s", clusters[1].GetName(), clusters[0].GetName())
+		}
+	}
+
+	return remoteClusterRegistered(source, target) && remoteClusterRegistered(target, source), nil
+}
+
+// replicateNamespaces adds the target cluster to the namespaces replication config, promoting local namespaces to global namespaces,
+// then replicates the existing workflows of the namespaces using temporal's force replication workflow.
+// It returns true once all the workflows are replicated to the target cluster, or a message describing what it is waiting for.
+func (r *TemporalReshardReconciler) replicateNamespaces(ctx context.Context, reshard *v1beta1.TemporalReshard, source, target *v1beta1.TemporalCluster, targetName string) (bool, string, error) {
+	logger := log.FromContext(ctx)
+
+	sourceClient, err := temporal.GetClusterClient(ctx, r.Client, source)
+	if err != nil {
+		return false, "", fmt.Errorf("can't create source cluster client: %w", err)
+	}
+	defer sourceClient.Close()
+
+	targetClient, err := temporal.GetClusterClient(ctx, r.Client, target)
+	if err != nil {
+		return false, "", fmt.Errorf("can't create target cluster client: %w", err)
+	}
+	defer targetClient.Close()
+
+	// Ensure TemporalNamespaces won't remove the target cluster from the namespaces replication config.
+	err = r.addNamespacesCluster(ctx, reshard, targetName)
+	if err != nil {
+		return false, "", err
+	}
+
+	replicated := true
+	for _, name := range reshard.Spec.Namespaces {
+		current, err := sourceClient.WorkflowService().DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{Namespace: name})
+		if err != nil {
+			return false, "", fmt.Errorf("can't describe \"%s\" namespace: %w", name, err)
+		}
+
+		if request := temporal.NamespaceReplicationRequest(current, targetName); request != nil {
+			logger.Info("Replicating namespace", "namespace", name, "cluster", targetName, "promote", request.GetPromoteNamespace())
+			_, err = sourceClient.WorkflowService().UpdateNamespace(ctx, request)
+			if err != nil {
+				return false, "", fmt.Errorf("can't replicate \"%s\" namespace: %w", name, err)
+			}
+			replicated = false
+			continue
+		}
+
+		exists, err := namespaceExists(ctx, targetClient, name)
+		if err != nil {
+			return false, "", err
+		}
+		replicated = replicated && exists
+	}
+
+	if !replicated {
+		return false, fmt.Sprintf("Waiting for the namespaces to be replicated to cluster %s", targetName), nil
+	}
+
+	// Only new workflow events are replicated to the target cluster: existing workflows are replicated
+	// by the force replication workflow, which completes once they all exist in the target cluster.
+	pending := []string{}
+	for _, name := range reshard.Spec.Namespaces {
+		done, err := temporal.ForceReplication(ctx, sourceClient, forceReplicationWorkflowID(reshard, name), name, targetName)
+		if err != nil {
+			return false, "", err
+		}
+		if !done {
+			pending = append(pending, name)
+		}
+	}
+
+	if len(pending) > 0 {
+		return false, fmt.Sprintf("Waiting for the workflows of namespaces %s to be replicated to cluster %s", strings.Join(pending, ", "), targetName), nil
+	}
+
+	return true, "", nil
+}
+
+// forceReplicationWorkflowID returns the ID of the force replication workflow started by the provided object for the namespace.
+func forceReplicationWorkflowID(owner client.Object, namespace string) string {
+	return fmt.Sprintf("temporal-operator-%s-%s", owner.GetUID(), namespace)
+}
+
+// addNamespacesCluster adds the cluster to the clusters of the global TemporalNamespaces managing the reshard namespaces.
+func (r *TemporalReshardReconciler) addNamespacesCluster(ctx context.Context, reshard *v1beta1.TemporalReshard, cluster string) error {
+	namespaces := &v1beta1.TemporalNamespaceList{}
+	err := r.List(ctx, namespaces, client.InNamespace(reshard.GetNamespace()))
+	if err != nil {
+		return fmt.Errorf("can't list temporal namespaces: %w", err)
+	}
+
+	for i := range namespaces.Items {
+		namespace := &namespaces.Items[i]
+		if !slices.Contains(reshard.Spec.Namespaces, namespace.GetName()) ||
+			namespace.Spec.ClusterRef.Name != reshard.Spec.ClusterRef.Name ||
+			!namespace.Spec.IsGlobalNamespace ||
+			len(namespace.Spec.Clusters) == 0 ||
+			slices.Contains(namespace.Spec.Clusters, cluster) {
+			continue
+		}
+
+		original := namespace.DeepCopy()
+		namespace.Spec.Clusters = append(namespace.Spec.Clusters, cluster)
+		err := r.Patch(ctx, namespace, client.MergeFrom(original))
+		if err != nil {
+			return fmt.Errorf("can't add cluster to \"%s\" temporal namespace clusters: %w", namespace.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// reconcileCutOverFailover creates the TemporalFailover failing the namespaces over to the target cluster, and returns it.
+func (r *TemporalReshardReconciler) reconcileCutOverFailover(ctx context.Context, reshard *v1beta1.TemporalReshard) (*v1beta1.TemporalFailover, error) {
+	failover := &v1beta1.TemporalFailover{}
+	name := types.NamespacedName{Namespace: reshard.GetNamespace(), Name: fmt.Sprintf("%s-cut-over", reshard.GetName())}
+
+	err := r.Get(ctx, name, failover)
+	if err == nil {
+		return failover, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	failover = &v1beta1.TemporalFailover{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name,
+			Namespace: name.Namespace,
+		},
+		Spec: v1beta1.TemporalFailoverSpec{
+			ClusterRef:       reshard.Spec.TargetClusterRef,
+			SourceClusterRef: reshard.Spec.ClusterRef,
+			Namespaces:       reshard.Spec.Namespaces,
+		},
+	}
+
+	err = controllerutil.SetControllerReference(reshard, failover, r.Scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Create(ctx, failover)
+	if err != nil {
+		return nil, fmt.Errorf("can't create cut over TemporalFailover: %w", err)
+	}
+
+	reshard.Status.FailoverRef = &corev1.LocalObjectReference{Name: failover.GetName()}
+	r.Recorder.Eventf(reshard, corev1.EventTypeNormal, "CutOverStarted", "TemporalFailover %s created", failover.GetName())
+
+	return failover, nil
+}
+
+// inProgress reports the provided phase condition as in progress, and requeues the reshard.
+func (r *TemporalReshardReconciler) inProgress(reshard *v1beta1.TemporalReshard, conditionType, message string) (ctrl.Result, error) {
+	v1beta1.SetTemporalReshardCondition(reshard, conditionType, metav1.ConditionFalse, v1beta1.ReshardInProgressReason, message)
+	v1beta1.SetTemporalReshardReady(reshard, metav1.ConditionFalse, v1beta1.ReshardInProgressReason, message)
+	return r.handleSuccessWithBackoff(reshard)
+}
+
+// fail marks the reshard as failed. A failed reshard is not retried.
+func (r *TemporalReshardReconciler) fail(reshard *v1beta1.TemporalReshard, err error) (ctrl.Result, error) {
+	r.Recorder.Event(reshard, corev1.EventTypeWarning, "ReshardFailed", err.Error())
+	reshard.Status.Phase = v1beta1.ReshardPhaseFailed
+	v1beta1.SetTemporalReshardReady(reshard, metav1.ConditionFalse, v1beta1.ReshardFailedReason, err.Error())
+	return r.handleSuccess(reshard)
+}
+
+func (r *TemporalReshardReconciler) handleSuccess(reshard *v1beta1.TemporalReshard) (ctrl.Result, error) {
+	return r.handleSuccessWithRequeue(reshard, 0)
+}
+
+// handleError requeues the reshard, as its phases must be retried until the namespaces are active in the target cluster.
+func (r *TemporalReshardReconciler) handleError(reshard *v1beta1.TemporalReshard, reason string, err error) (ctrl.Result, error) { //nolint:unparam
+	return r.handleErrorWithRequeue(reshard, reason, err, r.Backoff.When(client.ObjectKeyFromObject(reshard)))
+}
+
+func (r *TemporalReshardReconciler) handleSuccessWithRequeue(reshard *v1beta1.TemporalReshard, requeueAfter time.Duration) (ctrl.Result, error) {
+	r.Backoff.Forget(client.ObjectKeyFromObject(reshard))
+	v1beta1.SetTemporalReshardReconcileSuccess(reshard, metav1.ConditionTrue, v1beta1.ReconcileSuccessReason, "")
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// handleSuccessWithBackoff requeues the reshard until its current phase completes, backing off between checks.
+func (r *TemporalReshardReconciler) handleSuccessWithBackoff(reshard *v1beta1.TemporalReshard) (ctrl.Result, error) {
+	v1beta1.SetTemporalReshardReconcileSuccess(reshard, metav1.ConditionTrue, v1beta1.ReconcileSuccessReason, "")
+	return reconcile.Result{RequeueAfter: r.Backoff.When(client.ObjectKeyFromObject(reshard))}, nil
+}
+
+func (r *TemporalReshardReconciler) handleErrorWithRequeue(reshard *v1beta1.TemporalReshard, reason string, err error, requeueAfter time.Duration) (ctrl.Result, error) {
+	r.Recorder.Event(reshard, corev1.EventTypeWarning, temporalErrorEventReason(err), err.Error())
+	if reason == "" {
+		reason = v1beta1.ReconcileErrorReason
+	}
+	v1beta1.SetTemporalReshardReconcileError(reshard, metav1.ConditionTrue, reason, err.Error())
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TemporalReshardReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.TemporalReshard{}).
+		Owns(&v1beta1.TemporalFailover{}).
+		WithOptions(controller.Options{RateLimiter: r.Backoff.RateLimiter()}).
+		Complete(r)
+}
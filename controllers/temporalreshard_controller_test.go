@@ -0,0 +1,101 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReshardCreatesTargetCluster(t *testing.T) {
+	source := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+		Spec: v1beta1.TemporalClusterSpec{
+			Version:          version.MustNewVersionFromString("1.23.0"),
+			NumHistoryShards: 512,
+			Persistence: v1beta1.TemporalPersistenceSpec{
+				DefaultStore: &v1beta1.DatastoreSpec{Name: "default"},
+			},
+		},
+	}
+	reshard := &v1beta1.TemporalReshard{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-to-2048-shards", Namespace: "demo"},
+		Spec: v1beta1.TemporalReshardSpec{
+			ClusterRef:       v1beta1.TemporalClusterReference{Name: "prod"},
+			TargetClusterRef: v1beta1.TemporalClusterReference{Name: "prod-2048"},
+			Target: &v1beta1.TemporalReshardTargetSpec{
+				NumHistoryShards: 2048,
+				Persistence: v1beta1.TemporalPersistenceSpec{
+					DefaultStore: &v1beta1.DatastoreSpec{Name: "default-2048"},
+				},
+			},
+			Namespaces: []string{"payments"},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(v1beta1.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(source, reshard).
+		WithStatusSubresource(&v1beta1.TemporalReshard{}, &v1beta1.TemporalCluster{}).
+		Build()
+
+	r := &TemporalReshardReconciler{
+		Base: New(c, scheme, record.NewFakeRecorder(10), nil, 0),
+	}
+
+	ctx := context.Background()
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(reshard)})
+	require.NoError(t, err)
+
+	target := &v1beta1.TemporalCluster{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "demo", Name: "prod-2048"}, target))
+	assert.Equal(t, int32(2048), target.Spec.NumHistoryShards)
+	assert.Equal(t, "default-2048", target.Spec.Persistence.DefaultStore.Name)
+	assert.Equal(t, int64(2), target.GetClusterMetadata().InitialFailoverVersion)
+	assert.Empty(t, target.GetOwnerReferences())
+
+	// Both clusters are configured to replicate each other.
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(source), source))
+	assert.Equal(t, []v1beta1.RemoteClusterSpec{
+		{ClusterRef: &v1beta1.TemporalClusterReference{Name: "prod-2048", Namespace: "demo"}},
+	}, source.Spec.Replication.RemoteClusters)
+	assert.Equal(t, []v1beta1.RemoteClusterSpec{
+		{ClusterRef: &v1beta1.TemporalClusterReference{Name: "prod", Namespace: "demo"}},
+	}, target.Spec.Replication.RemoteClusters)
+
+	result := &v1beta1.TemporalReshard{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(reshard), result))
+	assert.Equal(t, v1beta1.ReshardPhaseConfiguringReplication, result.Status.Phase)
+	assert.True(t, apimeta.IsStatusConditionFalse(result.Status.Conditions, v1beta1.ReshardReplicationConfiguredCondition))
+}
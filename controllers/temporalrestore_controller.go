@@ -0,0 +1,345 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alexandrevilain/controller-tools/pkg/patch"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metrics"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/backup"
+)
+
+// TemporalRestoreReconciler reconciles a TemporalRestore object.
+type TemporalRestoreReconciler struct {
+	Base
+}
+
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalrestores,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalrestores/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalrestores/finalizers,verbs=update
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalclusters,verbs=get;list;watch;create;patch
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *TemporalRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	logger := log.FromContext(ctx)
+
+	logger.Info("Starting reconciliation")
+
+	restore := &v1beta1.TemporalRestore{}
+	err := r.Get(ctx, req.NamespacedName, restore)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			metrics.DeleteObject("TemporalRestore", req.Namespace, req.Name)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	start := time.Now()
+	defer func() {
+		metrics.ObserveReconcile("TemporalRestore", req.Namespace, req.Name, time.Since(start), reterr)
+	}()
+
+	// A restore is only run once.
+	if restore.IsCompleted() || !restore.ObjectMeta.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(restore, r.Client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	defer func() {
+		// Always attempt to Patch the TemporalRestore object and status after each reconciliation.
+		err := patchHelper.Patch(ctx, restore)
+		if err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	temporalBackup := &v1beta1.TemporalBackup{}
+	err = r.Get(ctx, types.NamespacedName{Namespace: restore.Namespace, Name: restore.Spec.BackupRef.Name}, temporalBackup)
+	if err != nil {
+		return r.handleError(restore, v1beta1.ReconcileErrorReason, err)
+	}
+
+	backupID := restore.Spec.BackupID
+	if backupID == "" {
+		backupID = temporalBackup.Status.BackupID
+	}
+	if backupID == "" {
+		err := fmt.Errorf("no backup ID provided and TemporalBackup \"%s\" does not report any", temporalBackup.GetName())
+		return r.handleError(restore, v1beta1.ReconcileErrorReason, err)
+	}
+
+	cluster := &v1beta1.TemporalCluster{}
+	err = r.Get(ctx, restore.Spec.ClusterRef.NamespacedName(restore), cluster)
+	if err != nil && (!apierrors.IsNotFound(err) || restore.Status.ClusterCreated) {
+		return r.handleError(restore, v1beta1.ReconcileErrorReason, err)
+	}
+	clusterFound := err == nil
+
+	// The spec stored with the backup is used to recreate a lost cluster, and to check the backup
+	// matches the version of an existing cluster, as schemas are restored along with the data.
+	if !restore.Status.ClusterCreated {
+		var existingCluster *v1beta1.TemporalCluster
+		if clusterFound {
+			existingCluster = cluster
+		}
+
+		spec, err := r.backupClusterSpec(ctx, restore, temporalBackup, existingCluster, backupID)
+		if err != nil {
+			return r.handleError(restore, v1beta1.ReconcileErrorReason, err)
+		}
+		if spec == nil {
+			logger.Info("Downloading the cluster spec stored with the backup", "backupID", backupID)
+			v1beta1.SetTemporalRestoreReady(restore, metav1.ConditionFalse, v1beta1.RestoreDownloadingClusterSpecReason, fmt.Sprintf("Downloading the cluster spec stored with backup %s", backupID))
+			return r.handleSuccessWithBackoff(restore)
+		}
+
+		if !clusterFound {
+			err = r.createCluster(ctx, restore, spec)
+			if err != nil {
+				return r.handleError(restore, v1beta1.ReconcileErrorReason, err)
+			}
+			logger.Info("Created cluster from the backup, waiting for it to be ready", "cluster", restore.Spec.ClusterRef.Name)
+			v1beta1.SetTemporalRestoreReady(restore, metav1.ConditionFalse, v1beta1.RestoreCreatingClusterReason, "TemporalCluster created from the backup, waiting for it to be ready")
+			return r.handleSuccessWithBackoff(restore)
+		}
+
+		err = validateRestoreVersion(backupID, spec, cluster)
+		if err != nil {
+			return r.handleError(restore, v1beta1.ReconcileErrorReason, err)
+		}
+	}
+
+	err = backup.ValidateDatastores(temporalBackup, cluster)
+	if err != nil {
+		return r.handleError(restore, v1beta1.ReconcileErrorReason, err)
+	}
+
+	// A cluster created by the restore is paused once its databases and schemas are set up.
+	if restore.Status.ClusterCreated && !cluster.IsPaused() {
+		if !cluster.IsReady() {
+			logger.Info("Waiting for the created cluster to be ready before pausing it")
+			v1beta1.SetTemporalRestoreReady(restore, metav1.ConditionFalse, v1beta1.RestoreCreatingClusterReason, "TemporalCluster created from the backup, waiting for it to be ready")
+			return r.handleSuccessWithBackoff(restore)
+		}
+
+		patch := client.MergeFrom(cluster.DeepCopy())
+		cluster.Spec.Paused = true
+		err = r.Patch(ctx, cluster, patch)
+		if err != nil {
+			return r.handleError(restore, v1beta1.ReconcileErrorReason, fmt.Errorf("can't pause created cluster: %w", err))
+		}
+	}
+
+	stopped, err := isClusterStopped(ctx, r.Client, cluster)
+	if err != nil {
+		return r.handleError(restore, v1beta1.ReconcileErrorReason, err)
+	}
+
+	if !stopped {
+		logger.Info("Waiting for the referenced cluster to be paused before restoring")
+		v1beta1.SetTemporalRestoreReady(restore, metav1.ConditionFalse, v1beta1.RestoreWaitingForPausedClusterReason, "Set spec.paused on the cluster to start the restore")
+		return r.handleSuccessWithBackoff(restore)
+	}
+
+	restore.Status.BackupID = backupID
+
+	job, err := getOrCreateJob(ctx, r.Client, backup.NewRestoreJobBuilder(restore, temporalBackup, cluster, backupID, r.Scheme))
+	if err != nil {
+		err = fmt.Errorf("can't reconcile restore job: %w", err)
+		return r.handleError(restore, v1beta1.ReconcileErrorReason, err)
+	}
+
+	switch {
+	case isJobSucceeded(job):
+		v1beta1.SetTemporalRestoreReady(restore, metav1.ConditionTrue, v1beta1.RestoreSucceededReason, "Restore successfully completed, the cluster can be resumed")
+	case isJobFailed(job) && restore.Status.Retries < restore.Spec.GetBackoffLimit():
+		// Restore scripts drop existing objects before restoring them, the job can be recreated safely.
+		restore.Status.Retries++
+		logger.Info("Restore job failed, retrying", "job", job.GetName(), "retries", restore.Status.Retries)
+		v1beta1.SetTemporalRestoreReady(restore, metav1.ConditionFalse, v1beta1.RestoreInProgressReason, fmt.Sprintf("Restore job failed, retrying (%d/%d)", restore.Status.Retries, restore.Spec.GetBackoffLimit()))
+		return r.handleSuccessWithBackoff(restore)
+	case isJobFailed(job):
+		v1beta1.SetTemporalRestoreReady(restore, metav1.ConditionFalse, v1beta1.RestoreFailedReason, "Restore job failed")
+	default:
+		v1beta1.SetTemporalRestoreReady(restore, metav1.ConditionFalse, v1beta1.RestoreInProgressReason, "Restore job is running")
+		return r.handleSuccessWithBackoff(restore)
+	}
+
+	logger.Info("Successfully reconciled restore", "restore", restore.GetName())
+
+	return r.handleSuccess(restore)
+}
+
+// backupClusterSpec returns the spec of the cluster stored with the backup (cluster.json), downloaded by a job
+// as the operator doesn't access the backup storage. It returns nil while the spec is being downloaded.
+// The provided cluster is nil if it doesn't exist.
+func (r *TemporalRestoreReconciler) backupClusterSpec(ctx context.Context, restore *v1beta1.TemporalRestore, temporalBackup *v1beta1.TemporalBackup, cluster *v1beta1.TemporalCluster, backupID string) (*v1beta1.TemporalClusterSpec, error) {
+	key := restore.Spec.ClusterRef.NamespacedName(restore)
+
+	job, err := getOrCreateJob(ctx, r.Client, backup.NewClusterSpecJobBuilder(restore, temporalBackup, cluster, key.Namespace, backupID, r.Scheme))
+	if err != nil {
+		return nil, fmt.Errorf("can't reconcile cluster spec job: %w", err)
+	}
+
+	switch {
+	case isJobFailed(job):
+		return nil, fmt.Errorf("the cluster spec stored with backup %s can't be downloaded, see job %s", backupID, job.GetName())
+	case !isJobSucceeded(job):
+		return nil, nil
+	}
+
+	message, err := r.jobTerminationMessage(ctx, job, backup.ClusterSpecContainerName)
+	if err != nil {
+		return nil, err
+	}
+
+	return backup.DecodeClusterSpec(message)
+}
+
+// createCluster creates the restored cluster from the provided spec stored with the backup.
+func (r *TemporalRestoreReconciler) createCluster(ctx context.Context, restore *v1beta1.TemporalRestore, spec *v1beta1.TemporalClusterSpec) error {
+	key := restore.Spec.ClusterRef.NamespacedName(restore)
+	cluster := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: *spec,
+	}
+	// The cluster has to run first so its databases and schemas are created.
+	cluster.Spec.Paused = false
+
+	err := r.Create(ctx, cluster)
+	if err != nil {
+		return fmt.Errorf("can't create cluster from the backup: %w", err)
+	}
+
+	restore.Status.ClusterCreated = true
+	return nil
+}
+
+// validateRestoreVersion returns an error if the backup was taken with another version than the one the cluster runs.
+// Schemas are restored along with the data: restoring an older backup would leave an outdated schema.
+func validateRestoreVersion(backupID string, spec *v1beta1.TemporalClusterSpec, cluster *v1beta1.TemporalCluster) error {
+	if spec.Version == nil {
+		return fmt.Errorf("the cluster spec stored with backup %s has no version", backupID)
+	}
+
+	clusterVersion := cluster.Status.Version
+	if clusterVersion == "" && cluster.Spec.Version != nil {
+		clusterVersion = cluster.Spec.Version.String()
+	}
+
+	if spec.Version.String() != clusterVersion {
+		return fmt.Errorf("backup %s was taken with version %s but the cluster runs version %s, restore it into a cluster running version %s",
+			backupID, spec.Version.String(), clusterVersion, spec.Version.String())
+	}
+
+	return nil
+}
+
+// jobTerminationMessage returns the termination message of the provided container in the succeeded pod of the job.
+func (r *TemporalRestoreReconciler) jobTerminationMessage(ctx context.Context, job *batchv1.Job, container string) (string, error) {
+	if job.Spec.Selector == nil {
+		return "", fmt.Errorf("job %s has no pod selector", job.GetName())
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return "", fmt.Errorf("can't parse job %s pod selector: %w", job.GetName(), err)
+	}
+
+	pods := &corev1.PodList{}
+	err = r.List(ctx, pods, client.InNamespace(job.GetNamespace()), client.MatchingLabelsSelector{Selector: selector})
+	if err != nil {
+		return "", fmt.Errorf("can't list job %s pods: %w", job.GetName(), err)
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name == container && status.State.Terminated != nil && status.State.Terminated.Message != "" {
+				return status.State.Terminated.Message, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("no succeeded pod of job %s reports its result, delete the job to run it again", job.GetName())
+}
+
+func (r *TemporalRestoreReconciler) handleSuccess(restore *v1beta1.TemporalRestore) (ctrl.Result, error) {
+	return r.handleSuccessWithRequeue(restore, 0)
+}
+
+func (r *TemporalRestoreReconciler) handleError(restore *v1beta1.TemporalRestore, reason string, err error) (ctrl.Result, error) { //nolint:unparam
+	return r.handleErrorWithRequeue(restore, reason, err, r.Backoff.When(client.ObjectKeyFromObject(restore)))
+}
+
+func (r *TemporalRestoreReconciler) handleSuccessWithRequeue(restore *v1beta1.TemporalRestore, requeueAfter time.Duration) (ctrl.Result, error) {
+	r.Backoff.Forget(client.ObjectKeyFromObject(restore))
+	v1beta1.SetTemporalRestoreReconcileSuccess(restore, metav1.ConditionTrue, v1beta1.ReconcileSuccessReason, "")
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// handleSuccessWithBackoff requeues the restore while it waits for its cluster or its job, backing off between checks.
+func (r *TemporalRestoreReconciler) handleSuccessWithBackoff(restore *v1beta1.TemporalRestore) (ctrl.Result, error) {
+	v1beta1.SetTemporalRestoreReconcileSuccess(restore, metav1.ConditionTrue, v1beta1.ReconcileSuccessReason, "")
+	return reconcile.Result{RequeueAfter: r.Backoff.When(client.ObjectKeyFromObject(restore))}, nil
+}
+
+func (r *TemporalRestoreReconciler) handleErrorWithRequeue(restore *v1beta1.TemporalRestore, reason string, err error, requeueAfter time.Duration) (ctrl.Result, error) {
+	if reason == "" {
+		reason = v1beta1.ReconcileErrorReason
+	}
+	v1beta1.SetTemporalRestoreReconcileError(restore, metav1.ConditionTrue, reason, err.Error())
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TemporalRestoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.TemporalRestore{}).
+		Owns(&batchv1.Job{}).
+		WithOptions(controller.Options{RateLimiter: r.Backoff.RateLimiter()}).
+		Complete(r)
+}
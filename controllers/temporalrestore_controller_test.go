@@ -0,0 +1,264 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/backup"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// clusterSpecMessage returns the termination message of the cluster spec job downloading the provided spec.
+func clusterSpecMessage(spec string) string {
+	buf := &bytes.Buffer{}
+	w := gzip.NewWriter(buf)
+	_, _ = w.Write([]byte(spec))
+	_ = w.Close()
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+// clusterSpecJobObjects returns the cluster spec job of the "prod-restore" restore with the provided condition,
+// and its pod reporting the provided spec.
+func clusterSpecJobObjects(condition batchv1.JobConditionType, spec string) []client.Object {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"job-name": "prod-restore-cluster-spec"}}
+
+	return []client.Object{
+		&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod-restore-cluster-spec", Namespace: "demo"},
+			Spec:       batchv1.JobSpec{Selector: selector},
+			Status: batchv1.JobStatus{
+				Conditions: []batchv1.JobCondition{{Type: condition, Status: corev1.ConditionTrue}},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod-restore-cluster-spec-abcde", Namespace: "demo", Labels: selector.MatchLabels},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodSucceeded,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name: backup.ClusterSpecContainerName,
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{Message: clusterSpecMessage(spec)},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// restoreTestObjects returns a "prod-restore" restore of the "prod-daily" backup, into the "prod" cluster.
+func restoreTestObjects() (*v1beta1.TemporalBackup, *v1beta1.TemporalRestore) {
+	// When rebuilding a lost cluster, the TemporalBackup is recreated along with it and doesn't report any status.
+	temporalBackup := &v1beta1.TemporalBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-daily", Namespace: "demo"},
+		Spec: v1beta1.TemporalBackupSpec{
+			ClusterRef: v1beta1.TemporalClusterReference{Name: "prod"},
+			Schedule:   "0 2 * * *",
+			Storage: v1beta1.BackupStorageSpec{
+				S3: &v1beta1.BackupS3Storage{Bucket: "temporal-backups", Region: "eu-west-1"},
+			},
+		},
+	}
+	restore := &v1beta1.TemporalRestore{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-restore", Namespace: "demo"},
+		Spec: v1beta1.TemporalRestoreSpec{
+			ClusterRef: v1beta1.TemporalClusterReference{Name: "prod"},
+			BackupRef:  corev1.LocalObjectReference{Name: "prod-daily"},
+			BackupID:   "20240101020000",
+		},
+	}
+	return temporalBackup, restore
+}
+
+func TestRestoreCreatesCluster(t *testing.T) {
+	tests := map[string]struct {
+		jobCondition           batchv1.JobConditionType
+		clusterSpec            string
+		expectedClusterCreated bool
+		expectedReason         string
+		expectedReconcileError string
+	}{
+		"cluster spec being downloaded": {
+			expectedReason: v1beta1.RestoreDownloadingClusterSpecReason,
+		},
+		"cluster spec downloaded": {
+			jobCondition:           batchv1.JobComplete,
+			clusterSpec:            `{"version":"1.23.0","numHistoryShards":512,"paused":true}`,
+			expectedClusterCreated: true,
+			expectedReason:         v1beta1.RestoreCreatingClusterReason,
+		},
+		"cluster spec download failed": {
+			jobCondition:           batchv1.JobFailed,
+			expectedReconcileError: "the cluster spec stored with backup 20240101020000 can't be downloaded, see job prod-restore-cluster-spec",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			scheme := runtime.NewScheme()
+			utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+			utilruntime.Must(v1beta1.AddToScheme(scheme))
+
+			temporalBackup, restore := restoreTestObjects()
+			objects := []client.Object{temporalBackup, restore}
+			if test.jobCondition != "" {
+				objects = append(objects, clusterSpecJobObjects(test.jobCondition, test.clusterSpec)...)
+			}
+
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(objects...).
+				WithStatusSubresource(&v1beta1.TemporalRestore{}).
+				Build()
+			r := &TemporalRestoreReconciler{
+				Base: New(c, scheme, record.NewFakeRecorder(10), nil, 0),
+			}
+			ctx := context.Background()
+
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(restore)})
+			require.NoError(tt, err)
+
+			result := &v1beta1.TemporalRestore{}
+			require.NoError(tt, c.Get(ctx, client.ObjectKeyFromObject(restore), result))
+			assert.Equal(tt, test.expectedClusterCreated, result.Status.ClusterCreated)
+
+			job := &batchv1.Job{}
+			require.NoError(tt, c.Get(ctx, client.ObjectKey{Namespace: "demo", Name: "prod-restore-cluster-spec"}, job))
+
+			cluster := &v1beta1.TemporalCluster{}
+			err = c.Get(ctx, client.ObjectKey{Namespace: "demo", Name: "prod"}, cluster)
+			if test.expectedReconcileError != "" {
+				condition := apimeta.FindStatusCondition(result.Status.Conditions, v1beta1.ReconcileErrorCondition)
+				require.NotNil(tt, condition)
+				assert.Equal(tt, test.expectedReconcileError, condition.Message)
+				assert.Error(tt, err)
+				return
+			}
+
+			condition := apimeta.FindStatusCondition(result.Status.Conditions, v1beta1.ReadyCondition)
+			require.NotNil(tt, condition)
+			assert.Equal(tt, test.expectedReason, condition.Reason)
+
+			if !test.expectedClusterCreated {
+				assert.Error(tt, err)
+				return
+			}
+
+			require.NoError(tt, err)
+			assert.Equal(tt, int32(512), cluster.Spec.NumHistoryShards)
+			assert.False(tt, cluster.Spec.Paused)
+		})
+	}
+}
+
+func TestRestoreChecksBackupVersion(t *testing.T) {
+	tests := map[string]struct {
+		clusterVersion         string
+		expectedReason         string
+		expectedReconcileError string
+	}{
+		"backup of the running version": {
+			clusterVersion: "1.23.0",
+			expectedReason: v1beta1.RestoreWaitingForPausedClusterReason,
+		},
+		"backup of an older version": {
+			clusterVersion:         "1.24.0",
+			expectedReconcileError: "backup 20240101020000 was taken with version 1.23.0 but the cluster runs version 1.24.0, restore it into a cluster running version 1.23.0",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			scheme := runtime.NewScheme()
+			utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+			utilruntime.Must(v1beta1.AddToScheme(scheme))
+
+			sqlStore := func(name string) *v1beta1.DatastoreSpec {
+				return &v1beta1.DatastoreSpec{
+					Name: name,
+					SQL:  &v1beta1.SQLSpec{PluginName: "postgres12", ConnectAddr: "postgres.demo:5432", DatabaseName: name},
+				}
+			}
+			cluster := &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString(test.clusterVersion),
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore:    sqlStore("default"),
+						VisibilityStore: sqlStore("visibility"),
+					},
+				},
+				Status: v1beta1.TemporalClusterStatus{
+					Version: test.clusterVersion,
+				},
+			}
+
+			temporalBackup, restore := restoreTestObjects()
+			objects := []client.Object{temporalBackup, restore, cluster}
+			objects = append(objects, clusterSpecJobObjects(batchv1.JobComplete, `{"version":"1.23.0","numHistoryShards":512}`)...)
+
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(objects...).
+				WithStatusSubresource(&v1beta1.TemporalRestore{}).
+				Build()
+			r := &TemporalRestoreReconciler{
+				Base: New(c, scheme, record.NewFakeRecorder(10), nil, 0),
+			}
+			ctx := context.Background()
+
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(restore)})
+			require.NoError(tt, err)
+
+			result := &v1beta1.TemporalRestore{}
+			require.NoError(tt, c.Get(ctx, client.ObjectKeyFromObject(restore), result))
+			assert.False(tt, result.Status.ClusterCreated)
+
+			if test.expectedReconcileError != "" {
+				condition := apimeta.FindStatusCondition(result.Status.Conditions, v1beta1.ReconcileErrorCondition)
+				require.NotNil(tt, condition)
+				assert.Equal(tt, test.expectedReconcileError, condition.Message)
+				return
+			}
+
+			condition := apimeta.FindStatusCondition(result.Status.Conditions, v1beta1.ReadyCondition)
+			require.NotNil(tt, condition)
+			assert.Equal(tt, test.expectedReason, condition.Reason)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// TLSTerminationType dictates where the secure communication will stop.
+type TLSTerminationType string
+
+const (
+	// TLSTerminationEdge terminates encryption at the router.
+	TLSTerminationEdge TLSTerminationType = "edge"
+	// TLSTerminationPassthrough passes the encrypted traffic through to the destination.
+	TLSTerminationPassthrough TLSTerminationType = "passthrough"
+	// TLSTerminationReencrypt terminates encryption at the router and re-encrypts the traffic to the destination.
+	TLSTerminationReencrypt TLSTerminationType = "reencrypt"
+)
+
+// InsecureEdgeTerminationPolicyType dictates the behavior of insecure connections to an edge-terminated route.
+type InsecureEdgeTerminationPolicyType string
+
+// WildcardPolicyType indicates the type of wildcard support needed by routes.
+type WildcardPolicyType string
+
+const (
+	// WildcardPolicyNone indicates no wildcard support is needed.
+	WildcardPolicyNone WildcardPolicyType = "None"
+)
+
+// +kubebuilder:object:root=true
+
+// Route exposes a service at a host name.
+type Route struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RouteSpec   `json:"spec"`
+	Status RouteStatus `json:"status,omitempty"`
+}
+
+// RouteSpec describes the hostname or path the route exposes.
+type RouteSpec struct {
+	// Host is an alias/DNS that points to the service.
+	Host string `json:"host,omitempty"`
+	// Path that the router watches for, to route traffic for to the service.
+	Path string `json:"path,omitempty"`
+	// To is the object this route points to.
+	To RouteTargetReference `json:"to"`
+	// Port is the target port on pods selected by the service this route points to.
+	Port *RoutePort `json:"port,omitempty"`
+	// TLS provides the ability to configure certificates and termination for the route.
+	TLS *TLSConfig `json:"tls,omitempty"`
+	// WildcardPolicy is the wildcard policy of the route.
+	WildcardPolicy WildcardPolicyType `json:"wildcardPolicy,omitempty"`
+}
+
+// RouteTargetReference specifies the target that resolve into endpoints.
+type RouteTargetReference struct {
+	// Kind of the referent, only "Service" is allowed.
+	Kind string `json:"kind"`
+	// Name of the service/target that is being referred to.
+	Name string `json:"name"`
+	// Weight as an integer between 0 and 256, default 100.
+	Weight *int32 `json:"weight"`
+}
+
+// RoutePort defines a port mapping from a router to an endpoint in the service endpoints.
+type RoutePort struct {
+	// TargetPort is the target port on pods selected by the service this route points to.
+	TargetPort intstr.IntOrString `json:"targetPort"`
+}
+
+// TLSConfig defines config used to secure a route and provide termination.
+type TLSConfig struct {
+	// Termination indicates termination type.
+	Termination TLSTerminationType `json:"termination"`
+	// Certificate provides certificate contents, in PEM format.
+	Certificate string `json:"certificate,omitempty"`
+	// Key provides key file contents, in PEM format.
+	Key string `json:"key,omitempty"`
+	// CACertificate provides the cert authority certificate contents, in PEM format.
+	CACertificate string `json:"caCertificate,omitempty"`
+	// DestinationCACertificate provides the contents of the ca certificate of the final destination, in PEM format.
+	DestinationCACertificate string `json:"destinationCACertificate,omitempty"`
+	// InsecureEdgeTerminationPolicy indicates the desired behavior for insecure connections to a route.
+	InsecureEdgeTerminationPolicy InsecureEdgeTerminationPolicyType `json:"insecureEdgeTerminationPolicy,omitempty"`
+}
+
+// RouteStatus provides relevant info about the status of a route.
+type RouteStatus struct {
+	// Ingress describes the places where the route may be exposed.
+	Ingress []RouteIngress `json:"ingress,omitempty"`
+}
+
+// RouteIngress holds information about the places where a route is exposed.
+type RouteIngress struct {
+	// Host is the host string under which the route is exposed.
+	Host string `json:"host,omitempty"`
+	// RouterName is a name chosen by the router to identify itself.
+	RouterName string `json:"routerName,omitempty"`
+	// RouterCanonicalHostname is the external host name for the router that can be used as a CNAME for the host requested for this route.
+	RouterCanonicalHostname string `json:"routerCanonicalHostname,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RouteList is a collection of Routes.
+type RouteList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Route `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Route{}, &RouteList{})
+}
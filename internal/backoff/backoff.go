@@ -0,0 +1,102 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package backoff computes exponentially increasing delays with jitter
+// between the reconciliations of a resource.
+package backoff
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// DefaultBaseDelay is the delay before the first retry.
+	DefaultBaseDelay = time.Second
+	// DefaultMaxDelay is the default cap of the delays.
+	DefaultMaxDelay = 5 * time.Minute
+)
+
+var _ workqueue.RateLimiter = (*Backoff)(nil)
+
+// Backoff tracks the attempts of each item and computes the delay before their next attempt.
+// The delay doubles at each attempt up to the max delay, and is randomized between its half and its full value
+// to spread the retries of items failing at the same time.
+// It implements workqueue.RateLimiter.
+type Backoff struct {
+	baseDelay time.Duration
+	maxDelay  time.Duration
+
+	mu       sync.Mutex
+	attempts map[any]int
+}
+
+// New returns a new Backoff.
+func New(baseDelay, maxDelay time.Duration) *Backoff {
+	if maxDelay < baseDelay {
+		maxDelay = baseDelay
+	}
+
+	return &Backoff{
+		baseDelay: baseDelay,
+		maxDelay:  maxDelay,
+		attempts:  map[any]int{},
+	}
+}
+
+// When records an attempt for the provided item and returns the delay before it.
+func (b *Backoff) When(item any) time.Duration {
+	b.mu.Lock()
+	attempt := b.attempts[item]
+	b.attempts[item] = attempt + 1
+	b.mu.Unlock()
+
+	delay := b.maxDelay
+	// Prevent overflows: past 2^30 the delay is capped anyway.
+	if attempt < 30 {
+		if d := b.baseDelay * time.Duration(1<<attempt); d < b.maxDelay {
+			delay = d
+		}
+	}
+
+	half := int64(delay / 2)
+	return time.Duration(half + rand.Int63n(half+1)) //nolint:gosec
+}
+
+// NumRequeues returns the number of attempts recorded for the provided item.
+func (b *Backoff) NumRequeues(item any) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.attempts[item]
+}
+
+// Forget resets the attempts of the provided item.
+func (b *Backoff) Forget(item any) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.attempts, item)
+}
+
+// RateLimiter returns a new Backoff using the same delays, with its own attempts.
+func (b *Backoff) RateLimiter() workqueue.RateLimiter {
+	return New(b.baseDelay, b.maxDelay)
+}
@@ -0,0 +1,51 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package backoff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff(t *testing.T) {
+	b := New(time.Second, 10*time.Second)
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}
+	for i, max := range expected {
+		delay := b.When("item")
+		assert.GreaterOrEqual(t, delay, max/2, "attempt %d", i)
+		assert.LessOrEqual(t, delay, max, "attempt %d", i)
+	}
+	assert.Equal(t, len(expected), b.NumRequeues("item"))
+	assert.Equal(t, 0, b.NumRequeues("other"))
+
+	b.Forget("item")
+	assert.Equal(t, 0, b.NumRequeues("item"))
+	assert.LessOrEqual(t, b.When("item"), time.Second)
+}
+
+func TestBackoffManyAttempts(t *testing.T) {
+	b := New(time.Second, time.Minute)
+	for i := 0; i < 100; i++ {
+		delay := b.When("item")
+		assert.Greater(t, delay, time.Duration(0))
+		assert.LessOrEqual(t, delay, time.Minute)
+	}
+}
@@ -21,11 +21,14 @@ import (
 	"fmt"
 
 	"github.com/alexandrevilain/controller-tools/pkg/discovery"
+	routev1 "github.com/alexandrevilain/temporal-operator/internal/apis/openshift/route/v1"
 	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/go-logr/logr"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	istionetworkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	istiosecurityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
 // AvailableAPIs holds available apis in the cluster.
@@ -33,6 +36,10 @@ type AvailableAPIs struct {
 	Istio              bool
 	CertManager        bool
 	PrometheusOperator bool
+	GatewayAPI         bool
+	GRPCRoute          bool
+	TLSRoute           bool
+	OpenShiftRoute     bool
 }
 
 // FindAvailableAPIs searches for available well-known APIs in the cluster.
@@ -55,9 +62,34 @@ func FindAvailableAPIs(logger logr.Logger, mgr discovery.Manager) (*AvailableAPI
 		return nil, fmt.Errorf("can't determine if prometheus-operator is available: %w", err)
 	}
 
+	resources.GatewayAPI, err = mgr.AreObjectsSupported(&gatewayv1.HTTPRoute{})
+	if err != nil {
+		return nil, fmt.Errorf("can't determine if gateway api is available: %w", err)
+	}
+
+	// GRPCRoute and TLSRoute are part of the Gateway API experimental channel, which may not be installed.
+	resources.GRPCRoute, err = mgr.AreObjectsSupported(&gatewayv1alpha2.GRPCRoute{})
+	if err != nil {
+		return nil, fmt.Errorf("can't determine if gateway api grpcroutes are available: %w", err)
+	}
+
+	resources.TLSRoute, err = mgr.AreObjectsSupported(&gatewayv1alpha2.TLSRoute{})
+	if err != nil {
+		return nil, fmt.Errorf("can't determine if gateway api tlsroutes are available: %w", err)
+	}
+
+	resources.OpenShiftRoute, err = mgr.AreObjectsSupported(&routev1.Route{})
+	if err != nil {
+		return nil, fmt.Errorf("can't determine if openshift routes are available: %w", err)
+	}
+
 	logResourceAvailability(logger, "cert-manager", resources.CertManager)
 	logResourceAvailability(logger, "istio", resources.Istio)
 	logResourceAvailability(logger, "prometheus-operator", resources.PrometheusOperator)
+	logResourceAvailability(logger, "gateway-api", resources.GatewayAPI)
+	logResourceAvailability(logger, "gateway-api-grpcroute", resources.GRPCRoute)
+	logResourceAvailability(logger, "gateway-api-tlsroute", resources.TLSRoute)
+	logResourceAvailability(logger, "openshift-route", resources.OpenShiftRoute)
 
 	return resources, nil
 }
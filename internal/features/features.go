@@ -0,0 +1,94 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package features holds the feature gates of the operator.
+package features
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Feature is the name of a feature gate.
+type Feature string
+
+const (
+	// ResourcePruning deletes the resources created for a TemporalCluster which are not generated anymore.
+	ResourcePruning Feature = "ResourcePruning"
+)
+
+// defaults holds the known features and whether they are enabled by default.
+var defaults = map[Feature]bool{
+	ResourcePruning: true,
+}
+
+var (
+	mu    sync.RWMutex
+	gates = map[Feature]bool{}
+)
+
+// Enabled returns true if the provided feature is enabled.
+func Enabled(feature Feature) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if enabled, ok := gates[feature]; ok {
+		return enabled
+	}
+	return defaults[feature]
+}
+
+// Validate returns an error if an unknown feature is provided.
+func Validate(features map[string]bool) error {
+	for name := range features {
+		if _, ok := defaults[Feature(name)]; !ok {
+			return fmt.Errorf("unknown feature gate %q, known feature gates: %s", name, strings.Join(Known(), ", "))
+		}
+	}
+	return nil
+}
+
+// Set overrides the default state of the provided features. Features not provided are reset to their default.
+// It returns an error if an unknown feature is provided.
+func Set(features map[string]bool) error {
+	if err := Validate(features); err != nil {
+		return err
+	}
+
+	newGates := make(map[Feature]bool, len(features))
+	for name, enabled := range features {
+		newGates[Feature(name)] = enabled
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	gates = newGates
+	return nil
+}
+
+// Known returns the names of the known features, sorted.
+func Known() []string {
+	names := make([]string, 0, len(defaults))
+	for feature := range defaults {
+		names = append(names, string(feature))
+	}
+	sort.Strings(names)
+	return names
+}
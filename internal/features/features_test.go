@@ -0,0 +1,43 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package features_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/internal/features"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSet(t *testing.T) {
+	defer func() {
+		require.NoError(t, features.Set(nil))
+	}()
+
+	assert.True(t, features.Enabled(features.ResourcePruning), "enabled by default")
+
+	require.NoError(t, features.Set(map[string]bool{"ResourcePruning": false}))
+	assert.False(t, features.Enabled(features.ResourcePruning))
+
+	require.NoError(t, features.Set(nil))
+	assert.True(t, features.Enabled(features.ResourcePruning), "reset to default")
+
+	err := features.Set(map[string]bool{"Unknown": true})
+	assert.EqualError(t, err, `unknown feature gate "Unknown", known feature gates: ResourcePruning`)
+}
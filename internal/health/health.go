@@ -0,0 +1,98 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package health provides the health checks of the operator.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+const (
+	// DefaultMaxReconcileDuration is the default duration after which a reconciliation is considered stuck.
+	DefaultMaxReconcileDuration = 10 * time.Minute
+
+	// longestRunningProcessorMetric is the controller-runtime workqueue metric holding the duration
+	// of the longest running reconciliation of a controller.
+	longestRunningProcessorMetric = "workqueue_longest_running_processor_seconds"
+
+	// cacheSyncTimeout is the time the informers have to report they are synced.
+	cacheSyncTimeout = time.Second
+)
+
+// ControllerChecker returns a health check failing when the provided controller has been reconciling
+// the same resource for more than maxReconcileDuration, e.g. when a reconciliation is blocked.
+// Controllers which are not started (e.g. on replicas not holding the leader election lease) are healthy.
+func ControllerChecker(gatherer prometheus.Gatherer, controller string, maxReconcileDuration time.Duration) healthz.Checker {
+	return func(_ *http.Request) error {
+		families, err := gatherer.Gather()
+		if err != nil {
+			return fmt.Errorf("can't gather metrics: %w", err)
+		}
+
+		for _, family := range families {
+			if family.GetName() != longestRunningProcessorMetric {
+				continue
+			}
+
+			for _, metric := range family.GetMetric() {
+				if !hasLabel(metric.GetLabel(), "name", controller) {
+					continue
+				}
+
+				running := time.Duration(metric.GetGauge().GetValue() * float64(time.Second))
+				if running > maxReconcileDuration {
+					return fmt.Errorf("controller %s has been reconciling a resource for %s", controller, running.Round(time.Second))
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+// CacheSyncChecker returns a health check failing until the informers of the provided cache are synced.
+func CacheSyncChecker(c cache.Cache) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), cacheSyncTimeout)
+		defer cancel()
+
+		if !c.WaitForCacheSync(ctx) {
+			return errors.New("informers are not synced")
+		}
+
+		return nil
+	}
+}
+
+func hasLabel(labels []*dto.LabelPair, name, value string) bool {
+	for _, label := range labels {
+		if label.GetName() == name && label.GetValue() == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package health_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/internal/health"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestControllerChecker(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	longestRunningProcessor := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: "workqueue",
+		Name:      "longest_running_processor_seconds",
+	}, []string{"name"})
+	registry.MustRegister(longestRunningProcessor)
+
+	longestRunningProcessor.WithLabelValues("temporalcluster").Set(5)
+	longestRunningProcessor.WithLabelValues("temporalnamespace").Set(900)
+
+	tests := map[string]struct {
+		controller  string
+		expectedErr string
+	}{
+		"healthy controller": {
+			controller: "temporalcluster",
+		},
+		"stuck controller": {
+			controller:  "temporalnamespace",
+			expectedErr: "controller temporalnamespace has been reconciling a resource for 15m0s",
+		},
+		"not started controller": {
+			controller: "temporalbackup",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			err := health.ControllerChecker(registry, test.controller, 10*time.Minute)(nil)
+			if test.expectedErr != "" {
+				assert.EqualError(tt, err, test.expectedErr)
+				return
+			}
+			assert.NoError(tt, err)
+		})
+	}
+}
@@ -63,3 +63,10 @@ func HeadlessLabels() map[string]string {
 		"app.kubernetes.io/headless": "true",
 	}
 }
+
+// CanaryLabels returns labels to express that a workload is a canary.
+func CanaryLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/canary": "true",
+	}
+}
@@ -0,0 +1,232 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package metrics holds the operator's own prometheus metrics,
+// exposed on the controller-runtime metrics endpoint.
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const metricsNamespace = "temporal_operator"
+
+var (
+	certificateExpiry = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "certificate_expiry_timestamp_seconds",
+			Help:      "Expiration time of the temporal clusters mTLS certificates, in seconds since epoch.",
+		},
+		[]string{"namespace", "cluster", "secret"},
+	)
+
+	reconcileDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "reconcile_duration_seconds",
+			Help:      "Duration of the reconciliations of each custom resource, by result.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+		},
+		[]string{"kind", "namespace", "name", "result"},
+	)
+
+	temporalRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "temporal_request_duration_seconds",
+			Help:      "Latency of the requests sent to the temporal clusters API.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"namespace", "cluster", "method"},
+	)
+
+	temporalRequestErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "temporal_request_errors_total",
+			Help:      "Number of failed requests sent to the temporal clusters API, by gRPC status code.",
+		},
+		[]string{"namespace", "cluster", "method", "code"},
+	)
+
+	namespacesDrifted = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "namespace_drifted_total",
+			Help:      "Number of times a temporal namespace was found to differ from its TemporalNamespace spec.",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	namespacesRepaired = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "namespace_repaired_total",
+			Help:      "Number of times a drifted temporal namespace was updated back to its TemporalNamespace spec.",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	certificateDaysToExpiry = &certificateDaysToExpiryCollector{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "certificate_days_to_expiry"),
+			"Number of days before the temporal clusters mTLS certificates expire.",
+			[]string{"namespace", "cluster", "secret"},
+			nil,
+		),
+		notAfter: map[certificateKey]time.Time{},
+	}
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		certificateExpiry,
+		certificateDaysToExpiry,
+		reconcileDuration,
+		temporalRequestDuration,
+		temporalRequestErrors,
+		namespacesDrifted,
+		namespacesRepaired,
+	)
+}
+
+type certificateKey struct {
+	namespace, cluster, secret string
+}
+
+// certificateDaysToExpiryCollector computes the days to expiry of the certificates at collection time,
+// so the value doesn't depend on when the cluster was last reconciled.
+type certificateDaysToExpiryCollector struct {
+	desc     *prometheus.Desc
+	mu       sync.Mutex
+	notAfter map[certificateKey]time.Time
+}
+
+func (c *certificateDaysToExpiryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *certificateDaysToExpiryCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, notAfter := range c.notAfter {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, time.Until(notAfter).Hours()/24, key.namespace, key.cluster, key.secret)
+	}
+}
+
+func (c *certificateDaysToExpiryCollector) set(namespace, cluster string, certificates []v1beta1.CertificateStatus) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.deleteLocked(namespace, cluster)
+	for _, certificate := range certificates {
+		c.notAfter[certificateKey{namespace, cluster, certificate.SecretName}] = certificate.NotAfter.Time
+	}
+}
+
+func (c *certificateDaysToExpiryCollector) delete(namespace, cluster string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.deleteLocked(namespace, cluster)
+}
+
+func (c *certificateDaysToExpiryCollector) deleteLocked(namespace, cluster string) {
+	for key := range c.notAfter {
+		if key.namespace == namespace && key.cluster == cluster {
+			delete(c.notAfter, key)
+		}
+	}
+}
+
+// SetCertificatesExpiry records the expiry of the provided cluster's certificates.
+// Certificates no longer used by the cluster are removed.
+func SetCertificatesExpiry(namespace, cluster string, certificates []v1beta1.CertificateStatus) {
+	certificateExpiry.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "cluster": cluster})
+
+	for _, certificate := range certificates {
+		certificateExpiry.
+			WithLabelValues(namespace, cluster, certificate.SecretName).
+			Set(float64(certificate.NotAfter.Unix()))
+	}
+
+	certificateDaysToExpiry.set(namespace, cluster, certificates)
+}
+
+// ObserveReconcile records the duration and the result of a custom resource reconciliation.
+func ObserveReconcile(kind, namespace, name string, duration time.Duration, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	reconcileDuration.WithLabelValues(kind, namespace, name, result).Observe(duration.Seconds())
+}
+
+// DeleteObject removes all reconciliation metrics recorded for the provided custom resource.
+func DeleteObject(kind, namespace, name string) {
+	reconcileDuration.DeletePartialMatch(prometheus.Labels{"kind": kind, "namespace": namespace, "name": name})
+}
+
+// DeleteCluster removes all metrics recorded for the provided cluster.
+func DeleteCluster(namespace, cluster string) {
+	certificateExpiry.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "cluster": cluster})
+	certificateDaysToExpiry.delete(namespace, cluster)
+	temporalRequestDuration.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "cluster": cluster})
+	temporalRequestErrors.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "cluster": cluster})
+	DeleteObject("TemporalCluster", namespace, cluster)
+}
+
+// NamespaceDrifted records that the provided temporal namespace differs from its spec.
+func NamespaceDrifted(namespace, name string) {
+	namespacesDrifted.WithLabelValues(namespace, name).Inc()
+}
+
+// NamespaceRepaired records that the provided drifted temporal namespace was updated back to its spec.
+func NamespaceRepaired(namespace, name string) {
+	namespacesRepaired.WithLabelValues(namespace, name).Inc()
+}
+
+// DeleteNamespace removes all metrics recorded for the provided temporal namespace.
+func DeleteNamespace(namespace, name string) {
+	namespacesDrifted.DeleteLabelValues(namespace, name)
+	namespacesRepaired.DeleteLabelValues(namespace, name)
+	DeleteObject("TemporalNamespace", namespace, name)
+}
+
+// TemporalAPIInterceptor returns a gRPC interceptor recording the latency and the errors
+// of the requests sent to the provided temporal cluster.
+func TemporalAPIInterceptor(namespace, cluster string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		temporalRequestDuration.WithLabelValues(namespace, cluster, method).Observe(time.Since(start).Seconds())
+		if err != nil {
+			temporalRequestErrors.WithLabelValues(namespace, cluster, method, status.Code(err).String()).Inc()
+		}
+		return err
+	}
+}
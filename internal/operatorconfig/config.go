@@ -0,0 +1,209 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package operatorconfig holds the configuration of the operator, read from flags and from an optional configuration file.
+package operatorconfig
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/backoff"
+	"github.com/alexandrevilain/temporal-operator/internal/features"
+	"github.com/alexandrevilain/temporal-operator/internal/health"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the configuration of the operator.
+// Flags set on the command line take precedence over the values of the configuration file.
+type Config struct {
+	// WatchNamespaces are the namespaces watched by the operator. Defaults to all namespaces.
+	WatchNamespaces []string `yaml:"watchNamespaces"`
+	// WatchLabelSelector restricts the temporal.io resources reconciled by the operator.
+	WatchLabelSelector string `yaml:"watchLabelSelector"`
+	// MaxConcurrentReconciles is the maximum number of concurrent reconciles of each controller.
+	MaxConcurrentReconciles int `yaml:"maxConcurrentReconciles"`
+	// ClusterMaxConcurrentReconciles is the maximum number of concurrent reconciles of the TemporalCluster controller.
+	ClusterMaxConcurrentReconciles int `yaml:"clusterMaxConcurrentReconciles"`
+	// NamespaceMaxConcurrentReconciles is the maximum number of concurrent reconciles of the TemporalNamespace controller.
+	NamespaceMaxConcurrentReconciles int `yaml:"namespaceMaxConcurrentReconciles"`
+	// MaxRequeueDelay is the maximum delay between the reconciliations of a resource waiting for a dependency or failing.
+	MaxRequeueDelay time.Duration `yaml:"maxRequeueDelay"`
+	// MaxReconcileDuration is the duration after which a controller reconciling the same resource is unhealthy.
+	MaxReconcileDuration time.Duration `yaml:"maxReconcileDuration"`
+	// LeaderElection configures the leader election.
+	LeaderElection LeaderElection `yaml:"leaderElection"`
+	// TemporalAPI configures the requests sent to the temporal clusters.
+	TemporalAPI TemporalAPI `yaml:"temporalAPI"`
+	// DefaultImages are the images used by the clusters not setting their own.
+	DefaultImages v1beta1.DefaultImages `yaml:"defaultImages"`
+	// FeatureGates enables or disables features of the operator.
+	FeatureGates map[string]bool `yaml:"featureGates"`
+}
+
+// LeaderElection configures the leader election.
+type LeaderElection struct {
+	// LeaseDuration is the duration non-leader candidates wait before acquiring the leadership.
+	LeaseDuration time.Duration `yaml:"leaseDuration"`
+	// RenewDeadline is the duration the leader retries renewing its leadership before giving it up.
+	RenewDeadline time.Duration `yaml:"renewDeadline"`
+	// RetryPeriod is the duration between the attempts to acquire or renew the leadership.
+	RetryPeriod time.Duration `yaml:"retryPeriod"`
+}
+
+// TemporalAPI configures the requests sent to the temporal clusters.
+type TemporalAPI struct {
+	// QPS is the maximum rate of requests per second sent to each temporal cluster. 0 disables rate limiting.
+	QPS float64 `yaml:"qps"`
+	// Burst is the number of requests sent to each temporal cluster allowed above QPS.
+	// It must be at least 1 when QPS is greater than 0.
+	Burst int `yaml:"burst"`
+}
+
+// Default returns the default configuration.
+func Default() *Config {
+	return &Config{
+		MaxConcurrentReconciles: 1,
+		MaxRequeueDelay:         backoff.DefaultMaxDelay,
+		MaxReconcileDuration:    health.DefaultMaxReconcileDuration,
+		LeaderElection: LeaderElection{
+			LeaseDuration: 15 * time.Second,
+			RenewDeadline: 10 * time.Second,
+			RetryPeriod:   2 * time.Second,
+		},
+		TemporalAPI: TemporalAPI{
+			QPS:   temporal.DefaultRequestsPerSecond,
+			Burst: temporal.DefaultRequestsBurst,
+		},
+	}
+}
+
+// BindFlags binds the configuration fields to flags of the provided flag set.
+func (c *Config) BindFlags(fs *flag.FlagSet) {
+	fs.Var((*stringSliceValue)(&c.WatchNamespaces), "watch-namespaces",
+		"Comma-separated list of namespaces watched by the operator. Defaults to all namespaces.")
+	fs.StringVar(&c.WatchLabelSelector, "watch-label-selector", c.WatchLabelSelector,
+		"Label selector restricting the temporal.io resources reconciled by the operator. Defaults to all resources.")
+	fs.IntVar(&c.MaxConcurrentReconciles, "max-concurrent-reconciles", c.MaxConcurrentReconciles,
+		"The maximum number of concurrent reconciles of each controller.")
+	fs.IntVar(&c.ClusterMaxConcurrentReconciles, "cluster-max-concurrent-reconciles", c.ClusterMaxConcurrentReconciles,
+		"The maximum number of concurrent reconciles of the TemporalCluster controller. Defaults to --max-concurrent-reconciles.")
+	fs.IntVar(&c.NamespaceMaxConcurrentReconciles, "namespace-max-concurrent-reconciles", c.NamespaceMaxConcurrentReconciles,
+		"The maximum number of concurrent reconciles of the TemporalNamespace controller. Defaults to --max-concurrent-reconciles.")
+	fs.DurationVar(&c.MaxRequeueDelay, "max-requeue-delay", c.MaxRequeueDelay,
+		"The maximum delay between the reconciliations of a resource waiting for a dependency or failing.")
+	fs.DurationVar(&c.MaxReconcileDuration, "max-reconcile-duration", c.MaxReconcileDuration,
+		"The duration after which a controller reconciling the same resource is reported as unhealthy.")
+	fs.DurationVar(&c.LeaderElection.LeaseDuration, "leader-elect-lease-duration", c.LeaderElection.LeaseDuration,
+		"The duration non-leader candidates wait before trying to acquire the leadership once the leader stopped renewing it.")
+	fs.DurationVar(&c.LeaderElection.RenewDeadline, "leader-elect-renew-deadline", c.LeaderElection.RenewDeadline,
+		"The duration the leader retries renewing its leadership before giving it up.")
+	fs.DurationVar(&c.LeaderElection.RetryPeriod, "leader-elect-retry-period", c.LeaderElection.RetryPeriod,
+		"The duration between the attempts of the candidates to acquire or renew the leadership.")
+	fs.Float64Var(&c.TemporalAPI.QPS, "temporal-api-qps", c.TemporalAPI.QPS,
+		"The maximum rate of requests per second sent by the operator to each temporal cluster. Set to 0 to disable rate limiting.")
+	fs.IntVar(&c.TemporalAPI.Burst, "temporal-api-burst", c.TemporalAPI.Burst,
+		"The number of requests sent by the operator to each temporal cluster allowed above --temporal-api-qps.")
+}
+
+// Load returns the configuration read from the provided file, on top of the default configuration.
+// The provided flags values, usually the flags set on the command line, override the file values.
+func Load(path string, flags map[string]string) (*Config, error) {
+	c := Default()
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("can't read configuration file: %w", err)
+		}
+
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(c)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("can't parse configuration file: %w", err)
+		}
+	}
+
+	fs := flag.NewFlagSet("config", flag.ContinueOnError)
+	c.BindFlags(fs)
+	for name, value := range flags {
+		if fs.Lookup(name) == nil {
+			continue
+		}
+		if err := fs.Set(name, value); err != nil {
+			return nil, fmt.Errorf("invalid value %q for flag --%s: %w", value, name, err)
+		}
+	}
+
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// validate returns an error if the configuration is invalid.
+func (c *Config) validate() error {
+	if c.LeaderElection.RenewDeadline >= c.LeaderElection.LeaseDuration {
+		return errors.New("leader election renew deadline must be lower than the lease duration")
+	}
+
+	// A rate limiter with a burst lower than 1 never lets any request through.
+	if c.TemporalAPI.QPS > 0 && c.TemporalAPI.Burst < 1 {
+		return errors.New("temporal API burst must be at least 1 when rate limiting is enabled")
+	}
+
+	return features.Validate(c.FeatureGates)
+}
+
+// ApplyReloadable applies the configuration values taking effect without restarting the operator:
+// the temporal API rate limit, the default images and the feature gates.
+func (c *Config) ApplyReloadable() error {
+	if err := features.Set(c.FeatureGates); err != nil {
+		return err
+	}
+	temporal.SetClusterRateLimit(c.TemporalAPI.QPS, c.TemporalAPI.Burst)
+	v1beta1.SetDefaultImages(c.DefaultImages)
+	return nil
+}
+
+// stringSliceValue is a flag value holding a comma-separated list of strings.
+type stringSliceValue []string
+
+func (v *stringSliceValue) String() string {
+	return strings.Join(*v, ",")
+}
+
+func (v *stringSliceValue) Set(value string) error {
+	*v = nil
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			*v = append(*v, item)
+		}
+	}
+	return nil
+}
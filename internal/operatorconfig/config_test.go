@@ -0,0 +1,158 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package operatorconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+watchNamespaces:
+  - temporal-prod
+  - temporal-staging
+namespaceMaxConcurrentReconciles: 10
+maxRequeueDelay: 2m
+temporalAPI:
+  qps: 5
+defaultImages:
+  temporal: registry.example.com/temporalio/server
+featureGates:
+  ResourcePruning: false
+`), 0o600))
+
+	tests := map[string]struct {
+		path        string
+		flags       map[string]string
+		expected    func(*Config)
+		expectedErr string
+	}{
+		"defaults without file": {
+			expected: func(*Config) {},
+		},
+		"file values": {
+			path: path,
+			expected: func(c *Config) {
+				c.WatchNamespaces = []string{"temporal-prod", "temporal-staging"}
+				c.NamespaceMaxConcurrentReconciles = 10
+				c.MaxRequeueDelay = 2 * time.Minute
+				c.TemporalAPI.QPS = 5
+				c.DefaultImages = v1beta1.DefaultImages{Temporal: "registry.example.com/temporalio/server"}
+				c.FeatureGates = map[string]bool{"ResourcePruning": false}
+			},
+		},
+		"flags override file values": {
+			path: path,
+			flags: map[string]string{
+				"watch-namespaces":  "temporal-dev",
+				"max-requeue-delay": "1m",
+				"leader-elect":      "true",
+			},
+			expected: func(c *Config) {
+				c.WatchNamespaces = []string{"temporal-dev"}
+				c.NamespaceMaxConcurrentReconciles = 10
+				c.MaxRequeueDelay = time.Minute
+				c.TemporalAPI.QPS = 5
+				c.DefaultImages = v1beta1.DefaultImages{Temporal: "registry.example.com/temporalio/server"}
+				c.FeatureGates = map[string]bool{"ResourcePruning": false}
+			},
+		},
+		"invalid leader election": {
+			flags:       map[string]string{"leader-elect-renew-deadline": "20s"},
+			expectedErr: "leader election renew deadline must be lower than the lease duration",
+		},
+		"invalid temporal API burst": {
+			flags:       map[string]string{"temporal-api-burst": "0"},
+			expectedErr: "temporal API burst must be at least 1 when rate limiting is enabled",
+		},
+		"no burst without rate limiting": {
+			flags: map[string]string{"temporal-api-qps": "0", "temporal-api-burst": "0"},
+			expected: func(c *Config) {
+				c.TemporalAPI.QPS = 0
+				c.TemporalAPI.Burst = 0
+			},
+		},
+		"missing file": {
+			path:        filepath.Join(t.TempDir(), "missing.yaml"),
+			expectedErr: "can't read configuration file",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			c, err := Load(test.path, test.flags)
+			if test.expectedErr != "" {
+				assert.ErrorContains(tt, err, test.expectedErr)
+				return
+			}
+			require.NoError(tt, err)
+
+			expected := Default()
+			test.expected(expected)
+			assert.Equal(tt, expected, c)
+		})
+	}
+}
+
+func TestLoadInvalidFile(t *testing.T) {
+	tests := map[string]struct {
+		content     string
+		expectedErr string
+	}{
+		"unknown field": {
+			content:     "watchNamespace: temporal\n",
+			expectedErr: "field watchNamespace not found",
+		},
+		"unknown feature gate": {
+			content:     "featureGates:\n  Unknown: true\n",
+			expectedErr: `unknown feature gate "Unknown"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			path := filepath.Join(tt.TempDir(), "config.yaml")
+			require.NoError(tt, os.WriteFile(path, []byte(test.content), 0o600))
+
+			_, err := Load(path, nil)
+			assert.ErrorContains(tt, err, test.expectedErr)
+		})
+	}
+}
+
+func TestRestartRequired(t *testing.T) {
+	started := Default()
+
+	reloadable := Default()
+	reloadable.TemporalAPI.QPS = 50
+	reloadable.DefaultImages.UI = "registry.example.com/temporalio/ui"
+	reloadable.FeatureGates = map[string]bool{"ResourcePruning": false}
+	assert.False(t, reloadable.restartRequired(started))
+
+	restart := Default()
+	restart.WatchNamespaces = []string{"temporal"}
+	assert.True(t, restart.restartRequired(started))
+}
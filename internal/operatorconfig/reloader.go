@@ -0,0 +1,98 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package operatorconfig
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DefaultReloadInterval is the default interval at which the configuration file is checked for changes.
+const DefaultReloadInterval = 30 * time.Second
+
+// Reloader reloads the configuration file when it changes, and applies the values taking effect without restart.
+// Polling the file handles ConfigMap volumes, whose files are updated by swapping symbolic links.
+type Reloader struct {
+	// Path is the path of the configuration file.
+	Path string
+	// Flags are the flags set on the command line, overriding the file values.
+	Flags map[string]string
+	// Interval is the interval at which the configuration file is read.
+	Interval time.Duration
+	// Started is the configuration the operator was started with.
+	Started *Config
+}
+
+// Start reloads the configuration file until the provided context is done.
+func (r *Reloader) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("config-reloader")
+
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+
+	current := r.Started
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		c, err := Load(r.Path, r.Flags)
+		if err != nil {
+			logger.Error(err, "Can't reload configuration file", "path", r.Path)
+			continue
+		}
+
+		if reflect.DeepEqual(c, current) {
+			continue
+		}
+
+		err = c.ApplyReloadable()
+		if err != nil {
+			logger.Error(err, "Can't apply configuration file", "path", r.Path)
+			continue
+		}
+		current = c
+
+		logger.Info("Configuration file reloaded", "path", r.Path)
+		if c.restartRequired(r.Started) {
+			logger.Info("Some configuration changes only take effect once the operator is restarted", "path", r.Path)
+		}
+	}
+}
+
+// NeedLeaderElection returns false: all replicas apply the configuration, as they all serve the webhooks.
+func (r *Reloader) NeedLeaderElection() bool {
+	return false
+}
+
+// restartRequired returns true if the configuration values only taking effect on startup differ.
+func (c *Config) restartRequired(started *Config) bool {
+	a, b := *c, *started
+	for _, config := range []*Config{&a, &b} {
+		config.TemporalAPI = TemporalAPI{}
+		config.DefaultImages = v1beta1.DefaultImages{}
+		config.FeatureGates = nil
+	}
+	return !reflect.DeepEqual(a, b)
+}
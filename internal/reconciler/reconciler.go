@@ -0,0 +1,360 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package reconciler reconciles the resources built by resource builders using server-side apply.
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/alexandrevilain/controller-tools/pkg/discovery"
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/csaupgrade"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// FieldManager is the field manager of the fields applied by the operator.
+const FieldManager = "temporal-operator"
+
+// legacyFieldManagers are the field managers of the fields updated by previous operator versions,
+// using get-mutate-update. Their fields are moved to FieldManager, so fields the builders stop setting are removed.
+var legacyFieldManagers = sets.New("manager")
+
+// PruneSetLabel is set on the objects applied by ReconcileAndPruneBuilders, to the name of their set of builders.
+const PruneSetLabel = "temporal.io/prune-set"
+
+// CurrentStateBuilder is implemented by builders computing the desired state from the current one.
+type CurrentStateBuilder interface {
+	resource.Builder
+	// SetCurrent provides the current object, or nil if it doesn't exist, before Update is called.
+	SetCurrent(current client.Object)
+}
+
+// IsFieldManagedByOthers returns true if the field at the provided path (e.g. "spec", "replicas") of the object
+// is owned by a field manager other than FieldManager, e.g. by a HorizontalPodAutoscaler scaling a deployment.
+func IsFieldManagedByOthers(object client.Object, path ...string) bool {
+	for _, entry := range object.GetManagedFields() {
+		if entry.Manager == FieldManager || entry.FieldsV1 == nil {
+			continue
+		}
+
+		fields := map[string]any{}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			continue
+		}
+
+		owned := true
+		for _, name := range path {
+			child, ok := fields["f:"+name].(map[string]any)
+			if !ok {
+				owned = false
+				break
+			}
+			fields = child
+		}
+		if owned {
+			return true
+		}
+	}
+	return false
+}
+
+// Reconciler applies the objects built by builders.
+// Only the fields set by the builders are owned by the operator: fields set by other controllers
+// (e.g. replicas set by horizontal pod autoscalers) are left untouched.
+type Reconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Recorder  record.EventRecorder
+	Discovery discovery.Manager
+}
+
+// ReconcileBuilder applies the object built by the provided builder, and returns it.
+func (r *Reconciler) ReconcileBuilder(ctx context.Context, owner client.Object, builder resource.Builder) (client.Object, error) {
+	gvk, err := apiutil.GVKForObject(builder.Build(), r.Scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.reconcile(ctx, owner, builder, gvk, nil)
+}
+
+// ReconcileBuilders applies the objects built by the enabled builders, and deletes the objects of the disabled ones.
+// It returns the applied objects. Builders of kinds not served by the API server are skipped.
+func (r *Reconciler) ReconcileBuilders(ctx context.Context, owner client.Object, builders []resource.Builder) ([]client.Object, error) {
+	objects, _, err := r.reconcileBuilders(ctx, owner, builders, nil)
+	return objects, err
+}
+
+// ReconcileAndPruneBuilders reconciles the provided builders as ReconcileBuilders does, then deletes the objects previously
+// applied for the owner in the same set of builders which are not built anymore (e.g. when their name changed).
+// Only the kinds of the provided builders are pruned.
+func (r *Reconciler) ReconcileAndPruneBuilders(ctx context.Context, owner client.Object, set string, builders []resource.Builder) ([]client.Object, error) {
+	objects, gvks, err := r.reconcileBuilders(ctx, owner, builders, map[string]string{PruneSetLabel: set})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.prune(ctx, owner, set, gvks, objects)
+	if err != nil {
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+// reconcileBuilders reconciles the provided builders, adding the provided labels to the applied objects.
+// It returns the applied objects and the kinds of the builders served by the API server.
+func (r *Reconciler) reconcileBuilders(ctx context.Context, owner client.Object, builders []resource.Builder, labels map[string]string) ([]client.Object, []schema.GroupVersionKind, error) {
+	logger := log.FromContext(ctx)
+
+	logger.Info("Reconciling resources", "count", len(builders))
+
+	objects := []client.Object{}
+	gvks := []schema.GroupVersionKind{}
+	for _, builder := range builders {
+		gvk, err := apiutil.GVKForObject(builder.Build(), r.Scheme)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		supported, err := r.Discovery.IsGVKSupported(gvk)
+		if err != nil {
+			return nil, nil, fmt.Errorf("can't determine if GVK \"%s\" is supported: %w", gvk.String(), err)
+		}
+
+		if !supported {
+			logger.V(2).Info("Skipping resource due to unsupported by apiserver", "kind", gvk.Kind)
+			continue
+		}
+
+		if !slices.Contains(gvks, gvk) {
+			gvks = append(gvks, gvk)
+		}
+
+		object, err := r.reconcile(ctx, owner, builder, gvk, labels)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if object != nil {
+			objects = append(objects, object)
+		}
+	}
+
+	return objects, gvks, nil
+}
+
+// prune deletes the objects of the provided kinds controlled by the owner and labeled with the provided set,
+// which are not part of the applied objects.
+func (r *Reconciler) prune(ctx context.Context, owner client.Object, set string, gvks []schema.GroupVersionKind, applied []client.Object) error {
+	keep := sets.New[string]()
+	for _, object := range applied {
+		keep.Insert(object.GetObjectKind().GroupVersionKind().String() + "/" + object.GetName())
+	}
+
+	for _, gvk := range gvks {
+		list, err := r.Scheme.New(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err != nil {
+			return fmt.Errorf("can't create list for %s GVK: %w", gvk, err)
+		}
+
+		objectList, ok := list.(client.ObjectList)
+		if !ok {
+			return fmt.Errorf("can't cast %s list to client.ObjectList", gvk)
+		}
+
+		err = r.Client.List(ctx, objectList, client.InNamespace(owner.GetNamespace()), client.MatchingLabels{PruneSetLabel: set})
+		if err != nil {
+			return fmt.Errorf("can't list %s objects: %w", gvk.Kind, err)
+		}
+
+		items, err := meta.ExtractList(objectList)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			object, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+
+			if keep.Has(gvk.String()+"/"+object.GetName()) || !metav1.IsControlledBy(object, owner) {
+				continue
+			}
+
+			err := r.Client.Delete(ctx, object)
+			r.logAndRecordOperationResult(ctx, owner, object, controllerutil.OperationResult("deleted"), err)
+			if err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("can't delete resource: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// reconcile applies the object of the provided builder, or deletes it if the builder is disabled.
+// Objects of disabled builders are only deleted when controlled by the owner.
+// It returns nil if the builder is disabled.
+func (r *Reconciler) reconcile(ctx context.Context, owner client.Object, builder resource.Builder, gvk schema.GroupVersionKind, labels map[string]string) (client.Object, error) {
+	current, err := resource.NewObjectFromGVK(gvk, r.Scheme)
+	if err != nil {
+		return nil, fmt.Errorf("can't create new object from %s GVK: %w", gvk, err)
+	}
+
+	found := true
+	err = r.Client.Get(ctx, client.ObjectKeyFromObject(builder.Build()), current)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		found = false
+	}
+
+	if !builder.Enabled() {
+		if found && metav1.IsControlledBy(current, owner) {
+			err := r.Client.Delete(ctx, current)
+			r.logAndRecordOperationResult(ctx, owner, current, controllerutil.OperationResult("deleted"), err)
+			if err != nil && !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("can't delete resource: %w", err)
+			}
+		}
+		return nil, nil
+	}
+
+	if found {
+		err := r.upgradeManagedFields(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if b, ok := builder.(CurrentStateBuilder); ok {
+		if found {
+			b.SetCurrent(current.DeepCopyObject().(client.Object))
+		} else {
+			b.SetCurrent(nil)
+		}
+	}
+
+	// The desired state is built from scratch: only the fields set by the builder are applied.
+	desired := builder.Build()
+	err = builder.Update(desired)
+	if err != nil {
+		return nil, err
+	}
+	if len(labels) > 0 {
+		desired.SetLabels(metadata.Merge(desired.GetLabels(), labels))
+	}
+	desired.GetObjectKind().SetGroupVersionKind(gvk)
+	desired.SetResourceVersion("")
+	desired.SetManagedFields(nil)
+
+	err = r.Client.Patch(ctx, desired, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership)
+
+	result := controllerutil.OperationResultNone
+	switch {
+	case !found:
+		result = controllerutil.OperationResultCreated
+	case desired.GetResourceVersion() != current.GetResourceVersion():
+		result = controllerutil.OperationResultUpdated
+	}
+	r.logAndRecordOperationResult(ctx, owner, desired, result, err)
+	if err != nil {
+		return nil, err
+	}
+
+	// Statuses are computed from the kind of the returned objects.
+	desired.GetObjectKind().SetGroupVersionKind(gvk)
+
+	return desired, nil
+}
+
+// upgradeManagedFields moves the fields owned by the legacy field managers to the operator's field manager.
+func (r *Reconciler) upgradeManagedFields(ctx context.Context, current client.Object) error {
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(current, legacyFieldManagers, FieldManager)
+	if err != nil {
+		return fmt.Errorf("can't compute managed fields upgrade: %w", err)
+	}
+	if patch == nil {
+		return nil
+	}
+
+	err = r.Client.Patch(ctx, current, client.RawPatch(types.JSONPatchType, patch))
+	if err != nil {
+		return fmt.Errorf("can't upgrade managed fields: %w", err)
+	}
+
+	return nil
+}
+
+// logAndRecordOperationResult logs and records an event for the provided object operation result.
+func (r *Reconciler) logAndRecordOperationResult(ctx context.Context, owner, resource client.Object, operationResult controllerutil.OperationResult, err error) {
+	logger := log.FromContext(ctx)
+
+	var (
+		action string
+		reason string
+	)
+	switch operationResult {
+	case controllerutil.OperationResultCreated:
+		action = "create"
+		reason = "RessourceCreate"
+	case controllerutil.OperationResultUpdated:
+		action = "update"
+		reason = "ResourceUpdate"
+	case controllerutil.OperationResult("deleted"):
+		action = "delete"
+		reason = "ResourceDelete"
+	default:
+		action = "apply"
+		reason = "ResourceApply"
+		// Unchanged resources are only reported on errors.
+		if err == nil {
+			return
+		}
+	}
+
+	if err == nil {
+		msg := fmt.Sprintf("%sd resource %s of type %T", action, resource.GetName(), resource)
+		logger.Info(msg)
+		r.Recorder.Event(owner, corev1.EventTypeNormal, reason+"Success", msg)
+		return
+	}
+
+	msg := fmt.Sprintf("failed to %s resource %s of Type %T", action, resource.GetName(), resource)
+	logger.Error(err, msg)
+	r.Recorder.Event(owner, corev1.EventTypeWarning, reason+"Error", msg)
+}
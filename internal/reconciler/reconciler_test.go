@@ -0,0 +1,376 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package reconciler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func TestPrune(t *testing.T) {
+	owner := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fakecluster",
+			Namespace: "default",
+			UID:       "owner-uid",
+		},
+	}
+
+	configMap := func(name string, labels map[string]string, controller string) *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    labels,
+			},
+		}
+		if controller != "" {
+			cm.OwnerReferences = []metav1.OwnerReference{
+				{
+					APIVersion: "temporal.io/v1beta1",
+					Kind:       "TemporalCluster",
+					Name:       "fakecluster",
+					UID:        "owner-uid",
+					Controller: ptr.To(controller == "owner-uid"),
+				},
+			}
+		}
+		return cm
+	}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(v1beta1.AddToScheme(scheme))
+	utilruntime.Must(corev1.AddToScheme(scheme))
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			configMap("applied", map[string]string{PruneSetLabel: "resources"}, "owner-uid"),
+			configMap("stale", map[string]string{PruneSetLabel: "resources"}, "owner-uid"),
+			configMap("other-set", map[string]string{PruneSetLabel: "other"}, "owner-uid"),
+			configMap("unlabeled", nil, "owner-uid"),
+			configMap("not-controlled", map[string]string{PruneSetLabel: "resources"}, "other"),
+		).
+		Build()
+
+	r := &Reconciler{
+		Client:   fakeClient,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+	}
+
+	applied := configMap("applied", nil, "")
+	applied.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+
+	err := r.prune(context.Background(), owner, "resources", []schema.GroupVersionKind{applied.GroupVersionKind()}, []client.Object{applied})
+	require.NoError(t, err)
+
+	result := &corev1.ConfigMapList{}
+	require.NoError(t, fakeClient.List(context.Background(), result))
+
+	names := []string{}
+	for _, item := range result.Items {
+		names = append(names, item.Name)
+	}
+
+	assert.ElementsMatch(t, []string{"applied", "other-set", "unlabeled", "not-controlled"}, names)
+}
+
+type configMapBuilder struct {
+	owner   client.Object
+	scheme  *runtime.Scheme
+	enabled bool
+}
+
+func (b *configMapBuilder) Build() client.Object {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "config",
+			Namespace: "default",
+		},
+	}
+}
+
+func (b *configMapBuilder) Enabled() bool {
+	return b.enabled
+}
+
+func (b *configMapBuilder) Update(object client.Object) error {
+	cm := object.(*corev1.ConfigMap)
+	cm.Data = map[string]string{"key": "desired"}
+	return controllerutil.SetControllerReference(b.owner, cm, b.scheme)
+}
+
+// applyCall is a server-side apply request received by the fake client.
+type applyCall struct {
+	object  *corev1.ConfigMap
+	options *client.PatchOptions
+}
+
+// applyAsUpdate emulates server-side apply, which isn't supported by the fake client, by creating or updating the object.
+// The applied objects and options are recorded in the provided calls.
+func applyAsUpdate(calls *[]applyCall) interceptor.Funcs {
+	return interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			if patch.Type() != types.ApplyPatchType {
+				return c.Patch(ctx, obj, patch, opts...)
+			}
+
+			options := &client.PatchOptions{}
+			options.ApplyOptions(opts)
+			*calls = append(*calls, applyCall{object: obj.DeepCopyObject().(*corev1.ConfigMap), options: options})
+
+			current := &corev1.ConfigMap{}
+			err := c.Get(ctx, client.ObjectKeyFromObject(obj), current)
+			if apierrors.IsNotFound(err) {
+				return c.Create(ctx, obj)
+			}
+			if err != nil {
+				return err
+			}
+
+			obj.SetResourceVersion(current.GetResourceVersion())
+			return c.Update(ctx, obj)
+		},
+	}
+}
+
+func TestReconcileBuilder(t *testing.T) {
+	owner := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fakecluster",
+			Namespace: "default",
+			UID:       "owner-uid",
+		},
+	}
+
+	existing := func(controlled bool) *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "config",
+				Namespace: "default",
+			},
+			Data: map[string]string{"key": "current"},
+		}
+		if controlled {
+			cm.OwnerReferences = []metav1.OwnerReference{
+				{
+					APIVersion: "temporal.io/v1beta1",
+					Kind:       "TemporalCluster",
+					Name:       "fakecluster",
+					UID:        "owner-uid",
+					Controller: ptr.To(true),
+				},
+			}
+		}
+		return cm
+	}
+
+	tests := map[string]struct {
+		existing        *corev1.ConfigMap
+		enabled         bool
+		expectedApplied bool
+		expectedData    map[string]string
+	}{
+		"creates the object": {
+			enabled:         true,
+			expectedApplied: true,
+			expectedData:    map[string]string{"key": "desired"},
+		},
+		"updates the object": {
+			existing:        existing(true),
+			enabled:         true,
+			expectedApplied: true,
+			expectedData:    map[string]string{"key": "desired"},
+		},
+		"deletes the controlled object of a disabled builder": {
+			existing: existing(true),
+			enabled:  false,
+		},
+		"keeps the object of a disabled builder not controlled by the owner": {
+			existing:     existing(false),
+			enabled:      false,
+			expectedData: map[string]string{"key": "current"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			ctx := context.Background()
+
+			scheme := runtime.NewScheme()
+			utilruntime.Must(v1beta1.AddToScheme(scheme))
+			utilruntime.Must(corev1.AddToScheme(scheme))
+
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if test.existing != nil {
+				builder = builder.WithObjects(test.existing)
+			}
+			calls := []applyCall{}
+			fakeClient := builder.WithInterceptorFuncs(applyAsUpdate(&calls)).Build()
+
+			r := &Reconciler{
+				Client:   fakeClient,
+				Scheme:   scheme,
+				Recorder: record.NewFakeRecorder(10),
+			}
+
+			object, err := r.ReconcileBuilder(ctx, owner, &configMapBuilder{owner: owner, scheme: scheme, enabled: test.enabled})
+			require.NoError(tt, err)
+
+			if test.expectedApplied {
+				require.Len(tt, calls, 1)
+				applied := calls[0]
+				assert.Equal(tt, FieldManager, applied.options.FieldManager)
+				assert.Equal(tt, ptr.To(true), applied.options.Force)
+				// The desired state is applied from scratch, without the current object's metadata.
+				assert.Empty(tt, applied.object.GetManagedFields())
+				assert.Equal(tt, corev1.SchemeGroupVersion.WithKind("ConfigMap"), applied.object.GroupVersionKind())
+				assert.True(tt, metav1.IsControlledBy(applied.object, owner))
+
+				require.NotNil(tt, object)
+				assert.Equal(tt, corev1.SchemeGroupVersion.WithKind("ConfigMap"), object.GetObjectKind().GroupVersionKind())
+			} else {
+				assert.Empty(tt, calls)
+				assert.Nil(tt, object)
+			}
+
+			result := &corev1.ConfigMap{}
+			err = fakeClient.Get(ctx, client.ObjectKey{Name: "config", Namespace: "default"}, result)
+			if test.expectedData == nil {
+				assert.True(tt, apierrors.IsNotFound(err), "object should be deleted")
+				return
+			}
+			require.NoError(tt, err)
+			assert.Equal(tt, test.expectedData, result.Data)
+		})
+	}
+}
+
+func TestUpgradeManagedFields(t *testing.T) {
+	now := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	managedFields := func(manager string, operation metav1.ManagedFieldsOperationType, fields string) metav1.ManagedFieldsEntry {
+		return metav1.ManagedFieldsEntry{
+			Manager:    manager,
+			Operation:  operation,
+			APIVersion: "v1",
+			Time:       &now,
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: []byte(fields)},
+		}
+	}
+
+	tests := map[string]struct {
+		managedFields         []metav1.ManagedFieldsEntry
+		expectedPatched       bool
+		expectedManagedFields map[string]metav1.ManagedFieldsOperationType
+	}{
+		"moves the fields of the legacy field manager to the operator's": {
+			managedFields: []metav1.ManagedFieldsEntry{
+				managedFields("manager", metav1.ManagedFieldsOperationUpdate, `{"f:data":{"f:key":{}}}`),
+				managedFields("kubectl", metav1.ManagedFieldsOperationUpdate, `{"f:data":{"f:other":{}}}`),
+			},
+			expectedPatched: true,
+			expectedManagedFields: map[string]metav1.ManagedFieldsOperationType{
+				FieldManager: metav1.ManagedFieldsOperationApply,
+				"kubectl":    metav1.ManagedFieldsOperationUpdate,
+			},
+		},
+		"doesn't patch objects already managed by the operator": {
+			managedFields: []metav1.ManagedFieldsEntry{
+				managedFields(FieldManager, metav1.ManagedFieldsOperationApply, `{"f:data":{"f:key":{}}}`),
+				managedFields("kubectl", metav1.ManagedFieldsOperationUpdate, `{"f:data":{"f:other":{}}}`),
+			},
+			expectedPatched: false,
+			expectedManagedFields: map[string]metav1.ManagedFieldsOperationType{
+				FieldManager: metav1.ManagedFieldsOperationApply,
+				"kubectl":    metav1.ManagedFieldsOperationUpdate,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			ctx := context.Background()
+
+			scheme := runtime.NewScheme()
+			utilruntime.Must(corev1.AddToScheme(scheme))
+
+			cm := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:          "config",
+					Namespace:     "default",
+					ManagedFields: test.managedFields,
+				},
+				Data: map[string]string{"key": "value", "other": "value"},
+			}
+
+			patched := false
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(cm).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						assert.Equal(tt, types.JSONPatchType, patch.Type())
+						patched = true
+						return c.Patch(ctx, obj, patch, opts...)
+					},
+				}).
+				Build()
+
+			r := &Reconciler{
+				Client: fakeClient,
+				Scheme: scheme,
+			}
+
+			current := &corev1.ConfigMap{}
+			require.NoError(tt, fakeClient.Get(ctx, client.ObjectKeyFromObject(cm), current))
+
+			require.NoError(tt, r.upgradeManagedFields(ctx, current))
+			assert.Equal(tt, test.expectedPatched, patched)
+
+			result := &corev1.ConfigMap{}
+			require.NoError(tt, fakeClient.Get(ctx, client.ObjectKeyFromObject(cm), result))
+
+			managers := map[string]metav1.ManagedFieldsOperationType{}
+			for _, entry := range result.GetManagedFields() {
+				managers[entry.Manager] = entry.Operation
+			}
+			assert.Equal(tt, test.expectedManagedFields, managers)
+		})
+	}
+}
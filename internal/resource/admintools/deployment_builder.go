@@ -24,7 +24,9 @@ import (
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"github.com/alexandrevilain/temporal-operator/internal/metadata"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/spiffe"
 	"github.com/alexandrevilain/temporal-operator/pkg/kubernetes"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -42,16 +44,16 @@ const (
 )
 
 type DeploymentBuilder struct {
-	instance   *v1beta1.TemporalCluster
-	scheme     *runtime.Scheme
-	configHash string
+	instance         *v1beta1.TemporalCluster
+	scheme           *runtime.Scheme
+	certificatesHash string
 }
 
-func NewDeploymentBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme, configHash string) *DeploymentBuilder {
+func NewDeploymentBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme, certificatesHash string) *DeploymentBuilder {
 	return &DeploymentBuilder{
-		instance:   instance,
-		scheme:     scheme,
-		configHash: configHash,
+		instance:         instance,
+		scheme:           scheme,
+		certificatesHash: certificatesHash,
 	}
 }
 
@@ -96,7 +98,7 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 	volumes := []corev1.Volume{}
 	volumeMounts := []corev1.VolumeMount{}
 
-	if b.instance.MTLSWithCertManagerEnabled() && b.instance.Spec.MTLS.FrontendEnabled() {
+	if b.instance.MTLSWithCertificatesEnabled() && b.instance.Spec.MTLS.FrontendEnabled() {
 		volumeMounts = append(volumeMounts,
 			corev1.VolumeMount{
 				Name:      certmanager.AdmintoolsFrontendClientCertificate,
@@ -108,7 +110,7 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 				Name: certmanager.AdmintoolsFrontendClientCertificate,
 				VolumeSource: corev1.VolumeSource{
 					Secret: &corev1.SecretVolumeSource{
-						SecretName:  b.instance.ChildResourceName(certmanager.AdmintoolsFrontendClientCertificate),
+						SecretName:  mtls.CertificateSecretName(b.instance, certmanager.AdmintoolsFrontendClientCertificate),
 						DefaultMode: ptr.To[int32](corev1.SecretVolumeSourceDefaultMode),
 					},
 				},
@@ -121,6 +123,11 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 		env = append(env, certmanager.GetTLSEnvironmentVariables(b.instance, "TEMPORAL", admintoolsCertsMountPath)...)
 	}
 
+	if b.instance.MTLSWithSPIFFEEnabled() && b.instance.Spec.MTLS.FrontendEnabled() {
+		env = append(env, certmanager.GetTLSEnvironmentVariables(b.instance, "TEMPORAL_CLI", admintoolsCertsMountPath)...)
+		env = append(env, certmanager.GetTLSEnvironmentVariables(b.instance, "TEMPORAL", admintoolsCertsMountPath)...)
+	}
+
 	deployment.Spec.Replicas = ptr.To[int32](1)
 
 	deployment.Spec.Selector = &metav1.LabelSelector{
@@ -128,14 +135,14 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 	}
 
 	deployment.Spec.Template = corev1.PodTemplateSpec{
-		ObjectMeta: meta.BuildPodObjectMeta(b.instance, "admintools", b.configHash),
+		ObjectMeta: meta.BuildPodObjectMeta(b.instance, "admintools", "", b.certificatesHash),
 		Spec: corev1.PodSpec{
 			ImagePullSecrets: b.instance.Spec.ImagePullSecrets,
-			Containers: []corev1.Container{
+			Containers: append([]corev1.Container{
 				{
 					Name:                     "admintools",
-					Image:                    fmt.Sprintf("%s:%s", b.instance.Spec.AdminTools.Image, b.instance.Spec.Version),
-					ImagePullPolicy:          corev1.PullIfNotPresent,
+					Image:                    meta.ImageReference(b.instance.Spec.AdminTools.Image, b.instance.Spec.Version.String(), b.instance.Spec.AdminTools.ImageDigest),
+					ImagePullPolicy:          meta.ImagePullPolicyOrDefault(b.instance.Spec.AdminTools.ImagePullPolicy),
 					TerminationMessagePath:   corev1.TerminationMessagePathDefault,
 					TerminationMessagePolicy: corev1.TerminationMessageReadFile,
 					Env:                      env,
@@ -152,21 +159,24 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 						SuccessThreshold:    1,
 						FailureThreshold:    3,
 					},
-					SecurityContext: &corev1.SecurityContext{
-						AllowPrivilegeEscalation: ptr.To(false),
-					},
-					VolumeMounts: volumeMounts,
+					SecurityContext: meta.ContainerSecurityContextOrDefault(b.instance.Spec.AdminTools.SecurityContext, meta.DefaultContainerSecurityContext()),
+					VolumeMounts:    volumeMounts,
 				},
-			},
+			}, b.instance.Spec.AdminTools.Sidecars...),
+			InitContainers:                b.instance.Spec.AdminTools.InitContainers,
 			RestartPolicy:                 corev1.RestartPolicyAlways,
 			TerminationGracePeriodSeconds: ptr.To[int64](30),
 			DNSPolicy:                     corev1.DNSClusterFirst,
-			SecurityContext:               &corev1.PodSecurityContext{},
+			SecurityContext:               meta.PodSecurityContextOrDefault(b.instance.Spec.AdminTools.PodSecurityContext, meta.DefaultPodSecurityContext(1000, false)),
 			SchedulerName:                 corev1.DefaultSchedulerName,
 			Volumes:                       volumes,
 		},
 	}
 
+	if b.instance.Spec.MTLS != nil && b.instance.Spec.MTLS.FrontendEnabled() {
+		spiffe.Inject(b.instance, &deployment.Spec.Template.Spec, "admintools", admintoolsCertsMountPath)
+	}
+
 	if b.instance.Spec.AdminTools.Overrides != nil && b.instance.Spec.AdminTools.Overrides.Deployment != nil {
 		err := kubernetes.ApplyDeploymentOverrides(deployment, b.instance.Spec.AdminTools.Overrides.Deployment)
 		if err != nil {
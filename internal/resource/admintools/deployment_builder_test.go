@@ -0,0 +1,140 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package admintools_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/admintools"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+)
+
+func TestDeploymentBuilderSecurityContext(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	restrictedPod := &corev1.PodSecurityContext{
+		RunAsUser:      ptr.To[int64](1000),
+		RunAsGroup:     ptr.To[int64](1000),
+		RunAsNonRoot:   ptr.To(true),
+		SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+	restrictedContainer := &corev1.SecurityContext{
+		AllowPrivilegeEscalation: ptr.To(false),
+		RunAsNonRoot:             ptr.To(true),
+		Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+
+	tests := map[string]struct {
+		podSecurityContext        *corev1.PodSecurityContext
+		securityContext           *corev1.SecurityContext
+		expectedPodSecurity       *corev1.PodSecurityContext
+		expectedContainerSecurity *corev1.SecurityContext
+	}{
+		"defaults to restricted": {
+			expectedPodSecurity:       restrictedPod,
+			expectedContainerSecurity: restrictedContainer,
+		},
+		"overrides take precedence": {
+			podSecurityContext: &corev1.PodSecurityContext{
+				RunAsUser: ptr.To[int64](2000),
+				FSGroup:   ptr.To[int64](2000),
+			},
+			securityContext: &corev1.SecurityContext{
+				ReadOnlyRootFilesystem: ptr.To(true),
+			},
+			expectedPodSecurity: &corev1.PodSecurityContext{
+				RunAsUser: ptr.To[int64](2000),
+				FSGroup:   ptr.To[int64](2000),
+			},
+			expectedContainerSecurity: &corev1.SecurityContext{
+				ReadOnlyRootFilesystem: ptr.To(true),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			cluster := &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.23.0"),
+					Services: &v1beta1.ServicesSpec{
+						Frontend: &v1beta1.ServiceSpec{Port: ptr.To(7233)},
+					},
+					AdminTools: &v1beta1.TemporalAdminToolsSpec{
+						Enabled:            true,
+						Image:              "temporalio/admin-tools",
+						PodSecurityContext: test.podSecurityContext,
+						SecurityContext:    test.securityContext,
+					},
+				},
+			}
+
+			builder := admintools.NewDeploymentBuilder(cluster, scheme, "")
+			object := builder.Build()
+			require.NoError(tt, builder.Update(object))
+
+			podSpec := object.(*appsv1.Deployment).Spec.Template.Spec
+			assert.Equal(tt, test.expectedPodSecurity, podSpec.SecurityContext)
+			require.Len(tt, podSpec.Containers, 1)
+			assert.Equal(tt, test.expectedContainerSecurity, podSpec.Containers[0].SecurityContext)
+		})
+	}
+}
+
+func TestDeploymentBuilderImage(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	cluster := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+		Spec: v1beta1.TemporalClusterSpec{
+			Version: version.MustNewVersionFromString("1.23.0"),
+			Services: &v1beta1.ServicesSpec{
+				Frontend: &v1beta1.ServiceSpec{Port: ptr.To(7233)},
+			},
+			AdminTools: &v1beta1.TemporalAdminToolsSpec{
+				Enabled:         true,
+				Image:           "temporalio/admin-tools",
+				ImageDigest:     "sha256:4a1c4b21597c1b4415bdbecb28a3296c6b5e23ca4f9feeb599860a1dac6a0108",
+				ImagePullPolicy: corev1.PullAlways,
+			},
+		},
+	}
+
+	builder := admintools.NewDeploymentBuilder(cluster, scheme, "")
+	object := builder.Build()
+	require.NoError(t, builder.Update(object))
+
+	container := object.(*appsv1.Deployment).Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "temporalio/admin-tools:1.23.0@sha256:4a1c4b21597c1b4415bdbecb28a3296c6b5e23ca4f9feeb599860a1dac6a0108", container.Image)
+	assert.Equal(t, corev1.PullAlways, container.ImagePullPolicy)
+}
@@ -0,0 +1,77 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package admintools
+
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+type NetworkPolicyBuilder struct {
+	instance *v1beta1.TemporalCluster
+	scheme   *runtime.Scheme
+}
+
+func NewNetworkPolicyBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme) *NetworkPolicyBuilder {
+	return &NetworkPolicyBuilder{
+		instance: instance,
+		scheme:   scheme,
+	}
+}
+
+func (b *NetworkPolicyBuilder) Build() client.Object {
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.instance.ChildResourceName(meta.ServiceAdminTools),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.GetLabels(b.instance, meta.ServiceAdminTools, b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *NetworkPolicyBuilder) Enabled() bool {
+	return b.instance.Spec.NetworkPolicies.IsEnabled() && b.instance.Spec.AdminTools != nil && b.instance.Spec.AdminTools.Enabled
+}
+
+func (b *NetworkPolicyBuilder) Update(object client.Object) error {
+	policy := object.(*networkingv1.NetworkPolicy)
+	policy.Labels = object.GetLabels()
+	policy.Annotations = object.GetAnnotations()
+	// Admin tools pods don't accept any traffic.
+	policy.Spec = networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{
+			MatchLabels: metadata.LabelsSelector(b.instance, meta.ServiceAdminTools),
+		},
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+	}
+
+	if err := controllerutil.SetControllerReference(b.instance, policy, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package backup
+
+import (
+	"fmt"
+	"path"
+	"slices"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	backupComponent = "backup"
+
+	// ClusterSpecFile is the name of the file holding the spec of the backed up cluster.
+	ClusterSpecFile = "cluster.json"
+)
+
+// BackupID returns the identifier of a non-scheduled backup.
+func BackupID(backup *v1beta1.TemporalBackup) string {
+	return backup.GetCreationTimestamp().UTC().Format(BackupIDFormat)
+}
+
+// backupJobTemplate returns the job template dumping the cluster's datastores and uploading them to the storage.
+// If backupID is empty, the identifier is generated from the job start time.
+func backupJobTemplate(backup *v1beta1.TemporalBackup, cluster *v1beta1.TemporalCluster, backupID string) batchv1.JobSpec {
+	env := []corev1.EnvVar{}
+	if backupID != "" {
+		env = append(env, corev1.EnvVar{Name: "BACKUP_ID", Value: backupID})
+	}
+
+	initContainers := []corev1.Container{}
+	for _, datastore := range SupportedDatastores(cluster) {
+		initContainers = append(initContainers, datastoreContainer(backup, datastore, "dump", DumpScript(datastore)))
+	}
+	if backup.Spec.Cassandra != nil && len(CassandraDatastores(cluster)) > 0 {
+		initContainers = append(initContainers, medusaContainer(backup, cluster, "medusa-backup", env, MedusaBackupScript(backup)))
+	}
+
+	uploadEnv := slices.Clone(env)
+	script := backupIDScript
+	if backup.Status.ClusterSpec != nil {
+		// The cluster spec is stored next to the dumps, so that the cluster can be recreated from any backup.
+		uploadEnv = append(uploadEnv, corev1.EnvVar{Name: "CLUSTER_SPEC", Value: string(backup.Status.ClusterSpec.Raw)})
+		script += fmt.Sprintf("\nprintf '%%s' \"${CLUSTER_SPEC}\" > %s", path.Join(backupMountPath, ClusterSpecFile))
+	}
+	script += fmt.Sprintf("\naws s3 cp --recursive %s \"%s\"", backupMountPath, Location(backup, "${BACKUP_ID}"))
+
+	containers := []corev1.Container{
+		storageContainer(backup, "upload", uploadEnv, script),
+	}
+
+	return batchv1.JobSpec{
+		TTLSecondsAfterFinished: cluster.Spec.JobTTLSecondsAfterFinished,
+		Template:                podTemplate(backup, cluster, backupComponent, initContainers, containers),
+	}
+}
+
+// JobBuilder builds the job taking a non-scheduled backup.
+type JobBuilder struct {
+	backup  *v1beta1.TemporalBackup
+	cluster *v1beta1.TemporalCluster
+	scheme  *runtime.Scheme
+}
+
+func NewJobBuilder(backup *v1beta1.TemporalBackup, cluster *v1beta1.TemporalCluster, scheme *runtime.Scheme) *JobBuilder {
+	return &JobBuilder{
+		backup:  backup,
+		cluster: cluster,
+		scheme:  scheme,
+	}
+}
+
+func (b *JobBuilder) Enabled() bool {
+	return !b.backup.Spec.IsScheduled()
+}
+
+func (b *JobBuilder) Build() client.Object {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%s-%s", b.backup.GetName(), backupComponent),
+			Namespace:   b.cluster.Namespace,
+			Labels:      metadata.GetLabels(b.cluster, backupComponent, b.cluster.Spec.Version, b.cluster.Labels),
+			Annotations: metadata.GetAnnotations(b.cluster.Name, b.cluster.Annotations),
+		},
+		Spec: backupJobTemplate(b.backup, b.cluster, BackupID(b.backup)),
+	}
+}
+
+func (b *JobBuilder) Update(object client.Object) error {
+	return setOwner(b.backup, b.cluster, object, b.scheme)
+}
+
+// CronJobBuilder builds the cronjob taking scheduled backups.
+type CronJobBuilder struct {
+	backup  *v1beta1.TemporalBackup
+	cluster *v1beta1.TemporalCluster
+	scheme  *runtime.Scheme
+}
+
+func NewCronJobBuilder(backup *v1beta1.TemporalBackup, cluster *v1beta1.TemporalCluster, scheme *runtime.Scheme) *CronJobBuilder {
+	return &CronJobBuilder{
+		backup:  backup,
+		cluster: cluster,
+		scheme:  scheme,
+	}
+}
+
+func (b *CronJobBuilder) Enabled() bool {
+	return b.backup.Spec.IsScheduled()
+}
+
+func (b *CronJobBuilder) Build() client.Object {
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", b.backup.GetName(), backupComponent),
+			Namespace: b.cluster.Namespace,
+		},
+	}
+}
+
+func (b *CronJobBuilder) Update(object client.Object) error {
+	cronJob := object.(*batchv1.CronJob)
+	cronJob.Labels = metadata.Merge(
+		object.GetLabels(),
+		metadata.GetLabels(b.cluster, backupComponent, b.cluster.Spec.Version, b.cluster.Labels),
+	)
+	cronJob.Annotations = metadata.Merge(
+		object.GetAnnotations(),
+		metadata.GetAnnotations(b.cluster.Name, b.cluster.Annotations),
+	)
+	cronJob.Spec.Schedule = b.backup.Spec.Schedule
+	cronJob.Spec.Suspend = &b.backup.Spec.Suspend
+	cronJob.Spec.ConcurrencyPolicy = batchv1.ForbidConcurrent
+	cronJob.Spec.JobTemplate = batchv1.JobTemplateSpec{
+		Spec: backupJobTemplate(b.backup, b.cluster, ""),
+	}
+
+	return setOwner(b.backup, b.cluster, object, b.scheme)
+}
@@ -0,0 +1,112 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package backup_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/backup"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+)
+
+func TestCronJobBuilder(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	cluster := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+		Spec: v1beta1.TemporalClusterSpec{
+			Version: version.MustNewVersionFromString("1.23.0"),
+			Persistence: v1beta1.TemporalPersistenceSpec{
+				DefaultStore: &v1beta1.DatastoreSpec{
+					Name:      "default",
+					Cassandra: &v1beta1.CassandraSpec{Keyspace: "temporal"},
+				},
+				VisibilityStore: &v1beta1.DatastoreSpec{
+					Name: "visibility",
+					SQL: &v1beta1.SQLSpec{
+						PluginName:   "postgres12",
+						ConnectAddr:  "postgres.demo:5432",
+						User:         "temporal",
+						DatabaseName: "temporal_visibility",
+					},
+				},
+			},
+		},
+	}
+	temporalBackup := &v1beta1.TemporalBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-daily", Namespace: "demo"},
+		Spec: v1beta1.TemporalBackupSpec{
+			ClusterRef: v1beta1.TemporalClusterReference{Name: "prod"},
+			Schedule:   "0 2 * * *",
+			Storage: v1beta1.BackupStorageSpec{
+				S3: &v1beta1.BackupS3Storage{
+					Bucket:   "temporal-backups",
+					Region:   "eu-west-1",
+					Endpoint: ptr.To("http://minio.demo:9000"),
+				},
+			},
+			Cassandra: &v1beta1.BackupCassandraSpec{
+				MedusaConfigRef: corev1.LocalObjectReference{Name: "medusa"},
+			},
+		},
+		Status: v1beta1.TemporalBackupStatus{
+			ClusterSpec: &apiextensionsv1.JSON{Raw: []byte(`{"version":"1.23.0"}`)},
+		},
+	}
+
+	builder := backup.NewCronJobBuilder(temporalBackup, cluster, scheme)
+	object := builder.Build()
+	require.NoError(t, builder.Update(object))
+
+	podSpec := object.(*batchv1.CronJob).Spec.JobTemplate.Spec.Template.Spec
+
+	initContainers := []string{}
+	for _, container := range podSpec.InitContainers {
+		initContainers = append(initContainers, container.Name)
+	}
+	assert.Equal(t, []string{"dump-visibility", "medusa-backup"}, initContainers)
+
+	upload := podSpec.Containers[0]
+	assert.Equal(t, `set -e
+if [ -z "${BACKUP_ID}" ]; then
+  [ -f /backup/backup-id ] || date -u +%Y%m%d%H%M%S > /backup/backup-id
+  BACKUP_ID="$(cat /backup/backup-id)"
+fi
+printf '%s' "${CLUSTER_SPEC}" > /backup/cluster.json
+aws s3 cp --recursive /backup "s3://temporal-backups/prod-daily/${BACKUP_ID}/"`, upload.Command[2])
+	assert.Contains(t, upload.Env, corev1.EnvVar{Name: "CLUSTER_SPEC", Value: `{"version":"1.23.0"}`})
+	assert.Contains(t, upload.Env, corev1.EnvVar{Name: "AWS_ENDPOINT_URL", Value: "http://minio.demo:9000"})
+
+	volumes := []string{}
+	for _, volume := range podSpec.Volumes {
+		volumes = append(volumes, volume.Name)
+	}
+	assert.Contains(t, volumes, "medusa-config")
+}
@@ -0,0 +1,171 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/persistence"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	clusterSpecComponent = "cluster-spec"
+
+	// ClusterSpecContainerName is the name of the container downloading the cluster spec stored with a backup.
+	// The gzipped and base64-encoded spec is written to its termination message.
+	ClusterSpecContainerName = "download-cluster-spec"
+)
+
+// ClusterSpecJobBuilder builds the job downloading the cluster spec stored with a backup, used to recreate
+// the restored cluster when it doesn't exist, and to check the backup version otherwise.
+// If the cluster doesn't exist, the job only relies on the backup settings.
+type ClusterSpecJobBuilder struct {
+	restore *v1beta1.TemporalRestore
+	backup  *v1beta1.TemporalBackup
+	// cluster is the restored cluster, nil if it doesn't exist
+	cluster   *v1beta1.TemporalCluster
+	namespace string
+	backupID  string
+	scheme    *runtime.Scheme
+}
+
+func NewClusterSpecJobBuilder(restore *v1beta1.TemporalRestore, backup *v1beta1.TemporalBackup, cluster *v1beta1.TemporalCluster, namespace, backupID string, scheme *runtime.Scheme) *ClusterSpecJobBuilder {
+	return &ClusterSpecJobBuilder{
+		restore:   restore,
+		backup:    backup,
+		cluster:   cluster,
+		namespace: namespace,
+		backupID:  backupID,
+		scheme:    scheme,
+	}
+}
+
+func (b *ClusterSpecJobBuilder) Enabled() bool {
+	return true
+}
+
+func (b *ClusterSpecJobBuilder) Build() client.Object {
+	labels := map[string]string{
+		"app.kubernetes.io/name":      b.restore.GetName(),
+		"app.kubernetes.io/component": clusterSpecComponent,
+		"app.kubernetes.io/part-of":   "temporal",
+	}
+
+	// Termination messages are limited to 4096 bytes, the spec is compressed to fit in.
+	script := fmt.Sprintf(`set -o pipefail; aws s3 cp "%s" - | gzip -c | base64 | tr -d '\n' > %s`,
+		Location(b.backup, b.backupID)+ClusterSpecFile, corev1.TerminationMessagePathDefault)
+	container := storageContainer(b.backup, ClusterSpecContainerName, nil, script)
+
+	serviceAccountName := b.backup.Spec.ServiceAccountName
+	if serviceAccountName == "" && b.cluster != nil {
+		serviceAccountName = b.cluster.Spec.JobServiceAccount.GetName(b.cluster.ChildResourceName(persistence.ServiceNameSuffix))
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", b.restore.GetName(), clusterSpecComponent),
+			Namespace: b.namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:                 corev1.RestartPolicyOnFailure,
+					ServiceAccountName:            serviceAccountName,
+					DeprecatedServiceAccount:      serviceAccountName,
+					Containers:                    []corev1.Container{container},
+					TerminationGracePeriodSeconds: ptr.To[int64](30),
+					DNSPolicy:                     corev1.DNSClusterFirst,
+					SecurityContext:               meta.DefaultPodSecurityContext(1000, false),
+					SchedulerName:                 corev1.DefaultSchedulerName,
+					Volumes: []corev1.Volume{
+						{
+							Name: backupVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (b *ClusterSpecJobBuilder) Update(object client.Object) error {
+	if b.cluster != nil {
+		return setOwner(b.restore, b.cluster, object, b.scheme)
+	}
+
+	// As cross-namespace owner references are not allowed, the job is left
+	// for garbage collection by its TTL if the cluster lives in another namespace.
+	if b.restore.GetNamespace() != object.GetNamespace() {
+		object.(*batchv1.Job).Spec.TTLSecondsAfterFinished = ptr.To[int32](3600)
+		return nil
+	}
+
+	if err := controllerutil.SetControllerReference(b.restore, object, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+	return nil
+}
+
+// DecodeClusterSpec decodes the cluster spec written by the cluster spec job in its termination message.
+func DecodeClusterSpec(message string) (*v1beta1.TemporalClusterSpec, error) {
+	compressed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(message))
+	if err != nil {
+		return nil, fmt.Errorf("can't decode cluster spec: %w", err)
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("can't decompress cluster spec: %w", err)
+	}
+	defer reader.Close()
+
+	raw, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("can't decompress cluster spec: %w", err)
+	}
+
+	spec := &v1beta1.TemporalClusterSpec{}
+	err = json.Unmarshal(raw, spec)
+	if err != nil {
+		return nil, fmt.Errorf("can't unmarshal cluster spec stored with the backup: %w", err)
+	}
+
+	return spec, nil
+}
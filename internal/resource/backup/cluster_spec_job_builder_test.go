@@ -0,0 +1,117 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package backup_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/backup"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestClusterSpecJobBuilder(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	temporalBackup := &v1beta1.TemporalBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-daily", Namespace: "demo"},
+		Spec: v1beta1.TemporalBackupSpec{
+			Storage: v1beta1.BackupStorageSpec{
+				S3: &v1beta1.BackupS3Storage{Bucket: "temporal-backups", Prefix: "prod", Region: "eu-west-1"},
+			},
+		},
+	}
+	restore := &v1beta1.TemporalRestore{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-restore", Namespace: "demo"},
+	}
+
+	tests := map[string]struct {
+		cluster                    *v1beta1.TemporalCluster
+		serviceAccountName         string
+		namespace                  string
+		expectedOwned              bool
+		expectedServiceAccountName string
+	}{
+		"lost cluster in the restore namespace": {
+			serviceAccountName:         "backup",
+			namespace:                  "demo",
+			expectedOwned:              true,
+			expectedServiceAccountName: "backup",
+		},
+		"lost cluster in another namespace": {
+			serviceAccountName:         "backup",
+			namespace:                  "temporal",
+			expectedServiceAccountName: "backup",
+		},
+		"existing cluster": {
+			cluster: &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "temporal", UID: "cluster-uid"},
+			},
+			namespace:                  "temporal",
+			expectedOwned:              true,
+			expectedServiceAccountName: "prod-schema-setup",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			temporalBackup.Spec.ServiceAccountName = test.serviceAccountName
+			builder := backup.NewClusterSpecJobBuilder(restore, temporalBackup, test.cluster, test.namespace, "20240101020000", scheme)
+			object := builder.Build()
+			require.NoError(tt, builder.Update(object))
+
+			job := object.(*batchv1.Job)
+			assert.Equal(tt, "prod-restore-cluster-spec", job.Name)
+			assert.Equal(tt, test.namespace, job.Namespace)
+			assert.Equal(tt, test.expectedServiceAccountName, job.Spec.Template.Spec.ServiceAccountName)
+			assert.Equal(tt, test.expectedOwned, len(job.OwnerReferences) == 1)
+			assert.Equal(tt, test.expectedOwned, job.Spec.TTLSecondsAfterFinished == nil)
+
+			container := job.Spec.Template.Spec.Containers[0]
+			assert.Equal(tt, backup.ClusterSpecContainerName, container.Name)
+			assert.Equal(tt, `set -e
+set -o pipefail; aws s3 cp "s3://temporal-backups/prod/prod-daily/20240101020000/cluster.json" - | gzip -c | base64 | tr -d '\n' > /dev/termination-log`, container.Command[2])
+		})
+	}
+}
+
+func TestDecodeClusterSpec(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := gzip.NewWriter(buf)
+	_, err := w.Write([]byte(`{"version":"1.23.0","numHistoryShards":512}`))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	spec, err := backup.DecodeClusterSpec(base64.StdEncoding.EncodeToString(buf.Bytes()) + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, "1.23.0", spec.Version.String())
+	assert.Equal(t, int32(512), spec.NumHistoryShards)
+
+	_, err = backup.DecodeClusterSpec("not base64")
+	assert.Error(t, err)
+}
@@ -0,0 +1,98 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package backup
+
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const restoreComponent = "restore"
+
+// RestoreJobBuilder builds the job restoring a backup into the cluster's datastores.
+type RestoreJobBuilder struct {
+	restore  *v1beta1.TemporalRestore
+	backup   *v1beta1.TemporalBackup
+	cluster  *v1beta1.TemporalCluster
+	backupID string
+	scheme   *runtime.Scheme
+}
+
+func NewRestoreJobBuilder(restore *v1beta1.TemporalRestore, backup *v1beta1.TemporalBackup, cluster *v1beta1.TemporalCluster, backupID string, scheme *runtime.Scheme) *RestoreJobBuilder {
+	return &RestoreJobBuilder{
+		restore:  restore,
+		backup:   backup,
+		cluster:  cluster,
+		backupID: backupID,
+		scheme:   scheme,
+	}
+}
+
+func (b *RestoreJobBuilder) Enabled() bool {
+	return true
+}
+
+func (b *RestoreJobBuilder) Build() client.Object {
+	initContainers := []corev1.Container{
+		storageContainer(b.backup, "download", nil, fmt.Sprintf(`aws s3 cp --recursive "%s" %s`, Location(b.backup, b.backupID), backupMountPath)),
+	}
+
+	// Datastores are independent from each others, restore them in parallel.
+	containers := []corev1.Container{}
+	for _, datastore := range SupportedDatastores(b.cluster) {
+		containers = append(containers, datastoreContainer(b.backup, datastore, restoreComponent, RestoreScript(datastore)))
+	}
+	if b.backup.Spec.Cassandra != nil && len(CassandraDatastores(b.cluster)) > 0 {
+		env := []corev1.EnvVar{{Name: "BACKUP_ID", Value: b.backupID}}
+		containers = append(containers, medusaContainer(b.backup, b.cluster, "medusa-restore", env, MedusaRestoreScript(b.backup, b.cluster)))
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        RestoreJobName(b.restore),
+			Namespace:   b.cluster.Namespace,
+			Labels:      metadata.GetLabels(b.cluster, restoreComponent, b.cluster.Spec.Version, b.cluster.Labels),
+			Annotations: metadata.GetAnnotations(b.cluster.Name, b.cluster.Annotations),
+		},
+		Spec: batchv1.JobSpec{
+			TTLSecondsAfterFinished: b.cluster.Spec.JobTTLSecondsAfterFinished,
+			Template:                podTemplate(b.backup, b.cluster, restoreComponent, initContainers, containers),
+		},
+	}
+}
+
+// RestoreJobName returns the name of the restore job for the current attempt.
+// Each retry uses a new job, keeping failed jobs around for troubleshooting.
+func RestoreJobName(restore *v1beta1.TemporalRestore) string {
+	name := fmt.Sprintf("%s-%s", restore.GetName(), restoreComponent)
+	if restore.Status.Retries > 0 {
+		name = fmt.Sprintf("%s-%d", name, restore.Status.Retries)
+	}
+	return name
+}
+
+func (b *RestoreJobBuilder) Update(object client.Object) error {
+	return setOwner(b.restore, b.cluster, object, b.scheme)
+}
@@ -0,0 +1,440 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"path"
+	"strings"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/istio"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/linkerd"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/persistence"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// DefaultPostgreSQLImage is the default image used to dump and restore PostgreSQL datastores.
+	DefaultPostgreSQLImage = "postgres:16-alpine"
+	// DefaultMySQLImage is the default image used to dump and restore MySQL datastores.
+	DefaultMySQLImage = "mysql:8.0"
+	// DefaultStorageImage is the default image used to transfer backups from and to the object storage.
+	DefaultStorageImage = "amazon/aws-cli:2.15.30"
+	// DefaultMedusaImage is the default image used to back up and restore Cassandra datastores.
+	DefaultMedusaImage = "k8ssandra/medusa:0.21.0"
+
+	// BackupIDFormat is the time layout used to generate backup identifiers.
+	BackupIDFormat = "20060102150405"
+
+	backupVolumeName = "backup"
+	backupMountPath  = "/backup"
+
+	medusaConfigVolumeName = "medusa-config"
+	medusaConfigMountPath  = "/etc/medusa"
+
+	// backupIDScript sets BACKUP_ID for scheduled backups, where it is generated by the first container
+	// needing it and shared with the following ones through the backup volume.
+	backupIDScript = `if [ -z "${BACKUP_ID}" ]; then
+  [ -f /backup/backup-id ] || date -u +%Y%m%d%H%M%S > /backup/backup-id
+  BACKUP_ID="$(cat /backup/backup-id)"
+fi`
+)
+
+// SupportedDatastores returns the cluster's datastores which can be backed up.
+// Cassandra and Elasticsearch datastores are not supported.
+func SupportedDatastores(cluster *v1beta1.TemporalCluster) []*v1beta1.DatastoreSpec {
+	result := []*v1beta1.DatastoreSpec{}
+	for _, datastore := range cluster.Spec.Persistence.GetDatastores() {
+		switch datastore.GetType() {
+		case v1beta1.PostgresSQLDatastore, v1beta1.PostgresSQL12Datastore, v1beta1.MySQLDatastore, v1beta1.MySQL8Datastore:
+			result = append(result, datastore)
+		}
+	}
+	return result
+}
+
+// CassandraDatastores returns the Cassandra datastores of the cluster, backed up using medusa.
+func CassandraDatastores(cluster *v1beta1.TemporalCluster) []*v1beta1.DatastoreSpec {
+	result := []*v1beta1.DatastoreSpec{}
+	for _, datastore := range cluster.Spec.Persistence.GetDatastores() {
+		if datastore.GetType() == v1beta1.CassandraDatastore {
+			result = append(result, datastore)
+		}
+	}
+	return result
+}
+
+// ValidateDatastores returns an error if the cluster's datastores can't be backed up and restored.
+// Elasticsearch datastores are ignored, while Cassandra datastores are rejected unless the backup
+// configures medusa, as a backup without them would be incomplete.
+func ValidateDatastores(backup *v1beta1.TemporalBackup, cluster *v1beta1.TemporalCluster) error {
+	cassandraDatastores := CassandraDatastores(cluster)
+	if len(cassandraDatastores) > 0 && backup.Spec.Cassandra == nil {
+		return fmt.Errorf("datastore %q uses cassandra, set spec.cassandra to back it up using medusa", cassandraDatastores[0].Name)
+	}
+
+	if len(SupportedDatastores(cluster)) == 0 && len(cassandraDatastores) == 0 {
+		return errors.New("cluster has no datastore supporting backups, only PostgreSQL, MySQL and Cassandra datastores are supported")
+	}
+
+	return nil
+}
+
+// Location returns the object storage URL of the provided backup.
+func Location(backup *v1beta1.TemporalBackup, backupID string) string {
+	s3 := backup.Spec.Storage.S3
+	return fmt.Sprintf("s3://%s/%s/", s3.Bucket, path.Join(s3.Prefix, backup.GetName(), backupID))
+}
+
+func isPostgreSQL(datastore *v1beta1.DatastoreSpec) bool {
+	t := datastore.GetType()
+	return t == v1beta1.PostgresSQLDatastore || t == v1beta1.PostgresSQL12Datastore
+}
+
+func dumpFile(datastore *v1beta1.DatastoreSpec) string {
+	return path.Join(backupMountPath, fmt.Sprintf("%s.sql.gz", datastore.LowerCaseName()))
+}
+
+// shellQuote quotes the provided value so it can be safely used as a shell word.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
+
+func hostAndPort(datastore *v1beta1.DatastoreSpec) (string, string) {
+	host, port, err := net.SplitHostPort(datastore.SQL.ConnectAddr)
+	if err != nil {
+		host = datastore.SQL.ConnectAddr
+		port = "3306"
+		if isPostgreSQL(datastore) {
+			port = "5432"
+		}
+	}
+	return host, port
+}
+
+// passwordEnvVars returns the environment variable holding the datastore password under the provided name.
+func passwordEnvVars(datastore *v1beta1.DatastoreSpec, name string) []corev1.EnvVar {
+	vars := persistence.GetDatastoresEnvironmentVariables([]*v1beta1.DatastoreSpec{datastore})
+	for i := range vars {
+		vars[i].Name = name
+	}
+	return vars
+}
+
+// postgreSQLEnvVars returns the libpq environment variables used to connect to the datastore.
+func postgreSQLEnvVars(datastore *v1beta1.DatastoreSpec) []corev1.EnvVar {
+	host, port := hostAndPort(datastore)
+	vars := []corev1.EnvVar{
+		{Name: "PGHOST", Value: host},
+		{Name: "PGPORT", Value: port},
+		{Name: "PGUSER", Value: datastore.SQL.User},
+		{Name: "PGDATABASE", Value: datastore.SQL.DatabaseName},
+	}
+	vars = append(vars, passwordEnvVars(datastore, "PGPASSWORD")...)
+
+	if datastore.TLS != nil && datastore.TLS.Enabled {
+		sslMode := "require"
+		if datastore.TLS.EnableHostVerification {
+			sslMode = "verify-full"
+		}
+		vars = append(vars, corev1.EnvVar{Name: "PGSSLMODE", Value: sslMode})
+		if datastore.TLS.HasCaFile() {
+			vars = append(vars, corev1.EnvVar{Name: "PGSSLROOTCERT", Value: datastore.GetTLSCaFileMountPath()})
+		}
+		if datastore.TLS.CertFileRef != nil {
+			vars = append(vars, corev1.EnvVar{Name: "PGSSLCERT", Value: datastore.GetTLSCertFileMountPath()})
+		}
+		if datastore.TLS.KeyFileRef != nil {
+			vars = append(vars, corev1.EnvVar{Name: "PGSSLKEY", Value: datastore.GetTLSKeyFileMountPath()})
+		}
+	}
+
+	return vars
+}
+
+// mySQLArgs returns the mysql client arguments used to connect to the datastore.
+func mySQLArgs(datastore *v1beta1.DatastoreSpec) string {
+	host, port := hostAndPort(datastore)
+	args := []string{
+		"--host=" + shellQuote(host),
+		"--port=" + shellQuote(port),
+		"--user=" + shellQuote(datastore.SQL.User),
+	}
+
+	if datastore.TLS != nil && datastore.TLS.Enabled {
+		sslMode := "REQUIRED"
+		if datastore.TLS.EnableHostVerification {
+			sslMode = "VERIFY_IDENTITY"
+		}
+		args = append(args, "--ssl-mode="+sslMode)
+		if datastore.TLS.HasCaFile() {
+			args = append(args, "--ssl-ca="+datastore.GetTLSCaFileMountPath())
+		}
+		if datastore.TLS.CertFileRef != nil {
+			args = append(args, "--ssl-cert="+datastore.GetTLSCertFileMountPath())
+		}
+		if datastore.TLS.KeyFileRef != nil {
+			args = append(args, "--ssl-key="+datastore.GetTLSKeyFileMountPath())
+		}
+	}
+
+	return strings.Join(args, " ")
+}
+
+// DumpScript returns the script dumping the provided datastore in the backup volume.
+func DumpScript(datastore *v1beta1.DatastoreSpec) string {
+	if isPostgreSQL(datastore) {
+		return fmt.Sprintf("set -o pipefail; pg_dump --clean --if-exists --no-owner | gzip > %s", dumpFile(datastore))
+	}
+	return fmt.Sprintf("set -o pipefail; mysqldump %s --single-transaction --routines %s | gzip > %s",
+		mySQLArgs(datastore), shellQuote(datastore.SQL.DatabaseName), dumpFile(datastore))
+}
+
+// RestoreScript returns the script restoring the provided datastore from the backup volume.
+func RestoreScript(datastore *v1beta1.DatastoreSpec) string {
+	if isPostgreSQL(datastore) {
+		return fmt.Sprintf("set -o pipefail; gunzip -c %s | psql -v ON_ERROR_STOP=1 --quiet", dumpFile(datastore))
+	}
+	return fmt.Sprintf("set -o pipefail; gunzip -c %s | mysql %s %s",
+		dumpFile(datastore), mySQLArgs(datastore), shellQuote(datastore.SQL.DatabaseName))
+}
+
+// MedusaBackupScript returns the script backing up the Cassandra datastores of the cluster.
+func MedusaBackupScript(backup *v1beta1.TemporalBackup) string {
+	hook := backup.Spec.Cassandra.BackupHook
+	if hook == "" {
+		hook = `medusa backup-cluster --backup-name "${BACKUP_ID}"`
+	}
+	return fmt.Sprintf("set -e\n%s\n%s", backupIDScript, hook)
+}
+
+// MedusaRestoreScript returns the script restoring the Cassandra datastores of the cluster.
+func MedusaRestoreScript(backup *v1beta1.TemporalBackup, cluster *v1beta1.TemporalCluster) string {
+	hook := backup.Spec.Cassandra.RestoreHook
+	if hook == "" {
+		args := []string{`medusa restore-cluster --backup-name "${BACKUP_ID}"`}
+		for _, datastore := range CassandraDatastores(cluster) {
+			args = append(args, "--keyspace "+shellQuote(datastore.Cassandra.Keyspace))
+		}
+		hook = strings.Join(args, " ")
+	}
+	return fmt.Sprintf("set -e\n%s", hook)
+}
+
+func cassandraKeyspaces(cluster *v1beta1.TemporalCluster) string {
+	keyspaces := []string{}
+	for _, datastore := range CassandraDatastores(cluster) {
+		keyspaces = append(keyspaces, datastore.Cassandra.Keyspace)
+	}
+	return strings.Join(keyspaces, " ")
+}
+
+func images(backup *v1beta1.TemporalBackup) v1beta1.BackupImagesSpec {
+	result := v1beta1.BackupImagesSpec{
+		PostgreSQL: DefaultPostgreSQLImage,
+		MySQL:      DefaultMySQLImage,
+		Storage:    DefaultStorageImage,
+		Medusa:     DefaultMedusaImage,
+	}
+	if backup.Spec.Images != nil {
+		if backup.Spec.Images.PostgreSQL != "" {
+			result.PostgreSQL = backup.Spec.Images.PostgreSQL
+		}
+		if backup.Spec.Images.MySQL != "" {
+			result.MySQL = backup.Spec.Images.MySQL
+		}
+		if backup.Spec.Images.Storage != "" {
+			result.Storage = backup.Spec.Images.Storage
+		}
+		if backup.Spec.Images.Medusa != "" {
+			result.Medusa = backup.Spec.Images.Medusa
+		}
+	}
+	return result
+}
+
+// datastoreContainer returns a container running the provided script against the datastore.
+func datastoreContainer(backup *v1beta1.TemporalBackup, datastore *v1beta1.DatastoreSpec, name, script string) corev1.Container {
+	images := images(backup)
+	image := images.MySQL
+	env := passwordEnvVars(datastore, "MYSQL_PWD")
+	if isPostgreSQL(datastore) {
+		image = images.PostgreSQL
+		env = postgreSQLEnvVars(datastore)
+	}
+
+	volumeMounts := []corev1.VolumeMount{{Name: backupVolumeName, MountPath: backupMountPath}}
+	volumeMounts = append(volumeMounts, persistence.GetDatastoresVolumeMounts([]*v1beta1.DatastoreSpec{datastore})...)
+
+	return corev1.Container{
+		Name:                     fmt.Sprintf("%s-%s", name, datastore.LowerCaseName()),
+		Image:                    image,
+		ImagePullPolicy:          corev1.PullIfNotPresent,
+		Resources:                backup.Spec.Resources,
+		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		Command:                  []string{"/bin/sh", "-c", script},
+		Env:                      env,
+		SecurityContext:          meta.DefaultContainerSecurityContext(),
+		VolumeMounts:             volumeMounts,
+	}
+}
+
+// medusaContainer returns the container running the provided medusa script for all Cassandra datastores.
+func medusaContainer(backup *v1beta1.TemporalBackup, cluster *v1beta1.TemporalCluster, name string, env []corev1.EnvVar, script string) corev1.Container {
+	env = append(env,
+		corev1.EnvVar{Name: "HOME", Value: "/tmp"},
+		corev1.EnvVar{Name: "CASSANDRA_KEYSPACES", Value: cassandraKeyspaces(cluster)},
+	)
+
+	return corev1.Container{
+		Name:                     name,
+		Image:                    images(backup).Medusa,
+		ImagePullPolicy:          corev1.PullIfNotPresent,
+		Resources:                backup.Spec.Resources,
+		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		Command:                  []string{"/bin/sh", "-c", script},
+		Env:                      env,
+		SecurityContext:          meta.DefaultContainerSecurityContext(),
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: backupVolumeName, MountPath: backupMountPath},
+			{Name: medusaConfigVolumeName, MountPath: medusaConfigMountPath, ReadOnly: true},
+		},
+	}
+}
+
+// storageContainer returns a container running the provided aws cli script against the backup storage.
+func storageContainer(backup *v1beta1.TemporalBackup, name string, env []corev1.EnvVar, script string) corev1.Container {
+	s3 := backup.Spec.Storage.S3
+
+	env = append(env,
+		corev1.EnvVar{Name: "HOME", Value: "/tmp"},
+		corev1.EnvVar{Name: "AWS_DEFAULT_REGION", Value: s3.Region},
+	)
+	if s3.Endpoint != nil && *s3.Endpoint != "" {
+		env = append(env, corev1.EnvVar{Name: "AWS_ENDPOINT_URL", Value: *s3.Endpoint})
+	}
+	if s3.Credentials != nil {
+		env = append(env,
+			corev1.EnvVar{Name: "AWS_ACCESS_KEY_ID", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: s3.Credentials.AccessKeyIDRef}},
+			corev1.EnvVar{Name: "AWS_SECRET_ACCESS_KEY", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: s3.Credentials.SecretAccessKeyRef}},
+		)
+	}
+
+	commands := []string{"set -e"}
+	if s3.S3ForcePathStyle {
+		commands = append(commands, "aws configure set default.s3.addressing_style path")
+	}
+	commands = append(commands, script)
+
+	return corev1.Container{
+		Name:                     name,
+		Image:                    images(backup).Storage,
+		ImagePullPolicy:          corev1.PullIfNotPresent,
+		Resources:                backup.Spec.Resources,
+		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		Command:                  []string{"/bin/sh", "-c", strings.Join(commands, "\n")},
+		Env:                      env,
+		SecurityContext:          meta.DefaultContainerSecurityContext(),
+		VolumeMounts:             []corev1.VolumeMount{{Name: backupVolumeName, MountPath: backupMountPath}},
+	}
+}
+
+// podTemplate returns the pod template running the provided containers against the cluster's datastores.
+func podTemplate(backup *v1beta1.TemporalBackup, cluster *v1beta1.TemporalCluster, component string, initContainers, containers []corev1.Container) corev1.PodTemplateSpec {
+	volumes := []corev1.Volume{
+		{
+			Name: backupVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+	volumes = append(volumes, persistence.GetDatastoresVolumes(SupportedDatastores(cluster))...)
+	if backup.Spec.Cassandra != nil && len(CassandraDatastores(cluster)) > 0 {
+		volumes = append(volumes, corev1.Volume{
+			Name: medusaConfigVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: backup.Spec.Cassandra.MedusaConfigRef.Name,
+				},
+			},
+		})
+	}
+
+	serviceAccountName := backup.Spec.ServiceAccountName
+	if serviceAccountName == "" {
+		serviceAccountName = cluster.Spec.JobServiceAccount.GetName(cluster.ChildResourceName(persistence.ServiceNameSuffix))
+	}
+
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: metadata.Merge(
+				istio.GetLabels(cluster),
+				metadata.GetLabels(cluster, component, cluster.Spec.Version, cluster.Labels),
+			),
+			Annotations: metadata.Merge(
+				linkerd.GetAnnotations(cluster),
+				istio.GetAnnotations(cluster),
+				metadata.GetAnnotations(cluster.Name, cluster.Annotations),
+			),
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:                 corev1.RestartPolicyOnFailure,
+			ImagePullSecrets:              cluster.Spec.ImagePullSecrets,
+			ServiceAccountName:            serviceAccountName,
+			DeprecatedServiceAccount:      serviceAccountName,
+			InitContainers:                initContainers,
+			Containers:                    containers,
+			TerminationGracePeriodSeconds: ptr.To[int64](30),
+			DNSPolicy:                     corev1.DNSClusterFirst,
+			SecurityContext:               meta.DefaultPodSecurityContext(1000, false),
+			SchedulerName:                 corev1.DefaultSchedulerName,
+			Volumes:                       volumes,
+		},
+	}
+}
+
+// setOwner sets the owner of the provided object.
+// As cross-namespace owner references are not allowed, the cluster owns the object
+// if it lives in another namespace than the provided owner.
+func setOwner(owner client.Object, cluster *v1beta1.TemporalCluster, object client.Object, scheme *runtime.Scheme) error {
+	if owner.GetNamespace() == object.GetNamespace() {
+		if err := controllerutil.SetControllerReference(owner, object, scheme); err != nil {
+			return fmt.Errorf("failed setting controller reference: %w", err)
+		}
+		return nil
+	}
+
+	if err := controllerutil.SetOwnerReference(cluster, object, scheme); err != nil {
+		return fmt.Errorf("failed setting owner reference: %w", err)
+	}
+	return nil
+}
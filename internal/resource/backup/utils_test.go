@@ -0,0 +1,236 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package backup_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/backup"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestScripts(t *testing.T) {
+	tests := map[string]struct {
+		datastore       *v1beta1.DatastoreSpec
+		expectedDump    string
+		expectedRestore string
+	}{
+		"postgresql": {
+			datastore: &v1beta1.DatastoreSpec{
+				Name: "default",
+				SQL: &v1beta1.SQLSpec{
+					PluginName:   "postgres12",
+					ConnectAddr:  "postgres.demo:5432",
+					User:         "temporal",
+					DatabaseName: "temporal",
+				},
+			},
+			expectedDump:    "set -o pipefail; pg_dump --clean --if-exists --no-owner | gzip > /backup/default.sql.gz",
+			expectedRestore: "set -o pipefail; gunzip -c /backup/default.sql.gz | psql -v ON_ERROR_STOP=1 --quiet",
+		},
+		"mysql": {
+			datastore: &v1beta1.DatastoreSpec{
+				Name: "visibility",
+				SQL: &v1beta1.SQLSpec{
+					PluginName:   "mysql8",
+					ConnectAddr:  "mysql.demo",
+					User:         "temporal",
+					DatabaseName: "temporal_visibility",
+				},
+			},
+			expectedDump:    "set -o pipefail; mysqldump --host='mysql.demo' --port='3306' --user='temporal' --single-transaction --routines 'temporal_visibility' | gzip > /backup/visibility.sql.gz",
+			expectedRestore: "set -o pipefail; gunzip -c /backup/visibility.sql.gz | mysql --host='mysql.demo' --port='3306' --user='temporal' 'temporal_visibility'",
+		},
+		"mysql with tls": {
+			datastore: &v1beta1.DatastoreSpec{
+				Name: "default",
+				SQL: &v1beta1.SQLSpec{
+					PluginName:   "mysql8",
+					ConnectAddr:  "mysql.demo:3306",
+					User:         "temporal",
+					DatabaseName: "temporal",
+				},
+				TLS: &v1beta1.DatastoreTLSSpec{
+					Enabled:                true,
+					EnableHostVerification: true,
+					CaFileRef: &v1beta1.SecretKeyReference{
+						Name: "ca",
+					},
+				},
+			},
+			expectedDump:    "set -o pipefail; mysqldump --host='mysql.demo' --port='3306' --user='temporal' --ssl-mode=VERIFY_IDENTITY --ssl-ca=/etc/tls/datastores/ca/default/ca.pem --single-transaction --routines 'temporal' | gzip > /backup/default.sql.gz",
+			expectedRestore: "set -o pipefail; gunzip -c /backup/default.sql.gz | mysql --host='mysql.demo' --port='3306' --user='temporal' --ssl-mode=VERIFY_IDENTITY --ssl-ca=/etc/tls/datastores/ca/default/ca.pem 'temporal'",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			assert.Equal(tt, test.expectedDump, backup.DumpScript(test.datastore))
+			assert.Equal(tt, test.expectedRestore, backup.RestoreScript(test.datastore))
+		})
+	}
+}
+
+func TestValidateDatastores(t *testing.T) {
+	postgres := &v1beta1.DatastoreSpec{
+		Name: "default",
+		SQL: &v1beta1.SQLSpec{
+			PluginName: "postgres12",
+		},
+	}
+	cassandra := &v1beta1.DatastoreSpec{
+		Name:      "default",
+		Cassandra: &v1beta1.CassandraSpec{Keyspace: "temporal"},
+	}
+	elasticsearch := &v1beta1.DatastoreSpec{
+		Name:          "visibility",
+		Elasticsearch: &v1beta1.ElasticsearchSpec{},
+	}
+
+	medusa := &v1beta1.BackupCassandraSpec{
+		MedusaConfigRef: corev1.LocalObjectReference{Name: "medusa"},
+	}
+
+	tests := map[string]struct {
+		persistence v1beta1.TemporalPersistenceSpec
+		cassandra   *v1beta1.BackupCassandraSpec
+		expectedErr string
+	}{
+		"postgresql": {
+			persistence: v1beta1.TemporalPersistenceSpec{
+				DefaultStore:    postgres,
+				VisibilityStore: postgres,
+			},
+		},
+		"elasticsearch visibility is ignored": {
+			persistence: v1beta1.TemporalPersistenceSpec{
+				DefaultStore:    postgres,
+				VisibilityStore: elasticsearch,
+			},
+		},
+		"cassandra default store": {
+			persistence: v1beta1.TemporalPersistenceSpec{
+				DefaultStore:    cassandra,
+				VisibilityStore: postgres,
+			},
+			expectedErr: "datastore \"default\" uses cassandra, set spec.cassandra to back it up using medusa",
+		},
+		"cassandra default store with medusa": {
+			persistence: v1beta1.TemporalPersistenceSpec{
+				DefaultStore:    cassandra,
+				VisibilityStore: elasticsearch,
+			},
+			cassandra: medusa,
+		},
+		"no supported datastore": {
+			persistence: v1beta1.TemporalPersistenceSpec{
+				DefaultStore:    elasticsearch,
+				VisibilityStore: elasticsearch,
+			},
+			expectedErr: "cluster has no datastore supporting backups, only PostgreSQL, MySQL and Cassandra datastores are supported",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			cluster := &v1beta1.TemporalCluster{
+				Spec: v1beta1.TemporalClusterSpec{
+					Persistence: test.persistence,
+				},
+			}
+
+			temporalBackup := &v1beta1.TemporalBackup{
+				Spec: v1beta1.TemporalBackupSpec{
+					Cassandra: test.cassandra,
+				},
+			}
+
+			err := backup.ValidateDatastores(temporalBackup, cluster)
+			if test.expectedErr == "" {
+				assert.NoError(tt, err)
+			} else {
+				assert.EqualError(tt, err, test.expectedErr)
+			}
+		})
+	}
+}
+
+func TestRestoreJobName(t *testing.T) {
+	restore := &v1beta1.TemporalRestore{}
+	restore.Name = "prod-restore"
+
+	assert.Equal(t, "prod-restore-restore", backup.RestoreJobName(restore))
+
+	restore.Status.Retries = 2
+	assert.Equal(t, "prod-restore-restore-2", backup.RestoreJobName(restore))
+}
+
+func TestMedusaScripts(t *testing.T) {
+	cluster := &v1beta1.TemporalCluster{
+		Spec: v1beta1.TemporalClusterSpec{
+			Persistence: v1beta1.TemporalPersistenceSpec{
+				DefaultStore: &v1beta1.DatastoreSpec{
+					Name:      "default",
+					Cassandra: &v1beta1.CassandraSpec{Keyspace: "temporal"},
+				},
+				VisibilityStore: &v1beta1.DatastoreSpec{
+					Name:          "visibility",
+					Elasticsearch: &v1beta1.ElasticsearchSpec{},
+				},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		cassandra       *v1beta1.BackupCassandraSpec
+		expectedBackup  string
+		expectedRestore string
+	}{
+		"default hooks": {
+			cassandra:       &v1beta1.BackupCassandraSpec{},
+			expectedBackup:  "medusa backup-cluster --backup-name \"${BACKUP_ID}\"",
+			expectedRestore: "set -e\nmedusa restore-cluster --backup-name \"${BACKUP_ID}\" --keyspace 'temporal'",
+		},
+		"custom hooks": {
+			cassandra: &v1beta1.BackupCassandraSpec{
+				BackupHook:  "medusa backup-cluster --backup-name \"${BACKUP_ID}\" --mode full",
+				RestoreHook: "medusa restore-cluster --backup-name \"${BACKUP_ID}\" --keyspace ${CASSANDRA_KEYSPACES}",
+			},
+			expectedBackup:  "medusa backup-cluster --backup-name \"${BACKUP_ID}\" --mode full",
+			expectedRestore: "set -e\nmedusa restore-cluster --backup-name \"${BACKUP_ID}\" --keyspace ${CASSANDRA_KEYSPACES}",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			temporalBackup := &v1beta1.TemporalBackup{
+				Spec: v1beta1.TemporalBackupSpec{
+					Cassandra: test.cassandra,
+				},
+			}
+
+			script := backup.MedusaBackupScript(temporalBackup)
+			assert.True(tt, strings.HasPrefix(script, "set -e\nif [ -z \"${BACKUP_ID}\" ]; then"), script)
+			assert.True(tt, strings.HasSuffix(script, "\n"+test.expectedBackup), script)
+			assert.Equal(tt, test.expectedRestore, backup.MedusaRestoreScript(temporalBackup, cluster))
+		})
+	}
+}
@@ -27,5 +27,10 @@ func isBuilderEnabled(c *v1beta1.TemporalCluster, serviceName string) bool {
 		return false
 	}
 
+	// In dev mode, all services are running in the frontend pods.
+	if c.Spec.DevMode.IsEnabled() && serviceName != string(primitives.FrontendService) {
+		return false
+	}
+
 	return true
 }
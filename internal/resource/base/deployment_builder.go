@@ -20,15 +20,19 @@ package base
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/alexandrevilain/controller-tools/pkg/resource"
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"github.com/alexandrevilain/temporal-operator/internal/metadata"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/spiffe"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/persistence"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/prometheus"
 	"github.com/alexandrevilain/temporal-operator/pkg/kubernetes"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal/log"
 	"go.temporal.io/server/common/primitives"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -48,15 +52,18 @@ type DeploymentBuilder struct {
 	scheme      *runtime.Scheme
 	service     *v1beta1.ServiceSpec
 	configHash  string
+	// certificatesHash is the hash of the mTLS certificates the pods should use.
+	certificatesHash string
 }
 
-func NewDeploymentBuilder(serviceName string, instance *v1beta1.TemporalCluster, scheme *runtime.Scheme, service *v1beta1.ServiceSpec, configHash string) *DeploymentBuilder {
+func NewDeploymentBuilder(serviceName string, instance *v1beta1.TemporalCluster, scheme *runtime.Scheme, service *v1beta1.ServiceSpec, configHash, certificatesHash string) *DeploymentBuilder {
 	return &DeploymentBuilder{
-		serviceName: serviceName,
-		instance:    instance,
-		scheme:      scheme,
-		service:     service,
-		configHash:  configHash,
+		serviceName:      serviceName,
+		instance:         instance,
+		scheme:           scheme,
+		service:          service,
+		configHash:       configHash,
+		certificatesHash: certificatesHash,
 	}
 }
 
@@ -72,7 +79,7 @@ func (b *DeploymentBuilder) Build() client.Object {
 }
 
 func (b *DeploymentBuilder) Enabled() bool {
-	return isBuilderEnabled(b.instance, b.serviceName)
+	return isBuilderEnabled(b.instance, b.serviceName) && !b.service.IsStatefulSet()
 }
 
 func (b *DeploymentBuilder) Update(object client.Object) error {
@@ -86,6 +93,41 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 		metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
 	)
 
+	deployment.Spec.Replicas = b.service.Replicas
+
+	if b.service.Strategy != nil {
+		deployment.Spec.Strategy = *b.service.Strategy.DeepCopy()
+	}
+
+	deployment.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: metadata.LabelsSelector(b.instance, b.serviceName),
+	}
+
+	deployment.Spec.Template = b.buildPodTemplateSpec()
+
+	if b.instance.Spec.Services.Overrides != nil && b.instance.Spec.Services.Overrides.Deployment != nil {
+		err := kubernetes.ApplyDeploymentOverrides(deployment, b.instance.Spec.Services.Overrides.Deployment)
+		if err != nil {
+			return fmt.Errorf("can't apply deployment overrides: %w", err)
+		}
+	}
+
+	if b.service.Overrides != nil && b.service.Overrides.Deployment != nil {
+		err := kubernetes.ApplyDeploymentOverrides(deployment, b.service.Overrides.Deployment)
+		if err != nil {
+			return fmt.Errorf("failed applying deployment overrides: %w", err)
+		}
+	}
+
+	if err := controllerutil.SetControllerReference(b.instance, deployment, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+
+	return nil
+}
+
+// buildPodTemplateSpec returns the pod template of the service's workload.
+func (b *DeploymentBuilder) buildPodTemplateSpec() corev1.PodTemplateSpec {
 	// worker has no grpc endpoint so omit liveness probe
 	var livenessProbe *corev1.Probe
 	if b.serviceName != string(primitives.WorkerService) {
@@ -103,6 +145,20 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 		}
 	}
 
+	if b.service.LivenessProbe != nil {
+		livenessProbe = b.service.LivenessProbe.DeepCopy()
+	}
+
+	terminationGracePeriodSeconds := ptr.To[int64](30)
+	if b.service.TerminationGracePeriodSeconds != nil {
+		terminationGracePeriodSeconds = b.service.TerminationGracePeriodSeconds
+	}
+
+	dnsPolicy := corev1.DNSClusterFirst
+	if b.service.DNSPolicy != "" {
+		dnsPolicy = b.service.DNSPolicy
+	}
+
 	envVars := []corev1.EnvVar{
 		{
 			Name: "POD_IP",
@@ -115,7 +171,7 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 		},
 		{
 			Name:  "SERVICES",
-			Value: b.serviceName,
+			Value: b.getServices(),
 		},
 	}
 
@@ -123,11 +179,22 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 
 	envVars = append(envVars, persistence.GetDatastoresEnvironmentVariables(datastores)...)
 
+	command := b.service.Command
+	if len(command) == 0 {
+		command = persistence.GetDatastoresCredentialsFilesCommand(datastores)
+	}
+
+	// Services with a config overlay use their own configuration.
+	configKey := meta.ConfigTemplateKey
+	if b.service.ConfigOverlay != nil {
+		configKey = meta.ServiceConfigTemplateKey(b.serviceName)
+	}
+
 	volumeMounts := []corev1.VolumeMount{
 		{
 			Name:      "config",
 			MountPath: "/etc/temporal/config/config_template.yaml",
-			SubPath:   "config_template.yaml",
+			SubPath:   configKey,
 		},
 	}
 
@@ -164,8 +231,7 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 
 		volumeMounts = append(volumeMounts, corev1.VolumeMount{
 			Name:      "dynamicconfig",
-			MountPath: "/etc/temporal/config/dynamic_config.yaml",
-			SubPath:   "dynamic_config.yaml",
+			MountPath: b.instance.Spec.DynamicConfig.GetMountPath(),
 		})
 	}
 
@@ -217,7 +283,7 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 		}
 	}
 
-	if b.instance.MTLSWithCertManagerEnabled() {
+	if b.instance.MTLSWithCertificatesEnabled() {
 		if b.instance.Spec.MTLS.InternodeEnabled() {
 			volumeMounts = append(volumeMounts,
 				corev1.VolumeMount{
@@ -235,7 +301,7 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 					Name: certmanager.InternodeIntermediateCACertificate,
 					VolumeSource: corev1.VolumeSource{
 						Secret: &corev1.SecretVolumeSource{
-							SecretName:  b.instance.ChildResourceName(certmanager.InternodeIntermediateCACertificate),
+							SecretName:  mtls.CertificateSecretName(b.instance, certmanager.InternodeIntermediateCACertificate),
 							DefaultMode: ptr.To[int32](corev1.SecretVolumeSourceDefaultMode),
 						},
 					},
@@ -244,7 +310,7 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 					Name: certmanager.InternodeCertificate,
 					VolumeSource: corev1.VolumeSource{
 						Secret: &corev1.SecretVolumeSource{
-							SecretName:  b.instance.ChildResourceName(certmanager.InternodeCertificate),
+							SecretName:  mtls.CertificateSecretName(b.instance, certmanager.InternodeCertificate),
 							DefaultMode: ptr.To[int32](corev1.SecretVolumeSourceDefaultMode),
 						},
 					},
@@ -268,7 +334,7 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 					Name: certmanager.FrontendIntermediateCACertificate,
 					VolumeSource: corev1.VolumeSource{
 						Secret: &corev1.SecretVolumeSource{
-							SecretName:  b.instance.ChildResourceName(certmanager.FrontendIntermediateCACertificate),
+							SecretName:  mtls.CertificateSecretName(b.instance, certmanager.FrontendIntermediateCACertificate),
 							DefaultMode: ptr.To[int32](corev1.SecretVolumeSourceDefaultMode),
 						},
 					},
@@ -277,7 +343,7 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 					Name: certmanager.FrontendCertificate,
 					VolumeSource: corev1.VolumeSource{
 						Secret: &corev1.SecretVolumeSource{
-							SecretName:  b.instance.ChildResourceName(certmanager.FrontendCertificate),
+							SecretName:  mtls.CertificateSecretName(b.instance, certmanager.FrontendCertificate),
 							DefaultMode: ptr.To[int32](corev1.SecretVolumeSourceDefaultMode),
 						},
 					},
@@ -294,15 +360,143 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 					Name: certmanager.WorkerFrontendClientCertificate,
 					VolumeSource: corev1.VolumeSource{
 						Secret: &corev1.SecretVolumeSource{
-							SecretName:  b.instance.ChildResourceName(certmanager.WorkerFrontendClientCertificate),
+							SecretName:  mtls.CertificateSecretName(b.instance, certmanager.WorkerFrontendClientCertificate),
 							DefaultMode: ptr.To[int32](corev1.SecretVolumeSourceDefaultMode),
 						},
 					},
 				})
 			}
+
+			if external := b.instance.Spec.MTLS.Frontend.External; external != nil {
+				volumeMounts = append(volumeMounts, corev1.VolumeMount{
+					Name:      certmanager.FrontendExternalCertificate,
+					MountPath: external.GetCertificateMountPath(),
+				})
+
+				volumes = append(volumes, corev1.Volume{
+					Name: certmanager.FrontendExternalCertificate,
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName:  b.instance.ChildResourceName(certmanager.FrontendExternalCertificate),
+							DefaultMode: ptr.To[int32](corev1.SecretVolumeSourceDefaultMode),
+						},
+					},
+				})
+
+				if external.ClientCASecretRef != nil {
+					volumeMounts = append(volumeMounts, corev1.VolumeMount{
+						Name:      certmanager.FrontendExternalClientCA,
+						MountPath: external.GetClientCACertificateMountPath(),
+					})
+
+					volumes = append(volumes, corev1.Volume{
+						Name: certmanager.FrontendExternalClientCA,
+						VolumeSource: corev1.VolumeSource{
+							Secret: &corev1.SecretVolumeSource{
+								SecretName: external.ClientCASecretRef.Name,
+								Items: []corev1.KeyToPath{
+									{
+										Key:  external.ClientCASecretRef.Key,
+										Path: certmanager.TLSCA,
+									},
+								},
+								DefaultMode: ptr.To[int32](corev1.SecretVolumeSourceDefaultMode),
+							},
+						},
+					})
+				}
+			}
 		}
 	}
 
+	if b.instance.RemoteClustersTrustBundleEnabled() {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      certmanager.RemoteClustersTrustBundle,
+			MountPath: b.instance.Spec.Replication.GetTrustBundleMountPath(),
+		})
+
+		volumes = append(volumes, corev1.Volume{
+			Name: certmanager.RemoteClustersTrustBundle,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  b.instance.ChildResourceName(certmanager.RemoteClustersTrustBundle),
+					DefaultMode: ptr.To[int32](corev1.SecretVolumeSourceDefaultMode),
+				},
+			},
+		})
+	}
+
+	if b.instance.ReplicationEnabled() {
+		for i, remote := range b.instance.Spec.Replication.RemoteClusters {
+			if remote.TLS == nil {
+				continue
+			}
+
+			name := fmt.Sprintf("remote-cluster-%d", i)
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{
+				Name:      name,
+				MountPath: remote.TLS.GetCertificateMountPath(),
+			})
+
+			volumes = append(volumes, corev1.Volume{
+				Name: name,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName:  remote.TLS.SecretRef.Name,
+						DefaultMode: ptr.To[int32](corev1.SecretVolumeSourceDefaultMode),
+					},
+				},
+			})
+		}
+	}
+
+	if b.instance.Spec.DevMode.IsEnabled() {
+		volumeSource := corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		}
+		if b.instance.Spec.DevMode.PersistentVolumeClaim != nil {
+			volumeSource = corev1.VolumeSource{
+				PersistentVolumeClaim: b.instance.Spec.DevMode.PersistentVolumeClaim,
+			}
+		}
+
+		volumes = append(volumes, corev1.Volume{
+			Name:         "sqlite-data",
+			VolumeSource: volumeSource,
+		})
+
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "sqlite-data",
+			MountPath: v1beta1.SQLiteDataMountPath,
+		})
+	}
+
+	if b.instance.Spec.Services.HasLogOverrides() {
+		envVars = append(envVars, log.ServiceEnvironmentVariables(b.instance.Spec.Log, b.service.Log)...)
+	}
+
+	if b.instance.Spec.Services.HasMetricsOverrides() && b.instance.Spec.Metrics.IsEnabled() &&
+		b.instance.Spec.Metrics.Prometheus != nil && b.instance.Spec.Metrics.Prometheus.ListenPort != nil {
+		envVars = append(envVars, prometheus.ServiceEnvironmentVariables(b.instance, b.service)...)
+	}
+
+	if b.instance.Spec.Telemetry.TracingEnabled() {
+		envVars = append(envVars, tracingEnvironmentVariables(b.instance.Spec.Telemetry.Tracing)...)
+	}
+
+	// Custom authorizers and claim mappers run in the frontend.
+	if b.serviceName == string(primitives.FrontendService) &&
+		b.instance.Spec.Authorization != nil && b.instance.Spec.Authorization.Plugin != nil {
+		plugin := b.instance.Spec.Authorization.Plugin
+		envVars = append(envVars, plugin.Env...)
+		volumeMounts = append(volumeMounts, plugin.VolumeMounts...)
+		volumes = append(volumes, plugin.Volumes...)
+	}
+
+	envVars = append(envVars, b.service.Env...)
+	volumeMounts = append(volumeMounts, b.service.VolumeMounts...)
+	volumes = append(volumes, b.service.Volumes...)
+
 	containerPorts := []corev1.ContainerPort{
 		{
 			Name:          "rpc",
@@ -316,14 +510,12 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 		},
 	}
 
-	if b.instance.Spec.Metrics.IsEnabled() {
-		if b.instance.Spec.Metrics.Prometheus != nil && b.instance.Spec.Metrics.Prometheus.ListenPort != nil {
-			containerPorts = append(containerPorts, corev1.ContainerPort{
-				Name:          prometheus.MetricsPortName.String(),
-				ContainerPort: *b.instance.Spec.Metrics.Prometheus.ListenPort,
-				Protocol:      corev1.ProtocolTCP,
-			})
-		}
+	if metricsPort := b.instance.ServiceMetricsPort(b.service); metricsPort != nil {
+		containerPorts = append(containerPorts, corev1.ContainerPort{
+			Name:          prometheus.MetricsPortName.String(),
+			ContainerPort: *metricsPort,
+			Protocol:      corev1.ProtocolTCP,
+		})
 	}
 
 	if b.serviceName == string(primitives.FrontendService) && b.instance.Spec.Services.Frontend.HTTPPort != nil {
@@ -334,70 +526,128 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 		})
 	}
 
-	deployment.Spec.Replicas = b.service.Replicas
+	image := b.instance.Spec.Image
+	if b.service.Image != "" {
+		image = b.service.Image
+	}
 
-	deployment.Spec.Selector = &metav1.LabelSelector{
-		MatchLabels: metadata.LabelsSelector(b.instance, b.serviceName),
+	podObjectMeta := meta.BuildPodObjectMeta(b.instance, b.serviceName, b.configHash, b.certificatesHash)
+	if b.service.Metrics != nil {
+		// Scrape annotations follow the service's metrics overrides.
+		for key := range prometheus.GetAnnotations(b.instance) {
+			delete(podObjectMeta.Annotations, key)
+		}
+		podObjectMeta.Annotations = metadata.Merge(podObjectMeta.Annotations, prometheus.GetServiceAnnotations(b.instance, b.service))
+	}
+	if b.service.PodMetadata != nil {
+		// Labels and annotations set by the operator take precedence over user-provided ones.
+		podObjectMeta.Labels = metadata.Merge(b.service.PodMetadata.Labels, podObjectMeta.Labels)
+		podObjectMeta.Annotations = metadata.Merge(b.service.PodMetadata.Annotations, podObjectMeta.Annotations)
 	}
 
-	deployment.Spec.Template = corev1.PodTemplateSpec{
-		ObjectMeta: meta.BuildPodObjectMeta(b.instance, b.serviceName, b.configHash),
+	template := corev1.PodTemplateSpec{
+		ObjectMeta: podObjectMeta,
 		Spec: corev1.PodSpec{
-			ServiceAccountName:       b.instance.ChildResourceName(b.serviceName),
-			DeprecatedServiceAccount: b.instance.ChildResourceName(b.serviceName),
+			ServiceAccountName:       b.service.ServiceAccount.GetName(b.instance.ChildResourceName(b.serviceName)),
+			DeprecatedServiceAccount: b.service.ServiceAccount.GetName(b.instance.ChildResourceName(b.serviceName)),
 			ImagePullSecrets:         b.instance.Spec.ImagePullSecrets,
-			Containers: []corev1.Container{
+			Containers: append([]corev1.Container{
 				{
 					Name:                     "service", // name "service" is here to simplify overrides
-					Image:                    fmt.Sprintf("%s:%s", b.instance.Spec.Image, b.instance.Spec.Version),
-					ImagePullPolicy:          corev1.PullIfNotPresent,
+					Image:                    meta.ImageReference(image, b.instance.Spec.Version.String(), b.service.ImageDigest),
+					ImagePullPolicy:          meta.ImagePullPolicyOrDefault(b.service.ImagePullPolicy),
+					Command:                  command,
+					Args:                     b.service.Args,
 					Resources:                b.service.Resources,
 					TerminationMessagePath:   corev1.TerminationMessagePathDefault,
 					TerminationMessagePolicy: corev1.TerminationMessageReadFile,
-					SecurityContext: &corev1.SecurityContext{
-						AllowPrivilegeEscalation: ptr.To(false),
-						Capabilities: &corev1.Capabilities{
-							Drop: []corev1.Capability{"ALL"},
-						},
-					},
-					Ports:         containerPorts,
-					LivenessProbe: livenessProbe,
-					Env:           envVars,
-					VolumeMounts:  volumeMounts,
+					SecurityContext:          meta.ContainerSecurityContextOrDefault(b.service.SecurityContext, meta.DefaultContainerSecurityContext()),
+					Ports:                    containerPorts,
+					LivenessProbe:            livenessProbe,
+					ReadinessProbe:           b.service.ReadinessProbe,
+					StartupProbe:             b.service.StartupProbe,
+					Lifecycle:                b.service.Lifecycle,
+					Env:                      envVars,
+					EnvFrom:                  b.service.EnvFrom,
+					VolumeMounts:             volumeMounts,
 				},
-			},
+			}, b.service.Sidecars...),
 			InitContainers:                b.service.InitContainers,
 			RestartPolicy:                 corev1.RestartPolicyAlways,
-			TerminationGracePeriodSeconds: ptr.To[int64](30),
-			DNSPolicy:                     corev1.DNSClusterFirst,
+			TerminationGracePeriodSeconds: terminationGracePeriodSeconds,
+			DNSPolicy:                     dnsPolicy,
+			DNSConfig:                     b.service.DNSConfig,
+			HostAliases:                   b.service.HostAliases,
 			SchedulerName:                 corev1.DefaultSchedulerName,
-			SecurityContext: &corev1.PodSecurityContext{
-				RunAsUser:    ptr.To[int64](1000),
-				RunAsGroup:   ptr.To[int64](1000),
-				FSGroup:      ptr.To[int64](1000),
-				RunAsNonRoot: ptr.To(true),
-			},
-			Volumes: volumes,
+			SecurityContext:               meta.PodSecurityContextOrDefault(b.service.PodSecurityContext, meta.DefaultPodSecurityContext(1000, true)),
+			Volumes:                       volumes,
 		},
 	}
 
-	if b.instance.Spec.Services.Overrides != nil && b.instance.Spec.Services.Overrides.Deployment != nil {
-		err := kubernetes.ApplyDeploymentOverrides(deployment, b.instance.Spec.Services.Overrides.Deployment)
-		if err != nil {
-			return fmt.Errorf("can't apply deployment overrides: %w", err)
-		}
+	spiffe.Inject(b.instance, &template.Spec, "service", b.spiffeMountPaths()...)
+
+	return template
+}
+
+// spiffeMountPaths returns the paths where SVIDs should be mounted when mTLS is enabled using SPIFFE.
+// The same SVID is used as internode and frontend certificates.
+func (b *DeploymentBuilder) spiffeMountPaths() []string {
+	if !b.instance.MTLSWithSPIFFEEnabled() {
+		return nil
 	}
 
-	if b.service.Overrides != nil && b.service.Overrides.Deployment != nil {
-		err := kubernetes.ApplyDeploymentOverrides(deployment, b.service.Overrides.Deployment)
-		if err != nil {
-			return fmt.Errorf("failed applying deployment overrides: %w", err)
+	paths := []string{}
+	if b.instance.Spec.MTLS.InternodeEnabled() {
+		paths = append(paths,
+			b.instance.Spec.MTLS.Internode.GetIntermediateCACertificateMountPath(),
+			b.instance.Spec.MTLS.Internode.GetCertificateMountPath(),
+		)
+	}
+	if b.instance.Spec.MTLS.FrontendEnabled() {
+		paths = append(paths,
+			b.instance.Spec.MTLS.Frontend.GetIntermediateCACertificateMountPath(),
+			b.instance.Spec.MTLS.Frontend.GetCertificateMountPath(),
+		)
+		if !b.instance.Spec.Services.InternalFrontend.IsEnabled() {
+			paths = append(paths, b.instance.Spec.MTLS.Frontend.GetWorkerCertificateMountPath())
 		}
 	}
 
-	if err := controllerutil.SetControllerReference(b.instance, deployment, b.scheme); err != nil {
-		return fmt.Errorf("failed setting controller reference: %w", err)
+	return paths
+}
+
+// getServices returns the list of temporal services the pods should run.
+// In dev mode, the frontend pods run all temporal services in a single process.
+func (b *DeploymentBuilder) getServices() string {
+	if b.instance.Spec.DevMode.IsEnabled() && b.serviceName == string(primitives.FrontendService) {
+		return strings.Join([]string{
+			string(primitives.FrontendService),
+			string(primitives.HistoryService),
+			string(primitives.MatchingService),
+			string(primitives.WorkerService),
+		}, ":")
 	}
+	return b.serviceName
+}
 
-	return nil
+// tracingEnvironmentVariables returns the environment variables holding the traces export headers and sampler.
+func tracingEnvironmentVariables(tracing *v1beta1.TracingSpec) []corev1.EnvVar {
+	envVars := []corev1.EnvVar{}
+	for i, header := range tracing.Headers {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: tracing.GetHeaderEnvVarName(i),
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: header.ValueFrom,
+			},
+		})
+	}
+
+	if tracing.SamplingRatio != "" {
+		envVars = append(envVars,
+			corev1.EnvVar{Name: "OTEL_TRACES_SAMPLER", Value: "parentbased_traceidratio"},
+			corev1.EnvVar{Name: "OTEL_TRACES_SAMPLER_ARG", Value: tracing.SamplingRatio},
+		)
+	}
+
+	return envVars
 }
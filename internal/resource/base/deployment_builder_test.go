@@ -0,0 +1,345 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package base_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/base"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+)
+
+// newDeploymentTestCluster returns a cluster whose frontend service uses the provided spec.
+func newDeploymentTestCluster(frontend *v1beta1.ServiceSpec) *v1beta1.TemporalCluster {
+	frontend.Port = ptr.To(7233)
+	frontend.MembershipPort = ptr.To(6933)
+	frontend.HTTPPort = ptr.To(7243)
+	return &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+		Spec: v1beta1.TemporalClusterSpec{
+			Version: version.MustNewVersionFromString("1.23.0"),
+			Image:   "temporalio/server",
+			Services: &v1beta1.ServicesSpec{
+				Frontend: frontend,
+			},
+			Persistence: v1beta1.TemporalPersistenceSpec{
+				DefaultStore: &v1beta1.DatastoreSpec{
+					Name: v1beta1.DefaultStoreName,
+					SQL:  &v1beta1.SQLSpec{PluginName: "postgres12"},
+				},
+				VisibilityStore: &v1beta1.DatastoreSpec{
+					Name: v1beta1.VisibilityStoreName,
+					SQL:  &v1beta1.SQLSpec{PluginName: "postgres12"},
+				},
+			},
+		},
+	}
+}
+
+// buildFrontendDeployment returns the frontend deployment built for the provided cluster.
+func buildFrontendDeployment(t *testing.T, cluster *v1beta1.TemporalCluster) *appsv1.Deployment {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	builder := base.NewDeploymentBuilder("frontend", cluster, scheme, cluster.Spec.Services.Frontend, "", "")
+	object := builder.Build()
+	require.NoError(t, builder.Update(object))
+	return object.(*appsv1.Deployment)
+}
+
+func TestDeploymentBuilderSecurityContext(t *testing.T) {
+	tests := map[string]struct {
+		service                   *v1beta1.ServiceSpec
+		expectedPodSecurity       *corev1.PodSecurityContext
+		expectedContainerSecurity *corev1.SecurityContext
+	}{
+		"defaults to restricted": {
+			service: &v1beta1.ServiceSpec{},
+			expectedPodSecurity: &corev1.PodSecurityContext{
+				RunAsUser:      ptr.To[int64](1000),
+				RunAsGroup:     ptr.To[int64](1000),
+				FSGroup:        ptr.To[int64](1000),
+				RunAsNonRoot:   ptr.To(true),
+				SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+			},
+			expectedContainerSecurity: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: ptr.To(false),
+				RunAsNonRoot:             ptr.To(true),
+				Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+				SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+			},
+		},
+		"overrides take precedence": {
+			service: &v1beta1.ServiceSpec{
+				PodSecurityContext: &corev1.PodSecurityContext{RunAsUser: ptr.To[int64](2000)},
+				SecurityContext:    &corev1.SecurityContext{ReadOnlyRootFilesystem: ptr.To(true)},
+			},
+			expectedPodSecurity:       &corev1.PodSecurityContext{RunAsUser: ptr.To[int64](2000)},
+			expectedContainerSecurity: &corev1.SecurityContext{ReadOnlyRootFilesystem: ptr.To(true)},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			deployment := buildFrontendDeployment(tt, newDeploymentTestCluster(test.service))
+
+			podSpec := deployment.Spec.Template.Spec
+			assert.Equal(tt, test.expectedPodSecurity, podSpec.SecurityContext)
+			require.NotEmpty(tt, podSpec.Containers)
+			assert.Equal(tt, test.expectedContainerSecurity, podSpec.Containers[0].SecurityContext)
+		})
+	}
+}
+
+func TestDeploymentBuilderServiceAccount(t *testing.T) {
+	tests := map[string]struct {
+		serviceAccount *v1beta1.ServiceAccountSpec
+		expectedName   string
+	}{
+		"defaults to the service account created by the operator": {
+			expectedName: "prod-frontend",
+		},
+		"existing service account": {
+			serviceAccount: &v1beta1.ServiceAccountSpec{Name: "frontend-identity"},
+			expectedName:   "frontend-identity",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			deployment := buildFrontendDeployment(tt, newDeploymentTestCluster(&v1beta1.ServiceSpec{ServiceAccount: test.serviceAccount}))
+			assert.Equal(tt, test.expectedName, deployment.Spec.Template.Spec.ServiceAccountName)
+		})
+	}
+}
+
+func TestDeploymentBuilderDynamicConfig(t *testing.T) {
+	cluster := newDeploymentTestCluster(&v1beta1.ServiceSpec{})
+	deployment := buildFrontendDeployment(t, cluster)
+	for _, mount := range deployment.Spec.Template.Spec.Containers[0].VolumeMounts {
+		assert.NotEqual(t, "dynamicconfig", mount.Name)
+	}
+
+	cluster.Spec.DynamicConfig = &v1beta1.DynamicConfigSpec{
+		PollInterval: &metav1.Duration{Duration: 10 * time.Second},
+	}
+	deployment = buildFrontendDeployment(t, cluster)
+
+	// The configmap is mounted as a directory, without subPath, so kubelet propagates its updates.
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "dynamicconfig",
+		MountPath: "/etc/temporal/config/dynamicconfig",
+	})
+	assert.Contains(t, deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "dynamicconfig",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "prod-dynamicconfig"},
+				DefaultMode:          ptr.To[int32](corev1.ConfigMapVolumeSourceDefaultMode),
+			},
+		},
+	})
+}
+
+func TestDeploymentBuilderDevMode(t *testing.T) {
+	cluster := newDeploymentTestCluster(&v1beta1.ServiceSpec{})
+	cluster.Spec.DevMode = &v1beta1.DevModeSpec{Enabled: true}
+
+	deployment := buildFrontendDeployment(t, cluster)
+	container := deployment.Spec.Template.Spec.Containers[0]
+
+	// All services run in the frontend pods.
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "SERVICES", Value: "frontend:history:matching:worker"})
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: "sqlite-data", MountPath: v1beta1.SQLiteDataMountPath})
+	assert.Contains(t, deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name:         "sqlite-data",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	})
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	assert.True(t, base.NewDeploymentBuilder("frontend", cluster, scheme, cluster.Spec.Services.Frontend, "", "").Enabled())
+	for _, service := range []string{"history", "matching", "worker"} {
+		assert.False(t, base.NewDeploymentBuilder(service, cluster, scheme, &v1beta1.ServiceSpec{}, "", "").Enabled(), service)
+	}
+	statefulSetService := &v1beta1.ServiceSpec{WorkloadType: v1beta1.StatefulSetWorkloadType}
+	assert.False(t, base.NewStatefulSetBuilder("history", cluster, scheme, statefulSetService, "", "").Enabled())
+
+	// Without dev mode, services run in their own pods.
+	cluster.Spec.DevMode = nil
+	deployment = buildFrontendDeployment(t, cluster)
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: "SERVICES", Value: "frontend"})
+	assert.True(t, base.NewDeploymentBuilder("history", cluster, scheme, &v1beta1.ServiceSpec{}, "", "").Enabled())
+}
+
+func TestDeploymentBuilderPodSpec(t *testing.T) {
+	tests := map[string]struct {
+		service *v1beta1.ServiceSpec
+		assert  func(t *testing.T, deployment *appsv1.Deployment)
+	}{
+		"defaults": {
+			service: &v1beta1.ServiceSpec{},
+			assert: func(t *testing.T, deployment *appsv1.Deployment) {
+				podSpec := deployment.Spec.Template.Spec
+				require.Len(t, podSpec.Containers, 1)
+				assert.Empty(t, podSpec.InitContainers)
+				assert.Equal(t, appsv1.DeploymentStrategy{}, deployment.Spec.Strategy)
+				assert.Equal(t, ptr.To[int64](30), podSpec.TerminationGracePeriodSeconds)
+				assert.Equal(t, corev1.DNSClusterFirst, podSpec.DNSPolicy)
+				container := podSpec.Containers[0]
+				assert.Equal(t, "service", container.Name)
+				assert.Equal(t, "temporalio/server:1.23.0", container.Image)
+				assert.Equal(t, corev1.PullIfNotPresent, container.ImagePullPolicy)
+				assert.Nil(t, container.ReadinessProbe)
+				assert.NotNil(t, container.LivenessProbe)
+				assert.Nil(t, container.Args)
+			},
+		},
+		"sidecars and init containers": {
+			service: &v1beta1.ServiceSpec{
+				Sidecars:       []corev1.Container{{Name: "proxy", Image: "envoyproxy/envoy"}},
+				InitContainers: []corev1.Container{{Name: "wait-db", Image: "busybox"}},
+			},
+			assert: func(t *testing.T, deployment *appsv1.Deployment) {
+				podSpec := deployment.Spec.Template.Spec
+				require.Len(t, podSpec.Containers, 2)
+				assert.Equal(t, "service", podSpec.Containers[0].Name)
+				assert.Equal(t, "proxy", podSpec.Containers[1].Name)
+				require.Len(t, podSpec.InitContainers, 1)
+				assert.Equal(t, "wait-db", podSpec.InitContainers[0].Name)
+			},
+		},
+		"env, volumes and volume mounts": {
+			service: &v1beta1.ServiceSpec{
+				Env:          []corev1.EnvVar{{Name: "GOMEMLIMIT", Value: "1GiB"}},
+				EnvFrom:      []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "extra"}}}},
+				Volumes:      []corev1.Volume{{Name: "plugins", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+				VolumeMounts: []corev1.VolumeMount{{Name: "plugins", MountPath: "/plugins"}},
+			},
+			assert: func(t *testing.T, deployment *appsv1.Deployment) {
+				podSpec := deployment.Spec.Template.Spec
+				container := podSpec.Containers[0]
+				assert.Contains(t, container.Env, corev1.EnvVar{Name: "GOMEMLIMIT", Value: "1GiB"})
+				assert.Equal(t, "GOMEMLIMIT", container.Env[len(container.Env)-1].Name, "user-provided env should be appended last")
+				assert.Len(t, container.EnvFrom, 1)
+				assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: "plugins", MountPath: "/plugins"})
+				assert.Contains(t, podSpec.Volumes, corev1.Volume{Name: "plugins", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}})
+			},
+		},
+		"probes, lifecycle and termination grace period": {
+			service: &v1beta1.ServiceSpec{
+				LivenessProbe:                 &corev1.Probe{PeriodSeconds: 20},
+				ReadinessProbe:                &corev1.Probe{PeriodSeconds: 5},
+				StartupProbe:                  &corev1.Probe{FailureThreshold: 30},
+				Lifecycle:                     &corev1.Lifecycle{PreStop: &corev1.LifecycleHandler{Sleep: &corev1.SleepAction{Seconds: 5}}},
+				TerminationGracePeriodSeconds: ptr.To[int64](120),
+			},
+			assert: func(t *testing.T, deployment *appsv1.Deployment) {
+				podSpec := deployment.Spec.Template.Spec
+				container := podSpec.Containers[0]
+				assert.Equal(t, &corev1.Probe{PeriodSeconds: 20}, container.LivenessProbe)
+				assert.Equal(t, &corev1.Probe{PeriodSeconds: 5}, container.ReadinessProbe)
+				assert.Equal(t, &corev1.Probe{FailureThreshold: 30}, container.StartupProbe)
+				assert.NotNil(t, container.Lifecycle)
+				assert.Equal(t, ptr.To[int64](120), podSpec.TerminationGracePeriodSeconds)
+			},
+		},
+		"strategy": {
+			service: &v1beta1.ServiceSpec{
+				Strategy: &appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			},
+			assert: func(t *testing.T, deployment *appsv1.Deployment) {
+				assert.Equal(t, appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}, deployment.Spec.Strategy)
+			},
+		},
+		"dns settings": {
+			service: &v1beta1.ServiceSpec{
+				DNSPolicy:   corev1.DNSNone,
+				DNSConfig:   &corev1.PodDNSConfig{Nameservers: []string{"10.0.0.10"}},
+				HostAliases: []corev1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"db.internal"}}},
+			},
+			assert: func(t *testing.T, deployment *appsv1.Deployment) {
+				podSpec := deployment.Spec.Template.Spec
+				assert.Equal(t, corev1.DNSNone, podSpec.DNSPolicy)
+				assert.Equal(t, &corev1.PodDNSConfig{Nameservers: []string{"10.0.0.10"}}, podSpec.DNSConfig)
+				assert.Equal(t, []corev1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"db.internal"}}}, podSpec.HostAliases)
+			},
+		},
+		"pod metadata": {
+			service: &v1beta1.ServiceSpec{
+				PodMetadata: &v1beta1.ObjectMetaOverride{
+					Labels: map[string]string{
+						"team":                        "platform",
+						"app.kubernetes.io/component": "overridden",
+					},
+					Annotations: map[string]string{"example.com/owner": "platform"},
+				},
+			},
+			assert: func(t *testing.T, deployment *appsv1.Deployment) {
+				objectMeta := deployment.Spec.Template.ObjectMeta
+				assert.Equal(t, "platform", objectMeta.Labels["team"])
+				assert.Equal(t, "platform", objectMeta.Annotations["example.com/owner"])
+				assert.Equal(t, "frontend", objectMeta.Labels["app.kubernetes.io/component"], "operator labels should take precedence")
+				assert.Subset(t, objectMeta.Labels, deployment.Spec.Selector.MatchLabels)
+			},
+		},
+		"image overrides": {
+			service: &v1beta1.ServiceSpec{
+				Image:           "example.com/temporal/server",
+				ImageDigest:     "sha256:4a1c4b21597c1b4415bdbecb28a3296c6b5e23ca4f9feeb599860a1dac6a0108",
+				ImagePullPolicy: corev1.PullAlways,
+			},
+			assert: func(t *testing.T, deployment *appsv1.Deployment) {
+				container := deployment.Spec.Template.Spec.Containers[0]
+				assert.Equal(t, "example.com/temporal/server:1.23.0@sha256:4a1c4b21597c1b4415bdbecb28a3296c6b5e23ca4f9feeb599860a1dac6a0108", container.Image)
+				assert.Equal(t, corev1.PullAlways, container.ImagePullPolicy)
+			},
+		},
+		"command and args": {
+			service: &v1beta1.ServiceSpec{
+				Command: []string{"/custom-entrypoint.sh"},
+				Args:    []string{"--verbose"},
+			},
+			assert: func(t *testing.T, deployment *appsv1.Deployment) {
+				container := deployment.Spec.Template.Spec.Containers[0]
+				assert.Equal(t, []string{"/custom-entrypoint.sh"}, container.Command)
+				assert.Equal(t, []string{"--verbose"}, container.Args)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			test.assert(tt, buildFrontendDeployment(tt, newDeploymentTestCluster(test.service)))
+		})
+	}
+}
@@ -72,6 +72,12 @@ func (b *DynamicConfigmapBuilder) Update(object client.Object) error {
 		return fmt.Errorf("failed computing expected dynamic config: %w", err)
 	}
 
+	config.ApplyVisibilityMigration(expectedValues, b.instance.Spec.Persistence.VisibilityMigration)
+	config.ApplyPersistenceLimits(expectedValues, b.instance.Spec.Persistence.Limits)
+	if b.instance.Spec.Services != nil && b.instance.Spec.Services.Frontend != nil {
+		config.ApplyFrontendLimits(expectedValues, b.instance.Spec.Services.Frontend.Limits)
+	}
+
 	currentContent, ok := configMap.Data["dynamic_config.yaml"]
 	if ok {
 		err := yaml.Unmarshal([]byte(currentContent), &currentValues)
@@ -0,0 +1,89 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package base
+
+import (
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"go.temporal.io/server/common/primitives"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ resource.Builder = (*FrontendCanaryDeploymentBuilder)(nil)
+
+// FrontendCanaryDeploymentBuilder builds the single replica frontend deployment running the new cluster version
+// during a canary upgrade. Its pods share the frontend labels, so they receive a share of the frontend traffic.
+type FrontendCanaryDeploymentBuilder struct {
+	*DeploymentBuilder
+	enabled bool
+}
+
+// NewFrontendCanaryDeploymentBuilder returns a builder for the frontend canary deployment.
+// The deployment is only enabled when a canary version is provided.
+func NewFrontendCanaryDeploymentBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme, service *v1beta1.ServiceSpec, configHash, certificatesHash string, canaryVersion *version.Version) *FrontendCanaryDeploymentBuilder {
+	canary := instance.DeepCopy()
+	if canaryVersion != nil {
+		canary.Spec.Version = canaryVersion
+	}
+
+	return &FrontendCanaryDeploymentBuilder{
+		DeploymentBuilder: NewDeploymentBuilder(string(primitives.FrontendService), canary, scheme, service, configHash, certificatesHash),
+		enabled:           canaryVersion != nil,
+	}
+}
+
+// FrontendCanaryName returns the name of the frontend canary deployment of the provided cluster.
+func FrontendCanaryName(instance *v1beta1.TemporalCluster) string {
+	return instance.ChildResourceName("frontend-canary")
+}
+
+func (b *FrontendCanaryDeploymentBuilder) Build() client.Object {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        FrontendCanaryName(b.instance),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.Merge(metadata.GetLabels(b.instance, b.serviceName, b.instance.Spec.Version, b.instance.Labels), metadata.CanaryLabels()),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *FrontendCanaryDeploymentBuilder) Enabled() bool {
+	return b.enabled && b.DeploymentBuilder.Enabled()
+}
+
+func (b *FrontendCanaryDeploymentBuilder) Update(object client.Object) error {
+	err := b.DeploymentBuilder.Update(object)
+	if err != nil {
+		return err
+	}
+
+	deployment := object.(*appsv1.Deployment)
+	deployment.Labels = metadata.Merge(deployment.Labels, metadata.CanaryLabels())
+	deployment.Spec.Replicas = ptr.To[int32](1)
+	deployment.Spec.Selector.MatchLabels = metadata.Merge(deployment.Spec.Selector.MatchLabels, metadata.CanaryLabels())
+	deployment.Spec.Template.Labels = metadata.Merge(deployment.Spec.Template.Labels, metadata.CanaryLabels())
+
+	return nil
+}
@@ -0,0 +1,91 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package base
+
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+var _ resource.Builder = (*FrontendGRPCRouteBuilder)(nil)
+
+type FrontendGRPCRouteBuilder struct {
+	instance *v1beta1.TemporalCluster
+	scheme   *runtime.Scheme
+}
+
+func NewFrontendGRPCRouteBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme) *FrontendGRPCRouteBuilder {
+	return &FrontendGRPCRouteBuilder{
+		instance: instance,
+		scheme:   scheme,
+	}
+}
+
+func (b *FrontendGRPCRouteBuilder) Build() client.Object {
+	return &gatewayv1alpha2.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.instance.ChildResourceName(meta.FrontendService),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.GetLabels(b.instance, meta.FrontendService, b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *FrontendGRPCRouteBuilder) Enabled() bool {
+	return b.instance.Spec.Services != nil &&
+		b.instance.Spec.Services.Frontend != nil &&
+		b.instance.Spec.Services.Frontend.GRPCRoute != nil
+}
+
+func (b *FrontendGRPCRouteBuilder) Update(object client.Object) error {
+	spec := b.instance.Spec.Services.Frontend.GRPCRoute
+
+	route := object.(*gatewayv1alpha2.GRPCRoute)
+	route.Labels = object.GetLabels()
+	route.Annotations = metadata.Merge(object.GetAnnotations(), spec.Annotations)
+	route.Spec = gatewayv1alpha2.GRPCRouteSpec{
+		CommonRouteSpec: gatewayv1alpha2.CommonRouteSpec{
+			ParentRefs: meta.GatewayParentRefs(spec.ParentRefs),
+		},
+		Hostnames: spec.Hostnames,
+		Rules: []gatewayv1alpha2.GRPCRouteRule{
+			{
+				BackendRefs: []gatewayv1alpha2.GRPCBackendRef{
+					{
+						BackendRef: meta.GatewayServiceBackendRef(b.instance.ChildResourceName(meta.FrontendService), int32(*b.instance.Spec.Services.Frontend.Port)),
+					},
+				},
+			},
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(b.instance, route, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+	return nil
+}
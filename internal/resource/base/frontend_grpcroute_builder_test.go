@@ -0,0 +1,75 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package base_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/base"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func TestFrontendGRPCRouteBuilder(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	require.NoError(t, gatewayv1alpha2.AddToScheme(scheme))
+
+	cluster := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+		Spec: v1beta1.TemporalClusterSpec{
+			Version: version.MustNewVersionFromString("1.23.0"),
+			Services: &v1beta1.ServicesSpec{
+				Frontend: &v1beta1.ServiceSpec{
+					Port: ptr.To(7233),
+					GRPCRoute: &v1beta1.GatewayRouteSpec{
+						ParentRefs: []gatewayv1.ParentReference{{Name: "public"}},
+						Hostnames:  []gatewayv1.Hostname{"temporal.example.com"},
+					},
+				},
+			},
+		},
+	}
+
+	builder := base.NewFrontendGRPCRouteBuilder(cluster, scheme)
+	assert.True(t, builder.Enabled())
+
+	object := builder.Build()
+	require.NoError(t, builder.Update(object))
+
+	route := object.(*gatewayv1alpha2.GRPCRoute)
+	require.Len(t, route.Spec.ParentRefs, 1)
+	assert.Equal(t, gatewayv1.Kind("Gateway"), *route.Spec.ParentRefs[0].Kind)
+	assert.Equal(t, []gatewayv1.Hostname{"temporal.example.com"}, route.Spec.Hostnames)
+	require.Len(t, route.Spec.Rules, 1)
+	require.Len(t, route.Spec.Rules[0].BackendRefs, 1)
+
+	backend := route.Spec.Rules[0].BackendRefs[0]
+	assert.Equal(t, gatewayv1.ObjectName("prod-frontend"), backend.Name)
+	assert.Equal(t, gatewayv1.PortNumber(7233), *backend.Port)
+	assert.Equal(t, cluster.Name, route.OwnerReferences[0].Name)
+}
@@ -0,0 +1,83 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package base
+
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	routev1 "github.com/alexandrevilain/temporal-operator/internal/apis/openshift/route/v1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ resource.Builder = (*FrontendRouteBuilder)(nil)
+
+type FrontendRouteBuilder struct {
+	instance *v1beta1.TemporalCluster
+	scheme   *runtime.Scheme
+}
+
+func NewFrontendRouteBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme) *FrontendRouteBuilder {
+	return &FrontendRouteBuilder{
+		instance: instance,
+		scheme:   scheme,
+	}
+}
+
+func (b *FrontendRouteBuilder) Build() client.Object {
+	return &routev1.Route{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.instance.ChildResourceName(meta.FrontendService),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.GetLabels(b.instance, meta.FrontendService, b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *FrontendRouteBuilder) Enabled() bool {
+	return b.instance.Spec.Services != nil &&
+		b.instance.Spec.Services.Frontend != nil &&
+		b.instance.Spec.Services.Frontend.Route != nil
+}
+
+func (b *FrontendRouteBuilder) Update(object client.Object) error {
+	spec := b.instance.Spec.Services.Frontend.Route
+
+	// The frontend terminates TLS itself when frontend mTLS is enabled.
+	defaultTermination := v1beta1.EdgeRouteTermination
+	if b.instance.Spec.MTLS != nil && b.instance.Spec.MTLS.FrontendEnabled() {
+		defaultTermination = v1beta1.PassthroughRouteTermination
+	}
+
+	route := object.(*routev1.Route)
+	route.Labels = object.GetLabels()
+	route.Annotations = metadata.Merge(object.GetAnnotations(), spec.Annotations)
+	route.Spec = meta.RouteSpecFor(spec, route, b.instance.ChildResourceName(meta.FrontendService), "grpc-rpc", "/", defaultTermination)
+
+	if err := controllerutil.SetControllerReference(b.instance, route, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package base_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	routev1 "github.com/alexandrevilain/temporal-operator/internal/apis/openshift/route/v1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/base"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+)
+
+func TestFrontendRouteBuilder(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	require.NoError(t, routev1.AddToScheme(scheme))
+
+	tests := map[string]struct {
+		route               *v1beta1.RouteSpec
+		mTLS                *v1beta1.MTLSSpec
+		existingHost        string
+		expectedHost        string
+		expectedTermination routev1.TLSTerminationType
+	}{
+		"defaults to edge termination": {
+			route:               &v1beta1.RouteSpec{Host: "temporal.apps.example.com"},
+			expectedHost:        "temporal.apps.example.com",
+			expectedTermination: routev1.TLSTerminationEdge,
+		},
+		"defaults to passthrough termination with frontend mTLS": {
+			route:               &v1beta1.RouteSpec{},
+			mTLS:                &v1beta1.MTLSSpec{Frontend: &v1beta1.FrontendMTLSSpec{Enabled: true}},
+			existingHost:        "prod-frontend-demo.apps.example.com",
+			expectedHost:        "prod-frontend-demo.apps.example.com",
+			expectedTermination: routev1.TLSTerminationPassthrough,
+		},
+		"reencrypt termination": {
+			route: &v1beta1.RouteSpec{
+				Termination:              v1beta1.ReencryptRouteTermination,
+				DestinationCACertificate: "ca",
+			},
+			mTLS:                &v1beta1.MTLSSpec{Frontend: &v1beta1.FrontendMTLSSpec{Enabled: true}},
+			expectedTermination: routev1.TLSTerminationReencrypt,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			cluster := &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.23.0"),
+					MTLS:    test.mTLS,
+					Services: &v1beta1.ServicesSpec{
+						Frontend: &v1beta1.ServiceSpec{
+							Port:  ptr.To(7233),
+							Route: test.route,
+						},
+					},
+				},
+			}
+
+			builder := base.NewFrontendRouteBuilder(cluster, scheme)
+			assert.True(tt, builder.Enabled())
+
+			object := builder.Build()
+			object.(*routev1.Route).Spec.Host = test.existingHost
+			require.NoError(tt, builder.Update(object))
+
+			route := object.(*routev1.Route)
+			assert.Equal(tt, test.expectedHost, route.Spec.Host)
+			assert.Empty(tt, route.Spec.Path)
+			assert.Equal(tt, "Service", route.Spec.To.Kind)
+			assert.Equal(tt, "prod-frontend", route.Spec.To.Name)
+			assert.Equal(tt, intstr.FromString("grpc-rpc"), route.Spec.Port.TargetPort)
+			assert.Equal(tt, test.expectedTermination, route.Spec.TLS.Termination)
+			assert.Equal(tt, test.route.DestinationCACertificate, route.Spec.TLS.DestinationCACertificate)
+			assert.Equal(tt, cluster.Name, route.OwnerReferences[0].Name)
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"github.com/alexandrevilain/temporal-operator/internal/metadata"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	"github.com/alexandrevilain/temporal-operator/pkg/kubernetes"
 	"go.temporal.io/server/common/primitives"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -71,30 +72,80 @@ func (b *FrontendServiceBuilder) Update(object client.Object) error {
 	service.Annotations = metadata.Merge(
 		object.GetAnnotations(),
 		metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		meta.ExternalDNSAnnotations(b.instance.Spec.Services.Frontend.ExternalHostname),
 	)
-	service.Spec.Type = corev1.ServiceTypeClusterIP
-	service.Spec.Selector = metadata.LabelsSelector(b.instance, string(primitives.FrontendService))
-	service.Spec.Ports = []corev1.ServicePort{
+
+	spec := b.instance.Spec.Services.Frontend.Service
+	exposed := spec.GetType() != corev1.ServiceTypeClusterIP
+
+	ports := []corev1.ServicePort{
 		{
-			Name:       "grpc-rpc",
-			Protocol:   corev1.ProtocolTCP,
-			Port:       int32(*b.instance.Spec.Services.Frontend.Port),
-			TargetPort: intstr.FromString("rpc"),
+			Name:        "grpc-rpc",
+			Protocol:    corev1.ProtocolTCP,
+			AppProtocol: b.instance.Spec.Services.Frontend.AppProtocols.GetRPC("grpc"),
+			Port:        int32(*b.instance.Spec.Services.Frontend.Port),
+			TargetPort:  intstr.FromString("rpc"),
 		},
 	}
+	if exposed {
+		ports[0].NodePort = nodePort(service, ports[0].Name, spec.NodePort)
+	}
 
 	if b.instance.Spec.Services.Frontend.HTTPPort != nil {
-		service.Spec.Ports = append(service.Spec.Ports, corev1.ServicePort{
-			Name:       "http",
-			Protocol:   corev1.ProtocolTCP,
-			Port:       int32(*b.instance.Spec.Services.Frontend.HTTPPort),
-			TargetPort: intstr.FromString("http"),
-		})
+		port := corev1.ServicePort{
+			Name:        "http",
+			Protocol:    corev1.ProtocolTCP,
+			AppProtocol: b.instance.Spec.Services.Frontend.AppProtocols.GetHTTP(),
+			Port:        int32(*b.instance.Spec.Services.Frontend.HTTPPort),
+			TargetPort:  intstr.FromString("http"),
+		}
+		if exposed {
+			port.NodePort = nodePort(service, port.Name, spec.HTTPNodePort)
+		}
+		ports = append(ports, port)
 	}
 
+	service.Spec.Type = spec.GetType()
+	service.Spec.Selector = metadata.LabelsSelector(b.instance, string(primitives.FrontendService))
+	service.Spec.Ports = ports
+
+	if spec.GetType() == corev1.ServiceTypeLoadBalancer {
+		service.Spec.LoadBalancerClass = spec.LoadBalancerClass
+		service.Spec.LoadBalancerSourceRanges = spec.LoadBalancerSourceRanges
+	} else {
+		service.Spec.LoadBalancerClass = nil
+		service.Spec.LoadBalancerSourceRanges = nil
+	}
+
+	meta.SetServiceIPFamilies(service, b.instance.Spec.Network)
+	meta.SetServiceTrafficPolicies(service, spec)
+
 	if err := controllerutil.SetControllerReference(b.instance, service, b.scheme); err != nil {
 		return fmt.Errorf("failed setting controller reference: %w", err)
 	}
 
+	if spec != nil {
+		err := kubernetes.ApplyServiceOverrides(service, spec.ObjectMetaOverride)
+		if err != nil {
+			return fmt.Errorf("failed applying service overrides: %w", err)
+		}
+	}
+
 	return nil
 }
+
+// nodePort returns the node port of the provided service port.
+// If no node port is requested, it keeps the one allocated by Kubernetes, to avoid updating the service at each reconciliation.
+func nodePort(service *corev1.Service, name string, requested *int32) int32 {
+	if requested != nil {
+		return *requested
+	}
+
+	for _, port := range service.Spec.Ports {
+		if port.Name == name {
+			return port.NodePort
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,176 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package base_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/base"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+)
+
+func TestFrontendServiceBuilder(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	tests := map[string]struct {
+		service                   *v1beta1.KubernetesServiceSpec
+		externalHostname          string
+		appProtocols              *v1beta1.AppProtocolsSpec
+		expectedAppProtocols      []*string
+		existingPorts             []corev1.ServicePort
+		expectedType              corev1.ServiceType
+		expectedNodePorts         []int32
+		expectedLoadBalancerClass *string
+		expectedAnnotations       map[string]string
+		network                   *v1beta1.NetworkSpec
+		expectedIPFamilyPolicy    *corev1.IPFamilyPolicy
+		expectedIPFamilies        []corev1.IPFamily
+		expectedTrafficPolicy     corev1.ServiceInternalTrafficPolicy
+		expectedSessionAffinity   corev1.ServiceAffinity
+		expectedAffinityConfig    *corev1.SessionAffinityConfig
+	}{
+		"defaults": {
+			expectedType:         corev1.ServiceTypeClusterIP,
+			expectedNodePorts:    []int32{0, 0},
+			expectedAppProtocols: []*string{ptr.To("grpc"), ptr.To("http")},
+		},
+		"app protocols overrides": {
+			appProtocols: &v1beta1.AppProtocolsSpec{
+				RPC:  ptr.To("kubernetes.io/h2c"),
+				HTTP: ptr.To(""),
+			},
+			expectedType:         corev1.ServiceTypeClusterIP,
+			expectedNodePorts:    []int32{0, 0},
+			expectedAppProtocols: []*string{ptr.To("kubernetes.io/h2c"), nil},
+		},
+		"dual-stack": {
+			network: &v1beta1.NetworkSpec{
+				IPFamilyPolicy: ptr.To(corev1.IPFamilyPolicyRequireDualStack),
+				IPFamilies:     []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
+			},
+			expectedType:           corev1.ServiceTypeClusterIP,
+			expectedNodePorts:      []int32{0, 0},
+			expectedIPFamilyPolicy: ptr.To(corev1.IPFamilyPolicyRequireDualStack),
+			expectedIPFamilies:     []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
+		},
+		"traffic policies": {
+			service: &v1beta1.KubernetesServiceSpec{
+				InternalTrafficPolicy:         ptr.To(corev1.ServiceInternalTrafficPolicyLocal),
+				SessionAffinity:               corev1.ServiceAffinityClientIP,
+				SessionAffinityTimeoutSeconds: ptr.To[int32](600),
+			},
+			expectedType:            corev1.ServiceTypeClusterIP,
+			expectedNodePorts:       []int32{0, 0},
+			expectedTrafficPolicy:   corev1.ServiceInternalTrafficPolicyLocal,
+			expectedSessionAffinity: corev1.ServiceAffinityClientIP,
+			expectedAffinityConfig: &corev1.SessionAffinityConfig{
+				ClientIP: &corev1.ClientIPConfig{TimeoutSeconds: ptr.To[int32](600)},
+			},
+		},
+		"node port": {
+			service: &v1beta1.KubernetesServiceSpec{
+				Type:     corev1.ServiceTypeNodePort,
+				NodePort: ptr.To[int32](30233),
+			},
+			existingPorts:     []corev1.ServicePort{{Name: "http", NodePort: 31243}},
+			expectedType:      corev1.ServiceTypeNodePort,
+			expectedNodePorts: []int32{30233, 31243},
+		},
+		"load balancer": {
+			service: &v1beta1.KubernetesServiceSpec{
+				ObjectMetaOverride: &v1beta1.ObjectMetaOverride{
+					Annotations: map[string]string{"service.beta.kubernetes.io/aws-load-balancer-scheme": "internet-facing"},
+				},
+				Type:                     corev1.ServiceTypeLoadBalancer,
+				LoadBalancerClass:        ptr.To("service.k8s.aws/nlb"),
+				LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+			},
+			externalHostname:          "temporal.example.com",
+			expectedType:              corev1.ServiceTypeLoadBalancer,
+			expectedNodePorts:         []int32{0, 0},
+			expectedLoadBalancerClass: ptr.To("service.k8s.aws/nlb"),
+			expectedAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-scheme": "internet-facing",
+				"external-dns.alpha.kubernetes.io/hostname":           "temporal.example.com",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			cluster := &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.23.0"),
+					Network: test.network,
+					Services: &v1beta1.ServicesSpec{
+						Frontend: &v1beta1.ServiceSpec{
+							Port:             ptr.To(7233),
+							HTTPPort:         ptr.To(7243),
+							ExternalHostname: test.externalHostname,
+							AppProtocols:     test.appProtocols,
+							Service:          test.service,
+						},
+					},
+				},
+			}
+
+			builder := base.NewFrontendServiceBuilder(cluster, scheme)
+			object := builder.Build()
+			object.(*corev1.Service).Spec.Ports = test.existingPorts
+			require.NoError(tt, builder.Update(object))
+
+			service := object.(*corev1.Service)
+			assert.Equal(tt, test.expectedType, service.Spec.Type)
+			require.Len(tt, service.Spec.Ports, 2)
+			for i, nodePort := range test.expectedNodePorts {
+				assert.Equal(tt, nodePort, service.Spec.Ports[i].NodePort)
+			}
+			for i, appProtocol := range test.expectedAppProtocols {
+				assert.Equal(tt, appProtocol, service.Spec.Ports[i].AppProtocol)
+			}
+			assert.Equal(tt, test.expectedLoadBalancerClass, service.Spec.LoadBalancerClass)
+			assert.Equal(tt, test.expectedIPFamilyPolicy, service.Spec.IPFamilyPolicy)
+			assert.Equal(tt, test.expectedIPFamilies, service.Spec.IPFamilies)
+			expectedTrafficPolicy := test.expectedTrafficPolicy
+			if expectedTrafficPolicy == "" {
+				expectedTrafficPolicy = corev1.ServiceInternalTrafficPolicyCluster
+			}
+			assert.Equal(tt, &expectedTrafficPolicy, service.Spec.InternalTrafficPolicy)
+			expectedSessionAffinity := test.expectedSessionAffinity
+			if expectedSessionAffinity == "" {
+				expectedSessionAffinity = corev1.ServiceAffinityNone
+			}
+			assert.Equal(tt, expectedSessionAffinity, service.Spec.SessionAffinity)
+			assert.Equal(tt, test.expectedAffinityConfig, service.Spec.SessionAffinityConfig)
+			for key, value := range test.expectedAnnotations {
+				assert.Equal(tt, value, service.Annotations[key])
+			}
+		})
+	}
+}
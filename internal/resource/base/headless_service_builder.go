@@ -23,6 +23,7 @@ import (
 	"github.com/alexandrevilain/controller-tools/pkg/resource"
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/prometheus"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -87,28 +88,39 @@ func (b *HeadlessServiceBuilder) Update(object client.Object) error {
 
 	service.Spec.Ports = []corev1.ServicePort{
 		{
-			Name:       "http-metrics",
-			TargetPort: prometheus.MetricsPortName,
-			Protocol:   corev1.ProtocolTCP,
-			Port:       9090,
-		},
-		{
-			// Here "tcp-" is used instead of "grpc-" because temporal uses
+			// Here "tcp" is used instead of "grpc" because temporal uses
 			// pod-to-pod traffic over ip. Because no "Host" header is set,
 			// istio can't create mTLS for gRPC.
-			Name:       "tcp-rpc",
-			TargetPort: intstr.FromString("rpc"),
-			Protocol:   corev1.ProtocolTCP,
-			Port:       int32(*b.service.Port),
+			Name:        "tcp-rpc",
+			TargetPort:  intstr.FromString("rpc"),
+			Protocol:    corev1.ProtocolTCP,
+			AppProtocol: b.service.AppProtocols.GetRPC("tcp"),
+			Port:        int32(*b.service.Port),
 		},
 		{
-			Name:       "tcp-membership",
-			TargetPort: intstr.FromString("membership"),
-			Protocol:   corev1.ProtocolTCP,
-			Port:       int32(*b.service.MembershipPort),
+			Name:        "tcp-membership",
+			TargetPort:  intstr.FromString("membership"),
+			Protocol:    corev1.ProtocolTCP,
+			AppProtocol: b.service.AppProtocols.GetMembership(),
+			Port:        int32(*b.service.MembershipPort),
 		},
 	}
 
+	// Services with disabled metrics don't expose them.
+	if metricsPort := b.instance.ServiceMetricsPort(b.service); metricsPort != nil {
+		service.Spec.Ports = append([]corev1.ServicePort{
+			{
+				Name:        "http-metrics",
+				TargetPort:  prometheus.MetricsPortName,
+				Protocol:    corev1.ProtocolTCP,
+				AppProtocol: b.service.AppProtocols.GetMetrics(),
+				Port:        *metricsPort,
+			},
+		}, service.Spec.Ports...)
+	}
+
+	meta.SetServiceIPFamilies(service, b.instance.Spec.Network)
+
 	if err := controllerutil.SetControllerReference(b.instance, service, b.scheme); err != nil {
 		return fmt.Errorf("failed setting controller reference: %w", err)
 	}
@@ -0,0 +1,107 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package base_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/base"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+)
+
+func TestInternalFrontendServiceBuilder(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	tests := map[string]struct {
+		enabled                 bool
+		service                 *v1beta1.KubernetesServiceSpec
+		expectedEnabled         bool
+		expectedTrafficPolicy   corev1.ServiceInternalTrafficPolicy
+		expectedSessionAffinity corev1.ServiceAffinity
+	}{
+		"disabled": {
+			expectedEnabled:         false,
+			expectedTrafficPolicy:   corev1.ServiceInternalTrafficPolicyCluster,
+			expectedSessionAffinity: corev1.ServiceAffinityNone,
+		},
+		"defaults": {
+			enabled:                 true,
+			expectedEnabled:         true,
+			expectedTrafficPolicy:   corev1.ServiceInternalTrafficPolicyCluster,
+			expectedSessionAffinity: corev1.ServiceAffinityNone,
+		},
+		"traffic policies": {
+			enabled: true,
+			service: &v1beta1.KubernetesServiceSpec{
+				InternalTrafficPolicy: ptr.To(corev1.ServiceInternalTrafficPolicyLocal),
+				SessionAffinity:       corev1.ServiceAffinityClientIP,
+			},
+			expectedEnabled:         true,
+			expectedTrafficPolicy:   corev1.ServiceInternalTrafficPolicyLocal,
+			expectedSessionAffinity: corev1.ServiceAffinityClientIP,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			cluster := &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.23.0"),
+					Services: &v1beta1.ServicesSpec{
+						InternalFrontend: &v1beta1.InternalFrontendServiceSpec{
+							ServiceSpec: v1beta1.ServiceSpec{
+								Port:    ptr.To(7236),
+								Service: test.service,
+							},
+							Enabled: test.enabled,
+						},
+					},
+				},
+			}
+
+			builder := base.NewInternalFrontendServiceBuilder(cluster, scheme)
+			assert.Equal(tt, test.expectedEnabled, builder.Enabled())
+
+			object := builder.Build()
+			require.NoError(tt, builder.Update(object))
+
+			service := object.(*corev1.Service)
+			assert.Equal(tt, "prod-internal-frontend", service.Name)
+			assert.Equal(tt, corev1.ServiceTypeClusterIP, service.Spec.Type)
+			assert.Equal(tt, "internal-frontend", service.Spec.Selector["app.kubernetes.io/component"])
+			require.Len(tt, service.Spec.Ports, 1)
+			assert.Equal(tt, int32(7236), service.Spec.Ports[0].Port)
+			assert.Equal(tt, intstr.FromString("rpc"), service.Spec.Ports[0].TargetPort)
+			assert.Equal(tt, ptr.To("grpc"), service.Spec.Ports[0].AppProtocol)
+			assert.Equal(tt, &test.expectedTrafficPolicy, service.Spec.InternalTrafficPolicy)
+			assert.Equal(tt, test.expectedSessionAffinity, service.Spec.SessionAffinity)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package base
+
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	"go.temporal.io/server/common/primitives"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ resource.Builder = (*NetworkPolicyBuilder)(nil)
+
+type NetworkPolicyBuilder struct {
+	serviceName string
+	instance    *v1beta1.TemporalCluster
+	scheme      *runtime.Scheme
+	service     *v1beta1.ServiceSpec
+}
+
+func NewNetworkPolicyBuilder(serviceName string, instance *v1beta1.TemporalCluster, scheme *runtime.Scheme, service *v1beta1.ServiceSpec) *NetworkPolicyBuilder {
+	return &NetworkPolicyBuilder{
+		serviceName: serviceName,
+		instance:    instance,
+		scheme:      scheme,
+		service:     service,
+	}
+}
+
+func (b *NetworkPolicyBuilder) Build() client.Object {
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.instance.ChildResourceName(b.serviceName),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.GetLabels(b.instance, b.serviceName, b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *NetworkPolicyBuilder) Enabled() bool {
+	return b.instance.Spec.NetworkPolicies.IsEnabled() && isBuilderEnabled(b.instance, b.serviceName)
+}
+
+func (b *NetworkPolicyBuilder) Update(object client.Object) error {
+	policy := object.(*networkingv1.NetworkPolicy)
+	policy.Labels = metadata.Merge(
+		object.GetLabels(),
+		metadata.GetLabels(b.instance, b.serviceName, b.instance.Spec.Version, b.instance.Labels),
+	)
+	policy.Annotations = metadata.Merge(
+		object.GetAnnotations(),
+		metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+	)
+
+	spec := b.instance.Spec.NetworkPolicies
+
+	policy.Spec = networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{
+			MatchLabels: metadata.LabelsSelector(b.instance, b.serviceName),
+		},
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		Ingress: []networkingv1.NetworkPolicyIngressRule{
+			{
+				// Internode traffic is only allowed between temporal services.
+				From: []networkingv1.NetworkPolicyPeer{meta.TemporalServicesPeer(b.instance)},
+				Ports: []networkingv1.NetworkPolicyPort{
+					tcpPort(intstr.FromInt32(int32(*b.service.Port))),
+					tcpPort(intstr.FromInt32(int32(*b.service.MembershipPort))),
+				},
+			},
+		},
+	}
+
+	if b.serviceName == string(primitives.FrontendService) {
+		ports := []networkingv1.NetworkPolicyPort{
+			tcpPort(intstr.FromInt32(int32(*b.service.Port))),
+		}
+		if b.service.HTTPPort != nil {
+			ports = append(ports, tcpPort(intstr.FromInt32(int32(*b.service.HTTPPort))))
+		}
+
+		// A nil list of peers allows traffic from anywhere.
+		var from []networkingv1.NetworkPolicyPeer
+		if len(spec.FrontendIngressFrom) > 0 {
+			from = append([]networkingv1.NetworkPolicyPeer{
+				meta.ComponentPeer(b.instance, meta.ServiceUIName),
+				meta.ComponentPeer(b.instance, meta.ServiceAdminTools),
+			}, spec.FrontendIngressFrom...)
+		}
+
+		policy.Spec.Ingress = append(policy.Spec.Ingress, networkingv1.NetworkPolicyIngressRule{
+			From:  from,
+			Ports: ports,
+		})
+	}
+
+	if metricsPort := b.instance.ServiceMetricsPort(b.service); metricsPort != nil {
+		policy.Spec.Ingress = append(policy.Spec.Ingress, networkingv1.NetworkPolicyIngressRule{
+			From: spec.MetricsIngressFrom,
+			Ports: []networkingv1.NetworkPolicyPort{
+				tcpPort(intstr.FromInt32(*metricsPort)),
+			},
+		})
+	}
+
+	if len(spec.Egress) > 0 {
+		policy.Spec.PolicyTypes = append(policy.Spec.PolicyTypes, networkingv1.PolicyTypeEgress)
+		policy.Spec.Egress = append([]networkingv1.NetworkPolicyEgressRule{
+			{
+				// Allow DNS resolution.
+				Ports: []networkingv1.NetworkPolicyPort{
+					{Protocol: ptr.To(corev1.ProtocolUDP), Port: ptr.To(intstr.FromInt32(53))},
+					{Protocol: ptr.To(corev1.ProtocolTCP), Port: ptr.To(intstr.FromInt32(53))},
+				},
+			},
+			{
+				To: []networkingv1.NetworkPolicyPeer{meta.TemporalServicesPeer(b.instance)},
+			},
+		}, spec.Egress...)
+	}
+
+	if err := controllerutil.SetControllerReference(b.instance, policy, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+
+	return nil
+}
+
+func tcpPort(port intstr.IntOrString) networkingv1.NetworkPolicyPort {
+	return networkingv1.NetworkPolicyPort{
+		Protocol: ptr.To(corev1.ProtocolTCP),
+		Port:     &port,
+	}
+}
@@ -0,0 +1,111 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package base_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/base"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+)
+
+func TestNetworkPolicyBuilder(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	operatorNamespace := networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"kubernetes.io/metadata.name": "temporal-system"},
+		},
+	}
+	datastore := networkingv1.NetworkPolicyEgressRule{
+		Ports: []networkingv1.NetworkPolicyPort{{Port: ptr.To(intstr.FromInt32(5432))}},
+	}
+
+	cluster := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+		Spec: v1beta1.TemporalClusterSpec{
+			Version: version.MustNewVersionFromString("1.23.0"),
+			Services: &v1beta1.ServicesSpec{
+				Frontend: &v1beta1.ServiceSpec{
+					Port:           ptr.To(7233),
+					MembershipPort: ptr.To(6933),
+					HTTPPort:       ptr.To(7243),
+				},
+				History: &v1beta1.ServiceSpec{
+					Port:           ptr.To(7234),
+					MembershipPort: ptr.To(6934),
+				},
+			},
+			NetworkPolicies: &v1beta1.NetworkPoliciesSpec{
+				Enabled:             true,
+				FrontendIngressFrom: []networkingv1.NetworkPolicyPeer{operatorNamespace},
+				Egress:              []networkingv1.NetworkPolicyEgressRule{datastore},
+			},
+		},
+	}
+
+	t.Run("history", func(tt *testing.T) {
+		builder := base.NewNetworkPolicyBuilder("history", cluster, scheme, cluster.Spec.Services.History)
+		require.True(tt, builder.Enabled())
+
+		object := builder.Build()
+		require.NoError(tt, builder.Update(object))
+
+		policy := object.(*networkingv1.NetworkPolicy)
+		assert.Equal(tt, "prod-history", policy.Name)
+		assert.Equal(tt, "history", policy.Spec.PodSelector.MatchLabels["app.kubernetes.io/component"])
+		require.Len(tt, policy.Spec.Ingress, 1)
+		assert.Len(tt, policy.Spec.Ingress[0].Ports, 2)
+		assert.Equal(tt, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress}, policy.Spec.PolicyTypes)
+		require.Len(tt, policy.Spec.Egress, 3)
+		assert.Equal(tt, datastore, policy.Spec.Egress[2])
+	})
+
+	t.Run("frontend", func(tt *testing.T) {
+		builder := base.NewNetworkPolicyBuilder("frontend", cluster, scheme, cluster.Spec.Services.Frontend)
+		object := builder.Build()
+		require.NoError(tt, builder.Update(object))
+
+		policy := object.(*networkingv1.NetworkPolicy)
+		require.Len(tt, policy.Spec.Ingress, 2)
+		clients := policy.Spec.Ingress[1]
+		assert.Len(tt, clients.Ports, 2)
+		require.Len(tt, clients.From, 3)
+		assert.Equal(tt, "ui", clients.From[0].PodSelector.MatchLabels["app.kubernetes.io/component"])
+		assert.Equal(tt, "admintools", clients.From[1].PodSelector.MatchLabels["app.kubernetes.io/component"])
+		assert.Equal(tt, operatorNamespace, clients.From[2])
+	})
+
+	t.Run("disabled", func(tt *testing.T) {
+		disabled := cluster.DeepCopy()
+		disabled.Spec.NetworkPolicies.Enabled = false
+		builder := base.NewNetworkPolicyBuilder("history", disabled, scheme, disabled.Spec.Services.History)
+		assert.False(tt, builder.Enabled())
+	})
+}
@@ -41,13 +41,15 @@ type ServiceAccountBuilder struct {
 	serviceName string
 	instance    *v1beta1.TemporalCluster
 	scheme      *runtime.Scheme
+	spec        *v1beta1.ServiceAccountSpec
 }
 
-func NewServiceAccountBuilder(serviceName string, instance *v1beta1.TemporalCluster, scheme *runtime.Scheme) *ServiceAccountBuilder {
+func NewServiceAccountBuilder(serviceName string, instance *v1beta1.TemporalCluster, scheme *runtime.Scheme, spec *v1beta1.ServiceAccountSpec) *ServiceAccountBuilder {
 	return &ServiceAccountBuilder{
 		serviceName: serviceName,
 		instance:    instance,
 		scheme:      scheme,
+		spec:        spec,
 	}
 }
 
@@ -63,7 +65,7 @@ func (b *ServiceAccountBuilder) Build() client.Object {
 }
 
 func (b *ServiceAccountBuilder) Enabled() bool {
-	return isBuilderEnabled(b.instance, b.serviceName)
+	return isBuilderEnabled(b.instance, b.serviceName) && !b.spec.IsExisting()
 }
 
 func (b *ServiceAccountBuilder) getIAMAnnotations() map[string]string {
@@ -73,6 +75,11 @@ func (b *ServiceAccountBuilder) getIAMAnnotations() map[string]string {
 		b.instance.Spec.Archival.Provider.S3.RoleName != nil {
 		annotations[awsRoleArnAnnotation] = *b.instance.Spec.Archival.Provider.S3.RoleName
 	}
+	if b.instance.Spec.Archival.IsEnabled() &&
+		b.instance.Spec.Archival.Provider.GCS != nil &&
+		b.instance.Spec.Archival.Provider.GCS.ServiceAccount != nil {
+		annotations[gcpServiceAccountAnnotation] = *b.instance.Spec.Archival.Provider.GCS.ServiceAccount
+	}
 	if b.instance.Spec.Persistence.DefaultStore.SQL != nil &&
 		b.instance.Spec.Persistence.DefaultStore.SQL.GCPServiceAccount != nil {
 		annotations[gcpServiceAccountAnnotation] = *b.instance.Spec.Persistence.DefaultStore.SQL.GCPServiceAccount
@@ -101,6 +108,7 @@ func (b *ServiceAccountBuilder) Update(object client.Object) error {
 	sa.Annotations = metadata.Merge(
 		sa.Annotations,
 		b.getIAMAnnotations(),
+		b.spec.GetAnnotations(),
 	)
 
 	if err := controllerutil.SetControllerReference(b.instance, sa, b.scheme); err != nil {
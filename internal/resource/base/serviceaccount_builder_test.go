@@ -0,0 +1,118 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package base_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/base"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+)
+
+func TestServiceAccountBuilder(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	tests := map[string]struct {
+		spec                *v1beta1.ServiceAccountSpec
+		archival            *v1beta1.ClusterArchivalSpec
+		gcpServiceAccount   *string
+		expectedEnabled     bool
+		expectedAnnotations map[string]string
+	}{
+		"defaults": {
+			expectedEnabled: true,
+		},
+		"existing service account": {
+			spec:            &v1beta1.ServiceAccountSpec{Name: "history"},
+			expectedEnabled: false,
+		},
+		"user annotations": {
+			spec: &v1beta1.ServiceAccountSpec{
+				Annotations: map[string]string{"example.com/team": "platform"},
+			},
+			expectedEnabled:     true,
+			expectedAnnotations: map[string]string{"example.com/team": "platform"},
+		},
+		"s3 archival role": {
+			archival: &v1beta1.ClusterArchivalSpec{
+				Enabled: true,
+				Provider: &v1beta1.ArchivalProvider{
+					S3: &v1beta1.S3Archiver{RoleName: ptr.To("arn:aws:iam::123456789012:role/temporal")},
+				},
+			},
+			expectedEnabled:     true,
+			expectedAnnotations: map[string]string{"eks.amazonaws.com/role-arn": "arn:aws:iam::123456789012:role/temporal"},
+		},
+		"cloudsql service account": {
+			gcpServiceAccount:   ptr.To("temporal@project.iam.gserviceaccount.com"),
+			expectedEnabled:     true,
+			expectedAnnotations: map[string]string{"iam.gke.io/gcp-service-account": "temporal@project.iam.gserviceaccount.com"},
+		},
+		"user annotations take precedence": {
+			spec: &v1beta1.ServiceAccountSpec{
+				Annotations: map[string]string{"iam.gke.io/gcp-service-account": "custom@project.iam.gserviceaccount.com"},
+			},
+			gcpServiceAccount:   ptr.To("temporal@project.iam.gserviceaccount.com"),
+			expectedEnabled:     true,
+			expectedAnnotations: map[string]string{"iam.gke.io/gcp-service-account": "custom@project.iam.gserviceaccount.com"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			cluster := &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version:  version.MustNewVersionFromString("1.23.0"),
+					Services: &v1beta1.ServicesSpec{},
+					Archival: test.archival,
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore: &v1beta1.DatastoreSpec{
+							SQL: &v1beta1.SQLSpec{PluginName: "postgres12", GCPServiceAccount: test.gcpServiceAccount},
+						},
+						VisibilityStore: &v1beta1.DatastoreSpec{
+							SQL: &v1beta1.SQLSpec{PluginName: "postgres12"},
+						},
+					},
+				},
+			}
+
+			builder := base.NewServiceAccountBuilder("history", cluster, scheme, test.spec)
+			assert.Equal(tt, test.expectedEnabled, builder.Enabled())
+
+			object := builder.Build()
+			require.NoError(tt, builder.Update(object))
+
+			sa := object.(*corev1.ServiceAccount)
+			assert.Equal(tt, "prod-history", sa.Name)
+			for key, value := range test.expectedAnnotations {
+				assert.Equal(tt, value, sa.Annotations[key])
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package base
+
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/pkg/kubernetes"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ resource.Builder = (*StatefulSetBuilder)(nil)
+
+// StatefulSetBuilder builds the StatefulSet of services running with the StatefulSet workload type.
+// It shares the pod template of the DeploymentBuilder.
+type StatefulSetBuilder struct {
+	*DeploymentBuilder
+}
+
+func NewStatefulSetBuilder(serviceName string, instance *v1beta1.TemporalCluster, scheme *runtime.Scheme, service *v1beta1.ServiceSpec, configHash, certificatesHash string) *StatefulSetBuilder {
+	return &StatefulSetBuilder{
+		DeploymentBuilder: NewDeploymentBuilder(serviceName, instance, scheme, service, configHash, certificatesHash),
+	}
+}
+
+func (b *StatefulSetBuilder) Build() client.Object {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.instance.ChildResourceName(b.serviceName),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.GetLabels(b.instance, b.serviceName, b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *StatefulSetBuilder) Enabled() bool {
+	return isBuilderEnabled(b.instance, b.serviceName) && b.service.IsStatefulSet()
+}
+
+func (b *StatefulSetBuilder) Update(object client.Object) error {
+	statefulSet := object.(*appsv1.StatefulSet)
+	statefulSet.Labels = metadata.Merge(
+		object.GetLabels(),
+		metadata.GetLabels(b.instance, b.serviceName, b.instance.Spec.Version, b.instance.Labels),
+	)
+	statefulSet.Annotations = metadata.Merge(
+		object.GetAnnotations(),
+		metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+	)
+
+	statefulSet.Spec.Replicas = b.service.Replicas
+	statefulSet.Spec.ServiceName = b.instance.ChildResourceName(fmt.Sprintf("%s-headless", b.serviceName))
+	// Pods are still replaced one by one during rolling updates, parallel management
+	// only avoids blocking scale operations on a single unready pod.
+	statefulSet.Spec.PodManagementPolicy = appsv1.ParallelPodManagement
+
+	statefulSet.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: metadata.LabelsSelector(b.instance, b.serviceName),
+	}
+
+	statefulSet.Spec.Template = b.buildPodTemplateSpec()
+
+	if b.instance.Spec.Services.Overrides != nil && b.instance.Spec.Services.Overrides.Deployment != nil {
+		err := kubernetes.ApplyStatefulSetOverrides(statefulSet, b.instance.Spec.Services.Overrides.Deployment)
+		if err != nil {
+			return fmt.Errorf("can't apply statefulset overrides: %w", err)
+		}
+	}
+
+	if b.service.Overrides != nil && b.service.Overrides.Deployment != nil {
+		err := kubernetes.ApplyStatefulSetOverrides(statefulSet, b.service.Overrides.Deployment)
+		if err != nil {
+			return fmt.Errorf("failed applying statefulset overrides: %w", err)
+		}
+	}
+
+	if err := controllerutil.SetControllerReference(b.instance, statefulSet, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+
+	return nil
+}
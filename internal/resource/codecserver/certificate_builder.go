@@ -0,0 +1,72 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package codecserver
+
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CertificateName is the name of the volume holding the codec server certificate.
+const CertificateName = "codec-server-certificate"
+
+type CertificateBuilder struct {
+	instance *v1beta1.TemporalCluster
+
+	*certmanager.GenericFrontendClientCertificateBuilder
+}
+
+func NewCertificateBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme) *CertificateBuilder {
+	return &CertificateBuilder{
+		instance:                                instance,
+		GenericFrontendClientCertificateBuilder: certmanager.NewGenericFrontendClientCertificateBuilder(instance, scheme, meta.ServiceCodecServer),
+	}
+}
+
+func (b *CertificateBuilder) Enabled() bool {
+	return b.instance.CodecServer().IsEnabled() &&
+		b.instance.CodecServer().MTLS &&
+		b.instance.MTLSWithCertManagerEnabled() &&
+		b.instance.Spec.MTLS.FrontendEnabled()
+}
+
+func (b *CertificateBuilder) Update(object client.Object) error {
+	err := b.GenericFrontendClientCertificateBuilder.Update(object)
+	if err != nil {
+		return err
+	}
+
+	serviceName := b.instance.ChildResourceName(meta.ServiceCodecServer)
+
+	certificate := object.(*certmanagerv1.Certificate)
+	certificate.Spec.CommonName = "codec server certificate"
+	certificate.Spec.DNSNames = append(certificate.Spec.DNSNames,
+		serviceName,
+		fmt.Sprintf("%s.%s", serviceName, b.instance.Namespace),
+		fmt.Sprintf("%s.%s.svc", serviceName, b.instance.Namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", serviceName, b.instance.Namespace),
+	)
+
+	return nil
+}
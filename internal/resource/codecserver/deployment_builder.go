@@ -0,0 +1,147 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package codecserver
+
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ resource.Builder = (*DeploymentBuilder)(nil)
+
+type DeploymentBuilder struct {
+	instance *v1beta1.TemporalCluster
+	scheme   *runtime.Scheme
+}
+
+func NewDeploymentBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme) *DeploymentBuilder {
+	return &DeploymentBuilder{
+		instance: instance,
+		scheme:   scheme,
+	}
+}
+
+func (b *DeploymentBuilder) Build() client.Object {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.instance.ChildResourceName(meta.ServiceCodecServer),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.GetLabels(b.instance, meta.ServiceCodecServer, b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *DeploymentBuilder) Enabled() bool {
+	return b.instance.CodecServer().IsEnabled()
+}
+
+func (b *DeploymentBuilder) Update(object client.Object) error {
+	spec := b.instance.CodecServer()
+
+	deployment := object.(*appsv1.Deployment)
+	deployment.Labels = metadata.Merge(
+		object.GetLabels(),
+		metadata.GetLabels(b.instance, meta.ServiceCodecServer, b.instance.Spec.Version, b.instance.Labels),
+	)
+	deployment.Annotations = metadata.Merge(
+		object.GetAnnotations(),
+		metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+	)
+
+	volumes := []corev1.Volume{}
+	volumeMounts := []corev1.VolumeMount{}
+
+	if spec.MTLS {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      CertificateName,
+			MountPath: spec.GetCertificateMountPath(),
+		})
+
+		volumes = append(volumes, corev1.Volume{
+			Name: CertificateName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  b.instance.ChildResourceName(certmanager.GetCertificateSecretName(meta.ServiceCodecServer)),
+					DefaultMode: ptr.To[int32](corev1.SecretVolumeSourceDefaultMode),
+				},
+			},
+		})
+	}
+
+	deployment.Spec.Replicas = spec.Replicas
+	if deployment.Spec.Replicas == nil {
+		deployment.Spec.Replicas = ptr.To[int32](1)
+	}
+
+	deployment.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: metadata.LabelsSelector(b.instance, meta.ServiceCodecServer),
+	}
+	deployment.Spec.Template = corev1.PodTemplateSpec{
+		ObjectMeta: meta.BuildPodObjectMeta(b.instance, meta.ServiceCodecServer, "", ""),
+		Spec: corev1.PodSpec{
+			ImagePullSecrets: b.instance.Spec.ImagePullSecrets,
+			Containers: []corev1.Container{
+				{
+					Name:                     meta.ServiceCodecServer,
+					Image:                    spec.Image,
+					ImagePullPolicy:          meta.ImagePullPolicyOrDefault(spec.ImagePullPolicy),
+					Args:                     spec.Args,
+					Resources:                spec.Resources,
+					TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+					TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+					SecurityContext:          meta.DefaultContainerSecurityContext(),
+					Ports: []corev1.ContainerPort{
+						{
+							Name:          "http",
+							ContainerPort: spec.GetPort(),
+							Protocol:      corev1.ProtocolTCP,
+						},
+					},
+					Env:          spec.Env,
+					EnvFrom:      spec.EnvFrom,
+					VolumeMounts: volumeMounts,
+				},
+			},
+			Volumes:                       volumes,
+			RestartPolicy:                 corev1.RestartPolicyAlways,
+			TerminationGracePeriodSeconds: ptr.To[int64](30),
+			DNSPolicy:                     corev1.DNSClusterFirst,
+			SchedulerName:                 corev1.DefaultSchedulerName,
+			SecurityContext:               meta.DefaultPodSecurityContext(5000, false),
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(b.instance, deployment, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+
+	return nil
+}
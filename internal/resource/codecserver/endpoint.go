@@ -0,0 +1,56 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package codecserver
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+)
+
+// Endpoint returns the codec server URL the UI should use.
+// It returns the user provided endpoint if any, then the first ingress host,
+// and finally falls back to the codec server service URL.
+func Endpoint(instance *v1beta1.TemporalCluster) string {
+	spec := instance.CodecServer()
+	if !spec.IsEnabled() {
+		return ""
+	}
+
+	if spec.Endpoint != "" {
+		return spec.Endpoint
+	}
+
+	if spec.Ingress != nil && len(spec.Ingress.Hosts) > 0 {
+		scheme := "http"
+		if len(meta.IngressTLS(spec.Ingress, nil, "")) > 0 {
+			scheme = "https"
+		}
+		host := strings.SplitN(spec.Ingress.Hosts[0], "/", 2)[0]
+		return fmt.Sprintf("%s://%s%s", scheme, host, strings.TrimSuffix(spec.Ingress.GetPath(), "/"))
+	}
+
+	scheme := "http"
+	if spec.MTLS {
+		scheme = "https"
+	}
+
+	return fmt.Sprintf("%s://%s.%s.svc:%d", scheme, instance.ChildResourceName(meta.ServiceCodecServer), instance.Namespace, spec.GetPort())
+}
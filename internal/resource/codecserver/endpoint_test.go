@@ -0,0 +1,91 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package codecserver_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/codecserver"
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEndpoint(t *testing.T) {
+	tests := map[string]struct {
+		codecServer *v1beta1.CodecServerSpec
+		expected    string
+	}{
+		"disabled": {
+			codecServer: &v1beta1.CodecServerSpec{Enabled: false},
+			expected:    "",
+		},
+		"service url": {
+			codecServer: &v1beta1.CodecServerSpec{Enabled: true},
+			expected:    "http://prod-codec-server.demo.svc:8888",
+		},
+		"service url with mTLS": {
+			codecServer: &v1beta1.CodecServerSpec{Enabled: true, MTLS: true},
+			expected:    "https://prod-codec-server.demo.svc:8888",
+		},
+		"ingress host": {
+			codecServer: &v1beta1.CodecServerSpec{
+				Enabled: true,
+				Ingress: &v1beta1.TemporalUIIngressSpec{
+					Hosts: []string{"codec.example.com"},
+					TLS:   []networkingv1.IngressTLS{{Hosts: []string{"codec.example.com"}}},
+				},
+			},
+			expected: "https://codec.example.com",
+		},
+		"ingress host with path and cert-manager": {
+			codecServer: &v1beta1.CodecServerSpec{
+				Enabled: true,
+				Ingress: &v1beta1.TemporalUIIngressSpec{
+					Hosts:                []string{"example.com"},
+					Path:                 "/codec/",
+					CertManagerIssuerRef: &v1beta1.CertManagerIssuerReference{Name: "letsencrypt", Kind: "ClusterIssuer"},
+				},
+			},
+			expected: "https://example.com/codec",
+		},
+		"custom endpoint": {
+			codecServer: &v1beta1.CodecServerSpec{
+				Enabled:  true,
+				Endpoint: "https://codec.internal.example.com",
+				Ingress: &v1beta1.TemporalUIIngressSpec{
+					Hosts: []string{"codec.example.com"},
+				},
+			},
+			expected: "https://codec.internal.example.com",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			cluster := &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+				Spec: v1beta1.TemporalClusterSpec{
+					UI: &v1beta1.TemporalUISpec{CodecServer: test.codecServer},
+				},
+			}
+			assert.Equal(tt, test.expected, codecserver.Endpoint(cluster))
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package codecserver
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+type IngressBuilder struct {
+	instance *v1beta1.TemporalCluster
+	scheme   *runtime.Scheme
+}
+
+func NewIngressBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme) *IngressBuilder {
+	return &IngressBuilder{
+		instance: instance,
+		scheme:   scheme,
+	}
+}
+
+func (b *IngressBuilder) Build() client.Object {
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.instance.ChildResourceName(meta.ServiceCodecServer),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.GetLabels(b.instance, meta.ServiceCodecServer, b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *IngressBuilder) Enabled() bool {
+	return b.instance.CodecServer().IsEnabled() &&
+		b.instance.CodecServer().Ingress != nil
+}
+
+func (b *IngressBuilder) Update(object client.Object) error {
+	spec := b.instance.CodecServer()
+
+	ingress := object.(*networkingv1.Ingress)
+	ingress.Labels = object.GetLabels()
+	ingress.Annotations = metadata.Merge(
+		object.GetAnnotations(),
+		meta.IngressCertManagerAnnotations(spec.Ingress.CertManagerIssuerRef),
+		spec.Ingress.Annotations,
+	)
+
+	rules := make([]networkingv1.IngressRule, 0, len(spec.Ingress.Hosts))
+	hosts := make([]string, 0, len(spec.Ingress.Hosts))
+
+	for _, h := range spec.Ingress.Hosts {
+		host := strings.SplitN(h, "/", 2)[0]
+		hosts = append(hosts, host)
+		pathType := networkingv1.PathTypePrefix
+		rules = append(rules, networkingv1.IngressRule{
+			Host: host,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{
+						{
+							Path:     spec.Ingress.GetPath(),
+							PathType: &pathType,
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{
+									Name: b.instance.ChildResourceName(meta.ServiceCodecServer),
+									Port: networkingv1.ServiceBackendPort{
+										Number: spec.GetPort(),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	ingress.Spec = networkingv1.IngressSpec{
+		IngressClassName: spec.Ingress.IngressClassName,
+		Rules:            rules,
+		TLS:              meta.IngressTLS(spec.Ingress, hosts, b.instance.ChildResourceName(meta.ServiceCodecServer+"-tls")),
+	}
+
+	if err := controllerutil.SetControllerReference(b.instance, ingress, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+	return nil
+}
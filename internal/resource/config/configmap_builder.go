@@ -29,17 +29,19 @@ import (
 	"github.com/alexandrevilain/temporal-operator/internal/metadata"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/prometheus"
 	archivalutil "github.com/alexandrevilain/temporal-operator/pkg/temporal/archival"
 	"github.com/alexandrevilain/temporal-operator/pkg/temporal/authorization"
+	configutil "github.com/alexandrevilain/temporal-operator/pkg/temporal/config"
 	"github.com/alexandrevilain/temporal-operator/pkg/temporal/log"
 	"github.com/alexandrevilain/temporal-operator/pkg/temporal/persistence"
 	"github.com/alexandrevilain/temporal-operator/pkg/version"
 	"go.temporal.io/server/common/cluster"
 	"go.temporal.io/server/common/config"
 	"go.temporal.io/server/common/dynamicconfig"
+	tlog "go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/metrics"
 	"go.temporal.io/server/common/primitives"
-	"gopkg.in/yaml.v3"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -81,10 +83,13 @@ func (b *ConfigmapBuilder) buildDatastoreConfig(store *v1beta1.DatastoreSpec) (*
 	switch store.GetType() {
 	case v1beta1.PostgresSQLDatastore,
 		v1beta1.PostgresSQL12Datastore,
+		v1beta1.CockroachDBDatastore,
 		v1beta1.MySQLDatastore,
 		v1beta1.MySQL8Datastore:
 		cfg.SQL = persistence.NewSQLConfigFromDatastoreSpec(store)
 		cfg.SQL.Password = fmt.Sprintf("{{ .Env.%s }}", store.GetPasswordEnvVarName())
+	case v1beta1.SQLiteDatastore:
+		cfg.SQL = persistence.NewSQLConfigFromDatastoreSpec(store)
 	case v1beta1.CassandraDatastore:
 		cfg.Cassandra = persistence.NewCassandraConfigFromDatastoreSpec(store)
 		cfg.Cassandra.Password = fmt.Sprintf("{{ .Env.%s }}", store.GetPasswordEnvVarName())
@@ -218,31 +223,19 @@ func (b *ConfigmapBuilder) Update(object client.Object) error {
 			Authorization: authorization.ToTemporalAuthorization(b.instance.Spec.Authorization),
 		},
 		Persistence: *persistenceConfig,
-		Log:         log.NewSQLConfigFromDatastoreSpec(b.instance.Spec.Log),
+		Log:         b.logConfig(),
 		Archival:    *archivalConfig,
 		NamespaceDefaults: config.NamespaceDefaults{
 			Archival: *archivalNamespaceDefaults,
 		},
-		ClusterMetadata: &cluster.Config{
-			EnableGlobalNamespace:    false,
-			FailoverVersionIncrement: 10,
-			MasterClusterName:        b.instance.Name,
-			CurrentClusterName:       b.instance.Name,
-			ClusterInformation: map[string]cluster.ClusterInformation{
-				b.instance.Name: {
-					Enabled:                true,
-					InitialFailoverVersion: 1,
-					RPCAddress:             "127.0.0.1:7233",
-				},
-			},
-		},
+		ClusterMetadata: b.clusterMetadata(),
 		Services: map[string]config.Service{
 			string(primitives.FrontendService): {
 				RPC: config.RPC{
 					GRPCPort:        *b.instance.Spec.Services.Frontend.Port,
 					MembershipPort:  *b.instance.Spec.Services.Frontend.MembershipPort,
 					BindOnLocalHost: false,
-					BindOnIP:        "0.0.0.0",
+					BindOnIP:        b.instance.BindAddress(),
 				},
 			},
 			string(primitives.HistoryService): {
@@ -250,7 +243,7 @@ func (b *ConfigmapBuilder) Update(object client.Object) error {
 					GRPCPort:        *b.instance.Spec.Services.History.Port,
 					MembershipPort:  *b.instance.Spec.Services.History.MembershipPort,
 					BindOnLocalHost: false,
-					BindOnIP:        "0.0.0.0",
+					BindOnIP:        b.instance.BindAddress(),
 				},
 			},
 			string(primitives.MatchingService): {
@@ -258,7 +251,7 @@ func (b *ConfigmapBuilder) Update(object client.Object) error {
 					GRPCPort:        *b.instance.Spec.Services.Matching.Port,
 					MembershipPort:  *b.instance.Spec.Services.Matching.MembershipPort,
 					BindOnLocalHost: false,
-					BindOnIP:        "0.0.0.0",
+					BindOnIP:        b.instance.BindAddress(),
 				},
 			},
 			string(primitives.WorkerService): {
@@ -266,7 +259,7 @@ func (b *ConfigmapBuilder) Update(object client.Object) error {
 					GRPCPort:        *b.instance.Spec.Services.Worker.Port,
 					MembershipPort:  *b.instance.Spec.Services.Worker.MembershipPort,
 					BindOnLocalHost: false,
-					BindOnIP:        "0.0.0.0",
+					BindOnIP:        b.instance.BindAddress(),
 				},
 			},
 		},
@@ -280,18 +273,28 @@ func (b *ConfigmapBuilder) Update(object client.Object) error {
 					MembershipPort:  *b.instance.Spec.Services.InternalFrontend.MembershipPort,
 					HTTPPort:        *b.instance.Spec.Services.InternalFrontend.HTTPPort,
 					BindOnLocalHost: false,
-					BindOnIP:        "0.0.0.0",
+					BindOnIP:        b.instance.BindAddress(),
 				},
 			}
 		}
 	}
 
-	if !b.instance.Spec.Version.GreaterOrEqual(version.V1_18_0) {
+	if b.instance.Spec.PublicClient != nil {
+		temporalCfg.PublicClient = config.PublicClient{
+			HostPort: b.instance.Spec.PublicClient.HostPort,
+		}
+	} else if !b.instance.Spec.Version.GreaterOrEqual(version.V1_18_0) {
 		temporalCfg.PublicClient = config.PublicClient{
 			HostPort: b.instance.GetPublicClientAddress(),
 		}
 	}
 
+	if b.instance.Spec.DCRedirectionPolicy != "" {
+		temporalCfg.DCRedirectionPolicy = config.DCRedirectionPolicy{
+			Policy: string(b.instance.Spec.DCRedirectionPolicy),
+		}
+	}
+
 	// Temporal >= 1.22 provides HTTP endpoint for the frontend
 	if b.instance.Spec.Version.GreaterOrEqual(version.V1_22_0) &&
 		b.instance.Spec.Services.Frontend.HTTPPort != nil {
@@ -304,7 +307,7 @@ func (b *ConfigmapBuilder) Update(object client.Object) error {
 
 	if b.instance.Spec.DynamicConfig != nil {
 		temporalCfg.DynamicConfigClient = &dynamicconfig.FileBasedClientConfig{
-			Filepath:     "/etc/temporal/config/dynamic_config.yaml",
+			Filepath:     b.instance.Spec.DynamicConfig.GetFilePath(),
 			PollInterval: b.instance.Spec.DynamicConfig.PollInterval.Duration,
 		}
 	}
@@ -312,7 +315,10 @@ func (b *ConfigmapBuilder) Update(object client.Object) error {
 	if b.instance.Spec.Metrics.IsEnabled() {
 		temporalCfg.Global.Metrics = &metrics.Config{
 			ClientConfig: metrics.ClientConfig{
-				Tags: map[string]string{"type": "{{ .Env.SERVICES }}"},
+				Tags: metadata.Merge(
+					b.instance.Spec.Metrics.Tags,
+					map[string]string{"type": "{{ .Env.SERVICES }}"},
+				),
 			},
 		}
 
@@ -342,15 +348,31 @@ func (b *ConfigmapBuilder) Update(object client.Object) error {
 			temporalCfg.Global.Metrics.ClientConfig.PerUnitHistogramBoundaries = buckets
 		}
 
+		if statsd := b.instance.Spec.Metrics.Statsd; statsd != nil {
+			temporalCfg.Global.Metrics.Statsd = &metrics.StatsdConfig{
+				HostPort: statsd.Address,
+				Prefix:   statsd.Prefix,
+				Reporter: metrics.StatsdReporterConfig{
+					TagSeparator: statsd.TagSeparator,
+				},
+			}
+			if statsd.FlushInterval != nil {
+				temporalCfg.Global.Metrics.Statsd.FlushInterval = statsd.FlushInterval.Duration
+			}
+			if statsd.FlushBytes != nil {
+				temporalCfg.Global.Metrics.Statsd.FlushBytes = *statsd.FlushBytes
+			}
+		}
+
 		if b.instance.Spec.Metrics.Prometheus != nil && b.instance.Spec.Metrics.Prometheus.ListenPort != nil {
 			temporalCfg.Global.Metrics.Prometheus = &metrics.PrometheusConfig{
 				TimerType:     "histogram",
-				ListenAddress: fmt.Sprintf("0.0.0.0:%d", *b.instance.Spec.Metrics.Prometheus.ListenPort),
+				ListenAddress: prometheus.ListenAddress(b.instance),
 			}
 		}
 	}
 
-	if b.instance.MTLSWithCertManagerEnabled() {
+	if b.instance.MTLSWithCertificatesEnabled() || b.instance.MTLSWithSPIFFEEnabled() {
 		temporalCfg.Global.TLS = config.RootTLS{
 			RefreshInterval:  b.instance.Spec.MTLS.RefreshInterval.Duration,
 			ExpirationChecks: config.CertExpirationValidation{},
@@ -366,7 +388,7 @@ func (b *ConfigmapBuilder) Update(object client.Object) error {
 		internodeServerKeyFilePath := path.Join(internodeMTLS.GetCertificateMountPath(), certmanager.TLSKey)
 		internodeClientTLS := config.ClientTLS{
 			ServerName:              internodeMTLS.ServerName(b.instance),
-			DisableHostVerification: false,
+			DisableHostVerification: !b.instance.MTLSWithHostVerification(),
 			RootCAFiles:             []string{internodeIntermediateCAFilePath},
 			ForceTLS:                true,
 		}
@@ -411,13 +433,34 @@ func (b *ConfigmapBuilder) Update(object client.Object) error {
 				},
 				Client: config.ClientTLS{
 					ServerName:              frontendMTLS.ServerName(b.instance),
-					DisableHostVerification: false,
+					DisableHostVerification: !b.instance.MTLSWithHostVerification(),
 					RootCAFiles:             []string{frontendIntermediateCAFilePath},
 					ForceTLS:                true,
 				},
 				PerHostOverrides: map[string]config.ServerTLS{},
 			}
 
+			// Accept the certificates of the remote clusters' services.
+			if b.instance.RemoteClustersTrustBundleEnabled() {
+				temporalCfg.Global.TLS.Frontend.Server.ClientCAFiles = append(temporalCfg.Global.TLS.Frontend.Server.ClientCAFiles,
+					path.Join(b.instance.Spec.Replication.GetTrustBundleMountPath(), certmanager.TLSCA),
+				)
+			}
+
+			// Serve the external certificate to clients requesting one of the external hostnames.
+			if external := frontendMTLS.External; external != nil {
+				externalServerTLS := temporalCfg.Global.TLS.Frontend.Server
+				externalServerTLS.CertFile = path.Join(external.GetCertificateMountPath(), certmanager.TLSCert)
+				externalServerTLS.KeyFile = path.Join(external.GetCertificateMountPath(), certmanager.TLSKey)
+				if external.ClientCASecretRef != nil {
+					externalServerTLS.ClientCAFiles = []string{path.Join(external.GetClientCACertificateMountPath(), certmanager.TLSCA)}
+				}
+
+				for _, dnsName := range external.DNSNames {
+					temporalCfg.Global.TLS.Frontend.PerHostOverrides[dnsName] = externalServerTLS
+				}
+			}
+
 			// If the Frontend mTLS is enabled, and if the internal frontend with internode mTLS is not enabled, the system worker should use the Frontend mTLS.
 			if !(b.instance.Spec.MTLS.InternodeEnabled() && b.instance.Spec.Services.InternalFrontend.IsEnabled()) {
 				temporalCfg.Global.TLS.SystemWorker = config.WorkerTLS{
@@ -425,7 +468,7 @@ func (b *ConfigmapBuilder) Update(object client.Object) error {
 					KeyFile:  path.Join(frontendMTLS.GetWorkerCertificateMountPath(), certmanager.TLSKey),
 					Client: config.ClientTLS{
 						ServerName:              frontendMTLS.ServerName(b.instance),
-						DisableHostVerification: false,
+						DisableHostVerification: !b.instance.MTLSWithHostVerification(),
 						RootCAFiles:             []string{frontendIntermediateCAFilePath},
 						ForceTLS:                true,
 					},
@@ -434,13 +477,41 @@ func (b *ConfigmapBuilder) Update(object client.Object) error {
 		}
 	}
 
-	result, err := yaml.Marshal(temporalCfg)
+	if b.instance.ReplicationEnabled() {
+		if remoteClusters := b.remoteClustersTLS(); len(remoteClusters) > 0 {
+			temporalCfg.Global.TLS.RemoteClusters = remoteClusters
+		}
+	}
+
+	result, err := marshalConfig(&temporalCfg, b.instance.Spec.Telemetry)
 	if err != nil {
 		return fmt.Errorf("failed marshaling temporal config: %w", err)
 	}
 
 	configMap.Data = map[string]string{
-		"config_template.yaml": string(result),
+		meta.ConfigTemplateKey: string(result),
+	}
+
+	for _, service := range []primitives.ServiceName{
+		primitives.FrontendService,
+		primitives.HistoryService,
+		primitives.MatchingService,
+		primitives.WorkerService,
+		primitives.InternalFrontendService,
+	} {
+		spec, err := b.instance.Spec.Services.GetServiceSpec(service)
+		if err != nil {
+			return err
+		}
+		if spec == nil || spec.ConfigOverlay == nil {
+			continue
+		}
+
+		serviceResult, err := configutil.ApplyOverlay(result, spec.ConfigOverlay.Raw)
+		if err != nil {
+			return fmt.Errorf("failed applying %s config overlay: %w", service, err)
+		}
+		configMap.Data[meta.ServiceConfigTemplateKey(string(service))] = string(serviceResult)
 	}
 
 	if err := controllerutil.SetControllerReference(b.instance, configMap, b.scheme); err != nil {
@@ -449,3 +520,81 @@ func (b *ConfigmapBuilder) Update(object client.Object) error {
 
 	return nil
 }
+
+// clusterMetadata returns the temporal cluster metadata of the cluster.
+// Remote clusters are registered at runtime by the operator, only the current cluster is configured.
+func (b *ConfigmapBuilder) clusterMetadata() *cluster.Config {
+	spec := b.instance.GetClusterMetadata()
+
+	rpcAddress := "127.0.0.1:7233"
+	if b.instance.ReplicationEnabled() {
+		rpcAddress = b.instance.GetPublicClientAddress()
+	}
+
+	return &cluster.Config{
+		EnableGlobalNamespace:    spec.EnableGlobalNamespace,
+		FailoverVersionIncrement: spec.FailoverVersionIncrement,
+		MasterClusterName:        spec.ClusterName,
+		CurrentClusterName:       spec.ClusterName,
+		ClusterInformation: map[string]cluster.ClusterInformation{
+			spec.ClusterName: {
+				Enabled:                true,
+				InitialFailoverVersion: spec.InitialFailoverVersion,
+				RPCAddress:             rpcAddress,
+			},
+		},
+	}
+}
+
+// remoteClustersTLS returns the TLS configuration of the connections to the remote clusters, keyed by their host.
+func (b *ConfigmapBuilder) remoteClustersTLS() map[string]config.GroupTLS {
+	remoteClusters := map[string]config.GroupTLS{}
+	for _, remote := range b.instance.Spec.Replication.RemoteClusters {
+		if remote.UsesTrustBundle() && b.instance.RemoteClustersTrustBundleEnabled() {
+			// Verify the remote cluster using the trust bundle, and authenticate using the frontend certificate.
+			frontendMTLS := b.instance.Spec.MTLS.Frontend
+			remoteClusters[remote.Host(b.instance.Namespace)] = config.GroupTLS{
+				Client: config.ClientTLS{
+					ServerName:              remote.ServerName(b.instance.Namespace),
+					DisableHostVerification: !b.instance.MTLSWithHostVerification(),
+					RootCAFiles:             []string{path.Join(b.instance.Spec.Replication.GetTrustBundleMountPath(), certmanager.TLSCA)},
+					ForceTLS:                true,
+				},
+				Server: config.ServerTLS{
+					CertFile:          path.Join(frontendMTLS.GetCertificateMountPath(), certmanager.TLSCert),
+					KeyFile:           path.Join(frontendMTLS.GetCertificateMountPath(), certmanager.TLSKey),
+					RequireClientAuth: true,
+				},
+			}
+			continue
+		}
+
+		if remote.TLS == nil {
+			continue
+		}
+		mountPath := remote.TLS.GetCertificateMountPath()
+		remoteClusters[remote.Host(b.instance.Namespace)] = config.GroupTLS{
+			Client: config.ClientTLS{
+				ServerName:  remote.TLS.ServerName,
+				RootCAFiles: []string{path.Join(mountPath, certmanager.TLSCA)},
+				ForceTLS:    true,
+			},
+			Server: config.ServerTLS{
+				CertFile:          path.Join(mountPath, certmanager.TLSCert),
+				KeyFile:           path.Join(mountPath, certmanager.TLSKey),
+				RequireClientAuth: true,
+			},
+		}
+	}
+	return remoteClusters
+}
+
+// logConfig returns the temporal log config of the cluster.
+// When services override it, the log level and format are read from environment variables set per service.
+func (b *ConfigmapBuilder) logConfig() tlog.Config {
+	cfg := log.NewSQLConfigFromDatastoreSpec(b.instance.Spec.Log)
+	if b.instance.Spec.Services.HasLogOverrides() {
+		return log.WithServiceOverrides(cfg)
+	}
+	return cfg
+}
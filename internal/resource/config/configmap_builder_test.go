@@ -0,0 +1,148 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/metrics"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+)
+
+func TestConfigmapBuilderDevMode(t *testing.T) {
+	cluster := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "preview", Namespace: "demo"},
+		Spec: v1beta1.TemporalClusterSpec{
+			Version: version.MustNewVersionFromString("1.23.0"),
+			DevMode: &v1beta1.DevModeSpec{Enabled: true},
+		},
+	}
+	cluster.Default()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	builder := NewConfigmapBuilder(cluster, scheme)
+	object := builder.Build()
+	require.NoError(t, builder.Update(object))
+
+	parsed := &config.Config{}
+	require.NoError(t, yaml.Unmarshal([]byte(object.(*corev1.ConfigMap).Data[meta.ConfigTemplateKey]), parsed))
+
+	for name, database := range map[string]string{
+		v1beta1.DefaultStoreName:    "/var/lib/temporal/sqlite/default.db",
+		v1beta1.VisibilityStoreName: "/var/lib/temporal/sqlite/visibility.db",
+	} {
+		store, ok := parsed.Persistence.DataStores[name]
+		require.True(t, ok, name)
+		require.NotNil(t, store.SQL, name)
+		assert.Equal(t, "sqlite", store.SQL.PluginName, name)
+		assert.Equal(t, database, store.SQL.DatabaseName, name)
+		// Databases are created and their schema set up by temporal on startup.
+		assert.Equal(t, map[string]string{"mode": "rwc", "setup": "true"}, store.SQL.ConnectAttributes, name)
+	}
+}
+
+func TestConfigmapBuilderStatsd(t *testing.T) {
+	tests := map[string]struct {
+		metrics      *v1beta1.MetricsSpec
+		expected     *metrics.StatsdConfig
+		expectedTags map[string]string
+	}{
+		"all settings": {
+			metrics: &v1beta1.MetricsSpec{
+				Enabled: true,
+				Tags:    map[string]string{"env": "prod", "type": "custom"},
+				Statsd: &v1beta1.StatsdSpec{
+					Address:       "statsd.monitoring:8125",
+					Prefix:        "temporal",
+					TagSeparator:  ",",
+					FlushInterval: &metav1.Duration{Duration: 5 * time.Second},
+					FlushBytes:    ptr.To(512),
+				},
+			},
+			expected: &metrics.StatsdConfig{
+				HostPort:      "statsd.monitoring:8125",
+				Prefix:        "temporal",
+				FlushInterval: 5 * time.Second,
+				FlushBytes:    512,
+				Reporter: metrics.StatsdReporterConfig{
+					TagSeparator: ",",
+				},
+			},
+			// The service type tag can't be overridden.
+			expectedTags: map[string]string{"env": "prod", "type": "{{ .Env.SERVICES }}"},
+		},
+		"temporal defaults": {
+			metrics: &v1beta1.MetricsSpec{
+				Enabled: true,
+				Statsd: &v1beta1.StatsdSpec{
+					Address: "statsd.monitoring:8125",
+					Prefix:  "temporal",
+				},
+			},
+			expected: &metrics.StatsdConfig{
+				HostPort: "statsd.monitoring:8125",
+				Prefix:   "temporal",
+			},
+			expectedTags: map[string]string{"type": "{{ .Env.SERVICES }}"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			cluster := &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.23.0"),
+					DevMode: &v1beta1.DevModeSpec{Enabled: true},
+					Metrics: test.metrics,
+				},
+			}
+			cluster.Default()
+
+			scheme := runtime.NewScheme()
+			require.NoError(tt, clientgoscheme.AddToScheme(scheme))
+			require.NoError(tt, v1beta1.AddToScheme(scheme))
+
+			builder := NewConfigmapBuilder(cluster, scheme)
+			object := builder.Build()
+			require.NoError(tt, builder.Update(object))
+
+			parsed := &config.Config{}
+			require.NoError(tt, yaml.Unmarshal([]byte(object.(*corev1.ConfigMap).Data[meta.ConfigTemplateKey]), parsed))
+
+			require.NotNil(tt, parsed.Global.Metrics)
+			assert.Equal(tt, test.expected, parsed.Global.Metrics.Statsd)
+			assert.Equal(tt, test.expectedTags, parsed.Global.Metrics.ClientConfig.Tags)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"go.temporal.io/server/common/config"
+	"gopkg.in/yaml.v3"
+)
+
+// otelExportConfig mirrors temporal's telemetry.ExportConfig, whose fields are unexported
+// and can't be set before marshaling the temporal config.
+type otelExportConfig struct {
+	Exporters []otelExporter `yaml:"exporters"`
+}
+
+type otelExporter struct {
+	Kind otelExporterKind     `yaml:"kind"`
+	Spec otelGRPCExporterSpec `yaml:"spec"`
+}
+
+type otelExporterKind struct {
+	Signal   string `yaml:"signal"`
+	Model    string `yaml:"model"`
+	Protocol string `yaml:"protocol"`
+}
+
+type otelGRPCExporterSpec struct {
+	Headers    map[string]string  `yaml:"headers,omitempty"`
+	Connection otelGRPCConnection `yaml:"connection"`
+}
+
+type otelGRPCConnection struct {
+	Endpoint string `yaml:"endpoint"`
+	Insecure bool   `yaml:"insecure"`
+}
+
+// newOtelExportConfig returns the otel export config sending traces to the provided collector.
+// Headers values are read from environment variables when rendering the config template.
+func newOtelExportConfig(tracing *v1beta1.TracingSpec) *otelExportConfig {
+	var headers map[string]string
+	if len(tracing.Headers) > 0 {
+		headers = make(map[string]string, len(tracing.Headers))
+		for i, header := range tracing.Headers {
+			headers[header.Name] = fmt.Sprintf("{{ .Env.%s }}", tracing.GetHeaderEnvVarName(i))
+		}
+	}
+
+	return &otelExportConfig{
+		Exporters: []otelExporter{
+			{
+				Kind: otelExporterKind{
+					Signal:   "traces",
+					Model:    "otlp",
+					Protocol: "grpc",
+				},
+				Spec: otelGRPCExporterSpec{
+					Headers: headers,
+					Connection: otelGRPCConnection{
+						Endpoint: tracing.Endpoint,
+						Insecure: tracing.Insecure,
+					},
+				},
+			},
+		},
+	}
+}
+
+// marshalConfig marshals the provided temporal config, setting its otel section if traces export is enabled.
+func marshalConfig(cfg *config.Config, telemetry *v1beta1.TelemetrySpec) ([]byte, error) {
+	if !telemetry.TracingEnabled() {
+		return yaml.Marshal(cfg)
+	}
+
+	doc := &yaml.Node{}
+	if err := doc.Encode(cfg); err != nil {
+		return nil, err
+	}
+
+	otel := &yaml.Node{}
+	if err := otel.Encode(newOtelExportConfig(telemetry.Tracing)); err != nil {
+		return nil, err
+	}
+
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		if doc.Content[i].Value == "otel" {
+			doc.Content[i+1] = otel
+			return yaml.Marshal(doc)
+		}
+	}
+
+	return nil, errors.New("can't find otel section in temporal config")
+}
@@ -0,0 +1,68 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/server/common/config"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestMarshalConfigWithTracing(t *testing.T) {
+	cfg := &config.Config{
+		Global: config.Global{
+			Membership: config.Membership{BroadcastAddress: "{{ default .Env.POD_IP \"0.0.0.0\" }}"},
+		},
+	}
+
+	withoutTracing, err := marshalConfig(cfg, nil)
+	require.NoError(t, err)
+	assert.Contains(t, string(withoutTracing), "otel: {}")
+
+	result, err := marshalConfig(cfg, &v1beta1.TelemetrySpec{
+		Tracing: &v1beta1.TracingSpec{
+			Endpoint: "otel-collector.observability:4317",
+			Insecure: true,
+			Headers: []v1beta1.TracingHeader{
+				{
+					Name: "x-api-key",
+					ValueFrom: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "otel"},
+						Key:                  "api-key",
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, string(result), "x-api-key: '{{ .Env.TEMPORAL_OTEL_HEADER_0 }}'")
+
+	// Ensure temporal is able to load the rendered otel section.
+	parsed := &config.Config{}
+	require.NoError(t, yaml.Unmarshal(result, parsed))
+	assert.Equal(t, cfg.Global.Membership.BroadcastAddress, parsed.Global.Membership.BroadcastAddress)
+
+	exporters, err := parsed.ExporterConfig.SpanExporters()
+	require.NoError(t, err)
+	assert.Len(t, exporters, 1)
+}
@@ -0,0 +1,147 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lifecycle
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// CurrentVersionEnvVar is the environment variable holding the version the cluster runs.
+	CurrentVersionEnvVar = "TEMPORAL_CURRENT_VERSION"
+	// TargetVersionEnvVar is the environment variable holding the version the cluster is upgraded to.
+	TargetVersionEnvVar = "TEMPORAL_TARGET_VERSION"
+)
+
+// PodSpec returns the pod spec of the provided hook.
+func PodSpec(hook *v1beta1.LifecycleHookSpec) (*corev1.PodSpec, error) {
+	if hook.Template.Spec == nil {
+		return nil, errors.New("pod spec is required")
+	}
+
+	spec := &corev1.PodSpec{}
+	err := json.Unmarshal(hook.Template.Spec.Raw, spec)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse pod spec: %w", err)
+	}
+
+	if len(spec.Containers) == 0 {
+		return nil, errors.New("pod spec must define at least one container")
+	}
+
+	return spec, nil
+}
+
+// HookJobBuilder builds the job running a lifecycle hook.
+type HookJobBuilder struct {
+	instance *v1beta1.TemporalCluster
+	scheme   *runtime.Scheme
+	hook     *v1beta1.LifecycleHookSpec
+	// name is the name of the job
+	name string
+	// currentVersion is the version the cluster runs
+	currentVersion *version.Version
+}
+
+func NewHookJobBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme, hook *v1beta1.LifecycleHookSpec, name string, currentVersion *version.Version) *HookJobBuilder {
+	return &HookJobBuilder{
+		instance:       instance,
+		scheme:         scheme,
+		hook:           hook,
+		name:           name,
+		currentVersion: currentVersion,
+	}
+}
+
+func (b *HookJobBuilder) Enabled() bool {
+	return true
+}
+
+func (b *HookJobBuilder) Build() client.Object {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.instance.ChildResourceName(b.name),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.GetLabels(b.instance, b.name, b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *HookJobBuilder) Update(object client.Object) error {
+	job := object.(*batchv1.Job)
+
+	spec, err := PodSpec(b.hook)
+	if err != nil {
+		return fmt.Errorf("invalid %s hook: %w", b.hook.Name, err)
+	}
+
+	if spec.RestartPolicy == "" {
+		spec.RestartPolicy = corev1.RestartPolicyNever
+	}
+
+	env := []corev1.EnvVar{
+		{Name: CurrentVersionEnvVar, Value: b.currentVersion.String()},
+		{Name: TargetVersionEnvVar, Value: b.instance.Spec.Version.String()},
+	}
+	for i := range spec.InitContainers {
+		spec.InitContainers[i].Env = append(spec.InitContainers[i].Env, env...)
+	}
+	for i := range spec.Containers {
+		spec.Containers[i].Env = append(spec.Containers[i].Env, env...)
+	}
+
+	labels := metadata.GetLabels(b.instance, b.name, b.instance.Spec.Version, b.instance.Labels)
+	annotations := metadata.GetAnnotations(b.instance.Name, b.instance.Annotations)
+	if b.hook.Template.ObjectMetaOverride != nil {
+		labels = metadata.Merge(b.hook.Template.Labels, labels)
+		annotations = metadata.Merge(b.hook.Template.Annotations, annotations)
+	}
+
+	job.Spec = batchv1.JobSpec{
+		TTLSecondsAfterFinished: b.instance.Spec.JobTTLSecondsAfterFinished,
+		BackoffLimit:            ptr.To(ptr.Deref(b.hook.BackoffLimit, 0)),
+		ActiveDeadlineSeconds:   b.hook.ActiveDeadlineSeconds,
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:      labels,
+				Annotations: annotations,
+			},
+			Spec: *spec,
+		},
+	}
+
+	if err := controllerutil.SetOwnerReference(b.instance, job, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+
+	return nil
+}
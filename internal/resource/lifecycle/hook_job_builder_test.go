@@ -0,0 +1,79 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lifecycle_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/lifecycle"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+)
+
+func TestHookJobBuilder(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	cluster := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+		Spec: v1beta1.TemporalClusterSpec{
+			Version: version.MustNewVersionFromString("1.23.0"),
+		},
+	}
+	hook := &v1beta1.LifecycleHookSpec{
+		Name: "backup",
+		Template: v1beta1.PodTemplateSpecOverride{
+			ObjectMetaOverride: &v1beta1.ObjectMetaOverride{
+				Labels: map[string]string{"team": "platform"},
+			},
+			Spec: &apiextensionsv1.JSON{Raw: []byte(`{"containers":[{"name":"backup","image":"backup:latest","env":[{"name":"BUCKET","value":"backups"}]}]}`)},
+		},
+	}
+
+	builder := lifecycle.NewHookJobBuilder(cluster, scheme, hook, "pre-upgrade-backup-v-1-23-0", version.MustNewVersionFromString("1.22.4"))
+
+	object := builder.Build()
+	require.NoError(t, builder.Update(object))
+
+	job := object.(*batchv1.Job)
+	assert.Equal(t, "prod-pre-upgrade-backup-v-1-23-0", job.GetName())
+	assert.Equal(t, ptr.To[int32](0), job.Spec.BackoffLimit)
+	assert.Equal(t, corev1.RestartPolicyNever, job.Spec.Template.Spec.RestartPolicy)
+	assert.Equal(t, "platform", job.Spec.Template.Labels["team"])
+	require.Len(t, job.Spec.Template.Spec.Containers, 1)
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "BUCKET", Value: "backups"},
+		{Name: lifecycle.CurrentVersionEnvVar, Value: "1.22.4"},
+		{Name: lifecycle.TargetVersionEnvVar, Value: "1.23.0"},
+	}, job.Spec.Template.Spec.Containers[0].Env)
+	require.Len(t, job.GetOwnerReferences(), 1)
+	assert.Equal(t, "prod", job.GetOwnerReferences()[0].Name)
+
+	hook.Template.Spec = &apiextensionsv1.JSON{Raw: []byte(`{}`)}
+	assert.Error(t, builder.Update(object))
+}
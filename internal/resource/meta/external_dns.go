@@ -0,0 +1,32 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package meta
+
+// ExternalDNSHostnameAnnotation is the annotation external-dns reads to create DNS records for a resource.
+const ExternalDNSHostnameAnnotation = "external-dns.alpha.kubernetes.io/hostname"
+
+// ExternalDNSAnnotations returns the external-dns annotations for the provided hostname.
+func ExternalDNSAnnotations(hostname string) map[string]string {
+	if hostname == "" {
+		return nil
+	}
+
+	return map[string]string{
+		ExternalDNSHostnameAnnotation: hostname,
+	}
+}
@@ -0,0 +1,54 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package meta
+
+import (
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// GatewayParentRefs returns the provided parent references with the values defaulted by the API server,
+// to avoid updating routes at each reconciliation.
+func GatewayParentRefs(refs []gatewayv1.ParentReference) []gatewayv1.ParentReference {
+	result := make([]gatewayv1.ParentReference, 0, len(refs))
+	for _, ref := range refs {
+		ref := *ref.DeepCopy()
+		if ref.Group == nil {
+			ref.Group = ptr.To(gatewayv1.Group(gatewayv1.GroupName))
+		}
+		if ref.Kind == nil {
+			ref.Kind = ptr.To(gatewayv1.Kind("Gateway"))
+		}
+		result = append(result, ref)
+	}
+	return result
+}
+
+// GatewayServiceBackendRef returns a reference to the provided service port,
+// with the values defaulted by the API server.
+func GatewayServiceBackendRef(name string, port int32) gatewayv1.BackendRef {
+	return gatewayv1.BackendRef{
+		BackendObjectReference: gatewayv1.BackendObjectReference{
+			Group: ptr.To(gatewayv1.Group("")),
+			Kind:  ptr.To(gatewayv1.Kind("Service")),
+			Name:  gatewayv1.ObjectName(name),
+			Port:  ptr.To(gatewayv1.PortNumber(port)),
+		},
+		Weight: ptr.To[int32](1),
+	}
+}
@@ -0,0 +1,41 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package meta
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ImageReference returns the reference of the provided image.
+// If a digest is provided, the image is pulled by digest, the tag being kept for readability.
+func ImageReference(image, tag, digest string) string {
+	if digest != "" {
+		return fmt.Sprintf("%s:%s@%s", image, tag, digest)
+	}
+	return fmt.Sprintf("%s:%s", image, tag)
+}
+
+// ImagePullPolicyOrDefault returns the provided pull policy or IfNotPresent if empty.
+func ImagePullPolicyOrDefault(policy corev1.PullPolicy) corev1.PullPolicy {
+	if policy == "" {
+		return corev1.PullIfNotPresent
+	}
+	return policy
+}
@@ -0,0 +1,46 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package meta_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageReference(t *testing.T) {
+	tests := map[string]struct {
+		digest   string
+		expected string
+	}{
+		"tag": {
+			expected: "temporalio/server:1.23.0",
+		},
+		"digest": {
+			digest:   "sha256:4a1c4b21597c1b4415bdbecb28a3296c6b5e23ca4f9feeb599860a1dac6a0108",
+			expected: "temporalio/server:1.23.0@sha256:4a1c4b21597c1b4415bdbecb28a3296c6b5e23ca4f9feeb599860a1dac6a0108",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			assert.Equal(tt, test.expected, meta.ImageReference("temporalio/server", "1.23.0", test.digest))
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package meta
+
+import (
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// IngressCertManagerAnnotations returns the cert-manager ingress-shim annotations
+// requesting a certificate from the provided issuer.
+func IngressCertManagerAnnotations(ref *v1beta1.CertManagerIssuerReference) map[string]string {
+	if ref == nil {
+		return nil
+	}
+
+	switch {
+	case ref.Group != "" && ref.Group != "cert-manager.io":
+		kind := ref.Kind
+		if kind == "" {
+			kind = "Issuer"
+		}
+		return map[string]string{
+			"cert-manager.io/issuer":       ref.Name,
+			"cert-manager.io/issuer-kind":  kind,
+			"cert-manager.io/issuer-group": ref.Group,
+		}
+	case ref.Kind == "ClusterIssuer":
+		return map[string]string{
+			"cert-manager.io/cluster-issuer": ref.Name,
+		}
+	default:
+		return map[string]string{
+			"cert-manager.io/issuer": ref.Name,
+		}
+	}
+}
+
+// IngressTLS returns the TLS configuration of the provided ingress spec.
+// When cert-manager issues the certificate and no TLS configuration is provided,
+// it returns a configuration for all hosts using the provided secret.
+func IngressTLS(spec *v1beta1.TemporalUIIngressSpec, hosts []string, secretName string) []networkingv1.IngressTLS {
+	if len(spec.TLS) > 0 || spec.CertManagerIssuerRef == nil {
+		return spec.TLS
+	}
+
+	return []networkingv1.IngressTLS{
+		{
+			Hosts:      hosts,
+			SecretName: secretName,
+		},
+	}
+}
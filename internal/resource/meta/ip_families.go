@@ -0,0 +1,39 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package meta
+
+import (
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// SetServiceIPFamilies sets the IP families configured on the cluster on the provided service.
+// If they're not configured, the service keeps the values defaulted by the API server.
+func SetServiceIPFamilies(service *corev1.Service, spec *v1beta1.NetworkSpec) {
+	if spec == nil {
+		return
+	}
+
+	if spec.IPFamilyPolicy != nil {
+		service.Spec.IPFamilyPolicy = spec.IPFamilyPolicy
+	}
+
+	if len(spec.IPFamilies) > 0 {
+		service.Spec.IPFamilies = spec.IPFamilies
+	}
+}
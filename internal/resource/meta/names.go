@@ -17,15 +17,28 @@
 
 package meta
 
+import "fmt"
+
 // Service components.
 const (
-	FrontendService      = "frontend"
-	ServiceConfig        = "config"
-	ServiceDynamicConfig = "dynamicconfig"
+	FrontendService         = "frontend"
+	InternalFrontendService = "internal-frontend"
+	ServiceConfig           = "config"
+	ServiceDynamicConfig    = "dynamicconfig"
 )
 
 // Additionals services.
 const (
-	ServiceUIName     = "ui"
-	ServiceAdminTools = "admintools"
+	ServiceUIName      = "ui"
+	ServiceAdminTools  = "admintools"
+	ServiceCodecServer = "codec-server"
 )
+
+// ConfigTemplateKey is the key of the temporal server configuration in the config configmap.
+const ConfigTemplateKey = "config_template.yaml"
+
+// ServiceConfigTemplateKey returns the key of the temporal server configuration
+// of a service overlaying the cluster's configuration in the config configmap.
+func ServiceConfigTemplateKey(service string) string {
+	return fmt.Sprintf("config_template_%s.yaml", service)
+}
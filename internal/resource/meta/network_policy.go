@@ -0,0 +1,57 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package meta
+
+import (
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"go.temporal.io/server/common/primitives"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TemporalServicesPeer returns a NetworkPolicyPeer selecting the pods of all temporal services of the provided cluster.
+func TemporalServicesPeer(instance *v1beta1.TemporalCluster) networkingv1.NetworkPolicyPeer {
+	return networkingv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: instance.SelectorLabels(),
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{
+					Key:      "app.kubernetes.io/component",
+					Operator: metav1.LabelSelectorOpIn,
+					Values: []string{
+						string(primitives.FrontendService),
+						string(primitives.InternalFrontendService),
+						string(primitives.HistoryService),
+						string(primitives.MatchingService),
+						string(primitives.WorkerService),
+					},
+				},
+			},
+		},
+	}
+}
+
+// ComponentPeer returns a NetworkPolicyPeer selecting the pods of the provided cluster's component.
+func ComponentPeer(instance *v1beta1.TemporalCluster, component string) networkingv1.NetworkPolicyPeer {
+	return networkingv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: metadata.LabelsSelector(instance, component),
+		},
+	}
+}
@@ -28,14 +28,25 @@ import (
 
 const (
 	configHashKey = "operator.temporal.io/config"
+	// CertificatesHashKey is the pod annotation containing the hash of the mTLS certificates used by the pod.
+	CertificatesHashKey = "operator.temporal.io/certificates"
 )
 
 // BuildPodObjectMeta return ObjectMeta for the service (frontend, ui, admintools) of the provided Cluster.
-func BuildPodObjectMeta(instance *v1beta1.TemporalCluster, service, configHash string) metav1.ObjectMeta {
+// The config and certificates hashes annotations are only set if their hash is not empty.
+func BuildPodObjectMeta(instance *v1beta1.TemporalCluster, service, configHash, certificatesHash string) metav1.ObjectMeta {
 	instanceAnnotations := metadata.FilterAnnotations(instance.Annotations, func(k, v string) bool {
 		return k != "kubectl.kubernetes.io/last-applied-configuration"
 	})
 
+	hashes := map[string]string{}
+	if configHash != "" {
+		hashes[configHashKey] = configHash
+	}
+	if certificatesHash != "" {
+		hashes[CertificatesHashKey] = certificatesHash
+	}
+
 	return metav1.ObjectMeta{
 		Labels: metadata.Merge(
 			istio.GetLabels(instance),
@@ -46,9 +57,7 @@ func BuildPodObjectMeta(instance *v1beta1.TemporalCluster, service, configHash s
 			istio.GetAnnotations(instance),
 			prometheus.GetAnnotations(instance),
 			metadata.GetAnnotations(instance.Name, instanceAnnotations),
-			map[string]string{
-				configHashKey: configHash,
-			},
+			hashes,
 		),
 	}
 }
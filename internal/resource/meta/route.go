@@ -0,0 +1,68 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package meta
+
+import (
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	routev1 "github.com/alexandrevilain/temporal-operator/internal/apis/openshift/route/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+// RouteSpecFor returns the spec of an OpenShift Route exposing the provided service port.
+// The existing route is used to keep the host generated by OpenShift when no host is provided.
+func RouteSpecFor(spec *v1beta1.RouteSpec, existing *routev1.Route, serviceName, servicePort, path string, defaultTermination v1beta1.RouteTermination) routev1.RouteSpec {
+	termination := spec.Termination
+	if termination == "" {
+		termination = defaultTermination
+	}
+
+	host := spec.Host
+	if host == "" {
+		host = existing.Spec.Host
+	}
+
+	tls := &routev1.TLSConfig{
+		Termination:                   routev1.TLSTerminationType(termination),
+		InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyType(spec.InsecureEdgeTerminationPolicy),
+	}
+	if termination == v1beta1.ReencryptRouteTermination {
+		tls.DestinationCACertificate = spec.DestinationCACertificate
+	}
+
+	// Passthrough routes can't match on path, as the router can't read the request.
+	if termination == v1beta1.PassthroughRouteTermination || path == "/" {
+		path = ""
+	}
+
+	// Set the values defaulted by the API server, to avoid updating the route at each reconciliation.
+	return routev1.RouteSpec{
+		Host: host,
+		Path: path,
+		To: routev1.RouteTargetReference{
+			Kind:   "Service",
+			Name:   serviceName,
+			Weight: ptr.To[int32](100),
+		},
+		Port: &routev1.RoutePort{
+			TargetPort: intstr.FromString(servicePort),
+		},
+		TLS:            tls,
+		WildcardPolicy: routev1.WildcardPolicyNone,
+	}
+}
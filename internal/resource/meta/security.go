@@ -0,0 +1,71 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package meta
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+// DefaultPodSecurityContext returns a pod security context compliant with the
+// "restricted" Pod Security Standard, running as the provided user and group.
+func DefaultPodSecurityContext(runAsUser int64, withFSGroup bool) *corev1.PodSecurityContext {
+	sc := &corev1.PodSecurityContext{
+		RunAsUser:    ptr.To(runAsUser),
+		RunAsGroup:   ptr.To(runAsUser),
+		RunAsNonRoot: ptr.To(true),
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}
+	if withFSGroup {
+		sc.FSGroup = ptr.To(runAsUser)
+	}
+	return sc
+}
+
+// DefaultContainerSecurityContext returns a container security context compliant with the
+// "restricted" Pod Security Standard.
+func DefaultContainerSecurityContext() *corev1.SecurityContext {
+	return &corev1.SecurityContext{
+		AllowPrivilegeEscalation: ptr.To(false),
+		RunAsNonRoot:             ptr.To(true),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}
+}
+
+// PodSecurityContextOrDefault returns the provided pod security context if not nil, otherwise it returns the default.
+func PodSecurityContextOrDefault(sc, def *corev1.PodSecurityContext) *corev1.PodSecurityContext {
+	if sc != nil {
+		return sc.DeepCopy()
+	}
+	return def
+}
+
+// ContainerSecurityContextOrDefault returns the provided container security context if not nil, otherwise it returns the default.
+func ContainerSecurityContextOrDefault(sc, def *corev1.SecurityContext) *corev1.SecurityContext {
+	if sc != nil {
+		return sc.DeepCopy()
+	}
+	return def
+}
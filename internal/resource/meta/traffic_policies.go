@@ -0,0 +1,46 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package meta
+
+import (
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// SetServiceTrafficPolicies sets the internal traffic policy and session affinity of the provided service.
+func SetServiceTrafficPolicies(service *corev1.Service, spec *v1beta1.KubernetesServiceSpec) {
+	internalTrafficPolicy := corev1.ServiceInternalTrafficPolicyCluster
+	if spec != nil && spec.InternalTrafficPolicy != nil {
+		internalTrafficPolicy = *spec.InternalTrafficPolicy
+	}
+	service.Spec.InternalTrafficPolicy = &internalTrafficPolicy
+
+	service.Spec.SessionAffinity = spec.GetSessionAffinity()
+	if service.Spec.SessionAffinity != corev1.ServiceAffinityClientIP {
+		service.Spec.SessionAffinityConfig = nil
+		return
+	}
+
+	if spec.SessionAffinityTimeoutSeconds != nil {
+		service.Spec.SessionAffinityConfig = &corev1.SessionAffinityConfig{
+			ClientIP: &corev1.ClientIPConfig{
+				TimeoutSeconds: spec.SessionAffinityTimeoutSeconds,
+			},
+		}
+	}
+}
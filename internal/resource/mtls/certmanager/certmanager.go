@@ -38,12 +38,21 @@ const (
 	InternodeCertificate = "internode-certificate"
 	// FrontendCertificate is the name of the certificate used by the frontend.
 	FrontendCertificate = "frontend-certificate"
+	// FrontendExternalCertificate is the name of the certificate served by the frontend
+	// to clients connecting using external hostnames.
+	FrontendExternalCertificate = "frontend-external-certificate"
+	// FrontendExternalClientCA is the name of the volume containing the CA bundle
+	// used to verify clients connecting using external hostnames.
+	FrontendExternalClientCA = "frontend-external-client-ca"
 	// InternodeIntermediateCACertificate is the name of the intermediate CA certificate used to issue
 	// internode certificates.
 	InternodeIntermediateCACertificate = "internode-intermediate-ca-certificate"
 	// FrontendIntermediateCACertificate is the name of the intermediate CA certificate used to issue
 	// frontend certificates.
 	FrontendIntermediateCACertificate = "frontend-intermediate-ca-certificate"
+	// RemoteClustersTrustBundle is the name of the secret containing the CA bundle
+	// of the remote clusters managed by the operator.
+	RemoteClustersTrustBundle = "remote-clusters-trust-bundle"
 )
 
 var (
@@ -20,6 +20,7 @@ package certmanager
 import (
 	"fmt"
 	"path"
+	"strconv"
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
@@ -47,11 +48,11 @@ func GetTLSEnvironmentVariables(instance *v1beta1.TemporalCluster, envPrefix, ce
 		},
 		{
 			Name:  addPrefix(envPrefix, "TLS_ENABLE_HOST_VERIFICATION"),
-			Value: "true",
+			Value: strconv.FormatBool(instance.MTLSWithHostVerification()),
 		},
 		{
 			Name:  addPrefix(envPrefix, "TLS_DISABLE_HOST_VERIFICATION"),
-			Value: "false",
+			Value: strconv.FormatBool(!instance.MTLSWithHostVerification()),
 		},
 		{
 			Name:  addPrefix(envPrefix, "TLS_SERVER_NAME"),
@@ -56,6 +56,10 @@ func (b *GenericFrontendClientCertificateBuilder) Build() client.Object {
 	}
 }
 
+func (b *GenericFrontendClientCertificateBuilder) Enabled() bool {
+	return true
+}
+
 func (b *GenericFrontendClientCertificateBuilder) Update(object client.Object) error {
 	certificate := object.(*certmanagerv1.Certificate)
 	certificate.Labels = object.GetLabels()
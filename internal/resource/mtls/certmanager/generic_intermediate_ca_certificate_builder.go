@@ -72,6 +72,15 @@ func (b *GenericItermediateCACertificateBuilder) Update(object client.Object) er
 		Usages: caCertificatesUsages,
 	}
 
+	// Use the user-provided issuer instead of the operator's root CA.
+	if issuerRef := b.instance.Spec.MTLS.CertManagerIssuerRef(); issuerRef != nil {
+		certificate.Spec.IssuerRef = certmanagermeta.ObjectReference{
+			Name:  issuerRef.Name,
+			Kind:  issuerRef.Kind,
+			Group: issuerRef.Group,
+		}
+	}
+
 	if err := controllerutil.SetControllerReference(b.instance, certificate, b.scheme); err != nil {
 		return fmt.Errorf("failed setting controller reference: %w", err)
 	}
@@ -0,0 +1,94 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package certmanager_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	certmanagermeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestIntermediateCACertificateIssuer(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	tests := map[string]struct {
+		certManager            *v1beta1.CertManagerMTLSSpec
+		expectedIssuer         certmanagermeta.ObjectReference
+		expectRootCAReconciled bool
+	}{
+		"operator root CA": {
+			expectedIssuer: certmanagermeta.ObjectReference{
+				Name: "prod-root-ca-issuer",
+				Kind: certmanagerv1.IssuerKind,
+			},
+			expectRootCAReconciled: true,
+		},
+		"existing cluster issuer": {
+			certManager: &v1beta1.CertManagerMTLSSpec{
+				IssuerRef: &v1beta1.CertManagerIssuerReference{
+					Name: "corporate-ca",
+					Kind: certmanagerv1.ClusterIssuerKind,
+				},
+			},
+			expectedIssuer: certmanagermeta.ObjectReference{
+				Name: "corporate-ca",
+				Kind: certmanagerv1.ClusterIssuerKind,
+			},
+			expectRootCAReconciled: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			cluster := &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.23.0"),
+					MTLS: &v1beta1.MTLSSpec{
+						Provider:             v1beta1.CertManagerMTLSProvider,
+						CertManager:          test.certManager,
+						Internode:            &v1beta1.InternodeMTLSSpec{Enabled: true},
+						CertificatesDuration: &v1beta1.CertificatesDurationSpec{},
+					},
+				},
+			}
+
+			builder := certmanager.NewMTLSInternodeIntermediateCACertificateBuilder(cluster, scheme)
+			object := builder.Build()
+			require.NoError(tt, builder.Update(object))
+
+			certificate := object.(*certmanagerv1.Certificate)
+			assert.Equal(tt, test.expectedIssuer, certificate.Spec.IssuerRef)
+
+			assert.Equal(tt, test.expectRootCAReconciled, certmanager.NewMTLSBootstrapIssuerBuilder(cluster, scheme).Enabled())
+			assert.Equal(tt, test.expectRootCAReconciled, certmanager.NewMTLSRootCACertificateBuilder(cluster, scheme).Enabled())
+			assert.Equal(tt, test.expectRootCAReconciled, certmanager.NewMTLSRootCAIssuerBuilder(cluster, scheme).Enabled())
+		})
+	}
+}
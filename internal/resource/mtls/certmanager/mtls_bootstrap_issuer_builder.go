@@ -53,7 +53,7 @@ func (b *MTLSBootstrapIssuerBuilder) Build() client.Object {
 }
 
 func (b *MTLSBootstrapIssuerBuilder) Enabled() bool {
-	return b.instance.MTLSWithCertManagerEnabled()
+	return b.instance.MTLSWithCertManagerEnabled() && b.instance.Spec.MTLS.CertManagerIssuerRef() == nil
 }
 
 func (b *MTLSBootstrapIssuerBuilder) Update(object client.Object) error {
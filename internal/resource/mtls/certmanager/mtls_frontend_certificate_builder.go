@@ -72,9 +72,7 @@ func (b *MTLSFrontendCertificateBuilder) Update(object client.Object) error {
 			Algorithm:      certmanagerv1.RSAKeyAlgorithm,
 			Size:           4096,
 		},
-		DNSNames: []string{
-			b.instance.Spec.MTLS.Frontend.ServerName(b.instance),
-		},
+		DNSNames: b.instance.Spec.MTLS.Frontend.DNSNames(b.instance),
 		IssuerRef: certmanagermeta.ObjectReference{
 			Name: b.instance.ChildResourceName(frontendIntermediateCAIssuer),
 			Kind: certmanagerv1.IssuerKind,
@@ -84,10 +82,7 @@ func (b *MTLSFrontendCertificateBuilder) Update(object client.Object) error {
 		},
 	}
 
-	// Add user-supplied extra DNS names.
-	certificate.Spec.DNSNames = append(certificate.Spec.DNSNames,
-		b.instance.Spec.MTLS.Frontend.ExtraDNSNames...,
-	)
+	certificate.Spec.IPAddresses = b.instance.Spec.MTLS.Frontend.ExtraIPAddresses
 
 	if err := controllerutil.SetControllerReference(b.instance, certificate, b.scheme); err != nil {
 		return fmt.Errorf("failed setting controller reference: %w", err)
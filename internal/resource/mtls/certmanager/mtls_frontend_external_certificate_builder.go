@@ -0,0 +1,104 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package certmanager
+
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	certmanagermeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+type MTLSFrontendExternalCertificateBuilder struct {
+	instance *v1beta1.TemporalCluster
+	scheme   *runtime.Scheme
+}
+
+func NewMTLSFrontendExternalCertificateBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme) *MTLSFrontendExternalCertificateBuilder {
+	return &MTLSFrontendExternalCertificateBuilder{
+		instance: instance,
+		scheme:   scheme,
+	}
+}
+
+func (b *MTLSFrontendExternalCertificateBuilder) Build() client.Object {
+	return &certmanagerv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.instance.ChildResourceName(FrontendExternalCertificate),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.GetLabels(b.instance, FrontendExternalCertificate, b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *MTLSFrontendExternalCertificateBuilder) Enabled() bool {
+	return b.instance.MTLSWithCertManagerEnabled() &&
+		b.instance.Spec.MTLS.FrontendEnabled() &&
+		b.instance.Spec.MTLS.Frontend.External != nil
+}
+
+func (b *MTLSFrontendExternalCertificateBuilder) Update(object client.Object) error {
+	external := b.instance.Spec.MTLS.Frontend.External
+
+	certificate := object.(*certmanagerv1.Certificate)
+	certificate.Labels = object.GetLabels()
+	certificate.Annotations = object.GetAnnotations()
+	certificate.Spec = certmanagerv1.CertificateSpec{
+		SecretName:  b.instance.ChildResourceName(FrontendExternalCertificate),
+		CommonName:  external.DNSNames[0],
+		Duration:    b.instance.Spec.MTLS.CertificatesDuration.FrontendCertificate,
+		RenewBefore: b.instance.Spec.MTLS.RenewBefore,
+		PrivateKey: &certmanagerv1.CertificatePrivateKey{
+			RotationPolicy: certmanagerv1.RotationPolicyAlways,
+			Encoding:       certmanagerv1.PKCS8,
+			Algorithm:      certmanagerv1.RSAKeyAlgorithm,
+			Size:           4096,
+		},
+		DNSNames: external.DNSNames,
+		IssuerRef: certmanagermeta.ObjectReference{
+			Name: b.instance.ChildResourceName(frontendIntermediateCAIssuer),
+			Kind: certmanagerv1.IssuerKind,
+		},
+		Usages: []certmanagerv1.KeyUsage{
+			certmanagerv1.UsageDigitalSignature,
+			certmanagerv1.UsageKeyEncipherment,
+			certmanagerv1.UsageServerAuth,
+		},
+	}
+
+	// Use the user-provided issuer, for instance one trusted by clients outside of the cluster.
+	if issuerRef := external.IssuerRef; issuerRef != nil {
+		certificate.Spec.IssuerRef = certmanagermeta.ObjectReference{
+			Name:  issuerRef.Name,
+			Kind:  issuerRef.Kind,
+			Group: issuerRef.Group,
+		}
+	}
+
+	if err := controllerutil.SetControllerReference(b.instance, certificate, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+	return nil
+}
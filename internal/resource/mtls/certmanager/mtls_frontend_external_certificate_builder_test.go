@@ -0,0 +1,106 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package certmanager_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	certmanagermeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestFrontendExternalCertificate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	tests := map[string]struct {
+		external       *v1beta1.FrontendExternalTLSSpec
+		expectedIssuer certmanagermeta.ObjectReference
+		expectEnabled  bool
+	}{
+		"no external certificate": {
+			expectEnabled: false,
+		},
+		"frontend intermediate CA": {
+			external: &v1beta1.FrontendExternalTLSSpec{
+				DNSNames: []string{"temporal.example.com"},
+			},
+			expectedIssuer: certmanagermeta.ObjectReference{
+				Name: "prod-frontend-intermediate-ca-issuer",
+				Kind: certmanagerv1.IssuerKind,
+			},
+			expectEnabled: true,
+		},
+		"external issuer": {
+			external: &v1beta1.FrontendExternalTLSSpec{
+				DNSNames: []string{"temporal.example.com"},
+				IssuerRef: &v1beta1.CertManagerIssuerReference{
+					Name: "letsencrypt",
+					Kind: certmanagerv1.ClusterIssuerKind,
+				},
+			},
+			expectedIssuer: certmanagermeta.ObjectReference{
+				Name: "letsencrypt",
+				Kind: certmanagerv1.ClusterIssuerKind,
+			},
+			expectEnabled: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			cluster := &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.23.0"),
+					MTLS: &v1beta1.MTLSSpec{
+						Provider: v1beta1.CertManagerMTLSProvider,
+						Frontend: &v1beta1.FrontendMTLSSpec{
+							Enabled:  true,
+							External: test.external,
+						},
+						CertificatesDuration: &v1beta1.CertificatesDurationSpec{},
+					},
+				},
+			}
+
+			builder := certmanager.NewMTLSFrontendExternalCertificateBuilder(cluster, scheme)
+			assert.Equal(tt, test.expectEnabled, builder.Enabled())
+			if !test.expectEnabled {
+				return
+			}
+
+			object := builder.Build()
+			require.NoError(tt, builder.Update(object))
+
+			certificate := object.(*certmanagerv1.Certificate)
+			assert.Equal(tt, "prod-frontend-external-certificate", certificate.Spec.SecretName)
+			assert.Equal(tt, test.external.DNSNames, certificate.Spec.DNSNames)
+			assert.Equal(tt, test.expectedIssuer, certificate.Spec.IssuerRef)
+		})
+	}
+}
@@ -54,7 +54,7 @@ func (b *MTLSRootCACertificateBuilder) Build() client.Object {
 }
 
 func (b *MTLSRootCACertificateBuilder) Enabled() bool {
-	return b.instance.MTLSWithCertManagerEnabled()
+	return b.instance.MTLSWithCertManagerEnabled() && b.instance.Spec.MTLS.CertManagerIssuerRef() == nil
 }
 
 func (b *MTLSRootCACertificateBuilder) Update(object client.Object) error {
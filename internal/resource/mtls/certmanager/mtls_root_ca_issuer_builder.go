@@ -38,5 +38,5 @@ func NewMTLSRootCAIssuerBuilder(instance *v1beta1.TemporalCluster, scheme *runti
 }
 
 func (b *MTLSRootCAIssuerBuilder) Enabled() bool {
-	return b.instance.MTLSWithCertManagerEnabled()
+	return b.instance.MTLSWithCertManagerEnabled() && b.instance.Spec.MTLS.CertManagerIssuerRef() == nil
 }
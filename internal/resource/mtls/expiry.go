@@ -0,0 +1,81 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mtls
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CertificateExpiry returns the expiration time of the certificate stored in the provided secret.
+func CertificateExpiry(secret *corev1.Secret) (time.Time, error) {
+	block, _ := pem.Decode(secret.Data[certmanager.TLSCert])
+	if block == nil {
+		return time.Time{}, fmt.Errorf("secret %s doesn't contain a PEM encoded certificate", secret.GetName())
+	}
+
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("secret %s contains an invalid certificate: %w", secret.GetName(), err)
+	}
+
+	return certificate.NotAfter, nil
+}
+
+// CertificatesExpiry returns the expiry of all certificates used by the cluster.
+// Certificates not issued yet are skipped.
+func CertificatesExpiry(ctx context.Context, c client.Reader, instance *v1beta1.TemporalCluster) ([]v1beta1.CertificateStatus, error) {
+	statuses := []v1beta1.CertificateStatus{}
+	var errs []error
+
+	for _, name := range CertificateSecretNames(instance) {
+		secret := &corev1.Secret{}
+		err := c.Get(ctx, types.NamespacedName{Namespace: instance.GetNamespace(), Name: name}, secret)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("can't get mTLS secret %s: %w", name, err)
+		}
+
+		notAfter, err := CertificateExpiry(secret)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		statuses = append(statuses, v1beta1.CertificateStatus{
+			SecretName: name,
+			NotAfter:   metav1.NewTime(notAfter),
+		})
+	}
+
+	return statuses, errors.Join(errs...)
+}
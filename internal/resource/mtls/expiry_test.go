@@ -0,0 +1,49 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mtls_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCertificateExpiry(t *testing.T) {
+	cert, _ := generateCertificate(t)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Data: map[string][]byte{
+			certmanager.TLSCert: cert,
+		},
+	}
+
+	notAfter, err := mtls.CertificateExpiry(secret)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), notAfter, time.Minute)
+
+	secret.Data[certmanager.TLSCert] = []byte("invalid")
+	_, err = mtls.CertificateExpiry(secret)
+	assert.ErrorContains(t, err, "secret test doesn't contain a PEM encoded certificate")
+}
@@ -0,0 +1,170 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mtls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"sort"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/strings/slices"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CertificateSecretName returns the name of the secret holding the provided certificate.
+// When using cert-manager, it's the secret generated for the certificate.
+// When using vault, CAs are stored alongside the issued certificates.
+// Otherwise it's the matching user-provided secret.
+func CertificateSecretName(instance *v1beta1.TemporalCluster, certificate string) string {
+	if instance.MTLSWithVaultEnabled() {
+		switch certificate {
+		case certmanager.InternodeIntermediateCACertificate:
+			certificate = certmanager.InternodeCertificate
+		case certmanager.FrontendIntermediateCACertificate:
+			certificate = certmanager.FrontendCertificate
+		}
+	}
+
+	if !instance.MTLSWithSecretsEnabled() {
+		return instance.ChildResourceName(certificate)
+	}
+
+	var ref *corev1.LocalObjectReference
+	switch certificate {
+	case certmanager.InternodeIntermediateCACertificate, certmanager.InternodeCertificate:
+		ref = instance.Spec.MTLS.Internode.SecretRef
+	case certmanager.FrontendIntermediateCACertificate, certmanager.FrontendCertificate:
+		ref = instance.Spec.MTLS.Frontend.SecretRef
+	default:
+		// All other certificates are frontend client certificates.
+		ref = instance.Spec.MTLS.Frontend.ClientSecretRef
+	}
+
+	if ref == nil {
+		return ""
+	}
+
+	return ref.Name
+}
+
+// CertificateSecretNames returns the sorted names of all secrets holding the certificates mounted in the cluster's pods.
+func CertificateSecretNames(instance *v1beta1.TemporalCluster) []string {
+	if !instance.MTLSWithCertificatesEnabled() {
+		return nil
+	}
+
+	certificates := []string{}
+	if instance.Spec.MTLS.InternodeEnabled() {
+		certificates = append(certificates,
+			certmanager.InternodeIntermediateCACertificate,
+			certmanager.InternodeCertificate,
+		)
+	}
+	if instance.Spec.MTLS.FrontendEnabled() {
+		certificates = append(certificates,
+			certmanager.FrontendIntermediateCACertificate,
+			certmanager.FrontendCertificate,
+			certmanager.WorkerFrontendClientCertificate,
+			certmanager.UIFrontendClientCertificate,
+			certmanager.AdmintoolsFrontendClientCertificate,
+		)
+		if instance.MTLSWithCertManagerEnabled() && instance.Spec.MTLS.Frontend.External != nil {
+			certificates = append(certificates, certmanager.FrontendExternalCertificate)
+		}
+	}
+
+	names := []string{}
+	for _, certificate := range certificates {
+		name := CertificateSecretName(instance, certificate)
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// ReferencedSecretNames returns the names of the user-provided secrets used as mTLS certificates.
+func ReferencedSecretNames(instance *v1beta1.TemporalCluster) []string {
+	if !instance.MTLSWithSecretsEnabled() {
+		return nil
+	}
+
+	refs := []*corev1.LocalObjectReference{}
+	if instance.Spec.MTLS.InternodeEnabled() {
+		refs = append(refs, instance.Spec.MTLS.Internode.SecretRef)
+	}
+	if instance.Spec.MTLS.FrontendEnabled() {
+		refs = append(refs, instance.Spec.MTLS.Frontend.SecretRef, instance.Spec.MTLS.Frontend.ClientSecretRef)
+	}
+
+	names := []string{}
+	for _, ref := range refs {
+		if ref != nil && ref.Name != "" {
+			names = append(names, ref.Name)
+		}
+	}
+
+	return names
+}
+
+// ValidateSecret ensures the provided secret contains a valid certificate, its key and a CA bundle.
+func ValidateSecret(secret *corev1.Secret) error {
+	for _, key := range []string{certmanager.TLSCA, certmanager.TLSCert, certmanager.TLSKey} {
+		if len(secret.Data[key]) == 0 {
+			return fmt.Errorf("secret %s is missing the %s key", secret.GetName(), key)
+		}
+	}
+
+	if !x509.NewCertPool().AppendCertsFromPEM(secret.Data[certmanager.TLSCA]) {
+		return fmt.Errorf("secret %s contains an invalid CA bundle", secret.GetName())
+	}
+
+	_, err := tls.X509KeyPair(secret.Data[certmanager.TLSCert], secret.Data[certmanager.TLSKey])
+	if err != nil {
+		return fmt.Errorf("secret %s contains an invalid certificate: %w", secret.GetName(), err)
+	}
+
+	return nil
+}
+
+// ValidateSecrets ensures all user-provided secrets referenced by the cluster exist and are valid.
+func ValidateSecrets(ctx context.Context, c client.Reader, instance *v1beta1.TemporalCluster) error {
+	for _, name := range ReferencedSecretNames(instance) {
+		secret := &corev1.Secret{}
+		err := c.Get(ctx, types.NamespacedName{Namespace: instance.GetNamespace(), Name: name}, secret)
+		if err != nil {
+			return fmt.Errorf("can't get mTLS secret %s: %w", name, err)
+		}
+
+		err = ValidateSecret(secret)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,218 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mtls_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCertificateSecretName(t *testing.T) {
+	secretsCluster := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Spec: v1beta1.TemporalClusterSpec{
+			MTLS: &v1beta1.MTLSSpec{
+				Provider: v1beta1.SecretsMTLSProvider,
+				Internode: &v1beta1.InternodeMTLSSpec{
+					Enabled:   true,
+					SecretRef: &corev1.LocalObjectReference{Name: "internode"},
+				},
+				Frontend: &v1beta1.FrontendMTLSSpec{
+					Enabled:         true,
+					SecretRef:       &corev1.LocalObjectReference{Name: "frontend"},
+					ClientSecretRef: &corev1.LocalObjectReference{Name: "client"},
+				},
+			},
+		},
+	}
+
+	certManagerCluster := secretsCluster.DeepCopy()
+	certManagerCluster.Spec.MTLS.Provider = v1beta1.CertManagerMTLSProvider
+
+	tests := map[string]struct {
+		cluster     *v1beta1.TemporalCluster
+		certificate string
+		expected    string
+	}{
+		"cert-manager certificate": {
+			cluster:     certManagerCluster,
+			certificate: certmanager.InternodeCertificate,
+			expected:    "prod-internode-certificate",
+		},
+		"internode CA": {
+			cluster:     secretsCluster,
+			certificate: certmanager.InternodeIntermediateCACertificate,
+			expected:    "internode",
+		},
+		"frontend certificate": {
+			cluster:     secretsCluster,
+			certificate: certmanager.FrontendCertificate,
+			expected:    "frontend",
+		},
+		"worker client certificate": {
+			cluster:     secretsCluster,
+			certificate: certmanager.WorkerFrontendClientCertificate,
+			expected:    "client",
+		},
+		"ui client certificate": {
+			cluster:     secretsCluster,
+			certificate: certmanager.UIFrontendClientCertificate,
+			expected:    "client",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			assert.Equal(tt, test.expected, mtls.CertificateSecretName(test.cluster, test.certificate))
+		})
+	}
+
+	assert.Equal(t, []string{"internode", "frontend", "client"}, mtls.ReferencedSecretNames(secretsCluster))
+	assert.Empty(t, mtls.ReferencedSecretNames(certManagerCluster))
+}
+
+func generateCertificate(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+func TestCertificateSecretNames(t *testing.T) {
+	cluster := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Spec: v1beta1.TemporalClusterSpec{
+			MTLS: &v1beta1.MTLSSpec{
+				Provider: v1beta1.SecretsMTLSProvider,
+				Internode: &v1beta1.InternodeMTLSSpec{
+					Enabled:   true,
+					SecretRef: &corev1.LocalObjectReference{Name: "internode"},
+				},
+				Frontend: &v1beta1.FrontendMTLSSpec{
+					Enabled:         true,
+					SecretRef:       &corev1.LocalObjectReference{Name: "frontend"},
+					ClientSecretRef: &corev1.LocalObjectReference{Name: "client"},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"client", "frontend", "internode"}, mtls.CertificateSecretNames(cluster))
+
+	cluster.Spec.MTLS.Provider = v1beta1.VaultMTLSProvider
+	assert.Equal(t, []string{
+		"prod-admintools-mtls-certificate",
+		"prod-frontend-certificate",
+		"prod-internode-certificate",
+		"prod-ui-mtls-certificate",
+		"prod-worker-mtls-certificate",
+	}, mtls.CertificateSecretNames(cluster))
+
+	cluster.Spec.MTLS.Provider = v1beta1.LinkerdMTLSProvider
+	assert.Empty(t, mtls.CertificateSecretNames(cluster))
+}
+
+func TestValidateSecret(t *testing.T) {
+	cert, key := generateCertificate(t)
+
+	tests := map[string]struct {
+		data        map[string][]byte
+		expectedErr string
+	}{
+		"valid secret": {
+			data: map[string][]byte{
+				certmanager.TLSCA:   cert,
+				certmanager.TLSCert: cert,
+				certmanager.TLSKey:  key,
+			},
+		},
+		"missing CA": {
+			data: map[string][]byte{
+				certmanager.TLSCert: cert,
+				certmanager.TLSKey:  key,
+			},
+			expectedErr: "secret test is missing the ca.crt key",
+		},
+		"invalid CA": {
+			data: map[string][]byte{
+				certmanager.TLSCA:   []byte("invalid"),
+				certmanager.TLSCert: cert,
+				certmanager.TLSKey:  key,
+			},
+			expectedErr: "secret test contains an invalid CA bundle",
+		},
+		"invalid key pair": {
+			data: map[string][]byte{
+				certmanager.TLSCA:   cert,
+				certmanager.TLSCert: cert,
+				certmanager.TLSKey:  []byte("invalid"),
+			},
+			expectedErr: "secret test contains an invalid certificate",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Data:       test.data,
+			}
+
+			err := mtls.ValidateSecret(secret)
+			if test.expectedErr == "" {
+				assert.NoError(tt, err)
+				return
+			}
+
+			assert.ErrorContains(tt, err, test.expectedErr)
+		})
+	}
+}
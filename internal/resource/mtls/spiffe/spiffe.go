@@ -0,0 +1,145 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package spiffe
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// HelperConfig is the name of the spiffe-helper configuration.
+	HelperConfig = "spiffe-helper"
+	// HelperConfigFileName is the name of the spiffe-helper configuration file.
+	HelperConfigFileName = "helper.conf"
+	// OperatorCertificatesPath is the path where the operator expects its own SVID,
+	// used to connect to clusters frontend.
+	OperatorCertificatesPath = "/etc/temporal-operator/spiffe"
+
+	workloadAPIVolume     = "spiffe-workload-api"
+	workloadAPIMountPath  = "/spiffe-workload-api"
+	certificatesVolume    = "spiffe-certificates"
+	certificatesMountPath = "/spiffe-certificates"
+	helperConfigVolume    = "spiffe-helper-config"
+	helperConfigMountPath = "/etc/spiffe-helper"
+)
+
+// HelperConfigContent returns the spiffe-helper configuration for the provided cluster.
+// SVIDs are written using the same file names as cert-manager's secrets.
+func HelperConfigContent(instance *v1beta1.TemporalCluster) string {
+	lines := []string{
+		fmt.Sprintf("agent_address = %q", path.Join(workloadAPIMountPath, instance.Spec.MTLS.SPIFFE.GetSocketName())),
+		fmt.Sprintf("cert_dir = %q", certificatesMountPath),
+		fmt.Sprintf("svid_file_name = %q", certmanager.TLSCert),
+		fmt.Sprintf("svid_key_file_name = %q", certmanager.TLSKey),
+		fmt.Sprintf("svid_bundle_file_name = %q", certmanager.TLSCA),
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func helperContainer(instance *v1beta1.TemporalCluster, name string, args ...string) corev1.Container {
+	return corev1.Container{
+		Name:                     name,
+		Image:                    instance.Spec.MTLS.SPIFFE.GetHelperImage(),
+		ImagePullPolicy:          corev1.PullIfNotPresent,
+		Args:                     append([]string{"-config", path.Join(helperConfigMountPath, HelperConfigFileName)}, args...),
+		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		SecurityContext:          meta.DefaultContainerSecurityContext(),
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      workloadAPIVolume,
+				MountPath: workloadAPIMountPath,
+				ReadOnly:  true,
+			},
+			{
+				Name:      certificatesVolume,
+				MountPath: certificatesMountPath,
+			},
+			{
+				Name:      helperConfigVolume,
+				MountPath: helperConfigMountPath,
+				ReadOnly:  true,
+			},
+		},
+	}
+}
+
+// Inject adds spiffe-helper to the provided pod spec if the cluster has mTLS enabled using SPIFFE.
+// An init container fetches the SVIDs before the pod starts, then a sidecar keeps them up to date.
+// The SVIDs are mounted in the provided container at each of the provided paths.
+func Inject(instance *v1beta1.TemporalCluster, spec *corev1.PodSpec, containerName string, mountPaths ...string) {
+	if !instance.MTLSWithSPIFFEEnabled() {
+		return
+	}
+
+	spec.Volumes = append(spec.Volumes,
+		corev1.Volume{
+			Name: workloadAPIVolume,
+			VolumeSource: corev1.VolumeSource{
+				CSI: &corev1.CSIVolumeSource{
+					Driver:   instance.Spec.MTLS.SPIFFE.GetCSIDriver(),
+					ReadOnly: ptr.To(true),
+				},
+			},
+		},
+		corev1.Volume{
+			Name: certificatesVolume,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					Medium: corev1.StorageMediumMemory,
+				},
+			},
+		},
+		corev1.Volume{
+			Name: helperConfigVolume,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: instance.ChildResourceName(HelperConfig),
+					},
+					DefaultMode: ptr.To[int32](corev1.ConfigMapVolumeSourceDefaultMode),
+				},
+			},
+		},
+	)
+
+	spec.InitContainers = append(spec.InitContainers, helperContainer(instance, "spiffe-helper-init", "-daemon-mode=false"))
+	spec.Containers = append(spec.Containers, helperContainer(instance, "spiffe-helper"))
+
+	for i := range spec.Containers {
+		if spec.Containers[i].Name != containerName {
+			continue
+		}
+
+		for _, mountPath := range mountPaths {
+			spec.Containers[i].VolumeMounts = append(spec.Containers[i].VolumeMounts, corev1.VolumeMount{
+				Name:      certificatesVolume,
+				MountPath: mountPath,
+				ReadOnly:  true,
+			})
+		}
+	}
+}
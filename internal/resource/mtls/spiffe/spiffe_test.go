@@ -0,0 +1,90 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package spiffe_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/spiffe"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestHelperConfigContent(t *testing.T) {
+	cluster := &v1beta1.TemporalCluster{
+		Spec: v1beta1.TemporalClusterSpec{
+			MTLS: &v1beta1.MTLSSpec{
+				Provider: v1beta1.SPIFFEMTLSProvider,
+				SPIFFE: &v1beta1.SPIFFEMTLSSpec{
+					SocketName: "agent.sock",
+				},
+			},
+		},
+	}
+
+	expected := `agent_address = "/spiffe-workload-api/agent.sock"
+cert_dir = "/spiffe-certificates"
+svid_file_name = "tls.crt"
+svid_key_file_name = "tls.key"
+svid_bundle_file_name = "ca.crt"
+`
+	assert.Equal(t, expected, spiffe.HelperConfigContent(cluster))
+}
+
+func TestInject(t *testing.T) {
+	cluster := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Spec: v1beta1.TemporalClusterSpec{
+			MTLS: &v1beta1.MTLSSpec{
+				Provider:  v1beta1.SPIFFEMTLSProvider,
+				Internode: &v1beta1.InternodeMTLSSpec{Enabled: true},
+			},
+		},
+	}
+
+	spec := &corev1.PodSpec{
+		Containers: []corev1.Container{{Name: "service"}},
+	}
+
+	spiffe.Inject(cluster, spec, "service", "/certs/ca", "/certs/internode")
+
+	assert.Len(t, spec.Volumes, 3)
+	assert.Equal(t, "csi.spiffe.io", spec.Volumes[0].CSI.Driver)
+	assert.Equal(t, "prod-spiffe-helper", spec.Volumes[2].ConfigMap.Name)
+
+	assert.Len(t, spec.InitContainers, 1)
+	assert.Contains(t, spec.InitContainers[0].Args, "-daemon-mode=false")
+	assert.Equal(t, "ghcr.io/spiffe/spiffe-helper:0.8.0", spec.InitContainers[0].Image)
+
+	assert.Len(t, spec.Containers, 2)
+	assert.Equal(t, "spiffe-helper", spec.Containers[1].Name)
+
+	mountPaths := []string{}
+	for _, mount := range spec.Containers[0].VolumeMounts {
+		mountPaths = append(mountPaths, mount.MountPath)
+	}
+	assert.Equal(t, []string{"/certs/ca", "/certs/internode"}, mountPaths)
+
+	// Nothing is injected if SPIFFE isn't the mTLS provider.
+	cluster.Spec.MTLS.Provider = v1beta1.CertManagerMTLSProvider
+	spec = &corev1.PodSpec{}
+	spiffe.Inject(cluster, spec, "service")
+	assert.Empty(t, spec.Volumes)
+}
@@ -0,0 +1,164 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package vault
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/strings/slices"
+)
+
+// Certificate describes a certificate issued by Vault and stored in a secret.
+type Certificate struct {
+	// SecretName is the name of the secret storing the certificate.
+	SecretName string
+	// Request contains the parameters used to issue the certificate.
+	Request IssueRequest
+}
+
+func duration(d *metav1.Duration) time.Duration {
+	if d == nil {
+		return 0
+	}
+	return d.Duration
+}
+
+func clientCertificate(instance *v1beta1.TemporalCluster, name string) Certificate {
+	dnsName := fmt.Sprintf("%s.%s", name, instance.ServerName())
+	certificate := Certificate{
+		SecretName: instance.ChildResourceName(certmanager.GetCertificateSecretName(name)),
+		Request: IssueRequest{
+			CommonName: dnsName,
+			DNSNames:   []string{dnsName},
+		},
+	}
+	if instance.Spec.MTLS.CertificatesDuration != nil {
+		certificate.Request.TTL = duration(instance.Spec.MTLS.CertificatesDuration.ClientCertificates)
+	}
+	return certificate
+}
+
+// Certificates returns the certificates to issue for the provided cluster.
+// Secrets are named like the ones generated by cert-manager, so they can be mounted the same way.
+func Certificates(instance *v1beta1.TemporalCluster) []Certificate {
+	if !instance.MTLSWithVaultEnabled() {
+		return nil
+	}
+
+	durations := instance.Spec.MTLS.CertificatesDuration
+	if durations == nil {
+		durations = &v1beta1.CertificatesDurationSpec{}
+	}
+
+	certificates := []Certificate{}
+	if instance.Spec.MTLS.InternodeEnabled() {
+		serverName := instance.Spec.MTLS.Internode.ServerName(instance)
+		certificates = append(certificates, Certificate{
+			SecretName: instance.ChildResourceName(certmanager.InternodeCertificate),
+			Request: IssueRequest{
+				CommonName: serverName,
+				DNSNames:   []string{serverName},
+				TTL:        duration(durations.InternodeCertificate),
+			},
+		})
+	}
+
+	if instance.Spec.MTLS.FrontendEnabled() {
+		serverName := instance.Spec.MTLS.Frontend.ServerName(instance)
+		certificates = append(certificates, Certificate{
+			SecretName: instance.ChildResourceName(certmanager.FrontendCertificate),
+			Request: IssueRequest{
+				CommonName:  serverName,
+				DNSNames:    instance.Spec.MTLS.Frontend.DNSNames(instance),
+				IPAddresses: instance.Spec.MTLS.Frontend.ExtraIPAddresses,
+				TTL:         duration(durations.FrontendCertificate),
+			},
+		})
+
+		if !instance.Spec.Services.InternalFrontend.IsEnabled() {
+			certificates = append(certificates, clientCertificate(instance, "worker"))
+		}
+
+		if instance.Spec.UI != nil && instance.Spec.UI.Enabled {
+			certificates = append(certificates, clientCertificate(instance, "ui"))
+		}
+
+		if instance.Spec.AdminTools != nil && instance.Spec.AdminTools.Enabled {
+			certificates = append(certificates, clientCertificate(instance, "admintools"))
+		}
+	}
+
+	return certificates
+}
+
+// SecretData returns the secret data for the provided issued certificate.
+func SecretData(issued *IssuedCertificate) map[string][]byte {
+	return map[string][]byte{
+		certmanager.TLSCA:   []byte(issued.CABundle()),
+		certmanager.TLSCert: []byte(issued.Certificate),
+		certmanager.TLSKey:  []byte(issued.PrivateKey),
+	}
+}
+
+// RenewalTime returns when the provided certificate should be renewed.
+// If renewBefore is not set, the certificate is renewed after 2/3 of its lifetime, like cert-manager does.
+func RenewalTime(cert *x509.Certificate, renewBefore *metav1.Duration) time.Time {
+	if renewBefore != nil {
+		return cert.NotAfter.Add(-renewBefore.Duration)
+	}
+
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	return cert.NotBefore.Add(lifetime * 2 / 3)
+}
+
+// ParseCertificate returns the leaf certificate stored in the provided secret.
+func ParseCertificate(secret *corev1.Secret) (*x509.Certificate, error) {
+	block, _ := pem.Decode(secret.Data[certmanager.TLSCert])
+	if block == nil {
+		return nil, errors.New("can't decode certificate")
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// MatchesRequest returns true if the provided certificate has been issued for the provided request.
+func MatchesRequest(cert *x509.Certificate, req IssueRequest) bool {
+	expected := slices.Clone(req.DNSNames)
+	actual := slices.Clone(cert.DNSNames)
+	sort.Strings(expected)
+	sort.Strings(actual)
+
+	expectedIPs := slices.Clone(req.IPAddresses)
+	actualIPs := make([]string, 0, len(cert.IPAddresses))
+	for _, ip := range cert.IPAddresses {
+		actualIPs = append(actualIPs, ip.String())
+	}
+	sort.Strings(expectedIPs)
+	sort.Strings(actualIPs)
+
+	return cert.Subject.CommonName == req.CommonName && slices.Equal(expected, actual) && slices.Equal(expectedIPs, actualIPs)
+}
@@ -0,0 +1,102 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package vault_test
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/vault"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCertificates(t *testing.T) {
+	cluster := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+		Spec: v1beta1.TemporalClusterSpec{
+			Services: &v1beta1.ServicesSpec{
+				Frontend: &v1beta1.ServiceSpec{ExternalHostname: "grpc.temporal.example.com"},
+			},
+			UI: &v1beta1.TemporalUISpec{Enabled: true},
+			MTLS: &v1beta1.MTLSSpec{
+				Provider:  v1beta1.VaultMTLSProvider,
+				Internode: &v1beta1.InternodeMTLSSpec{Enabled: true},
+				Frontend: &v1beta1.FrontendMTLSSpec{
+					Enabled:       true,
+					ExtraDNSNames: []string{"temporal.example.com"},
+				},
+				CertificatesDuration: &v1beta1.CertificatesDurationSpec{
+					ClientCertificates: &metav1.Duration{Duration: time.Hour},
+				},
+			},
+		},
+	}
+
+	certificates := vault.Certificates(cluster)
+
+	names := []string{}
+	for _, certificate := range certificates {
+		names = append(names, certificate.SecretName)
+	}
+	assert.Equal(t, []string{
+		"prod-internode-certificate",
+		"prod-frontend-certificate",
+		"prod-worker-mtls-certificate",
+		"prod-ui-mtls-certificate",
+	}, names)
+
+	assert.Equal(t, vault.IssueRequest{
+		CommonName: "prod-frontend.demo.svc.cluster.local",
+		DNSNames:   []string{"prod-frontend.demo.svc.cluster.local", "temporal.example.com", "grpc.temporal.example.com"},
+	}, certificates[1].Request)
+
+	assert.Equal(t, vault.IssueRequest{
+		CommonName: "ui.prod.demo.svc.cluster.local",
+		DNSNames:   []string{"ui.prod.demo.svc.cluster.local"},
+		TTL:        time.Hour,
+	}, certificates[3].Request)
+
+	cluster.Spec.MTLS.Provider = v1beta1.CertManagerMTLSProvider
+	assert.Empty(t, vault.Certificates(cluster))
+}
+
+func TestRenewalTime(t *testing.T) {
+	notBefore := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cert := &x509.Certificate{
+		NotBefore: notBefore,
+		NotAfter:  notBefore.Add(3 * time.Hour),
+	}
+
+	assert.Equal(t, notBefore.Add(2*time.Hour), vault.RenewalTime(cert, nil))
+	assert.Equal(t, notBefore.Add(150*time.Minute), vault.RenewalTime(cert, &metav1.Duration{Duration: 30 * time.Minute}))
+}
+
+func TestMatchesRequest(t *testing.T) {
+	cert := &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "frontend"},
+		DNSNames: []string{"b", "a"},
+	}
+
+	assert.True(t, vault.MatchesRequest(cert, vault.IssueRequest{CommonName: "frontend", DNSNames: []string{"a", "b"}}))
+	assert.False(t, vault.MatchesRequest(cert, vault.IssueRequest{CommonName: "frontend", DNSNames: []string{"a"}}))
+	assert.False(t, vault.MatchesRequest(cert, vault.IssueRequest{CommonName: "internode", DNSNames: []string{"a", "b"}}))
+}
@@ -0,0 +1,189 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package vault
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Client is a minimal HashiCorp Vault HTTP API client.
+// It only supports the endpoints needed to issue certificates from the PKI secrets engine.
+type Client struct {
+	address    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient returns a new Vault client for the provided address.
+// If caBundle is not empty, it's used to verify the Vault server certificate.
+func NewClient(address string, caBundle []byte) (*Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if len(caBundle) > 0 {
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(caBundle) {
+			return nil, errors.New("failed to parse vault CA bundle")
+		}
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    certPool,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	return &Client{
+		address: strings.TrimSuffix(address, "/"),
+		httpClient: &http.Client{
+			Transport: transport,
+			Timeout:   30 * time.Second,
+		},
+	}, nil
+}
+
+// SetToken sets the token used to authenticate requests.
+func (c *Client) SetToken(token string) {
+	c.token = token
+}
+
+// LoginWithKubernetes authenticates using the kubernetes auth method and sets the client's token.
+func (c *Client) LoginWithKubernetes(ctx context.Context, mountPath, role, jwt string) error {
+	resp := &struct {
+		Auth *struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}{}
+
+	body := map[string]string{
+		"role": role,
+		"jwt":  jwt,
+	}
+
+	err := c.do(ctx, fmt.Sprintf("auth/%s/login", strings.Trim(mountPath, "/")), body, resp)
+	if err != nil {
+		return fmt.Errorf("can't login to vault: %w", err)
+	}
+
+	if resp.Auth == nil || resp.Auth.ClientToken == "" {
+		return errors.New("can't login to vault: no token returned")
+	}
+
+	c.token = resp.Auth.ClientToken
+	return nil
+}
+
+// IssueRequest contains the parameters of a certificate to issue.
+type IssueRequest struct {
+	CommonName string
+	DNSNames   []string
+	// IPAddresses are the requested IP SANs.
+	IPAddresses []string
+	// TTL is the requested certificate lifetime. If zero, the role's default is used.
+	TTL time.Duration
+}
+
+// IssuedCertificate is a certificate issued by the PKI secrets engine.
+type IssuedCertificate struct {
+	Certificate string   `json:"certificate"`
+	PrivateKey  string   `json:"private_key"`
+	IssuingCA   string   `json:"issuing_ca"`
+	CAChain     []string `json:"ca_chain"`
+}
+
+// CABundle returns the PEM-encoded CA bundle of the issued certificate.
+func (c *IssuedCertificate) CABundle() string {
+	if len(c.CAChain) == 0 {
+		return c.IssuingCA
+	}
+	return strings.Join(c.CAChain, "\n")
+}
+
+// IssueCertificate issues a new certificate using the provided PKI mount and role.
+func (c *Client) IssueCertificate(ctx context.Context, mountPath, role string, req IssueRequest) (*IssuedCertificate, error) {
+	body := map[string]string{
+		"common_name": req.CommonName,
+		"alt_names":   strings.Join(req.DNSNames, ","),
+	}
+	if len(req.IPAddresses) > 0 {
+		body["ip_sans"] = strings.Join(req.IPAddresses, ",")
+	}
+	if req.TTL > 0 {
+		body["ttl"] = req.TTL.String()
+	}
+
+	resp := &struct {
+		Data *IssuedCertificate `json:"data"`
+	}{}
+
+	err := c.do(ctx, fmt.Sprintf("%s/issue/%s", strings.Trim(mountPath, "/"), role), body, resp)
+	if err != nil {
+		return nil, fmt.Errorf("can't issue certificate: %w", err)
+	}
+
+	if resp.Data == nil || resp.Data.Certificate == "" || resp.Data.PrivateKey == "" {
+		return nil, errors.New("can't issue certificate: empty response")
+	}
+
+	return resp.Data, nil
+}
+
+func (c *Client) do(ctx context.Context, path string, body, out any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/v1/%s", c.address, path), bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("X-Vault-Token", c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		vaultErr := &struct {
+			Errors []string `json:"errors"`
+		}{}
+		if json.Unmarshal(data, vaultErr) == nil && len(vaultErr.Errors) > 0 {
+			return fmt.Errorf("vault returned status %d: %s", resp.StatusCode, strings.Join(vaultErr.Errors, ", "))
+		}
+		return fmt.Errorf("vault returned status %d", resp.StatusCode)
+	}
+
+	return json.Unmarshal(data, out)
+}
@@ -0,0 +1,84 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package vault_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/vault"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]string{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		switch r.URL.Path {
+		case "/v1/auth/kubernetes/login":
+			assert.Equal(t, "temporal", body["role"])
+			assert.Equal(t, "jwt", body["jwt"])
+			_, _ = w.Write([]byte(`{"auth": {"client_token": "token"}}`))
+		case "/v1/pki/issue/temporal":
+			if r.Header.Get("X-Vault-Token") != "token" {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"errors": ["permission denied"]}`))
+				return
+			}
+			assert.Equal(t, "frontend.demo.svc.cluster.local", body["common_name"])
+			assert.Equal(t, "frontend.demo.svc.cluster.local,temporal.example.com", body["alt_names"])
+			assert.Equal(t, "1h0m0s", body["ttl"])
+			_, _ = w.Write([]byte(`{"data": {"certificate": "cert", "private_key": "key", "issuing_ca": "ca", "ca_chain": ["intermediate", "root"]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	req := vault.IssueRequest{
+		CommonName: "frontend.demo.svc.cluster.local",
+		DNSNames:   []string{"frontend.demo.svc.cluster.local", "temporal.example.com"},
+		TTL:        time.Hour,
+	}
+
+	client, err := vault.NewClient(server.URL+"/", nil)
+	require.NoError(t, err)
+
+	_, err = client.IssueCertificate(context.Background(), "pki", "temporal", req)
+	assert.ErrorContains(t, err, "vault returned status 403: permission denied")
+
+	err = client.LoginWithKubernetes(context.Background(), "kubernetes", "temporal", "jwt")
+	require.NoError(t, err)
+
+	issued, err := client.IssueCertificate(context.Background(), "/pki/", "temporal", req)
+	require.NoError(t, err)
+	assert.Equal(t, "cert", issued.Certificate)
+	assert.Equal(t, "key", issued.PrivateKey)
+	assert.Equal(t, "intermediate\nroot", issued.CABundle())
+}
+
+func TestNewClientInvalidCABundle(t *testing.T) {
+	_, err := vault.NewClient("https://vault:8200", []byte("invalid"))
+	assert.Error(t, err)
+}
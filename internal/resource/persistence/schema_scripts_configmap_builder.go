@@ -26,6 +26,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/alexandrevilain/controller-tools/pkg/hash"
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"github.com/alexandrevilain/temporal-operator/internal/metadata"
 	"github.com/alexandrevilain/temporal-operator/pkg/version"
@@ -56,6 +57,14 @@ const (
 	CreateAdvancedVisibilityDatabaseScript  = "create-advanced-visibility-database.sh"
 	SetupAdvancedVisibilitySchemaScript     = "setup-advanced-visibility-schema.sh"
 	UpdateAdvancedVisibilitySchemaScript    = "update-advanced-visibility-schema.sh"
+	DropDefaultDatabaseScript               = "drop-default-database.sh"
+	DropVisibilityDatabaseScript            = "drop-visibility-database.sh"
+	DropSecondaryVisibilityDatabaseScript   = "drop-secondary-visibility-database.sh"
+	DropAdvancedVisibilityDatabaseScript    = "drop-advanced-visibility-database.sh"
+
+	ApplyVisibilityILMPolicyScript          = "apply-visibility-ilm-policy.sh"
+	ApplySecondaryVisibilityILMPolicyScript = "apply-secondary-visibility-ilm-policy.sh"
+	ApplyAdvancedVisibilityILMPolicyScript  = "apply-advanced-visibility-ilm-policy.sh"
 
 	defaultSchemaPath    = "temporal"
 	visibilitySchemaPath = "visibility"
@@ -119,7 +128,7 @@ func (b *SchemaScriptsConfigmapBuilder) computeSchemaDir(storeType v1beta1.Datas
 	case v1beta1.PostgresSQLDatastore:
 		storeSchemaPath = postgreSQLSchemaPath
 		storeVersionSchemaPath = postgreSQLVersionSchemaPath
-	case v1beta1.PostgresSQL12Datastore:
+	case v1beta1.PostgresSQL12Datastore, v1beta1.CockroachDBDatastore:
 		storeSchemaPath = postgreSQLSchemaPath
 		storeVersionSchemaPath = postgreSQL12VersionSchemaPath
 	case v1beta1.MySQLDatastore:
@@ -172,13 +181,13 @@ func (b *SchemaScriptsConfigmapBuilder) getSQLArgs(spec *v1beta1.DatastoreSpec)
 	if spec.PasswordSecretRef != nil {
 		args.Set(schema.CLIOptPassword, fmt.Sprintf("$%s", spec.GetPasswordEnvVarName())) // --password
 	}
-	args.Set(schema.CLIOptDatabase, spec.SQL.DatabaseName) // --database
-	args.Set(schema.CLIOptPluginName, spec.SQL.PluginName) // --plugin
+	args.Set(schema.CLIOptDatabase, spec.SQL.DatabaseName)              // --database
+	args.Set(schema.CLIOptPluginName, spec.SQL.GetTemporalPluginName()) // --plugin
 	// TODO(alexandrevilain): support schema.CLIOptTimeout
 
-	if len(spec.SQL.ConnectAttributes) > 0 {
+	if connectAttributes := spec.SQL.GetConnectAttributes(); len(connectAttributes) > 0 {
 		attributes := url.Values{}
-		for k, v := range spec.SQL.ConnectAttributes {
+		for k, v := range connectAttributes {
 			attributes.Add(k, v)
 		}
 		args.Set(schema.CLIOptConnectAttributes, attributes.Encode())
@@ -222,19 +231,20 @@ func (b *SchemaScriptsConfigmapBuilder) getStoreArgs(spec *v1beta1.DatastoreSpec
 		args = b.getCassandraArgs(spec)
 	case v1beta1.PostgresSQLDatastore,
 		v1beta1.PostgresSQL12Datastore,
+		v1beta1.CockroachDBDatastore,
 		v1beta1.MySQLDatastore,
 		v1beta1.MySQL8Datastore:
 		args, err = b.getSQLArgs(spec)
 		if err != nil {
 			return nil, err
 		}
-	case v1beta1.ElasticsearchDatastore, v1beta1.UnknownDatastore:
+	case v1beta1.ElasticsearchDatastore, v1beta1.SQLiteDatastore, v1beta1.UnknownDatastore:
 		return nil, fmt.Errorf("unsupported datastore: %s", spec.GetType())
 	}
 
 	if spec.TLS != nil && spec.TLS.Enabled {
 		args.Set(schema.CLIFlagEnableTLS, "")
-		if spec.TLS.CaFileRef != nil {
+		if spec.TLS.HasCaFile() {
 			args.Set(schema.CLIFlagTLSCaFile, spec.GetTLSCaFileMountPath())
 		}
 
@@ -263,6 +273,7 @@ func (b *SchemaScriptsConfigmapBuilder) getStoreTool(storeType v1beta1.Datastore
 	switch storeType {
 	case v1beta1.PostgresSQLDatastore,
 		v1beta1.PostgresSQL12Datastore,
+		v1beta1.CockroachDBDatastore,
 		v1beta1.MySQLDatastore,
 		v1beta1.MySQL8Datastore:
 		tool = "temporal-sql-tool"
@@ -287,6 +298,50 @@ func (b *SchemaScriptsConfigmapBuilder) getESVersion(es *v1beta1.ElasticsearchSp
 	return version
 }
 
+func (b *SchemaScriptsConfigmapBuilder) getESSchemaData(spec *v1beta1.DatastoreSpec) esSchemaData {
+	data := esSchemaData{
+		baseData:       b.baseData(),
+		Version:        b.getESVersion(spec.Elasticsearch),
+		URL:            spec.Elasticsearch.URL,
+		Username:       spec.Elasticsearch.Username,
+		PasswordEnvVar: spec.GetPasswordEnvVarName(),
+		TLSArgs:        b.getCurlTLSArgs(spec),
+		Indices:        spec.Elasticsearch.Indices,
+	}
+
+	if policy := spec.Elasticsearch.IndexLifecyclePolicy; policy != nil && policy.Policy != nil {
+		data.ILMPolicyName = policy.Name
+		data.ILMPolicy = string(policy.Policy.Raw)
+	}
+
+	return data
+}
+
+// getCurlTLSArgs returns curl arguments used to connect to the datastore using TLS.
+func (b *SchemaScriptsConfigmapBuilder) getCurlTLSArgs(spec *v1beta1.DatastoreSpec) string {
+	if spec.TLS == nil || !spec.TLS.Enabled {
+		return ""
+	}
+
+	args := []string{}
+	if spec.TLS.HasCaFile() {
+		args = append(args, fmt.Sprintf(`--cacert "%s"`, spec.GetTLSCaFileMountPath()))
+	}
+
+	if spec.TLS.CertFileRef != nil && spec.TLS.KeyFileRef != nil {
+		args = append(args,
+			fmt.Sprintf(`--cert "%s"`, spec.GetTLSCertFileMountPath()),
+			fmt.Sprintf(`--key "%s"`, spec.GetTLSKeyFileMountPath()),
+		)
+	}
+
+	if !spec.TLS.EnableHostVerification {
+		args = append(args, "--insecure")
+	}
+
+	return strings.Join(args, " ")
+}
+
 func (b *SchemaScriptsConfigmapBuilder) renderTemplate(name string, data any) (string, error) {
 	var result bytes.Buffer
 	err := templates[name].Execute(&result, data)
@@ -337,14 +392,7 @@ func (b *SchemaScriptsConfigmapBuilder) GetStoreCreateTemplate(spec *v1beta1.Dat
 func (b *SchemaScriptsConfigmapBuilder) GetStoreSetupTemplate(spec *v1beta1.DatastoreSpec) (string, error) {
 	storeType := spec.GetType()
 	if storeType == v1beta1.ElasticsearchDatastore {
-		data := esSchemaData{
-			baseData:       b.baseData(),
-			Version:        b.getESVersion(spec.Elasticsearch),
-			URL:            spec.Elasticsearch.URL,
-			Username:       spec.Elasticsearch.Username,
-			PasswordEnvVar: spec.GetPasswordEnvVarName(),
-			Indices:        spec.Elasticsearch.Indices,
-		}
+		data := b.getESSchemaData(spec)
 		return b.renderTemplate(setupESVisibility, data)
 	}
 
@@ -366,14 +414,7 @@ func (b *SchemaScriptsConfigmapBuilder) GetStoreSetupTemplate(spec *v1beta1.Data
 func (b *SchemaScriptsConfigmapBuilder) GetStoreUpdateTemplate(spec *v1beta1.DatastoreSpec, targetSchema Schema) (string, error) {
 	storeType := spec.GetType()
 	if storeType == v1beta1.ElasticsearchDatastore {
-		data := esSchemaData{
-			baseData:       b.baseData(),
-			Version:        b.getESVersion(spec.Elasticsearch),
-			URL:            spec.Elasticsearch.URL,
-			Username:       spec.Elasticsearch.Username,
-			PasswordEnvVar: spec.GetPasswordEnvVarName(),
-			Indices:        spec.Elasticsearch.Indices,
-		}
+		data := b.getESSchemaData(spec)
 		return b.renderTemplate(updateESVisibility, data)
 	}
 
@@ -392,6 +433,71 @@ func (b *SchemaScriptsConfigmapBuilder) GetStoreUpdateTemplate(spec *v1beta1.Dat
 	return b.renderTemplate(updateSchemaTemplate, data)
 }
 
+// GetStoreILMPolicyTemplate returns the script applying the index lifecycle policy of the provided datastore.
+// It does nothing if the datastore isn't an elasticsearch datastore or has no policy.
+func (b *SchemaScriptsConfigmapBuilder) GetStoreILMPolicyTemplate(spec *v1beta1.DatastoreSpec) (string, error) {
+	if spec.GetType() != v1beta1.ElasticsearchDatastore {
+		return b.renderTemplate(noOpTemplate, b.baseData())
+	}
+
+	return b.renderTemplate(applyESILMPolicy, b.getESSchemaData(spec))
+}
+
+// ILMPolicyHash returns the hash of the index lifecycle policy of the provided datastore.
+// It returns an empty string if the datastore has no policy.
+func ILMPolicyHash(spec *v1beta1.DatastoreSpec) (string, error) {
+	if spec.Elasticsearch == nil || spec.Elasticsearch.IndexLifecyclePolicy == nil || spec.Elasticsearch.IndexLifecyclePolicy.Policy == nil {
+		return "", nil
+	}
+
+	return hash.Sha256(spec.Elasticsearch.IndexLifecyclePolicy)
+}
+
+// IsDroppable returns true if the provided datastore has been created by the operator
+// and can be dropped on cluster deletion. Datastores with skipCreate or skipSchemaSetup
+// have been provisioned by an administrator, they are never dropped.
+func IsDroppable(spec *v1beta1.DatastoreSpec) bool {
+	return !spec.SkipCreate && !spec.SkipSchemaSetup
+}
+
+// GetStoreDropTemplate returns the script dropping the database, keyspace or indices of the provided datastore.
+func (b *SchemaScriptsConfigmapBuilder) GetStoreDropTemplate(spec *v1beta1.DatastoreSpec) (string, error) {
+	storeType := spec.GetType()
+	if !IsDroppable(spec) {
+		return b.renderTemplate(noOpTemplate, b.baseData())
+	}
+
+	if storeType == v1beta1.ElasticsearchDatastore {
+		data := b.getESSchemaData(spec)
+		return b.renderTemplate(dropESVisibility, data)
+	}
+
+	args, err := b.getStoreArgs(spec)
+	if err != nil {
+		return "", fmt.Errorf("can't get store args: %w", err)
+	}
+
+	if storeType == v1beta1.CassandraDatastore {
+		data := createKeyspace{
+			baseData:       b.baseData(),
+			Tool:           b.getStoreTool(storeType),
+			ConnectionArgs: b.argsMapToString(args),
+			KeyspaceName:   spec.Cassandra.Keyspace,
+		}
+
+		return b.renderTemplate(dropCassandraTemplate, data)
+	}
+
+	data := createDatabase{
+		baseData:       b.baseData(),
+		Tool:           b.getStoreTool(storeType),
+		ConnectionArgs: b.argsMapToString(args),
+		DatabaseName:   spec.SQL.DatabaseName,
+	}
+
+	return b.renderTemplate(dropDatabaseTemplate, data)
+}
+
 func (b *SchemaScriptsConfigmapBuilder) Update(object client.Object) error {
 	configMap := object.(*corev1.ConfigMap)
 	configMap.Data = map[string]string{}
@@ -412,6 +518,11 @@ func (b *SchemaScriptsConfigmapBuilder) Update(object client.Object) error {
 		return err
 	}
 
+	configMap.Data[DropDefaultDatabaseScript], err = b.GetStoreDropTemplate(b.instance.Spec.Persistence.DefaultStore)
+	if err != nil {
+		return err
+	}
+
 	configMap.Data[CreateVisibilityDatabaseScript], err = b.GetStoreCreateTemplate(b.instance.Spec.Persistence.VisibilityStore)
 	if err != nil {
 		return err
@@ -427,6 +538,16 @@ func (b *SchemaScriptsConfigmapBuilder) Update(object client.Object) error {
 		return err
 	}
 
+	configMap.Data[DropVisibilityDatabaseScript], err = b.GetStoreDropTemplate(b.instance.Spec.Persistence.VisibilityStore)
+	if err != nil {
+		return err
+	}
+
+	configMap.Data[ApplyVisibilityILMPolicyScript], err = b.GetStoreILMPolicyTemplate(b.instance.Spec.Persistence.VisibilityStore)
+	if err != nil {
+		return err
+	}
+
 	secondaryVisibilityStore := b.instance.Spec.Persistence.SecondaryVisibilityStore
 	if secondaryVisibilityStore != nil {
 		configMap.Data[CreateSecondaryVisibilityDatabaseScript], err = b.GetStoreCreateTemplate(secondaryVisibilityStore)
@@ -443,6 +564,16 @@ func (b *SchemaScriptsConfigmapBuilder) Update(object client.Object) error {
 		if err != nil {
 			return err
 		}
+
+		configMap.Data[DropSecondaryVisibilityDatabaseScript], err = b.GetStoreDropTemplate(secondaryVisibilityStore)
+		if err != nil {
+			return err
+		}
+
+		configMap.Data[ApplySecondaryVisibilityILMPolicyScript], err = b.GetStoreILMPolicyTemplate(secondaryVisibilityStore)
+		if err != nil {
+			return err
+		}
 	}
 
 	advancedVisibilityStore := b.instance.Spec.Persistence.AdvancedVisibilityStore
@@ -461,6 +592,16 @@ func (b *SchemaScriptsConfigmapBuilder) Update(object client.Object) error {
 		if err != nil {
 			return err
 		}
+
+		configMap.Data[DropAdvancedVisibilityDatabaseScript], err = b.GetStoreDropTemplate(advancedVisibilityStore)
+		if err != nil {
+			return err
+		}
+
+		configMap.Data[ApplyAdvancedVisibilityILMPolicyScript], err = b.GetStoreILMPolicyTemplate(advancedVisibilityStore)
+		if err != nil {
+			return err
+		}
 	}
 
 	if err := controllerutil.SetControllerReference(b.instance, configMap, b.scheme); err != nil {
@@ -0,0 +1,110 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package persistence
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSQLArgs(t *testing.T) {
+	tests := map[string]struct {
+		sql          *v1beta1.SQLSpec
+		expectedArgs string
+	}{
+		"postgres12": {
+			sql: &v1beta1.SQLSpec{
+				PluginName:   "postgres12",
+				ConnectAddr:  "postgres:5432",
+				User:         "temporal",
+				DatabaseName: "temporal",
+			},
+			expectedArgs: `--endpoint="postgres" --port="5432" --user="temporal" --database="temporal" --plugin="postgres12"`,
+		},
+		"cockroachdb": {
+			sql: &v1beta1.SQLSpec{
+				PluginName:   "cockroachdb",
+				ConnectAddr:  "cockroachdb:26257",
+				User:         "root",
+				DatabaseName: "temporal",
+			},
+			expectedArgs: `--endpoint="cockroachdb" --port="26257" --user="root" --database="temporal" --plugin="postgres12" --connect-attributes="serial_normalization=sql_sequence"`,
+		},
+		"cockroachdb with overridden session variable": {
+			sql: &v1beta1.SQLSpec{
+				PluginName:   "cockroachdb",
+				ConnectAddr:  "cockroachdb:26257",
+				User:         "root",
+				DatabaseName: "temporal",
+				ConnectAttributes: map[string]string{
+					"serial_normalization": "unordered_rowid",
+				},
+			},
+			expectedArgs: `--endpoint="cockroachdb" --port="26257" --user="root" --database="temporal" --plugin="postgres12" --connect-attributes="serial_normalization=unordered_rowid"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			b := &SchemaScriptsConfigmapBuilder{}
+			args, err := b.getSQLArgs(&v1beta1.DatastoreSpec{SQL: test.sql})
+			assert.NoError(tt, err)
+			assert.Equal(tt, test.expectedArgs, b.argsMapToString(args))
+		})
+	}
+}
+
+func TestIsDroppable(t *testing.T) {
+	tests := map[string]struct {
+		spec     *v1beta1.DatastoreSpec
+		expected bool
+	}{
+		"sql": {
+			spec:     &v1beta1.DatastoreSpec{SQL: &v1beta1.SQLSpec{PluginName: "postgres12"}},
+			expected: true,
+		},
+		"sql with skipCreate": {
+			spec:     &v1beta1.DatastoreSpec{SQL: &v1beta1.SQLSpec{PluginName: "postgres12"}, SkipCreate: true},
+			expected: false,
+		},
+		"sql with skipSchemaSetup": {
+			spec:     &v1beta1.DatastoreSpec{SQL: &v1beta1.SQLSpec{PluginName: "postgres12"}, SkipSchemaSetup: true},
+			expected: false,
+		},
+		"elasticsearch": {
+			spec:     &v1beta1.DatastoreSpec{Elasticsearch: &v1beta1.ElasticsearchSpec{}},
+			expected: true,
+		},
+		"elasticsearch with skipCreate": {
+			spec:     &v1beta1.DatastoreSpec{Elasticsearch: &v1beta1.ElasticsearchSpec{}, SkipCreate: true},
+			expected: false,
+		},
+		"elasticsearch with skipSchemaSetup": {
+			spec:     &v1beta1.DatastoreSpec{Elasticsearch: &v1beta1.ElasticsearchSpec{}, SkipSchemaSetup: true},
+			expected: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			assert.Equal(tt, test.expected, IsDroppable(test.spec))
+		})
+	}
+}
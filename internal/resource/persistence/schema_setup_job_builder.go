@@ -22,6 +22,7 @@ import (
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/istio"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/linkerd"
 	batchv1 "k8s.io/api/batch/v1"
@@ -118,28 +119,26 @@ func (b *SchemaJobBuilder) Build() client.Object {
 				Spec: corev1.PodSpec{
 					RestartPolicy:            corev1.RestartPolicyOnFailure,
 					ImagePullSecrets:         b.instance.Spec.ImagePullSecrets,
-					ServiceAccountName:       b.instance.ChildResourceName(ServiceNameSuffix),
-					DeprecatedServiceAccount: b.instance.ChildResourceName(ServiceNameSuffix),
+					ServiceAccountName:       b.instance.Spec.JobServiceAccount.GetName(b.instance.ChildResourceName(ServiceNameSuffix)),
+					DeprecatedServiceAccount: b.instance.Spec.JobServiceAccount.GetName(b.instance.ChildResourceName(ServiceNameSuffix)),
 					Containers: []corev1.Container{
 						{
 							Name:                     "schema-script-runner",
-							Image:                    fmt.Sprintf("%s:%s", b.instance.Spec.AdminTools.Image, b.instance.Spec.Version),
-							ImagePullPolicy:          corev1.PullIfNotPresent,
+							Image:                    meta.ImageReference(b.instance.Spec.AdminTools.Image, b.instance.Spec.Version.String(), b.instance.Spec.AdminTools.ImageDigest),
+							ImagePullPolicy:          meta.ImagePullPolicyOrDefault(b.instance.Spec.AdminTools.ImagePullPolicy),
 							Resources:                b.instance.Spec.JobResources,
 							TerminationMessagePath:   corev1.TerminationMessagePathDefault,
 							TerminationMessagePolicy: corev1.TerminationMessageReadFile,
 							Command:                  append([]string{"/bin/sh", "-c"}, b.command...),
 							Env:                      envVars,
-							SecurityContext: &corev1.SecurityContext{
-								AllowPrivilegeEscalation: ptr.To(false),
-							},
-							VolumeMounts: volumeMounts,
+							SecurityContext:          meta.DefaultContainerSecurityContext(),
+							VolumeMounts:             volumeMounts,
 						},
 					},
 					InitContainers:                b.instance.Spec.JobInitContainers,
 					TerminationGracePeriodSeconds: ptr.To[int64](30),
 					DNSPolicy:                     corev1.DNSClusterFirst,
-					SecurityContext:               &corev1.PodSecurityContext{},
+					SecurityContext:               meta.DefaultPodSecurityContext(1000, false),
 					SchedulerName:                 corev1.DefaultSchedulerName,
 					Volumes:                       volumes,
 				},
@@ -0,0 +1,82 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package persistence_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/persistence"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+)
+
+func TestSchemaJobBuilderSecurityContext(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	cluster := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+		Spec: v1beta1.TemporalClusterSpec{
+			Version: version.MustNewVersionFromString("1.23.0"),
+			Services: &v1beta1.ServicesSpec{
+				Frontend: &v1beta1.ServiceSpec{Port: ptr.To(7233)},
+			},
+			AdminTools: &v1beta1.TemporalAdminToolsSpec{
+				Image: "temporalio/admin-tools",
+			},
+			Persistence: v1beta1.TemporalPersistenceSpec{
+				DefaultStore: &v1beta1.DatastoreSpec{
+					Name: v1beta1.DefaultStoreName,
+					SQL:  &v1beta1.SQLSpec{PluginName: "postgres12"},
+				},
+				VisibilityStore: &v1beta1.DatastoreSpec{
+					Name: v1beta1.VisibilityStoreName,
+					SQL:  &v1beta1.SQLSpec{PluginName: "postgres12"},
+				},
+			},
+		},
+	}
+
+	builder := persistence.NewSchemaJobBuilder(cluster, scheme, "setup-default-schema", []string{"/etc/scripts/setup-default-schema.sh"})
+	object := builder.Build()
+	require.NoError(t, builder.Update(object))
+
+	podSpec := object.(*batchv1.Job).Spec.Template.Spec
+	assert.Equal(t, &corev1.PodSecurityContext{
+		RunAsUser:      ptr.To[int64](1000),
+		RunAsGroup:     ptr.To[int64](1000),
+		RunAsNonRoot:   ptr.To(true),
+		SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}, podSpec.SecurityContext)
+	require.Len(t, podSpec.Containers, 1)
+	assert.Equal(t, &corev1.SecurityContext{
+		AllowPrivilegeEscalation: ptr.To(false),
+		RunAsNonRoot:             ptr.To(true),
+		Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}, podSpec.Containers[0].SecurityContext)
+}
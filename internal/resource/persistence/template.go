@@ -39,6 +39,14 @@ const (
 	updateSchemaTemplate = "update-schema.sh"
 	updateESVisibility   = "update-es-visibility.sh"
 
+	// Index lifecycle policy templates.
+	applyESILMPolicy = "apply-es-ilm-policy.sh"
+
+	// Drop datastores templates.
+	dropCassandraTemplate = "drop-cassandra.sh"
+	dropDatabaseTemplate  = "drop-database.sh"
+	dropESVisibility      = "drop-es-visibility.sh"
+
 	// noOpTemplate does nothing.
 	noOpTemplate = "no-op.sh"
 )
@@ -77,18 +85,44 @@ var (
 			{{ .Tool }} {{ .ConnectionArgs }} update-schema -d {{ .SchemaDir }}
 			{{ template "scripts" . }}
 		`),
+		dropCassandraTemplate: dedent.Dedent(`
+			#!/bin/bash
+			{{ .Tool }} {{ .ConnectionArgs }} drop-keyspace -k {{ .KeyspaceName }} --force
+			{{ template "scripts" . }}
+		`),
+		dropDatabaseTemplate: dedent.Dedent(`
+			#!/bin/bash
+			{{ .Tool }} {{ .ConnectionArgs }} drop-database --force
+			{{ template "scripts" . }}
+		`),
+		dropESVisibility: dedent.Dedent(`
+			#!/bin/bash
+			# Missing indices are ignored, so the script can be run again.
+			curl --fail --user "{{ .Username }}":"${{ .PasswordEnvVar }}"{{ with .TLSArgs }} {{ . }}{{ end }} -X DELETE "{{ .URL }}/{{ .Indices.Visibility }}?ignore_unavailable=true" --write-out "\n"
+			{{ if .Indices.SecondaryVisibility }}
+			curl --fail --user "{{ .Username }}":"${{ .PasswordEnvVar }}"{{ with .TLSArgs }} {{ . }}{{ end }} -X DELETE "{{ .URL }}/{{ .Indices.SecondaryVisibility }}?ignore_unavailable=true" --write-out "\n"
+			{{ end }}
+			curl --user "{{ .Username }}":"${{ .PasswordEnvVar }}"{{ with .TLSArgs }} {{ . }}{{ end }} -X DELETE "{{ .URL }}/_template/{{ .Indices.Visibility }}_template" --write-out "\n"
+			{{ template "scripts" . }}
+		`),
+		applyESILMPolicy: dedent.Dedent(`
+			#!/bin/bash
+			{{ template "ilm" . }}
+			{{ template "scripts" . }}
+		`),
 		setupESVisibility: dedent.Dedent(`
 			#!/bin/bash
 			# Change index_patterns from temporal_visibility_v1* to {{ .Indices.Visibility }}* at index_template_{{ .Version }}.json before apply
 			sed 's/temporal_visibility_v1./{{ .Indices.Visibility }}*/g' /etc/temporal/schema/elasticsearch/visibility/index_template_{{ .Version }}.json > /tmp/index_template_{{ .Version }}.json
 
-			curl --fail --user "{{ .Username }}":"${{ .PasswordEnvVar }}" -X PUT "{{ .URL }}/_cluster/settings" -H "Content-Type: application/json" --data-binary @/etc/temporal/schema/elasticsearch/visibility/cluster_settings_{{ .Version }}.json --write-out "\n"
-			curl --fail --user "{{ .Username }}":"${{ .PasswordEnvVar }}" -X PUT "{{ .URL }}/_template/{{ .Indices.Visibility }}_template" -H "Content-Type: application/json" --data-binary @/tmp/index_template_{{ .Version }}.json --write-out "\n"
+			curl --fail --user "{{ .Username }}":"${{ .PasswordEnvVar }}"{{ with .TLSArgs }} {{ . }}{{ end }} -X PUT "{{ .URL }}/_cluster/settings" -H "Content-Type: application/json" --data-binary @/etc/temporal/schema/elasticsearch/visibility/cluster_settings_{{ .Version }}.json --write-out "\n"
+			curl --fail --user "{{ .Username }}":"${{ .PasswordEnvVar }}"{{ with .TLSArgs }} {{ . }}{{ end }} -X PUT "{{ .URL }}/_template/{{ .Indices.Visibility }}_template" -H "Content-Type: application/json" --data-binary @/tmp/index_template_{{ .Version }}.json --write-out "\n"
 			# No --fail here because create index is not idempotent operaton.
-			curl --user "{{ .Username }}":"${{ .PasswordEnvVar }}" -X PUT "{{ .URL }}/{{ .Indices.Visibility }}" --write-out "\n"
+			curl --user "{{ .Username }}":"${{ .PasswordEnvVar }}"{{ with .TLSArgs }} {{ . }}{{ end }} -X PUT "{{ .URL }}/{{ .Indices.Visibility }}" --write-out "\n"
 			{{ if .Indices.SecondaryVisibility }}
-			curl --user "{{ .Username }}":"${{ .PasswordEnvVar }}" -X PUT "{{ .URL }}/{{ .Indices.SecondaryVisibility }}" --write-out "\n"
+			curl --user "{{ .Username }}":"${{ .PasswordEnvVar }}"{{ with .TLSArgs }} {{ . }}{{ end }} -X PUT "{{ .URL }}/{{ .Indices.SecondaryVisibility }}" --write-out "\n"
 			{{ end }}
+			{{ template "ilm" . }}
 			{{ template "scripts" . }}
 		`),
 		updateESVisibility: dedent.Dedent(`
@@ -126,7 +160,7 @@ var (
 						}
 						'
 
-						curl --silent --user "{{ .Username }}":"${{ .PasswordEnvVar }}" -X PUT "{{ .URL }}/{{ .Indices.Visibility }}${doc_type}/_mapping" -H "Content-Type: application/json" --data-binary "$new_mapping" | jq
+						curl --silent --user "{{ .Username }}":"${{ .PasswordEnvVar }}"{{ with .TLSArgs }} {{ . }}{{ end }} -X PUT "{{ .URL }}/{{ .Indices.Visibility }}${doc_type}/_mapping" -H "Content-Type: application/json" --data-binary "$new_mapping" | jq
 						;;
 					v3)
 						echo "Upgrading to schema v3"
@@ -141,7 +175,7 @@ var (
 						}
 						'
 
-						curl --silent --user "{{ .Username }}":"${{ .PasswordEnvVar }}" -X PUT "{{ .URL }}/{{ .Indices.Visibility }}/_mapping" -H "Content-Type: application/json" --data-binary "$new_mapping" | jq
+						curl --silent --user "{{ .Username }}":"${{ .PasswordEnvVar }}"{{ with .TLSArgs }} {{ . }}{{ end }} -X PUT "{{ .URL }}/{{ .Indices.Visibility }}/_mapping" -H "Content-Type: application/json" --data-binary "$new_mapping" | jq
 						;;
 					v4)
 						echo "Upgrading to schema v4"
@@ -156,7 +190,7 @@ var (
 						}
 						'
 
-						curl --silent --user "{{ .Username }}":"${{ .PasswordEnvVar }}" -X PUT "{{ .URL }}/{{ .Indices.Visibility }}/_mapping" -H "Content-Type: application/json" --data-binary "$new_mapping" | jq
+						curl --silent --user "{{ .Username }}":"${{ .PasswordEnvVar }}"{{ with .TLSArgs }} {{ . }}{{ end }} -X PUT "{{ .URL }}/{{ .Indices.Visibility }}/_mapping" -H "Content-Type: application/json" --data-binary "$new_mapping" | jq
 						;;
 					v5)
 						echo "Upgrading to schema v5"
@@ -171,18 +205,20 @@ var (
 						}
 						'
 
-						curl --silent --user "{{ .Username }}":"${{ .PasswordEnvVar }}" -X PUT "{{ .URL }}/{{ .Indices.Visibility }}/_mapping" -H "Content-Type: application/json" --data-binary "$new_mapping" | jq
+						curl --silent --user "{{ .Username }}":"${{ .PasswordEnvVar }}"{{ with .TLSArgs }} {{ . }}{{ end }} -X PUT "{{ .URL }}/{{ .Indices.Visibility }}/_mapping" -H "Content-Type: application/json" --data-binary "$new_mapping" | jq
 					;;
 				esac
 			}
 
+			{{ template "ilm" . }}
+
 			# Get the expected schema version from the current simlink pointing to the versionned.
 			expected_version=$(realpath /etc/temporal/schema/elasticsearch/visibility/index_template_v7.json | sed -e 's/.*versioned\/\(.*\)\/index_template_v7.json.*/\1/')
 			current_version=""
 			current_version_found=false
 
 			# Get the current_mapping value in elasticsearch.
-			current_mapping=$(curl --silent --user "{{ .Username }}":"${{ .PasswordEnvVar }}" {{ .URL }}/{{ .Indices.Visibility }})
+			current_mapping=$(curl --silent --user "{{ .Username }}":"${{ .PasswordEnvVar }}"{{ with .TLSArgs }} {{ . }}{{ end }} {{ .URL }}/{{ .Indices.Visibility }})
 
 			# Guess current mapping version
 			# v0 does not have the "ExecutionDuration" property
@@ -268,7 +304,7 @@ var (
 
 			do_upgrade $expected_version
 
-			until curl --silent --user "{{ .Username }}":"${{ .PasswordEnvVar }}" "{{ .URL }}/_cluster/health/{{ .Indices.Visibility }}" | jq --exit-status '.status=="green" | .'; do
+			until curl --silent --user "{{ .Username }}":"${{ .PasswordEnvVar }}"{{ with .TLSArgs }} {{ . }}{{ end }} "{{ .URL }}/_cluster/health/{{ .Indices.Visibility }}" | jq --exit-status '.status=="green" | .'; do
 				echo "Waiting for Elasticsearch index {{ .Indices.Visibility }} become green."
 				sleep 1
 			done
@@ -316,7 +352,10 @@ type (
 		URL            string
 		Username       string
 		PasswordEnvVar string
+		TLSArgs        string
 		Indices        v1beta1.ElasticsearchIndices
+		ILMPolicyName  string
+		ILMPolicy      string
 	}
 )
 
@@ -335,9 +374,25 @@ var proxyShutdownScriptsContent = dedent.Dedent(`
 		{{- end -}}
 	`)
 
+// esILMScriptsContent creates or updates the index lifecycle policy and attaches it to visibility indices.
+var esILMScriptsContent = dedent.Dedent(`
+		{{- define "ilm" -}}
+		{{- if .ILMPolicyName -}}
+		curl --fail --user "{{ .Username }}":"${{ .PasswordEnvVar }}"{{ with .TLSArgs }} {{ . }}{{ end }} -X PUT "{{ .URL }}/_ilm/policy/{{ .ILMPolicyName }}" -H "Content-Type: application/json" --data-binary @- --write-out "\n" <<'EOF'
+		{"policy": {{ .ILMPolicy }}}
+		EOF
+		curl --fail --user "{{ .Username }}":"${{ .PasswordEnvVar }}"{{ with .TLSArgs }} {{ . }}{{ end }} -X PUT "{{ .URL }}/{{ .Indices.Visibility }}/_settings" -H "Content-Type: application/json" --data-binary '{"index.lifecycle.name": "{{ .ILMPolicyName }}"}' --write-out "\n"
+		{{ if .Indices.SecondaryVisibility -}}
+		curl --fail --user "{{ .Username }}":"${{ .PasswordEnvVar }}"{{ with .TLSArgs }} {{ . }}{{ end }} -X PUT "{{ .URL }}/{{ .Indices.SecondaryVisibility }}/_settings" -H "Content-Type: application/json" --data-binary '{"index.lifecycle.name": "{{ .ILMPolicyName }}"}' --write-out "\n"
+		{{ end -}}
+		{{- end -}}
+		{{- end -}}
+	`)
+
 func init() {
 	for name, content := range templatesContent {
 		templates[name] = template.Must(template.New(name).Parse(proxyShutdownScriptsContent))
+		template.Must(templates[name].Parse(esILMScriptsContent))
 		template.Must(templates[name].Parse(content))
 	}
 }
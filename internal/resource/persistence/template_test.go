@@ -21,6 +21,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -36,3 +37,63 @@ func TestTemplates(t *testing.T) {
 	}))
 	assert.Contains(t, s.String(), "curl -X POST http://localhost:4191/shutdown")
 }
+
+func TestESTemplatesTLSArgs(t *testing.T) {
+	var s strings.Builder
+	assert.NoError(t, templates[setupESVisibility].Execute(&s, esSchemaData{
+		Version:        "v7",
+		URL:            "https://elasticsearch:9200",
+		Username:       "temporal",
+		PasswordEnvVar: "TEMPORAL_VISIBILITY_DATASTORE_PASSWORD",
+		TLSArgs:        `--cacert "/etc/tls/datastores/ca/visibility/ca.pem"`,
+	}))
+	assert.Contains(t, s.String(), `curl --fail --user "temporal":"$TEMPORAL_VISIBILITY_DATASTORE_PASSWORD" --cacert "/etc/tls/datastores/ca/visibility/ca.pem" -X PUT "https://elasticsearch:9200/_cluster/settings"`)
+}
+
+func TestESTemplatesILMPolicy(t *testing.T) {
+	data := esSchemaData{
+		Version:        "v7",
+		URL:            "http://elasticsearch:9200",
+		Username:       "temporal",
+		PasswordEnvVar: "TEMPORAL_VISIBILITY_DATASTORE_PASSWORD",
+		Indices: v1beta1.ElasticsearchIndices{
+			Visibility: "temporal_visibility_v1",
+		},
+	}
+
+	var s strings.Builder
+	assert.NoError(t, templates[setupESVisibility].Execute(&s, data))
+	assert.NotContains(t, s.String(), "_ilm/policy")
+
+	data.ILMPolicyName = "temporal"
+	data.ILMPolicy = `{"phases":{"hot":{"actions":{}}}}`
+
+	for _, name := range []string{setupESVisibility, updateESVisibility, applyESILMPolicy} {
+		s.Reset()
+		assert.NoError(t, templates[name].Execute(&s, data))
+		assert.Contains(t, s.String(), `-X PUT "http://elasticsearch:9200/_ilm/policy/temporal"`)
+		assert.Contains(t, s.String(), "\n{\"policy\": {\"phases\":{\"hot\":{\"actions\":{}}}}}\nEOF\n")
+		assert.Contains(t, s.String(), `-X PUT "http://elasticsearch:9200/temporal_visibility_v1/_settings"`)
+	}
+}
+
+func TestDropTemplates(t *testing.T) {
+	var s strings.Builder
+	assert.NoError(t, templates[dropDatabaseTemplate].Execute(&s, createDatabase{
+		Tool:           "temporal-sql-tool",
+		ConnectionArgs: "--db temporal",
+	}))
+	assert.Contains(t, s.String(), "temporal-sql-tool --db temporal drop-database --force")
+
+	s.Reset()
+	assert.NoError(t, templates[dropESVisibility].Execute(&s, esSchemaData{
+		URL:            "http://elasticsearch:9200",
+		Username:       "temporal",
+		PasswordEnvVar: "TEMPORAL_VISIBILITY_DATASTORE_PASSWORD",
+		Indices: v1beta1.ElasticsearchIndices{
+			Visibility: "temporal_visibility_v1",
+		},
+	}))
+	assert.Contains(t, s.String(), `-X DELETE "http://elasticsearch:9200/temporal_visibility_v1?ignore_unavailable=true"`)
+	assert.Contains(t, s.String(), `-X DELETE "http://elasticsearch:9200/_template/temporal_visibility_v1_template"`)
+}
@@ -20,6 +20,7 @@ package persistence
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
@@ -27,8 +28,30 @@ import (
 
 const (
 	defaultPasswordSecretKey = "password"
+	temporalEntrypoint       = "/etc/temporal/entrypoint.sh"
 )
 
+// GetDatastoresCredentialsFilesCommand returns a container command setting datastores password environment variables
+// from their credentials files before running the temporal entrypoint.
+// It returns nil if none of the provided datastores uses a credentials file.
+func GetDatastoresCredentialsFilesCommand(datastores []*v1beta1.DatastoreSpec) []string {
+	vars := []string{}
+	for _, datastore := range datastores {
+		if datastore.CredentialsFile != "" {
+			vars = append(vars, fmt.Sprintf(`"%s=$(cat '%s')"`, datastore.GetPasswordEnvVarName(), datastore.CredentialsFile))
+		}
+	}
+
+	if len(vars) == 0 {
+		return nil
+	}
+
+	script := fmt.Sprintf(`exec env %s %s "$@"`, strings.Join(vars, " "), temporalEntrypoint)
+
+	// The last argument is used as $0 by sh, container args are then passed to the entrypoint.
+	return []string{"/bin/sh", "-c", script, temporalEntrypoint}
+}
+
 // GetDatastoresEnvironmentVariables returns needed env vars for the provided datastores list.
 func GetDatastoresEnvironmentVariables(datastores []*v1beta1.DatastoreSpec) []corev1.EnvVar {
 	vars := []corev1.EnvVar{}
@@ -82,6 +105,29 @@ func GetDatastoresVolumes(datastores []*v1beta1.DatastoreSpec) []corev1.Volume {
 						},
 					},
 				)
+			} else if datastore.TLS.CaConfigMapRef != nil {
+				key := datastore.TLS.CaConfigMapRef.Key
+				if key == "" {
+					key = v1beta1.DataStoreClientTLSCaFileName
+				}
+				volumes = append(volumes,
+					corev1.Volume{
+						Name: fmt.Sprintf("%s-tls-ca-file", datastore.LowerCaseName()),
+						VolumeSource: corev1.VolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{
+								LocalObjectReference: corev1.LocalObjectReference{
+									Name: datastore.TLS.CaConfigMapRef.Name,
+								},
+								Items: []corev1.KeyToPath{
+									{
+										Key:  key,
+										Path: v1beta1.DataStoreClientTLSCaFileName,
+									},
+								},
+							},
+						},
+					},
+				)
 			}
 			if datastore.TLS.CertFileRef != nil {
 				key := datastore.TLS.CertFileRef.Key
@@ -139,7 +185,7 @@ func GetDatastoresVolumeMounts(datastores []*v1beta1.DatastoreSpec) []corev1.Vol
 	volumeMounts := []corev1.VolumeMount{}
 	for _, datastore := range datastores {
 		if datastore.TLS != nil && datastore.TLS.Enabled {
-			if datastore.TLS.CaFileRef != nil {
+			if datastore.TLS.HasCaFile() {
 				volumeMounts = append(volumeMounts, corev1.VolumeMount{
 					Name:      fmt.Sprintf("%s-tls-ca-file", datastore.LowerCaseName()),
 					MountPath: filepath.Dir(datastore.GetTLSCaFileMountPath()),
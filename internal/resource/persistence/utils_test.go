@@ -207,6 +207,38 @@ func TestGetDatastoresVolumes(t *testing.T) {
 				},
 			},
 		},
+		"datastore list with ca configmap reference filed": {
+			datastores: []*v1beta1.DatastoreSpec{
+				{
+					Name: "test",
+					TLS: &v1beta1.DatastoreTLSSpec{
+						Enabled: true,
+						CaConfigMapRef: &v1beta1.ConfigMapKeyReference{
+							Name: "rds-ca-bundle",
+							Key:  "global-bundle.pem",
+						},
+					},
+				},
+			},
+			expectedEnvVars: []corev1.Volume{
+				{
+					Name: "test-tls-ca-file",
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "rds-ca-bundle",
+							},
+							Items: []corev1.KeyToPath{
+								{
+									Key:  "global-bundle.pem",
+									Path: v1beta1.DataStoreClientTLSCaFileName,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		"datastore list with cert file reference filed": {
 			datastores: []*v1beta1.DatastoreSpec{
 				{
@@ -409,3 +441,50 @@ func TestGetDatastoresVolumeMounts(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDatastoresCredentialsFilesCommand(t *testing.T) {
+	tests := map[string]struct {
+		datastores      []*v1beta1.DatastoreSpec
+		expectedCommand []string
+	}{
+		"no credentials files": {
+			datastores: []*v1beta1.DatastoreSpec{
+				{
+					Name: "default",
+					PasswordSecretRef: &v1beta1.SecretKeyReference{
+						Name: "secret",
+					},
+				},
+			},
+			expectedCommand: nil,
+		},
+		"credentials files": {
+			datastores: []*v1beta1.DatastoreSpec{
+				{
+					Name:            "default",
+					CredentialsFile: "/vault/secrets/default",
+				},
+				{
+					Name: "visibility",
+				},
+				{
+					Name:            "secondaryVisibility",
+					CredentialsFile: "/vault/secrets/visibility",
+				},
+			},
+			expectedCommand: []string{
+				"/bin/sh",
+				"-c",
+				`exec env "TEMPORAL_DEFAULT_DATASTORE_PASSWORD=$(cat '/vault/secrets/default')" "TEMPORAL_SECONDARYVISIBILITY_DATASTORE_PASSWORD=$(cat '/vault/secrets/visibility')" /etc/temporal/entrypoint.sh "$@"`,
+				"/etc/temporal/entrypoint.sh",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			result := persistence.GetDatastoresCredentialsFilesCommand(test.datastores)
+			assert.Equal(tt, test.expectedCommand, result)
+		})
+	}
+}
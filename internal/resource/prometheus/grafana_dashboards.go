@@ -0,0 +1,256 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package prometheus
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"go.temporal.io/server/common/primitives"
+)
+
+const (
+	// ServerDashboardKey is the ConfigMap key of the temporal server dashboard.
+	ServerDashboardKey = "temporal-server.json"
+	// SDKDashboardKey is the ConfigMap key of the temporal SDK dashboard.
+	SDKDashboardKey = "temporal-sdk.json"
+
+	grafanaPanelWidth  = 12
+	grafanaPanelHeight = 8
+)
+
+type grafanaDashboard struct {
+	UID           string            `json:"uid"`
+	Title         string            `json:"title"`
+	Tags          []string          `json:"tags"`
+	Editable      bool              `json:"editable"`
+	SchemaVersion int               `json:"schemaVersion"`
+	Refresh       string            `json:"refresh"`
+	Time          grafanaTimeRange  `json:"time"`
+	Templating    grafanaTemplating `json:"templating"`
+	Panels        []grafanaPanel    `json:"panels"`
+}
+
+type grafanaTimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type grafanaTemplating struct {
+	List []grafanaVariable `json:"list"`
+}
+
+type grafanaVariable struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	Type  string `json:"type"`
+	Query string `json:"query"`
+}
+
+type grafanaDatasourceRef struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+type grafanaGridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type grafanaFieldConfig struct {
+	Defaults grafanaFieldDefaults `json:"defaults"`
+}
+
+type grafanaFieldDefaults struct {
+	Unit string `json:"unit"`
+}
+
+type grafanaTarget struct {
+	RefID        string               `json:"refId"`
+	Datasource   grafanaDatasourceRef `json:"datasource"`
+	Expr         string               `json:"expr"`
+	LegendFormat string               `json:"legendFormat"`
+}
+
+type grafanaPanel struct {
+	ID          int                  `json:"id"`
+	Title       string               `json:"title"`
+	Type        string               `json:"type"`
+	Datasource  grafanaDatasourceRef `json:"datasource"`
+	GridPos     grafanaGridPos       `json:"gridPos"`
+	FieldConfig grafanaFieldConfig   `json:"fieldConfig"`
+	Targets     []grafanaTarget      `json:"targets"`
+}
+
+// dashboardQuery is a prometheus query of a dashboard panel.
+type dashboardQuery struct {
+	legend string
+	expr   string
+}
+
+// prometheusDatasource references the datasource selected using the dashboard's datasource variable.
+var prometheusDatasource = grafanaDatasourceRef{Type: "prometheus", UID: "${datasource}"}
+
+// timeseries returns a time series panel plotting the provided queries.
+func timeseries(title, unit string, queries ...dashboardQuery) grafanaPanel {
+	targets := make([]grafanaTarget, 0, len(queries))
+	for i, query := range queries {
+		targets = append(targets, grafanaTarget{
+			RefID:        string(rune('A' + i)),
+			Datasource:   prometheusDatasource,
+			Expr:         query.expr,
+			LegendFormat: query.legend,
+		})
+	}
+
+	return grafanaPanel{
+		Title:       title,
+		Type:        "timeseries",
+		Datasource:  prometheusDatasource,
+		FieldConfig: grafanaFieldConfig{Defaults: grafanaFieldDefaults{Unit: unit}},
+		Targets:     targets,
+	}
+}
+
+// newGrafanaDashboard returns a dashboard laying out the provided panels two per row.
+func newGrafanaDashboard(uid, title string, panels ...grafanaPanel) *grafanaDashboard {
+	for i := range panels {
+		panels[i].ID = i + 1
+		panels[i].GridPos = grafanaGridPos{
+			H: grafanaPanelHeight,
+			W: grafanaPanelWidth,
+			X: (i % 2) * grafanaPanelWidth,
+			Y: (i / 2) * grafanaPanelHeight,
+		}
+	}
+
+	return &grafanaDashboard{
+		UID:           uid,
+		Title:         title,
+		Tags:          []string{"temporal"},
+		Editable:      true,
+		SchemaVersion: 39,
+		Refresh:       "30s",
+		Time:          grafanaTimeRange{From: "now-1h", To: "now"},
+		Templating: grafanaTemplating{
+			List: []grafanaVariable{
+				{Name: "datasource", Label: "Data source", Type: "datasource", Query: "prometheus"},
+			},
+		},
+		Panels: panels,
+	}
+}
+
+// dashboardUID returns a grafana dashboard uid unique to the provided cluster.
+// Grafana limits uids to 40 characters, so the cluster's namespace and name are hashed.
+func dashboardUID(instance *v1beta1.TemporalCluster, dashboard string) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s/%s", instance.Namespace, instance.Name)))
+	return fmt.Sprintf("temporal-%s-%s", dashboard, hex.EncodeToString(hash[:])[:12])
+}
+
+// ServerDashboard returns the JSON model of the temporal server dashboard of the provided cluster.
+func ServerDashboard(instance *v1beta1.TemporalCluster) ([]byte, error) {
+	cluster := clusterPodsSelector(instance)
+	frontend := servicePodsSelector(instance, primitives.FrontendService)
+	history := servicePodsSelector(instance, primitives.HistoryService)
+	matching := servicePodsSelector(instance, primitives.MatchingService)
+	m := func(name string) string { return metricName(instance, name) }
+
+	dashboard := newGrafanaDashboard(
+		dashboardUID(instance, "server"),
+		fmt.Sprintf("Temporal server / %s/%s", instance.Namespace, instance.Name),
+		timeseries("Frontend requests", "reqps",
+			dashboardQuery{"{{operation}}", fmt.Sprintf(`sum by (operation) (rate(%[2]s{%[1]s}[1m]))`, frontend, m("service_requests"))},
+		),
+		timeseries("Frontend errors", "reqps",
+			dashboardQuery{"{{operation}}", fmt.Sprintf(`sum by (operation) (rate(%[2]s{%[1]s}[1m]))`, frontend, m("service_errors"))},
+		),
+		timeseries("Frontend latency p95", "s",
+			dashboardQuery{"{{operation}}", fmt.Sprintf(`histogram_quantile(0.95, sum by (le, operation) (rate(%[2]s{%[1]s}[1m])))`, frontend, m("service_latency_bucket"))},
+		),
+		timeseries("Workflow outcomes", "ops",
+			dashboardQuery{"success", fmt.Sprintf(`sum(rate(%[2]s{%[1]s}[1m]))`, history, m("workflow_success"))},
+			dashboardQuery{"failed", fmt.Sprintf(`sum(rate(%[2]s{%[1]s}[1m]))`, history, m("workflow_failed"))},
+			dashboardQuery{"timeout", fmt.Sprintf(`sum(rate(%[2]s{%[1]s}[1m]))`, history, m("workflow_timeout"))},
+			dashboardQuery{"terminate", fmt.Sprintf(`sum(rate(%[2]s{%[1]s}[1m]))`, history, m("workflow_terminate"))},
+			dashboardQuery{"cancel", fmt.Sprintf(`sum(rate(%[2]s{%[1]s}[1m]))`, history, m("workflow_cancel"))},
+		),
+		timeseries("Persistence requests", "reqps",
+			dashboardQuery{"{{operation}}", fmt.Sprintf(`sum by (operation) (rate(%[2]s{%[1]s}[1m]))`, cluster, m("persistence_requests"))},
+		),
+		timeseries("Persistence errors", "reqps",
+			dashboardQuery{"{{operation}}", fmt.Sprintf(`sum by (operation) (rate(%[2]s{%[1]s}[1m]))`, cluster, m("persistence_errors"))},
+		),
+		timeseries("Persistence latency p95", "s",
+			dashboardQuery{"{{operation}}", fmt.Sprintf(`histogram_quantile(0.95, sum by (le, operation) (rate(%[2]s{%[1]s}[1m])))`, cluster, m("persistence_latency_bucket"))},
+		),
+		timeseries("History shard lock latency p95", "s",
+			dashboardQuery{"lock", fmt.Sprintf(`histogram_quantile(0.95, sum by (le) (rate(%[2]s{%[1]s}[1m])))`, history, m("lock_latency_bucket"))},
+		),
+		timeseries("Matching backlog dispatch latency p95", "s",
+			dashboardQuery{"{{taskqueue}}", fmt.Sprintf(`histogram_quantile(0.95, sum by (le, taskqueue) (rate(%[2]s{%[1]s}[1m])))`, matching, m("asyncmatch_latency_bucket"))},
+		),
+		timeseries("Matching sync match rate", "percentunit",
+			dashboardQuery{"sync match", fmt.Sprintf(`sum(rate(%[2]s{%[1]s}[1m])) / (sum(rate(%[2]s{%[1]s}[1m])) + sum(rate(%[3]s{%[1]s}[1m])))`, matching, m("syncmatch_latency_count"), m("asyncmatch_latency_count"))},
+		),
+	)
+
+	return json.Marshal(dashboard)
+}
+
+// SDKDashboard returns the JSON model of the temporal SDK dashboard.
+// SDK metrics are reported by the workers, they aren't scoped to the cluster's pods.
+func SDKDashboard(instance *v1beta1.TemporalCluster) ([]byte, error) {
+	dashboard := newGrafanaDashboard(
+		dashboardUID(instance, "sdk"),
+		fmt.Sprintf("Temporal SDK / %s/%s", instance.Namespace, instance.Name),
+		timeseries("Requests", "reqps",
+			dashboardQuery{"{{operation}}", `sum by (operation) (rate(temporal_request[1m]))`},
+		),
+		timeseries("Request failures", "reqps",
+			dashboardQuery{"{{operation}}", `sum by (operation) (rate(temporal_request_failure[1m]))`},
+		),
+		timeseries("Workflow completions", "ops",
+			dashboardQuery{"completed", `sum(rate(temporal_workflow_completed[1m]))`},
+			dashboardQuery{"failed", `sum(rate(temporal_workflow_failed[1m]))`},
+			dashboardQuery{"canceled", `sum(rate(temporal_workflow_canceled[1m]))`},
+		),
+		timeseries("Activity execution failures", "ops",
+			dashboardQuery{"{{activity_type}}", `sum by (activity_type) (rate(temporal_activity_execution_failed[1m]))`},
+		),
+		timeseries("Workflow task schedule-to-start latency p95", "s",
+			dashboardQuery{"{{task_queue}}", `histogram_quantile(0.95, sum by (le, task_queue) (rate(temporal_workflow_task_schedule_to_start_latency_bucket[1m])))`},
+		),
+		timeseries("Activity schedule-to-start latency p95", "s",
+			dashboardQuery{"{{task_queue}}", `histogram_quantile(0.95, sum by (le, task_queue) (rate(temporal_activity_schedule_to_start_latency_bucket[1m])))`},
+		),
+		timeseries("Sticky cache size", "short",
+			dashboardQuery{"{{pod}}", `sum by (pod) (temporal_sticky_cache_size)`},
+		),
+		timeseries("Worker task slots available", "short",
+			dashboardQuery{"{{worker_type}}", `sum by (worker_type) (temporal_worker_task_slots_available)`},
+		),
+	)
+
+	return json.Marshal(dashboard)
+}
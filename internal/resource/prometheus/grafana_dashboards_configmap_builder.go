@@ -0,0 +1,98 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package prometheus
+
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ resource.Builder = (*GrafanaDashboardsConfigmapBuilder)(nil)
+
+type GrafanaDashboardsConfigmapBuilder struct {
+	instance *v1beta1.TemporalCluster
+	scheme   *runtime.Scheme
+}
+
+func NewGrafanaDashboardsConfigmapBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme) *GrafanaDashboardsConfigmapBuilder {
+	return &GrafanaDashboardsConfigmapBuilder{
+		instance: instance,
+		scheme:   scheme,
+	}
+}
+
+func (b *GrafanaDashboardsConfigmapBuilder) Build() client.Object {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.instance.ChildResourceName("grafana-dashboards"),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.GetLabels(b.instance, "grafana-dashboards", b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *GrafanaDashboardsConfigmapBuilder) Enabled() bool {
+	return b.instance.Spec.Metrics.IsEnabled() &&
+		b.instance.Spec.Metrics.Prometheus != nil &&
+		b.instance.Spec.Metrics.Prometheus.GrafanaDashboards.IsEnabled()
+}
+
+func (b *GrafanaDashboardsConfigmapBuilder) Update(object client.Object) error {
+	configMap := object.(*corev1.ConfigMap)
+	spec := b.instance.Spec.Metrics.Prometheus.GrafanaDashboards
+
+	configMap.Labels = metadata.Merge(
+		object.GetLabels(),
+		spec.Labels,
+	)
+	configMap.Annotations = metadata.Merge(
+		object.GetAnnotations(),
+		metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		spec.Annotations,
+	)
+
+	serverDashboard, err := ServerDashboard(b.instance)
+	if err != nil {
+		return fmt.Errorf("can't render server dashboard: %w", err)
+	}
+
+	sdkDashboard, err := SDKDashboard(b.instance)
+	if err != nil {
+		return fmt.Errorf("can't render sdk dashboard: %w", err)
+	}
+
+	configMap.Data = map[string]string{
+		ServerDashboardKey: string(serverDashboard),
+		SDKDashboardKey:    string(sdkDashboard),
+	}
+
+	if err := controllerutil.SetControllerReference(b.instance, configMap, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+
+	return nil
+}
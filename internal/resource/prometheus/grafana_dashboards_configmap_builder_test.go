@@ -0,0 +1,82 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package prometheus_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/prometheus"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestGrafanaDashboardsConfigmapBuilder(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	cluster := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "a-very-long-namespace-name-for-temporal"},
+		Spec: v1beta1.TemporalClusterSpec{
+			Version: version.MustNewVersionFromString("1.23.0"),
+			Metrics: &v1beta1.MetricsSpec{
+				Enabled: true,
+				Prometheus: &v1beta1.PrometheusSpec{
+					GrafanaDashboards: &v1beta1.GrafanaDashboardsSpec{
+						Enabled:     true,
+						Labels:      map[string]string{"grafana_dashboard": "1"},
+						Annotations: map[string]string{"grafana_folder": "Temporal"},
+					},
+				},
+			},
+		},
+	}
+
+	builder := prometheus.NewGrafanaDashboardsConfigmapBuilder(cluster, scheme)
+	assert.True(t, builder.Enabled())
+
+	object := builder.Build()
+	require.NoError(t, builder.Update(object))
+
+	configMap := object.(*corev1.ConfigMap)
+	assert.Equal(t, "1", configMap.Labels["grafana_dashboard"])
+	assert.Equal(t, "Temporal", configMap.Annotations["grafana_folder"])
+
+	for _, key := range []string{prometheus.ServerDashboardKey, prometheus.SDKDashboardKey} {
+		dashboard := struct {
+			UID    string `json:"uid"`
+			Panels []struct {
+				Targets []struct {
+					Expr string `json:"expr"`
+				} `json:"targets"`
+			} `json:"panels"`
+		}{}
+		require.NoError(t, json.Unmarshal([]byte(configMap.Data[key]), &dashboard), key)
+		assert.LessOrEqual(t, len(dashboard.UID), 40, key)
+		assert.NotEmpty(t, dashboard.Panels, key)
+	}
+
+	assert.Contains(t, configMap.Data[prometheus.ServerDashboardKey], `namespace=\"a-very-long-namespace-name-for-temporal\",pod=~\"prod-frontend-.*\"`)
+}
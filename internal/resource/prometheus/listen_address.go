@@ -0,0 +1,61 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package prometheus
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ListenAddressEnvVar is the environment variable holding the prometheus listen address of a service,
+// when services override the cluster's metrics exposition.
+const ListenAddressEnvVar = "TEMPORAL_PROMETHEUS_LISTEN_ADDRESS"
+
+// ListenAddress returns the prometheus listen address of the temporal services.
+// When services override the cluster's metrics exposition, it's read from the environment variable set on each service.
+func ListenAddress(instance *v1beta1.TemporalCluster) string {
+	if instance.Spec.Services.HasMetricsOverrides() {
+		return fmt.Sprintf("{{ .Env.%s }}", ListenAddressEnvVar)
+	}
+	return net.JoinHostPort(instance.BindAddress(), strconv.Itoa(int(*instance.Spec.Metrics.Prometheus.ListenPort)))
+}
+
+// ServiceEnvironmentVariables returns the environment variable holding the prometheus listen address of a service.
+// Services with disabled metrics listen on the loopback interface only.
+func ServiceEnvironmentVariables(instance *v1beta1.TemporalCluster, service *v1beta1.ServiceSpec) []corev1.EnvVar {
+	address := instance.BindAddress()
+	port := instance.ServiceMetricsPort(service)
+	if port == nil {
+		address = instance.LoopbackAddress()
+		port = instance.Spec.Metrics.Prometheus.ListenPort
+		if service.Metrics != nil && service.Metrics.Port != nil {
+			port = service.Metrics.Port
+		}
+	}
+
+	return []corev1.EnvVar{
+		{
+			Name:  ListenAddressEnvVar,
+			Value: net.JoinHostPort(address, strconv.Itoa(int(*port))),
+		},
+	}
+}
@@ -0,0 +1,83 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package prometheus_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/prometheus"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestServiceEnvironmentVariables(t *testing.T) {
+	tests := map[string]struct {
+		service          *v1beta1.ServiceSpec
+		expectedAddress  string
+		expectedScrapeAt string
+	}{
+		"cluster port": {
+			service:          &v1beta1.ServiceSpec{},
+			expectedAddress:  "0.0.0.0:9090",
+			expectedScrapeAt: "9090",
+		},
+		"service port": {
+			service: &v1beta1.ServiceSpec{
+				Metrics: &v1beta1.ServiceMetricsSpec{Port: ptr.To[int32](9091)},
+			},
+			expectedAddress:  "0.0.0.0:9091",
+			expectedScrapeAt: "9091",
+		},
+		"disabled": {
+			service: &v1beta1.ServiceSpec{
+				Metrics: &v1beta1.ServiceMetricsSpec{Enabled: ptr.To(false)},
+			},
+			expectedAddress: "127.0.0.1:9090",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			cluster := &v1beta1.TemporalCluster{
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.23.0"),
+					Services: &v1beta1.ServicesSpec{
+						History: test.service,
+					},
+					Metrics: &v1beta1.MetricsSpec{
+						Enabled: true,
+						Prometheus: &v1beta1.PrometheusSpec{
+							ListenPort: ptr.To[int32](9090),
+							ScrapeConfig: &v1beta1.PrometheusScrapeConfig{
+								Annotations: true,
+							},
+						},
+					},
+				},
+			}
+
+			assert.Equal(tt, []corev1.EnvVar{
+				{Name: prometheus.ListenAddressEnvVar, Value: test.expectedAddress},
+			}, prometheus.ServiceEnvironmentVariables(cluster, test.service))
+			assert.Equal(tt, test.expectedScrapeAt, prometheus.GetServiceAnnotations(cluster, test.service)["prometheus.io/port"])
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package prometheus
+
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ resource.Builder = (*PodMonitorBuilder)(nil)
+
+type PodMonitorBuilder struct {
+	serviceName string
+	instance    *v1beta1.TemporalCluster
+	scheme      *runtime.Scheme
+	service     *v1beta1.ServiceSpec
+}
+
+func NewPodMonitorBuilder(serviceName string, instance *v1beta1.TemporalCluster, scheme *runtime.Scheme, service *v1beta1.ServiceSpec) *PodMonitorBuilder {
+	return &PodMonitorBuilder{
+		serviceName: serviceName,
+		instance:    instance,
+		scheme:      scheme,
+		service:     service,
+	}
+}
+
+func (b *PodMonitorBuilder) Build() client.Object {
+	return &monitoringv1.PodMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.instance.ChildResourceName(b.serviceName),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.GetLabels(b.instance, b.serviceName, b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *PodMonitorBuilder) Enabled() bool {
+	return b.instance.Spec.Metrics.IsEnabled() &&
+		b.instance.Spec.Metrics.Prometheus != nil &&
+		b.instance.Spec.Metrics.Prometheus.ScrapeConfig != nil &&
+		b.instance.Spec.Metrics.Prometheus.ScrapeConfig.PodMonitor != nil &&
+		b.instance.Spec.Metrics.Prometheus.ScrapeConfig.PodMonitor.Enabled &&
+		b.instance.ServiceMetricsPort(b.service) != nil
+}
+
+func (b *PodMonitorBuilder) Update(object client.Object) error {
+	pm := object.(*monitoringv1.PodMonitor)
+	config := b.instance.Spec.Metrics.Prometheus.ScrapeConfig.PodMonitor
+
+	pm.Labels = metadata.Merge(
+		pm.GetLabels(),
+		config.Labels,
+	)
+
+	pm.Annotations = metadata.Merge(
+		pm.GetAnnotations(),
+		metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+	)
+
+	pm.Spec = monitoringv1.PodMonitorSpec{
+		NamespaceSelector: monitoringv1.NamespaceSelector{
+			MatchNames: []string{
+				b.instance.Namespace,
+			},
+		},
+		Selector: metav1.LabelSelector{
+			MatchLabels: metadata.LabelsSelector(b.instance, b.serviceName),
+		},
+		PodMetricsEndpoints: []monitoringv1.PodMetricsEndpoint{
+			{
+				Port:                 MetricsPortName.String(),
+				Interval:             config.Interval,
+				ScrapeTimeout:        config.ScrapeTimeout,
+				MetricRelabelConfigs: config.MetricRelabelConfigs,
+				RelabelConfigs:       config.RelabelConfigs,
+			},
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(b.instance, pm, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package prometheus_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/prometheus"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+func TestPodMonitorBuilder(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	require.NoError(t, monitoringv1.AddToScheme(scheme))
+
+	relabelings := []*monitoringv1.RelabelConfig{
+		{
+			SourceLabels: []monitoringv1.LabelName{"__meta_kubernetes_pod_node_name"},
+			TargetLabel:  "node",
+		},
+	}
+
+	cluster := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+		Spec: v1beta1.TemporalClusterSpec{
+			Version: version.MustNewVersionFromString("1.23.0"),
+			Metrics: &v1beta1.MetricsSpec{
+				Enabled: true,
+				Prometheus: &v1beta1.PrometheusSpec{
+					ListenPort: ptr.To[int32](9090),
+					ScrapeConfig: &v1beta1.PrometheusScrapeConfig{
+						PodMonitor: &v1beta1.PrometheusScrapeConfigPodMonitor{
+							Enabled:        true,
+							Labels:         map[string]string{"release": "prometheus"},
+							Interval:       "15s",
+							RelabelConfigs: relabelings,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	builder := prometheus.NewPodMonitorBuilder("history", cluster, scheme, &v1beta1.ServiceSpec{})
+	assert.True(t, builder.Enabled())
+
+	object := builder.Build()
+	require.NoError(t, builder.Update(object))
+
+	pm := object.(*monitoringv1.PodMonitor)
+	assert.Equal(t, "prometheus", pm.Labels["release"])
+	assert.Equal(t, []string{"demo"}, pm.Spec.NamespaceSelector.MatchNames)
+	assert.Equal(t, "history", pm.Spec.Selector.MatchLabels["app.kubernetes.io/component"])
+	require.Len(t, pm.Spec.PodMetricsEndpoints, 1)
+	assert.Equal(t, "metrics", pm.Spec.PodMetricsEndpoints[0].Port)
+	assert.Equal(t, monitoringv1.Duration("15s"), pm.Spec.PodMetricsEndpoints[0].Interval)
+	assert.Equal(t, relabelings, pm.Spec.PodMetricsEndpoints[0].RelabelConfigs)
+
+	disabled := prometheus.NewPodMonitorBuilder("history", cluster, scheme, &v1beta1.ServiceSpec{
+		Metrics: &v1beta1.ServiceMetricsSpec{Enabled: ptr.To(false)},
+	})
+	assert.False(t, disabled.Enabled())
+}
@@ -17,7 +17,31 @@
 
 package prometheus
 
-import "k8s.io/apimachinery/pkg/util/intstr"
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"go.temporal.io/server/common/primitives"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
 
 // MetricsPortName returns the default port name for metrics endpoints.
 var MetricsPortName = intstr.FromString("metrics")
+
+// clusterPodsSelector returns the PromQL label selector of the provided cluster's temporal services pods.
+func clusterPodsSelector(instance *v1beta1.TemporalCluster) string {
+	return fmt.Sprintf(`namespace="%s",pod=~"%s-(frontend|internal-frontend|history|matching|worker)-.*"`, instance.Namespace, instance.Name)
+}
+
+// servicePodsSelector returns the PromQL label selector of the provided temporal service pods.
+func servicePodsSelector(instance *v1beta1.TemporalCluster, service primitives.ServiceName) string {
+	return fmt.Sprintf(`namespace="%s",pod=~"%s-.*"`, instance.Namespace, instance.ChildResourceName(string(service)))
+}
+
+// metricName returns the name of the provided temporal server metric, prefixed with the cluster's metrics prefix.
+func metricName(instance *v1beta1.TemporalCluster, name string) string {
+	if instance.Spec.Metrics == nil || instance.Spec.Metrics.Prefix == nil || *instance.Spec.Metrics.Prefix == "" {
+		return name
+	}
+	return fmt.Sprintf("%s_%s", *instance.Spec.Metrics.Prefix, name)
+}
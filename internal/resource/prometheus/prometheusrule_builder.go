@@ -0,0 +1,191 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package prometheus
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"go.temporal.io/server/common/primitives"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const defaultCertificateExpiryThreshold = 7 * 24 * time.Hour
+
+var _ resource.Builder = (*PrometheusRuleBuilder)(nil)
+
+type PrometheusRuleBuilder struct {
+	instance *v1beta1.TemporalCluster
+	scheme   *runtime.Scheme
+}
+
+func NewPrometheusRuleBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme) *PrometheusRuleBuilder {
+	return &PrometheusRuleBuilder{
+		instance: instance,
+		scheme:   scheme,
+	}
+}
+
+func (b *PrometheusRuleBuilder) Build() client.Object {
+	return &monitoringv1.PrometheusRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.instance.ChildResourceName("alerts"),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.GetLabels(b.instance, "alerts", b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *PrometheusRuleBuilder) Enabled() bool {
+	return b.instance.Spec.Metrics.IsEnabled() &&
+		b.instance.Spec.Metrics.Prometheus != nil &&
+		b.instance.Spec.Metrics.Prometheus.Rule.IsEnabled()
+}
+
+func (b *PrometheusRuleBuilder) Update(object client.Object) error {
+	rule := object.(*monitoringv1.PrometheusRule)
+	spec := b.instance.Spec.Metrics.Prometheus.Rule
+
+	rule.Labels = metadata.Merge(
+		rule.GetLabels(),
+		spec.Labels,
+	)
+
+	rule.Annotations = metadata.Merge(
+		rule.GetAnnotations(),
+		metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+	)
+
+	rules := []monitoringv1.Rule{}
+	for _, alert := range b.alerts() {
+		if !spec.IsAlertEnabled(v1beta1.PrometheusAlert(alert.Alert)) {
+			continue
+		}
+		alert.Labels = metadata.Merge(alert.Labels, spec.AlertLabels)
+		rules = append(rules, alert)
+	}
+
+	rule.Spec = monitoringv1.PrometheusRuleSpec{
+		Groups: []monitoringv1.RuleGroup{
+			{
+				Name:  b.instance.ChildResourceName("alerts"),
+				Rules: rules,
+			},
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(b.instance, rule, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+
+	return nil
+}
+
+// alerts returns all the alerts the operator can create for the cluster.
+func (b *PrometheusRuleBuilder) alerts() []monitoringv1.Rule {
+	clusterSelector := clusterPodsSelector(b.instance)
+	cluster := fmt.Sprintf("%s/%s", b.instance.Namespace, b.instance.Name)
+
+	alerts := []monitoringv1.Rule{
+		{
+			Alert: string(v1beta1.PersistenceLatencyHighAlert),
+			Expr:  intstr.FromString(fmt.Sprintf(`histogram_quantile(0.99, sum by (le, operation) (rate(%s{%s}[5m]))) > 1`, metricName(b.instance, "persistence_latency_bucket"), clusterSelector)),
+			For:   ptr.To(monitoringv1.Duration("10m")),
+			Labels: map[string]string{
+				"severity": "warning",
+			},
+			Annotations: map[string]string{
+				"summary":     fmt.Sprintf("Persistence latency of temporal cluster %s is high", cluster),
+				"description": "The p99 latency of the {{ $labels.operation }} persistence operation is {{ $value | humanizeDuration }}.",
+			},
+		},
+		{
+			Alert: string(v1beta1.ShardLockLatencyHighAlert),
+			Expr:  intstr.FromString(fmt.Sprintf(`histogram_quantile(0.99, sum by (le) (rate(%s{%s}[5m]))) > 0.5`, metricName(b.instance, "lock_latency_bucket"), servicePodsSelector(b.instance, primitives.HistoryService))),
+			For:   ptr.To(monitoringv1.Duration("10m")),
+			Labels: map[string]string{
+				"severity": "warning",
+			},
+			Annotations: map[string]string{
+				"summary":     fmt.Sprintf("History shard lock contention on temporal cluster %s", cluster),
+				"description": "The p99 latency of history shard locks is {{ $value | humanizeDuration }}.",
+			},
+		},
+		{
+			Alert: string(v1beta1.TaskBacklogHighAlert),
+			Expr:  intstr.FromString(fmt.Sprintf(`histogram_quantile(0.95, sum by (le, taskqueue) (rate(%s{%s}[5m]))) > 10`, metricName(b.instance, "asyncmatch_latency_bucket"), servicePodsSelector(b.instance, primitives.MatchingService))),
+			For:   ptr.To(monitoringv1.Duration("15m")),
+			Labels: map[string]string{
+				"severity": "warning",
+			},
+			Annotations: map[string]string{
+				"summary":     fmt.Sprintf("Tasks are backlogged on temporal cluster %s", cluster),
+				"description": "The p95 latency of tasks dispatched from the {{ $labels.taskqueue }} task queue backlog is {{ $value | humanizeDuration }}, workers may not keep up.",
+			},
+		},
+		{
+			Alert: string(v1beta1.FrontendErrorRateHighAlert),
+			Expr: intstr.FromString(fmt.Sprintf(`sum(rate(%[2]s{%[1]s}[5m])) / sum(rate(%[3]s{%[1]s}[5m])) > 0.05`,
+				servicePodsSelector(b.instance, primitives.FrontendService), metricName(b.instance, "service_errors"), metricName(b.instance, "service_requests"))),
+			For: ptr.To(monitoringv1.Duration("10m")),
+			Labels: map[string]string{
+				"severity": "critical",
+			},
+			Annotations: map[string]string{
+				"summary":     fmt.Sprintf("Frontend error rate of temporal cluster %s is high", cluster),
+				"description": "{{ $value | humanizePercentage }} of frontend requests are failing.",
+			},
+		},
+	}
+
+	if b.instance.MTLSWithCertificatesEnabled() {
+		threshold := defaultCertificateExpiryThreshold
+		if b.instance.Spec.MTLS.ExpiryWarningThreshold != nil {
+			threshold = b.instance.Spec.MTLS.ExpiryWarningThreshold.Duration
+		}
+
+		// The metric is exposed by the operator: its namespace label is renamed to exported_namespace
+		// when the operator isn't scraped with honorLabels.
+		expiry := fmt.Sprintf(`(temporal_operator_certificate_expiry_timestamp_seconds{namespace="%[1]s",cluster="%[2]s"} or temporal_operator_certificate_expiry_timestamp_seconds{exported_namespace="%[1]s",cluster="%[2]s"})`,
+			b.instance.Namespace, b.instance.Name)
+
+		alerts = append(alerts, monitoringv1.Rule{
+			Alert: string(v1beta1.CertificateExpiringSoonAlert),
+			Expr:  intstr.FromString(fmt.Sprintf(`%s - time() < %d`, expiry, int64(threshold.Seconds()))),
+			Labels: map[string]string{
+				"severity": "critical",
+			},
+			Annotations: map[string]string{
+				"summary":     fmt.Sprintf("A mTLS certificate of temporal cluster %s expires soon", cluster),
+				"description": "The certificate stored in the {{ $labels.secret }} secret expires in {{ $value | humanizeDuration }}.",
+			},
+		})
+	}
+
+	return alerts
+}
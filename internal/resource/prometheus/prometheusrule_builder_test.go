@@ -0,0 +1,120 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package prometheus_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/prometheus"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+func TestPrometheusRuleBuilder(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	require.NoError(t, monitoringv1.AddToScheme(scheme))
+
+	tests := map[string]struct {
+		mTLS           *v1beta1.MTLSSpec
+		prefix         *string
+		expectedMetric string
+		disabledAlerts []v1beta1.PrometheusAlert
+		expectedAlerts []string
+	}{
+		"default alerts": {
+			expectedMetric: "persistence_latency_bucket{",
+			expectedAlerts: []string{
+				"TemporalPersistenceLatencyHigh",
+				"TemporalShardLockLatencyHigh",
+				"TemporalTaskBacklogHigh",
+				"TemporalFrontendErrorRateHigh",
+			},
+		},
+		"certificate expiry with mTLS": {
+			mTLS: &v1beta1.MTLSSpec{
+				Provider:  v1beta1.CertManagerMTLSProvider,
+				Internode: &v1beta1.InternodeMTLSSpec{Enabled: true},
+			},
+			disabledAlerts: []v1beta1.PrometheusAlert{v1beta1.ShardLockLatencyHighAlert, v1beta1.TaskBacklogHighAlert},
+			expectedMetric: "persistence_latency_bucket{",
+			expectedAlerts: []string{
+				"TemporalPersistenceLatencyHigh",
+				"TemporalFrontendErrorRateHigh",
+				"TemporalCertificateExpiringSoon",
+			},
+		},
+		"prefixed metrics": {
+			prefix:         ptr.To("temporal"),
+			disabledAlerts: []v1beta1.PrometheusAlert{v1beta1.ShardLockLatencyHighAlert, v1beta1.TaskBacklogHighAlert, v1beta1.FrontendErrorRateHighAlert},
+			expectedMetric: "temporal_persistence_latency_bucket{",
+			expectedAlerts: []string{
+				"TemporalPersistenceLatencyHigh",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			cluster := &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.23.0"),
+					MTLS:    test.mTLS,
+					Metrics: &v1beta1.MetricsSpec{
+						Enabled: true,
+						Prefix:  test.prefix,
+						Prometheus: &v1beta1.PrometheusSpec{
+							Rule: &v1beta1.PrometheusRuleSpec{
+								Enabled:        true,
+								Labels:         map[string]string{"release": "prometheus"},
+								AlertLabels:    map[string]string{"team": "platform"},
+								DisabledAlerts: test.disabledAlerts,
+							},
+						},
+					},
+				},
+			}
+
+			builder := prometheus.NewPrometheusRuleBuilder(cluster, scheme)
+			assert.True(tt, builder.Enabled())
+
+			object := builder.Build()
+			require.NoError(tt, builder.Update(object))
+
+			rule := object.(*monitoringv1.PrometheusRule)
+			assert.Equal(tt, "prometheus", rule.Labels["release"])
+			require.Len(tt, rule.Spec.Groups, 1)
+
+			alerts := []string{}
+			for _, alert := range rule.Spec.Groups[0].Rules {
+				alerts = append(alerts, alert.Alert)
+				assert.Equal(tt, "platform", alert.Labels["team"])
+				assert.Contains(tt, alert.Expr.String(), `namespace="demo"`)
+			}
+			assert.Equal(tt, test.expectedAlerts, alerts)
+			assert.Contains(tt, rule.Spec.Groups[0].Rules[0].Expr.String(), test.expectedMetric)
+		})
+	}
+}
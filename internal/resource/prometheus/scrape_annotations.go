@@ -25,16 +25,21 @@ import (
 
 // GetAnnotations returns prometheus scrape annotations.
 func GetAnnotations(instance *v1beta1.TemporalCluster) map[string]string {
-	if instance.Spec.Metrics.IsEnabled() &&
-		instance.Spec.Metrics.Prometheus != nil &&
-		instance.Spec.Metrics.Prometheus.ListenPort != nil &&
+	return GetServiceAnnotations(instance, nil)
+}
+
+// GetServiceAnnotations returns prometheus scrape annotations for the provided temporal service,
+// honoring its metrics overrides.
+func GetServiceAnnotations(instance *v1beta1.TemporalCluster, service *v1beta1.ServiceSpec) map[string]string {
+	port := instance.ServiceMetricsPort(service)
+	if port != nil &&
 		instance.Spec.Metrics.Prometheus.ScrapeConfig != nil &&
 		instance.Spec.Metrics.Prometheus.ScrapeConfig.Annotations {
 		return map[string]string{
 			"prometheus.io/scrape": "true",
 			"prometheus.io/scheme": "http",
 			"prometheus.io/path":   "/metrics",
-			"prometheus.io/port":   fmt.Sprintf("%d", *instance.Spec.Metrics.Prometheus.ListenPort),
+			"prometheus.io/port":   fmt.Sprintf("%d", *port),
 		}
 	}
 	return map[string]string{}
@@ -64,7 +64,8 @@ func (b *ServiceMonitorBuilder) Enabled() bool {
 		b.instance.Spec.Metrics.Prometheus != nil &&
 		b.instance.Spec.Metrics.Prometheus.ScrapeConfig != nil &&
 		b.instance.Spec.Metrics.Prometheus.ScrapeConfig.ServiceMonitor != nil &&
-		b.instance.Spec.Metrics.Prometheus.ScrapeConfig.ServiceMonitor.Enabled
+		b.instance.Spec.Metrics.Prometheus.ScrapeConfig.ServiceMonitor.Enabled &&
+		b.instance.ServiceMetricsPort(b.service) != nil
 }
 
 func (b *ServiceMonitorBuilder) applySpecOverride(sm *monitoringv1.ServiceMonitor, specOverride *monitoringv1.ServiceMonitorSpec) error {
@@ -123,7 +124,10 @@ func (b *ServiceMonitorBuilder) Update(object client.Object) error {
 		Endpoints: []monitoringv1.Endpoint{
 			{
 				TargetPort:           &MetricsPortName,
+				Interval:             b.instance.Spec.Metrics.Prometheus.ScrapeConfig.ServiceMonitor.Interval,
+				ScrapeTimeout:        b.instance.Spec.Metrics.Prometheus.ScrapeConfig.ServiceMonitor.ScrapeTimeout,
 				MetricRelabelConfigs: b.instance.Spec.Metrics.Prometheus.ScrapeConfig.ServiceMonitor.MetricRelabelConfigs,
+				RelabelConfigs:       b.instance.Spec.Metrics.Prometheus.ScrapeConfig.ServiceMonitor.RelabelConfigs,
 			},
 		},
 	}
@@ -0,0 +1,75 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ui
+
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// ConfigMapName is the name of the ConfigMap holding the user-provided UI configuration.
+const ConfigMapName = "ui-config"
+
+type ConfigmapBuilder struct {
+	instance *v1beta1.TemporalCluster
+	scheme   *runtime.Scheme
+}
+
+func NewConfigmapBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme) *ConfigmapBuilder {
+	return &ConfigmapBuilder{
+		instance: instance,
+		scheme:   scheme,
+	}
+}
+
+func (b *ConfigmapBuilder) Build() client.Object {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.instance.ChildResourceName(ConfigMapName),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.GetLabels(b.instance, "ui", b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *ConfigmapBuilder) Enabled() bool {
+	return b.instance.Spec.UI != nil &&
+		b.instance.Spec.UI.Enabled &&
+		len(b.instance.Spec.UI.Config) > 0
+}
+
+func (b *ConfigmapBuilder) Update(object client.Object) error {
+	configMap := object.(*corev1.ConfigMap)
+	configMap.Labels = object.GetLabels()
+	configMap.Annotations = object.GetAnnotations()
+	configMap.Data = b.instance.Spec.UI.Config
+
+	if err := controllerutil.SetControllerReference(b.instance, configMap, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+
+	return nil
+}
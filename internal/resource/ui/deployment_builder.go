@@ -19,16 +19,25 @@ package ui
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
+	"github.com/alexandrevilain/controller-tools/pkg/hash"
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/reconciler"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/codecserver"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/spiffe"
 	"github.com/alexandrevilain/temporal-operator/pkg/kubernetes"
+	"golang.org/x/exp/slices"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -39,16 +48,20 @@ const (
 )
 
 type DeploymentBuilder struct {
-	instance   *v1beta1.TemporalCluster
-	scheme     *runtime.Scheme
-	configHash string
+	instance         *v1beta1.TemporalCluster
+	scheme           *runtime.Scheme
+	certificatesHash string
+	// current is the current deployment, nil if it doesn't exist.
+	current *appsv1.Deployment
 }
 
-func NewDeploymentBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme, configHash string) *DeploymentBuilder {
+var _ reconciler.CurrentStateBuilder = (*DeploymentBuilder)(nil)
+
+func NewDeploymentBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme, certificatesHash string) *DeploymentBuilder {
 	return &DeploymentBuilder{
-		instance:   instance,
-		scheme:     scheme,
-		configHash: configHash,
+		instance:         instance,
+		scheme:           scheme,
+		certificatesHash: certificatesHash,
 	}
 }
 
@@ -63,6 +76,10 @@ func (b *DeploymentBuilder) Build() client.Object {
 	}
 }
 
+func (b *DeploymentBuilder) SetCurrent(current client.Object) {
+	b.current, _ = current.(*appsv1.Deployment)
+}
+
 func (b *DeploymentBuilder) Enabled() bool {
 	return b.instance.Spec.UI != nil && b.instance.Spec.UI.Enabled
 }
@@ -92,7 +109,37 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 		},
 	}
 
-	if b.instance.MTLSWithCertManagerEnabled() && b.instance.Spec.MTLS.FrontendEnabled() {
+	if publicPath := b.instance.Spec.UI.GetPublicPath(); publicPath != "/" {
+		env = append(env, corev1.EnvVar{
+			Name:  "TEMPORAL_UI_PUBLIC_PATH",
+			Value: strings.TrimSuffix(publicPath, "/"),
+		})
+	}
+
+	env = append(env, writeActionsEnvironmentVariables(b.instance.Spec.UI)...)
+
+	if auth := b.instance.Spec.UI.Auth; auth.IsEnabled() {
+		env = append(env, b.authEnvironmentVariables(auth)...)
+	}
+
+	if codecServer := b.instance.CodecServer(); codecServer.IsEnabled() {
+		env = append(env,
+			corev1.EnvVar{
+				Name:  "TEMPORAL_CODEC_ENDPOINT",
+				Value: codecserver.Endpoint(b.instance),
+			},
+			corev1.EnvVar{
+				Name:  "TEMPORAL_CODEC_PASS_ACCESS_TOKEN",
+				Value: strconv.FormatBool(codecServer.PassAccessToken),
+			},
+			corev1.EnvVar{
+				Name:  "TEMPORAL_CODEC_INCLUDE_CREDENTIALS",
+				Value: strconv.FormatBool(codecServer.IncludeCredentials),
+			},
+		)
+	}
+
+	if b.instance.MTLSWithCertificatesEnabled() && b.instance.Spec.MTLS.FrontendEnabled() {
 		volumeMounts = append(volumeMounts,
 			corev1.VolumeMount{
 				Name:      certmanager.UIFrontendClientCertificate,
@@ -105,7 +152,7 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 				Name: certmanager.UIFrontendClientCertificate,
 				VolumeSource: corev1.VolumeSource{
 					Secret: &corev1.SecretVolumeSource{
-						SecretName:  b.instance.ChildResourceName(certmanager.UIFrontendClientCertificate),
+						SecretName:  mtls.CertificateSecretName(b.instance, certmanager.UIFrontendClientCertificate),
 						DefaultMode: ptr.To[int32](corev1.SecretVolumeSourceDefaultMode),
 					},
 				},
@@ -115,23 +162,70 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 		env = append(env, certmanager.GetTLSEnvironmentVariables(b.instance, "TEMPORAL", uiCertsMountPath)...)
 	}
 
-	deployment.Spec.Replicas = b.instance.Spec.UI.Replicas
+	if b.instance.MTLSWithSPIFFEEnabled() && b.instance.Spec.MTLS.FrontendEnabled() {
+		env = append(env, certmanager.GetTLSEnvironmentVariables(b.instance, "TEMPORAL", uiCertsMountPath)...)
+	}
+
+	// The UI doesn't read the temporal server configuration: it is only restarted when its own configuration changes.
+	configHash := ""
+	var envFrom []corev1.EnvFromSource
+	if len(b.instance.Spec.UI.Config) > 0 {
+		// User-provided configuration takes precedence over generated environment variables.
+		env = slices.DeleteFunc(env, func(e corev1.EnvVar) bool {
+			_, ok := b.instance.Spec.UI.Config[e.Name]
+			return ok
+		})
+
+		envFrom = append(envFrom, corev1.EnvFromSource{
+			ConfigMapRef: &corev1.ConfigMapEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: b.instance.ChildResourceName(ConfigMapName),
+				},
+			},
+		})
+
+		// Restart the UI when its configuration changes.
+		var err error
+		configHash, err = hash.Sha256(b.instance.Spec.UI.Config)
+		if err != nil {
+			return fmt.Errorf("can't compute ui config hash: %w", err)
+		}
+	}
+
+	deployment.Spec.Replicas = b.replicas()
+
+	livenessProbe := &corev1.Probe{
+		InitialDelaySeconds: 10,
+		TimeoutSeconds:      1,
+		PeriodSeconds:       10,
+		SuccessThreshold:    1,
+		FailureThreshold:    3,
+		ProbeHandler: corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{
+				Port: intstr.FromString("http"),
+			},
+		},
+	}
+	if b.instance.Spec.UI.LivenessProbe != nil {
+		livenessProbe = b.instance.Spec.UI.LivenessProbe.DeepCopy()
+	}
 
 	deployment.Spec.Selector = &metav1.LabelSelector{
 		MatchLabels: metadata.LabelsSelector(b.instance, "ui"),
 	}
 	deployment.Spec.Template = corev1.PodTemplateSpec{
-		ObjectMeta: meta.BuildPodObjectMeta(b.instance, "ui", b.configHash),
+		ObjectMeta: meta.BuildPodObjectMeta(b.instance, "ui", configHash, b.certificatesHash),
 		Spec: corev1.PodSpec{
 			ImagePullSecrets: b.instance.Spec.ImagePullSecrets,
-			Containers: []corev1.Container{
+			Containers: append([]corev1.Container{
 				{
 					Name:                     "ui",
-					Image:                    fmt.Sprintf("%s:%s", b.instance.Spec.UI.Image, b.instance.Spec.UI.Version),
-					ImagePullPolicy:          corev1.PullIfNotPresent,
+					Image:                    meta.ImageReference(b.instance.Spec.UI.Image, b.instance.Spec.UI.Version, b.instance.Spec.UI.ImageDigest),
+					ImagePullPolicy:          meta.ImagePullPolicyOrDefault(b.instance.Spec.UI.ImagePullPolicy),
 					Resources:                b.instance.Spec.UI.Resources,
 					TerminationMessagePath:   corev1.TerminationMessagePathDefault,
 					TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+					SecurityContext:          meta.ContainerSecurityContextOrDefault(b.instance.Spec.UI.SecurityContext, meta.DefaultContainerSecurityContext()),
 					Ports: []corev1.ContainerPort{
 						{
 							Name:          "http",
@@ -139,23 +233,28 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 							Protocol:      corev1.ProtocolTCP,
 						},
 					},
-					Env:          env,
-					VolumeMounts: volumeMounts,
+					Env:            env,
+					EnvFrom:        envFrom,
+					VolumeMounts:   volumeMounts,
+					LivenessProbe:  livenessProbe,
+					ReadinessProbe: b.instance.Spec.UI.ReadinessProbe,
+					StartupProbe:   b.instance.Spec.UI.StartupProbe,
 				},
-			},
+			}, b.instance.Spec.UI.Sidecars...),
+			InitContainers:                b.instance.Spec.UI.InitContainers,
 			Volumes:                       volumes,
 			RestartPolicy:                 corev1.RestartPolicyAlways,
 			TerminationGracePeriodSeconds: ptr.To[int64](30),
 			DNSPolicy:                     corev1.DNSClusterFirst,
 			SchedulerName:                 corev1.DefaultSchedulerName,
-			SecurityContext: &corev1.PodSecurityContext{
-				RunAsUser:    ptr.To[int64](5000),
-				RunAsGroup:   ptr.To[int64](5000),
-				RunAsNonRoot: ptr.To[bool](true),
-			},
+			SecurityContext:               meta.PodSecurityContextOrDefault(b.instance.Spec.UI.PodSecurityContext, meta.DefaultPodSecurityContext(5000, false)),
 		},
 	}
 
+	if b.instance.Spec.MTLS != nil && b.instance.Spec.MTLS.FrontendEnabled() {
+		spiffe.Inject(b.instance, &deployment.Spec.Template.Spec, "ui", uiCertsMountPath)
+	}
+
 	if b.instance.Spec.UI.Overrides != nil && b.instance.Spec.UI.Overrides.Deployment != nil {
 		err := kubernetes.ApplyDeploymentOverrides(deployment, b.instance.Spec.UI.Overrides.Deployment)
 		if err != nil {
@@ -169,3 +268,126 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 
 	return nil
 }
+
+// authEnvironmentVariables returns the environment variables configuring the UI OIDC authentication.
+// uiActionEnvironmentVariables maps the UI write actions to the temporal-ui environment variables disabling them.
+var uiActionEnvironmentVariables = map[v1beta1.TemporalUIAction]string{
+	v1beta1.TerminateUIAction:    "TEMPORAL_WORKFLOW_TERMINATE_DISABLED",
+	v1beta1.CancelUIAction:       "TEMPORAL_WORKFLOW_CANCEL_DISABLED",
+	v1beta1.SignalUIAction:       "TEMPORAL_WORKFLOW_SIGNAL_DISABLED",
+	v1beta1.ResetUIAction:        "TEMPORAL_WORKFLOW_RESET_DISABLED",
+	v1beta1.BatchActionsUIAction: "TEMPORAL_BATCH_ACTIONS_DISABLED",
+}
+
+func writeActionsEnvironmentVariables(spec *v1beta1.TemporalUISpec) []corev1.EnvVar {
+	if spec.ReadOnly {
+		return []corev1.EnvVar{
+			{
+				Name:  "TEMPORAL_DISABLE_WRITE_ACTIONS",
+				Value: "true",
+			},
+		}
+	}
+
+	env := []corev1.EnvVar{}
+	for _, action := range spec.DisabledActions {
+		name, ok := uiActionEnvironmentVariables[action]
+		if !ok || slices.ContainsFunc(env, func(e corev1.EnvVar) bool { return e.Name == name }) {
+			continue
+		}
+		env = append(env, corev1.EnvVar{
+			Name:  name,
+			Value: "true",
+		})
+	}
+
+	return env
+}
+
+func (b *DeploymentBuilder) authEnvironmentVariables(auth *v1beta1.TemporalUIAuthSpec) []corev1.EnvVar {
+	callbackURL := auth.CallbackURL
+	if callbackURL == "" {
+		callbackURL = b.instance.Spec.UI.PublicURL() + "/auth/sso/callback"
+	}
+
+	env := []corev1.EnvVar{
+		{
+			Name:  "TEMPORAL_AUTH_ENABLED",
+			Value: "true",
+		},
+		{
+			Name:  "TEMPORAL_AUTH_TYPE",
+			Value: "oidc",
+		},
+		{
+			Name:  "TEMPORAL_AUTH_PROVIDER_URL",
+			Value: auth.ProviderURL,
+		},
+		{
+			Name:  "TEMPORAL_AUTH_SCOPES",
+			Value: strings.Join(auth.GetScopes(), ","),
+		},
+		{
+			Name:  "TEMPORAL_AUTH_CALLBACK_URL",
+			Value: callbackURL,
+		},
+	}
+
+	if auth.IssuerURL != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  "TEMPORAL_AUTH_ISSUER_URL",
+			Value: auth.IssuerURL,
+		})
+	}
+
+	if auth.Label != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  "TEMPORAL_AUTH_LABEL",
+			Value: auth.Label,
+		})
+	}
+
+	if auth.ClientIDSecretRef != nil {
+		env = append(env, corev1.EnvVar{
+			Name: "TEMPORAL_AUTH_CLIENT_ID",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: auth.ClientIDSecretRef,
+			},
+		})
+	} else {
+		env = append(env, corev1.EnvVar{
+			Name:  "TEMPORAL_AUTH_CLIENT_ID",
+			Value: auth.ClientID,
+		})
+	}
+
+	if auth.ClientSecretRef != nil {
+		env = append(env, corev1.EnvVar{
+			Name: "TEMPORAL_AUTH_CLIENT_SECRET",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: auth.ClientSecretRef,
+			},
+		})
+	}
+
+	return env
+}
+
+// replicas returns the replicas count applied to the deployment.
+// When autoscaling, the replicas count is managed by the HorizontalPodAutoscaler: it is left unset once the autoscaler
+// owns the field. Until then the current count is applied, as releasing the field would reset it to its default.
+func (b *DeploymentBuilder) replicas() *int32 {
+	if !b.instance.Spec.UI.Autoscaling.IsEnabled() {
+		return b.instance.Spec.UI.Replicas
+	}
+
+	if b.current == nil {
+		return b.instance.Spec.UI.Replicas
+	}
+
+	if reconciler.IsFieldManagedByOthers(b.current, "spec", "replicas") {
+		return nil
+	}
+
+	return b.current.Spec.Replicas
+}
@@ -0,0 +1,166 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ui_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/ui"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+)
+
+func TestDeploymentBuilderWriteActions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	tests := map[string]struct {
+		readOnly        bool
+		disabledActions []v1beta1.TemporalUIAction
+		expectedEnv     []string
+		unexpectedEnv   []string
+	}{
+		"defaults": {
+			unexpectedEnv: []string{"TEMPORAL_DISABLE_WRITE_ACTIONS", "TEMPORAL_WORKFLOW_TERMINATE_DISABLED"},
+		},
+		"read only": {
+			readOnly:        true,
+			disabledActions: []v1beta1.TemporalUIAction{v1beta1.TerminateUIAction},
+			expectedEnv:     []string{"TEMPORAL_DISABLE_WRITE_ACTIONS"},
+			unexpectedEnv:   []string{"TEMPORAL_WORKFLOW_TERMINATE_DISABLED"},
+		},
+		"disabled actions": {
+			disabledActions: []v1beta1.TemporalUIAction{v1beta1.TerminateUIAction, v1beta1.ResetUIAction, v1beta1.BatchActionsUIAction},
+			expectedEnv:     []string{"TEMPORAL_WORKFLOW_TERMINATE_DISABLED", "TEMPORAL_WORKFLOW_RESET_DISABLED", "TEMPORAL_BATCH_ACTIONS_DISABLED"},
+			unexpectedEnv:   []string{"TEMPORAL_DISABLE_WRITE_ACTIONS", "TEMPORAL_WORKFLOW_SIGNAL_DISABLED"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			cluster := &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.23.0"),
+					Services: &v1beta1.ServicesSpec{
+						Frontend: &v1beta1.ServiceSpec{Port: ptr.To(7233)},
+					},
+					UI: &v1beta1.TemporalUISpec{
+						Enabled:         true,
+						ReadOnly:        test.readOnly,
+						DisabledActions: test.disabledActions,
+					},
+				},
+			}
+
+			builder := ui.NewDeploymentBuilder(cluster, scheme, "")
+			object := builder.Build()
+			require.NoError(tt, builder.Update(object))
+
+			deployment := object.(*appsv1.Deployment)
+			env := map[string]string{}
+			for _, e := range deployment.Spec.Template.Spec.Containers[0].Env {
+				env[e.Name] = e.Value
+			}
+			for _, name := range test.expectedEnv {
+				assert.Equal(tt, "true", env[name], name)
+			}
+			for _, name := range test.unexpectedEnv {
+				assert.NotContains(tt, env, name)
+			}
+		})
+	}
+}
+
+func TestDeploymentBuilderReplicas(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	current := func(replicas int32, managers ...string) *appsv1.Deployment {
+		deployment := &appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{Replicas: ptr.To(replicas)},
+		}
+		for _, manager := range managers {
+			deployment.ManagedFields = append(deployment.ManagedFields, metav1.ManagedFieldsEntry{
+				Manager:  manager,
+				FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)},
+			})
+		}
+		return deployment
+	}
+
+	tests := map[string]struct {
+		autoscaling *v1beta1.AutoscalingSpec
+		current     *appsv1.Deployment
+		expected    *int32
+	}{
+		"without autoscaling": {
+			current:  current(3, "kube-controller-manager"),
+			expected: ptr.To[int32](2),
+		},
+		"autoscaling on creation": {
+			autoscaling: &v1beta1.AutoscalingSpec{Enabled: true},
+			expected:    ptr.To[int32](2),
+		},
+		"autoscaling before the autoscaler owns replicas": {
+			autoscaling: &v1beta1.AutoscalingSpec{Enabled: true},
+			current:     current(3, "temporal-operator"),
+			expected:    ptr.To[int32](3),
+		},
+		"autoscaling once the autoscaler owns replicas": {
+			autoscaling: &v1beta1.AutoscalingSpec{Enabled: true},
+			current:     current(5, "temporal-operator", "kube-controller-manager"),
+			expected:    nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			cluster := &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.23.0"),
+					Services: &v1beta1.ServicesSpec{
+						Frontend: &v1beta1.ServiceSpec{Port: ptr.To(7233)},
+					},
+					UI: &v1beta1.TemporalUISpec{
+						Enabled:     true,
+						Replicas:    ptr.To[int32](2),
+						Autoscaling: test.autoscaling,
+					},
+				},
+			}
+
+			builder := ui.NewDeploymentBuilder(cluster, scheme, "")
+			builder.SetCurrent(test.current)
+			object := builder.Build()
+			require.NoError(tt, builder.Update(object))
+
+			assert.Equal(tt, test.expected, object.(*appsv1.Deployment).Spec.Replicas)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ui
+
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+type HorizontalPodAutoscalerBuilder struct {
+	instance *v1beta1.TemporalCluster
+	scheme   *runtime.Scheme
+}
+
+func NewHorizontalPodAutoscalerBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme) *HorizontalPodAutoscalerBuilder {
+	return &HorizontalPodAutoscalerBuilder{
+		instance: instance,
+		scheme:   scheme,
+	}
+}
+
+func (b *HorizontalPodAutoscalerBuilder) Build() client.Object {
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.instance.ChildResourceName("ui"),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.GetLabels(b.instance, "ui", b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *HorizontalPodAutoscalerBuilder) Enabled() bool {
+	return b.instance.Spec.UI != nil &&
+		b.instance.Spec.UI.Enabled &&
+		b.instance.Spec.UI.Autoscaling.IsEnabled()
+}
+
+func (b *HorizontalPodAutoscalerBuilder) Update(object client.Object) error {
+	spec := b.instance.Spec.UI.Autoscaling
+
+	hpa := object.(*autoscalingv2.HorizontalPodAutoscaler)
+	hpa.Labels = object.GetLabels()
+	hpa.Annotations = object.GetAnnotations()
+
+	metrics := spec.Metrics
+	if len(metrics) == 0 {
+		metrics = []autoscalingv2.MetricSpec{
+			{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name: corev1.ResourceCPU,
+					Target: autoscalingv2.MetricTarget{
+						Type:               autoscalingv2.UtilizationMetricType,
+						AverageUtilization: ptr.To[int32](80),
+					},
+				},
+			},
+		}
+	}
+
+	minReplicas := spec.MinReplicas
+	if minReplicas == nil {
+		minReplicas = ptr.To[int32](1)
+	}
+
+	hpa.Spec = autoscalingv2.HorizontalPodAutoscalerSpec{
+		ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       b.instance.ChildResourceName("ui"),
+		},
+		MinReplicas: minReplicas,
+		MaxReplicas: spec.MaxReplicas,
+		Metrics:     metrics,
+		Behavior:    spec.Behavior,
+	}
+
+	if err := controllerutil.SetControllerReference(b.instance, hpa, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+
+	return nil
+}
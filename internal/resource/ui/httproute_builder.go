@@ -0,0 +1,99 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ui
+
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+type HTTPRouteBuilder struct {
+	instance *v1beta1.TemporalCluster
+	scheme   *runtime.Scheme
+}
+
+func NewHTTPRouteBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme) *HTTPRouteBuilder {
+	return &HTTPRouteBuilder{
+		instance: instance,
+		scheme:   scheme,
+	}
+}
+
+func (b *HTTPRouteBuilder) Build() client.Object {
+	return &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.instance.ChildResourceName("ui"),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.GetLabels(b.instance, "ui", b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *HTTPRouteBuilder) Enabled() bool {
+	return b.instance.Spec.UI != nil &&
+		b.instance.Spec.UI.Enabled &&
+		b.instance.Spec.UI.HTTPRoute != nil
+}
+
+func (b *HTTPRouteBuilder) Update(object client.Object) error {
+	spec := b.instance.Spec.UI.HTTPRoute
+
+	route := object.(*gatewayv1.HTTPRoute)
+	route.Labels = object.GetLabels()
+	route.Annotations = metadata.Merge(object.GetAnnotations(), spec.Annotations)
+
+	route.Spec = gatewayv1.HTTPRouteSpec{
+		CommonRouteSpec: gatewayv1.CommonRouteSpec{
+			ParentRefs: meta.GatewayParentRefs(spec.ParentRefs),
+		},
+		Hostnames: spec.Hostnames,
+		Rules: []gatewayv1.HTTPRouteRule{
+			{
+				Matches: []gatewayv1.HTTPRouteMatch{
+					{
+						Path: &gatewayv1.HTTPPathMatch{
+							Type:  ptr.To(gatewayv1.PathMatchPathPrefix),
+							Value: ptr.To(b.instance.Spec.UI.HTTPRoutePath()),
+						},
+					},
+				},
+				Filters: spec.Filters,
+				BackendRefs: []gatewayv1.HTTPBackendRef{
+					{
+						BackendRef: meta.GatewayServiceBackendRef(b.instance.ChildResourceName("ui"), UIServicePort),
+					},
+				},
+			},
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(b.instance, route, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+	return nil
+}
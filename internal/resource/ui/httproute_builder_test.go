@@ -0,0 +1,78 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ui_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/ui"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestHTTPRouteBuilder(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	require.NoError(t, gatewayv1.AddToScheme(scheme))
+
+	cluster := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+		Spec: v1beta1.TemporalClusterSpec{
+			Version: version.MustNewVersionFromString("1.23.0"),
+			UI: &v1beta1.TemporalUISpec{
+				Enabled: true,
+				HTTPRoute: &v1beta1.TemporalUIHTTPRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: "public"}},
+					Hostnames:  []gatewayv1.Hostname{"temporal.example.com"},
+					Path:       "/temporal",
+				},
+			},
+		},
+	}
+
+	builder := ui.NewHTTPRouteBuilder(cluster, scheme)
+	assert.True(t, builder.Enabled())
+
+	object := builder.Build()
+	require.NoError(t, builder.Update(object))
+
+	route := object.(*gatewayv1.HTTPRoute)
+	assert.Equal(t, "prod-ui", route.Name)
+	assert.Equal(t, []gatewayv1.ParentReference{
+		{
+			Group: ptr.To(gatewayv1.Group(gatewayv1.GroupName)),
+			Kind:  ptr.To(gatewayv1.Kind("Gateway")),
+			Name:  "public",
+		},
+	}, route.Spec.ParentRefs)
+	assert.Equal(t, []gatewayv1.Hostname{"temporal.example.com"}, route.Spec.Hostnames)
+
+	require.Len(t, route.Spec.Rules, 1)
+	rule := route.Spec.Rules[0]
+	assert.Equal(t, "/temporal", *rule.Matches[0].Path.Value)
+	assert.Equal(t, gatewayv1.ObjectName("prod-ui"), rule.BackendRefs[0].Name)
+	assert.Equal(t, gatewayv1.PortNumber(ui.UIServicePort), *rule.BackendRefs[0].Port)
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -61,7 +62,7 @@ func (b *IngressBuilder) Enabled() bool {
 }
 
 // parseHost parses the provided ingress host.
-// It parses the path, but it's useless for now has the UI does not support another path than "/".
+// The parsed path is ignored, spec.ui.ingress.path should be used instead.
 func (b *IngressBuilder) parseHost(host string) *url.URL {
 	result := &url.URL{}
 	parts := strings.Split(host, "/")
@@ -78,12 +79,19 @@ func (b *IngressBuilder) parseHost(host string) *url.URL {
 func (b *IngressBuilder) Update(object client.Object) error {
 	ingress := object.(*networkingv1.Ingress)
 	ingress.Labels = object.GetLabels()
-	ingress.Annotations = metadata.Merge(object.GetAnnotations(), b.instance.Spec.UI.Ingress.Annotations)
+	ingress.Annotations = metadata.Merge(
+		object.GetAnnotations(),
+		meta.IngressCertManagerAnnotations(b.instance.Spec.UI.Ingress.CertManagerIssuerRef),
+		meta.ExternalDNSAnnotations(b.instance.Spec.UI.ExternalHostname),
+		b.instance.Spec.UI.Ingress.Annotations,
+	)
 
 	rules := make([]networkingv1.IngressRule, 0, len(b.instance.Spec.UI.Ingress.Hosts))
+	hosts := make([]string, 0, len(b.instance.Spec.UI.Ingress.Hosts))
 
 	for _, host := range b.instance.Spec.UI.Ingress.Hosts {
 		parsedURL := b.parseHost(host)
+		hosts = append(hosts, parsedURL.Host)
 		pathType := networkingv1.PathTypePrefix
 		rules = append(rules, networkingv1.IngressRule{
 			Host: parsedURL.Host,
@@ -91,7 +99,7 @@ func (b *IngressBuilder) Update(object client.Object) error {
 				HTTP: &networkingv1.HTTPIngressRuleValue{
 					Paths: []networkingv1.HTTPIngressPath{
 						{
-							Path:     "/", // Note that the generated source code for the UI uses hardcoded "/".
+							Path:     b.instance.Spec.UI.IngressPath(),
 							PathType: &pathType,
 							Backend: networkingv1.IngressBackend{
 								Service: &networkingv1.IngressServiceBackend{
@@ -111,7 +119,7 @@ func (b *IngressBuilder) Update(object client.Object) error {
 	ingress.Spec = networkingv1.IngressSpec{
 		IngressClassName: b.instance.Spec.UI.Ingress.IngressClassName,
 		Rules:            rules,
-		TLS:              b.instance.Spec.UI.Ingress.TLS,
+		TLS:              meta.IngressTLS(b.instance.Spec.UI.Ingress, hosts, b.instance.ChildResourceName("ui-tls")),
 	}
 
 	if err := controllerutil.SetControllerReference(b.instance, ingress, b.scheme); err != nil {
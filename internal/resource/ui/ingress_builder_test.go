@@ -0,0 +1,120 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ui_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/ui"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestIngressBuilder(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	tests := map[string]struct {
+		ingress             *v1beta1.TemporalUIIngressSpec
+		publicPath          string
+		expectedPath        string
+		expectedAnnotations map[string]string
+		expectedTLS         []networkingv1.IngressTLS
+	}{
+		"defaults": {
+			ingress: &v1beta1.TemporalUIIngressSpec{
+				Hosts: []string{"temporal.example.com"},
+			},
+			expectedPath: "/",
+		},
+		"path and user provided TLS": {
+			ingress: &v1beta1.TemporalUIIngressSpec{
+				Hosts: []string{"example.com"},
+				Path:  "/temporal",
+				TLS:   []networkingv1.IngressTLS{{Hosts: []string{"example.com"}, SecretName: "my-tls"}},
+			},
+			expectedPath: "/temporal",
+			expectedTLS:  []networkingv1.IngressTLS{{Hosts: []string{"example.com"}, SecretName: "my-tls"}},
+		},
+		"public path": {
+			ingress: &v1beta1.TemporalUIIngressSpec{
+				Hosts: []string{"example.com"},
+			},
+			publicPath:   "/temporal",
+			expectedPath: "/temporal",
+		},
+		"cert-manager cluster issuer": {
+			ingress: &v1beta1.TemporalUIIngressSpec{
+				Hosts:                []string{"temporal.example.com"},
+				CertManagerIssuerRef: &v1beta1.CertManagerIssuerReference{Name: "letsencrypt", Kind: "ClusterIssuer"},
+			},
+			expectedPath:        "/",
+			expectedAnnotations: map[string]string{"cert-manager.io/cluster-issuer": "letsencrypt"},
+			expectedTLS:         []networkingv1.IngressTLS{{Hosts: []string{"temporal.example.com"}, SecretName: "prod-ui-tls"}},
+		},
+		"cert-manager external issuer": {
+			ingress: &v1beta1.TemporalUIIngressSpec{
+				Hosts:                []string{"temporal.example.com"},
+				CertManagerIssuerRef: &v1beta1.CertManagerIssuerReference{Name: "pca", Kind: "AWSPCAIssuer", Group: "awspca.cert-manager.io"},
+			},
+			expectedPath: "/",
+			expectedAnnotations: map[string]string{
+				"cert-manager.io/issuer":       "pca",
+				"cert-manager.io/issuer-kind":  "AWSPCAIssuer",
+				"cert-manager.io/issuer-group": "awspca.cert-manager.io",
+			},
+			expectedTLS: []networkingv1.IngressTLS{{Hosts: []string{"temporal.example.com"}, SecretName: "prod-ui-tls"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			cluster := &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.23.0"),
+					UI: &v1beta1.TemporalUISpec{
+						Enabled:    true,
+						PublicPath: test.publicPath,
+						Ingress:    test.ingress,
+					},
+				},
+			}
+
+			builder := ui.NewIngressBuilder(cluster, scheme)
+			object := builder.Build()
+			require.NoError(tt, builder.Update(object))
+
+			ingress := object.(*networkingv1.Ingress)
+			require.Len(tt, ingress.Spec.Rules, 1)
+			assert.Equal(tt, test.expectedPath, ingress.Spec.Rules[0].HTTP.Paths[0].Path)
+			assert.Equal(tt, "prod-ui", ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name)
+			for key, value := range test.expectedAnnotations {
+				assert.Equal(tt, value, ingress.Annotations[key])
+			}
+			assert.Equal(tt, test.expectedTLS, ingress.Spec.TLS)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ui
+
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	routev1 "github.com/alexandrevilain/temporal-operator/internal/apis/openshift/route/v1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+type RouteBuilder struct {
+	instance *v1beta1.TemporalCluster
+	scheme   *runtime.Scheme
+}
+
+func NewRouteBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme) *RouteBuilder {
+	return &RouteBuilder{
+		instance: instance,
+		scheme:   scheme,
+	}
+}
+
+func (b *RouteBuilder) Build() client.Object {
+	return &routev1.Route{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.instance.ChildResourceName("ui"),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.GetLabels(b.instance, "ui", b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *RouteBuilder) Enabled() bool {
+	return b.instance.Spec.UI != nil &&
+		b.instance.Spec.UI.Enabled &&
+		b.instance.Spec.UI.Route != nil
+}
+
+func (b *RouteBuilder) Update(object client.Object) error {
+	spec := b.instance.Spec.UI.Route
+
+	route := object.(*routev1.Route)
+	route.Labels = object.GetLabels()
+	route.Annotations = metadata.Merge(object.GetAnnotations(), spec.Annotations)
+	route.Spec = meta.RouteSpecFor(spec, route, b.instance.ChildResourceName("ui"), "http", b.instance.Spec.UI.GetPublicPath(), v1beta1.EdgeRouteTermination)
+
+	if err := controllerutil.SetControllerReference(b.instance, route, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+	return nil
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
 	"github.com/alexandrevilain/temporal-operator/pkg/kubernetes"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -62,17 +63,26 @@ func (b *ServiceBuilder) Update(object client.Object) error {
 	service := object.(*corev1.Service)
 	service.Labels = object.GetLabels()
 	service.Annotations = object.GetAnnotations()
+	// The UI DNS record points to the ingress when there's one.
+	if b.instance.Spec.UI.Ingress == nil && b.instance.Spec.UI.ExternalHostname != "" {
+		service.Annotations = metadata.Merge(service.Annotations, meta.ExternalDNSAnnotations(b.instance.Spec.UI.ExternalHostname))
+	} else {
+		delete(service.Annotations, meta.ExternalDNSHostnameAnnotation)
+	}
 	service.Spec.Type = corev1.ServiceTypeClusterIP
 	service.Spec.Selector = metadata.LabelsSelector(b.instance, "ui")
 	service.Spec.Ports = []corev1.ServicePort{
 		{
-			Name:       "http",
-			TargetPort: intstr.FromString("http"),
-			Protocol:   corev1.ProtocolTCP,
-			Port:       int32(UIServicePort),
+			Name:        "http",
+			TargetPort:  intstr.FromString("http"),
+			Protocol:    corev1.ProtocolTCP,
+			AppProtocol: b.instance.Spec.UI.GetAppProtocol(),
+			Port:        int32(UIServicePort),
 		},
 	}
 
+	meta.SetServiceIPFamilies(service, b.instance.Spec.Network)
+
 	if err := controllerutil.SetControllerReference(b.instance, service, b.scheme); err != nil {
 		return fmt.Errorf("failed setting controller reference: %w", err)
 	}
@@ -19,16 +19,21 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"os"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/config"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
@@ -36,12 +41,19 @@ import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	istionetworkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	istiosecurityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	"github.com/alexandrevilain/controller-tools/pkg/discovery"
 	temporaliov1beta1 "github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"github.com/alexandrevilain/temporal-operator/controllers"
+	routev1 "github.com/alexandrevilain/temporal-operator/internal/apis/openshift/route/v1"
+	"github.com/alexandrevilain/temporal-operator/internal/backoff"
 	internaldiscovery "github.com/alexandrevilain/temporal-operator/internal/discovery"
+	"github.com/alexandrevilain/temporal-operator/internal/health"
+	"github.com/alexandrevilain/temporal-operator/internal/operatorconfig"
 	"github.com/alexandrevilain/temporal-operator/webhooks"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	//+kubebuilder:scaffold:imports
 )
@@ -58,6 +70,9 @@ func init() {
 	utilruntime.Must(istionetworkingv1beta1.AddToScheme(scheme))
 	utilruntime.Must(temporaliov1beta1.AddToScheme(scheme))
 	utilruntime.Must(monitoringv1.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1alpha2.AddToScheme(scheme))
+	utilruntime.Must(routev1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -66,12 +81,17 @@ func main() {
 		metricsAddr          string
 		enableLeaderElection bool
 		probeAddr            string
+		configFile           string
 	)
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&configFile, "config", "",
+		"The operator configuration file. Flags set on the command line take precedence over the file values.")
+	// The flags set on the command line are applied on top of the configuration file by operatorconfig.Load.
+	operatorconfig.Default().BindFlags(flag.CommandLine)
 
 	opts := zap.Options{
 		Development: true,
@@ -81,14 +101,53 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	flags := map[string]string{}
+	flag.Visit(func(f *flag.Flag) {
+		flags[f.Name] = f.Value.String()
+	})
+
+	cfg, err := operatorconfig.Load(configFile, flags)
+	if err != nil {
+		setupLog.Error(err, "invalid operator configuration")
+		os.Exit(1)
+	}
+
+	if err := cfg.ApplyReloadable(); err != nil {
+		setupLog.Error(err, "invalid operator configuration")
+		os.Exit(1)
+	}
+
+	// Controllers are keyed by the group kind they reconcile.
+	groupKindConcurrency := map[string]int{}
+	if cfg.ClusterMaxConcurrentReconciles > 0 {
+		groupKindConcurrency["TemporalCluster.temporal.io"] = cfg.ClusterMaxConcurrentReconciles
+	}
+	if cfg.NamespaceMaxConcurrentReconciles > 0 {
+		groupKindConcurrency["TemporalNamespace.temporal.io"] = cfg.NamespaceMaxConcurrentReconciles
+	}
+
+	cacheOpts, err := newCacheOptions(cfg.WatchNamespaces, cfg.WatchLabelSelector)
+	if err != nil {
+		setupLog.Error(err, "invalid watch configuration")
+		os.Exit(1)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
+		Cache:  cacheOpts,
 		Metrics: metricsserver.Options{
 			BindAddress: metricsAddr,
 		},
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "0cfcfa11.temporal.io",
+		LeaseDuration:          &cfg.LeaderElection.LeaseDuration,
+		RenewDeadline:          &cfg.LeaderElection.RenewDeadline,
+		RetryPeriod:            &cfg.LeaderElection.RetryPeriod,
+		Controller: config.Controller{
+			MaxConcurrentReconciles: cfg.MaxConcurrentReconciles,
+			GroupKindConcurrency:    groupKindConcurrency,
+		},
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -108,7 +167,7 @@ func main() {
 	}
 
 	if err = (&controllers.TemporalClusterReconciler{
-		Base:          controllers.New(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("cluster-controller"), discoveryManager),
+		Base:          controllers.New(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("cluster-controller"), discoveryManager, cfg.MaxRequeueDelay),
 		AvailableAPIs: availableAPIs,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Cluster")
@@ -123,7 +182,7 @@ func main() {
 	}
 
 	if err = (&controllers.TemporalClusterClientReconciler{
-		Base:          controllers.New(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("clusterclient-controller"), discoveryManager),
+		Base:          controllers.New(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("clusterclient-controller"), discoveryManager, cfg.MaxRequeueDelay),
 		AvailableAPIs: availableAPIs,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterClient")
@@ -131,12 +190,49 @@ func main() {
 	}
 
 	if err = (&controllers.TemporalNamespaceReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("namespace-controller"),
+		Backoff:  backoff.New(backoff.DefaultBaseDelay, cfg.MaxRequeueDelay),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Namespace")
 		os.Exit(1)
 	}
+
+	if err = (&controllers.TemporalBackupReconciler{
+		Base: controllers.New(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("backup-controller"), discoveryManager, cfg.MaxRequeueDelay),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Backup")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.TemporalRestoreReconciler{
+		Base: controllers.New(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("restore-controller"), discoveryManager, cfg.MaxRequeueDelay),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Restore")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.TemporalFailoverReconciler{
+		Base: controllers.New(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("failover-controller"), discoveryManager, cfg.MaxRequeueDelay),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Failover")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.TemporalReshardReconciler{
+		Base: controllers.New(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("reshard-controller"), discoveryManager, cfg.MaxRequeueDelay),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Reshard")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.TemporalNamespaceMigrationReconciler{
+		Base: controllers.New(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("namespacemigration-controller"), discoveryManager, cfg.MaxRequeueDelay),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NamespaceMigration")
+		os.Exit(1)
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -147,6 +243,38 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if err := mgr.AddReadyzCheck("informers", health.CacheSyncChecker(mgr.GetCache())); err != nil {
+		setupLog.Error(err, "unable to set up ready check", "check", "informers")
+		os.Exit(1)
+	}
+	// Controllers are named after the kind they reconcile.
+	for _, controller := range []string{
+		"temporalcluster",
+		"temporalclusterclient",
+		"temporalnamespace",
+		"temporalbackup",
+		"temporalrestore",
+		"temporalfailover",
+		"temporalreshard",
+		"temporalnamespacemigration",
+	} {
+		if err := mgr.AddHealthzCheck(controller, health.ControllerChecker(metrics.Registry, controller, cfg.MaxReconcileDuration)); err != nil {
+			setupLog.Error(err, "unable to set up health check", "controller", controller)
+			os.Exit(1)
+		}
+	}
+
+	if configFile != "" {
+		if err := mgr.Add(&operatorconfig.Reloader{
+			Path:     configFile,
+			Flags:    flags,
+			Interval: operatorconfig.DefaultReloadInterval,
+			Started:  cfg,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up configuration reloader")
+			os.Exit(1)
+		}
+	}
 
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
@@ -154,3 +282,40 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// newCacheOptions returns the manager cache options restricting the objects watched by the operator
+// to the provided namespaces, and its custom resources to the provided label selector.
+func newCacheOptions(watchNamespaces []string, watchLabelSelector string) (cache.Options, error) {
+	opts := cache.Options{}
+
+	for _, namespace := range watchNamespaces {
+		if opts.DefaultNamespaces == nil {
+			opts.DefaultNamespaces = map[string]cache.Config{}
+		}
+		opts.DefaultNamespaces[namespace] = cache.Config{}
+	}
+
+	if watchLabelSelector != "" {
+		selector, err := labels.Parse(watchLabelSelector)
+		if err != nil {
+			return opts, fmt.Errorf("can't parse watch label selector: %w", err)
+		}
+
+		// Only the custom resources are filtered: the resources they own don't carry their labels.
+		opts.ByObject = map[client.Object]cache.ByObject{}
+		for _, obj := range []client.Object{
+			&temporaliov1beta1.TemporalCluster{},
+			&temporaliov1beta1.TemporalClusterClient{},
+			&temporaliov1beta1.TemporalNamespace{},
+			&temporaliov1beta1.TemporalBackup{},
+			&temporaliov1beta1.TemporalRestore{},
+			&temporaliov1beta1.TemporalFailover{},
+			&temporaliov1beta1.TemporalReshard{},
+			&temporaliov1beta1.TemporalNamespaceMigration{},
+		} {
+			opts.ByObject[obj] = cache.ByObject{Label: selector}
+		}
+	}
+
+	return opts, nil
+}
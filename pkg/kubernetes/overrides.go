@@ -113,6 +113,32 @@ func ApplyDeploymentOverrides(deployment *appsv1.Deployment, override *v1beta1.D
 	return nil
 }
 
+// ApplyStatefulSetOverrides applies the provided DeploymentOverride to the provided StatefulSet.
+func ApplyStatefulSetOverrides(statefulSet *appsv1.StatefulSet, override *v1beta1.DeploymentOverride) error {
+	if override == nil {
+		return nil
+	}
+
+	if override.ObjectMetaOverride != nil {
+		if len(override.Labels) > 0 {
+			statefulSet.Labels = metadata.Merge(statefulSet.Labels, override.Labels)
+		}
+
+		if len(override.Annotations) > 0 {
+			statefulSet.Annotations = metadata.Merge(statefulSet.Annotations, override.Annotations)
+		}
+	}
+
+	if override.Spec != nil {
+		err := ApplyPodTemplateSpecOverrides(&statefulSet.Spec.Template, override.Spec.Template)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // ApplyServiceOverrides applies the provided ServiceOverride to the provided Service.
 func ApplyServiceOverrides(service *corev1.Service, override *v1beta1.ObjectMetaOverride) error {
 	if override == nil {
@@ -0,0 +1,69 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package maintenance computes the maintenance windows of temporal clusters.
+package maintenance
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/robfig/cron/v3"
+)
+
+// DefaultWindowDuration is the duration of maintenance windows if none is set.
+const DefaultWindowDuration = 2 * time.Hour
+
+// Window is a recurring maintenance window.
+type Window struct {
+	schedule cron.Schedule
+	duration time.Duration
+}
+
+// NewWindow parses the provided maintenance window spec.
+func NewWindow(spec *v1beta1.MaintenanceWindowSpec) (*Window, error) {
+	schedule, err := cron.ParseStandard(spec.Schedule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule: %w", err)
+	}
+
+	duration := DefaultWindowDuration
+	if spec.Duration != nil {
+		duration = spec.Duration.Duration
+	}
+	if duration <= 0 {
+		return nil, fmt.Errorf("duration must be positive, got %s", duration)
+	}
+
+	return &Window{
+		schedule: schedule,
+		duration: duration,
+	}, nil
+}
+
+// IsOpen returns true if a maintenance window is open at the provided time.
+func (w *Window) IsOpen(t time.Time) bool {
+	// The last window which could still be open started at most duration ago.
+	start := w.schedule.Next(t.Add(-w.duration))
+	return !start.After(t)
+}
+
+// NextStart returns the time at which the next maintenance window opens after the provided time.
+func (w *Window) NextStart(t time.Time) time.Time {
+	return w.schedule.Next(t)
+}
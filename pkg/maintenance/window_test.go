@@ -0,0 +1,83 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package maintenance_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/maintenance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWindow(t *testing.T) {
+	// Windows open every saturday at 02:00 UTC, for one hour.
+	window, err := maintenance.NewWindow(&v1beta1.MaintenanceWindowSpec{
+		Schedule: "0 2 * * SAT",
+		Duration: &metav1.Duration{Duration: time.Hour},
+	})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		now          time.Time
+		expectedOpen bool
+		expectedNext time.Time
+	}{
+		"before the window": {
+			now:          time.Date(2024, 5, 18, 1, 59, 0, 0, time.UTC),
+			expectedOpen: false,
+			expectedNext: time.Date(2024, 5, 18, 2, 0, 0, 0, time.UTC),
+		},
+		"window start": {
+			now:          time.Date(2024, 5, 18, 2, 0, 0, 0, time.UTC),
+			expectedOpen: true,
+			expectedNext: time.Date(2024, 5, 25, 2, 0, 0, 0, time.UTC),
+		},
+		"during the window": {
+			now:          time.Date(2024, 5, 18, 2, 30, 0, 0, time.UTC),
+			expectedOpen: true,
+			expectedNext: time.Date(2024, 5, 25, 2, 0, 0, 0, time.UTC),
+		},
+		"window end": {
+			now:          time.Date(2024, 5, 18, 3, 0, 0, 0, time.UTC),
+			expectedOpen: false,
+			expectedNext: time.Date(2024, 5, 25, 2, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			assert.Equal(tt, test.expectedOpen, window.IsOpen(test.now))
+			assert.Equal(tt, test.expectedNext, window.NextStart(test.now))
+		})
+	}
+}
+
+func TestNewWindowErrors(t *testing.T) {
+	_, err := maintenance.NewWindow(&v1beta1.MaintenanceWindowSpec{Schedule: "every saturday"})
+	assert.Error(t, err)
+
+	_, err = maintenance.NewWindow(&v1beta1.MaintenanceWindowSpec{
+		Schedule: "0 2 * * SAT",
+		Duration: &metav1.Duration{Duration: -time.Hour},
+	})
+	assert.Error(t, err)
+}
@@ -58,8 +58,14 @@ var deployGVK = schema.GroupVersionKind{
 	Kind:    "Deployment",
 }
 
+var statefulSetGVK = schema.GroupVersionKind{
+	Group:   "apps",
+	Version: "v1",
+	Kind:    "StatefulSet",
+}
+
 // ReconciledObjectsToServiceStatuses returns a list of service statuses from a list of reconciled objects.
-// It filters for deployments and only returns the ones that match the cluster's services.
+// It filters for deployments and statefulsets and only returns the ones that match the cluster's services.
 func ReconciledObjectsToServiceStatuses(c *v1beta1.TemporalCluster, objects []client.Object) ([]*v1beta1.ServiceStatus, error) {
 	services := []primitives.ServiceName{
 		primitives.FrontendService,
@@ -72,7 +78,8 @@ func ReconciledObjectsToServiceStatuses(c *v1beta1.TemporalCluster, objects []cl
 	result := []*v1beta1.ServiceStatus{}
 
 	for _, object := range objects {
-		if object.GetObjectKind().GroupVersionKind() != deployGVK {
+		gvk := object.GetObjectKind().GroupVersionKind()
+		if gvk != deployGVK && gvk != statefulSetGVK {
 			continue
 		}
 
@@ -23,6 +23,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"github.com/alexandrevilain/temporal-operator/pkg/status"
@@ -271,6 +272,48 @@ func TestReconciledObjectsToServiceStatuses(t *testing.T) {
 				},
 			},
 		},
+		"history statefulset ready with version": {
+			cluster: &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "default",
+				},
+				Spec: v1beta1.TemporalClusterSpec{},
+			},
+			objects: []client.Object{
+				&appsv1.StatefulSet{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "StatefulSet",
+						APIVersion: "apps/v1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-history",
+						Namespace: "default",
+						Labels: map[string]string{
+							"app.kubernetes.io/version": "1.2.3",
+						},
+					},
+					Spec: appsv1.StatefulSetSpec{
+						Replicas: ptr.To[int32](1),
+					},
+					Status: appsv1.StatefulSetStatus{
+						ObservedGeneration: 1,
+						UpdatedReplicas:    1,
+						ReadyReplicas:      1,
+						AvailableReplicas:  1,
+						CurrentReplicas:    1,
+						Replicas:           1,
+					},
+				},
+			},
+			expected: []*v1beta1.ServiceStatus{
+				{
+					Name:    "history",
+					Ready:   true,
+					Version: "1.2.3",
+				},
+			},
+		},
 		"frontend service not ready with version": {
 			cluster: &v1beta1.TemporalCluster{
 				ObjectMeta: metav1.ObjectMeta{
@@ -48,6 +48,15 @@ func URI(provider *v1beta1.ArchivalProvider, spec *v1beta1.ArchivalSpec) string
 	return u.String()
 }
 
+// NamespaceURI returns the archival URI of a namespace using the provided provider.
+// If the namespace spec doesn't specify a path, the cluster-level path is used.
+func NamespaceURI(provider *v1beta1.ArchivalProvider, clusterSpec, spec *v1beta1.ArchivalSpec) string {
+	if spec.Path == "" && clusterSpec != nil {
+		return URI(provider, clusterSpec)
+	}
+	return URI(provider, spec)
+}
+
 func FilestoreArchiverToTemporalFilestoreArchiver(a *v1beta1.FilestoreArchiver) *config.FilestoreArchiver {
 	if a == nil {
 		return nil
@@ -76,7 +85,10 @@ func GCSArchiverToTemporalGstorageArchiver(a *v1beta1.GCSArchiver) *config.Gstor
 		return nil
 	}
 
-	return &config.GstorageArchiver{
-		CredentialsPath: a.CredentialsFileMountPath(),
+	cfg := &config.GstorageArchiver{}
+	if a.CredentialsRef != nil {
+		cfg.CredentialsPath = a.CredentialsFileMountPath()
 	}
+
+	return cfg
 }
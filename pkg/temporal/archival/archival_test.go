@@ -0,0 +1,88 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package archival_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal/archival"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestNamespaceURI(t *testing.T) {
+	provider := &v1beta1.ArchivalProvider{
+		S3: &v1beta1.S3Archiver{},
+	}
+
+	tests := map[string]struct {
+		clusterSpec *v1beta1.ArchivalSpec
+		spec        *v1beta1.ArchivalSpec
+		expected    string
+	}{
+		"namespace path": {
+			clusterSpec: &v1beta1.ArchivalSpec{Path: "cluster-bucket"},
+			spec:        &v1beta1.ArchivalSpec{Enabled: true, Path: "namespace-bucket"},
+			expected:    "s3://namespace-bucket",
+		},
+		"defaults to cluster path": {
+			clusterSpec: &v1beta1.ArchivalSpec{Path: "cluster-bucket"},
+			spec:        &v1beta1.ArchivalSpec{Enabled: true},
+			expected:    "s3://cluster-bucket",
+		},
+		"no cluster default": {
+			clusterSpec: nil,
+			spec:        &v1beta1.ArchivalSpec{Enabled: true, Path: "namespace-bucket"},
+			expected:    "s3://namespace-bucket",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			assert.Equal(tt, test.expected, archival.NamespaceURI(provider, test.clusterSpec, test.spec))
+		})
+	}
+}
+
+func TestGCSArchiverToTemporalGstorageArchiver(t *testing.T) {
+	tests := map[string]struct {
+		archiver *v1beta1.GCSArchiver
+		expected string
+	}{
+		"with credentials": {
+			archiver: &v1beta1.GCSArchiver{
+				CredentialsRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "gcs-credentials"},
+					Key:                  "credentials.json",
+				},
+			},
+			expected: "/etc/archival/credentials.json",
+		},
+		"with workload identity": {
+			archiver: &v1beta1.GCSArchiver{},
+			expected: "",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			assert.Equal(tt, test.expected, archival.GCSArchiverToTemporalGstorageArchiver(test.archiver).CredentialsPath)
+		})
+	}
+}
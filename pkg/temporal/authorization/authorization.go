@@ -28,13 +28,28 @@ func ToTemporalAuthorization(authorization *v1beta1.AuthorizationSpec) config.Au
 		return config.Authorization{}
 	}
 
+	keyProvider := config.JWTKeyProvider{
+		KeySourceURIs: authorization.JWTKeyProvider.KeySourceURIs,
+	}
+	if authorization.JWTKeyProvider.RefreshInterval != nil {
+		keyProvider.RefreshInterval = authorization.JWTKeyProvider.RefreshInterval.Duration
+	}
+
 	return config.Authorization{
-		JWTKeyProvider: config.JWTKeyProvider{
-			KeySourceURIs:   authorization.JWTKeyProvider.KeySourceURIs,
-			RefreshInterval: authorization.JWTKeyProvider.RefreshInterval.Duration,
-		},
+		JWTKeyProvider:       keyProvider,
 		PermissionsClaimName: authorization.PermissionsClaimName,
-		Authorizer:           authorization.Authorizer,
-		ClaimMapper:          authorization.ClaimMapper,
+		Authorizer:           toTemporalName(authorization.Authorizer),
+		ClaimMapper:          toTemporalName(authorization.ClaimMapper),
+		AuthHeaderName:       authorization.AuthHeaderName,
+		AuthExtraHeaderName:  authorization.AuthExtraHeaderName,
+	}
+}
+
+// toTemporalName returns the name temporal expects for the provided authorizer or claim mapper.
+// Temporal uses an empty name for its no-operation implementations.
+func toTemporalName(name string) string {
+	if name == v1beta1.NoopAuthorization {
+		return ""
 	}
+	return name
 }
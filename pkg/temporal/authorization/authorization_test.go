@@ -0,0 +1,104 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package authorization_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal/authorization"
+	"github.com/stretchr/testify/assert"
+	"go.temporal.io/server/common/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestToTemporalAuthorization(t *testing.T) {
+	tests := map[string]struct {
+		spec     *v1beta1.AuthorizationSpec
+		expected config.Authorization
+	}{
+		"nil spec": {
+			spec:     nil,
+			expected: config.Authorization{},
+		},
+		"noop implementations": {
+			spec: &v1beta1.AuthorizationSpec{
+				Authorizer:  v1beta1.NoopAuthorization,
+				ClaimMapper: v1beta1.NoopAuthorization,
+			},
+			expected: config.Authorization{},
+		},
+		"custom implementations": {
+			spec: &v1beta1.AuthorizationSpec{
+				Authorizer:  "opa",
+				ClaimMapper: "custom",
+			},
+			expected: config.Authorization{
+				Authorizer:  "opa",
+				ClaimMapper: "custom",
+			},
+		},
+		"without refresh interval": {
+			spec: &v1beta1.AuthorizationSpec{
+				JWTKeyProvider: v1beta1.AuthorizationSpecJWTKeyProvider{
+					KeySourceURIs: []string{"https://example.com/.well-known/jwks.json"},
+				},
+				Authorizer:  "default",
+				ClaimMapper: "default",
+			},
+			expected: config.Authorization{
+				JWTKeyProvider: config.JWTKeyProvider{
+					KeySourceURIs: []string{"https://example.com/.well-known/jwks.json"},
+				},
+				Authorizer:  "default",
+				ClaimMapper: "default",
+			},
+		},
+		"full spec": {
+			spec: &v1beta1.AuthorizationSpec{
+				JWTKeyProvider: v1beta1.AuthorizationSpecJWTKeyProvider{
+					KeySourceURIs:   []string{"https://example.com/.well-known/jwks.json"},
+					RefreshInterval: &metav1.Duration{Duration: time.Minute},
+				},
+				PermissionsClaimName: "permissions",
+				Authorizer:           "default",
+				ClaimMapper:          "default",
+				AuthHeaderName:       "x-authorization",
+				AuthExtraHeaderName:  "x-authorization-extras",
+			},
+			expected: config.Authorization{
+				JWTKeyProvider: config.JWTKeyProvider{
+					KeySourceURIs:   []string{"https://example.com/.well-known/jwks.json"},
+					RefreshInterval: time.Minute,
+				},
+				PermissionsClaimName: "permissions",
+				Authorizer:           "default",
+				ClaimMapper:          "default",
+				AuthHeaderName:       "x-authorization",
+				AuthExtraHeaderName:  "x-authorization-extras",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			assert.Equal(tt, test.expected, authorization.ToTemporalAuthorization(test.spec))
+		})
+	}
+}
@@ -23,11 +23,18 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metrics"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/spiffe"
 	temporallog "github.com/alexandrevilain/temporal-operator/pkg/temporal/log"
 	temporalclient "go.temporal.io/sdk/client"
+	"google.golang.org/grpc"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -71,10 +78,15 @@ func GetTlSConfigFromSecret(secret *corev1.Secret) (*tls.Config, error) {
 
 // GetClusterClientTLSConfig returns the tls configuration for the provided temporal cluster.
 func GetClusterClientTLSConfig(ctx context.Context, client client.Client, cluster *v1beta1.TemporalCluster) (*tls.Config, error) {
-	secret := &corev1.Secret{}
+	// When using user-provided secrets, the frontend certificate may not be usable as a client certificate.
+	certificate := certmanager.FrontendCertificate
+	if cluster.MTLSWithSecretsEnabled() {
+		certificate = certmanager.WorkerFrontendClientCertificate
+	}
 
+	secret := &corev1.Secret{}
 	err := client.Get(ctx, types.NamespacedName{
-		Name:      cluster.ChildResourceName(certmanager.FrontendCertificate),
+		Name:      mtls.CertificateSecretName(cluster, certificate),
 		Namespace: cluster.GetNamespace(),
 	}, secret)
 	if err != nil {
@@ -90,12 +102,65 @@ func GetClusterClientTLSConfig(ctx context.Context, client client.Client, cluste
 	return tlsConfig, nil
 }
 
+// GetClusterClientSPIFFETLSConfig returns the tls configuration for the provided temporal cluster
+// using the operator's SVID, written by spiffe-helper in the operator's pod.
+func GetClusterClientSPIFFETLSConfig(cluster *v1beta1.TemporalCluster) (*tls.Config, error) {
+	secret := &corev1.Secret{Data: map[string][]byte{}}
+	for _, key := range []string{certmanager.TLSCA, certmanager.TLSCert, certmanager.TLSKey} {
+		data, err := os.ReadFile(filepath.Join(spiffe.OperatorCertificatesPath, key))
+		if err != nil {
+			return nil, fmt.Errorf("can't read operator's SVID: %w", err)
+		}
+		secret.Data[key] = data
+	}
+
+	tlsConfig, err := GetTlSConfigFromSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig.ServerName = cluster.Spec.MTLS.Frontend.ServerName(cluster)
+
+	if !cluster.MTLSWithHostVerification() {
+		roots := tlsConfig.RootCAs
+		// Only skip the server name verification, the certificate chain is verified below.
+		tlsConfig.InsecureSkipVerify = true //nolint:gosec
+		tlsConfig.VerifyConnection = func(cs tls.ConnectionState) error {
+			if len(cs.PeerCertificates) == 0 {
+				return errors.New("no server certificate provided")
+			}
+
+			opts := x509.VerifyOptions{
+				Roots:         roots,
+				Intermediates: x509.NewCertPool(),
+			}
+			for _, cert := range cs.PeerCertificates[1:] {
+				opts.Intermediates.AddCert(cert)
+			}
+
+			_, err := cs.PeerCertificates[0].Verify(opts)
+			return err
+		}
+	}
+
+	return tlsConfig, nil
+}
+
 func buildClusterClientOptions(ctx context.Context, client client.Client, cluster *v1beta1.TemporalCluster, overrides ...ClientOption) (temporalclient.Options, error) {
 	opts := temporalclient.Options{
 		HostPort: cluster.GetPublicClientAddress(),
-		Logger:   temporallog.NewTemporalSDKLogFromContext(ctx),
+		// Clients are cached and shared between reconciliations: the request-scoped logger from ctx can't be used.
+		Logger: temporallog.NewTemporalSDKLog(log.Log.WithName("temporal-client").WithValues("namespace", cluster.Namespace, "name", cluster.Name)),
 	}
-	if cluster.MTLSWithCertManagerEnabled() && cluster.Spec.MTLS.FrontendEnabled() {
+	opts.ConnectionOptions.DialOptions = append(opts.ConnectionOptions.DialOptions,
+		// Rate limiting comes first, so the recorded latency doesn't include the time spent waiting.
+		grpc.WithChainUnaryInterceptor(
+			rateLimitInterceptor(types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}),
+			metrics.TemporalAPIInterceptor(cluster.Namespace, cluster.Name),
+		),
+	)
+
+	if cluster.MTLSWithCertificatesEnabled() && cluster.Spec.MTLS.FrontendEnabled() {
 		tlsConfig, err := GetClusterClientTLSConfig(ctx, client, cluster)
 		if err != nil {
 			return opts, fmt.Errorf("can't get cluster TLS config: %w", err)
@@ -103,6 +168,22 @@ func buildClusterClientOptions(ctx context.Context, client client.Client, cluste
 		opts.ConnectionOptions.TLS = tlsConfig
 	}
 
+	if cluster.MTLSWithSPIFFEEnabled() && cluster.Spec.MTLS.FrontendEnabled() {
+		tlsConfig, err := GetClusterClientSPIFFETLSConfig(cluster)
+		if err != nil {
+			return opts, fmt.Errorf("can't get cluster TLS config: %w", err)
+		}
+		opts.ConnectionOptions.TLS = tlsConfig
+	}
+
+	if cluster.Spec.Authorization != nil && cluster.Spec.Authorization.OperatorCredentials != nil {
+		headers, err := GetClusterClientHeaders(ctx, client, cluster)
+		if err != nil {
+			return opts, fmt.Errorf("can't get cluster credentials: %w", err)
+		}
+		opts.HeadersProvider = staticHeadersProvider(headers)
+	}
+
 	for _, override := range overrides {
 		override(&opts)
 	}
@@ -110,6 +191,44 @@ func buildClusterClientOptions(ctx context.Context, client client.Client, cluste
 	return opts, nil
 }
 
+// staticHeadersProvider provides the same headers to all requests.
+type staticHeadersProvider map[string]string
+
+func (p staticHeadersProvider) GetHeaders(context.Context) (map[string]string, error) {
+	return p, nil
+}
+
+// GetClusterClientHeaders returns the headers holding the operator's bearer token, read from the referenced secret,
+// attached to the requests sent to the provided temporal cluster.
+func GetClusterClientHeaders(ctx context.Context, client client.Client, cluster *v1beta1.TemporalCluster) (map[string]string, error) {
+	ref := cluster.Spec.Authorization.OperatorCredentials.TokenSecretRef
+	if ref == nil {
+		return nil, errors.New("no token secret reference provided")
+	}
+
+	secret := &corev1.Secret{}
+	err := client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: cluster.GetNamespace()}, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	token := strings.TrimSpace(string(secret.Data[ref.Key]))
+	if token == "" {
+		return nil, fmt.Errorf("secret %s doesn't contain a token in the %s key", ref.Name, ref.Key)
+	}
+
+	headerName := cluster.Spec.Authorization.AuthHeaderName
+	if headerName == "" {
+		headerName = "authorization"
+	}
+
+	if !strings.HasPrefix(strings.ToLower(token), "bearer ") {
+		token = "Bearer " + token
+	}
+
+	return map[string]string{headerName: token}, nil
+}
+
 // ClientOption is an override option for temporal sdk client.
 type ClientOption func(opts *temporalclient.Options)
 
@@ -128,6 +247,7 @@ func WithHostPort(hostPort string) ClientOption {
 }
 
 // GetClusterClient returns a temporal sdk client for the provider temporal cluster.
+// The connection to the cluster's frontend is cached, closing the returned client releases it.
 func GetClusterClient(ctx context.Context, client client.Client, cluster *v1beta1.TemporalCluster, overrides ...ClientOption) (temporalclient.Client, error) {
 	opts, err := buildClusterClientOptions(ctx, client, cluster, overrides...)
 	if err != nil {
@@ -136,14 +256,24 @@ func GetClusterClient(ctx context.Context, client client.Client, cluster *v1beta
 
 	log.FromContext(ctx).V(1).Info("Connecting to temporal cluster", "address", opts.HostPort)
 
-	c, err := temporalclient.Dial(opts)
+	// Connections to other addresses (e.g. a canary frontend pod) are not cached.
+	if opts.HostPort != cluster.GetPublicClientAddress() {
+		c, err := temporalclient.Dial(opts)
+		if err != nil {
+			return nil, fmt.Errorf("can't create temporal client: %w", err)
+		}
+		return c, nil
+	}
+
+	c, release, err := clients.get(types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}, opts)
 	if err != nil {
 		return nil, fmt.Errorf("can't create temporal client: %w", err)
 	}
-	return c, nil
+	return sharedClient{Client: c, release: release}, nil
 }
 
 // GetClusterNamespaceClient returns a temporal sdk namespace client for the provider temporal cluster.
+// It shares the cached connection of GetClusterClient.
 func GetClusterNamespaceClient(ctx context.Context, client client.Client, cluster *v1beta1.TemporalCluster, overrides ...ClientOption) (temporalclient.NamespaceClient, error) {
 	opts, err := buildClusterClientOptions(ctx, client, cluster, overrides...)
 	if err != nil {
@@ -152,5 +282,13 @@ func GetClusterNamespaceClient(ctx context.Context, client client.Client, cluste
 
 	log.FromContext(ctx).V(1).Info("Connecting to temporal cluster", "address", opts.HostPort)
 
-	return temporalclient.NewNamespaceClient(opts)
+	if opts.HostPort != cluster.GetPublicClientAddress() {
+		return temporalclient.NewNamespaceClient(opts)
+	}
+
+	c, release, err := clients.get(types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("can't create temporal client: %w", err)
+	}
+	return sharedNamespaceClient{client: c, release: release}, nil
 }
@@ -0,0 +1,223 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.temporal.io/api/workflowservice/v1"
+	temporalclient "go.temporal.io/sdk/client"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// clients holds the connections to the temporal clusters, shared by all the reconciliations.
+var clients = &clientCache{
+	entries:   map[types.NamespacedName]*clientCacheEntry{},
+	newClient: temporalclient.NewLazyClient,
+}
+
+type clientCacheEntry struct {
+	hash    string
+	rootCAs *x509.CertPool
+	client  temporalclient.Client
+	// refs is the number of clients returned by get which are not released yet.
+	refs int
+	// retired is true once the entry is replaced or forgotten: its client is closed when the last reference is released.
+	retired bool
+}
+
+// clientCache caches a temporal client per cluster.
+// A client is replaced when its address, TLS material or credentials change. Replaced clients are closed
+// once the reconciliations using them have released them, so in-flight requests are not cancelled.
+type clientCache struct {
+	mu        sync.Mutex
+	entries   map[types.NamespacedName]*clientCacheEntry
+	newClient func(opts temporalclient.Options) (temporalclient.Client, error)
+}
+
+// get returns the cached client for the provided cluster and options, creating it if needed.
+// The returned release function has to be called once the client isn't used anymore.
+func (c *clientCache) get(cluster types.NamespacedName, opts temporalclient.Options) (temporalclient.Client, func(), error) {
+	hash, err := hashClientOptions(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// x509.CertPool doesn't expose its certificates to hash them: pools are compared with Equal,
+	// which compares the raw certificates, so a renewed CA with the same subject replaces the client.
+	var rootCAs *x509.CertPool
+	if opts.ConnectionOptions.TLS != nil {
+		rootCAs = opts.ConnectionOptions.TLS.RootCAs
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[cluster]
+	if !ok || entry.hash != hash || !entry.rootCAs.Equal(rootCAs) {
+		if ok {
+			c.retire(entry)
+			delete(c.entries, cluster)
+		}
+
+		// Lazy clients connect on their first request: nothing is dialed while holding the lock.
+		client, err := c.newClient(opts)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		entry = &clientCacheEntry{hash: hash, rootCAs: rootCAs, client: client}
+		c.entries[cluster] = entry
+	}
+
+	entry.refs++
+	return entry.client, sync.OnceFunc(func() { c.release(entry) }), nil
+}
+
+// release releases a reference to the client of the provided entry, closing it if it's retired and not used anymore.
+func (c *clientCache) release(entry *clientCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry.refs--
+	if entry.retired && entry.refs == 0 {
+		entry.client.Close()
+	}
+}
+
+// retire marks the provided entry as retired, closing its client if it's not used anymore.
+// It must be called while holding the lock.
+func (c *clientCache) retire(entry *clientCacheEntry) {
+	entry.retired = true
+	if entry.refs == 0 {
+		entry.client.Close()
+	}
+}
+
+// forget removes the cached client of the provided cluster, it's closed once it isn't used anymore.
+func (c *clientCache) forget(cluster types.NamespacedName) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[cluster]; ok {
+		c.retire(entry)
+		delete(c.entries, cluster)
+	}
+}
+
+// hashClientOptions returns a hash of the address, client certificates and headers of the provided options.
+// Root CAs are compared separately, see clientCache.get.
+func hashClientOptions(opts temporalclient.Options) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "address:%s;", opts.HostPort)
+
+	if tlsConfig := opts.ConnectionOptions.TLS; tlsConfig != nil {
+		fmt.Fprintf(h, "tls:%s:%t;", tlsConfig.ServerName, tlsConfig.InsecureSkipVerify)
+		// Renewed certificates have new serial numbers: the whole chain is hashed.
+		for _, certificate := range tlsConfig.Certificates {
+			for _, der := range certificate.Certificate {
+				_, _ = h.Write(der)
+			}
+		}
+	}
+
+	if opts.HeadersProvider != nil {
+		headers, err := opts.HeadersProvider.GetHeaders(context.Background())
+		if err != nil {
+			return "", fmt.Errorf("can't get client headers: %w", err)
+		}
+		names := make([]string, 0, len(headers))
+		for name := range headers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(h, "header:%s=%s;", name, headers[name])
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ForgetClusterClient closes the cached connection to the provided cluster, e.g. once it's deleted.
+func ForgetClusterClient(cluster types.NamespacedName) {
+	clients.forget(cluster)
+	limiters.forget(cluster)
+}
+
+// sharedClient is a temporal client using a cached connection: closing it keeps the connection open.
+type sharedClient struct {
+	temporalclient.Client
+	release func()
+}
+
+// Close releases the connection, which is shared with the other reconciliations.
+func (c sharedClient) Close() {
+	c.release()
+}
+
+// namespaceRequestTimeout is the timeout of the namespace client requests.
+const namespaceRequestTimeout = 10 * time.Second
+
+// sharedNamespaceClient is a temporal namespace client using a cached connection.
+type sharedNamespaceClient struct {
+	client  temporalclient.Client
+	release func()
+}
+
+var _ temporalclient.NamespaceClient = sharedNamespaceClient{}
+
+// Register registers a namespace.
+func (c sharedNamespaceClient) Register(ctx context.Context, request *workflowservice.RegisterNamespaceRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, namespaceRequestTimeout)
+	defer cancel()
+
+	_, err := c.client.WorkflowService().RegisterNamespace(ctx, request)
+	return err
+}
+
+// Describe describes a namespace.
+func (c sharedNamespaceClient) Describe(ctx context.Context, name string) (*workflowservice.DescribeNamespaceResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, namespaceRequestTimeout)
+	defer cancel()
+
+	return c.client.WorkflowService().DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{
+		Namespace: name,
+	})
+}
+
+// Update updates a namespace.
+func (c sharedNamespaceClient) Update(ctx context.Context, request *workflowservice.UpdateNamespaceRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, namespaceRequestTimeout)
+	defer cancel()
+
+	_, err := c.client.WorkflowService().UpdateNamespace(ctx, request)
+	return err
+}
+
+// Close releases the connection, which is shared with the other reconciliations.
+func (c sharedNamespaceClient) Close() {
+	c.release()
+}
@@ -0,0 +1,152 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	temporalclient "go.temporal.io/sdk/client"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// fakeCachedClient counts the number of times it's closed.
+type fakeCachedClient struct {
+	temporalclient.Client
+	closed int
+}
+
+func (c *fakeCachedClient) Close() {
+	c.closed++
+}
+
+func newTestClientCache() *clientCache {
+	return &clientCache{
+		entries: map[types.NamespacedName]*clientCacheEntry{},
+		newClient: func(temporalclient.Options) (temporalclient.Client, error) {
+			return &fakeCachedClient{}, nil
+		},
+	}
+}
+
+// newTestCAPool returns a pool holding a new self-signed CA named temporal-ca.
+func newTestCAPool(t *testing.T) *x509.CertPool {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "temporal-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(certificate)
+	return pool
+}
+
+func TestClientCache(t *testing.T) {
+	cache := newTestClientCache()
+	cluster := types.NamespacedName{Namespace: "default", Name: "prod"}
+	opts := temporalclient.Options{
+		HostPort:        "prod-frontend.default:7233",
+		HeadersProvider: staticHeadersProvider{"authorization": "Bearer first"},
+	}
+
+	first, releaseFirst, err := cache.get(cluster, opts)
+	assert.NoError(t, err)
+
+	second, releaseSecond, err := cache.get(cluster, opts)
+	assert.NoError(t, err)
+	assert.Same(t, first, second)
+
+	// Rotated credentials replace the cached client.
+	opts.HeadersProvider = staticHeadersProvider{"authorization": "Bearer second"}
+	third, releaseThird, err := cache.get(cluster, opts)
+	assert.NoError(t, err)
+	assert.NotSame(t, first, third)
+	assert.Len(t, cache.entries, 1)
+
+	// The replaced client is closed once all the reconciliations using it released it.
+	releaseFirst()
+	releaseFirst()
+	assert.Zero(t, first.(*fakeCachedClient).closed)
+	releaseSecond()
+	assert.Equal(t, 1, first.(*fakeCachedClient).closed)
+
+	// A client which isn't used anymore is closed when it's forgotten.
+	releaseThird()
+	assert.Zero(t, third.(*fakeCachedClient).closed)
+	cache.forget(cluster)
+	assert.Empty(t, cache.entries)
+	assert.Equal(t, 1, third.(*fakeCachedClient).closed)
+}
+
+func TestClientCacheRootCAs(t *testing.T) {
+	cache := newTestClientCache()
+	cluster := types.NamespacedName{Namespace: "default", Name: "prod"}
+
+	opts := func(rootCAs *x509.CertPool) temporalclient.Options {
+		return temporalclient.Options{
+			HostPort: "prod-frontend.default:7233",
+			ConnectionOptions: temporalclient.ConnectionOptions{
+				TLS: &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12},
+			},
+		}
+	}
+
+	pool := newTestCAPool(t)
+
+	first, release, err := cache.get(cluster, opts(pool))
+	require.NoError(t, err)
+	release()
+
+	// Pools with the same certificates share the client.
+	samePool := pool.Clone()
+	second, release, err := cache.get(cluster, opts(samePool))
+	require.NoError(t, err)
+	release()
+	assert.Same(t, first, second)
+
+	// A renewed CA with the same subject replaces the client.
+	renewed := newTestCAPool(t)
+	assert.Equal(t, pool.Subjects(), renewed.Subjects()) //nolint:staticcheck
+	third, release, err := cache.get(cluster, opts(renewed))
+	require.NoError(t, err)
+	release()
+	assert.NotSame(t, first, third)
+	assert.Equal(t, 1, first.(*fakeCachedClient).closed)
+}
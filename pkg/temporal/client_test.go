@@ -0,0 +1,102 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetClusterClientHeaders(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "operator-token", Namespace: "demo"},
+		Data: map[string][]byte{
+			"token":  []byte("my-token\n"),
+			"bearer": []byte("Bearer my-token"),
+		},
+	}
+
+	tests := map[string]struct {
+		authorization *v1beta1.AuthorizationSpec
+		expected      map[string]string
+		expectedErr   string
+	}{
+		"adds bearer prefix": {
+			authorization: &v1beta1.AuthorizationSpec{
+				OperatorCredentials: &v1beta1.OperatorCredentialsSpec{
+					TokenSecretRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "operator-token"},
+						Key:                  "token",
+					},
+				},
+			},
+			expected: map[string]string{"authorization": "Bearer my-token"},
+		},
+		"keeps existing bearer prefix and uses custom header": {
+			authorization: &v1beta1.AuthorizationSpec{
+				AuthHeaderName: "x-auth",
+				OperatorCredentials: &v1beta1.OperatorCredentialsSpec{
+					TokenSecretRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "operator-token"},
+						Key:                  "bearer",
+					},
+				},
+			},
+			expected: map[string]string{"x-auth": "Bearer my-token"},
+		},
+		"missing key": {
+			authorization: &v1beta1.AuthorizationSpec{
+				OperatorCredentials: &v1beta1.OperatorCredentialsSpec{
+					TokenSecretRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "operator-token"},
+						Key:                  "missing",
+					},
+				},
+			},
+			expectedErr: "secret operator-token doesn't contain a token in the missing key",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			c := fake.NewClientBuilder().WithObjects(secret).Build()
+			cluster := &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+				Spec: v1beta1.TemporalClusterSpec{
+					Authorization: test.authorization,
+				},
+			}
+
+			headers, err := temporal.GetClusterClientHeaders(context.Background(), c, cluster)
+			if test.expectedErr != "" {
+				assert.ErrorContains(tt, err, test.expectedErr)
+				return
+			}
+
+			assert.NoError(tt, err)
+			assert.Equal(tt, test.expected, headers)
+		})
+	}
+}
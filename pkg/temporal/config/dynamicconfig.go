@@ -21,6 +21,8 @@ import (
 	"encoding/json"
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"go.temporal.io/server/common/dynamicconfig"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type YamlDynamicConfig map[string][]YamlConstrainedValue
@@ -92,3 +94,117 @@ func constrainedValueToYamlConstrainedValue(cv *v1beta1.ConstrainedValue) (YamlC
 		Value:       value,
 	}, nil
 }
+
+// ApplyVisibilityMigration sets the dual visibility dynamic config keys from the provided VisibilityMigrationSpec.
+func ApplyVisibilityMigration(cfg YamlDynamicConfig, spec *v1beta1.VisibilityMigrationSpec) {
+	if spec == nil {
+		return
+	}
+
+	cfg[dynamicconfig.SecondaryVisibilityWritingMode] = []YamlConstrainedValue{
+		{
+			Constraints: map[string]any{},
+			Value:       string(spec.WritingMode),
+		},
+	}
+	cfg[dynamicconfig.EnableReadFromSecondaryVisibility] = []YamlConstrainedValue{
+		{
+			Constraints: map[string]any{},
+			Value:       spec.ReadFromSecondary,
+		},
+	}
+}
+
+// FrontendLimitsValues returns the dynamic config values of the provided FrontendLimitsSpec, keyed by dynamic config key.
+func FrontendLimitsValues(spec *v1beta1.FrontendLimitsSpec) map[string]any {
+	values := map[string]any{}
+	if spec == nil {
+		return values
+	}
+
+	ints := map[string]*int32{
+		dynamicconfig.FrontendRPS:                                         spec.RPS,
+		dynamicconfig.FrontendMaxNamespaceRPSPerInstance:                  spec.NamespaceRPS,
+		dynamicconfig.FrontendGlobalNamespaceRPS:                          spec.GlobalNamespaceRPS,
+		dynamicconfig.FrontendMaxConcurrentLongRunningRequestsPerInstance: spec.MaxConcurrentLongPolls,
+	}
+	for key, value := range ints {
+		if value != nil {
+			values[key] = int(*value)
+		}
+	}
+
+	if keepAlive := spec.KeepAlive; keepAlive != nil {
+		durations := map[string]*metav1.Duration{
+			dynamicconfig.KeepAliveMinTime:               keepAlive.MinTime,
+			dynamicconfig.KeepAliveMaxConnectionIdle:     keepAlive.MaxConnectionIdle,
+			dynamicconfig.KeepAliveMaxConnectionAge:      keepAlive.MaxConnectionAge,
+			dynamicconfig.KeepAliveMaxConnectionAgeGrace: keepAlive.MaxConnectionAgeGrace,
+			dynamicconfig.KeepAliveTime:                  keepAlive.Time,
+			dynamicconfig.KeepAliveTimeout:               keepAlive.Timeout,
+		}
+		for key, value := range durations {
+			if value != nil {
+				values[key] = value.Duration.String()
+			}
+		}
+
+		if keepAlive.PermitWithoutStream != nil {
+			values[dynamicconfig.KeepAlivePermitWithoutStream] = *keepAlive.PermitWithoutStream
+		}
+	}
+
+	return values
+}
+
+// ApplyFrontendLimits sets the frontend rate limits and keepalive dynamic config keys from the provided FrontendLimitsSpec.
+func ApplyFrontendLimits(cfg YamlDynamicConfig, spec *v1beta1.FrontendLimitsSpec) {
+	for key, value := range FrontendLimitsValues(spec) {
+		cfg[key] = []YamlConstrainedValue{
+			{
+				Constraints: map[string]any{},
+				Value:       value,
+			},
+		}
+	}
+}
+
+// PersistenceLimitsValues returns the dynamic config values of the provided PersistenceLimitsSpec, keyed by dynamic config key.
+func PersistenceLimitsValues(spec *v1beta1.PersistenceLimitsSpec) map[string]any {
+	values := map[string]any{}
+	if spec == nil {
+		return values
+	}
+
+	ints := map[string]*int32{}
+	if defaultStore := spec.DefaultStore; defaultStore != nil {
+		ints[dynamicconfig.FrontendPersistenceMaxQPS] = defaultStore.FrontendMaxQPS
+		ints[dynamicconfig.HistoryPersistenceMaxQPS] = defaultStore.HistoryMaxQPS
+		ints[dynamicconfig.MatchingPersistenceMaxQPS] = defaultStore.MatchingMaxQPS
+		ints[dynamicconfig.WorkerPersistenceMaxQPS] = defaultStore.WorkerMaxQPS
+	}
+	if visibilityStore := spec.VisibilityStore; visibilityStore != nil {
+		ints[dynamicconfig.VisibilityPersistenceMaxReadQPS] = visibilityStore.MaxReadQPS
+		ints[dynamicconfig.VisibilityPersistenceMaxWriteQPS] = visibilityStore.MaxWriteQPS
+	}
+
+	for key, value := range ints {
+		if value != nil {
+			values[key] = int(*value)
+		}
+	}
+
+	return values
+}
+
+// ApplyPersistenceLimits sets the persistence rate limits dynamic config keys from the provided PersistenceLimitsSpec.
+func ApplyPersistenceLimits(cfg YamlDynamicConfig, spec *v1beta1.PersistenceLimitsSpec) {
+	for key, value := range PersistenceLimitsValues(spec) {
+		cfg[key] = []YamlConstrainedValue{
+			{
+				Constraints: map[string]any{},
+				Value:       value,
+			},
+		}
+	}
+}
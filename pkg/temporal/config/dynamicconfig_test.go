@@ -19,12 +19,15 @@ package config_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"github.com/alexandrevilain/temporal-operator/pkg/temporal/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 func TestDynamicConfigToYamlDynamicConfig(t *testing.T) {
@@ -161,3 +164,134 @@ func TestDynamicConfigToYamlDynamicConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyVisibilityMigration(t *testing.T) {
+	tests := map[string]struct {
+		spec     *v1beta1.VisibilityMigrationSpec
+		expected config.YamlDynamicConfig
+	}{
+		"nil spec": {
+			spec:     nil,
+			expected: config.YamlDynamicConfig{},
+		},
+		"dual write": {
+			spec: &v1beta1.VisibilityMigrationSpec{
+				WritingMode: v1beta1.SecondaryVisibilityWritingModeDual,
+			},
+			expected: config.YamlDynamicConfig{
+				"system.secondaryVisibilityWritingMode": {
+					{
+						Constraints: map[string]any{},
+						Value:       "dual",
+					},
+				},
+				"system.enableReadFromSecondaryVisibility": {
+					{
+						Constraints: map[string]any{},
+						Value:       false,
+					},
+				},
+			},
+		},
+		"dual write and read from secondary": {
+			spec: &v1beta1.VisibilityMigrationSpec{
+				WritingMode:       v1beta1.SecondaryVisibilityWritingModeDual,
+				ReadFromSecondary: true,
+			},
+			expected: config.YamlDynamicConfig{
+				"system.secondaryVisibilityWritingMode": {
+					{
+						Constraints: map[string]any{},
+						Value:       "dual",
+					},
+				},
+				"system.enableReadFromSecondaryVisibility": {
+					{
+						Constraints: map[string]any{},
+						Value:       true,
+					},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			result := config.YamlDynamicConfig{}
+			config.ApplyVisibilityMigration(result, test.spec)
+			assert.Equal(tt, test.expected, result)
+		})
+	}
+}
+
+func TestApplyFrontendLimits(t *testing.T) {
+	spec := &v1beta1.FrontendLimitsSpec{
+		RPS:                    ptr.To[int32](2400),
+		MaxConcurrentLongPolls: ptr.To[int32](1200),
+		KeepAlive: &v1beta1.FrontendKeepAliveSpec{
+			MaxConnectionAge:    &metav1.Duration{Duration: 5 * time.Minute},
+			PermitWithoutStream: ptr.To(true),
+		},
+	}
+
+	result := config.YamlDynamicConfig{}
+	config.ApplyFrontendLimits(result, spec)
+
+	expected := config.YamlDynamicConfig{
+		"frontend.rps": {
+			{
+				Constraints: map[string]any{},
+				Value:       2400,
+			},
+		},
+		"frontend.namespaceCount": {
+			{
+				Constraints: map[string]any{},
+				Value:       1200,
+			},
+		},
+		"frontend.keepAliveMaxConnectionAge": {
+			{
+				Constraints: map[string]any{},
+				Value:       "5m0s",
+			},
+		},
+		"frontend.keepAlivePermitWithoutStream": {
+			{
+				Constraints: map[string]any{},
+				Value:       true,
+			},
+		},
+	}
+	assert.Equal(t, expected, result)
+}
+
+func TestApplyPersistenceLimits(t *testing.T) {
+	spec := &v1beta1.PersistenceLimitsSpec{
+		DefaultStore: &v1beta1.DefaultStoreLimitsSpec{
+			HistoryMaxQPS: ptr.To[int32](9000),
+		},
+		VisibilityStore: &v1beta1.VisibilityStoreLimitsSpec{
+			MaxReadQPS: ptr.To[int32](5000),
+		},
+	}
+
+	result := config.YamlDynamicConfig{}
+	config.ApplyPersistenceLimits(result, spec)
+
+	expected := config.YamlDynamicConfig{
+		"history.persistenceMaxQPS": {
+			{
+				Constraints: map[string]any{},
+				Value:       9000,
+			},
+		},
+		"system.visibilityPersistenceMaxReadQPS": {
+			{
+				Constraints: map[string]any{},
+				Value:       5000,
+			},
+		},
+	}
+	assert.Equal(t, expected, result)
+}
@@ -0,0 +1,63 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ApplyOverlay merges the provided JSON overlay into the YAML temporal server configuration.
+// Maps are merged recursively, other values of the overlay replace the configuration ones.
+// The result is deterministic: keys are sorted.
+func ApplyOverlay(cfg []byte, overlay []byte) ([]byte, error) {
+	base := map[string]any{}
+	err := yaml.Unmarshal(cfg, &base)
+	if err != nil {
+		return nil, fmt.Errorf("can't unmarshal configuration: %w", err)
+	}
+
+	patch := map[string]any{}
+	err = json.Unmarshal(overlay, &patch)
+	if err != nil {
+		return nil, fmt.Errorf("can't unmarshal configuration overlay: %w", err)
+	}
+
+	return yaml.Marshal(mergeMaps(base, patch))
+}
+
+func mergeMaps(base, overlay map[string]any) map[string]any {
+	for key, value := range overlay {
+		overlayMap, ok := value.(map[string]any)
+		if !ok {
+			base[key] = value
+			continue
+		}
+
+		baseMap, ok := base[key].(map[string]any)
+		if !ok {
+			base[key] = overlayMap
+			continue
+		}
+
+		base[key] = mergeMaps(baseMap, overlayMap)
+	}
+	return base
+}
@@ -0,0 +1,83 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyOverlay(t *testing.T) {
+	cfg := []byte(`
+services:
+  history:
+    rpc:
+      grpcPort: 7234
+      membershipPort: 6934
+global:
+  membership:
+    broadcastAddress: "{{ default .Env.POD_IP \"0.0.0.0\" }}"
+  authorization:
+    permissionsClaimName: permissions
+`)
+
+	tests := map[string]struct {
+		overlay  string
+		expected string
+	}{
+		"merges maps": {
+			overlay: `{"services":{"history":{"rpc":{"grpcPort":8234}}},"global":{"membership":{"maxJoinDuration":"30s"}}}`,
+			expected: `global:
+    authorization:
+        permissionsClaimName: permissions
+    membership:
+        broadcastAddress: '{{ default .Env.POD_IP "0.0.0.0" }}'
+        maxJoinDuration: 30s
+services:
+    history:
+        rpc:
+            grpcPort: 8234
+            membershipPort: 6934
+`,
+		},
+		"replaces values": {
+			overlay: `{"global":{"authorization":"disabled"}}`,
+			expected: `global:
+    authorization: disabled
+    membership:
+        broadcastAddress: '{{ default .Env.POD_IP "0.0.0.0" }}'
+services:
+    history:
+        rpc:
+            grpcPort: 7234
+            membershipPort: 6934
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			result, err := config.ApplyOverlay(cfg, []byte(test.overlay))
+			require.NoError(tt, err)
+			assert.Equal(tt, test.expected, string(result))
+		})
+	}
+}
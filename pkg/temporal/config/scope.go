@@ -0,0 +1,64 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// servicesSection is the section of the temporal server configuration containing each service's configuration.
+	servicesSection = "services"
+	// rpcSection is the section of a service configuration containing its ports.
+	rpcSection = "rpc"
+)
+
+// ScopeToService returns the YAML temporal server configuration restricted to the sections read by the provided service:
+// only the rpc section of the other services is kept, as every service reads the ports of all services to reach
+// their members. It allows detecting changes affecting a single service.
+// The result is deterministic: keys are sorted.
+func ScopeToService(cfg []byte, service string) ([]byte, error) {
+	base := map[string]any{}
+	err := yaml.Unmarshal(cfg, &base)
+	if err != nil {
+		return nil, fmt.Errorf("can't unmarshal configuration: %w", err)
+	}
+
+	if services, ok := base[servicesSection].(map[string]any); ok {
+		scoped := map[string]any{}
+		for name, serviceConfig := range services {
+			if name == service {
+				scoped[name] = serviceConfig
+				continue
+			}
+
+			serviceMap, ok := serviceConfig.(map[string]any)
+			if !ok {
+				continue
+			}
+			if rpc, ok := serviceMap[rpcSection]; ok {
+				scoped[name] = map[string]any{rpcSection: rpc}
+			}
+		}
+		base[servicesSection] = scoped
+	}
+
+	return yaml.Marshal(base)
+}
@@ -0,0 +1,137 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScopeToService(t *testing.T) {
+	cfg := []byte(`
+services:
+  history:
+    rpc:
+      grpcPort: 7234
+    tls:
+      enabled: true
+  matching:
+    rpc:
+      grpcPort: 7235
+    tls:
+      enabled: true
+global:
+  membership:
+    maxJoinDuration: 30s
+`)
+
+	tests := map[string]struct {
+		service  string
+		expected string
+	}{
+		"keeps own service configuration and other services rpc": {
+			service: "history",
+			expected: `global:
+    membership:
+        maxJoinDuration: 30s
+services:
+    history:
+        rpc:
+            grpcPort: 7234
+        tls:
+            enabled: true
+    matching:
+        rpc:
+            grpcPort: 7235
+`,
+		},
+		"keeps only services rpc for unknown service": {
+			service: "internal-frontend",
+			expected: `global:
+    membership:
+        maxJoinDuration: 30s
+services:
+    history:
+        rpc:
+            grpcPort: 7234
+    matching:
+        rpc:
+            grpcPort: 7235
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			result, err := config.ScopeToService(cfg, test.service)
+			require.NoError(tt, err)
+			assert.Equal(tt, test.expected, string(result))
+		})
+	}
+
+	t.Run("other services non-rpc changes don't affect the scoped configuration", func(tt *testing.T) {
+		updated := []byte(`
+services:
+  history:
+    rpc:
+      grpcPort: 7234
+    tls:
+      enabled: true
+  matching:
+    rpc:
+      grpcPort: 7235
+    tls:
+      enabled: false
+global:
+  membership:
+    maxJoinDuration: 30s
+`)
+		before, err := config.ScopeToService(cfg, "history")
+		require.NoError(tt, err)
+		after, err := config.ScopeToService(updated, "history")
+		require.NoError(tt, err)
+		assert.Equal(tt, before, after)
+	})
+
+	t.Run("other services ports changes affect the scoped configuration", func(tt *testing.T) {
+		updated := []byte(`
+services:
+  history:
+    rpc:
+      grpcPort: 7234
+    tls:
+      enabled: true
+  matching:
+    rpc:
+      grpcPort: 8235
+    tls:
+      enabled: true
+global:
+  membership:
+    maxJoinDuration: 30s
+`)
+		before, err := config.ScopeToService(cfg, "history")
+		require.NoError(tt, err)
+		after, err := config.ScopeToService(updated, "history")
+		require.NoError(tt, err)
+		assert.NotEqual(tt, before, after)
+	})
+}
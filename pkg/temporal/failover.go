@@ -0,0 +1,66 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal
+
+import (
+	"fmt"
+
+	"go.temporal.io/api/replication/v1"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+// NamespaceFailoverRequest returns the request making the provided cluster active for the namespace.
+func NamespaceFailoverRequest(namespace, activeCluster string) *workflowservice.UpdateNamespaceRequest {
+	return &workflowservice.UpdateNamespaceRequest{
+		Namespace: namespace,
+		ReplicationConfig: &replication.NamespaceReplicationConfig{
+			ActiveClusterName: activeCluster,
+		},
+	}
+}
+
+// CheckNamespaceMetadataReplicated returns an error if the namespace, as described by both the source and the target clusters,
+// isn't a global namespace whose metadata is replicated to the target cluster.
+// It's a metadata consistency check only: the replication lag of the namespace workflows isn't exposed by the frontend API,
+// the force replication workflow must be used to ensure they are replicated.
+func CheckNamespaceMetadataReplicated(targetCluster string, source, target *workflowservice.DescribeNamespaceResponse) error {
+	name := source.GetNamespaceInfo().GetName()
+
+	if !source.GetIsGlobalNamespace() {
+		return fmt.Errorf("namespace %s is not a global namespace", name)
+	}
+
+	replicated := false
+	for _, cluster := range source.GetReplicationConfig().GetClusters() {
+		if cluster.GetClusterName() == targetCluster {
+			replicated = true
+			break
+		}
+	}
+	if !replicated {
+		return fmt.Errorf("namespace %s is not replicated to cluster %s", name, targetCluster)
+	}
+
+	if source.GetFailoverVersion() != target.GetFailoverVersion() ||
+		source.GetReplicationConfig().GetActiveClusterName() != target.GetReplicationConfig().GetActiveClusterName() {
+		return fmt.Errorf("namespace %s metadata isn't replicated to cluster %s yet: failover version %d, expected %d",
+			name, targetCluster, target.GetFailoverVersion(), source.GetFailoverVersion())
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	"github.com/stretchr/testify/assert"
+	namespacev1 "go.temporal.io/api/namespace/v1"
+	"go.temporal.io/api/replication/v1"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+func TestCheckNamespaceMetadataReplicated(t *testing.T) {
+	describe := func(mutate func(*workflowservice.DescribeNamespaceResponse)) *workflowservice.DescribeNamespaceResponse {
+		response := &workflowservice.DescribeNamespaceResponse{
+			NamespaceInfo:     &namespacev1.NamespaceInfo{Name: "payments"},
+			IsGlobalNamespace: true,
+			FailoverVersion:   11,
+			ReplicationConfig: &replication.NamespaceReplicationConfig{
+				ActiveClusterName: "prod-east",
+				Clusters: []*replication.ClusterReplicationConfig{
+					{ClusterName: "prod-east"},
+					{ClusterName: "prod-west"},
+				},
+			},
+		}
+		if mutate != nil {
+			mutate(response)
+		}
+		return response
+	}
+
+	tests := map[string]struct {
+		source        *workflowservice.DescribeNamespaceResponse
+		target        *workflowservice.DescribeNamespaceResponse
+		expectedError string
+	}{
+		"replicated namespace": {
+			source: describe(nil),
+			target: describe(nil),
+		},
+		"local namespace": {
+			source: describe(func(r *workflowservice.DescribeNamespaceResponse) {
+				r.IsGlobalNamespace = false
+			}),
+			target:        describe(nil),
+			expectedError: "namespace payments is not a global namespace",
+		},
+		"namespace not replicated to the target cluster": {
+			source: describe(func(r *workflowservice.DescribeNamespaceResponse) {
+				r.ReplicationConfig.Clusters = r.ReplicationConfig.Clusters[:1]
+			}),
+			target:        describe(nil),
+			expectedError: "namespace payments is not replicated to cluster prod-west",
+		},
+		"metadata not replicated": {
+			source: describe(nil),
+			target: describe(func(r *workflowservice.DescribeNamespaceResponse) {
+				r.FailoverVersion = 1
+			}),
+			expectedError: "namespace payments metadata isn't replicated to cluster prod-west yet: failover version 1, expected 11",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			err := temporal.CheckNamespaceMetadataReplicated("prod-west", test.source, test.target)
+			if test.expectedError == "" {
+				assert.NoError(tt, err)
+			} else {
+				assert.EqualError(tt, err, test.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/taskqueue/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	temporalclient "go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/server/common/primitives"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ForceReplicationWorkflowType is the type of temporal's system workflow replicating the existing workflows of a namespace
+// to a remote cluster. It runs in the system namespace of the cluster the namespace is active in.
+const ForceReplicationWorkflowType = "force-replication"
+
+// forceReplicationParams holds the parameters of the force replication workflow used by the operator.
+type forceReplicationParams struct {
+	Namespace          string
+	Query              string
+	EnableVerification bool
+	TargetClusterName  string
+}
+
+// ForceReplicationRequest returns the request starting the force replication workflow with the provided id.
+// The workflow replicates all the workflows of the namespace, open and closed, to the target cluster,
+// and verifies they exist in the target cluster before completing.
+func ForceReplicationRequest(id, namespace, targetCluster string) (*workflowservice.StartWorkflowExecutionRequest, error) {
+	input, err := converter.GetDefaultDataConverter().ToPayloads(forceReplicationParams{
+		Namespace:          namespace,
+		EnableVerification: true,
+		TargetClusterName:  targetCluster,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't encode force replication parameters: %w", err)
+	}
+
+	return &workflowservice.StartWorkflowExecutionRequest{
+		Namespace:             primitives.SystemLocalNamespace,
+		WorkflowId:            id,
+		WorkflowType:          &common.WorkflowType{Name: ForceReplicationWorkflowType},
+		TaskQueue:             &taskqueue.TaskQueue{Name: primitives.DefaultWorkerTaskQueue, Kind: enums.TASK_QUEUE_KIND_NORMAL},
+		Input:                 input,
+		RequestId:             uuid.NewString(),
+		WorkflowIdReusePolicy: enums.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE,
+	}, nil
+}
+
+// ForceReplication starts the force replication workflow with the provided id if it isn't running,
+// and returns true once it completed: the workflows of the namespace are then replicated to the target cluster.
+// A workflow which didn't complete successfully is started again.
+func ForceReplication(ctx context.Context, c temporalclient.Client, id, namespace, targetCluster string) (bool, error) {
+	response, err := c.WorkflowService().DescribeWorkflowExecution(ctx, &workflowservice.DescribeWorkflowExecutionRequest{
+		Namespace: primitives.SystemLocalNamespace,
+		Execution: &common.WorkflowExecution{WorkflowId: id},
+	})
+
+	var notFound *serviceerror.NotFound
+	switch {
+	case errors.As(err, &notFound):
+	case err != nil:
+		return false, fmt.Errorf("can't describe force replication workflow %s: %w", id, err)
+	default:
+		switch status := response.GetWorkflowExecutionInfo().GetStatus(); status {
+		case enums.WORKFLOW_EXECUTION_STATUS_COMPLETED:
+			return true, nil
+		case enums.WORKFLOW_EXECUTION_STATUS_RUNNING, enums.WORKFLOW_EXECUTION_STATUS_CONTINUED_AS_NEW:
+			return false, nil
+		default:
+			log.FromContext(ctx).Info("Force replication workflow didn't complete, starting it again", "workflow", id, "status", status.String())
+		}
+	}
+
+	request, err := ForceReplicationRequest(id, namespace, targetCluster)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = c.WorkflowService().StartWorkflowExecution(ctx, request)
+	var alreadyStarted *serviceerror.WorkflowExecutionAlreadyStarted
+	if err != nil && !errors.As(err, &alreadyStarted) {
+		return false, fmt.Errorf("can't start force replication workflow %s: %w", id, err)
+	}
+
+	return false, nil
+}
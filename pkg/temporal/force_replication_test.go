@@ -0,0 +1,51 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/converter"
+)
+
+func TestForceReplicationRequest(t *testing.T) {
+	request, err := temporal.ForceReplicationRequest("reshard-payments", "payments", "prod-2048")
+	require.NoError(t, err)
+
+	assert.Equal(t, "temporal-system", request.GetNamespace())
+	assert.Equal(t, "reshard-payments", request.GetWorkflowId())
+	assert.Equal(t, temporal.ForceReplicationWorkflowType, request.GetWorkflowType().GetName())
+	assert.Equal(t, "default-worker-tq", request.GetTaskQueue().GetName())
+	assert.Equal(t, enums.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE, request.GetWorkflowIdReusePolicy())
+	assert.NotEmpty(t, request.GetRequestId())
+
+	var params map[string]any
+	err = converter.GetDefaultDataConverter().FromPayloads(request.GetInput(), &params)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"Namespace":          "payments",
+		"Query":              "",
+		"EnableVerification": true,
+		"TargetClusterName":  "prod-2048",
+	}, params)
+}
@@ -18,8 +18,20 @@
 package log
 
 import (
+	"fmt"
+
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"go.temporal.io/server/common/log"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// LevelEnvVar is the environment variable holding the log level of a service,
+	// when services override the cluster's log configuration.
+	LevelEnvVar = "TEMPORAL_LOG_LEVEL"
+	// FormatEnvVar is the environment variable holding the log format of a service,
+	// when services override the cluster's log configuration.
+	FormatEnvVar = "TEMPORAL_LOG_FORMAT"
 )
 
 // NewLogConfigFromLogSpec creates a new instance of a temporal log config from the provided LogSpec.
@@ -43,3 +55,30 @@ func NewSQLConfigFromDatastoreSpec(spec *v1beta1.LogSpec) log.Config {
 		Development: spec.Development,
 	}
 }
+
+// WithServiceOverrides returns the provided log config, reading the log level and format
+// from the environment variables set on each service.
+func WithServiceOverrides(cfg log.Config) log.Config {
+	cfg.Level = fmt.Sprintf("{{ .Env.%s }}", LevelEnvVar)
+	cfg.Format = fmt.Sprintf("{{ .Env.%s }}", FormatEnvVar)
+	return cfg
+}
+
+// ServiceEnvironmentVariables returns the environment variables holding the log level and format of a service,
+// from the cluster's log spec and the service's overrides.
+func ServiceEnvironmentVariables(spec *v1beta1.LogSpec, override *v1beta1.ServiceLogSpec) []corev1.EnvVar {
+	cfg := NewSQLConfigFromDatastoreSpec(spec)
+	if override != nil {
+		if override.Level != "" {
+			cfg.Level = override.Level
+		}
+		if override.Format != "" {
+			cfg.Format = override.Format
+		}
+	}
+
+	return []corev1.EnvVar{
+		{Name: LevelEnvVar, Value: cfg.Level},
+		{Name: FormatEnvVar, Value: cfg.Format},
+	}
+}
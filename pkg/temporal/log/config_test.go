@@ -0,0 +1,63 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package log_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal/log"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestServiceEnvironmentVariables(t *testing.T) {
+	tests := map[string]struct {
+		spec     *v1beta1.LogSpec
+		override *v1beta1.ServiceLogSpec
+		expected []corev1.EnvVar
+	}{
+		"defaults": {
+			expected: []corev1.EnvVar{
+				{Name: log.LevelEnvVar, Value: "info"},
+				{Name: log.FormatEnvVar, Value: ""},
+			},
+		},
+		"cluster settings": {
+			spec: &v1beta1.LogSpec{Level: "warn", Format: "json"},
+			expected: []corev1.EnvVar{
+				{Name: log.LevelEnvVar, Value: "warn"},
+				{Name: log.FormatEnvVar, Value: "json"},
+			},
+		},
+		"service overrides": {
+			spec:     &v1beta1.LogSpec{Level: "warn", Format: "json"},
+			override: &v1beta1.ServiceLogSpec{Level: "debug"},
+			expected: []corev1.EnvVar{
+				{Name: log.LevelEnvVar, Value: "debug"},
+				{Name: log.FormatEnvVar, Value: "json"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			assert.Equal(tt, test.expected, log.ServiceEnvironmentVariables(test.spec, test.override))
+		})
+	}
+}
@@ -41,6 +41,11 @@ func NewTemporalSDKLogFromContext(ctx context.Context) sdklog.Logger {
 	return &sdkLogAdapter{l: log.FromContext(ctx)}
 }
 
+// NewTemporalSDKLog creates a new logger adapter for temporal using the provided logger.
+func NewTemporalSDKLog(l logr.Logger) sdklog.Logger {
+	return &sdkLogAdapter{l: l}
+}
+
 func (a *sdkLogAdapter) Debug(msg string, keyvals ...interface{}) {
 	a.l.V(10).Info(msg, keyvals...)
 }
@@ -0,0 +1,69 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal
+
+import (
+	"fmt"
+	"io"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// ErrorRate returns the ratio of failed requests reported by the provided temporal service prometheus metrics.
+// Requests are counted using the service_requests metric, failures using the service_errors metric,
+// both prefixed with the provided metrics prefix, if any. It returns 0 if no request was served.
+func ErrorRate(metrics io.Reader, prefix string) (float64, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(metrics)
+	if err != nil {
+		return 0, fmt.Errorf("can't parse metrics: %w", err)
+	}
+
+	name := func(metric string) string {
+		if prefix == "" {
+			return metric
+		}
+		return fmt.Sprintf("%s_%s", prefix, metric)
+	}
+
+	requests := sumCounter(families[name("service_requests")])
+	if requests == 0 {
+		return 0, nil
+	}
+
+	return sumCounter(families[name("service_errors")]) / requests, nil
+}
+
+// sumCounter returns the sum of the provided counter family values, across all labels.
+func sumCounter(family *dto.MetricFamily) float64 {
+	if family == nil {
+		return 0
+	}
+
+	sum := 0.0
+	for _, metric := range family.GetMetric() {
+		switch {
+		case metric.GetCounter() != nil:
+			sum += metric.GetCounter().GetValue()
+		case metric.GetUntyped() != nil:
+			sum += metric.GetUntyped().GetValue()
+		}
+	}
+	return sum
+}
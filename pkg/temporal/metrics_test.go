@@ -0,0 +1,78 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorRate(t *testing.T) {
+	tests := map[string]struct {
+		metrics      string
+		prefix       string
+		expectedRate float64
+	}{
+		"no requests": {
+			metrics: `# TYPE service_pending_requests gauge
+service_pending_requests{operation="StartWorkflowExecution"} 0
+`,
+			expectedRate: 0,
+		},
+		"no errors": {
+			metrics: `# TYPE service_requests counter
+service_requests{operation="StartWorkflowExecution"} 10
+service_requests{operation="PollWorkflowTaskQueue"} 30
+`,
+			expectedRate: 0,
+		},
+		"errors across operations": {
+			metrics: `# TYPE service_requests counter
+service_requests{operation="StartWorkflowExecution"} 10
+service_requests{operation="PollWorkflowTaskQueue"} 30
+# TYPE service_errors counter
+service_errors{operation="StartWorkflowExecution"} 2
+service_errors{operation="PollWorkflowTaskQueue"} 8
+`,
+			expectedRate: 0.25,
+		},
+		"prefixed metrics": {
+			metrics: `# TYPE temporal_service_requests counter
+temporal_service_requests{operation="StartWorkflowExecution"} 4
+# TYPE temporal_service_errors counter
+temporal_service_errors{operation="StartWorkflowExecution"} 1
+# TYPE service_errors counter
+service_errors{operation="StartWorkflowExecution"} 4
+`,
+			prefix:       "temporal",
+			expectedRate: 0.25,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			rate, err := temporal.ErrorRate(strings.NewReader(test.metrics), test.prefix)
+			require.NoError(tt, err)
+			assert.InDelta(tt, test.expectedRate, rate, 0.0001)
+		})
+	}
+}
@@ -18,6 +18,9 @@
 package temporal
 
 import (
+	"fmt"
+	"maps"
+
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"github.com/alexandrevilain/temporal-operator/pkg/temporal/archival"
 	"go.temporal.io/api/enums/v1"
@@ -28,7 +31,48 @@ import (
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
 )
 
+// ApplyNamespaceDefaults returns a copy of the provided namespace, with the unset settings inherited from the cluster's namespace defaults.
+func ApplyNamespaceDefaults(cluster *v1beta1.TemporalCluster, namespace *v1beta1.TemporalNamespace) *v1beta1.TemporalNamespace {
+	namespace = namespace.DeepCopy()
+
+	defaults := cluster.Spec.NamespaceDefaults
+	if defaults == nil {
+		return namespace
+	}
+
+	if namespace.Spec.RetentionPeriod == nil && defaults.RetentionPeriod != nil {
+		namespace.Spec.RetentionPeriod = defaults.RetentionPeriod.DeepCopy()
+	}
+
+	if defaults.Archival != nil {
+		if namespace.Spec.Archival == nil {
+			namespace.Spec.Archival = &v1beta1.TemporalNamespaceArchivalSpec{}
+		}
+		if namespace.Spec.Archival.History == nil && defaults.Archival.History != nil {
+			namespace.Spec.Archival.History = defaults.Archival.History.DeepCopy()
+		}
+		if namespace.Spec.Archival.Visibility == nil && defaults.Archival.Visibility != nil {
+			namespace.Spec.Archival.Visibility = defaults.Archival.Visibility.DeepCopy()
+		}
+	}
+
+	if len(defaults.SearchAttributes) > 0 {
+		searchAttributes := make(map[string]v1beta1.SearchAttributeType, len(defaults.SearchAttributes)+len(namespace.Spec.SearchAttributes))
+		for name, attributeType := range defaults.SearchAttributes {
+			searchAttributes[name] = attributeType
+		}
+		for name, attributeType := range namespace.Spec.SearchAttributes {
+			searchAttributes[name] = attributeType
+		}
+		namespace.Spec.SearchAttributes = searchAttributes
+	}
+
+	return namespace
+}
+
 func NamespaceToRegisterNamespaceRequest(cluster *v1beta1.TemporalCluster, namespace *v1beta1.TemporalNamespace) *workflowservice.RegisterNamespaceRequest {
+	namespace = ApplyNamespaceDefaults(cluster, namespace)
+
 	re := &workflowservice.RegisterNamespaceRequest{
 		Namespace:     namespace.GetName(),
 		Description:   namespace.Spec.Description,
@@ -47,7 +91,7 @@ func NamespaceToRegisterNamespaceRequest(cluster *v1beta1.TemporalCluster, names
 			}
 
 			re.HistoryArchivalState = state
-			re.HistoryArchivalUri = archival.URI(cluster.Spec.Archival.Provider, namespace.Spec.Archival.History)
+			re.HistoryArchivalUri = archival.NamespaceURI(cluster.Spec.Archival.Provider, cluster.Spec.Archival.History, namespace.Spec.Archival.History)
 		}
 
 		// Check for namespace-level visibility archival config override.
@@ -58,7 +102,7 @@ func NamespaceToRegisterNamespaceRequest(cluster *v1beta1.TemporalCluster, names
 			}
 
 			re.VisibilityArchivalState = state
-			re.VisibilityArchivalUri = archival.URI(cluster.Spec.Archival.Provider, namespace.Spec.Archival.Visibility)
+			re.VisibilityArchivalUri = archival.NamespaceURI(cluster.Spec.Archival.Provider, cluster.Spec.Archival.Visibility, namespace.Spec.Archival.Visibility)
 		}
 	}
 
@@ -84,6 +128,63 @@ func NamespaceToRegisterNamespaceRequest(cluster *v1beta1.TemporalCluster, names
 	return re
 }
 
+// DefaultNamespaceToRegisterNamespaceRequest returns the request registering the provided cluster's default namespace.
+func DefaultNamespaceToRegisterNamespaceRequest(namespace v1beta1.DefaultNamespaceSpec) *workflowservice.RegisterNamespaceRequest {
+	re := &workflowservice.RegisterNamespaceRequest{
+		Namespace: namespace.Name,
+	}
+
+	if namespace.RetentionPeriod != nil {
+		re.WorkflowExecutionRetentionPeriod = durationpb.New(namespace.RetentionPeriod.Duration)
+	}
+
+	return re
+}
+
+var searchAttributeTypes = map[v1beta1.SearchAttributeType]enums.IndexedValueType{
+	v1beta1.SearchAttributeTypeText:        enums.INDEXED_VALUE_TYPE_TEXT,
+	v1beta1.SearchAttributeTypeKeyword:     enums.INDEXED_VALUE_TYPE_KEYWORD,
+	v1beta1.SearchAttributeTypeInt:         enums.INDEXED_VALUE_TYPE_INT,
+	v1beta1.SearchAttributeTypeDouble:      enums.INDEXED_VALUE_TYPE_DOUBLE,
+	v1beta1.SearchAttributeTypeBool:        enums.INDEXED_VALUE_TYPE_BOOL,
+	v1beta1.SearchAttributeTypeDatetime:    enums.INDEXED_VALUE_TYPE_DATETIME,
+	v1beta1.SearchAttributeTypeKeywordList: enums.INDEXED_VALUE_TYPE_KEYWORD_LIST,
+}
+
+// NamespaceToAddSearchAttributesRequest returns the request adding the namespace's search attributes missing from the current ones,
+// or nil if there is none to add.
+// It returns an error if a search attribute already exists with another type, as temporal doesn't allow changing it.
+func NamespaceToAddSearchAttributesRequest(namespace *v1beta1.TemporalNamespace, current *operatorservice.ListSearchAttributesResponse) (*operatorservice.AddSearchAttributesRequest, error) {
+	missing := map[string]enums.IndexedValueType{}
+	for name, attributeType := range namespace.Spec.SearchAttributes {
+		desired, ok := searchAttributeTypes[attributeType]
+		if !ok {
+			return nil, fmt.Errorf("search attribute %s has an unknown type %s", name, attributeType)
+		}
+
+		existing, ok := current.GetCustomAttributes()[name]
+		if !ok {
+			existing, ok = current.GetSystemAttributes()[name]
+		}
+
+		switch {
+		case !ok:
+			missing[name] = desired
+		case existing != desired:
+			return nil, fmt.Errorf("search attribute %s already exists with type %s, can't change it to %s", name, existing, desired)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil, nil
+	}
+
+	return &operatorservice.AddSearchAttributesRequest{
+		Namespace:        namespace.GetName(),
+		SearchAttributes: missing,
+	}, nil
+}
+
 func NamespaceToDeleteNamespaceRequest(namespace *v1beta1.TemporalNamespace) *operatorservice.DeleteNamespaceRequest {
 	return &operatorservice.DeleteNamespaceRequest{
 		Namespace: namespace.GetName(),
@@ -91,6 +192,8 @@ func NamespaceToDeleteNamespaceRequest(namespace *v1beta1.TemporalNamespace) *op
 }
 
 func NamespaceToUpdateNamespaceRequest(cluster *v1beta1.TemporalCluster, namespace *v1beta1.TemporalNamespace) *workflowservice.UpdateNamespaceRequest {
+	namespace = ApplyNamespaceDefaults(cluster, namespace)
+
 	re := &workflowservice.UpdateNamespaceRequest{
 		Namespace: namespace.GetName(),
 		UpdateInfo: &namespacev1.UpdateNamespaceInfo{
@@ -112,7 +215,7 @@ func NamespaceToUpdateNamespaceRequest(cluster *v1beta1.TemporalCluster, namespa
 			}
 
 			re.Config.HistoryArchivalState = state
-			re.Config.HistoryArchivalUri = archival.URI(cluster.Spec.Archival.Provider, namespace.Spec.Archival.History)
+			re.Config.HistoryArchivalUri = archival.NamespaceURI(cluster.Spec.Archival.Provider, cluster.Spec.Archival.History, namespace.Spec.Archival.History)
 		}
 
 		// Check for namespace-level visibility archival config override.
@@ -123,7 +226,7 @@ func NamespaceToUpdateNamespaceRequest(cluster *v1beta1.TemporalCluster, namespa
 			}
 
 			re.Config.VisibilityArchivalState = state
-			re.Config.VisibilityArchivalUri = archival.URI(cluster.Spec.Archival.Provider, namespace.Spec.Archival.Visibility)
+			re.Config.VisibilityArchivalUri = archival.NamespaceURI(cluster.Spec.Archival.Provider, cluster.Spec.Archival.Visibility, namespace.Spec.Archival.Visibility)
 		}
 	}
 
@@ -148,3 +251,61 @@ func NamespaceToUpdateNamespaceRequest(cluster *v1beta1.TemporalCluster, namespa
 
 	return re
 }
+
+// NamespaceDrifted returns true if the provided temporal namespace differs from the desired update request.
+// Only the fields set by the operator are compared.
+func NamespaceDrifted(desired *workflowservice.UpdateNamespaceRequest, current *workflowservice.DescribeNamespaceResponse) bool {
+	info := current.GetNamespaceInfo()
+	if info.GetDescription() != desired.GetUpdateInfo().GetDescription() ||
+		info.GetOwnerEmail() != desired.GetUpdateInfo().GetOwnerEmail() {
+		return true
+	}
+
+	// Temporal merges the provided data with the existing one.
+	for key, value := range desired.GetUpdateInfo().GetData() {
+		if info.GetData()[key] != value {
+			return true
+		}
+	}
+
+	config := current.GetConfig()
+	if retention := desired.GetConfig().GetWorkflowExecutionRetentionTtl(); retention != nil &&
+		retention.AsDuration() != config.GetWorkflowExecutionRetentionTtl().AsDuration() {
+		return true
+	}
+
+	if state := desired.GetConfig().GetHistoryArchivalState(); state != enums.ARCHIVAL_STATE_UNSPECIFIED &&
+		(state != config.GetHistoryArchivalState() || desired.GetConfig().GetHistoryArchivalUri() != config.GetHistoryArchivalUri()) {
+		return true
+	}
+
+	if state := desired.GetConfig().GetVisibilityArchivalState(); state != enums.ARCHIVAL_STATE_UNSPECIFIED &&
+		(state != config.GetVisibilityArchivalState() || desired.GetConfig().GetVisibilityArchivalUri() != config.GetVisibilityArchivalUri()) {
+		return true
+	}
+
+	if desired.GetPromoteNamespace() && !current.GetIsGlobalNamespace() {
+		return true
+	}
+
+	if active := desired.GetReplicationConfig().GetActiveClusterName(); active != "" &&
+		active != current.GetReplicationConfig().GetActiveClusterName() {
+		return true
+	}
+
+	if clusters := desired.GetReplicationConfig().GetClusters(); len(clusters) > 0 {
+		desiredClusters := make(map[string]bool, len(clusters))
+		for _, cluster := range clusters {
+			desiredClusters[cluster.GetClusterName()] = true
+		}
+		currentClusters := make(map[string]bool, len(current.GetReplicationConfig().GetClusters()))
+		for _, cluster := range current.GetReplicationConfig().GetClusters() {
+			currentClusters[cluster.GetClusterName()] = true
+		}
+		if !maps.Equal(desiredClusters, currentClusters) {
+			return true
+		}
+	}
+
+	return false
+}
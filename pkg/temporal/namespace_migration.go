@@ -0,0 +1,151 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/operatorservice/v1"
+	"go.temporal.io/api/replication/v1"
+	"go.temporal.io/api/workflowservice/v1"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+)
+
+// CheckReplicationClusters returns an error if the provided clusters can't replicate each other.
+// Clusters with different numbers of history shards can replicate each other since temporal 1.20,
+// if the number of history shards of one cluster is a multiple of the other's.
+func CheckReplicationClusters(source, target *v1beta1.TemporalCluster) error {
+	sourceShards, targetShards := source.Spec.NumHistoryShards, target.Spec.NumHistoryShards
+	if sourceShards <= 0 || targetShards <= 0 {
+		return errors.New("clusters must have a positive number of history shards")
+	}
+
+	if sourceShards != targetShards {
+		if max(sourceShards, targetShards)%min(sourceShards, targetShards) != 0 {
+			return fmt.Errorf("the number of history shards of one cluster must be a multiple of the other's, got %d and %d", sourceShards, targetShards)
+		}
+
+		for _, cluster := range []*v1beta1.TemporalCluster{source, target} {
+			if cluster.Spec.Version == nil || !cluster.Spec.Version.GreaterOrEqual(version.V1_20_0) {
+				return fmt.Errorf("cluster %s must run temporal 1.20.0 or later to replicate with a different number of history shards", cluster.GetName())
+			}
+		}
+	}
+
+	sourceMetadata, targetMetadata := source.GetClusterMetadata(), target.GetClusterMetadata()
+	if sourceMetadata.ClusterName == targetMetadata.ClusterName {
+		return fmt.Errorf("clusters have the same temporal cluster name: %s", sourceMetadata.ClusterName)
+	}
+
+	if sourceMetadata.InitialFailoverVersion == targetMetadata.InitialFailoverVersion {
+		return fmt.Errorf("clusters have the same initial failover version: %d", sourceMetadata.InitialFailoverVersion)
+	}
+
+	if sourceMetadata.FailoverVersionIncrement != targetMetadata.FailoverVersionIncrement {
+		return errors.New("clusters must have the same failover version increment")
+	}
+
+	return nil
+}
+
+// NamespaceReplicationRequest returns the next request needed to replicate the namespace to the provided cluster,
+// or nil if the namespace is already replicated to it.
+// Local namespaces are first promoted to global namespaces.
+func NamespaceReplicationRequest(current *workflowservice.DescribeNamespaceResponse, cluster string) *workflowservice.UpdateNamespaceRequest {
+	name := current.GetNamespaceInfo().GetName()
+
+	if !current.GetIsGlobalNamespace() {
+		return &workflowservice.UpdateNamespaceRequest{
+			Namespace:        name,
+			PromoteNamespace: true,
+		}
+	}
+
+	clusters := make([]*replication.ClusterReplicationConfig, 0, len(current.GetReplicationConfig().GetClusters())+1)
+	for _, replicated := range current.GetReplicationConfig().GetClusters() {
+		if replicated.GetClusterName() == cluster {
+			return nil
+		}
+		clusters = append(clusters, &replication.ClusterReplicationConfig{ClusterName: replicated.GetClusterName()})
+	}
+	clusters = append(clusters, &replication.ClusterReplicationConfig{ClusterName: cluster})
+
+	return &workflowservice.UpdateNamespaceRequest{
+		Namespace: name,
+		ReplicationConfig: &replication.NamespaceReplicationConfig{
+			Clusters: clusters,
+		},
+	}
+}
+
+// NamespaceCloneRequest returns the request registering the described namespace as a local namespace of the target cluster,
+// with the same description, owner, data and retention period.
+// Archival states are only copied if archival is enabled in the target cluster, using the target cluster's archival URIs.
+func NamespaceCloneRequest(current *workflowservice.DescribeNamespaceResponse, target *v1beta1.TemporalCluster) *workflowservice.RegisterNamespaceRequest {
+	info := current.GetNamespaceInfo()
+	config := current.GetConfig()
+
+	re := &workflowservice.RegisterNamespaceRequest{
+		Namespace:                        info.GetName(),
+		Description:                      info.GetDescription(),
+		OwnerEmail:                       info.GetOwnerEmail(),
+		Data:                             maps.Clone(info.GetData()),
+		WorkflowExecutionRetentionPeriod: config.GetWorkflowExecutionRetentionTtl(),
+	}
+
+	if target.Spec.Archival.IsEnabled() {
+		re.HistoryArchivalState = config.GetHistoryArchivalState()
+		re.VisibilityArchivalState = config.GetVisibilityArchivalState()
+	}
+
+	return re
+}
+
+// SearchAttributesCloneRequest returns the request adding the custom search attributes of the source cluster missing
+// from the target cluster to the namespace, or nil if there is none to add.
+// It returns an error if a search attribute exists in both clusters with different types.
+func SearchAttributesCloneRequest(namespace string, source, target *operatorservice.ListSearchAttributesResponse) (*operatorservice.AddSearchAttributesRequest, error) {
+	missing := map[string]enums.IndexedValueType{}
+	for name, attributeType := range source.GetCustomAttributes() {
+		existing, ok := target.GetCustomAttributes()[name]
+		if !ok {
+			existing, ok = target.GetSystemAttributes()[name]
+		}
+
+		switch {
+		case !ok:
+			missing[name] = attributeType
+		case existing != attributeType:
+			return nil, fmt.Errorf("search attribute %s has type %s in the target cluster, expected %s", name, existing, attributeType)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil, nil
+	}
+
+	return &operatorservice.AddSearchAttributesRequest{
+		Namespace:        namespace,
+		SearchAttributes: missing,
+	}, nil
+}
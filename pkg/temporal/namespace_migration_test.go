@@ -0,0 +1,264 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	"github.com/stretchr/testify/assert"
+	"go.temporal.io/api/enums/v1"
+	namespacev1 "go.temporal.io/api/namespace/v1"
+	"go.temporal.io/api/operatorservice/v1"
+	"go.temporal.io/api/replication/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+)
+
+func TestCheckReplicationClusters(t *testing.T) {
+	cluster := func(name string, shards int32, failoverVersion int64) *v1beta1.TemporalCluster {
+		return &v1beta1.TemporalCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1beta1.TemporalClusterSpec{
+				Version:          version.MustNewVersionFromString("1.23.0"),
+				NumHistoryShards: shards,
+				ClusterMetadata:  &v1beta1.ClusterMetadataSpec{InitialFailoverVersion: failoverVersion},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		source        *v1beta1.TemporalCluster
+		target        *v1beta1.TemporalCluster
+		expectedError string
+	}{
+		"same number of shards": {
+			source: cluster("prod-east", 512, 1),
+			target: cluster("prod-west", 512, 2),
+		},
+		"number of shards multiple": {
+			source: cluster("prod-east", 512, 1),
+			target: cluster("prod-west", 1024, 2),
+		},
+		"number of shards not a multiple": {
+			source:        cluster("prod-east", 512, 1),
+			target:        cluster("prod-west", 1000, 2),
+			expectedError: "the number of history shards of one cluster must be a multiple of the other's, got 512 and 1000",
+		},
+		"same failover version": {
+			source:        cluster("prod-east", 512, 1),
+			target:        cluster("prod-west", 512, 1),
+			expectedError: "clusters have the same initial failover version: 1",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			err := temporal.CheckReplicationClusters(test.source, test.target)
+			if test.expectedError == "" {
+				assert.NoError(tt, err)
+			} else {
+				assert.EqualError(tt, err, test.expectedError)
+			}
+		})
+	}
+}
+
+func TestNamespaceReplicationRequest(t *testing.T) {
+	tests := map[string]struct {
+		current  *workflowservice.DescribeNamespaceResponse
+		expected *workflowservice.UpdateNamespaceRequest
+	}{
+		"local namespace": {
+			current: &workflowservice.DescribeNamespaceResponse{
+				NamespaceInfo: &namespacev1.NamespaceInfo{Name: "payments"},
+			},
+			expected: &workflowservice.UpdateNamespaceRequest{
+				Namespace:        "payments",
+				PromoteNamespace: true,
+			},
+		},
+		"global namespace": {
+			current: &workflowservice.DescribeNamespaceResponse{
+				NamespaceInfo:     &namespacev1.NamespaceInfo{Name: "payments"},
+				IsGlobalNamespace: true,
+				ReplicationConfig: &replication.NamespaceReplicationConfig{
+					ActiveClusterName: "prod-east",
+					Clusters:          []*replication.ClusterReplicationConfig{{ClusterName: "prod-east"}},
+				},
+			},
+			expected: &workflowservice.UpdateNamespaceRequest{
+				Namespace: "payments",
+				ReplicationConfig: &replication.NamespaceReplicationConfig{
+					Clusters: []*replication.ClusterReplicationConfig{
+						{ClusterName: "prod-east"},
+						{ClusterName: "prod-west"},
+					},
+				},
+			},
+		},
+		"replicated namespace": {
+			current: &workflowservice.DescribeNamespaceResponse{
+				NamespaceInfo:     &namespacev1.NamespaceInfo{Name: "payments"},
+				IsGlobalNamespace: true,
+				ReplicationConfig: &replication.NamespaceReplicationConfig{
+					ActiveClusterName: "prod-east",
+					Clusters: []*replication.ClusterReplicationConfig{
+						{ClusterName: "prod-east"},
+						{ClusterName: "prod-west"},
+					},
+				},
+			},
+			expected: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			assert.Equal(tt, test.expected, temporal.NamespaceReplicationRequest(test.current, "prod-west"))
+		})
+	}
+}
+
+func TestNamespaceCloneRequest(t *testing.T) {
+	current := &workflowservice.DescribeNamespaceResponse{
+		NamespaceInfo: &namespacev1.NamespaceInfo{
+			Name:        "payments",
+			Description: "Payments",
+			OwnerEmail:  "payments@example.com",
+			Data:        map[string]string{"team": "payments"},
+		},
+		Config: &namespacev1.NamespaceConfig{
+			WorkflowExecutionRetentionTtl: durationpb.New(72 * time.Hour),
+			HistoryArchivalState:          enums.ARCHIVAL_STATE_ENABLED,
+			HistoryArchivalUri:            "s3://prod-east-archival/history",
+			VisibilityArchivalState:       enums.ARCHIVAL_STATE_DISABLED,
+		},
+	}
+
+	tests := map[string]struct {
+		target   *v1beta1.TemporalCluster
+		expected *workflowservice.RegisterNamespaceRequest
+	}{
+		"target without archival": {
+			target: &v1beta1.TemporalCluster{},
+			expected: &workflowservice.RegisterNamespaceRequest{
+				Namespace:                        "payments",
+				Description:                      "Payments",
+				OwnerEmail:                       "payments@example.com",
+				Data:                             map[string]string{"team": "payments"},
+				WorkflowExecutionRetentionPeriod: durationpb.New(72 * time.Hour),
+			},
+		},
+		"target with archival": {
+			target: &v1beta1.TemporalCluster{
+				Spec: v1beta1.TemporalClusterSpec{
+					Archival: &v1beta1.ClusterArchivalSpec{Enabled: true},
+				},
+			},
+			expected: &workflowservice.RegisterNamespaceRequest{
+				Namespace:                        "payments",
+				Description:                      "Payments",
+				OwnerEmail:                       "payments@example.com",
+				Data:                             map[string]string{"team": "payments"},
+				WorkflowExecutionRetentionPeriod: durationpb.New(72 * time.Hour),
+				HistoryArchivalState:             enums.ARCHIVAL_STATE_ENABLED,
+				VisibilityArchivalState:          enums.ARCHIVAL_STATE_DISABLED,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			assert.Equal(tt, test.expected, temporal.NamespaceCloneRequest(current, test.target))
+		})
+	}
+}
+
+func TestSearchAttributesCloneRequest(t *testing.T) {
+	source := &operatorservice.ListSearchAttributesResponse{
+		CustomAttributes: map[string]enums.IndexedValueType{
+			"CustomerId": enums.INDEXED_VALUE_TYPE_KEYWORD,
+			"Amount":     enums.INDEXED_VALUE_TYPE_DOUBLE,
+		},
+		SystemAttributes: map[string]enums.IndexedValueType{
+			"WorkflowType": enums.INDEXED_VALUE_TYPE_KEYWORD,
+		},
+	}
+
+	tests := map[string]struct {
+		target        *operatorservice.ListSearchAttributesResponse
+		expected      *operatorservice.AddSearchAttributesRequest
+		expectedError string
+	}{
+		"all missing": {
+			target: &operatorservice.ListSearchAttributesResponse{},
+			expected: &operatorservice.AddSearchAttributesRequest{
+				Namespace: "payments",
+				SearchAttributes: map[string]enums.IndexedValueType{
+					"CustomerId": enums.INDEXED_VALUE_TYPE_KEYWORD,
+					"Amount":     enums.INDEXED_VALUE_TYPE_DOUBLE,
+				},
+			},
+		},
+		"some missing": {
+			target: &operatorservice.ListSearchAttributesResponse{
+				CustomAttributes: map[string]enums.IndexedValueType{
+					"CustomerId": enums.INDEXED_VALUE_TYPE_KEYWORD,
+				},
+			},
+			expected: &operatorservice.AddSearchAttributesRequest{
+				Namespace: "payments",
+				SearchAttributes: map[string]enums.IndexedValueType{
+					"Amount": enums.INDEXED_VALUE_TYPE_DOUBLE,
+				},
+			},
+		},
+		"none missing": {
+			target: &operatorservice.ListSearchAttributesResponse{
+				CustomAttributes: source.CustomAttributes,
+			},
+			expected: nil,
+		},
+		"type mismatch": {
+			target: &operatorservice.ListSearchAttributesResponse{
+				CustomAttributes: map[string]enums.IndexedValueType{
+					"Amount": enums.INDEXED_VALUE_TYPE_INT,
+				},
+			},
+			expectedError: "search attribute Amount has type Int in the target cluster, expected Double",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			request, err := temporal.SearchAttributesCloneRequest("payments", source, test.target)
+			if test.expectedError != "" {
+				assert.EqualError(tt, err, test.expectedError)
+				return
+			}
+			assert.NoError(tt, err)
+			assert.Equal(tt, test.expected, request)
+		})
+	}
+}
@@ -0,0 +1,254 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	"github.com/stretchr/testify/assert"
+	"go.temporal.io/api/enums/v1"
+	namespacev1 "go.temporal.io/api/namespace/v1"
+	"go.temporal.io/api/operatorservice/v1"
+	"go.temporal.io/api/replication/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNamespaceDrifted(t *testing.T) {
+	namespace := &v1beta1.TemporalNamespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec: v1beta1.TemporalNamespaceSpec{
+			Description:     "Default namespace",
+			OwnerEmail:      "platform@example.com",
+			Data:            map[string]string{"team": "platform"},
+			RetentionPeriod: &metav1.Duration{Duration: 72 * time.Hour},
+		},
+	}
+	desired := temporal.NamespaceToUpdateNamespaceRequest(&v1beta1.TemporalCluster{}, namespace)
+
+	current := func(mutate func(*workflowservice.DescribeNamespaceResponse)) *workflowservice.DescribeNamespaceResponse {
+		response := &workflowservice.DescribeNamespaceResponse{
+			NamespaceInfo: &namespacev1.NamespaceInfo{
+				Name:        "default",
+				Description: "Default namespace",
+				OwnerEmail:  "platform@example.com",
+				Data:        map[string]string{"team": "platform", "extra": "value"},
+			},
+			Config: &namespacev1.NamespaceConfig{
+				WorkflowExecutionRetentionTtl: durationpb.New(72 * time.Hour),
+			},
+		}
+		if mutate != nil {
+			mutate(response)
+		}
+		return response
+	}
+
+	tests := map[string]struct {
+		current  *workflowservice.DescribeNamespaceResponse
+		expected bool
+	}{
+		"in sync": {
+			current:  current(nil),
+			expected: false,
+		},
+		"description changed": {
+			current: current(func(r *workflowservice.DescribeNamespaceResponse) {
+				r.NamespaceInfo.Description = "changed"
+			}),
+			expected: true,
+		},
+		"data changed": {
+			current: current(func(r *workflowservice.DescribeNamespaceResponse) {
+				r.NamespaceInfo.Data["team"] = "other"
+			}),
+			expected: true,
+		},
+		"retention changed": {
+			current: current(func(r *workflowservice.DescribeNamespaceResponse) {
+				r.Config.WorkflowExecutionRetentionTtl = durationpb.New(24 * time.Hour)
+			}),
+			expected: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			assert.Equal(tt, test.expected, temporal.NamespaceDrifted(desired, test.current))
+		})
+	}
+
+	t.Run("replication clusters", func(tt *testing.T) {
+		global := namespace.DeepCopy()
+		global.Spec.IsGlobalNamespace = true
+		global.Spec.ActiveClusterName = "active"
+		global.Spec.Clusters = []string{"active", "standby"}
+		desired := temporal.NamespaceToUpdateNamespaceRequest(&v1beta1.TemporalCluster{}, global)
+
+		withClusters := func(names ...string) *workflowservice.DescribeNamespaceResponse {
+			response := current(nil)
+			response.IsGlobalNamespace = true
+			response.ReplicationConfig = &replication.NamespaceReplicationConfig{ActiveClusterName: "active"}
+			for _, name := range names {
+				response.ReplicationConfig.Clusters = append(response.ReplicationConfig.Clusters, &replication.ClusterReplicationConfig{ClusterName: name})
+			}
+			return response
+		}
+
+		assert.False(tt, temporal.NamespaceDrifted(desired, withClusters("standby", "active")))
+		assert.True(tt, temporal.NamespaceDrifted(desired, withClusters("active")))
+	})
+}
+
+func TestApplyNamespaceDefaults(t *testing.T) {
+	cluster := &v1beta1.TemporalCluster{
+		Spec: v1beta1.TemporalClusterSpec{
+			NamespaceDefaults: &v1beta1.NamespaceDefaultsSpec{
+				RetentionPeriod: &metav1.Duration{Duration: 72 * time.Hour},
+				Archival: &v1beta1.TemporalNamespaceArchivalSpec{
+					History:    &v1beta1.ArchivalSpec{Enabled: true, Path: "/history"},
+					Visibility: &v1beta1.ArchivalSpec{Enabled: true, Path: "/visibility"},
+				},
+				SearchAttributes: map[string]v1beta1.SearchAttributeType{
+					"CustomerId": v1beta1.SearchAttributeTypeKeyword,
+					"Region":     v1beta1.SearchAttributeTypeKeyword,
+				},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		namespace *v1beta1.TemporalNamespace
+		expected  v1beta1.TemporalNamespaceSpec
+	}{
+		"inherits defaults": {
+			namespace: &v1beta1.TemporalNamespace{},
+			expected: v1beta1.TemporalNamespaceSpec{
+				RetentionPeriod: &metav1.Duration{Duration: 72 * time.Hour},
+				Archival: &v1beta1.TemporalNamespaceArchivalSpec{
+					History:    &v1beta1.ArchivalSpec{Enabled: true, Path: "/history"},
+					Visibility: &v1beta1.ArchivalSpec{Enabled: true, Path: "/visibility"},
+				},
+				SearchAttributes: map[string]v1beta1.SearchAttributeType{
+					"CustomerId": v1beta1.SearchAttributeTypeKeyword,
+					"Region":     v1beta1.SearchAttributeTypeKeyword,
+				},
+			},
+		},
+		"overrides defaults": {
+			namespace: &v1beta1.TemporalNamespace{
+				Spec: v1beta1.TemporalNamespaceSpec{
+					RetentionPeriod: &metav1.Duration{Duration: 24 * time.Hour},
+					Archival: &v1beta1.TemporalNamespaceArchivalSpec{
+						History: &v1beta1.ArchivalSpec{Enabled: false},
+					},
+					SearchAttributes: map[string]v1beta1.SearchAttributeType{
+						"Region": v1beta1.SearchAttributeTypeText,
+						"Amount": v1beta1.SearchAttributeTypeDouble,
+					},
+				},
+			},
+			expected: v1beta1.TemporalNamespaceSpec{
+				RetentionPeriod: &metav1.Duration{Duration: 24 * time.Hour},
+				Archival: &v1beta1.TemporalNamespaceArchivalSpec{
+					History:    &v1beta1.ArchivalSpec{Enabled: false},
+					Visibility: &v1beta1.ArchivalSpec{Enabled: true, Path: "/visibility"},
+				},
+				SearchAttributes: map[string]v1beta1.SearchAttributeType{
+					"CustomerId": v1beta1.SearchAttributeTypeKeyword,
+					"Region":     v1beta1.SearchAttributeTypeText,
+					"Amount":     v1beta1.SearchAttributeTypeDouble,
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			original := test.namespace.DeepCopy()
+			result := temporal.ApplyNamespaceDefaults(cluster, test.namespace)
+			assert.Equal(tt, test.expected, result.Spec)
+			assert.Equal(tt, original, test.namespace)
+		})
+	}
+}
+
+func TestNamespaceToAddSearchAttributesRequest(t *testing.T) {
+	namespace := &v1beta1.TemporalNamespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments"},
+		Spec: v1beta1.TemporalNamespaceSpec{
+			SearchAttributes: map[string]v1beta1.SearchAttributeType{
+				"CustomerId": v1beta1.SearchAttributeTypeKeyword,
+				"Amount":     v1beta1.SearchAttributeTypeDouble,
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		current       *operatorservice.ListSearchAttributesResponse
+		expected      *operatorservice.AddSearchAttributesRequest
+		expectedError string
+	}{
+		"missing search attributes": {
+			current: &operatorservice.ListSearchAttributesResponse{
+				CustomAttributes: map[string]enums.IndexedValueType{
+					"CustomerId": enums.INDEXED_VALUE_TYPE_KEYWORD,
+				},
+			},
+			expected: &operatorservice.AddSearchAttributesRequest{
+				Namespace: "payments",
+				SearchAttributes: map[string]enums.IndexedValueType{
+					"Amount": enums.INDEXED_VALUE_TYPE_DOUBLE,
+				},
+			},
+		},
+		"existing search attributes": {
+			current: &operatorservice.ListSearchAttributesResponse{
+				CustomAttributes: map[string]enums.IndexedValueType{
+					"CustomerId": enums.INDEXED_VALUE_TYPE_KEYWORD,
+					"Amount":     enums.INDEXED_VALUE_TYPE_DOUBLE,
+				},
+			},
+			expected: nil,
+		},
+		"search attribute type changed": {
+			current: &operatorservice.ListSearchAttributesResponse{
+				CustomAttributes: map[string]enums.IndexedValueType{
+					"Amount": enums.INDEXED_VALUE_TYPE_INT,
+				},
+			},
+			expectedError: "search attribute Amount already exists with type Int, can't change it to Double",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			request, err := temporal.NamespaceToAddSearchAttributesRequest(namespace, test.current)
+			if test.expectedError != "" {
+				assert.EqualError(tt, err, test.expectedError)
+				return
+			}
+			assert.NoError(tt, err)
+			assert.Equal(tt, test.expected, request)
+		})
+	}
+}
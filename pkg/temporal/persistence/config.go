@@ -20,6 +20,7 @@ package persistence
 import (
 	"fmt"
 	"net/url"
+	"path"
 	"strings"
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
@@ -30,14 +31,18 @@ import (
 
 // NewSQLconfigFromDatastoreSpec creates a new instance of a temporal SQL config from the provided DatastoreSpec.
 func NewSQLConfigFromDatastoreSpec(spec *v1beta1.DatastoreSpec) *config.SQL {
+	if spec.GetType() == v1beta1.SQLiteDatastore {
+		return newSQLiteConfigFromDatastoreSpec(spec)
+	}
+
 	return &config.SQL{
 		User:               spec.SQL.User,
 		Password:           "",
-		PluginName:         spec.SQL.PluginName,
+		PluginName:         spec.SQL.GetTemporalPluginName(),
 		DatabaseName:       spec.SQL.DatabaseName,
 		ConnectAddr:        spec.SQL.ConnectAddr,
 		ConnectProtocol:    spec.SQL.ConnectProtocol,
-		ConnectAttributes:  spec.SQL.ConnectAttributes,
+		ConnectAttributes:  spec.SQL.GetConnectAttributes(),
 		MaxConns:           spec.SQL.MaxConns,
 		MaxIdleConns:       spec.SQL.MaxIdleConns,
 		MaxConnLifetime:    spec.SQL.MaxConnLifetime.Duration,
@@ -46,6 +51,29 @@ func NewSQLConfigFromDatastoreSpec(spec *v1beta1.DatastoreSpec) *config.SQL {
 	}
 }
 
+// newSQLiteConfigFromDatastoreSpec creates a new instance of a temporal SQL config for a SQLite database.
+// The database file is stored in the dev mode data volume and its schema is set up by temporal on startup.
+func newSQLiteConfigFromDatastoreSpec(spec *v1beta1.DatastoreSpec) *config.SQL {
+	attributes := map[string]string{
+		"mode":  "rwc",
+		"setup": "true",
+	}
+	for k, v := range spec.SQL.ConnectAttributes {
+		attributes[k] = v
+	}
+
+	databaseName := spec.SQL.DatabaseName
+	if !path.IsAbs(databaseName) {
+		databaseName = path.Join(v1beta1.SQLiteDataMountPath, databaseName)
+	}
+
+	return &config.SQL{
+		PluginName:        spec.SQL.PluginName,
+		DatabaseName:      databaseName,
+		ConnectAttributes: attributes,
+	}
+}
+
 // NewElasticsearchConfigFromDatastoreSpec creates a new instance of a temporal elasticsearch client config from the provided DatastoreSpec.
 func NewElasticsearchConfigFromDatastoreSpec(spec *v1beta1.DatastoreSpec) (*esclient.Config, error) {
 	parsedURL, err := url.Parse(spec.Elasticsearch.URL)
@@ -62,6 +90,7 @@ func NewElasticsearchConfigFromDatastoreSpec(spec *v1beta1.DatastoreSpec) (*escl
 		CloseIdleConnectionsInterval: spec.Elasticsearch.CloseIdleConnectionsInterval.Duration,
 		EnableSniff:                  spec.Elasticsearch.EnableSniff,
 		EnableHealthcheck:            spec.Elasticsearch.EnableSniff,
+		TLS:                          tlsConfigConfigFromDatastoreSpec(spec),
 	}, nil
 }
 
@@ -0,0 +1,65 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package persistence_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal/persistence"
+	"github.com/stretchr/testify/assert"
+	"go.temporal.io/server/common/config"
+)
+
+func TestNewSQLConfigFromDatastoreSpecSQLite(t *testing.T) {
+	tests := map[string]struct {
+		spec     *v1beta1.SQLSpec
+		expected *config.SQL
+	}{
+		"relative database name": {
+			spec: &v1beta1.SQLSpec{
+				PluginName:   "sqlite",
+				DatabaseName: "default.db",
+			},
+			expected: &config.SQL{
+				PluginName:        "sqlite",
+				DatabaseName:      "/var/lib/temporal/sqlite/default.db",
+				ConnectAttributes: map[string]string{"mode": "rwc", "setup": "true"},
+			},
+		},
+		"absolute database name and extra attributes": {
+			spec: &v1beta1.SQLSpec{
+				PluginName:        "sqlite",
+				DatabaseName:      "/data/visibility.db",
+				ConnectAttributes: map[string]string{"journal_mode": "wal"},
+			},
+			expected: &config.SQL{
+				PluginName:        "sqlite",
+				DatabaseName:      "/data/visibility.db",
+				ConnectAttributes: map[string]string{"mode": "rwc", "setup": "true", "journal_mode": "wal"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			cfg := persistence.NewSQLConfigFromDatastoreSpec(&v1beta1.DatastoreSpec{SQL: test.spec})
+			assert.Equal(tt, test.expected, cfg)
+		})
+	}
+}
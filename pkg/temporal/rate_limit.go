@@ -0,0 +1,107 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// DefaultRequestsPerSecond is the default maximum rate of the requests sent to each temporal cluster.
+	DefaultRequestsPerSecond = 20
+	// DefaultRequestsBurst is the default number of requests sent to each temporal cluster above the rate limit.
+	DefaultRequestsBurst = 50
+)
+
+// limiters holds the rate limiters of the requests sent to the temporal clusters, shared by all the reconciliations.
+var limiters = &rateLimiters{
+	limit:   DefaultRequestsPerSecond,
+	burst:   DefaultRequestsBurst,
+	entries: map[types.NamespacedName]*rate.Limiter{},
+}
+
+// rateLimiters holds a rate limiter per cluster, so a burst of reconciliations
+// (e.g. hundreds of TemporalNamespace synced at once) can't overload a cluster frontend.
+type rateLimiters struct {
+	mu      sync.Mutex
+	limit   rate.Limit
+	burst   int
+	entries map[types.NamespacedName]*rate.Limiter
+}
+
+// get returns the rate limiter of the provided cluster, or nil if rate limiting is disabled.
+func (l *rateLimiters) get(cluster types.NamespacedName) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.limit <= 0 {
+		return nil
+	}
+
+	limiter, ok := l.entries[cluster]
+	if !ok {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		l.entries[cluster] = limiter
+	}
+
+	return limiter
+}
+
+// forget removes the rate limiter of the provided cluster.
+func (l *rateLimiters) forget(cluster types.NamespacedName) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.entries, cluster)
+}
+
+// SetClusterRateLimit sets the maximum rate of the requests sent to each temporal cluster, and the number
+// of requests allowed above this rate. A rate lower or equal to 0 disables rate limiting.
+// Changing the rate limit resets the clusters rate limiters.
+func SetClusterRateLimit(requestsPerSecond float64, burst int) {
+	limiters.mu.Lock()
+	defer limiters.mu.Unlock()
+
+	if limiters.limit == rate.Limit(requestsPerSecond) && limiters.burst == burst {
+		return
+	}
+
+	limiters.limit = rate.Limit(requestsPerSecond)
+	limiters.burst = burst
+	limiters.entries = map[types.NamespacedName]*rate.Limiter{}
+}
+
+// rateLimitInterceptor returns a gRPC interceptor delaying the requests sent to the provided temporal cluster
+// to respect its rate limit. Requests which can't be sent before their deadline fail with ResourceExhausted.
+func rateLimitInterceptor(cluster types.NamespacedName) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if limiter := limiters.get(cluster); limiter != nil {
+			if err := limiter.Wait(ctx); err != nil {
+				return status.Errorf(codes.ResourceExhausted, "client-side rate limit of cluster %s: %v", cluster, err)
+			}
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
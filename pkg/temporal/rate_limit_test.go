@@ -0,0 +1,69 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestRateLimiters(t *testing.T) {
+	l := &rateLimiters{limit: 1, burst: 1, entries: map[types.NamespacedName]*rate.Limiter{}}
+	prod := types.NamespacedName{Namespace: "default", Name: "prod"}
+	staging := types.NamespacedName{Namespace: "default", Name: "staging"}
+
+	assert.Same(t, l.get(prod), l.get(prod), "limiters are shared for a cluster")
+	assert.NotSame(t, l.get(prod), l.get(staging), "clusters have their own limiters")
+
+	first := l.get(prod)
+	l.forget(prod)
+	assert.NotSame(t, first, l.get(prod))
+
+	l.limit = 0
+	assert.Nil(t, l.get(prod), "rate limiting is disabled")
+}
+
+func TestRateLimitInterceptor(t *testing.T) {
+	SetClusterRateLimit(1, 1)
+	defer SetClusterRateLimit(DefaultRequestsPerSecond, DefaultRequestsBurst)
+
+	interceptor := rateLimitInterceptor(types.NamespacedName{Namespace: "default", Name: "prod"})
+	calls := 0
+	invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+		calls++
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	assert.NoError(t, interceptor(ctx, "/temporal.api.workflowservice.v1.WorkflowService/DescribeNamespace", nil, nil, nil, invoker))
+
+	// The burst is consumed: the next request can't be sent before the context deadline.
+	err := interceptor(ctx, "/temporal.api.workflowservice.v1.WorkflowService/DescribeNamespace", nil, nil, nil, invoker)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,77 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+)
+
+// CheckReshardClusters returns an error if the namespaces of the source cluster can't be moved to the target cluster by replicating them.
+// The target cluster must have a different number of history shards.
+func CheckReshardClusters(source, target *v1beta1.TemporalCluster) error {
+	if shards := source.Spec.NumHistoryShards; shards > 0 && shards == target.Spec.NumHistoryShards {
+		return fmt.Errorf("clusters have the same number of history shards: %d", shards)
+	}
+
+	return CheckReplicationClusters(source, target)
+}
+
+// ReshardTargetCluster returns the target cluster of the reshard, created from the source cluster spec
+// with the number of history shards, persistence and cluster metadata of the reshard target spec.
+// The target cluster doesn't inherit the replication, adoption, default namespaces and public client address of the source cluster.
+func ReshardTargetCluster(reshard *v1beta1.TemporalReshard, source *v1beta1.TemporalCluster) *v1beta1.TemporalCluster {
+	name := reshard.Spec.TargetClusterRef.NamespacedName(reshard)
+	target := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name,
+			Namespace: name.Namespace,
+		},
+		Spec: *source.Spec.DeepCopy(),
+	}
+
+	target.Spec.NumHistoryShards = reshard.Spec.Target.NumHistoryShards
+	target.Spec.Persistence = *reshard.Spec.Target.Persistence.DeepCopy()
+	target.Spec.Replication = nil
+	target.Spec.Adoption = nil
+	target.Spec.DefaultNamespaces = nil
+	target.Spec.PublicClient = nil
+	target.Spec.Paused = false
+
+	sourceMetadata := source.GetClusterMetadata()
+	metadata := &v1beta1.ClusterMetadataSpec{
+		InitialFailoverVersion:   sourceMetadata.InitialFailoverVersion%sourceMetadata.FailoverVersionIncrement + 1,
+		FailoverVersionIncrement: sourceMetadata.FailoverVersionIncrement,
+	}
+	if override := reshard.Spec.Target.ClusterMetadata; override != nil {
+		metadata.ClusterName = override.ClusterName
+		if override.InitialFailoverVersion != 0 {
+			metadata.InitialFailoverVersion = override.InitialFailoverVersion
+		}
+		if override.FailoverVersionIncrement != 0 {
+			metadata.FailoverVersionIncrement = override.FailoverVersionIncrement
+		}
+		metadata.EnableGlobalNamespace = override.EnableGlobalNamespace
+	}
+	target.Spec.ClusterMetadata = metadata
+
+	return target
+}
@@ -0,0 +1,152 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckReshardClusters(t *testing.T) {
+	cluster := func(name string, shards int32, mutate func(*v1beta1.TemporalCluster)) *v1beta1.TemporalCluster {
+		c := &v1beta1.TemporalCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1beta1.TemporalClusterSpec{
+				Version:          version.MustNewVersionFromString("1.23.0"),
+				NumHistoryShards: shards,
+			},
+		}
+		if mutate != nil {
+			mutate(c)
+		}
+		return c
+	}
+	withFailoverVersion := func(version int64) func(*v1beta1.TemporalCluster) {
+		return func(c *v1beta1.TemporalCluster) {
+			c.Spec.ClusterMetadata = &v1beta1.ClusterMetadataSpec{InitialFailoverVersion: version}
+		}
+	}
+
+	tests := map[string]struct {
+		source        *v1beta1.TemporalCluster
+		target        *v1beta1.TemporalCluster
+		expectedError string
+	}{
+		"more shards": {
+			source: cluster("prod", 512, nil),
+			target: cluster("prod-resharded", 2048, withFailoverVersion(2)),
+		},
+		"less shards": {
+			source: cluster("prod", 2048, nil),
+			target: cluster("prod-resharded", 512, withFailoverVersion(2)),
+		},
+		"same number of shards": {
+			source:        cluster("prod", 512, nil),
+			target:        cluster("prod-resharded", 512, withFailoverVersion(2)),
+			expectedError: "clusters have the same number of history shards: 512",
+		},
+		"number of shards not a multiple": {
+			source:        cluster("prod", 512, nil),
+			target:        cluster("prod-resharded", 1000, withFailoverVersion(2)),
+			expectedError: "the number of history shards of one cluster must be a multiple of the other's, got 512 and 1000",
+		},
+		"old temporal version": {
+			source: cluster("prod", 512, func(c *v1beta1.TemporalCluster) {
+				c.Spec.Version = version.MustNewVersionFromString("1.19.1")
+			}),
+			target:        cluster("prod-resharded", 1024, withFailoverVersion(2)),
+			expectedError: "cluster prod must run temporal 1.20.0 or later to replicate with a different number of history shards",
+		},
+		"same failover version": {
+			source:        cluster("prod", 512, nil),
+			target:        cluster("prod-resharded", 1024, nil),
+			expectedError: "clusters have the same initial failover version: 1",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			err := temporal.CheckReshardClusters(test.source, test.target)
+			if test.expectedError == "" {
+				assert.NoError(tt, err)
+			} else {
+				assert.EqualError(tt, err, test.expectedError)
+			}
+		})
+	}
+}
+
+func TestReshardTargetCluster(t *testing.T) {
+	source := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo"},
+		Spec: v1beta1.TemporalClusterSpec{
+			Version:          version.MustNewVersionFromString("1.23.0"),
+			NumHistoryShards: 512,
+			Persistence: v1beta1.TemporalPersistenceSpec{
+				DefaultStore: &v1beta1.DatastoreSpec{Name: "default"},
+			},
+			ClusterMetadata: &v1beta1.ClusterMetadataSpec{
+				ClusterName:            "prod-east",
+				InitialFailoverVersion: 3,
+			},
+			Replication:       &v1beta1.ReplicationSpec{},
+			DefaultNamespaces: []v1beta1.DefaultNamespaceSpec{{Name: "payments"}},
+			PublicClient:      &v1beta1.PublicClientSpec{HostPort: "prod.example.com:7233"},
+		},
+	}
+
+	reshard := &v1beta1.TemporalReshard{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-to-2048-shards", Namespace: "demo"},
+		Spec: v1beta1.TemporalReshardSpec{
+			ClusterRef:       v1beta1.TemporalClusterReference{Name: "prod"},
+			TargetClusterRef: v1beta1.TemporalClusterReference{Name: "prod-2048"},
+			Target: &v1beta1.TemporalReshardTargetSpec{
+				NumHistoryShards: 2048,
+				Persistence: v1beta1.TemporalPersistenceSpec{
+					DefaultStore: &v1beta1.DatastoreSpec{Name: "default-2048"},
+				},
+			},
+		},
+	}
+
+	target := temporal.ReshardTargetCluster(reshard, source)
+
+	assert.Equal(t, "prod-2048", target.GetName())
+	assert.Equal(t, "demo", target.GetNamespace())
+	assert.Equal(t, source.Spec.Version, target.Spec.Version)
+	assert.Equal(t, int32(2048), target.Spec.NumHistoryShards)
+	assert.Equal(t, "default-2048", target.Spec.Persistence.DefaultStore.Name)
+	assert.Nil(t, target.Spec.Replication)
+	assert.Nil(t, target.Spec.DefaultNamespaces)
+	assert.Nil(t, target.Spec.PublicClient)
+	assert.Equal(t, v1beta1.ClusterMetadataSpec{
+		ClusterName:              "prod-2048",
+		InitialFailoverVersion:   4,
+		FailoverVersionIncrement: 10,
+	}, target.GetClusterMetadata())
+	assert.NoError(t, temporal.CheckReshardClusters(source, target))
+
+	// The source cluster spec is left untouched.
+	assert.Equal(t, int32(512), source.Spec.NumHistoryShards)
+	assert.Equal(t, "default", source.Spec.Persistence.DefaultStore.Name)
+}
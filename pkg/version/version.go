@@ -40,6 +40,21 @@ var (
 	V1_21_0 = MustNewVersionFromString("1.21.0") //nolint:stylecheck,revive
 	V1_22_0 = MustNewVersionFromString("1.22.0") //nolint:stylecheck,revive
 	V1_23_0 = MustNewVersionFromString("1.23.0") //nolint:stylecheck,revive
+	// UpgradeStepReleases holds the release used for each minor version a multi-minor
+	// upgrade goes through. It's the latest patch release of each supported minor version,
+	// also used by clusters following the newest patch release of their minor version.
+	UpgradeStepReleases = []*Version{
+		MustNewVersionFromString("1.14.6"),
+		MustNewVersionFromString("1.15.2"),
+		MustNewVersionFromString("1.16.3"),
+		MustNewVersionFromString("1.17.6"),
+		MustNewVersionFromString("1.18.5"),
+		MustNewVersionFromString("1.19.1"),
+		MustNewVersionFromString("1.20.4"),
+		MustNewVersionFromString("1.21.6"),
+		MustNewVersionFromString("1.22.7"),
+		MustNewVersionFromString("1.23.0"),
+	}
 )
 
 // Version is a wrapper around semver.Version which supports correct
@@ -107,6 +122,70 @@ func (v *Version) UpgradeConstraint() (*semver.Constraints, error) {
 	return semver.NewConstraint(constraint)
 }
 
+// CheckUpgrade returns an error if upgrading from the current version to the provided version
+// doesn't follow temporal's supported upgrade paths: versions can't be downgraded,
+// and minor versions can't be skipped (from v1.n.x, only v1.n.y and v1.n+1.y are allowed).
+// See: https://docs.temporal.io/cluster-deployment-guide#upgrade-server
+func (v *Version) CheckUpgrade(to *Version) error {
+	switch {
+	case to.LessThan(v.Version):
+		return fmt.Errorf("downgrading from %s to %s is not supported", v, to)
+	case to.Major() != v.Major():
+		return fmt.Errorf("upgrading from %s to %s changes the major version, which is not supported", v, to)
+	case to.Minor() > v.Minor()+1:
+		return fmt.Errorf("upgrading from %s to %s skips minor versions, upgrade to the latest %d.%d patch release first", v, to, v.Major(), v.Minor()+1)
+	}
+	return nil
+}
+
+// UpgradePath returns the versions a cluster running the current version has to be upgraded to,
+// in order, to reach the provided version without skipping minor versions.
+// Each skipped minor version is replaced by its release from UpgradeStepReleases.
+// The returned path is empty if both versions are equal.
+func (v *Version) UpgradePath(to *Version) ([]*Version, error) {
+	if to.Equal(v.Version) {
+		return nil, nil
+	}
+
+	err := v.CheckUpgrade(to)
+	if err == nil {
+		return []*Version{to}, nil
+	}
+	if to.LessThan(v.Version) || to.Major() != v.Major() {
+		return nil, err
+	}
+
+	path := []*Version{}
+	for minor := v.Minor() + 1; minor < to.Minor(); minor++ {
+		step := upgradeStepRelease(v.Major(), minor)
+		if step == nil {
+			return nil, fmt.Errorf("upgrading from %s to %s requires a %d.%d release, which is unknown: upgrade to a %d.%d release first", v, to, v.Major(), minor, v.Major(), minor)
+		}
+		path = append(path, step)
+	}
+
+	return append(path, to), nil
+}
+
+// LatestPatchRelease returns the newest known patch release of the current minor version.
+// It returns the current version if it's newer than all known releases of its minor version.
+func (v *Version) LatestPatchRelease() *Version {
+	release := upgradeStepRelease(v.Major(), v.Minor())
+	if release == nil || !release.GreaterThan(v.Version) {
+		return v
+	}
+	return release
+}
+
+func upgradeStepRelease(major, minor uint64) *Version {
+	for _, release := range UpgradeStepReleases {
+		if release.Major() == major && release.Minor() == minor {
+			return release
+		}
+	}
+	return nil
+}
+
 // OpenAPISchemaType is used by the kube-openapi generator when constructing
 // the OpenAPI spec of this type.
 //
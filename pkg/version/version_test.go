@@ -67,3 +67,140 @@ func TestUpgradeConstraint(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckUpgrade(t *testing.T) {
+	tests := map[string]struct {
+		version        *version.Version
+		upgradeVersion *version.Version
+		expectedError  string
+	}{
+		"same version": {
+			version:        version.MustNewVersionFromString("1.22.4"),
+			upgradeVersion: version.MustNewVersionFromString("1.22.4"),
+		},
+		"patch upgrade": {
+			version:        version.MustNewVersionFromString("1.22.0"),
+			upgradeVersion: version.MustNewVersionFromString("1.22.4"),
+		},
+		"next minor version": {
+			version:        version.MustNewVersionFromString("1.22.4"),
+			upgradeVersion: version.MustNewVersionFromString("1.23.0"),
+		},
+		"skipped minor version": {
+			version:        version.MustNewVersionFromString("1.21.3"),
+			upgradeVersion: version.MustNewVersionFromString("1.23.0"),
+			expectedError:  "upgrading from 1.21.3 to 1.23.0 skips minor versions, upgrade to the latest 1.22 patch release first",
+		},
+		"downgrade": {
+			version:        version.MustNewVersionFromString("1.23.0"),
+			upgradeVersion: version.MustNewVersionFromString("1.22.4"),
+			expectedError:  "downgrading from 1.23.0 to 1.22.4 is not supported",
+		},
+		"patch downgrade": {
+			version:        version.MustNewVersionFromString("1.22.4"),
+			upgradeVersion: version.MustNewVersionFromString("1.22.3"),
+			expectedError:  "downgrading from 1.22.4 to 1.22.3 is not supported",
+		},
+		"major upgrade": {
+			version:        version.MustNewVersionFromString("1.23.0"),
+			upgradeVersion: version.MustNewVersionFromString("2.0.0"),
+			expectedError:  "upgrading from 1.23.0 to 2.0.0 changes the major version, which is not supported",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			err := test.version.CheckUpgrade(test.upgradeVersion)
+			if test.expectedError == "" {
+				assert.NoError(tt, err)
+			} else {
+				assert.EqualError(tt, err, test.expectedError)
+			}
+		})
+	}
+}
+
+func TestUpgradePath(t *testing.T) {
+	tests := map[string]struct {
+		version       *version.Version
+		target        *version.Version
+		expectedPath  []string
+		expectedError string
+	}{
+		"same version": {
+			version: version.MustNewVersionFromString("1.22.4"),
+			target:  version.MustNewVersionFromString("1.22.4"),
+		},
+		"next minor version": {
+			version:      version.MustNewVersionFromString("1.22.4"),
+			target:       version.MustNewVersionFromString("1.23.0"),
+			expectedPath: []string{"1.23.0"},
+		},
+		"several minor versions": {
+			version:      version.MustNewVersionFromString("1.19.0"),
+			target:       version.MustNewVersionFromString("1.23.0"),
+			expectedPath: []string{"1.20.4", "1.21.6", "1.22.7", "1.23.0"},
+		},
+		"downgrade": {
+			version:       version.MustNewVersionFromString("1.23.0"),
+			target:        version.MustNewVersionFromString("1.20.4"),
+			expectedError: "downgrading from 1.23.0 to 1.20.4 is not supported",
+		},
+		"unknown intermediate release": {
+			version:       version.MustNewVersionFromString("1.11.0"),
+			target:        version.MustNewVersionFromString("1.14.0"),
+			expectedError: "upgrading from 1.11.0 to 1.14.0 requires a 1.12 release, which is unknown: upgrade to a 1.12 release first",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			path, err := test.version.UpgradePath(test.target)
+			if test.expectedError != "" {
+				assert.EqualError(tt, err, test.expectedError)
+				return
+			}
+			require.NoError(tt, err)
+
+			versions := []string{}
+			for _, v := range path {
+				versions = append(versions, v.String())
+			}
+			if test.expectedPath == nil {
+				assert.Empty(tt, versions)
+			} else {
+				assert.Equal(tt, test.expectedPath, versions)
+			}
+		})
+	}
+}
+
+func TestLatestPatchRelease(t *testing.T) {
+	tests := map[string]struct {
+		version  *version.Version
+		expected string
+	}{
+		"older patch release": {
+			version:  version.MustNewVersionFromString("1.22.4"),
+			expected: "1.22.7",
+		},
+		"latest patch release": {
+			version:  version.MustNewVersionFromString("1.21.6"),
+			expected: "1.21.6",
+		},
+		"newer than known releases": {
+			version:  version.MustNewVersionFromString("1.20.9"),
+			expected: "1.20.9",
+		},
+		"unknown minor version": {
+			version:  version.MustNewVersionFromString("1.12.1"),
+			expected: "1.12.1",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			assert.Equal(tt, test.expected, test.version.LatestPatchRelease().String())
+		})
+	}
+}
@@ -291,6 +291,45 @@ func TestPersistence(t *testing.T) {
 				}
 			},
 		},
+		"cockroachdb persistence": {
+			upgradePath:        []string{},
+			deployDependencies: []deployDependencyFunc{deployAndWaitForCockroachDB},
+			cluster: func(ctx context.Context, cfg *envconf.Config, namespace string) *v1beta1.TemporalCluster {
+				connectAddr := fmt.Sprintf("cockroachdb.%s:26257", namespace) // create the temporal cluster
+
+				return &v1beta1.TemporalCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test",
+						Namespace: namespace,
+					},
+					Spec: v1beta1.TemporalClusterSpec{
+						NumHistoryShards:           1,
+						JobTTLSecondsAfterFinished: &jobTTL,
+						Version:                    version.MustNewVersionFromString(newDatastoreVersion),
+						Persistence: v1beta1.TemporalPersistenceSpec{
+							DefaultStore: &v1beta1.DatastoreSpec{
+								SQL: &v1beta1.SQLSpec{
+									User:            "root",
+									PluginName:      "cockroachdb",
+									DatabaseName:    "temporal",
+									ConnectAddr:     connectAddr,
+									ConnectProtocol: "tcp",
+								},
+							},
+							VisibilityStore: &v1beta1.DatastoreSpec{
+								SQL: &v1beta1.SQLSpec{
+									User:            "root",
+									PluginName:      "cockroachdb",
+									DatabaseName:    "temporal_visibility",
+									ConnectAddr:     connectAddr,
+									ConnectProtocol: "tcp",
+								},
+							},
+						},
+					},
+				}
+			},
+		},
 		"cassandra persistence": {
 			upgradePath:        defaultUpgradePath,
 			deployDependencies: []deployDependencyFunc{deployAndWaitForCassandra},
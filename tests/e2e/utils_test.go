@@ -124,6 +124,10 @@ func deployAndWaitForPostgres(ctx context.Context, cfg *envconf.Config, namespac
 	return deployAndWaitFor(ctx, cfg, "postgres", namespace)
 }
 
+func deployAndWaitForCockroachDB(ctx context.Context, cfg *envconf.Config, namespace string) error {
+	return deployAndWaitFor(ctx, cfg, "cockroachdb", namespace)
+}
+
 func deployAndWaitForElasticSearch(ctx context.Context, cfg *envconf.Config, namespace string) error {
 	err := deployTestManifest(ctx, cfg, "elasticsearch", namespace)
 	if err != nil {
@@ -21,15 +21,26 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"path"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"github.com/alexandrevilain/temporal-operator/internal/discovery"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/lifecycle"
+	"github.com/alexandrevilain/temporal-operator/pkg/maintenance"
+	configutil "github.com/alexandrevilain/temporal-operator/pkg/temporal/config"
 	"github.com/alexandrevilain/temporal-operator/pkg/version"
 	enumspb "go.temporal.io/api/enums/v1"
 	enumsspb "go.temporal.io/server/api/enums/v1"
+	"go.temporal.io/server/common/dynamicconfig"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 	"k8s.io/utils/strings/slices"
@@ -95,10 +106,26 @@ func (w *TemporalClusterWebhook) Default(_ context.Context, obj runtime.Object)
 	return nil
 }
 
+// hasCustomFrontendImage returns true if the frontend doesn't run the official temporal server image,
+// or the default one configured for the operator.
+func hasCustomFrontendImage(cluster *v1beta1.TemporalCluster) bool {
+	image := cluster.Spec.Image
+	if cluster.Spec.Services != nil && cluster.Spec.Services.Frontend != nil && cluster.Spec.Services.Frontend.Image != "" {
+		image = cluster.Spec.Services.Frontend.Image
+	}
+
+	return image != "" && image != v1beta1.DefaultTemporalImage && image != v1beta1.GetDefaultImages().Temporal
+}
+
 func (w *TemporalClusterWebhook) validateCluster(cluster *v1beta1.TemporalCluster) (admission.Warnings, field.ErrorList) {
 	var warns admission.Warnings
 	var errs field.ErrorList
 
+	// The number of history shards is required to be greater than 0 by the CRD schema.
+	if cluster.Spec.NumHistoryShards > 0 && cluster.Spec.NumHistoryShards&(cluster.Spec.NumHistoryShards-1) != 0 {
+		warns = append(warns, "spec.numHistoryShards is not a power of two, which is recommended: it can't be changed once the cluster is created")
+	}
+
 	// If mTLS is enabled using cert-manager, but cert-manager support is disabled on the controller
 	// it can't process the request, return the error.
 	if cluster.MTLSWithCertManagerEnabled() && !w.AvailableAPIs.CertManager {
@@ -111,6 +138,66 @@ func (w *TemporalClusterWebhook) validateCluster(cluster *v1beta1.TemporalCluste
 		)
 	}
 
+	// The HTTPRoute can't be created if the Gateway API is not installed in the cluster.
+	if cluster.Spec.UI != nil && cluster.Spec.UI.HTTPRoute != nil && !w.AvailableAPIs.GatewayAPI {
+		errs = append(errs,
+			field.Forbidden(
+				field.NewPath("spec", "ui", "httpRoute"),
+				"Can't create an HTTPRoute for the UI as the Gateway API is not available in the cluster",
+			),
+		)
+	}
+
+	if cluster.Spec.Services != nil && cluster.Spec.Services.Frontend != nil {
+		frontend := cluster.Spec.Services.Frontend
+		if frontend.GRPCRoute != nil && !w.AvailableAPIs.GRPCRoute {
+			errs = append(errs,
+				field.Forbidden(
+					field.NewPath("spec", "services", "frontend", "grpcRoute"),
+					"Can't create a GRPCRoute for the frontend as the Gateway API GRPCRoute is not available in the cluster",
+				),
+			)
+		}
+		if frontend.TLSRoute != nil && !w.AvailableAPIs.TLSRoute {
+			errs = append(errs,
+				field.Forbidden(
+					field.NewPath("spec", "services", "frontend", "tlsRoute"),
+					"Can't create a TLSRoute for the frontend as the Gateway API TLSRoute is not available in the cluster",
+				),
+			)
+		}
+		// TLSRoutes pass TLS traffic through, the frontend has to terminate it.
+		if frontend.TLSRoute != nil && (cluster.Spec.MTLS == nil || !cluster.Spec.MTLS.FrontendEnabled()) {
+			errs = append(errs,
+				field.Forbidden(
+					field.NewPath("spec", "services", "frontend", "tlsRoute"),
+					"A TLSRoute requires frontend mTLS to be enabled",
+				),
+			)
+		}
+		if frontend.GRPCRoute != nil && cluster.Spec.MTLS != nil && cluster.Spec.MTLS.FrontendEnabled() {
+			warns = append(warns, "spec.services.frontend.grpcRoute is set with frontend mTLS enabled: the Gateway must connect to the frontend using TLS, consider using spec.services.frontend.tlsRoute")
+		}
+	}
+
+	// OpenShift Routes can only be created when running on OpenShift.
+	if cluster.Spec.UI != nil && cluster.Spec.UI.Route != nil && !w.AvailableAPIs.OpenShiftRoute {
+		errs = append(errs,
+			field.Forbidden(
+				field.NewPath("spec", "ui", "route"),
+				"Can't create a Route for the UI as the OpenShift Route API is not available in the cluster",
+			),
+		)
+	}
+	if cluster.Spec.Services != nil && cluster.Spec.Services.Frontend != nil && cluster.Spec.Services.Frontend.Route != nil && !w.AvailableAPIs.OpenShiftRoute {
+		errs = append(errs,
+			field.Forbidden(
+				field.NewPath("spec", "services", "frontend", "route"),
+				"Can't create a Route for the frontend as the OpenShift Route API is not available in the cluster",
+			),
+		)
+	}
+
 	mTLSWarnings, mTLSErrors := cluster.Spec.MTLS.Validate()
 	warns = append(warns, mTLSWarnings...)
 	errs = append(errs, mTLSErrors...)
@@ -139,6 +226,38 @@ func (w *TemporalClusterWebhook) validateCluster(cluster *v1beta1.TemporalCluste
 		)
 	}
 
+	// Ensure datastores are configured and supported by the store they are used for.
+	stores := cluster.Spec.Persistence.GetDatastoresMap()
+	for _, name := range []string{"defaultStore", "visibilityStore", "secondaryVisibilityStore", "advancedVisibilityStore"} {
+		if store := stores[name]; store != nil && store.GetType() == v1beta1.UnknownDatastore {
+			errs = append(errs,
+				field.Required(
+					field.NewPath("spec", "persistence", name),
+					"one of sql, cassandra or elasticsearch must be set",
+				),
+			)
+		}
+	}
+
+	if store := cluster.Spec.Persistence.DefaultStore; store != nil && store.GetType() == v1beta1.ElasticsearchDatastore {
+		errs = append(errs,
+			field.Forbidden(
+				field.NewPath("spec", "persistence", "defaultStore", "elasticsearch"),
+				"Elasticsearch can only be used as a visibility store",
+			),
+		)
+	}
+
+	if store := cluster.Spec.Persistence.VisibilityStore; store != nil && store.GetType() == v1beta1.ElasticsearchDatastore &&
+		!cluster.Spec.Version.GreaterOrEqual(version.V1_21_0) {
+		errs = append(errs,
+			field.Forbidden(
+				field.NewPath("spec", "persistence", "visibilityStore", "elasticsearch"),
+				"temporal cluster version < 1.21.0 only supports Elasticsearch as an advanced visibility store",
+			),
+		)
+	}
+
 	// Ensure dynamicconfig is valid.
 	if cluster.Spec.DynamicConfig != nil {
 		for key, constrainedValues := range cluster.Spec.DynamicConfig.Values {
@@ -185,6 +304,136 @@ func (w *TemporalClusterWebhook) validateCluster(cluster *v1beta1.TemporalCluste
 		}
 	}
 
+	// Ensure dynamic config keys managed using typed fields are not set.
+	if cluster.Spec.DynamicConfig != nil {
+		managedKeys := map[string]string{}
+		if cluster.Spec.Services != nil && cluster.Spec.Services.Frontend != nil {
+			for key := range configutil.FrontendLimitsValues(cluster.Spec.Services.Frontend.Limits) {
+				managedKeys[key] = "spec.services.frontend.limits"
+			}
+		}
+		for key := range configutil.PersistenceLimitsValues(cluster.Spec.Persistence.Limits) {
+			managedKeys[key] = "spec.persistence.limits"
+		}
+
+		keys := []string{}
+		for key := range managedKeys {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if _, ok := cluster.Spec.DynamicConfig.Values[key]; ok {
+				errs = append(errs,
+					field.Forbidden(
+						field.NewPath("spec", "dynamicConfig", "values", key),
+						fmt.Sprintf("this key is managed using %s", managedKeys[key]),
+					),
+				)
+			}
+		}
+	}
+
+	if cluster.Spec.PublicClient != nil {
+		if cluster.Spec.Services != nil && cluster.Spec.Services.InternalFrontend.IsEnabled() {
+			errs = append(errs,
+				field.Forbidden(
+					field.NewPath("spec", "publicClient"),
+					"publicClient can't be set when the internal frontend is enabled",
+				),
+			)
+		}
+
+		if _, _, err := net.SplitHostPort(cluster.Spec.PublicClient.HostPort); err != nil {
+			errs = append(errs, field.Invalid(field.NewPath("spec", "publicClient", "hostPort"), cluster.Spec.PublicClient.HostPort, "must be a host:port address"))
+		}
+	}
+
+	if cluster.Spec.UpgradeStrategy != nil && cluster.Spec.UpgradeStrategy.Canary != nil {
+		var frontend *v1beta1.ServiceSpec
+		if cluster.Spec.Services != nil {
+			frontend = cluster.Spec.Services.Frontend
+		}
+
+		if frontend.IsStatefulSet() {
+			errs = append(errs,
+				field.Forbidden(
+					field.NewPath("spec", "upgradeStrategy", "canary"),
+					"canary upgrades are not supported when the frontend runs as a StatefulSet",
+				),
+			)
+		}
+
+		// Workloads kept on the current version during the canary would still pull the new binary by digest.
+		if cluster.ImageDigestsPinned() {
+			errs = append(errs,
+				field.Forbidden(
+					field.NewPath("spec", "upgradeStrategy", "canary"),
+					"canary upgrades are not supported when images are pinned to a digest",
+				),
+			)
+		}
+
+		if cluster.Spec.UpgradeStrategy.Canary.MaxErrorRatePercent != nil && cluster.ServiceMetricsPort(frontend) == nil {
+			errs = append(errs,
+				field.Forbidden(
+					field.NewPath("spec", "upgradeStrategy", "canary", "maxErrorRatePercent"),
+					"maxErrorRatePercent requires prometheus metrics to be enabled on the frontend",
+				),
+			)
+		}
+	}
+
+	if cluster.Spec.Adoption != nil && cluster.GetName() != cluster.Spec.Adoption.HelmFullname() {
+		errs = append(errs,
+			field.Forbidden(
+				field.NewPath("spec", "adoption", "helmRelease"),
+				fmt.Sprintf("the cluster must be named %q to adopt the resources of the %q helm release", cluster.Spec.Adoption.HelmFullname(), cluster.Spec.Adoption.HelmRelease),
+			),
+		)
+	}
+
+	// Rolled back services run the previous version, but would still pull the new binary by digest.
+	if cluster.Spec.UpgradeStrategy != nil && cluster.Spec.UpgradeStrategy.AutoRollback != nil && cluster.ImageDigestsPinned() {
+		errs = append(errs,
+			field.Forbidden(
+				field.NewPath("spec", "upgradeStrategy", "autoRollback"),
+				"automatic rollbacks are not supported when images are pinned to a digest",
+			),
+		)
+	}
+
+	if cluster.Spec.VersionPolicy == v1beta1.PinnedMinorVersionPolicy && cluster.ImageDigestsPinned() {
+		errs = append(errs,
+			field.Forbidden(
+				field.NewPath("spec", "versionPolicy"),
+				"the PinnedMinor version policy is not supported when images are pinned to a digest",
+			),
+		)
+	}
+
+	if cluster.Spec.MaintenanceWindow != nil {
+		if _, err := maintenance.NewWindow(cluster.Spec.MaintenanceWindow); err != nil {
+			errs = append(errs, field.Invalid(field.NewPath("spec", "maintenanceWindow"), cluster.Spec.MaintenanceWindow.Schedule, err.Error()))
+		}
+	}
+
+	if cluster.Spec.Lifecycle != nil {
+		hookNames := map[string]bool{}
+		for i := range cluster.Spec.Lifecycle.PreUpgrade {
+			hook := &cluster.Spec.Lifecycle.PreUpgrade[i]
+			hookPath := field.NewPath("spec", "lifecycle", "preUpgrade").Index(i)
+			if hookNames[hook.Name] {
+				errs = append(errs, field.Duplicate(hookPath.Child("name"), hook.Name))
+			}
+			hookNames[hook.Name] = true
+
+			if _, err := lifecycle.PodSpec(hook); err != nil {
+				errs = append(errs, field.Invalid(hookPath.Child("template", "spec"), field.OmitValueType{}, err.Error()))
+			}
+		}
+	}
+
 	// validate archival
 	if cluster.Spec.Archival.IsEnabled() {
 		if cluster.Spec.Archival.Provider == nil || cluster.Spec.Archival.Provider.Kind() == v1beta1.UnknownArchivalProviderKind {
@@ -194,20 +443,269 @@ func (w *TemporalClusterWebhook) validateCluster(cluster *v1beta1.TemporalCluste
 					"Please provide an archival provider or disable cluster archival",
 				),
 			)
-		}
-
-		if cluster.Spec.Archival.Provider.Kind() == v1beta1.S3ArchivalProviderKind {
-			if cluster.Spec.Archival.Provider.S3.RoleName == nil && cluster.Spec.Archival.Provider.S3.Credentials == nil {
+		} else if cluster.Spec.Archival.Provider.Kind() == v1beta1.S3ArchivalProviderKind {
+			s3 := cluster.Spec.Archival.Provider.S3
+			// IRSA can also be configured on the history service account directly.
+			var historyServiceAccount *v1beta1.ServiceAccountSpec
+			if cluster.Spec.Services != nil && cluster.Spec.Services.History != nil {
+				historyServiceAccount = cluster.Spec.Services.History.ServiceAccount
+			}
+			if s3.RoleName == nil && s3.Credentials == nil && historyServiceAccount == nil {
 				errs = append(errs,
 					field.Forbidden(
 						field.NewPath("spec", "archival", "provider", "s3"),
-						"Please provide s3 role name if using EKS or s3 credentials for s3 provider (spec.archival.provider.s3.roleName or spec.archival.provider.s3.credentials)",
+						"Please provide s3 role name if using EKS, s3 credentials or a history service account for s3 provider (spec.archival.provider.s3.roleName, spec.archival.provider.s3.credentials or spec.services.history.serviceAccount)",
+					),
+				)
+			}
+		}
+
+		if cluster.Spec.Archival.History != nil && cluster.Spec.Archival.History.Path == "" {
+			errs = append(errs,
+				field.Required(
+					field.NewPath("spec", "archival", "history", "path"),
+					"Please provide the default history archival path",
+				),
+			)
+		}
+
+		if cluster.Spec.Archival.Visibility != nil && cluster.Spec.Archival.Visibility.Path == "" {
+			errs = append(errs,
+				field.Required(
+					field.NewPath("spec", "archival", "visibility", "path"),
+					"Please provide the default visibility archival path",
+				),
+			)
+		}
+	}
+
+	// GCP service accounts are all bound using the same service account annotation, they can't differ.
+	type gcpServiceAccount struct {
+		path  *field.Path
+		value *string
+	}
+	gcpServiceAccounts := []gcpServiceAccount{}
+	if cluster.Spec.Archival.IsEnabled() && cluster.Spec.Archival.Provider != nil && cluster.Spec.Archival.Provider.GCS != nil {
+		gcpServiceAccounts = append(gcpServiceAccounts, gcpServiceAccount{
+			path:  field.NewPath("spec", "archival", "provider", "gcs", "serviceAccount"),
+			value: cluster.Spec.Archival.Provider.GCS.ServiceAccount,
+		})
+	}
+	for _, name := range []string{"defaultStore", "visibilityStore", "secondaryVisibilityStore", "advancedVisibilityStore"} {
+		if store := stores[name]; store != nil && store.SQL != nil {
+			gcpServiceAccounts = append(gcpServiceAccounts, gcpServiceAccount{
+				path:  field.NewPath("spec", "persistence", name, "sql", "gcpServiceAccount"),
+				value: store.SQL.GCPServiceAccount,
+			})
+		}
+	}
+	var boundGCPServiceAccount *gcpServiceAccount
+	for i, account := range gcpServiceAccounts {
+		if account.value == nil {
+			continue
+		}
+		if boundGCPServiceAccount == nil {
+			boundGCPServiceAccount = &gcpServiceAccounts[i]
+			continue
+		}
+		if *account.value != *boundGCPServiceAccount.value {
+			errs = append(errs,
+				field.Invalid(
+					account.path,
+					*account.value,
+					fmt.Sprintf("conflicts with %s: a single GCP service account can be bound to the cluster's service accounts", boundGCPServiceAccount.path),
+				),
+			)
+		}
+	}
+
+	// validate authorization
+	if cluster.Spec.Authorization != nil {
+		if cluster.Spec.Authorization.ClaimMapper == v1beta1.DefaultAuthorization && len(cluster.Spec.Authorization.JWTKeyProvider.KeySourceURIs) == 0 {
+			errs = append(errs,
+				field.Required(
+					field.NewPath("spec", "authorization", "jwtKeyProvider", "keySourceURIs"),
+					"Please provide at least one key source URI when using the default claim mapper",
+				),
+			)
+		}
+
+		implementations := []struct{ name, value string }{
+			{"authorizer", cluster.Spec.Authorization.Authorizer},
+			{"claimMapper", cluster.Spec.Authorization.ClaimMapper},
+		}
+		for _, implementation := range implementations {
+			if !cluster.Spec.Authorization.IsBuiltin(implementation.value) && !hasCustomFrontendImage(cluster) {
+				errs = append(errs,
+					field.Invalid(
+						field.NewPath("spec", "authorization", implementation.name),
+						implementation.value,
+						"Custom implementations require a temporal server image providing them, please set spec.image or spec.services.frontend.image",
+					),
+				)
+			}
+		}
+
+		if cluster.Spec.Authorization.Authorizer == v1beta1.DefaultAuthorization && (cluster.Spec.Services == nil || !cluster.Spec.Services.InternalFrontend.IsEnabled()) {
+			warns = append(warns, "spec.authorization.authorizer is set to default without internal frontend: the system worker calls to the frontend may be denied, consider enabling spec.services.internalFrontend")
+		}
+	}
+
+	// The UI serves plain HTTP, so the router has to terminate TLS.
+	if cluster.Spec.UI != nil && cluster.Spec.UI.Route != nil {
+		termination := cluster.Spec.UI.Route.Termination
+		if termination != "" && termination != v1beta1.EdgeRouteTermination {
+			errs = append(errs,
+				field.NotSupported(
+					field.NewPath("spec", "ui", "route", "termination"),
+					termination,
+					[]string{string(v1beta1.EdgeRouteTermination)},
+				),
+			)
+		}
+	}
+
+	// The frontend serves TLS only when frontend mTLS is enabled.
+	if cluster.Spec.Services != nil && cluster.Spec.Services.Frontend != nil && cluster.Spec.Services.Frontend.Route != nil {
+		termination := cluster.Spec.Services.Frontend.Route.Termination
+		frontendTLS := cluster.Spec.MTLS != nil && cluster.Spec.MTLS.FrontendEnabled()
+		switch {
+		case frontendTLS && termination == v1beta1.EdgeRouteTermination:
+			errs = append(errs,
+				field.Invalid(
+					field.NewPath("spec", "services", "frontend", "route", "termination"),
+					termination,
+					"edge termination can't be used when frontend mTLS is enabled, use passthrough or reencrypt",
+				),
+			)
+		case !frontendTLS && termination != "" && termination != v1beta1.EdgeRouteTermination:
+			errs = append(errs,
+				field.Invalid(
+					field.NewPath("spec", "services", "frontend", "route", "termination"),
+					termination,
+					"passthrough and reencrypt terminations require frontend mTLS to be enabled",
+				),
+			)
+		}
+	}
+
+	if cluster.Spec.UI != nil && cluster.Spec.UI.ExternalHostname != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(cluster.Spec.UI.ExternalHostname) {
+			errs = append(errs,
+				field.Invalid(
+					field.NewPath("spec", "ui", "externalHostname"),
+					cluster.Spec.UI.ExternalHostname,
+					msg,
+				),
+			)
+		}
+	}
+
+	// validate ui public path
+	if cluster.Spec.UI != nil && cluster.Spec.UI.PublicPath != "" {
+		publicPath := cluster.Spec.UI.PublicPath
+		if cluster.Spec.UI.Ingress != nil && cluster.Spec.UI.Ingress.Path != "" && cluster.Spec.UI.Ingress.Path != publicPath {
+			errs = append(errs,
+				field.Invalid(
+					field.NewPath("spec", "ui", "ingress", "path"),
+					cluster.Spec.UI.Ingress.Path,
+					"ingress path must match spec.ui.publicPath",
+				),
+			)
+		}
+		if cluster.Spec.UI.HTTPRoute != nil && cluster.Spec.UI.HTTPRoute.Path != "" && cluster.Spec.UI.HTTPRoute.Path != publicPath {
+			errs = append(errs,
+				field.Invalid(
+					field.NewPath("spec", "ui", "httpRoute", "path"),
+					cluster.Spec.UI.HTTPRoute.Path,
+					"httpRoute path must match spec.ui.publicPath",
+				),
+			)
+		}
+	}
+
+	if cluster.Spec.UI != nil && cluster.Spec.UI.ReadOnly && len(cluster.Spec.UI.DisabledActions) > 0 {
+		warns = append(warns, "spec.ui.disabledActions is ignored as spec.ui.readOnly disables all write actions")
+	}
+
+	// validate ui autoscaling
+	if cluster.Spec.UI != nil && cluster.Spec.UI.Autoscaling.IsEnabled() {
+		autoscaling := cluster.Spec.UI.Autoscaling
+		if autoscaling.MinReplicas != nil && *autoscaling.MinReplicas > autoscaling.MaxReplicas {
+			errs = append(errs,
+				field.Invalid(
+					field.NewPath("spec", "ui", "autoscaling", "maxReplicas"),
+					autoscaling.MaxReplicas,
+					"maxReplicas must be greater than or equal to minReplicas",
+				),
+			)
+		}
+	}
+
+	// validate ui configuration
+	if cluster.Spec.UI != nil {
+		keys := make([]string, 0, len(cluster.Spec.UI.Config))
+		for key := range cluster.Spec.UI.Config {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			for _, msg := range validation.IsEnvVarName(key) {
+				errs = append(errs,
+					field.Invalid(
+						field.NewPath("spec", "ui", "config").Key(key),
+						key,
+						msg,
 					),
 				)
 			}
 		}
 	}
 
+	// validate ui authentication
+	if cluster.Spec.UI != nil && cluster.Spec.UI.Auth.IsEnabled() {
+		auth := cluster.Spec.UI.Auth
+		if auth.ClientID == "" && auth.ClientIDSecretRef == nil {
+			errs = append(errs,
+				field.Required(
+					field.NewPath("spec", "ui", "auth", "clientID"),
+					"Please provide the OIDC client ID using clientID or clientIDSecretRef",
+				),
+			)
+		}
+
+		if auth.CallbackURL == "" && cluster.Spec.UI.PublicURL() == "" {
+			errs = append(errs,
+				field.Required(
+					field.NewPath("spec", "ui", "auth", "callbackURL"),
+					"Please provide the callback URL, it can't be guessed as the UI has no ingress or HTTPRoute host",
+				),
+			)
+		}
+	}
+
+	// validate codec server
+	if codecServer := cluster.CodecServer(); codecServer.IsEnabled() {
+		if codecServer.Image == "" {
+			errs = append(errs,
+				field.Required(
+					field.NewPath("spec", "ui", "codecServer", "image"),
+					"Please provide the codec server image",
+				),
+			)
+		}
+
+		if codecServer.MTLS && (!cluster.MTLSWithCertManagerEnabled() || !cluster.Spec.MTLS.FrontendEnabled()) {
+			errs = append(errs,
+				field.Forbidden(
+					field.NewPath("spec", "ui", "codecServer", "mTLS"),
+					"codec server mTLS requires frontend mTLS using cert-manager",
+				),
+			)
+		}
+	}
+
 	// Check that the user-specified version is not marked as broken.
 	for _, version := range version.ForbiddenBrokenReleases {
 		if cluster.Spec.Version.Equal(version.Version) {
@@ -232,8 +730,8 @@ func (w *TemporalClusterWebhook) validateCluster(cluster *v1beta1.TemporalCluste
 			)
 		}
 
-		// Ensure mysql8 and postgres12 plugins are only used for cluster version >= 1.20
-		newStores := []string{string(v1beta1.PostgresSQL12Datastore), string(v1beta1.MySQL8Datastore)}
+		// Ensure mysql8, postgres12 and cockroachdb plugins are only used for cluster version >= 1.20
+		newStores := []string{string(v1beta1.PostgresSQL12Datastore), string(v1beta1.MySQL8Datastore), string(v1beta1.CockroachDBDatastore)}
 		for name, store := range cluster.Spec.Persistence.GetDatastoresMap() {
 			if store != nil && store.SQL != nil && slices.Contains(newStores, store.SQL.PluginName) {
 				errs = append(errs,
@@ -282,19 +780,328 @@ func (w *TemporalClusterWebhook) validateCluster(cluster *v1beta1.TemporalCluste
 		}
 	}
 
-	// Check for per unit histogram boundaries if metrics is enabled
-	if cluster.Spec.Metrics.IsEnabled() && cluster.Spec.Metrics.PerUnitHistogramBoundaries != nil {
-		p := cluster.Spec.Metrics.PerUnitHistogramBoundaries
-		for _, value := range p {
-			for _, str := range value {
-				_, err := strconv.ParseFloat(str, 64)
-				if err != nil {
-					errs = append(errs,
-						field.Forbidden(
-							field.NewPath("spec", "metrics", "perUnitHistogramBoundaries"),
-							fmt.Sprintf("can't parse this strings value to float64: %s ", str),
-						),
-					)
+	if cluster.Spec.Services != nil {
+		specs := cluster.Spec.Services.GetServiceSpecsMap()
+		for _, name := range serviceNames {
+			spec, ok := specs[name]
+			if !ok {
+				continue
+			}
+
+			// Ensure services using the "None" DNS policy provide their own DNS configuration.
+			if spec.DNSPolicy == corev1.DNSNone && spec.DNSConfig == nil {
+				errs = append(errs,
+					field.Required(
+						field.NewPath("spec", "services", name, "dnsConfig"),
+						"dnsConfig is required when dnsPolicy is set to None",
+					),
+				)
+			}
+
+			if (spec.GRPCRoute != nil || spec.TLSRoute != nil) && name != "frontend" {
+				errs = append(errs,
+					field.Forbidden(
+						field.NewPath("spec", "services", name),
+						"only the frontend service can be exposed using a GRPCRoute or a TLSRoute",
+					),
+				)
+			}
+
+			if spec.Limits != nil && name != "frontend" {
+				errs = append(errs,
+					field.Forbidden(
+						field.NewPath("spec", "services", name, "limits"),
+						"only the frontend service supports limits",
+					),
+				)
+			}
+
+			if spec.Route != nil && name != "frontend" {
+				errs = append(errs,
+					field.Forbidden(
+						field.NewPath("spec", "services", name, "route"),
+						"only the frontend service can be exposed using a Route",
+					),
+				)
+			}
+
+			if spec.ExternalHostname != "" {
+				hostnamePath := field.NewPath("spec", "services", name, "externalHostname")
+				if name != "frontend" {
+					errs = append(errs, field.Forbidden(hostnamePath, "only the frontend service can have an external hostname"))
+				}
+				for _, msg := range validation.IsDNS1123Subdomain(spec.ExternalHostname) {
+					errs = append(errs, field.Invalid(hostnamePath, spec.ExternalHostname, msg))
+				}
+			}
+
+			if spec.Service != nil {
+				errs = append(errs, validateKubernetesService(field.NewPath("spec", "services", name, "service"), name, spec.Service)...)
+			}
+
+			// Ensure only services benefiting from stable identities run as StatefulSets.
+			if spec.IsStatefulSet() && name != "history" && name != "matching" {
+				errs = append(errs,
+					field.Forbidden(
+						field.NewPath("spec", "services", name, "workloadType"),
+						"only history and matching services can run as a StatefulSet",
+					),
+				)
+			}
+
+			// StatefulSets have no equivalent of the Recreate strategy nor of maxSurge.
+			if spec.IsStatefulSet() && spec.Strategy != nil {
+				errs = append(errs,
+					field.Forbidden(
+						field.NewPath("spec", "services", name, "strategy"),
+						"strategy can't be set on services running as a StatefulSet",
+					),
+				)
+			}
+		}
+	}
+
+	// Temporal only uses one reporter, statsd taking precedence over prometheus.
+	if m := cluster.Spec.Metrics; m != nil && m.Statsd != nil {
+		statsdPath := field.NewPath("spec", "metrics", "statsd")
+		if m.Prometheus != nil {
+			errs = append(errs, field.Forbidden(statsdPath, "statsd and prometheus reporters can't be used at the same time"))
+		}
+		if _, _, err := net.SplitHostPort(m.Statsd.Address); err != nil {
+			errs = append(errs, field.Invalid(statsdPath.Child("address"), m.Statsd.Address, "must be a host:port address"))
+		}
+	}
+
+	if m := cluster.Spec.Metrics; m != nil {
+		tagsPath := field.NewPath("spec", "metrics", "tags")
+		for key := range m.Tags {
+			if key == "type" {
+				errs = append(errs, field.Forbidden(tagsPath.Key(key), "the type tag is set by the operator to the service name"))
+				continue
+			}
+			if !metricTagKeyRegexp.MatchString(key) {
+				errs = append(errs, field.Invalid(tagsPath.Key(key), key, "must be a valid metric label name"))
+			}
+		}
+	}
+
+	// Ensure metrics aren't scraped twice.
+	if m := cluster.Spec.Metrics; m != nil && m.Prometheus != nil && m.Prometheus.ScrapeConfig != nil {
+		scrapeConfig := m.Prometheus.ScrapeConfig
+		if scrapeConfig.PodMonitor != nil && scrapeConfig.PodMonitor.Enabled &&
+			scrapeConfig.ServiceMonitor != nil && scrapeConfig.ServiceMonitor.Enabled {
+			errs = append(errs,
+				field.Forbidden(
+					field.NewPath("spec", "metrics", "prometheus", "scrapeConfig", "podMonitor", "enabled"),
+					"podMonitor and serviceMonitor can't be enabled at the same time",
+				),
+			)
+		}
+	}
+
+	if cluster.Spec.Telemetry.TracingEnabled() {
+		errs = append(errs, validateTracing(field.NewPath("spec", "telemetry", "tracing"), cluster.Spec.Telemetry.Tracing)...)
+	}
+
+	if cluster.Spec.Network != nil {
+		errs = append(errs, validateNetwork(field.NewPath("spec", "network"), cluster.Spec.Network)...)
+	}
+
+	if cluster.Spec.Services != nil {
+		errs = append(errs, validatePorts(field.NewPath("spec", "services"), cluster)...)
+	}
+
+	if metadata := cluster.GetClusterMetadata(); metadata.InitialFailoverVersion > metadata.FailoverVersionIncrement {
+		errs = append(errs,
+			field.Invalid(
+				field.NewPath("spec", "clusterMetadata", "initialFailoverVersion"),
+				metadata.InitialFailoverVersion,
+				"must be lower than or equal to failoverVersionIncrement",
+			),
+		)
+	}
+
+	if cluster.Spec.Replication != nil {
+		errs = append(errs, validateReplication(field.NewPath("spec", "replication"), cluster)...)
+	}
+
+	defaultNamespaces := map[string]bool{}
+	for i, namespace := range cluster.Spec.DefaultNamespaces {
+		if defaultNamespaces[namespace.Name] {
+			errs = append(errs, field.Duplicate(field.NewPath("spec", "defaultNamespaces").Index(i).Child("name"), namespace.Name))
+		}
+		defaultNamespaces[namespace.Name] = true
+	}
+
+	// Ensure visibility migration settings are consistent.
+	if migration := cluster.Spec.Persistence.VisibilityMigration; migration != nil {
+		if cluster.Spec.Persistence.SecondaryVisibilityStore == nil {
+			errs = append(errs,
+				field.Required(
+					field.NewPath("spec", "persistence", "secondaryVisibilityStore"),
+					"secondaryVisibilityStore is required when visibilityMigration is set",
+				),
+			)
+		}
+
+		if migration.ReadFromSecondary && migration.WritingMode == v1beta1.SecondaryVisibilityWritingModeOff {
+			errs = append(errs,
+				field.Forbidden(
+					field.NewPath("spec", "persistence", "visibilityMigration", "readFromSecondary"),
+					"can't read from secondary visibility store when writing to it is off",
+				),
+			)
+		}
+
+		if cluster.Spec.DynamicConfig != nil {
+			for _, key := range []string{dynamicconfig.SecondaryVisibilityWritingMode, dynamicconfig.EnableReadFromSecondaryVisibility} {
+				if _, ok := cluster.Spec.DynamicConfig.Values[key]; ok {
+					errs = append(errs,
+						field.Forbidden(
+							field.NewPath("spec", "dynamicConfig", "values", key),
+							"this key is managed using spec.persistence.visibilityMigration",
+						),
+					)
+				}
+			}
+		}
+	}
+
+	// Ensure SQL datastores connection pools are consistent.
+	for name, store := range cluster.Spec.Persistence.GetDatastoresMap() {
+		if store == nil || store.SQL == nil {
+			continue
+		}
+
+		if store.SQL.MaxConns > 0 && store.SQL.MaxIdleConns > store.SQL.MaxConns {
+			errs = append(errs,
+				field.Invalid(
+					field.NewPath("spec", "persistence", name, "sql", "maxIdleConns"),
+					store.SQL.MaxIdleConns,
+					"maxIdleConns can't be greater than maxConns",
+				),
+			)
+		}
+
+		if store.SQL.MaxConnLifetime.Duration < 0 {
+			errs = append(errs,
+				field.Invalid(
+					field.NewPath("spec", "persistence", name, "sql", "maxConnLifetime"),
+					store.SQL.MaxConnLifetime.Duration.String(),
+					"maxConnLifetime can't be negative",
+				),
+			)
+		}
+	}
+
+	// Ensure datastores credentials files are absolute paths which can be safely used in a shell.
+	for name, store := range cluster.Spec.Persistence.GetDatastoresMap() {
+		if store == nil || store.CredentialsFile == "" {
+			continue
+		}
+
+		if !path.IsAbs(store.CredentialsFile) || strings.ContainsAny(store.CredentialsFile, `'"`) {
+			errs = append(errs,
+				field.Invalid(
+					field.NewPath("spec", "persistence", name, "credentialsFile"),
+					store.CredentialsFile,
+					"credentialsFile must be an absolute path without quotes",
+				),
+			)
+		}
+	}
+
+	// Ensure datastores TLS references are consistent.
+	for name, store := range cluster.Spec.Persistence.GetDatastoresMap() {
+		if store == nil || store.TLS == nil || !store.TLS.Enabled {
+			continue
+		}
+
+		if store.TLS.CaFileRef != nil && store.TLS.CaConfigMapRef != nil {
+			errs = append(errs,
+				field.Forbidden(
+					field.NewPath("spec", "persistence", name, "tls", "caConfigMapRef"),
+					"caFileRef and caConfigMapRef can't be used together",
+				),
+			)
+		}
+
+		if store.TLS.CertFileRef != nil && store.TLS.KeyFileRef == nil {
+			errs = append(errs,
+				field.Required(
+					field.NewPath("spec", "persistence", name, "tls", "keyFileRef"),
+					"keyFileRef is required when certFileRef is set",
+				),
+			)
+		}
+
+		if store.TLS.KeyFileRef != nil && store.TLS.CertFileRef == nil {
+			errs = append(errs,
+				field.Required(
+					field.NewPath("spec", "persistence", name, "tls", "certFileRef"),
+					"certFileRef is required when keyFileRef is set",
+				),
+			)
+		}
+	}
+
+	// Ensure dev mode settings are consistent and sqlite is only used in dev mode.
+	if cluster.Spec.DevMode.IsEnabled() {
+		if cluster.Spec.Services != nil && cluster.Spec.Services.InternalFrontend.IsEnabled() {
+			errs = append(errs,
+				field.Forbidden(
+					field.NewPath("spec", "services", "internalFrontend", "enabled"),
+					"internal frontend is not supported in dev mode",
+				),
+			)
+		}
+
+		if cluster.Spec.Services != nil && cluster.Spec.Services.Frontend != nil &&
+			cluster.Spec.Services.Frontend.Replicas != nil && *cluster.Spec.Services.Frontend.Replicas > 1 {
+			errs = append(errs,
+				field.Forbidden(
+					field.NewPath("spec", "services", "frontend", "replicas"),
+					"dev mode only supports a single frontend replica",
+				),
+			)
+		}
+
+		for name, store := range cluster.Spec.Persistence.GetDatastoresMap() {
+			if store != nil && store.GetType() != v1beta1.SQLiteDatastore {
+				errs = append(errs,
+					field.Forbidden(
+						field.NewPath("spec", "persistence", name),
+						"dev mode only supports sqlite datastores",
+					),
+				)
+			}
+		}
+	} else {
+		for name, store := range cluster.Spec.Persistence.GetDatastoresMap() {
+			if store != nil && store.GetType() == v1beta1.SQLiteDatastore {
+				errs = append(errs,
+					field.Forbidden(
+						field.NewPath("spec", "persistence", name, "sql", "pluginName"),
+						"sqlite plugin is only supported in dev mode",
+					),
+				)
+			}
+		}
+	}
+
+	// Check for per unit histogram boundaries if metrics is enabled
+	if cluster.Spec.Metrics.IsEnabled() && cluster.Spec.Metrics.PerUnitHistogramBoundaries != nil {
+		p := cluster.Spec.Metrics.PerUnitHistogramBoundaries
+		for _, value := range p {
+			for _, str := range value {
+				_, err := strconv.ParseFloat(str, 64)
+				if err != nil {
+					errs = append(errs,
+						field.Forbidden(
+							field.NewPath("spec", "metrics", "perUnitHistogramBoundaries"),
+							fmt.Sprintf("can't parse this strings value to float64: %s ", str),
+						),
+					)
 				}
 			}
 		}
@@ -315,6 +1122,15 @@ func (w *TemporalClusterWebhook) ValidateCreate(_ context.Context, obj runtime.O
 	return warns, w.aggregateClusterErrors(cluster, errs)
 }
 
+// metricTagKeyRegexp matches valid prometheus label names.
+var metricTagKeyRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// allowedPluginUpgrades lists the SQL plugins which can be switched to a newer driver.
+var allowedPluginUpgrades = map[string]string{
+	string(v1beta1.PostgresSQLDatastore): string(v1beta1.PostgresSQL12Datastore),
+	string(v1beta1.MySQLDatastore):       string(v1beta1.MySQL8Datastore),
+}
+
 // ValidateUpdate validates TemporalCluster updates.
 // It mainly check for sequential version upgrades.
 func (w *TemporalClusterWebhook) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
@@ -330,19 +1146,78 @@ func (w *TemporalClusterWebhook) ValidateUpdate(_ context.Context, oldObj, newOb
 
 	warns, errs := w.validateCluster(newCluster)
 
-	// Ensure user is doing a sequential version upgrade.
+	// Ensure user is doing a supported version upgrade. Upgrades skipping minor versions
+	// are done by the operator, one minor version at a time.
 	// See: https://docs.temporal.io/cluster-deployment-guide#upgrade-server
-	constraint, err := oldCluster.Spec.Version.UpgradeConstraint()
+	upgradePath, err := oldCluster.Spec.Version.UpgradePath(newCluster.Spec.Version)
 	if err != nil {
-		return nil, fmt.Errorf("can't compute version upgrade constraint: %w", err)
+		errs = append(errs,
+			field.Forbidden(
+				field.NewPath("spec", "version"),
+				fmt.Sprintf("Unauthorized version upgrade: %s", err),
+			),
+		)
+	} else if len(upgradePath) > 1 && newCluster.ImageDigestsPinned() {
+		errs = append(errs,
+			field.Forbidden(
+				field.NewPath("spec", "version"),
+				"Upgrades skipping minor versions are not supported when images are pinned to a digest, upgrade one minor version at a time",
+			),
+		)
+	}
+
+	errs = append(errs, validateImageDigestsUpdate(oldCluster, newCluster)...)
+
+	// Ensure SQL plugins of existing databases are only switched to the newer driver of the same database engine,
+	// as temporal-sql-tool only supports upgrading schemas from postgres to postgres12 and from mysql to mysql8.
+	// Pointing a store to another database (e.g. when promoting a secondary visibility store) is allowed.
+	oldStores := oldCluster.Spec.Persistence.GetDatastoresMap()
+	for name, store := range newCluster.Spec.Persistence.GetDatastoresMap() {
+		oldStore, ok := oldStores[name]
+		if !ok || oldStore == nil || oldStore.SQL == nil || store == nil || store.SQL == nil {
+			continue
+		}
+
+		if oldStore.SQL.PluginName == store.SQL.PluginName ||
+			oldStore.SQL.ConnectAddr != store.SQL.ConnectAddr ||
+			oldStore.SQL.DatabaseName != store.SQL.DatabaseName {
+			continue
+		}
+
+		if allowedPluginUpgrades[oldStore.SQL.PluginName] != store.SQL.PluginName {
+			errs = append(errs,
+				field.Forbidden(
+					field.NewPath("spec", "persistence", name, "sql", "pluginName"),
+					fmt.Sprintf("can't switch plugin from %s to %s", oldStore.SQL.PluginName, store.SQL.PluginName),
+				),
+			)
+		}
+	}
+
+	// Cluster metadata is persisted by temporal, it can't be changed once set.
+	oldMetadata, newMetadata := oldCluster.GetClusterMetadata(), newCluster.GetClusterMetadata()
+	metadataPath := field.NewPath("spec", "clusterMetadata")
+	adoptionChanged := !equality.Semantic.DeepEqual(newCluster.Spec.Adoption, oldCluster.Spec.Adoption)
+	// The default cluster name depends on the adoption, whose change is already refused below.
+	if newMetadata.ClusterName != oldMetadata.ClusterName && !adoptionChanged {
+		errs = append(errs, field.Forbidden(metadataPath.Child("clusterName"), "clusterName is immutable"))
+	}
+	if newMetadata.InitialFailoverVersion != oldMetadata.InitialFailoverVersion {
+		errs = append(errs, field.Forbidden(metadataPath.Child("initialFailoverVersion"), "initialFailoverVersion is immutable"))
+	}
+	if newMetadata.FailoverVersionIncrement != oldMetadata.FailoverVersionIncrement {
+		errs = append(errs, field.Forbidden(metadataPath.Child("failoverVersionIncrement"), "failoverVersionIncrement is immutable"))
+	}
+	if oldMetadata.EnableGlobalNamespace && !newMetadata.EnableGlobalNamespace {
+		errs = append(errs, field.Forbidden(metadataPath.Child("enableGlobalNamespace"), "global namespaces can't be disabled once enabled"))
 	}
 
-	allowed := constraint.Check(newCluster.Spec.Version.Version)
-	if !allowed {
+	// Adopted resources keep the selectors of the helm chart, which can't be changed afterwards.
+	if adoptionChanged {
 		errs = append(errs,
 			field.Forbidden(
-				field.NewPath("spec", "version"),
-				"Unauthorized version upgrade. Only sequential version upgrades are allowed (from v1.n.x to v1.n+1.x)",
+				field.NewPath("spec", "adoption"),
+				"adoption is immutable, it changes the selectors of the cluster's workloads",
 			),
 		)
 	}
@@ -353,7 +1228,7 @@ func (w *TemporalClusterWebhook) ValidateUpdate(_ context.Context, oldObj, newOb
 		errs = append(errs,
 			field.Forbidden(
 				field.NewPath("spec", "numHistoryShards"),
-				"Number of history shards is immutable",
+				"Number of history shards is immutable, changing it corrupts the cluster's data",
 			),
 		)
 	}
@@ -374,3 +1249,236 @@ func (w *TemporalClusterWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error
 		WithValidator(w).
 		Complete()
 }
+
+// validateImageDigestsUpdate ensures images pinned to a digest follow version changes.
+// The digest takes precedence over the version, an unchanged digest would keep running the previous version.
+func validateImageDigestsUpdate(oldCluster, newCluster *v1beta1.TemporalCluster) field.ErrorList {
+	var errs field.ErrorList
+
+	if oldCluster.Spec.Version.String() != newCluster.Spec.Version.String() {
+		if oldCluster.Spec.Services != nil && newCluster.Spec.Services != nil {
+			oldSpecs := oldCluster.Spec.Services.GetServiceSpecsMap()
+			newSpecs := newCluster.Spec.Services.GetServiceSpecsMap()
+			for _, name := range serviceNames {
+				oldSpec, newSpec := oldSpecs[name], newSpecs[name]
+				if oldSpec != nil && newSpec != nil && newSpec.ImageDigest != "" && newSpec.ImageDigest == oldSpec.ImageDigest {
+					errs = append(errs, field.Forbidden(field.NewPath("spec", "services", name, "imageDigest"), "imageDigest must be updated along with spec.version"))
+				}
+			}
+		}
+
+		if oldCluster.Spec.AdminTools != nil && newCluster.Spec.AdminTools != nil &&
+			newCluster.Spec.AdminTools.ImageDigest != "" && newCluster.Spec.AdminTools.ImageDigest == oldCluster.Spec.AdminTools.ImageDigest {
+			errs = append(errs, field.Forbidden(field.NewPath("spec", "admintools", "imageDigest"), "imageDigest must be updated along with spec.version"))
+		}
+	}
+
+	if oldCluster.Spec.UI != nil && newCluster.Spec.UI != nil && oldCluster.Spec.UI.Version != newCluster.Spec.UI.Version &&
+		newCluster.Spec.UI.ImageDigest != "" && newCluster.Spec.UI.ImageDigest == oldCluster.Spec.UI.ImageDigest {
+		errs = append(errs, field.Forbidden(field.NewPath("spec", "ui", "imageDigest"), "imageDigest must be updated along with spec.ui.version"))
+	}
+
+	return errs
+}
+
+// serviceNames are the names of the services specs fields, in the order they are validated.
+var serviceNames = []string{"frontend", "internalFrontend", "history", "matching", "worker"}
+
+// validateKubernetesService validates the Kubernetes Service configuration of the named temporal service.
+func validateKubernetesService(fldPath *field.Path, name string, spec *v1beta1.KubernetesServiceSpec) field.ErrorList {
+	var errs field.ErrorList
+
+	if spec.SessionAffinityTimeoutSeconds != nil && spec.GetSessionAffinity() != corev1.ServiceAffinityClientIP {
+		errs = append(errs, field.Forbidden(fldPath.Child("sessionAffinityTimeoutSeconds"), "sessionAffinityTimeoutSeconds requires the ClientIP session affinity"))
+	}
+
+	// The internal frontend Service is only reachable from the cluster, only its traffic settings can be configured.
+	if name == "internalFrontend" {
+		if spec.ObjectMetaOverride != nil || spec.GetType() != corev1.ServiceTypeClusterIP || spec.NodePort != nil || spec.HTTPNodePort != nil ||
+			spec.LoadBalancerClass != nil || len(spec.LoadBalancerSourceRanges) > 0 {
+			errs = append(errs, field.Forbidden(fldPath, "only internalTrafficPolicy and sessionAffinity settings can be configured for the internal frontend"))
+		}
+		return errs
+	}
+
+	if name != "frontend" {
+		return append(errs, field.Forbidden(fldPath, "only the Kubernetes Service of the frontend and internal frontend can be configured"))
+	}
+
+	serviceType := spec.GetType()
+	if serviceType == corev1.ServiceTypeClusterIP {
+		if spec.NodePort != nil {
+			errs = append(errs, field.Forbidden(fldPath.Child("nodePort"), "nodePort requires the NodePort or LoadBalancer type"))
+		}
+		if spec.HTTPNodePort != nil {
+			errs = append(errs, field.Forbidden(fldPath.Child("httpNodePort"), "httpNodePort requires the NodePort or LoadBalancer type"))
+		}
+	}
+
+	if serviceType != corev1.ServiceTypeLoadBalancer {
+		if spec.LoadBalancerClass != nil {
+			errs = append(errs, field.Forbidden(fldPath.Child("loadBalancerClass"), "loadBalancerClass requires the LoadBalancer type"))
+		}
+		if len(spec.LoadBalancerSourceRanges) > 0 {
+			errs = append(errs, field.Forbidden(fldPath.Child("loadBalancerSourceRanges"), "loadBalancerSourceRanges requires the LoadBalancer type"))
+		}
+	}
+
+	for i, cidr := range spec.LoadBalancerSourceRanges {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			errs = append(errs, field.Invalid(fldPath.Child("loadBalancerSourceRanges").Index(i), cidr, "must be a valid CIDR"))
+		}
+	}
+
+	return errs
+}
+
+// validateNetwork validates the IP families configuration of the cluster.
+func validateNetwork(fldPath *field.Path, spec *v1beta1.NetworkSpec) field.ErrorList {
+	var errs field.ErrorList
+
+	familiesPath := fldPath.Child("ipFamilies")
+	seen := map[corev1.IPFamily]bool{}
+	for i, family := range spec.IPFamilies {
+		if family != corev1.IPv4Protocol && family != corev1.IPv6Protocol {
+			errs = append(errs, field.NotSupported(familiesPath.Index(i), family, []string{string(corev1.IPv4Protocol), string(corev1.IPv6Protocol)}))
+		}
+		if seen[family] {
+			errs = append(errs, field.Duplicate(familiesPath.Index(i), family))
+		}
+		seen[family] = true
+	}
+
+	if spec.IPFamilyPolicy == nil {
+		return errs
+	}
+
+	switch *spec.IPFamilyPolicy {
+	case corev1.IPFamilyPolicySingleStack:
+		if len(spec.IPFamilies) > 1 {
+			errs = append(errs, field.Invalid(familiesPath, spec.IPFamilies, "only one IP family is allowed with the SingleStack policy"))
+		}
+	case corev1.IPFamilyPolicyRequireDualStack:
+		if len(spec.IPFamilies) == 1 {
+			errs = append(errs, field.Invalid(familiesPath, spec.IPFamilies, "two IP families are required with the RequireDualStack policy"))
+		}
+	}
+
+	return errs
+}
+
+// validatePorts ensures the ports a temporal service listens on don't conflict.
+// In dev mode, all services run in the frontend container and can't share ports.
+func validatePorts(fldPath *field.Path, cluster *v1beta1.TemporalCluster) field.ErrorList {
+	var errs field.ErrorList
+
+	specs := cluster.Spec.Services.GetServiceSpecsMap()
+	devMode := cluster.Spec.DevMode.IsEnabled()
+	used := map[int]string{}
+	for _, name := range serviceNames {
+		spec, ok := specs[name]
+		if !ok {
+			continue
+		}
+		if !devMode {
+			used = map[int]string{}
+		}
+
+		servicePath := fldPath.Child(name)
+		ports := []struct {
+			path *field.Path
+			port *int
+		}{
+			{servicePath.Child("port"), spec.Port},
+			{servicePath.Child("membershipPort"), spec.MembershipPort},
+			{servicePath.Child("httpPort"), spec.HTTPPort},
+		}
+		// In dev mode, metrics are exposed once for all services.
+		if metricsPort := cluster.ServiceMetricsPort(spec); metricsPort != nil && (!devMode || name == "frontend") {
+			metricsPath := field.NewPath("spec", "metrics", "prometheus", "listenPort")
+			if spec.Metrics != nil && spec.Metrics.Port != nil {
+				metricsPath = servicePath.Child("metrics", "port")
+			}
+			ports = append(ports, struct {
+				path *field.Path
+				port *int
+			}{metricsPath, ptr.To(int(*metricsPort))})
+		}
+
+		for _, p := range ports {
+			// A zero port disables the listener, e.g. the HTTP API of the services other than the frontend.
+			if p.port == nil || *p.port == 0 {
+				continue
+			}
+			if other, ok := used[*p.port]; ok {
+				errs = append(errs, field.Invalid(p.path, *p.port, fmt.Sprintf("port conflicts with %s", other)))
+				continue
+			}
+			used[*p.port] = p.path.String()
+		}
+	}
+
+	return errs
+}
+
+// validateTracing validates the traces export configuration.
+func validateTracing(fldPath *field.Path, spec *v1beta1.TracingSpec) field.ErrorList {
+	var errs field.ErrorList
+
+	if _, _, err := net.SplitHostPort(spec.Endpoint); err != nil {
+		errs = append(errs, field.Invalid(fldPath.Child("endpoint"), spec.Endpoint, "must be a host:port address"))
+	}
+
+	seen := map[string]bool{}
+	for i, header := range spec.Headers {
+		headerPath := fldPath.Child("headers").Index(i)
+		name := strings.ToLower(header.Name)
+		if seen[name] {
+			errs = append(errs, field.Duplicate(headerPath.Child("name"), header.Name))
+		}
+		seen[name] = true
+
+		if header.ValueFrom == nil {
+			errs = append(errs, field.Required(headerPath.Child("valueFrom"), "header value must reference a secret key"))
+		}
+	}
+
+	return errs
+}
+
+// validateReplication validates the cross-cluster replication configuration of the provided cluster.
+func validateReplication(fldPath *field.Path, cluster *v1beta1.TemporalCluster) field.ErrorList {
+	var errs field.ErrorList
+	spec := cluster.Spec.Replication
+
+	secrets := map[string]bool{}
+	for i, remote := range spec.RemoteClusters {
+		remotePath := fldPath.Child("remoteClusters").Index(i)
+
+		switch {
+		case remote.ClusterRef != nil && remote.Address != "":
+			errs = append(errs, field.Forbidden(remotePath, "clusterRef and address can't be set at the same time"))
+		case remote.ClusterRef != nil:
+			ref := remote.ClusterRef.NamespacedName(cluster)
+			if ref.Name == cluster.Name && ref.Namespace == cluster.Namespace {
+				errs = append(errs, field.Invalid(remotePath.Child("clusterRef"), remote.ClusterRef.Name, "a cluster can't replicate with itself"))
+			}
+		case remote.Address == "":
+			errs = append(errs, field.Required(remotePath, "either clusterRef or address must be set"))
+		default:
+			if _, _, err := net.SplitHostPort(remote.Address); err != nil {
+				errs = append(errs, field.Invalid(remotePath.Child("address"), remote.Address, "must be a host:port address"))
+			}
+		}
+
+		if remote.TLS != nil {
+			// Secrets are mounted in the services pods using their name.
+			if secrets[remote.TLS.SecretRef.Name] {
+				errs = append(errs, field.Duplicate(remotePath.Child("tls", "secretRef", "name"), remote.TLS.SecretRef.Name))
+			}
+			secrets[remote.TLS.SecretRef.Name] = true
+		}
+	}
+
+	return errs
+}
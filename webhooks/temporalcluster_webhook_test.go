@@ -20,12 +20,16 @@ package webhooks_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"github.com/alexandrevilain/temporal-operator/internal/discovery"
 	"github.com/alexandrevilain/temporal-operator/pkg/version"
 	"github.com/alexandrevilain/temporal-operator/webhooks"
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
@@ -166,6 +170,1172 @@ func TestValidateCreate(t *testing.T) {
 				},
 			},
 		},
+		"error when archival history path is missing": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Archival: &v1beta1.ClusterArchivalSpec{
+						Enabled: true,
+						Provider: &v1beta1.ArchivalProvider{
+							Filestore: &v1beta1.FilestoreArchiver{},
+						},
+						History: &v1beta1.ArchivalSpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.archival.history.path: Required value: Please provide the default history archival path",
+		},
+		"error when archival provider is missing": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Archival: &v1beta1.ClusterArchivalSpec{
+						Enabled: true,
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.archival.provider: Forbidden: Please provide an archival provider or disable cluster archival",
+		},
+		"error when s3 archival has no credentials": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Archival: &v1beta1.ClusterArchivalSpec{
+						Enabled: true,
+						Provider: &v1beta1.ArchivalProvider{
+							S3: &v1beta1.S3Archiver{
+								Region: "eu-west-1",
+							},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.archival.provider.s3: Forbidden: Please provide s3 role name if using EKS",
+		},
+		"works with s3 archival using the history service account": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Services: &v1beta1.ServicesSpec{
+						History: &v1beta1.ServiceSpec{
+							ServiceAccount: &v1beta1.ServiceAccountSpec{
+								Name: "temporal-history",
+							},
+						},
+					},
+					Archival: &v1beta1.ClusterArchivalSpec{
+						Enabled: true,
+						Provider: &v1beta1.ArchivalProvider{
+							S3: &v1beta1.S3Archiver{
+								Region: "eu-west-1",
+							},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+		},
+		"error when secrets mTLS provider has no secret reference": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					MTLS: &v1beta1.MTLSSpec{
+						Provider: v1beta1.SecretsMTLSProvider,
+						Internode: &v1beta1.InternodeMTLSSpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.mTLS.internode.secretRef: Required value: must be set when using secrets as mTLS provider",
+		},
+		"error when vault mTLS provider has no auth method": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					MTLS: &v1beta1.MTLSSpec{
+						Provider: v1beta1.VaultMTLSProvider,
+						Vault: &v1beta1.VaultMTLSSpec{
+							Address: "https://vault:8200",
+							Role:    "temporal",
+						},
+						Internode: &v1beta1.InternodeMTLSSpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.mTLS.vault.auth: Invalid value",
+		},
+		"error when frontend external certificate is used without cert-manager": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					MTLS: &v1beta1.MTLSSpec{
+						Provider: v1beta1.LinkerdMTLSProvider,
+						Frontend: &v1beta1.FrontendMTLSSpec{
+							Enabled: true,
+							External: &v1beta1.FrontendExternalTLSSpec{
+								DNSNames: []string{"temporal.example.com"},
+							},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.mTLS.frontend.external: Forbidden: only supported when using cert-manager as mTLS provider",
+		},
+		"error when default claim mapper has no key source": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Authorization: &v1beta1.AuthorizationSpec{
+						Authorizer:  "default",
+						ClaimMapper: "default",
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.authorization.jwtKeyProvider.keySourceURIs: Required value",
+		},
+		"error when custom authorizer is used with the official image": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Image:   v1beta1.DefaultTemporalImage,
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Authorization: &v1beta1.AuthorizationSpec{
+						Authorizer: "opa",
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.authorization.authorizer: Invalid value: \"opa\"",
+		},
+		"error when codec server has no image": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					UI: &v1beta1.TemporalUISpec{
+						Enabled: true,
+						CodecServer: &v1beta1.CodecServerSpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.ui.codecServer.image: Required value",
+		},
+		"error when codec server mTLS is used without frontend mTLS": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					UI: &v1beta1.TemporalUISpec{
+						Enabled: true,
+						CodecServer: &v1beta1.CodecServerSpec{
+							Enabled: true,
+							Image:   "example.com/codec-server:v1",
+							MTLS:    true,
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.ui.codecServer.mTLS: Forbidden: codec server mTLS requires frontend mTLS using cert-manager",
+		},
+		"error when ui httpRoute is used without gateway api": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					UI: &v1beta1.TemporalUISpec{
+						Enabled:   true,
+						HTTPRoute: &v1beta1.TemporalUIHTTPRouteSpec{},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.ui.httpRoute: Forbidden: Can't create an HTTPRoute for the UI as the Gateway API is not available in the cluster",
+		},
+		"error when ui ingress path doesn't match public path": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					UI: &v1beta1.TemporalUISpec{
+						Enabled:    true,
+						PublicPath: "/temporal",
+						Ingress: &v1beta1.TemporalUIIngressSpec{
+							Hosts: []string{"temporal.example.com"},
+							Path:  "/ui",
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.ui.ingress.path: Invalid value: \"/ui\": ingress path must match spec.ui.publicPath",
+		},
+		"error when ui route is used without openshift": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					UI: &v1beta1.TemporalUISpec{
+						Enabled: true,
+						Route:   &v1beta1.RouteSpec{},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.ui.route: Forbidden: Can't create a Route for the UI as the OpenShift Route API is not available in the cluster",
+		},
+		"error when frontend route uses passthrough without mtls": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Services: &v1beta1.ServicesSpec{
+						Frontend: &v1beta1.ServiceSpec{
+							Route: &v1beta1.RouteSpec{Termination: v1beta1.PassthroughRouteTermination},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{OpenShiftRoute: true},
+			},
+			expectedErr: "spec.services.frontend.route.termination: Invalid value: \"passthrough\": passthrough and reencrypt terminations require frontend mTLS to be enabled",
+		},
+		"error when frontend node port is set with cluster ip type": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Services: &v1beta1.ServicesSpec{
+						Frontend: &v1beta1.ServiceSpec{
+							Service: &v1beta1.KubernetesServiceSpec{
+								NodePort: ptr.To[int32](30233),
+							},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.services.frontend.service.nodePort: Forbidden: nodePort requires the NodePort or LoadBalancer type",
+		},
+		"error when history service is configured": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Services: &v1beta1.ServicesSpec{
+						History: &v1beta1.ServiceSpec{
+							Service: &v1beta1.KubernetesServiceSpec{
+								Type: corev1.ServiceTypeLoadBalancer,
+							},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.services.history.service: Forbidden: only the Kubernetes Service of the frontend and internal frontend can be configured",
+		},
+		"error when frontend tlsRoute is used without frontend mtls": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Services: &v1beta1.ServicesSpec{
+						Frontend: &v1beta1.ServiceSpec{
+							TLSRoute: &v1beta1.GatewayRouteSpec{},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{TLSRoute: true},
+			},
+			expectedErr: "spec.services.frontend.tlsRoute: Forbidden: A TLSRoute requires frontend mTLS to be enabled",
+		},
+		"error when frontend grpcRoute is used without gateway api": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Services: &v1beta1.ServicesSpec{
+						Frontend: &v1beta1.ServiceSpec{
+							GRPCRoute: &v1beta1.GatewayRouteSpec{},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.services.frontend.grpcRoute: Forbidden: Can't create a GRPCRoute for the frontend as the Gateway API GRPCRoute is not available in the cluster",
+		},
+		"error when history service has an external hostname": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Services: &v1beta1.ServicesSpec{
+						History: &v1beta1.ServiceSpec{
+							ExternalHostname: "history.example.com",
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.services.history.externalHostname: Forbidden: only the frontend service can have an external hostname",
+		},
+		"error when ui external hostname is invalid": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					UI: &v1beta1.TemporalUISpec{
+						Enabled:          true,
+						ExternalHostname: "https://temporal.example.com",
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.ui.externalHostname: Invalid value: \"https://temporal.example.com\"",
+		},
+		"error when ip families are duplicated": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Network: &v1beta1.NetworkSpec{
+						IPFamilies: []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv6Protocol},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.network.ipFamilies[1]: Duplicate value: \"IPv6\"",
+		},
+		"error when require dual-stack has a single ip family": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Network: &v1beta1.NetworkSpec{
+						IPFamilyPolicy: ptr.To(corev1.IPFamilyPolicyRequireDualStack),
+						IPFamilies:     []corev1.IPFamily{corev1.IPv6Protocol},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "two IP families are required with the RequireDualStack policy",
+		},
+		"error when internal frontend service sets a type": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Services: &v1beta1.ServicesSpec{
+						InternalFrontend: &v1beta1.InternalFrontendServiceSpec{
+							Enabled: true,
+							ServiceSpec: v1beta1.ServiceSpec{
+								Service: &v1beta1.KubernetesServiceSpec{
+									Type:                  corev1.ServiceTypeNodePort,
+									InternalTrafficPolicy: ptr.To(corev1.ServiceInternalTrafficPolicyLocal),
+								},
+							},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.services.internalFrontend.service: Forbidden: only internalTrafficPolicy and sessionAffinity settings can be configured for the internal frontend",
+		},
+		"error when pod monitor and service monitor are enabled": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Metrics: &v1beta1.MetricsSpec{
+						Enabled: true,
+						Prometheus: &v1beta1.PrometheusSpec{
+							ListenPort: ptr.To[int32](9090),
+							ScrapeConfig: &v1beta1.PrometheusScrapeConfig{
+								ServiceMonitor: &v1beta1.PrometheusScrapeConfigServiceMonitor{Enabled: true},
+								PodMonitor:     &v1beta1.PrometheusScrapeConfigPodMonitor{Enabled: true},
+							},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "podMonitor and serviceMonitor can't be enabled at the same time",
+		},
+		"error when tracing endpoint has no port": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Telemetry: &v1beta1.TelemetrySpec{
+						Tracing: &v1beta1.TracingSpec{
+							Endpoint: "otel-collector.observability",
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.telemetry.tracing.endpoint: Invalid value: \"otel-collector.observability\": must be a host:port address",
+		},
+		"error when statsd and prometheus reporters are set": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Metrics: &v1beta1.MetricsSpec{
+						Enabled: true,
+						Prometheus: &v1beta1.PrometheusSpec{
+							ListenPort: ptr.To[int32](9090),
+						},
+						Statsd: &v1beta1.StatsdSpec{
+							Address: "datadog-agent.datadog:8125",
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.metrics.statsd: Forbidden: statsd and prometheus reporters can't be used at the same time",
+		},
+		"error when metrics tags override the type tag": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Metrics: &v1beta1.MetricsSpec{
+						Enabled: true,
+						Tags: map[string]string{
+							"type": "custom",
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.metrics.tags[type]: Forbidden: the type tag is set by the operator to the service name",
+		},
+		"error when replication remote cluster has no address": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Replication: &v1beta1.ReplicationSpec{
+						RemoteClusters: []v1beta1.RemoteClusterSpec{
+							{},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.replication.remoteClusters[0]: Required value: either clusterRef or address must be set",
+		},
+		"error when dynamic config sets a key managed by frontend limits": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Services: &v1beta1.ServicesSpec{
+						Frontend: &v1beta1.ServiceSpec{
+							Limits: &v1beta1.FrontendLimitsSpec{
+								RPS: ptr.To[int32](1000),
+							},
+						},
+					},
+					DynamicConfig: &v1beta1.DynamicConfigSpec{
+						Values: map[string][]v1beta1.ConstrainedValue{
+							"frontend.rps": {
+								{
+									Value: &apiextensionsv1.JSON{Raw: []byte(`2000`)},
+								},
+							},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.dynamicConfig.values.frontend.rps: Forbidden: this key is managed using spec.services.frontend.limits",
+		},
+		"error when default namespaces are duplicated": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					DefaultNamespaces: []v1beta1.DefaultNamespaceSpec{
+						{Name: "default", RetentionPeriod: &metav1.Duration{Duration: 72 * time.Hour}},
+						{Name: "default", RetentionPeriod: &metav1.Duration{Duration: 24 * time.Hour}},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.defaultNamespaces[1].name: Duplicate value: \"default\"",
+		},
+		"error when GCP service accounts conflict": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore: &v1beta1.DatastoreSpec{
+							SQL: &v1beta1.SQLSpec{
+								PluginName:        "postgres12",
+								GCPServiceAccount: ptr.To("temporal@project.iam.gserviceaccount.com"),
+							},
+						},
+					},
+					Archival: &v1beta1.ClusterArchivalSpec{
+						Enabled: true,
+						Provider: &v1beta1.ArchivalProvider{
+							GCS: &v1beta1.GCSArchiver{
+								ServiceAccount: ptr.To("archival@project.iam.gserviceaccount.com"),
+							},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.persistence.defaultStore.sql.gcpServiceAccount: Invalid value: \"temporal@project.iam.gserviceaccount.com\": conflicts with spec.archival.provider.gcs.serviceAccount",
+		},
+		"works with the same GCP service account": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore: &v1beta1.DatastoreSpec{
+							SQL: &v1beta1.SQLSpec{
+								PluginName:        "postgres12",
+								GCPServiceAccount: ptr.To("temporal@project.iam.gserviceaccount.com"),
+							},
+						},
+						VisibilityStore: &v1beta1.DatastoreSpec{
+							SQL: &v1beta1.SQLSpec{
+								PluginName:        "postgres12",
+								GCPServiceAccount: ptr.To("temporal@project.iam.gserviceaccount.com"),
+							},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+		},
+		"errors are reported in services order": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Services: &v1beta1.ServicesSpec{
+						History: &v1beta1.ServiceSpec{DNSPolicy: corev1.DNSNone},
+						Worker:  &v1beta1.ServiceSpec{DNSPolicy: corev1.DNSNone},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.services.history.dnsConfig: Required value: dnsConfig is required when dnsPolicy is set to None, spec.services.worker.dnsConfig: Required value",
+		},
+		"error when dynamic config sets a key managed by persistence limits": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						Limits: &v1beta1.PersistenceLimitsSpec{
+							DefaultStore: &v1beta1.DefaultStoreLimitsSpec{
+								HistoryMaxQPS: ptr.To[int32](9000),
+							},
+						},
+					},
+					DynamicConfig: &v1beta1.DynamicConfigSpec{
+						Values: map[string][]v1beta1.ConstrainedValue{
+							"history.persistenceMaxQPS": {
+								{
+									Value: &apiextensionsv1.JSON{Raw: []byte(`3000`)},
+								},
+							},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.dynamicConfig.values.history.persistenceMaxQPS: Forbidden: this key is managed using spec.persistence.limits",
+		},
+		"error when a datastore is empty": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						VisibilityStore: &v1beta1.DatastoreSpec{
+							Name: "visibility",
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.persistence.visibilityStore: Required value: one of sql, cassandra or elasticsearch must be set",
+		},
+		"error when elasticsearch is used as default store": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore: &v1beta1.DatastoreSpec{
+							Elasticsearch: &v1beta1.ElasticsearchSpec{Version: "v7"},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.persistence.defaultStore.elasticsearch: Forbidden: Elasticsearch can only be used as a visibility store",
+		},
+		"error when elasticsearch is used as visibility store for cluster < 1.21.0": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.20.0"),
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						VisibilityStore: &v1beta1.DatastoreSpec{
+							Elasticsearch: &v1beta1.ElasticsearchSpec{Version: "v7"},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.persistence.visibilityStore.elasticsearch: Forbidden: temporal cluster version < 1.21.0 only supports Elasticsearch as an advanced visibility store",
+		},
+		"works with services using the same ports": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Services: &v1beta1.ServicesSpec{
+						Frontend: &v1beta1.ServiceSpec{Port: ptr.To(7233), MembershipPort: ptr.To(6933)},
+						History:  &v1beta1.ServiceSpec{Port: ptr.To(7233), MembershipPort: ptr.To(6933)},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+		},
+		"error when the metrics port conflicts with a service port": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Metrics: &v1beta1.MetricsSpec{
+						Enabled: true,
+						Prometheus: &v1beta1.PrometheusSpec{
+							ListenPort: ptr.To[int32](7234),
+						},
+					},
+					Services: &v1beta1.ServicesSpec{
+						History: &v1beta1.ServiceSpec{Port: ptr.To(7234), MembershipPort: ptr.To(6934)},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.metrics.prometheus.listenPort: Invalid value: 7234: port conflicts with spec.services.history.port",
+		},
+		"error when services use the same ports in dev mode": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					DevMode: &v1beta1.DevModeSpec{Enabled: true},
+					Services: &v1beta1.ServicesSpec{
+						Frontend: &v1beta1.ServiceSpec{Port: ptr.To(7233), MembershipPort: ptr.To(6933)},
+						History:  &v1beta1.ServiceSpec{Port: ptr.To(7233), MembershipPort: ptr.To(6934)},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.services.history.port: Invalid value: 7233: port conflicts with spec.services.frontend.port",
+		},
+		"error when public client is set with the internal frontend": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Services: &v1beta1.ServicesSpec{
+						InternalFrontend: &v1beta1.InternalFrontendServiceSpec{
+							Enabled: true,
+						},
+					},
+					PublicClient: &v1beta1.PublicClientSpec{
+						HostPort: "temporal.example.com:7233",
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.publicClient: Forbidden: publicClient can't be set when the internal frontend is enabled",
+		},
+		"error when canary error rate is checked without metrics": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					UpgradeStrategy: &v1beta1.UpgradeStrategySpec{
+						Canary: &v1beta1.CanaryUpgradeSpec{
+							MaxErrorRatePercent: ptr.To[int32](5),
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.upgradeStrategy.canary.maxErrorRatePercent: Forbidden: maxErrorRatePercent requires prometheus metrics to be enabled on the frontend",
+		},
+		"error when canary upgrades are used with image digests": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					UpgradeStrategy: &v1beta1.UpgradeStrategySpec{
+						Canary: &v1beta1.CanaryUpgradeSpec{},
+					},
+					AdminTools: &v1beta1.TemporalAdminToolsSpec{ImageDigest: "sha256:aaaa"},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.upgradeStrategy.canary: Forbidden: canary upgrades are not supported when images are pinned to a digest",
+		},
+		"error when pre-upgrade hook names are not unique": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Lifecycle: &v1beta1.LifecycleSpec{
+						PreUpgrade: []v1beta1.LifecycleHookSpec{
+							{
+								Name: "backup",
+								Template: v1beta1.PodTemplateSpecOverride{
+									Spec: &apiextensionsv1.JSON{Raw: []byte(`{"containers":[{"name":"backup","image":"backup"}]}`)},
+								},
+							},
+							{
+								Name: "backup",
+								Template: v1beta1.PodTemplateSpecOverride{
+									Spec: &apiextensionsv1.JSON{Raw: []byte(`{"containers":[]}`)},
+								},
+							},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "[spec.lifecycle.preUpgrade[1].name: Duplicate value: \"backup\", spec.lifecycle.preUpgrade[1].template.spec: Invalid value: pod spec must define at least one container]",
+		},
+		"error when maintenance window schedule is invalid": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					MaintenanceWindow: &v1beta1.MaintenanceWindowSpec{
+						Schedule: "every saturday",
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.maintenanceWindow: Invalid value: \"every saturday\": invalid schedule: expected exactly 5 fields, found 2: [every saturday]",
+		},
+		"error when pinned minor version policy is used with image digests": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version:       version.MustNewVersionFromString("1.22.0"),
+					VersionPolicy: v1beta1.PinnedMinorVersionPolicy,
+					Services: &v1beta1.ServicesSpec{
+						History: &v1beta1.ServiceSpec{ImageDigest: "sha256:aaaa"},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.versionPolicy: Forbidden: the PinnedMinor version policy is not supported when images are pinned to a digest",
+		},
+		"error when auto rollback is used with image digests": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					UpgradeStrategy: &v1beta1.UpgradeStrategySpec{
+						AutoRollback: &v1beta1.AutoRollbackSpec{},
+					},
+					Services: &v1beta1.ServicesSpec{
+						History: &v1beta1.ServiceSpec{ImageDigest: "sha256:aaaa"},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.upgradeStrategy.autoRollback: Forbidden: automatic rollbacks are not supported when images are pinned to a digest",
+		},
+		"error when adopting a helm release with another name": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Adoption: &v1beta1.AdoptionSpec{
+						HelmRelease: "prod",
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.adoption.helmRelease: Forbidden: the cluster must be named \"prod-temporal\" to adopt the resources of the \"prod\" helm release",
+		},
+		"error when ui auth has no client id": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					UI: &v1beta1.TemporalUISpec{
+						Enabled: true,
+						Ingress: &v1beta1.TemporalUIIngressSpec{
+							Hosts: []string{"temporal.example.com"},
+						},
+						Auth: &v1beta1.TemporalUIAuthSpec{
+							Enabled:     true,
+							ProviderURL: "https://sso.example.com",
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.ui.auth.clientID: Required value",
+		},
+		"error when ui auth callback url can't be guessed": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					UI: &v1beta1.TemporalUISpec{
+						Enabled: true,
+						Auth: &v1beta1.TemporalUIAuthSpec{
+							Enabled:     true,
+							ProviderURL: "https://sso.example.com",
+							ClientID:    "temporal-ui",
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.ui.auth.callbackURL: Required value",
+		},
+		"error when ui config key is not an environment variable name": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					UI: &v1beta1.TemporalUISpec{
+						Enabled: true,
+						Config: map[string]string{
+							"TEMPORAL_DEFAULT_NAMESPACE": "default",
+							"1banner":                    "hello",
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.ui.config[1banner]: Invalid value",
+		},
+		"error when ui autoscaling max replicas is lower than min replicas": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					UI: &v1beta1.TemporalUISpec{
+						Enabled: true,
+						Autoscaling: &v1beta1.AutoscalingSpec{
+							Enabled:     true,
+							MinReplicas: ptr.To[int32](3),
+							MaxReplicas: 2,
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.ui.autoscaling.maxReplicas: Invalid value: 2: maxReplicas must be greater than or equal to minReplicas",
+		},
 		"error with version not supported": {
 			object: &v1beta1.TemporalCluster{
 				TypeMeta: v1beta1.TemporalClusterTypeMeta,
@@ -250,6 +1420,123 @@ func TestValidateCreate(t *testing.T) {
 			},
 			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.persistence.advancedVisibilityStore.elasticsearch.version: Forbidden: temporal cluster version >= 1.18.0 doesn't support ElasticSearch v6",
 		},
+		"error with dns policy None and no dns config": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					Services: &v1beta1.ServicesSpec{
+						History: &v1beta1.ServiceSpec{
+							DNSPolicy: corev1.DNSNone,
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.services.history.dnsConfig: Required value: dnsConfig is required when dnsPolicy is set to None",
+		},
+		"error with datastore client certificate without key": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore: &v1beta1.DatastoreSpec{
+							Cassandra: &v1beta1.CassandraSpec{
+								Hosts: []string{"cassandra.example.com"},
+							},
+							TLS: &v1beta1.DatastoreTLSSpec{
+								Enabled: true,
+								CertFileRef: &v1beta1.SecretKeyReference{
+									Name: "cassandra-client-tls",
+								},
+							},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.persistence.defaultStore.tls.keyFileRef: Required value: keyFileRef is required when certFileRef is set",
+		},
+		"error with sql max idle connections greater than max connections": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore: &v1beta1.DatastoreSpec{
+							SQL: &v1beta1.SQLSpec{
+								PluginName:   "postgres",
+								MaxConns:     10,
+								MaxIdleConns: 20,
+							},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.persistence.defaultStore.sql.maxIdleConns: Invalid value: 20: maxIdleConns can't be greater than maxConns",
+		},
+		"error with sqlite without dev mode": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore: &v1beta1.DatastoreSpec{
+							SQL: &v1beta1.SQLSpec{
+								PluginName: "sqlite",
+							},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.persistence.defaultStore.sql.pluginName: Forbidden: sqlite plugin is only supported in dev mode",
+		},
+		"error with strategy on a statefulset service": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.4"),
+					Services: &v1beta1.ServicesSpec{
+						History: &v1beta1.ServiceSpec{
+							WorkloadType: v1beta1.StatefulSetWorkloadType,
+							Strategy: &appsv1.DeploymentStrategy{
+								Type: appsv1.RecreateDeploymentStrategyType,
+							},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "spec.services.history.strategy: Forbidden: strategy can't be set on services running as a StatefulSet",
+		},
 	}
 
 	for name, test := range tests {
@@ -297,6 +1584,59 @@ func TestValidateUpdate(t *testing.T) {
 				},
 			},
 		},
+		"digest unchanged along with version": {
+			oldlObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.4"),
+					Services: &v1beta1.ServicesSpec{
+						History: &v1beta1.ServiceSpec{ImageDigest: "sha256:1111"},
+					},
+				},
+			},
+			newObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.23.0"),
+					Services: &v1beta1.ServicesSpec{
+						History: &v1beta1.ServiceSpec{ImageDigest: "sha256:1111"},
+					},
+				},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.services.history.imageDigest: Forbidden: imageDigest must be updated along with spec.version",
+		},
+		"digest updated along with version": {
+			oldlObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.4"),
+					Services: &v1beta1.ServicesSpec{
+						History: &v1beta1.ServiceSpec{ImageDigest: "sha256:1111"},
+					},
+				},
+			},
+			newObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.23.0"),
+					Services: &v1beta1.ServicesSpec{
+						History: &v1beta1.ServiceSpec{ImageDigest: "sha256:2222"},
+					},
+				},
+			},
+		},
 		"version rollback": {
 			oldlObject: &v1beta1.TemporalCluster{
 				TypeMeta: v1beta1.TemporalClusterTypeMeta,
@@ -315,9 +1655,9 @@ func TestValidateUpdate(t *testing.T) {
 				Spec:   v1beta1.TemporalClusterSpec{Version: version.MustNewVersionFromString("1.18.4")},
 				Status: v1beta1.TemporalClusterStatus{},
 			},
-			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.version: Forbidden: Unauthorized version upgrade. Only sequential version upgrades are allowed (from v1.n.x to v1.n+1.x)",
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.version: Forbidden: Unauthorized version upgrade: downgrading from 1.19.0 to 1.18.4 is not supported",
 		},
-		"not a sequential version update": {
+		"multi-minor version upgrade": {
 			oldlObject: &v1beta1.TemporalCluster{
 				TypeMeta: v1beta1.TemporalClusterTypeMeta,
 				ObjectMeta: metav1.ObjectMeta{
@@ -335,7 +1675,56 @@ func TestValidateUpdate(t *testing.T) {
 				Spec:   v1beta1.TemporalClusterSpec{Version: version.MustNewVersionFromString("1.19.4")},
 				Status: v1beta1.TemporalClusterStatus{},
 			},
-			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.version: Forbidden: Unauthorized version upgrade. Only sequential version upgrades are allowed (from v1.n.x to v1.n+1.x)",
+		},
+		"multi-minor version upgrade with pinned image digest": {
+			oldlObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.17.0"),
+				},
+			},
+			newObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.19.4"),
+					Services: &v1beta1.ServicesSpec{
+						History: &v1beta1.ServiceSpec{ImageDigest: "sha256:aaaa"},
+					},
+				},
+				Status: v1beta1.TemporalClusterStatus{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.version: Forbidden: Upgrades skipping minor versions are not supported when images are pinned to a digest, upgrade one minor version at a time",
+		},
+		"immutable adoption": {
+			oldlObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.4"),
+				},
+			},
+			newObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.4"),
+					Adoption: &v1beta1.AdoptionSpec{
+						HelmRelease: "fake",
+						ChartName:   "fake",
+					},
+				},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.adoption: Forbidden: adoption is immutable, it changes the selectors of the cluster's workloads",
 		},
 		"immutable numHistoryShards": {
 			oldlObject: &v1beta1.TemporalCluster{
@@ -358,7 +1747,154 @@ func TestValidateUpdate(t *testing.T) {
 					NumHistoryShards: int32(512),
 				},
 			},
-			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.numHistoryShards: Forbidden: Number of history shards is immutable",
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.numHistoryShards: Forbidden: Number of history shards is immutable, changing it corrupts the cluster's data",
+		},
+		"immutable cluster name": {
+			oldlObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version:          version.MustNewVersionFromString("1.19.4"),
+					NumHistoryShards: int32(256),
+				},
+			},
+			newObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version:          version.MustNewVersionFromString("1.19.4"),
+					NumHistoryShards: int32(256),
+					ClusterMetadata: &v1beta1.ClusterMetadataSpec{
+						ClusterName: "prod-east",
+					},
+				},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.clusterMetadata.clusterName: Forbidden: clusterName is immutable",
+		},
+		"sql plugin upgraded to newer driver": {
+			oldlObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version:          version.MustNewVersionFromString("1.22.0"),
+					NumHistoryShards: int32(1),
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore: &v1beta1.DatastoreSpec{
+							SQL: &v1beta1.SQLSpec{
+								PluginName:   "postgres",
+								DatabaseName: "temporal",
+								ConnectAddr:  "db:5432",
+							},
+						},
+					},
+				},
+			},
+			newObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version:          version.MustNewVersionFromString("1.22.0"),
+					NumHistoryShards: int32(1),
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore: &v1beta1.DatastoreSpec{
+							SQL: &v1beta1.SQLSpec{
+								PluginName:   "postgres12",
+								DatabaseName: "temporal",
+								ConnectAddr:  "db:5432",
+							},
+						},
+					},
+				},
+			},
+		},
+		"sql plugin switched to another engine": {
+			oldlObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version:          version.MustNewVersionFromString("1.22.0"),
+					NumHistoryShards: int32(1),
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore: &v1beta1.DatastoreSpec{
+							SQL: &v1beta1.SQLSpec{
+								PluginName:   "postgres12",
+								DatabaseName: "temporal",
+								ConnectAddr:  "db:5432",
+							},
+						},
+					},
+				},
+			},
+			newObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version:          version.MustNewVersionFromString("1.22.0"),
+					NumHistoryShards: int32(1),
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore: &v1beta1.DatastoreSpec{
+							SQL: &v1beta1.SQLSpec{
+								PluginName:   "mysql8",
+								DatabaseName: "temporal",
+								ConnectAddr:  "db:5432",
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.persistence.defaultStore.sql.pluginName: Forbidden: can't switch plugin from postgres12 to mysql8",
+		},
+		"sql plugin switched with another database": {
+			oldlObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version:          version.MustNewVersionFromString("1.22.0"),
+					NumHistoryShards: int32(1),
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore: &v1beta1.DatastoreSpec{
+							SQL: &v1beta1.SQLSpec{
+								PluginName:   "postgres12",
+								DatabaseName: "temporal",
+								ConnectAddr:  "db:5432",
+							},
+						},
+					},
+				},
+			},
+			newObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version:          version.MustNewVersionFromString("1.22.0"),
+					NumHistoryShards: int32(1),
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore: &v1beta1.DatastoreSpec{
+							SQL: &v1beta1.SQLSpec{
+								PluginName:   "mysql8",
+								DatabaseName: "temporal_new",
+								ConnectAddr:  "db:5432",
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 